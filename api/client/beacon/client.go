@@ -32,9 +32,17 @@ const (
 	getWeakSubjectivityPath  = "/prysm/v1/beacon/weak_subjectivity"
 	getForkSchedulePath      = "/eth/v1/config/fork_schedule"
 	getConfigSpecPath        = "/eth/v1/config/spec"
+	getGenesisPath           = "/eth/v1/beacon/genesis"
 	getStatePath             = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath       = "/eth/v1/node/version"
 	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getValidatorsPath        = "/eth/v1/beacon/states/{{.Id}}/validators"
+	getLivenessPath          = "/eth/v1/validator/liveness"
+
+	getLightClientBootstrapPath        = "/eth/v1/beacon/light_client/bootstrap"
+	getLightClientUpdatesPath          = "/eth/v1/beacon/light_client/updates"
+	getLightClientFinalityUpdatePath   = "/eth/v1/beacon/light_client/finality_update"
+	getLightClientOptimisticUpdatePath = "/eth/v1/beacon/light_client/optimistic_update"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -80,6 +88,8 @@ func idTemplate(ts string) func(StateOrBlockId) string {
 	return f
 }
 
+var renderGetValidatorsPath = idTemplate(getValidatorsPath)
+
 func renderGetBlockPath(id StateOrBlockId) string {
 	return path.Join(getSignedBlockPath, string(id))
 }
@@ -189,6 +199,22 @@ func (c *Client) GetConfigSpec(ctx context.Context) (*structs.GetSpecResponse, e
 	return fsr, nil
 }
 
+// GetGenesis retrieves the genesis time, validators root and fork version of the chain of the beacon node.
+func (c *Client) GetGenesis(ctx context.Context) (*structs.Genesis, error) {
+	body, err := c.Get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis")
+	}
+	gr := &structs.GetGenesisResponse{}
+	if err := json.Unmarshal(body, gr); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetGenesis")
+	}
+	if gr.Data == nil {
+		return nil, errors.New("genesis response has no data")
+	}
+	return gr.Data, nil
+}
+
 type NodeVersion struct {
 	implementation string
 	semver         string
@@ -334,6 +360,115 @@ func (c *Client) GetBLStoExecutionChanges(ctx context.Context) (*structs.BLSToEx
 	return poolResponse, nil
 }
 
+// GetValidators retrieves the validators of the given state which match the given ids, which can be validator
+// indices or hex encoded public keys. Ids of unknown validators are omitted from the response.
+func (c *Client) GetValidators(ctx context.Context, stateId StateOrBlockId, ids []string) (*structs.GetValidatorsResponse, error) {
+	resp := &structs.GetValidatorsResponse{}
+	if err := c.post(ctx, renderGetValidatorsPath(stateId), &structs.GetValidatorsRequest{Ids: ids}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLiveness retrieves whether the validators of the given indices were seen participating in the given epoch.
+func (c *Client) GetLiveness(ctx context.Context, epoch primitives.Epoch, indices []string) (*structs.GetLivenessResponse, error) {
+	resp := &structs.GetLivenessResponse{}
+	if err := c.post(ctx, path.Join(getLivenessPath, strconv.FormatUint(uint64(epoch), 10)), indices, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// post sends the request as JSON to a beacon API endpoint, and decodes the JSON response into resp.
+func (c *Client) post(ctx context.Context, p string, request, resp interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
+	}
+	u := c.BaseURL().ResolveReference(&url.URL{Path: p})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return errors.Wrap(err, "invalid format, failed to create new POST request object")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	r, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = r.Body.Close()
+	}()
+	if r.StatusCode != http.StatusOK {
+		return client.Non200Err(r)
+	}
+	return json.NewDecoder(r.Body).Decode(resp)
+}
+
+// GetLightClientBootstrap retrieves the light client bootstrap for the given block root.
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*structs.LightClientBootstrapResponse, error) {
+	body, err := c.Get(ctx, path.Join(getLightClientBootstrapPath, fmt.Sprintf("%#x", blockRoot)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client bootstrap for block root %#x", blockRoot)
+	}
+	resp := &structs.LightClientBootstrapResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientBootstrap")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("light client bootstrap response has no data")
+	}
+	return resp, nil
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client updates of count sync committee periods,
+// starting with startPeriod. Fewer updates are returned if the beacon node does not have all of them.
+func (c *Client) GetLightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]*structs.LightClientUpdateResponse, error) {
+	query := url.Values{}
+	query.Set("start_period", strconv.FormatUint(startPeriod, 10))
+	query.Set("count", strconv.FormatUint(count, 10))
+	body, err := c.Get(ctx, getLightClientUpdatesPath, client.WithQueryParams(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client updates from period %d", startPeriod)
+	}
+	var resp []*structs.LightClientUpdateResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientUpdatesByRange")
+	}
+	return resp, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update known to the beacon node.
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (*structs.LightClientFinalityUpdateResponse, error) {
+	body, err := c.Get(ctx, getLightClientFinalityUpdatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting light client finality update")
+	}
+	resp := &structs.LightClientFinalityUpdateResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientFinalityUpdate")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("light client finality update response has no data")
+	}
+	return resp, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update known to the beacon node.
+func (c *Client) GetLightClientOptimisticUpdate(ctx context.Context) (*structs.LightClientOptimisticUpdateResponse, error) {
+	body, err := c.Get(ctx, getLightClientOptimisticUpdatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting light client optimistic update")
+	}
+	resp := &structs.LightClientOptimisticUpdateResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientOptimisticUpdate")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("light client optimistic update response has no data")
+	}
+	return resp, nil
+}
+
 type forkScheduleResponse struct {
 	Data []structs.Fork
 }
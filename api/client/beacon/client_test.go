@@ -1,6 +1,11 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -137,3 +142,52 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLightClientUpdatesByRange(t *testing.T) {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req}
+		if req.URL.Path != getLightClientUpdatesPath {
+			res.StatusCode = http.StatusNotFound
+			return res, nil
+		}
+		require.Equal(t, "3", req.URL.Query().Get("start_period"))
+		require.Equal(t, "2", req.URL.Query().Get("count"))
+		res.StatusCode = http.StatusOK
+		res.Body = io.NopCloser(bytes.NewBufferString(`[{"version":"altair","data":{"signature_slot":"1"}},{"version":"capella","data":{"signature_slot":"2"}}]`))
+		return res, nil
+	}}
+
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	updates, err := c.GetLightClientUpdatesByRange(context.Background(), 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(updates))
+	require.Equal(t, "altair", updates[0].Version)
+	require.Equal(t, "2", updates[1].Data.SignatureSlot)
+}
+
+func TestGetLiveness(t *testing.T) {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req}
+		if req.Method != http.MethodPost || req.URL.Path != "/eth/v1/validator/liveness/7" {
+			res.StatusCode = http.StatusNotFound
+			return res, nil
+		}
+		var indices []string
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&indices))
+		require.DeepEqual(t, []string{"1", "4"}, indices)
+		res.StatusCode = http.StatusOK
+		res.Body = io.NopCloser(bytes.NewBufferString(`{"data":[{"index":"1","is_live":false},{"index":"4","is_live":true}]}`))
+		return res, nil
+	}}
+
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+	liveness, err := c.GetLiveness(context.Background(), 7, []string{"1", "4"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(liveness.Data))
+	require.Equal(t, true, liveness.Data[1].IsLive)
+
+	_, err = c.GetLiveness(context.Background(), 8, []string{"1"})
+	require.ErrorContains(t, "404", err)
+}
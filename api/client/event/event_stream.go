@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api"
@@ -30,6 +32,7 @@ const (
 	EventBlobSidecar                 = "blob_sidecar"
 	EventError                       = "error"
 	EventConnectionError             = "connection_error"
+	EventGap                         = "event_gap"
 )
 
 var (
@@ -49,13 +52,26 @@ type Event struct {
 
 // EventStream is responsible for subscribing to the Beacon API events endpoint
 // and dispatching received events to subscribers.
+// The id of the last received event is tracked, so that when an established stream is interrupted
+// the subscription is resumed with a Last-Event-ID header and the beacon node can replay missed events.
 type EventStream struct {
-	ctx        context.Context
-	httpClient *http.Client
-	host       string
-	topics     []string
+	ctx            context.Context
+	httpClient     *http.Client
+	host           string
+	topics         []string
+	lastEventIDMu  sync.RWMutex
+	lastEventID    string
+	reconnectDelay time.Duration
 }
 
+const (
+	// DefaultReconnectDelay is the time waited before resuming an event stream that was interrupted.
+	DefaultReconnectDelay = time.Second
+	// maxReconnectDelay bounds the delay between attempts to connect to a beacon node which can't be reached,
+	// which doubles after every failed attempt.
+	maxReconnectDelay = time.Minute
+)
+
 func NewEventStream(ctx context.Context, httpClient *http.Client, host string, topics []string) (*EventStream, error) {
 	// Check if the host is a valid URL
 	_, err := url.ParseRequestURI(host)
@@ -67,32 +83,87 @@ func NewEventStream(ctx context.Context, httpClient *http.Client, host string, t
 	}
 
 	return &EventStream{
-		ctx:        ctx,
-		httpClient: httpClient,
-		host:       host,
-		topics:     topics,
+		ctx:            ctx,
+		httpClient:     httpClient,
+		host:           host,
+		topics:         topics,
+		reconnectDelay: DefaultReconnectDelay,
 	}, nil
 }
 
+// LastEventID returns the id of the last event received on the stream, or an empty string if no event had an id.
+func (h *EventStream) LastEventID() string {
+	h.lastEventIDMu.RLock()
+	defer h.lastEventIDMu.RUnlock()
+	return h.lastEventID
+}
+
+func (h *EventStream) setLastEventID(id string) {
+	h.lastEventIDMu.Lock()
+	defer h.lastEventIDMu.Unlock()
+	h.lastEventID = id
+}
+
+// Subscribe streams events into the channel. When a stream ends or can't be established, it is resumed from
+// the last received event id after a delay, which backs off while the beacon node can't be reached.
+// The beacon node sends an EventGap event when some of the events after the last received event id can't be
+// replayed, and one is also sent when a stream is resumed without any event id having been received.
+// Subscribe returns when the context is canceled.
 func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 	allTopics := strings.Join(h.topics, ",")
 	log.WithField("topics", allTopics).Info("Listening to Beacon API events")
 	fullUrl := h.host + "/eth/v1/events?topics=" + allTopics
+	delay := h.reconnectDelay
+	var resumed bool
+	for {
+		connected, done := h.stream(fullUrl, eventsChannel, resumed)
+		if done {
+			return
+		}
+		if connected {
+			resumed = true
+			delay = h.reconnectDelay
+		}
+		select {
+		case <-h.ctx.Done():
+			log.Info("Context canceled, stopping event stream")
+			close(eventsChannel)
+			return
+		case <-time.After(delay):
+		}
+		if !connected {
+			delay = min(2*delay, maxReconnectDelay)
+		}
+		log.WithField("lastEventID", h.LastEventID()).Info("Resuming Beacon API event stream")
+	}
+}
+
+// stream opens a single connection to the events endpoint and reads events until the stream ends.
+// It returns whether the stream was established, and whether the subscription should not be resumed.
+func (h *EventStream) stream(fullUrl string, eventsChannel chan<- *Event, resumed bool) (bool, bool) {
 	req, err := http.NewRequestWithContext(h.ctx, http.MethodGet, fullUrl, nil)
 	if err != nil {
 		eventsChannel <- &Event{
 			EventType: EventConnectionError,
 			Data:      []byte(errors.Wrap(err, "failed to create HTTP request").Error()),
 		}
+		return false, true
 	}
 	req.Header.Set("Accept", api.EventStreamMediaType)
 	req.Header.Set("Connection", api.KeepAlive)
+	lastEventID := h.LastEventID()
+	if lastEventID != "" {
+		req.Header.Set(api.LastEventIDHeader, lastEventID)
+	}
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
-		eventsChannel <- &Event{
-			EventType: EventConnectionError,
-			Data:      []byte(errors.Wrap(err, client.ErrConnectionIssue.Error()).Error()),
+		if h.ctx.Err() == nil {
+			eventsChannel <- &Event{
+				EventType: EventConnectionError,
+				Data:      []byte(errors.Wrap(err, client.ErrConnectionIssue.Error()).Error()),
+			}
 		}
+		return false, false
 	}
 
 	defer func() {
@@ -100,12 +171,24 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			log.WithError(closeErr).Error("Failed to close events response body")
 		}
 	}()
+	if resp.StatusCode != http.StatusOK {
+		eventsChannel <- &Event{
+			EventType: EventConnectionError,
+			Data:      []byte(errors.Wrapf(client.ErrConnectionIssue, "unexpected status code %d", resp.StatusCode).Error()),
+		}
+		// A rejected request, for example with an unknown topic, is not retried.
+		return false, resp.StatusCode >= 400 && resp.StatusCode < 500
+	}
+	// Without an event id the beacon node can't replay the events sent while the stream was interrupted.
+	if resumed && lastEventID == "" {
+		eventsChannel <- &Event{EventType: EventGap, Data: []byte(`{"last_event_id":""}`)}
+	}
 	// Create a new scanner to read lines from the response body
 	scanner := bufio.NewScanner(resp.Body)
 	// Set the split function for the scanning operation
 	scanner.Split(scanLinesWithCarriage)
 
-	var eventType, data string // Variables to store event type and data
+	var eventType, data, id string // Variables to store event type, data and id
 
 	// Iterate over lines of the event stream
 	for scanner.Scan() {
@@ -113,7 +196,7 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 		case <-h.ctx.Done():
 			log.Info("Context canceled, stopping event stream")
 			close(eventsChannel)
-			return
+			return true, true
 		default:
 			line := scanner.Text()
 			// Handle the event based on your specific format
@@ -123,9 +206,13 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 					// Process the event when both eventType and data are set
 					eventsChannel <- &Event{EventType: eventType, Data: []byte(data)}
 				}
+				// An event without an id leaves the last event id unchanged.
+				if id != "" {
+					h.setLastEventID(id)
+				}
 
-				// Reset eventType and data for the next event
-				eventType, data = "", ""
+				// Reset eventType, data and id for the next event
+				eventType, data, id = "", "", ""
 				continue
 			}
 			et, ok := strings.CutPrefix(line, "event: ")
@@ -138,6 +225,11 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 				// Extract data from the "data" field
 				data = d
 			}
+			i, ok := strings.CutPrefix(line, "id: ")
+			if ok {
+				// Extract the event id from the "id" field
+				id = i
+			}
 		}
 	}
 
@@ -147,4 +239,5 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 			Data:      []byte(errors.Wrap(err, errors.Wrap(client.ErrConnectionIssue, "scanner failed").Error()).Error()),
 		}
 	}
+	return true, false
 }
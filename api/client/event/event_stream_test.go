@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	log "github.com/sirupsen/logrus"
 )
@@ -79,3 +81,98 @@ func TestEventStream(t *testing.T) {
 		}
 	}
 }
+
+func TestEventStream_ResumesWithLastEventID(t *testing.T) {
+	lastEventIDs := make(chan string, 2)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs <- r.Header.Get(api.LastEventIDHeader)
+		flusher, ok := w.(http.Flusher)
+		require.Equal(t, true, ok)
+		_, err := fmt.Fprint(w, "id: 7\nevent: head\ndata: data1\n\nevent: payload_attributes\ndata: data2\n\n")
+		require.NoError(t, err)
+		flusher.Flush()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := NewEventStream(ctx, http.DefaultClient, server.URL, []string{"head"})
+	require.NoError(t, err)
+	stream.reconnectDelay = 10 * time.Millisecond
+	eventsChannel := make(chan *Event, 4)
+	go stream.Subscribe(eventsChannel)
+
+	require.Equal(t, "", <-lastEventIDs)
+	// The payload attributes event has no id, so the client resumes from the head event.
+	require.Equal(t, "7", <-lastEventIDs)
+	require.Equal(t, "7", stream.LastEventID())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestEventStream_RetriesFailedConnections(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, "id: 1\nevent: head\ndata: data1\n\n")
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// The beacon node can't be reached for the first attempts.
+	var attempts int
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts <= 2 {
+			return nil, errors.New("connection refused")
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := NewEventStream(ctx, httpClient, server.URL, []string{"head"})
+	require.NoError(t, err)
+	stream.reconnectDelay = 10 * time.Millisecond
+	eventsChannel := make(chan *Event, 4)
+	go stream.Subscribe(eventsChannel)
+
+	require.Equal(t, EventConnectionError, (<-eventsChannel).EventType)
+	require.Equal(t, EventConnectionError, (<-eventsChannel).EventType)
+	ev := <-eventsChannel
+	require.Equal(t, EventHead, ev.EventType)
+	require.Equal(t, "data1", string(ev.Data))
+
+	// The channel is closed once the context is canceled.
+	cancel()
+	for range eventsChannel {
+	}
+}
+
+func TestEventStream_GapWithoutLastEventID(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		_, err := fmt.Fprint(w, "event: head\ndata: data1\n\n")
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := NewEventStream(ctx, http.DefaultClient, server.URL, []string{"head"})
+	require.NoError(t, err)
+	stream.reconnectDelay = 10 * time.Millisecond
+	eventsChannel := make(chan *Event, 4)
+	go stream.Subscribe(eventsChannel)
+
+	require.Equal(t, EventHead, (<-eventsChannel).EventType)
+	// The event had no id, so the events sent while the stream was interrupted can't be replayed.
+	require.Equal(t, EventGap, (<-eventsChannel).EventType)
+	require.Equal(t, EventHead, (<-eventsChannel).EventType)
+}
@@ -1,9 +1,13 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -73,6 +77,66 @@ func (c *Client) GetLocalValidatorKeys(ctx context.Context) (*rpc.ListKeystoresR
 	return jsonlocal, nil
 }
 
+// ImportKeystores calls the keymanager API to import keystores, along with the slashing protection history of their
+// keys in the EIP-3076 format.
+func (c *Client) ImportKeystores(ctx context.Context, request *rpc.ImportKeystoresRequest) (*rpc.ImportKeystoresResponse, error) {
+	b, err := c.send(ctx, http.MethodPost, localKeysPath, request)
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpc.ImportKeystoresResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse keystore import response")
+	}
+	return resp, nil
+}
+
+// DeleteKeystores calls the keymanager API to delete the keystores of the given public keys in hex format. The
+// response holds the slashing protection history of the keys in the EIP-3076 format.
+func (c *Client) DeleteKeystores(ctx context.Context, pubkeys []string) (*rpc.DeleteKeystoresResponse, error) {
+	b, err := c.send(ctx, http.MethodDelete, localKeysPath, &rpc.DeleteKeystoresRequest{Pubkeys: pubkeys})
+	if err != nil {
+		return nil, err
+	}
+	resp := &rpc.DeleteKeystoresResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse keystore deletion response")
+	}
+	return resp, nil
+}
+
+// send sends a request with a JSON body to the keymanager API, and returns the body of the response.
+func (c *Client) send(ctx context.Context, method, path string, body interface{}) (_ []byte, err error) {
+	enc, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON")
+	}
+	u := c.BaseURL().ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(enc))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create new %s request object", method)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client.WithAuthorizationToken(c.Token())(req)
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "failed to close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.Non200Err(resp)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading http response body")
+	}
+	return b, nil
+}
+
 // GetRemoteValidatorKeys calls the keymanager APIs for web3signer validator keys
 func (c *Client) GetRemoteValidatorKeys(ctx context.Context) (*rpc.ListRemoteKeysResponse, error) {
 	remoteBytes, err := c.Get(ctx, remoteKeysPath, client.WithAuthorizationToken(c.Token()))
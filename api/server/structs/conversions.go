@@ -757,6 +757,15 @@ func (a *IndexedAttestationElectra) ToConsensus() (*eth.IndexedAttestationElectr
 	}, nil
 }
 
+func ClientVersionV1FromConsensus(v *enginev1.ClientVersionV1) *ClientVersionV1 {
+	return &ClientVersionV1{
+		Code:    v.Code,
+		Name:    v.Name,
+		Version: v.Version,
+		Commit:  v.Commit,
+	}
+}
+
 func WithdrawalsFromConsensus(ws []*enginev1.Withdrawal) []*Withdrawal {
 	result := make([]*Withdrawal, len(ws))
 	for i, w := range ws {
@@ -7,9 +7,16 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	v1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	v2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v5/proto/migration"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
 func LightClientUpdateFromConsensus(update *v2.LightClientUpdate) (*LightClientUpdate, error) {
@@ -125,3 +132,279 @@ func lightClientHeaderContainerToJSON(container *v2.LightClientHeaderContainer)
 
 	return json.Marshal(header)
 }
+
+// LightClientHeaderFromConsensus converts a light client header of any fork to its JSON representation.
+func LightClientHeaderFromConsensus(h interfaces.LightClientHeader) (json.RawMessage, error) {
+	if h == nil {
+		return nil, errNilValue
+	}
+	beacon := BeaconBlockHeaderFromConsensus(h.Beacon())
+	if h.Version() < version.Capella {
+		return json.Marshal(&LightClientHeader{Beacon: beacon})
+	}
+	execution, err := h.Execution()
+	if err != nil {
+		return nil, err
+	}
+	executionBranch, err := h.ExecutionBranch()
+	if err != nil {
+		return nil, err
+	}
+	branch := make([]string, len(executionBranch))
+	for i := range executionBranch {
+		branch[i] = hexutil.Encode(executionBranch[i][:])
+	}
+
+	var header any
+	switch e := execution.Proto().(type) {
+	case *enginev1.ExecutionPayloadHeaderCapella:
+		payload, err := ExecutionPayloadHeaderCapellaFromConsensus(e)
+		if err != nil {
+			return nil, err
+		}
+		header = &LightClientHeaderCapella{Beacon: beacon, Execution: payload, ExecutionBranch: branch}
+	case *enginev1.ExecutionPayloadHeaderDeneb:
+		payload, err := ExecutionPayloadHeaderDenebFromConsensus(e)
+		if err != nil {
+			return nil, err
+		}
+		header = &LightClientHeaderDeneb{Beacon: beacon, Execution: payload, ExecutionBranch: branch}
+	default:
+		return nil, fmt.Errorf("unsupported execution payload header type %T", e)
+	}
+	return json.Marshal(header)
+}
+
+func (b *LightClientBootstrap) ToConsensus() (*v2.LightClientBootstrap, error) {
+	if b == nil {
+		return nil, errNilValue
+	}
+	header, err := lightClientHeaderContainerFromJSON(b.Header)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Header")
+	}
+	if b.CurrentSyncCommittee == nil {
+		return nil, server.NewDecodeError(errNilValue, "CurrentSyncCommittee")
+	}
+	committee, err := b.CurrentSyncCommittee.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "CurrentSyncCommittee")
+	}
+	branch, err := branchFromJSON(b.CurrentSyncCommitteeBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "CurrentSyncCommitteeBranch")
+	}
+
+	return &v2.LightClientBootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       migration.V1Alpha1SyncCommitteeToV2(committee),
+		CurrentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+func (u *LightClientUpdate) ToConsensus() (*v2.LightClientUpdate, error) {
+	if u == nil {
+		return nil, errNilValue
+	}
+	attestedHeader, err := lightClientHeaderContainerFromJSON(u.AttestedHeader)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttestedHeader")
+	}
+	var nextSyncCommittee *v2.SyncCommittee
+	if u.NextSyncCommittee != nil {
+		committee, err := u.NextSyncCommittee.ToConsensus()
+		if err != nil {
+			return nil, server.NewDecodeError(err, "NextSyncCommittee")
+		}
+		nextSyncCommittee = migration.V1Alpha1SyncCommitteeToV2(committee)
+	}
+	nextSyncCommitteeBranch, err := branchFromJSON(u.NextSyncCommitteeBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "NextSyncCommitteeBranch")
+	}
+	var finalizedHeader *v2.LightClientHeaderContainer
+	if len(u.FinalizedHeader) != 0 && string(u.FinalizedHeader) != "null" {
+		finalizedHeader, err = lightClientHeaderContainerFromJSON(u.FinalizedHeader)
+		if err != nil {
+			return nil, server.NewDecodeError(err, "FinalizedHeader")
+		}
+	}
+	finalityBranch, err := branchFromJSON(u.FinalityBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalityBranch")
+	}
+	syncAggregate, err := syncAggregateFromJSON(u.SyncAggregate)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncAggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(u.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SignatureSlot")
+	}
+
+	return &v2.LightClientUpdate{
+		AttestedHeader:          attestedHeader,
+		NextSyncCommittee:       nextSyncCommittee,
+		NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+		FinalizedHeader:         finalizedHeader,
+		FinalityBranch:          finalityBranch,
+		SyncAggregate:           syncAggregate,
+		SignatureSlot:           primitives.Slot(signatureSlot),
+	}, nil
+}
+
+func (u *LightClientFinalityUpdate) ToConsensus() (*v2.LightClientFinalityUpdate, error) {
+	if u == nil {
+		return nil, errNilValue
+	}
+	attestedHeader, err := lightClientHeaderContainerFromJSON(u.AttestedHeader)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttestedHeader")
+	}
+	finalizedHeader, err := lightClientHeaderContainerFromJSON(u.FinalizedHeader)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalizedHeader")
+	}
+	finalityBranch, err := branchFromJSON(u.FinalityBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalityBranch")
+	}
+	syncAggregate, err := syncAggregateFromJSON(u.SyncAggregate)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncAggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(u.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SignatureSlot")
+	}
+
+	return &v2.LightClientFinalityUpdate{
+		AttestedHeader:  attestedHeader,
+		FinalizedHeader: finalizedHeader,
+		FinalityBranch:  finalityBranch,
+		SyncAggregate:   syncAggregate,
+		SignatureSlot:   primitives.Slot(signatureSlot),
+	}, nil
+}
+
+func (u *LightClientOptimisticUpdate) ToConsensus() (*v2.LightClientOptimisticUpdate, error) {
+	if u == nil {
+		return nil, errNilValue
+	}
+	attestedHeader, err := lightClientHeaderContainerFromJSON(u.AttestedHeader)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttestedHeader")
+	}
+	syncAggregate, err := syncAggregateFromJSON(u.SyncAggregate)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncAggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(u.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SignatureSlot")
+	}
+
+	return &v2.LightClientOptimisticUpdate{
+		AttestedHeader: attestedHeader,
+		SyncAggregate:  syncAggregate,
+		SignatureSlot:  primitives.Slot(signatureSlot),
+	}, nil
+}
+
+func branchFromJSON(branch []string) ([][]byte, error) {
+	if branch == nil {
+		return nil, nil
+	}
+	branchBytes := make([][]byte, len(branch))
+	for i, root := range branch {
+		b, err := bytesutil.DecodeHexWithLength(root, fieldparams.RootLength)
+		if err != nil {
+			return nil, server.NewDecodeError(err, fmt.Sprintf("[%d]", i))
+		}
+		branchBytes[i] = b
+	}
+	return branchBytes, nil
+}
+
+func syncAggregateFromJSON(aggregate *SyncAggregate) (*v1.SyncAggregate, error) {
+	if aggregate == nil {
+		return nil, errNilValue
+	}
+	bits, err := bytesutil.DecodeHexWithLength(aggregate.SyncCommitteeBits, fieldparams.SyncAggregateSyncCommitteeBytesLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncCommitteeBits")
+	}
+	sig, err := bytesutil.DecodeHexWithLength(aggregate.SyncCommitteeSignature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncCommitteeSignature")
+	}
+	return &v1.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: sig,
+	}, nil
+}
+
+// lightClientHeaderContainerFromJSON decodes a light client header of any fork. The fork of the header
+// is told by its fields, as headers of several forks may be mixed in one light client object.
+func lightClientHeaderContainerFromJSON(raw json.RawMessage) (*v2.LightClientHeaderContainer, error) {
+	header := &struct {
+		Beacon          *BeaconBlockHeader `json:"beacon"`
+		Execution       json.RawMessage    `json:"execution"`
+		ExecutionBranch []string           `json:"execution_branch"`
+	}{}
+	if err := json.Unmarshal(raw, header); err != nil {
+		return nil, err
+	}
+	beaconHeader, err := header.Beacon.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Beacon")
+	}
+	beacon := migration.V1Alpha1HeaderToV1(beaconHeader)
+	if len(header.Execution) == 0 || string(header.Execution) == "null" {
+		return &v2.LightClientHeaderContainer{
+			Header: &v2.LightClientHeaderContainer_HeaderAltair{
+				HeaderAltair: &v2.LightClientHeader{Beacon: beacon},
+			},
+		}, nil
+	}
+	executionBranch, err := branchFromJSON(header.ExecutionBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExecutionBranch")
+	}
+	execution := &ExecutionPayloadHeaderDeneb{}
+	if err := json.Unmarshal(header.Execution, execution); err != nil {
+		return nil, server.NewDecodeError(err, "Execution")
+	}
+	if execution.BlobGasUsed == "" {
+		executionCapella := &ExecutionPayloadHeaderCapella{}
+		if err := json.Unmarshal(header.Execution, executionCapella); err != nil {
+			return nil, server.NewDecodeError(err, "Execution")
+		}
+		e, err := executionCapella.ToConsensus()
+		if err != nil {
+			return nil, server.NewDecodeError(err, "Execution")
+		}
+		return &v2.LightClientHeaderContainer{
+			Header: &v2.LightClientHeaderContainer_HeaderCapella{
+				HeaderCapella: &v2.LightClientHeaderCapella{
+					Beacon:          beacon,
+					Execution:       e,
+					ExecutionBranch: executionBranch,
+				},
+			},
+		}, nil
+	}
+	e, err := execution.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Execution")
+	}
+	return &v2.LightClientHeaderContainer{
+		Header: &v2.LightClientHeaderContainer_HeaderDeneb{
+			HeaderDeneb: &v2.LightClientHeaderDeneb{
+				Beacon:          beacon,
+				Execution:       e,
+				ExecutionBranch: executionBranch,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,54 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	// DoubleVote is the kind of an attester slashing whose attestations have the same target.
+	DoubleVote = "double_vote"
+	// SurroundVote is the kind of an attester slashing whose attestations surround one another.
+	SurroundVote = "surround_vote"
+)
+
+func DetectedAttesterSlashingFromConsensus(epoch primitives.Epoch, src eth.AttSlashing) (*DetectedAttesterSlashing, error) {
+	if src == nil || src.FirstAttestation() == nil || src.SecondAttestation() == nil {
+		return nil, errNilValue
+	}
+	var slashing interface{}
+	switch s := src.(type) {
+	case *eth.AttesterSlashing:
+		slashing = AttesterSlashingFromConsensus(s)
+	case *eth.AttesterSlashingElectra:
+		slashing = AttesterSlashingElectraFromConsensus(s)
+	default:
+		return nil, fmt.Errorf("unsupported attester slashing type %T", src)
+	}
+	data, err := json.Marshal(slashing)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal attester slashing")
+	}
+	kind := SurroundVote
+	if src.FirstAttestation().GetData().Target.Epoch == src.SecondAttestation().GetData().Target.Epoch {
+		kind = DoubleVote
+	}
+	return &DetectedAttesterSlashing{
+		Epoch:    fmt.Sprintf("%d", epoch),
+		Kind:     kind,
+		Version:  version.String(src.Version()),
+		Slashing: data,
+	}, nil
+}
+
+func DetectedProposerSlashingFromConsensus(epoch primitives.Epoch, src *eth.ProposerSlashing) *DetectedProposerSlashing {
+	return &DetectedProposerSlashing{
+		Epoch:    fmt.Sprintf("%d", epoch),
+		Slashing: ProposerSlashingFromConsensus(src),
+	}
+}
@@ -161,6 +161,20 @@ type Randao struct {
 	Randao string `json:"randao"`
 }
 
+type GetStateProofResponse struct {
+	Version             string      `json:"version"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+	Data                *StateProof `json:"data"`
+}
+
+type StateProof struct {
+	StateRoot string   `json:"state_root"`
+	Gindices  []string `json:"gindices"`
+	Leaves    []string `json:"leaves"`
+	Proof     []string `json:"proof"`
+}
+
 type GetSyncCommitteeResponse struct {
 	ExecutionOptimistic bool                     `json:"execution_optimistic"`
 	Finalized           bool                     `json:"finalized"`
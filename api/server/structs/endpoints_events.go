@@ -100,3 +100,9 @@ type LightClientOptimisticUpdateEvent struct {
 	Version string                       `json:"version"`
 	Data    *LightClientOptimisticUpdate `json:"data"`
 }
+
+// EventGapEvent is sent to a client resuming an event stream when some of the events after its last event id
+// are no longer retained by the beacon node, and can't be replayed.
+type EventGapEvent struct {
+	LastEventID string `json:"last_event_id"`
+}
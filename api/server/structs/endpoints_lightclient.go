@@ -71,3 +71,24 @@ type LightClientOptimisticUpdateResponse struct {
 type LightClientUpdatesByRangeResponse struct {
 	Updates []*LightClientUpdateResponse `json:"updates"`
 }
+
+type LightClientHeaderResponse struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type LightClientFinalityResponse struct {
+	Data *LightClientFinality `json:"data"`
+}
+
+type LightClientFinality struct {
+	Version                string          `json:"version"`
+	Header                 json.RawMessage `json:"header"`
+	Period                 string          `json:"period"`
+	NextSyncCommitteeKnown bool            `json:"next_sync_committee_known"`
+}
+
+type LightClientExecutionPayloadHeaderResponse struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
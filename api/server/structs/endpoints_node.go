@@ -64,6 +64,22 @@ type Version struct {
 	Version string `json:"version"`
 }
 
+type GetVersionV2Response struct {
+	Data *VersionV2 `json:"data"`
+}
+
+type VersionV2 struct {
+	BeaconNode      *ClientVersionV1 `json:"beacon_node"`
+	ExecutionClient *ClientVersionV1 `json:"execution_client,omitempty"`
+}
+
+type ClientVersionV1 struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
 type AddrRequest struct {
 	Addr string `json:"addr"`
 }
@@ -71,3 +87,29 @@ type AddrRequest struct {
 type PeersResponse struct {
 	Peers []*Peer `json:"peers"`
 }
+
+type GetDetailedHealthResponse struct {
+	Data *DetailedHealth `json:"data"`
+}
+
+type DetailedHealth struct {
+	Status   string           `json:"status"`
+	Services []*ServiceHealth `json:"services"`
+	Checks   []*HealthCheck   `json:"checks"`
+}
+
+type ServiceHealth struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type HealthCheck struct {
+	Name          string            `json:"name"`
+	Status        string            `json:"status"`
+	Value         string            `json:"value"`
+	WarnThreshold string            `json:"warn_threshold,omitempty"`
+	FailThreshold string            `json:"fail_threshold,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	Details       map[string]string `json:"details,omitempty"`
+}
@@ -0,0 +1,45 @@
+package structs
+
+import "encoding/json"
+
+type GetSlasherValidatorSpansResponse struct {
+	Data []*SlasherEpochSpans `json:"data"`
+}
+
+// SlasherEpochSpans are the min and max spans of a validator at an epoch.
+// A min span of 65535 and a max span of 0 mean that the slasher has no attestation for them.
+type SlasherEpochSpans struct {
+	Epoch   string `json:"epoch"`
+	MinSpan string `json:"min_span"`
+	MaxSpan string `json:"max_span"`
+}
+
+type GetSlasherValidatorProposalsResponse struct {
+	Data []*SlasherProposal `json:"data"`
+}
+
+type SlasherProposal struct {
+	HeaderRoot   string                   `json:"header_root"`
+	SignedHeader *SignedBeaconBlockHeader `json:"signed_header"`
+}
+
+type GetDetectedSlashingsResponse struct {
+	Data *DetectedSlashings `json:"data"`
+}
+
+type DetectedSlashings struct {
+	AttesterSlashings []*DetectedAttesterSlashing `json:"attester_slashings"`
+	ProposerSlashings []*DetectedProposerSlashing `json:"proposer_slashings"`
+}
+
+type DetectedAttesterSlashing struct {
+	Epoch    string          `json:"epoch"`
+	Kind     string          `json:"kind"`
+	Version  string          `json:"version"`
+	Slashing json.RawMessage `json:"slashing"` // represents `AttesterSlashing` or `AttesterSlashingElectra` based on the version
+}
+
+type DetectedProposerSlashing struct {
+	Epoch    string            `json:"epoch"`
+	Slashing *ProposerSlashing `json:"slashing"`
+}
@@ -149,3 +149,48 @@ type ActiveSetChanges struct {
 	EjectedPublicKeys   []string `json:"ejected_public_keys"`
 	EjectedIndices      []string `json:"ejected_indices"`
 }
+
+type GetValidatorRewardsResponse struct {
+	LastIndexedEpoch string                   `json:"last_indexed_epoch"`
+	Data             []*ValidatorEpochRewards `json:"data"`
+}
+
+type ValidatorEpochRewards struct {
+	ValidatorIndex string `json:"validator_index"`
+	Epoch          string `json:"epoch"`
+	Source         string `json:"source"`
+	Target         string `json:"target"`
+	Head           string `json:"head"`
+	Inactivity     string `json:"inactivity"`
+	SyncCommittee  string `json:"sync_committee"`
+	Proposer       string `json:"proposer"`
+	Total          string `json:"total"`
+}
+
+type GetMonitoredValidatorsResponse struct {
+	Data []string `json:"data"`
+}
+
+type MonitorValidatorsRequest struct {
+	Indices []string `json:"indices"`
+}
+
+type GetValidatorMonitorHistoryResponse struct {
+	Data []*ValidatorMonitorEpochSummary `json:"data"`
+}
+
+type ValidatorMonitorEpochSummary struct {
+	ValidatorIndex        string `json:"validator_index"`
+	Epoch                 string `json:"epoch"`
+	AttestationIncluded   bool   `json:"attestation_included"`
+	InclusionDistance     string `json:"inclusion_distance"`
+	TimelySource          bool   `json:"timely_source"`
+	TimelyTarget          bool   `json:"timely_target"`
+	TimelyHead            bool   `json:"timely_head"`
+	ProposedBlocks        string `json:"proposed_blocks"`
+	SyncCommitteeExpected string `json:"sync_committee_expected"`
+	SyncCommitteeIncluded string `json:"sync_committee_included"`
+	StartBalance          string `json:"start_balance"`
+	EndBalance            string `json:"end_balance"`
+	BalanceChange         string `json:"balance_change"`
+}
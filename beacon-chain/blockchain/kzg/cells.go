@@ -0,0 +1,129 @@
+package kzg
+
+import (
+	GoEthKZG "github.com/crate-crypto/go-eth-kzg"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+)
+
+var (
+	errInvalidBlobLength = errors.New("invalid blob length")
+	errCellCountMismatch = errors.New("data column cells, commitments and proofs count mismatch")
+	errCellOutOfRange    = errors.New("data column index out of range")
+)
+
+// CellsAndProofs holds the cells of an extended blob along with their KZG proofs, indexed by column.
+type CellsAndProofs struct {
+	Cells  [][]byte
+	Proofs [][]byte
+}
+
+// BlobToKZGCommitment computes the KZG commitment of a blob.
+func BlobToKZGCommitment(blob []byte) ([]byte, error) {
+	if len(blob) != fieldparams.BlobLength {
+		return nil, errInvalidBlobLength
+	}
+	commitment, err := kzgContext.BlobToKZGCommitment(bytesToBlob(blob), 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute blob commitment")
+	}
+	return commitment[:], nil
+}
+
+// ComputeCellsAndProofs extends the blob with its erasure code and computes the cells and the cell KZG proofs
+// of the extended blob. The first half of the cells is the blob itself.
+func ComputeCellsAndProofs(blob []byte) (CellsAndProofs, error) {
+	if len(blob) != fieldparams.BlobLength {
+		return CellsAndProofs{}, errInvalidBlobLength
+	}
+	cellCtx, err := getCellContext()
+	if err != nil {
+		return CellsAndProofs{}, err
+	}
+	var b GoEthKZG.Blob
+	copy(b[:], blob)
+	cells, proofs, err := cellCtx.ComputeCellsAndKZGProofs(&b, 0)
+	if err != nil {
+		return CellsAndProofs{}, errors.Wrap(err, "could not compute cells and proofs")
+	}
+	cp := CellsAndProofs{
+		Cells:  make([][]byte, len(cells)),
+		Proofs: make([][]byte, len(proofs)),
+	}
+	for i := range cells {
+		cp.Cells[i] = cells[i][:]
+		proof := proofs[i]
+		cp.Proofs[i] = proof[:]
+	}
+	return cp, nil
+}
+
+// RecoverCellsAndProofs rebuilds all the cells and proofs of an extended blob from at least half of its cells,
+// given with their column indices.
+func RecoverCellsAndProofs(columnIndices []uint64, cells [][]byte) (CellsAndProofs, error) {
+	if len(columnIndices) != len(cells) {
+		return CellsAndProofs{}, errCellCountMismatch
+	}
+	cellCtx, err := getCellContext()
+	if err != nil {
+		return CellsAndProofs{}, err
+	}
+	goCells := make([]*GoEthKZG.Cell, len(cells))
+	for i := range cells {
+		goCells[i] = bytesToCell(cells[i])
+	}
+	recovered, proofs, err := cellCtx.RecoverCellsAndComputeKZGProofs(columnIndices, goCells, 0)
+	if err != nil {
+		return CellsAndProofs{}, errors.Wrap(err, "could not recover cells and proofs")
+	}
+	cp := CellsAndProofs{
+		Cells:  make([][]byte, len(recovered)),
+		Proofs: make([][]byte, len(proofs)),
+	}
+	for i := range recovered {
+		cp.Cells[i] = recovered[i][:]
+		proof := proofs[i]
+		cp.Proofs[i] = proof[:]
+	}
+	return cp, nil
+}
+
+// VerifyDataColumns performs a batch verification of the cell KZG proofs of the given data column sidecars
+// against the commitments they carry.
+func VerifyDataColumns(columns ...blocks.RODataColumn) error {
+	var (
+		cmts    []GoEthKZG.KZGCommitment
+		indices []uint64
+		cells   []*GoEthKZG.Cell
+		proofs  []GoEthKZG.KZGProof
+	)
+	for _, col := range columns {
+		if col.Index >= fieldparams.NumberOfColumns {
+			return errCellOutOfRange
+		}
+		if len(col.Column) != len(col.KzgCommitments) || len(col.Column) != len(col.KzgProofs) {
+			return errCellCountMismatch
+		}
+		for i := range col.Column {
+			cmts = append(cmts, GoEthKZG.KZGCommitment(bytesToCommitment(col.KzgCommitments[i])))
+			indices = append(indices, col.Index)
+			cells = append(cells, bytesToCell(col.Column[i]))
+			proofs = append(proofs, GoEthKZG.KZGProof(bytesToKZGProof(col.KzgProofs[i])))
+		}
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+	cellCtx, err := getCellContext()
+	if err != nil {
+		return err
+	}
+	return cellCtx.VerifyCellKZGProofBatch(cmts, indices, cells, proofs)
+}
+
+func bytesToCell(cell []byte) *GoEthKZG.Cell {
+	var ret GoEthKZG.Cell
+	copy(ret[:], cell)
+	return &ret
+}
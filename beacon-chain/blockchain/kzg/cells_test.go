@@ -0,0 +1,78 @@
+package kzg
+
+import (
+	"bytes"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func testDataColumn(t *testing.T, index uint64, commitment []byte, cp CellsAndProofs) blocks.RODataColumn {
+	col, err := blocks.NewRODataColumnWithRoot(&ethpb.DataColumnSidecar{
+		Index:          index,
+		Column:         [][]byte{cp.Cells[index]},
+		KzgCommitments: [][]byte{commitment},
+		KzgProofs:      [][]byte{cp.Proofs[index]},
+		SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{},
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+	}, [32]byte{})
+	require.NoError(t, err)
+	return col
+}
+
+func TestComputeCellsAndProofs(t *testing.T) {
+	require.NoError(t, Start())
+	blob := GetRandBlob(123)
+	commitment, _, err := GenerateCommitmentAndProof(blob)
+	require.NoError(t, err)
+	computed, err := BlobToKZGCommitment(blob[:])
+	require.NoError(t, err)
+	require.DeepEqual(t, commitment[:], computed)
+
+	_, err = ComputeCellsAndProofs(blob[:10])
+	require.ErrorIs(t, err, errInvalidBlobLength)
+
+	cp, err := ComputeCellsAndProofs(blob[:])
+	require.NoError(t, err)
+	require.Equal(t, fieldparams.NumberOfColumns, len(cp.Cells))
+	require.Equal(t, fieldparams.NumberOfColumns, len(cp.Proofs))
+	// The first half of the extended blob is the blob itself.
+	require.Equal(t, true, bytes.Equal(blob[:], bytes.Join(cp.Cells[:fieldparams.NumberOfColumns/2], nil)))
+
+	cols := []blocks.RODataColumn{
+		testDataColumn(t, 0, commitment[:], cp),
+		testDataColumn(t, 17, commitment[:], cp),
+		testDataColumn(t, 127, commitment[:], cp),
+	}
+	require.NoError(t, VerifyDataColumns(cols...))
+
+	cols[1].KzgProofs[0] = cp.Proofs[18]
+	require.NotNil(t, VerifyDataColumns(cols...))
+	cols[1].KzgProofs = nil
+	require.ErrorIs(t, VerifyDataColumns(cols...), errCellCountMismatch)
+}
+
+func TestRecoverCellsAndProofs(t *testing.T) {
+	require.NoError(t, Start())
+	blob := GetRandBlob(42)
+	cp, err := ComputeCellsAndProofs(blob[:])
+	require.NoError(t, err)
+
+	indices := make([]uint64, 0, fieldparams.NumberOfColumns/2)
+	cells := make([][]byte, 0, fieldparams.NumberOfColumns/2)
+	for i := uint64(1); i < fieldparams.NumberOfColumns; i += 2 {
+		indices = append(indices, i)
+		cells = append(cells, cp.Cells[i])
+	}
+	recovered, err := RecoverCellsAndProofs(indices, cells)
+	require.NoError(t, err)
+	require.DeepEqual(t, cp, recovered)
+
+	_, err = RecoverCellsAndProofs(indices[:1], cells)
+	require.ErrorIs(t, err, errCellCountMismatch)
+}
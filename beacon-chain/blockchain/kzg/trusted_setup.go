@@ -3,7 +3,9 @@ package kzg
 import (
 	_ "embed"
 	"encoding/json"
+	"sync"
 
+	GoEthKZG "github.com/crate-crypto/go-eth-kzg"
 	GoKZG "github.com/crate-crypto/go-kzg-4844"
 	"github.com/pkg/errors"
 )
@@ -12,6 +14,11 @@ var (
 	//go:embed trusted_setup.json
 	embeddedTrustedSetup []byte // 1.2Mb
 	kzgContext           *GoKZG.Context
+	// The cell context precomputes large tables from the setup, so it is only built once per process, the first
+	// time cells are used. Nodes which never compute or verify cells don't pay for it.
+	cellContextOnce sync.Once
+	cellContext     *GoEthKZG.Context
+	cellContextErr  error
 )
 
 func Start() error {
@@ -26,3 +33,19 @@ func Start() error {
 	}
 	return nil
 }
+
+// getCellContext returns the go-eth-kzg context used for cells, building it on first use.
+func getCellContext() (*GoEthKZG.Context, error) {
+	cellContextOnce.Do(func() {
+		cellSetup := GoEthKZG.JSONTrustedSetup{}
+		if err := json.Unmarshal(embeddedTrustedSetup, &cellSetup); err != nil {
+			cellContextErr = errors.Wrap(err, "could not parse trusted setup JSON for cells")
+			return
+		}
+		cellContext, cellContextErr = GoEthKZG.NewContext4096(&cellSetup)
+		if cellContextErr != nil {
+			cellContextErr = errors.Wrap(cellContextErr, "could not initialize go-eth-kzg context")
+		}
+	})
+	return cellContext, cellContextErr
+}
@@ -10,3 +10,13 @@ func TestStart(t *testing.T) {
 	require.NoError(t, Start())
 	require.NotNil(t, kzgContext)
 }
+
+func TestGetCellContext(t *testing.T) {
+	cellCtx, err := getCellContext()
+	require.NoError(t, err)
+	require.NotNil(t, cellCtx)
+	// The context is only built once.
+	again, err := getCellContext()
+	require.NoError(t, err)
+	require.Equal(t, cellCtx, again)
+}
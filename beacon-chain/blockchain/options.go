@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
@@ -125,6 +126,14 @@ func WithP2PBroadcaster(p p2p.Broadcaster) Option {
 	}
 }
 
+// WithNodeID sets the discovery node ID of the beacon node, from which the data columns it custodies are derived.
+func WithNodeID(id enode.ID) Option {
+	return func(s *Service) error {
+		s.cfg.NodeID = id
+		return nil
+	}
+}
+
 // WithStateNotifier to notify an event feed of state processing.
 func WithStateNotifier(n statefeed.Notifier) Option {
 	return func(s *Service) error {
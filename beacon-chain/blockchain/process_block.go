@@ -3,6 +3,7 @@ package blockchain
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	coreTime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
@@ -551,6 +553,18 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	if expected == 0 {
 		return nil
 	}
+	// Past EIP-7594, blobs are no longer gossiped and the node waits for the columns it custodies instead, while
+	// sampling the columns it does not custody.
+	if peerdas.IsActive(block.Slot()) {
+		sampled := s.sampleDataColumns(ctx, root, signed)
+		if err := s.areDataColumnsAvailable(ctx, root, signed); err != nil {
+			return err
+		}
+		if err := <-sampled; err != nil {
+			return errors.Wrap(err, "could not sample data columns")
+		}
+		return nil
+	}
 	// get a map of BlobSidecar indices that are not currently available.
 	missing, err := missingIndices(s.blobStorage, root, kzgCommitments)
 	if err != nil {
@@ -596,6 +610,118 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	}
 }
 
+// sampleDataColumns starts sampling the data columns of the block, and returns the channel receiving the result.
+// The result is nil right away when no sampler is set.
+func (s *Service) sampleDataColumns(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock) <-chan error {
+	result := make(chan error, 1)
+	if s.dataColumnSampler == nil {
+		result <- nil
+		return result
+	}
+	rob, err := consensusblocks.NewROBlockWithRoot(signed, root)
+	if err != nil {
+		result <- err
+		return result
+	}
+	go func() {
+		result <- s.dataColumnSampler.SampleDataColumns(ctx, rob)
+	}()
+	return result
+}
+
+// areDataColumnsAvailable blocks until all the data column sidecars the node custodies for the given block root
+// are available, or an error or context cancellation occurs. The columns still missing at the start of the next
+// slot are requested from peers. A nil result means that the data availability check is successful.
+func (s *Service) areDataColumnsAvailable(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	slot := signed.Block().Slot()
+	custody, err := peerdas.CustodyColumns(s.cfg.NodeID, peerdas.CustodySubnetCount())
+	if err != nil {
+		return errors.Wrap(err, "could not compute custody columns")
+	}
+	stored, err := s.blobStorage.DataColumnIndices(root)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve stored data column indices")
+	}
+	missing := make(map[uint64]struct{}, len(custody))
+	for idx := range custody {
+		if !stored[idx] {
+			missing[idx] = struct{}{}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// The gossip handler for data columns writes the index of each verified column referencing the given
+	// root to the channel returned by dataColumnNotifiers.forRoot.
+	nc := s.dataColumnNotifiers.forRoot(root)
+	waiting := func() []uint64 {
+		s.dataColumnNotifiers.RLock()
+		seen := s.dataColumnNotifiers.seenIndex[root]
+		s.dataColumnNotifiers.RUnlock()
+		columns := make([]uint64, 0, len(custody))
+		for idx := range custody {
+			if !stored[idx] && !seen[idx] {
+				columns = append(columns, idx)
+			}
+		}
+		sort.Slice(columns, func(i, j int) bool { return columns[i] < columns[j] })
+		return columns
+	}
+
+	// Log for DA checks that cross over into the next slot; helpful for debugging. The columns still missing
+	// by then are requested from peers, right away for the blocks of past slots.
+	requestCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	nextSlot := slots.BeginsAt(slot+1, s.genesisTime)
+	pastSlot := !nextSlot.After(time.Now())
+	nst := time.AfterFunc(time.Until(nextSlot), func() {
+		columns := waiting()
+		if len(columns) == 0 {
+			return
+		}
+		fields := logrus.Fields{
+			"slot":            slot,
+			"root":            fmt.Sprintf("%#x", root),
+			"columnsExpected": len(custody),
+			"columnsWaiting":  len(columns),
+		}
+		if !pastSlot {
+			log.WithFields(fields).Error("Still waiting for data columns DA check at slot end.")
+		}
+		s.requestDataColumns(requestCtx, root, signed, columns, fields)
+	})
+	defer nst.Stop()
+	for {
+		select {
+		case idx := <-nc:
+			delete(missing, idx)
+			if len(missing) > 0 {
+				continue
+			}
+			s.dataColumnNotifiers.delete(root)
+			return nil
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "context deadline waiting for data column sidecars slot: %d, BlockRoot: %#x", slot, root)
+		}
+	}
+}
+
+// requestDataColumns requests the given custody columns of the block from peers, when a requester is set.
+func (s *Service) requestDataColumns(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock, columns []uint64, fields logrus.Fields) {
+	if s.dataColumnRequester == nil {
+		return
+	}
+	rob, err := consensusblocks.NewROBlockWithRoot(signed, root)
+	if err != nil {
+		log.WithError(err).WithFields(fields).Error("Could not request missing data columns")
+		return
+	}
+	if err := s.dataColumnRequester.RequestDataColumns(ctx, rob, columns); err != nil {
+		log.WithError(err).WithFields(fields).Warn("Could not retrieve missing data columns from peers")
+	}
+}
+
 func daCheckLogFields(root [32]byte, slot primitives.Slot, expected, missing int) logrus.Fields {
 	return logrus.Fields{
 		"slot":          slot,
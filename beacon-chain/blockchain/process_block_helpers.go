@@ -127,7 +127,61 @@ func (s *Service) sendLightClientFeeds(cfg *postBlockProcessConfig) {
 
 		// LightClientFinalityUpdate needs super majority
 		s.tryPublishLightClientFinalityUpdate(cfg.ctx, cfg.roblock, finalized, cfg.postState)
+
+		if err := s.saveLightClientUpdate(cfg.ctx, cfg.roblock, cfg.postState); err != nil {
+			log.WithError(err).Error("Failed to save light client update")
+		}
+	}
+}
+
+// saveLightClientUpdate saves the light client update of the block when it is the best update
+// of the sync committee period of its attested block. These are served to light clients by period.
+func (s *Service) saveLightClientUpdate(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock,
+	postState state.BeaconState) error {
+	attestedRoot := signed.Block().ParentRoot()
+	attestedBlock, err := s.cfg.BeaconDB.Block(ctx, attestedRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get attested block")
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, attestedRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	finalizedCheckPoint := attestedState.FinalizedCheckpoint()
+	if finalizedCheckPoint != nil {
+		finalizedRoot := bytesutil.ToBytes32(finalizedCheckPoint.Root)
+		finalizedBlock, err = s.cfg.BeaconDB.Block(ctx, finalizedRoot)
+		if err != nil {
+			finalizedBlock = nil
+		}
 	}
+
+	update, err := lightclient.NewLightClientUpdateFromBeaconState(
+		ctx,
+		postState,
+		signed,
+		attestedState,
+		attestedBlock,
+		finalizedBlock,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(attestedState.Slot()))
+	oldUpdate, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get light client update")
+	}
+	if oldUpdate != nil && oldUpdate.Data != nil && !lightclient.IsBetterUpdate(update, oldUpdate.Data) {
+		return nil
+	}
+	return s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, &ethpbv2.LightClientUpdateWithVersion{
+		Version: ethpbv2.Version(attestedState.Version()),
+		Data:    update,
+	})
 }
 
 func (s *Service) tryPublishLightClientFinalityUpdate(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock, finalized *forkchoicetypes.Checkpoint, postState state.BeaconState) {
@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
@@ -25,6 +26,7 @@ import (
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -40,6 +42,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -2446,3 +2449,110 @@ func fakeResult(missing []uint64) map[uint64]struct{} {
 	}
 	return r
 }
+
+func TestSaveLightClientUpdate(t *testing.T) {
+	s, tr := minimalTestService(t)
+	ctx := tr.ctx
+	l := util.NewTestLightClient(t).SetupTestAltair()
+
+	attestedRoot := l.Block.Block().ParentRoot()
+	require.NoError(t, tr.db.SaveBlock(ctx, l.AttestedBlock))
+	require.NoError(t, tr.db.SaveState(ctx, l.AttestedState, attestedRoot))
+
+	require.NoError(t, s.saveLightClientUpdate(ctx, l.Block, l.State))
+
+	period := slots.SyncCommitteePeriod(slots.ToEpoch(l.AttestedState.Slot()))
+	update, err := tr.db.LightClientUpdate(ctx, period)
+	require.NoError(t, err)
+	require.NotNil(t, update.Data)
+	require.Equal(t, l.Block.Block().Slot(), update.Data.SignatureSlot)
+	require.Equal(t, l.AttestedBlock.Block().Slot(), update.Data.AttestedHeader.GetHeaderAltair().Beacon.Slot)
+}
+
+type mockDataColumnSampler struct {
+	err     error
+	sampled int
+}
+
+func (m *mockDataColumnSampler) SampleDataColumns(context.Context, consensusblocks.ROBlock) error {
+	m.sampled++
+	return m.err
+}
+
+func TestIsDataAvailable_SamplesDataColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch, cfg.Eip7594ForkEpoch = 0, 0
+	params.OverrideBeaconConfig(cfg)
+
+	blobStorage := filesystem.NewEphemeralBlobStorage(t)
+	s, _ := minimalTestService(t, WithBlobStorage(blobStorage))
+	s.SetGenesisTime(time.Now().Add(-time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second))
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	// All the columns are stored, the node only waits for the sampling.
+	for _, column := range verification.FakeVerifyDataColumnSliceForTest(t, columns) {
+		require.NoError(t, blobStorage.SaveDataColumn(column))
+	}
+	ctx := context.Background()
+	require.NoError(t, s.isDataAvailable(ctx, blk.Root(), blk))
+
+	sampler := &mockDataColumnSampler{}
+	s.SetDataColumnSampler(sampler)
+	require.NoError(t, s.isDataAvailable(ctx, blk.Root(), blk))
+	require.Equal(t, 1, sampler.sampled)
+
+	sampler.err = errors.New("missing columns")
+	require.ErrorContains(t, "could not sample data columns", s.isDataAvailable(ctx, blk.Root(), blk))
+}
+
+// mockDataColumnRequester serves the requested columns as if they had been received from peers.
+type mockDataColumnRequester struct {
+	s         *Service
+	columns   []consensusblocks.RODataColumn
+	requested []uint64
+}
+
+func (m *mockDataColumnRequester) RequestDataColumns(ctx context.Context, _ consensusblocks.ROBlock, columns []uint64) error {
+	m.requested = columns
+	for _, idx := range columns {
+		if err := m.s.ReceiveDataColumn(ctx, consensusblocks.NewVerifiedRODataColumn(m.columns[idx])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAreDataColumnsAvailable_RequestsMissingColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch, cfg.Eip7594ForkEpoch = 0, 0
+	params.OverrideBeaconConfig(cfg)
+
+	blobStorage := filesystem.NewEphemeralBlobStorage(t)
+	s, _ := minimalTestService(t, WithBlobStorage(blobStorage))
+	// The block is from a past slot, so the missing columns are requested right away.
+	s.SetGenesisTime(time.Now().Add(-3 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second))
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	custody, err := peerdas.CustodyColumns(s.cfg.NodeID, peerdas.CustodySubnetCount())
+	require.NoError(t, err)
+	expected := make([]uint64, 0, len(custody))
+	for idx := uint64(0); idx < params.BeaconConfig().NumberOfColumns; idx++ {
+		if custody[idx] {
+			expected = append(expected, idx)
+		}
+	}
+	// The first custody column is already stored and is not requested.
+	require.NoError(t, blobStorage.SaveDataColumn(consensusblocks.NewVerifiedRODataColumn(columns[expected[0]])))
+
+	requester := &mockDataColumnRequester{s: s, columns: columns}
+	s.SetDataColumnRequester(requester)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.areDataColumnsAvailable(ctx, blk.Root(), blk))
+	require.DeepEqual(t, expected[1:], requester.requested)
+	stored, err := blobStorage.DataColumnIndices(blk.Root())
+	require.NoError(t, err)
+	for _, idx := range expected {
+		require.Equal(t, true, stored[idx])
+	}
+}
@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"context"
+	"sync"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+)
+
+// ReceiveDataColumn saves the data column sidecar to database and sends the new event.
+func (s *Service) ReceiveDataColumn(_ context.Context, dc blocks.VerifiedRODataColumn) error {
+	if err := s.blobStorage.SaveDataColumn(dc); err != nil {
+		return err
+	}
+
+	s.dataColumnNotifiers.notifyIndex(dc.BlockRoot(), dc.Index)
+	return nil
+}
+
+// dataColumnNotifierMap mirrors blobNotifierMap for data column sidecars, whose indices
+// range over all the columns of the extended blob matrix.
+type dataColumnNotifierMap struct {
+	sync.RWMutex
+	notifiers map[[32]byte]chan uint64
+	seenIndex map[[32]byte][fieldparams.NumberOfColumns]bool
+}
+
+func newDataColumnNotifierMap() *dataColumnNotifierMap {
+	return &dataColumnNotifierMap{
+		notifiers: make(map[[32]byte]chan uint64),
+		seenIndex: make(map[[32]byte][fieldparams.NumberOfColumns]bool),
+	}
+}
+
+// notifyIndex notifies a data column by its index for a given root.
+func (dn *dataColumnNotifierMap) notifyIndex(root [32]byte, idx uint64) {
+	if idx >= fieldparams.NumberOfColumns {
+		return
+	}
+
+	dn.Lock()
+	seen := dn.seenIndex[root]
+	if seen[idx] {
+		dn.Unlock()
+		return
+	}
+	seen[idx] = true
+	dn.seenIndex[root] = seen
+
+	c, ok := dn.notifiers[root]
+	if !ok {
+		c = make(chan uint64, fieldparams.NumberOfColumns)
+		dn.notifiers[root] = c
+	}
+
+	dn.Unlock()
+
+	c <- idx
+}
+
+func (dn *dataColumnNotifierMap) forRoot(root [32]byte) chan uint64 {
+	dn.Lock()
+	defer dn.Unlock()
+	c, ok := dn.notifiers[root]
+	if !ok {
+		c = make(chan uint64, fieldparams.NumberOfColumns)
+		dn.notifiers[root] = c
+	}
+	return c
+}
+
+func (dn *dataColumnNotifierMap) delete(root [32]byte) {
+	dn.Lock()
+	defer dn.Unlock()
+	delete(dn.seenIndex, root)
+	delete(dn.notifiers, root)
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
@@ -65,11 +66,24 @@ type Service struct {
 	clockWaiter                   startup.ClockWaiter
 	syncComplete                  chan struct{}
 	blobNotifiers                 *blobNotifierMap
+	dataColumnNotifiers           *dataColumnNotifierMap
+	dataColumnSampler             DataColumnSampler
+	dataColumnRequester           DataColumnRequester
 	blockBeingSynced              *currentlySyncingBlock
 	blobStorage                   *filesystem.BlobStorage
 	lastPublishedLightClientEpoch primitives.Epoch
 }
 
+// DataColumnSampler samples the data columns of a block which the node does not custody.
+type DataColumnSampler interface {
+	SampleDataColumns(ctx context.Context, blk blocks.ROBlock) error
+}
+
+// DataColumnRequester requests from peers the data columns of a block which the node custodies but did not receive.
+type DataColumnRequester interface {
+	RequestDataColumns(ctx context.Context, blk blocks.ROBlock, columns []uint64) error
+}
+
 // config options for the service.
 type config struct {
 	BeaconBlockBuf          int
@@ -94,6 +108,7 @@ type config struct {
 	FinalizedStateAtStartUp state.BeaconState
 	ExecutionEngineCaller   execution.EngineCaller
 	SyncChecker             Checker
+	NodeID                  enode.ID
 }
 
 // Checker is an interface used to determine if a node is in initial sync
@@ -178,6 +193,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
 		blobNotifiers:        bn,
+		dataColumnNotifiers:  newDataColumnNotifierMap(),
 		cfg:                  &config{},
 		blockBeingSynced:     &currentlySyncingBlock{roots: make(map[[32]byte]struct{})},
 	}
@@ -196,6 +212,18 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	return srv, nil
 }
 
+// SetDataColumnSampler sets the sampler run by the data availability check of blocks past the PeerDAS fork. The
+// sampler is provided by the sync service, which depends on this service, so it must be set before starting it.
+func (s *Service) SetDataColumnSampler(sampler DataColumnSampler) {
+	s.dataColumnSampler = sampler
+}
+
+// SetDataColumnRequester sets the requester used by the data availability check of blocks past the PeerDAS fork
+// for the custody columns not received over gossip. Like the sampler, it must be set before starting the service.
+func (s *Service) SetDataColumnRequester(requester DataColumnRequester) {
+	s.dataColumnRequester = requester
+}
+
 // Start a blockchain service's main event loop.
 func (s *Service) Start() {
 	saved := s.cfg.FinalizedStateAtStartUp
@@ -27,4 +27,40 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "builder_relay_latency_milliseconds",
+			Help:    "Captures the latency of the builder API calls to each relay in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay", "method"},
+	)
+	relayErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_errors_total",
+			Help: "The number of failed builder API calls and invalid bids of each relay",
+		},
+		[]string{"relay", "method"},
+	)
+	relayBids = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_bids_total",
+			Help: "The number of valid bids received from each relay",
+		},
+		[]string{"relay"},
+	)
+	relayBidsWon = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_bids_won_total",
+			Help: "The number of bids of each relay which were the highest bid for their slot",
+		},
+		[]string{"relay"},
+	)
+	relayUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "builder_relay_up",
+			Help: "Whether the last status check of each relay succeeded",
+		},
+		[]string{"relay"},
+	)
 )
@@ -1,6 +1,9 @@
 package builder
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
@@ -13,19 +16,32 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
-		if err != nil {
-			return nil, err
+	endpoints := c.StringSlice(flags.MevRelayEndpoints.Name)
+	if endpoint := c.String(flags.MevRelayEndpoint.Name); endpoint != "" {
+		endpoints = append([]string{endpoint}, endpoints...)
+	}
+	if len(endpoints) <= 1 {
+		var client *builder.Client
+		if len(endpoints) == 1 {
+			var err error
+			client, err = builder.NewClient(endpoints[0])
+			if err != nil {
+				return nil, err
+			}
 		}
+		return []Option{WithBuilderClient(client)}, nil
 	}
-	opts := []Option{
-		WithBuilderClient(client),
+
+	clients := make([]builder.BuilderClient, len(endpoints))
+	for i, endpoint := range endpoints {
+		client, err := builder.NewClient(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create client for relay %s", relayName(endpoint))
+		}
+		clients[i] = client
 	}
-	return opts, nil
+	timeout := time.Duration(c.Uint64(flags.MevRelayGetHeaderTimeoutMs.Name)) * time.Millisecond
+	return []Option{WithRelayClients(clients, timeout)}, nil
 }
 
 // WithBuilderClient sets the builder client for the beacon chain builder service.
@@ -36,6 +52,19 @@ func WithBuilderClient(client builder.BuilderClient) Option {
 	}
 }
 
+// WithRelayClients sets several relay clients for the beacon chain builder service. Headers are requested
+// from all relays, which have the given time to return their bids, and the highest bid is used.
+func WithRelayClients(clients []builder.BuilderClient, getHeaderTimeout time.Duration) Option {
+	return func(s *Service) error {
+		client, err := newMultiRelayClient(clients, getHeaderTimeout)
+		if err != nil {
+			return err
+		}
+		s.cfg.builderClient = client
+		return nil
+	}
+}
+
 // WithHeadFetcher gets the head info from chain service.
 func WithHeadFetcher(svc blockchain.HeadFetcher) Option {
 	return func(s *Service) error {
@@ -0,0 +1,334 @@
+package builder
+
+import (
+	"context"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultGetHeaderTimeout is the time given to the relays to return their bids.
+const defaultGetHeaderTimeout = 950 * time.Millisecond
+
+// winnersToKeep is the number of slots for which the relay that won the bid is remembered.
+const winnersToKeep = 64
+
+var (
+	errNoRelays        = errors.New("no relays configured")
+	errNoBids          = errors.New("no relay returned a valid bid")
+	errNoAllowedRelays = errors.New("none of the configured relays is allowed by the proposer")
+)
+
+type allowedRelaysKey struct{}
+
+// WithAllowedRelays returns a copy of the context which restricts the headers requested with it to the
+// given relays. Relays are matched by host, so either the relay URLs or their hosts may be given.
+func WithAllowedRelays(ctx context.Context, relays []string) context.Context {
+	if len(relays) == 0 {
+		return ctx
+	}
+	allowed := make(map[string]bool, len(relays))
+	for _, r := range relays {
+		allowed[relayName(r)] = true
+	}
+	return context.WithValue(ctx, allowedRelaysKey{}, allowed)
+}
+
+// relayAllowed returns whether headers may be requested from the named relay with the context.
+func relayAllowed(ctx context.Context, name string) bool {
+	allowed, ok := ctx.Value(allowedRelaysKey{}).(map[string]bool)
+	return !ok || allowed[name]
+}
+
+// relay is a single builder relay and its label in logs and metrics.
+type relay struct {
+	client builder.BuilderClient
+	name   string
+}
+
+// winningBid identifies the bid which won the auction of a slot and the relay which provided it.
+type winningBid struct {
+	blockHash [32]byte
+	relay     *relay
+}
+
+// multiRelayClient is a builder.BuilderClient which fans out the builder API calls to several relays.
+// Headers are requested from all relays, and the highest valid bid is returned. Blinded blocks are
+// submitted to the relay which provided the winning bid.
+type multiRelayClient struct {
+	relays           []*relay
+	getHeaderTimeout time.Duration
+
+	sync.Mutex
+	winners map[primitives.Slot]winningBid
+}
+
+func newMultiRelayClient(clients []builder.BuilderClient, getHeaderTimeout time.Duration) (*multiRelayClient, error) {
+	if len(clients) == 0 {
+		return nil, errNoRelays
+	}
+	if getHeaderTimeout == 0 {
+		getHeaderTimeout = defaultGetHeaderTimeout
+	}
+	relays := make([]*relay, len(clients))
+	for i, c := range clients {
+		relays[i] = &relay{client: c, name: relayName(c.NodeURL())}
+	}
+	return &multiRelayClient{
+		relays:           relays,
+		getHeaderTimeout: getHeaderTimeout,
+		winners:          make(map[primitives.Slot]winningBid),
+	}, nil
+}
+
+// relayName returns the host of a relay URL, so that the relay public key in the URL is not logged.
+func relayName(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" {
+		return u
+	}
+	return parsed.Host
+}
+
+// NodeURL returns the hosts of all relays.
+func (m *multiRelayClient) NodeURL() string {
+	names := make([]string, len(m.relays))
+	for i, r := range m.relays {
+		names[i] = r.name
+	}
+	return strings.Join(names, ",")
+}
+
+type relayBid struct {
+	relay *relay
+	bid   builder.SignedBid
+	value *big.Int
+	hash  [32]byte
+}
+
+// GetHeader requests a header from all relays allowed by the context and returns the highest valid bid
+// received before the deadline. Ties are won by the relay which answered first.
+func (m *multiRelayClient) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubkey [48]byte) (builder.SignedBid, error) {
+	relays := make([]*relay, 0, len(m.relays))
+	for _, r := range m.relays {
+		if relayAllowed(ctx, r.name) {
+			relays = append(relays, r)
+		}
+	}
+	if len(relays) == 0 {
+		return nil, errNoAllowedRelays
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.getHeaderTimeout)
+	defer cancel()
+
+	bids := make(chan *relayBid, len(relays))
+	var wg sync.WaitGroup
+	for _, r := range relays {
+		wg.Add(1)
+		go func(r *relay) {
+			defer wg.Done()
+			start := time.Now()
+			signedBid, err := r.client.GetHeader(ctx, slot, parentHash, pubkey)
+			relayLatency.WithLabelValues(r.name, "get_header").Observe(float64(time.Since(start).Milliseconds()))
+			if err != nil {
+				relayErrors.WithLabelValues(r.name, "get_header").Inc()
+				log.WithError(err).WithField("relay", r.name).Debug("Could not get header from relay")
+				return
+			}
+			b, err := validRelayBid(signedBid, parentHash)
+			if err != nil {
+				relayErrors.WithLabelValues(r.name, "get_header").Inc()
+				log.WithError(err).WithField("relay", r.name).Warn("Relay returned an invalid bid")
+				return
+			}
+			b.relay = r
+			relayBids.WithLabelValues(r.name).Inc()
+			bids <- b
+		}(r)
+	}
+	wg.Wait()
+	close(bids)
+
+	var best *relayBid
+	for b := range bids {
+		if best == nil || b.value.Cmp(best.value) > 0 {
+			best = b
+		}
+	}
+	if best == nil {
+		return nil, errNoBids
+	}
+	relayBidsWon.WithLabelValues(best.relay.name).Inc()
+	log.WithFields(log.Fields{
+		"slot":  slot,
+		"relay": best.relay.name,
+		"value": best.value.String(),
+	}).Debug("Selected highest relay bid")
+
+	m.Lock()
+	m.winners[slot] = winningBid{blockHash: best.hash, relay: best.relay}
+	for s := range m.winners {
+		if s+winnersToKeep < slot {
+			delete(m.winners, s)
+		}
+	}
+	m.Unlock()
+	return best.bid, nil
+}
+
+// validRelayBid checks that a bid is signed by the builder and builds on top of the requested parent.
+func validRelayBid(signedBid builder.SignedBid, parentHash [32]byte) (*relayBid, error) {
+	if signedBid == nil || signedBid.IsNil() {
+		return nil, errors.New("nil builder bid")
+	}
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
+	}
+	if bid == nil || bid.IsNil() {
+		return nil, errors.New("nil builder bid")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid header")
+	}
+	if bytesutil.ToBytes32(header.ParentHash()) != parentHash {
+		return nil, errors.Errorf("bid parent hash %#x does not match requested parent hash %#x", header.ParentHash(), parentHash)
+	}
+	value := bid.Value()
+	if value == nil || (*big.Int)(value).Sign() <= 0 {
+		return nil, errors.New("bid value is not positive")
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder,
+		nil, /* fork version */
+		nil /* genesis val root */)
+	if err != nil {
+		return nil, err
+	}
+	if err := signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d); err != nil {
+		return nil, errors.Wrap(err, "invalid bid signature")
+	}
+	return &relayBid{bid: signedBid, value: value, hash: bytesutil.ToBytes32(header.BlockHash())}, nil
+}
+
+// RegisterValidator sends the registrations to all relays. It only fails if no relay accepted the registrations.
+func (m *multiRelayClient) RegisterValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(m.relays))
+	var wg sync.WaitGroup
+	for i, r := range m.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			start := time.Now()
+			err := r.client.RegisterValidator(ctx, svr)
+			relayLatency.WithLabelValues(r.name, "register_validator").Observe(float64(time.Since(start).Milliseconds()))
+			if err != nil {
+				relayErrors.WithLabelValues(r.name, "register_validator").Inc()
+				log.WithError(err).WithField("relay", r.name).Warn("Could not register validators with relay")
+				errs[i] = err
+			}
+		}(i, r)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Wrap(errs[0], "no relay accepted the validator registrations")
+}
+
+// SubmitBlindedBlock submits the blinded block to the relay which provided the winning bid for its slot.
+// If the relay is not known, for example after a restart, the block is submitted to all relays and the
+// first payload returned is used.
+func (m *multiRelayClient) SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	if sb == nil || sb.IsNil() {
+		return nil, nil, errors.New("nil blinded block")
+	}
+	header, err := sb.Block().Body().Execution()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get execution header")
+	}
+	m.Lock()
+	winner, ok := m.winners[sb.Block().Slot()]
+	m.Unlock()
+	if ok && winner.blockHash == bytesutil.ToBytes32(header.BlockHash()) {
+		return m.submitBlindedBlock(ctx, winner.relay, sb)
+	}
+
+	log.WithField("slot", sb.Block().Slot()).Warn("Relay of the winning bid is unknown, submitting blinded block to all relays")
+	type result struct {
+		payload interfaces.ExecutionData
+		bundle  *v1.BlobsBundle
+		err     error
+	}
+	results := make(chan result, len(m.relays))
+	for _, r := range m.relays {
+		go func(r *relay) {
+			payload, bundle, err := m.submitBlindedBlock(ctx, r, sb)
+			results <- result{payload: payload, bundle: bundle, err: err}
+		}(r)
+	}
+	var firstErr error
+	for range m.relays {
+		res := <-results
+		if res.err == nil {
+			return res.payload, res.bundle, nil
+		}
+		if firstErr == nil {
+			firstErr = res.err
+		}
+	}
+	return nil, nil, firstErr
+}
+
+func (m *multiRelayClient) submitBlindedBlock(ctx context.Context, r *relay, sb interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	start := time.Now()
+	payload, bundle, err := r.client.SubmitBlindedBlock(ctx, sb)
+	relayLatency.WithLabelValues(r.name, "submit_blinded_block").Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		relayErrors.WithLabelValues(r.name, "submit_blinded_block").Inc()
+		return nil, nil, errors.Wrapf(err, "could not submit blinded block to relay %s", r.name)
+	}
+	return payload, bundle, nil
+}
+
+// Status checks the status of all relays and updates their health. It only fails if every relay is down.
+func (m *multiRelayClient) Status(ctx context.Context) error {
+	errs := make([]error, len(m.relays))
+	var wg sync.WaitGroup
+	for i, r := range m.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			if err := r.client.Status(ctx); err != nil {
+				relayUp.WithLabelValues(r.name).Set(0)
+				log.WithError(err).WithField("relay", r.name).Warn("Relay is down")
+				errs[i] = err
+				return
+			}
+			relayUp.WithLabelValues(r.name).Set(1)
+		}(i, r)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Wrap(errs[0], "all relays are down")
+}
@@ -0,0 +1,185 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type fakeRelay struct {
+	url   string
+	bid   builder.SignedBid
+	err   error
+	delay time.Duration
+
+	sync.Mutex
+	registrations int
+	submissions   int
+}
+
+func (r *fakeRelay) NodeURL() string {
+	return r.url
+}
+
+func (r *fakeRelay) GetHeader(ctx context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.bid, r.err
+}
+
+func (r *fakeRelay) RegisterValidator(_ context.Context, _ []*ethpb.SignedValidatorRegistrationV1) error {
+	r.Lock()
+	defer r.Unlock()
+	r.registrations++
+	return r.err
+}
+
+func (r *fakeRelay) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	r.Lock()
+	defer r.Unlock()
+	r.submissions++
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	payload, err := blocks.WrappedExecutionPayloadCapella(&v1.ExecutionPayloadCapella{})
+	return payload, nil, err
+}
+
+func (r *fakeRelay) Status(_ context.Context) error {
+	return r.err
+}
+
+func signedBid(t *testing.T, parentHash, blockHash [32]byte, value int64) builder.SignedBid {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	bid := &ethpb.BuilderBidCapella{
+		Header: &v1.ExecutionPayloadHeaderCapella{
+			ParentHash:       parentHash[:],
+			FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+			StateRoot:        make([]byte, fieldparams.RootLength),
+			ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+			LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+			PrevRandao:       make([]byte, fieldparams.RootLength),
+			ExtraData:        make([]byte, 0),
+			BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+			BlockHash:        blockHash[:],
+			TransactionsRoot: make([]byte, fieldparams.RootLength),
+			WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+		},
+		Pubkey: sk.PublicKey().Marshal(),
+		Value:  bytesutil.PadTo(bytesutil.ReverseByteOrder(big.NewInt(value).Bytes()), 32),
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	sr, err := signing.ComputeSigningRoot(bid, domain)
+	require.NoError(t, err)
+	sBid, err := builder.WrappedSignedBuilderBidCapella(&ethpb.SignedBuilderBidCapella{
+		Message:   bid,
+		Signature: sk.Sign(sr[:]).Marshal(),
+	})
+	require.NoError(t, err)
+	return sBid
+}
+
+func blindedBlock(t *testing.T, slot primitives.Slot, blockHash [32]byte) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockCapella()
+	b.Block.Slot = slot
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = blockHash[:]
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return sb
+}
+
+func TestMultiRelayClient_GetHeader(t *testing.T) {
+	parentHash := [32]byte{'p'}
+	low := &fakeRelay{url: "https://0xaa@low.example.com", bid: signedBid(t, parentHash, [32]byte{'a'}, 100)}
+	high := &fakeRelay{url: "https://0xbb@high.example.com", bid: signedBid(t, parentHash, [32]byte{'b'}, 200)}
+	wrongParent := &fakeRelay{url: "https://wrong.example.com", bid: signedBid(t, [32]byte{'x'}, [32]byte{'c'}, 1000)}
+	slow := &fakeRelay{url: "https://slow.example.com", bid: signedBid(t, parentHash, [32]byte{'d'}, 2000), delay: time.Second}
+	failing := &fakeRelay{url: "https://failing.example.com", err: errors.New("bad gateway")}
+
+	m, err := newMultiRelayClient([]builder.BuilderClient{low, high, wrongParent, slow, failing}, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "low.example.com,high.example.com,wrong.example.com,slow.example.com,failing.example.com", m.NodeURL())
+
+	bid, err := m.GetHeader(context.Background(), 10, parentHash, [48]byte{})
+	require.NoError(t, err)
+	assert.Equal(t, high.bid, bid)
+
+	// The blinded block of the winning bid is only submitted to the relay of the bid.
+	_, _, err = m.SubmitBlindedBlock(context.Background(), blindedBlock(t, 10, [32]byte{'b'}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, high.submissions)
+	assert.Equal(t, 0, low.submissions)
+
+	// Without a known winning bid, the blinded block is submitted to all relays and the first payload is used.
+	m, err = newMultiRelayClient([]builder.BuilderClient{failing, low}, 100*time.Millisecond)
+	require.NoError(t, err)
+	_, _, err = m.SubmitBlindedBlock(context.Background(), blindedBlock(t, 11, [32]byte{'b'}))
+	require.NoError(t, err)
+	low.Lock()
+	assert.Equal(t, 1, low.submissions)
+	low.Unlock()
+}
+
+func TestMultiRelayClient_GetHeader_AllowedRelays(t *testing.T) {
+	parentHash := [32]byte{'p'}
+	low := &fakeRelay{url: "https://0xaa@low.example.com", bid: signedBid(t, parentHash, [32]byte{'a'}, 100)}
+	high := &fakeRelay{url: "https://0xbb@high.example.com", bid: signedBid(t, parentHash, [32]byte{'b'}, 200)}
+	m, err := newMultiRelayClient([]builder.BuilderClient{low, high}, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	ctx := WithAllowedRelays(context.Background(), []string{"https://0xaa@low.example.com"})
+	bid, err := m.GetHeader(ctx, 10, parentHash, [48]byte{})
+	require.NoError(t, err)
+	assert.Equal(t, low.bid, bid)
+
+	ctx = WithAllowedRelays(context.Background(), []string{"other.example.com"})
+	_, err = m.GetHeader(ctx, 10, parentHash, [48]byte{})
+	require.ErrorIs(t, err, errNoAllowedRelays)
+}
+
+func TestMultiRelayClient_GetHeader_NoBids(t *testing.T) {
+	failing := &fakeRelay{url: "https://failing.example.com", err: errors.New("bad gateway")}
+	m, err := newMultiRelayClient([]builder.BuilderClient{failing}, 0)
+	require.NoError(t, err)
+	_, err = m.GetHeader(context.Background(), 10, [32]byte{}, [48]byte{})
+	require.ErrorIs(t, err, errNoBids)
+}
+
+func TestMultiRelayClient_RegisterValidator(t *testing.T) {
+	ok := &fakeRelay{url: "https://ok.example.com"}
+	failing := &fakeRelay{url: "https://failing.example.com", err: errors.New("bad gateway")}
+	m, err := newMultiRelayClient([]builder.BuilderClient{ok, failing}, 0)
+	require.NoError(t, err)
+	require.NoError(t, m.RegisterValidator(context.Background(), []*ethpb.SignedValidatorRegistrationV1{}))
+	assert.Equal(t, 1, ok.registrations)
+	assert.Equal(t, 1, failing.registrations)
+	require.NoError(t, m.Status(context.Background()))
+
+	m, err = newMultiRelayClient([]builder.BuilderClient{failing}, 0)
+	require.NoError(t, err)
+	require.ErrorContains(t, "bad gateway", m.RegisterValidator(context.Background(), []*ethpb.SignedValidatorRegistrationV1{}))
+	require.ErrorContains(t, "all relays are down", m.Status(context.Background()))
+}
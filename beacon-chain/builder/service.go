@@ -115,6 +115,12 @@ func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHas
 		return nil, ErrNoBuilder
 	}
 
+	// A single relay is not wrapped by the multi relay client, which checks the allowed relays itself.
+	if _, ok := s.c.(*multiRelayClient); !ok && !relayAllowed(ctx, relayName(s.c.NodeURL())) {
+		tracing.AnnotateError(span, errNoAllowedRelays)
+		return nil, errNoAllowedRelays
+	}
+
 	h, err := s.c.GetHeader(ctx, slot, parentHash, pubKey)
 	tracing.AnnotateError(span, err)
 	return h, err
@@ -208,6 +208,21 @@ func BeaconCommittee(
 	return ComputeCommittee(validatorIndices, seed, indexOffset, count)
 }
 
+// CachedBeaconCommittee returns the beacon committee of the given slot and committee index from the committee cache,
+// or nil if the committees of the seed are not cached.
+func CachedBeaconCommittee(
+	ctx context.Context,
+	seed [32]byte,
+	slot primitives.Slot,
+	committeeIndex primitives.CommitteeIndex,
+) ([]primitives.ValidatorIndex, error) {
+	committee, err := committeeCache.Committee(ctx, slot, seed, committeeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not interface with committee cache")
+	}
+	return committee, nil
+}
+
 // CommitteeAssignment represents committee list, committee index, and to be attested slot for a given epoch.
 type CommitteeAssignment struct {
 	Committee      []primitives.ValidatorIndex
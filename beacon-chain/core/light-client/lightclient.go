@@ -12,10 +12,12 @@ import (
 	consensus_types "github.com/prysmaticlabs/prysm/v5/consensus-types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	v11 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/proto/migration"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
@@ -78,6 +80,63 @@ func NewLightClientOptimisticUpdateFromBeaconState(
 	return createLightClientOptimisticUpdate(update), nil
 }
 
+// NewLightClientBootstrapFromBeaconState creates the light client bootstrap for the block from its post state.
+func NewLightClientBootstrapFromBeaconState(
+	ctx context.Context,
+	state state.BeaconState,
+	block interfaces.ReadOnlySignedBeaconBlock,
+) (*ethpbv2.LightClientBootstrap, error) {
+	// assert compute_epoch_at_slot(state.slot) >= ALTAIR_FORK_EPOCH
+	if slots.ToEpoch(state.Slot()) < params.BeaconConfig().AltairForkEpoch {
+		return nil, fmt.Errorf("light client bootstrap is not supported before Altair, invalid slot %d", state.Slot())
+	}
+
+	// assert state.slot == state.latest_block_header.slot
+	latestBlockHeader := state.LatestBlockHeader()
+	if state.Slot() != latestBlockHeader.Slot {
+		return nil, fmt.Errorf("state slot %d not equal to latest block header slot %d", state.Slot(), latestBlockHeader.Slot)
+	}
+
+	// header.state_root = hash_tree_root(state)
+	stateRoot, err := state.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state root")
+	}
+	latestBlockHeader.StateRoot = stateRoot[:]
+
+	// assert hash_tree_root(header) == hash_tree_root(block.message)
+	latestBlockHeaderRoot, err := latestBlockHeader.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get latest block header root")
+	}
+	blockRoot, err := block.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block root")
+	}
+	if latestBlockHeaderRoot != blockRoot {
+		return nil, fmt.Errorf("latest block header root %#x not equal to block root %#x", latestBlockHeaderRoot, blockRoot)
+	}
+
+	header, err := BlockToLightClientHeader(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert block to light client header")
+	}
+	currentSyncCommittee, err := state.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	currentSyncCommitteeProof, err := state.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee proof")
+	}
+
+	return &ethpbv2.LightClientBootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       migration.V1Alpha1SyncCommitteeToV2(currentSyncCommittee),
+		CurrentSyncCommitteeBranch: currentSyncCommitteeProof,
+	}, nil
+}
+
 func NewLightClientUpdateFromBeaconState(
 	ctx context.Context,
 	state state.BeaconState,
@@ -233,6 +292,94 @@ func NewLightClientUpdateFromBeaconState(
 	return result, nil
 }
 
+// IsBetterUpdate reports whether the new light client update is better than the old one for the same sync
+// committee period, following is_better_update of the light client sync protocol.
+func IsBetterUpdate(newUpdate, oldUpdate *ethpbv2.LightClientUpdate) bool {
+	// Compare supermajority (> 2/3) sync committee participation
+	maxActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Len()
+	newNumActiveParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldNumActiveParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Compare presence of relevant sync committee
+	newHasRelevantSyncCommittee := isSyncCommitteeUpdate(newUpdate) &&
+		periodAtSlot(attestedSlot(newUpdate)) == periodAtSlot(newUpdate.SignatureSlot)
+	oldHasRelevantSyncCommittee := isSyncCommitteeUpdate(oldUpdate) &&
+		periodAtSlot(attestedSlot(oldUpdate)) == periodAtSlot(oldUpdate.SignatureSlot)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	// Compare indication of any finality
+	newHasFinality := isFinalityUpdate(newUpdate)
+	oldHasFinality := isFinalityUpdate(oldUpdate)
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// Compare sync committee finality
+	if newHasFinality {
+		newHasSyncCommitteeFinality := periodAtSlot(finalizedSlot(newUpdate)) == periodAtSlot(attestedSlot(newUpdate))
+		oldHasSyncCommitteeFinality := periodAtSlot(finalizedSlot(oldUpdate)) == periodAtSlot(attestedSlot(oldUpdate))
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Tiebreaker 2: Prefer older data (fewer changes to best)
+	if attestedSlot(newUpdate) != attestedSlot(oldUpdate) {
+		return attestedSlot(newUpdate) < attestedSlot(oldUpdate)
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+func isSyncCommitteeUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isZeroBranch(update.NextSyncCommitteeBranch)
+}
+
+func isFinalityUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isZeroBranch(update.FinalityBranch)
+}
+
+func isZeroBranch(branch [][]byte) bool {
+	for _, leaf := range branch {
+		if !bytes.Equal(leaf, make([]byte, fieldparams.RootLength)) {
+			return false
+		}
+	}
+	return true
+}
+
+func periodAtSlot(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+func attestedSlot(update *ethpbv2.LightClientUpdate) primitives.Slot {
+	if header := beaconHeader(update.AttestedHeader); header != nil {
+		return header.Slot
+	}
+	return 0
+}
+
+func finalizedSlot(update *ethpbv2.LightClientUpdate) primitives.Slot {
+	if header := beaconHeader(update.FinalizedHeader); header != nil {
+		return header.Slot
+	}
+	return 0
+}
+
 func createDefaultLightClientUpdate() (*ethpbv2.LightClientUpdate, error) {
 	syncCommitteeSize := params.BeaconConfig().SyncCommitteeSize
 	pubKeys := make([][]byte, syncCommitteeSize)
@@ -4,11 +4,16 @@ import (
 	"testing"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	consensustypes "github.com/prysmaticlabs/prysm/v5/consensus-types"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
 	v11 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
 
 	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -746,3 +751,101 @@ func TestLightClient_BlockToLightClientHeader(t *testing.T) {
 		})
 	})
 }
+
+func TestLightClient_IsBetterUpdate(t *testing.T) {
+	slotsPerPeriod := primitives.Slot(params.BeaconConfig().EpochsPerSyncCommitteePeriod) * params.BeaconConfig().SlotsPerEpoch
+	newUpdate := func(participants uint64, attested, signature primitives.Slot, syncCommittee, finality bool) *ethpbv2.LightClientUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		branch := func(set bool, depth int) [][]byte {
+			b := make([][]byte, depth)
+			for i := range b {
+				b[i] = make([]byte, fieldparams.RootLength)
+			}
+			if set {
+				b[0][0] = 1
+			}
+			return b
+		}
+		return &ethpbv2.LightClientUpdate{
+			AttestedHeader: &ethpbv2.LightClientHeaderContainer{
+				Header: &ethpbv2.LightClientHeaderContainer_HeaderAltair{
+					HeaderAltair: &ethpbv2.LightClientHeader{Beacon: &ethpbv1.BeaconBlockHeader{Slot: attested}},
+				},
+			},
+			FinalizedHeader: &ethpbv2.LightClientHeaderContainer{
+				Header: &ethpbv2.LightClientHeaderContainer_HeaderAltair{
+					HeaderAltair: &ethpbv2.LightClientHeader{Beacon: &ethpbv1.BeaconBlockHeader{Slot: attested - 64}},
+				},
+			},
+			NextSyncCommitteeBranch: branch(syncCommittee, fieldparams.SyncCommitteeBranchDepth),
+			FinalityBranch:          branch(finality, fieldparams.FinalityBranchDepth),
+			SyncAggregate:           &ethpbv1.SyncAggregate{SyncCommitteeBits: bits},
+			SignatureSlot:           signature,
+		}
+	}
+	attested := slotsPerPeriod + 100
+
+	tests := []struct {
+		name      string
+		newUpdate *ethpbv2.LightClientUpdate
+		oldUpdate *ethpbv2.LightClientUpdate
+		want      bool
+	}{
+		{
+			name:      "supermajority",
+			newUpdate: newUpdate(400, attested, attested+1, false, false),
+			oldUpdate: newUpdate(300, attested, attested+1, true, true),
+			want:      true,
+		},
+		{
+			name:      "more participants without supermajority",
+			newUpdate: newUpdate(200, attested, attested+1, false, false),
+			oldUpdate: newUpdate(300, attested, attested+1, true, true),
+			want:      false,
+		},
+		{
+			name:      "relevant sync committee",
+			newUpdate: newUpdate(400, attested, attested+1, true, false),
+			oldUpdate: newUpdate(500, attested, attested+1, false, true),
+			want:      true,
+		},
+		{
+			name:      "sync committee of other period is not relevant",
+			newUpdate: newUpdate(400, attested, 2*slotsPerPeriod, true, false),
+			oldUpdate: newUpdate(400, attested, attested+1, false, false),
+			want:      false,
+		},
+		{
+			name:      "finality",
+			newUpdate: newUpdate(400, attested, attested+1, true, true),
+			oldUpdate: newUpdate(500, attested, attested+1, true, false),
+			want:      true,
+		},
+		{
+			name:      "participation beyond supermajority",
+			newUpdate: newUpdate(500, attested, attested+1, true, true),
+			oldUpdate: newUpdate(400, attested, attested+1, true, true),
+			want:      true,
+		},
+		{
+			name:      "older attested header",
+			newUpdate: newUpdate(400, attested+1, attested+2, true, true),
+			oldUpdate: newUpdate(400, attested, attested+2, true, true),
+			want:      false,
+		},
+		{
+			name:      "older signature slot",
+			newUpdate: newUpdate(400, attested, attested+1, true, true),
+			oldUpdate: newUpdate(400, attested, attested+2, true, true),
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, lightClient.IsBetterUpdate(tt.newUpdate, tt.oldUpdate))
+		})
+	}
+}
@@ -0,0 +1,511 @@
+package light_client
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/proto/migration"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// The light client data forks. The data types change with the header of the attested block,
+// which gains an execution payload header in Capella and blob gas fields in Deneb.
+const (
+	dataForkAltair = iota
+	dataForkCapella
+	dataForkDeneb
+	dataForkElectra
+)
+
+// BootstrapToProto converts the light client bootstrap to the SSZ serializable message of the
+// header's fork, as served over the req/resp protocols.
+func BootstrapToProto(bootstrap *ethpbv2.LightClientBootstrap) (proto.Message, error) {
+	if bootstrap == nil || bootstrap.Header == nil {
+		return nil, errors.New("nil light client bootstrap")
+	}
+	fork, err := dataFork(bootstrap.Header)
+	if err != nil {
+		return nil, err
+	}
+	committee := syncCommitteeToProto(bootstrap.CurrentSyncCommittee)
+	switch fork {
+	case dataForkAltair:
+		branch, err := branchOfDepth(bootstrap.CurrentSyncCommitteeBranch, fieldparams.SyncCommitteeBranchDepth)
+		if err != nil {
+			return nil, errors.Wrap(err, "current sync committee branch")
+		}
+		return &ethpb.LightClientBootstrapAltair{
+			Header:                     headerAltair(bootstrap.Header),
+			CurrentSyncCommittee:       committee,
+			CurrentSyncCommitteeBranch: branch,
+		}, nil
+	case dataForkCapella:
+		branch, err := branchOfDepth(bootstrap.CurrentSyncCommitteeBranch, fieldparams.SyncCommitteeBranchDepth)
+		if err != nil {
+			return nil, errors.Wrap(err, "current sync committee branch")
+		}
+		header, err := headerCapella(bootstrap.Header)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientBootstrapCapella{
+			Header:                     header,
+			CurrentSyncCommittee:       committee,
+			CurrentSyncCommitteeBranch: branch,
+		}, nil
+	case dataForkDeneb:
+		branch, err := branchOfDepth(bootstrap.CurrentSyncCommitteeBranch, fieldparams.SyncCommitteeBranchDepth)
+		if err != nil {
+			return nil, errors.Wrap(err, "current sync committee branch")
+		}
+		header, err := headerDeneb(bootstrap.Header)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientBootstrapDeneb{
+			Header:                     header,
+			CurrentSyncCommittee:       committee,
+			CurrentSyncCommitteeBranch: branch,
+		}, nil
+	default:
+		branch, err := branchOfDepth(bootstrap.CurrentSyncCommitteeBranch, fieldparams.SyncCommitteeBranchDepthElectra)
+		if err != nil {
+			return nil, errors.Wrap(err, "current sync committee branch")
+		}
+		header, err := headerDeneb(bootstrap.Header)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientBootstrapElectra{
+			Header:                     header,
+			CurrentSyncCommittee:       committee,
+			CurrentSyncCommitteeBranch: branch,
+		}, nil
+	}
+}
+
+// UpdateToProto converts the light client update to the SSZ serializable message of the
+// attested header's fork, as served over the req/resp protocols.
+func UpdateToProto(update *ethpbv2.LightClientUpdate) (proto.Message, error) {
+	if update == nil || update.AttestedHeader == nil {
+		return nil, errors.New("nil light client update")
+	}
+	fork, err := dataFork(update.AttestedHeader)
+	if err != nil {
+		return nil, err
+	}
+	finalityBranch, err := branchOfDepth(update.FinalityBranch, fieldparams.FinalityBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "finality branch")
+	}
+	nextSyncCommitteeBranchDepth := fieldparams.SyncCommitteeBranchDepth
+	if fork == dataForkElectra {
+		nextSyncCommitteeBranchDepth = fieldparams.SyncCommitteeBranchDepthElectra
+	}
+	nextSyncCommitteeBranch, err := branchOfDepth(update.NextSyncCommitteeBranch, nextSyncCommitteeBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "next sync committee branch")
+	}
+	committee := syncCommitteeToProto(update.NextSyncCommittee)
+	aggregate := syncAggregateToProto(update.SyncAggregate)
+
+	switch fork {
+	case dataForkAltair:
+		return &ethpb.LightClientUpdateAltair{
+			AttestedHeader:          headerAltair(update.AttestedHeader),
+			NextSyncCommittee:       committee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         headerAltair(update.FinalizedHeader),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           aggregate,
+			SignatureSlot:           update.SignatureSlot,
+		}, nil
+	case dataForkCapella:
+		attested, finalized, err := headersCapella(update.AttestedHeader, update.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientUpdateCapella{
+			AttestedHeader:          attested,
+			NextSyncCommittee:       committee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalized,
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           aggregate,
+			SignatureSlot:           update.SignatureSlot,
+		}, nil
+	case dataForkDeneb:
+		attested, finalized, err := headersDeneb(update.AttestedHeader, update.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientUpdateDeneb{
+			AttestedHeader:          attested,
+			NextSyncCommittee:       committee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalized,
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           aggregate,
+			SignatureSlot:           update.SignatureSlot,
+		}, nil
+	default:
+		attested, finalized, err := headersDeneb(update.AttestedHeader, update.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientUpdateElectra{
+			AttestedHeader:          attested,
+			NextSyncCommittee:       committee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalized,
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           aggregate,
+			SignatureSlot:           update.SignatureSlot,
+		}, nil
+	}
+}
+
+// FinalityUpdateToProto converts the light client finality update to the SSZ serializable message of the
+// attested header's fork, as served over the req/resp protocols and gossiped.
+func FinalityUpdateToProto(update *ethpbv2.LightClientFinalityUpdate) (proto.Message, error) {
+	if update == nil || update.AttestedHeader == nil {
+		return nil, errors.New("nil light client finality update")
+	}
+	fork, err := dataFork(update.AttestedHeader)
+	if err != nil {
+		return nil, err
+	}
+	finalityBranch, err := branchOfDepth(update.FinalityBranch, fieldparams.FinalityBranchDepth)
+	if err != nil {
+		return nil, errors.Wrap(err, "finality branch")
+	}
+	aggregate := syncAggregateToProto(update.SyncAggregate)
+
+	switch fork {
+	case dataForkAltair:
+		return &ethpb.LightClientFinalityUpdateAltair{
+			AttestedHeader:  headerAltair(update.AttestedHeader),
+			FinalizedHeader: headerAltair(update.FinalizedHeader),
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   aggregate,
+			SignatureSlot:   update.SignatureSlot,
+		}, nil
+	case dataForkCapella:
+		attested, finalized, err := headersCapella(update.AttestedHeader, update.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientFinalityUpdateCapella{
+			AttestedHeader:  attested,
+			FinalizedHeader: finalized,
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   aggregate,
+			SignatureSlot:   update.SignatureSlot,
+		}, nil
+	default:
+		attested, finalized, err := headersDeneb(update.AttestedHeader, update.FinalizedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientFinalityUpdateDeneb{
+			AttestedHeader:  attested,
+			FinalizedHeader: finalized,
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   aggregate,
+			SignatureSlot:   update.SignatureSlot,
+		}, nil
+	}
+}
+
+// OptimisticUpdateToProto converts the light client optimistic update to the SSZ serializable message of the
+// attested header's fork, as served over the req/resp protocols and gossiped.
+func OptimisticUpdateToProto(update *ethpbv2.LightClientOptimisticUpdate) (proto.Message, error) {
+	if update == nil || update.AttestedHeader == nil {
+		return nil, errors.New("nil light client optimistic update")
+	}
+	fork, err := dataFork(update.AttestedHeader)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := syncAggregateToProto(update.SyncAggregate)
+
+	switch fork {
+	case dataForkAltair:
+		return &ethpb.LightClientOptimisticUpdateAltair{
+			AttestedHeader: headerAltair(update.AttestedHeader),
+			SyncAggregate:  aggregate,
+			SignatureSlot:  update.SignatureSlot,
+		}, nil
+	case dataForkCapella:
+		attested, err := headerCapella(update.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientOptimisticUpdateCapella{
+			AttestedHeader: attested,
+			SyncAggregate:  aggregate,
+			SignatureSlot:  update.SignatureSlot,
+		}, nil
+	default:
+		attested, err := headerDeneb(update.AttestedHeader)
+		if err != nil {
+			return nil, err
+		}
+		return &ethpb.LightClientOptimisticUpdateDeneb{
+			AttestedHeader: attested,
+			SyncAggregate:  aggregate,
+			SignatureSlot:  update.SignatureSlot,
+		}, nil
+	}
+}
+
+// dataFork returns the light client data fork of the header, which determines the types of the messages it is
+// sent in.
+func dataFork(container *ethpbv2.LightClientHeaderContainer) (int, error) {
+	switch h := container.Header.(type) {
+	case *ethpbv2.LightClientHeaderContainer_HeaderAltair:
+		return dataForkAltair, nil
+	case *ethpbv2.LightClientHeaderContainer_HeaderCapella:
+		return dataForkCapella, nil
+	case *ethpbv2.LightClientHeaderContainer_HeaderDeneb:
+		if h.HeaderDeneb.Beacon != nil && slots.ToEpoch(h.HeaderDeneb.Beacon.Slot) >= params.BeaconConfig().ElectraForkEpoch {
+			return dataForkElectra, nil
+		}
+		return dataForkDeneb, nil
+	default:
+		return 0, fmt.Errorf("unsupported light client header type %T", h)
+	}
+}
+
+func beaconHeader(container *ethpbv2.LightClientHeaderContainer) *ethpbv1.BeaconBlockHeader {
+	if container == nil {
+		return nil
+	}
+	switch h := container.Header.(type) {
+	case *ethpbv2.LightClientHeaderContainer_HeaderAltair:
+		return h.HeaderAltair.Beacon
+	case *ethpbv2.LightClientHeaderContainer_HeaderCapella:
+		return h.HeaderCapella.Beacon
+	case *ethpbv2.LightClientHeaderContainer_HeaderDeneb:
+		return h.HeaderDeneb.Beacon
+	default:
+		return nil
+	}
+}
+
+func beaconHeaderToProto(header *ethpbv1.BeaconBlockHeader) *ethpb.BeaconBlockHeader {
+	if header == nil {
+		return &ethpb.BeaconBlockHeader{
+			ParentRoot: make([]byte, fieldparams.RootLength),
+			StateRoot:  make([]byte, fieldparams.RootLength),
+			BodyRoot:   make([]byte, fieldparams.RootLength),
+		}
+	}
+	return migration.V1HeaderToV1Alpha1(header)
+}
+
+// headerAltair converts the header, which is empty when the container is nil, e.g. for the finalized header of
+// an update without finality.
+func headerAltair(container *ethpbv2.LightClientHeaderContainer) *ethpb.LightClientHeaderAltair {
+	return &ethpb.LightClientHeaderAltair{Beacon: beaconHeaderToProto(beaconHeader(container))}
+}
+
+// headerCapella converts the header, upgrading an Altair header with an empty execution payload header.
+func headerCapella(container *ethpbv2.LightClientHeaderContainer) (*ethpb.LightClientHeaderCapella, error) {
+	header := &ethpb.LightClientHeaderCapella{
+		Beacon:          beaconHeaderToProto(beaconHeader(container)),
+		Execution:       emptyPayloadHeaderCapella(),
+		ExecutionBranch: emptyBranch(fieldparams.ExecutionBranchDepth),
+	}
+	if container == nil {
+		return header, nil
+	}
+	switch h := container.Header.(type) {
+	case *ethpbv2.LightClientHeaderContainer_HeaderAltair:
+	case *ethpbv2.LightClientHeaderContainer_HeaderCapella:
+		branch, err := executionBranch(h.HeaderCapella.ExecutionBranch)
+		if err != nil {
+			return nil, errors.Wrap(err, "execution branch")
+		}
+		if h.HeaderCapella.Execution != nil {
+			header.Execution = h.HeaderCapella.Execution
+		}
+		header.ExecutionBranch = branch
+	default:
+		return nil, fmt.Errorf("cannot convert light client header %T to Capella", h)
+	}
+	return header, nil
+}
+
+// headerDeneb converts the header, upgrading Altair and Capella headers.
+func headerDeneb(container *ethpbv2.LightClientHeaderContainer) (*ethpb.LightClientHeaderDeneb, error) {
+	header := &ethpb.LightClientHeaderDeneb{
+		Beacon:          beaconHeaderToProto(beaconHeader(container)),
+		Execution:       emptyPayloadHeaderDeneb(),
+		ExecutionBranch: emptyBranch(fieldparams.ExecutionBranchDepth),
+	}
+	if container == nil {
+		return header, nil
+	}
+	switch h := container.Header.(type) {
+	case *ethpbv2.LightClientHeaderContainer_HeaderAltair:
+	case *ethpbv2.LightClientHeaderContainer_HeaderCapella:
+		branch, err := executionBranch(h.HeaderCapella.ExecutionBranch)
+		if err != nil {
+			return nil, errors.Wrap(err, "execution branch")
+		}
+		if e := h.HeaderCapella.Execution; e != nil {
+			header.Execution = &enginev1.ExecutionPayloadHeaderDeneb{
+				ParentHash:       e.ParentHash,
+				FeeRecipient:     e.FeeRecipient,
+				StateRoot:        e.StateRoot,
+				ReceiptsRoot:     e.ReceiptsRoot,
+				LogsBloom:        e.LogsBloom,
+				PrevRandao:       e.PrevRandao,
+				BlockNumber:      e.BlockNumber,
+				GasLimit:         e.GasLimit,
+				GasUsed:          e.GasUsed,
+				Timestamp:        e.Timestamp,
+				ExtraData:        e.ExtraData,
+				BaseFeePerGas:    e.BaseFeePerGas,
+				BlockHash:        e.BlockHash,
+				TransactionsRoot: e.TransactionsRoot,
+				WithdrawalsRoot:  e.WithdrawalsRoot,
+			}
+		}
+		header.ExecutionBranch = branch
+	case *ethpbv2.LightClientHeaderContainer_HeaderDeneb:
+		branch, err := executionBranch(h.HeaderDeneb.ExecutionBranch)
+		if err != nil {
+			return nil, errors.Wrap(err, "execution branch")
+		}
+		if h.HeaderDeneb.Execution != nil {
+			header.Execution = h.HeaderDeneb.Execution
+		}
+		header.ExecutionBranch = branch
+	default:
+		return nil, fmt.Errorf("cannot convert light client header %T to Deneb", h)
+	}
+	return header, nil
+}
+
+func headersCapella(attested, finalized *ethpbv2.LightClientHeaderContainer) (*ethpb.LightClientHeaderCapella, *ethpb.LightClientHeaderCapella, error) {
+	attestedHeader, err := headerCapella(attested)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "attested header")
+	}
+	finalizedHeader, err := headerCapella(finalized)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "finalized header")
+	}
+	return attestedHeader, finalizedHeader, nil
+}
+
+func headersDeneb(attested, finalized *ethpbv2.LightClientHeaderContainer) (*ethpb.LightClientHeaderDeneb, *ethpb.LightClientHeaderDeneb, error) {
+	attestedHeader, err := headerDeneb(attested)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "attested header")
+	}
+	finalizedHeader, err := headerDeneb(finalized)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "finalized header")
+	}
+	return attestedHeader, finalizedHeader, nil
+}
+
+func syncCommitteeToProto(committee *ethpbv2.SyncCommittee) *ethpb.SyncCommittee {
+	if committee == nil {
+		pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+		for i := range pubkeys {
+			pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+		}
+		return &ethpb.SyncCommittee{
+			Pubkeys:         pubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		}
+	}
+	return migration.V2SyncCommitteeToV1Alpha1(committee)
+}
+
+func syncAggregateToProto(aggregate *ethpbv1.SyncAggregate) *ethpb.SyncAggregate {
+	if aggregate == nil {
+		return &ethpb.SyncAggregate{
+			SyncCommitteeBits:      make([]byte, fieldparams.SyncAggregateSyncCommitteeBytesLength),
+			SyncCommitteeSignature: make([]byte, fieldparams.BLSSignatureLength),
+		}
+	}
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      aggregate.SyncCommitteeBits,
+		SyncCommitteeSignature: aggregate.SyncCommitteeSignature,
+	}
+}
+
+// branchOfDepth checks the depth of the merkle branch. Missing and zero branches, which the update
+// functions use for absent data, are returned as zero branches of the expected depth.
+func branchOfDepth(branch [][]byte, depth int) ([][]byte, error) {
+	if len(branch) == depth {
+		return branch, nil
+	}
+	zero := make([]byte, fieldparams.RootLength)
+	for _, leaf := range branch {
+		if !bytes.Equal(leaf, zero) {
+			return nil, fmt.Errorf("branch has %d leaves instead of expected %d", len(branch), depth)
+		}
+	}
+	return emptyBranch(depth), nil
+}
+
+// executionBranch returns the proof of the execution payload header against the body root. Headers built from
+// blocks carry the proof against the block root, whose first leaves are the proof against the body root.
+func executionBranch(branch [][]byte) ([][]byte, error) {
+	if len(branch) > fieldparams.ExecutionBranchDepth {
+		branch = branch[:fieldparams.ExecutionBranchDepth]
+	}
+	return branchOfDepth(branch, fieldparams.ExecutionBranchDepth)
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func emptyPayloadHeaderCapella() *enginev1.ExecutionPayloadHeaderCapella {
+	return &enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       make([]byte, fieldparams.RootLength),
+		FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:        make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+		LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:       make([]byte, fieldparams.RootLength),
+		BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+		BlockHash:        make([]byte, fieldparams.RootLength),
+		TransactionsRoot: make([]byte, fieldparams.RootLength),
+		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptyPayloadHeaderDeneb() *enginev1.ExecutionPayloadHeaderDeneb {
+	return &enginev1.ExecutionPayloadHeaderDeneb{
+		ParentHash:       make([]byte, fieldparams.RootLength),
+		FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:        make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+		LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:       make([]byte, fieldparams.RootLength),
+		BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+		BlockHash:        make([]byte, fieldparams.RootLength),
+		TransactionsRoot: make([]byte, fieldparams.RootLength),
+		WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+	}
+}
@@ -0,0 +1,181 @@
+package light_client_test
+
+import (
+	"testing"
+
+	ssz "github.com/prysmaticlabs/fastssz"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestLightClient_NewLightClientBootstrapFromBeaconState(t *testing.T) {
+	t.Run("Altair", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestAltair()
+
+		bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.AttestedState, l.AttestedBlock)
+		require.NoError(t, err)
+		l.CheckAttestedHeader(bootstrap.Header)
+		require.Equal(t, fieldparams.SyncCommitteeBranchDepth, len(bootstrap.CurrentSyncCommitteeBranch))
+
+		msg, err := lightClient.BootstrapToProto(bootstrap)
+		require.NoError(t, err)
+		_, ok := msg.(*ethpb.LightClientBootstrapAltair)
+		require.Equal(t, true, ok)
+		_, err = msg.(ssz.Marshaler).MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("Capella", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestCapella(false)
+
+		bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.AttestedState, l.AttestedBlock)
+		require.NoError(t, err)
+		l.CheckAttestedHeader(bootstrap.Header)
+
+		msg, err := lightClient.BootstrapToProto(bootstrap)
+		require.NoError(t, err)
+		b, ok := msg.(*ethpb.LightClientBootstrapCapella)
+		require.Equal(t, true, ok)
+		executionRoot, err := b.Header.Execution.HashTreeRoot()
+		require.NoError(t, err)
+		// The execution payload header is field 9 of the block body.
+		assert.Equal(t, true, trie.VerifyMerkleProof(b.Header.Beacon.BodyRoot, executionRoot[:], 9, b.Header.ExecutionBranch))
+		_, err = b.MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("Deneb", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestDeneb(false)
+
+		bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.AttestedState, l.AttestedBlock)
+		require.NoError(t, err)
+		l.CheckAttestedHeader(bootstrap.Header)
+
+		msg, err := lightClient.BootstrapToProto(bootstrap)
+		require.NoError(t, err)
+		_, ok := msg.(*ethpb.LightClientBootstrapDeneb)
+		require.Equal(t, true, ok)
+		_, err = msg.(ssz.Marshaler).MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("state does not match block", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestAltair()
+
+		_, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State, l.AttestedBlock)
+		require.ErrorContains(t, "not equal to", err)
+	})
+}
+
+func TestLightClient_UpdateToProto(t *testing.T) {
+	t.Run("Altair", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestAltair()
+
+		update, err := lightClient.NewLightClientUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+		require.NoError(t, err)
+		msg, err := lightClient.UpdateToProto(update)
+		require.NoError(t, err)
+		u, ok := msg.(*ethpb.LightClientUpdateAltair)
+		require.Equal(t, true, ok)
+		assert.Equal(t, update.SignatureSlot, u.SignatureSlot)
+		assert.Equal(t, fieldparams.FinalityBranchDepth, len(u.FinalityBranch))
+		_, err = u.MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("Capella with Altair finalized header", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestCapellaFinalizedBlockAltair(false)
+
+		update, err := lightClient.NewLightClientUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+		require.NoError(t, err)
+		msg, err := lightClient.UpdateToProto(update)
+		require.NoError(t, err)
+		u, ok := msg.(*ethpb.LightClientUpdateCapella)
+		require.Equal(t, true, ok)
+		assert.DeepEqual(t, make([]byte, fieldparams.RootLength), u.FinalizedHeader.Execution.BlockHash)
+		assert.Equal(t, fieldparams.ExecutionBranchDepth, len(u.FinalizedHeader.ExecutionBranch))
+		_, err = u.MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("Deneb with Capella finalized header", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestDenebFinalizedBlockCapella(false)
+
+		update, err := lightClient.NewLightClientUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+		require.NoError(t, err)
+		msg, err := lightClient.UpdateToProto(update)
+		require.NoError(t, err)
+		u, ok := msg.(*ethpb.LightClientUpdateDeneb)
+		require.Equal(t, true, ok)
+		finalized := update.FinalizedHeader.GetHeaderCapella()
+		require.NotNil(t, finalized)
+		assert.DeepEqual(t, finalized.Execution.BlockHash, u.FinalizedHeader.Execution.BlockHash)
+		assert.Equal(t, uint64(0), u.FinalizedHeader.Execution.BlobGasUsed)
+		_, err = u.MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid branch", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestAltair()
+
+		update, err := lightClient.NewLightClientUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+		require.NoError(t, err)
+		update.FinalityBranch = [][]byte{{'a'}}
+		_, err = lightClient.UpdateToProto(update)
+		require.ErrorContains(t, "finality branch", err)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := lightClient.UpdateToProto(&ethpbv2.LightClientUpdate{})
+		require.ErrorContains(t, "nil light client update", err)
+	})
+}
+
+func TestLightClient_FinalityUpdateToProto(t *testing.T) {
+	t.Run("Capella", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestCapella(false)
+
+		update, err := lightClient.NewLightClientFinalityUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+		require.NoError(t, err)
+		msg, err := lightClient.FinalityUpdateToProto(update)
+		require.NoError(t, err)
+		u, ok := msg.(*ethpb.LightClientFinalityUpdateCapella)
+		require.Equal(t, true, ok)
+		assert.DeepEqual(t, update.AttestedHeader.GetHeaderCapella().Execution, u.AttestedHeader.Execution)
+		_, err = u.MarshalSSZ()
+		require.NoError(t, err)
+	})
+
+	t.Run("Deneb", func(t *testing.T) {
+		l := util.NewTestLightClient(t).SetupTestDeneb(false)
+
+		update, err := lightClient.NewLightClientFinalityUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+		require.NoError(t, err)
+		msg, err := lightClient.FinalityUpdateToProto(update)
+		require.NoError(t, err)
+		u, ok := msg.(*ethpb.LightClientFinalityUpdateDeneb)
+		require.Equal(t, true, ok)
+		_, err = u.MarshalSSZ()
+		require.NoError(t, err)
+	})
+}
+
+func TestLightClient_OptimisticUpdateToProto(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestAltair()
+
+	update, err := lightClient.NewLightClientOptimisticUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock)
+	require.NoError(t, err)
+	msg, err := lightClient.OptimisticUpdateToProto(update)
+	require.NoError(t, err)
+	u, ok := msg.(*ethpb.LightClientOptimisticUpdateAltair)
+	require.Equal(t, true, ok)
+	assert.Equal(t, update.SignatureSlot, u.SignatureSlot)
+	_, err = u.MarshalSSZ()
+	require.NoError(t, err)
+}
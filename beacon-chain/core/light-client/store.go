@@ -0,0 +1,600 @@
+package light_client
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Generalized indices of the fields proven by light client data.
+const (
+	executionPayloadGeneralizedIndex            = 25
+	currentSyncCommitteeGeneralizedIndex        = 54
+	nextSyncCommitteeGeneralizedIndex           = 55
+	currentSyncCommitteeGeneralizedIndexElectra = 86
+	nextSyncCommitteeGeneralizedIndexElectra    = 87
+	finalizedRootGeneralizedIndex               = 105
+)
+
+var (
+	ErrInvalidBootstrap         = errors.New("invalid light client bootstrap")
+	ErrInvalidUpdate            = errors.New("invalid light client update")
+	ErrInsufficientParticipants = errors.New("insufficient sync committee participants")
+)
+
+// Store tracks the finalized and optimistic headers of the chain from the light client data it processes,
+// following the light client sync protocol. It is not safe for concurrent use.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#lightclientstore
+type Store struct {
+	finalizedHeader               interfaces.LightClientHeader
+	currentSyncCommittee          *ethpb.SyncCommittee
+	nextSyncCommittee             *ethpb.SyncCommittee // nil until known
+	bestValidUpdate               *storeUpdate
+	optimisticHeader              interfaces.LightClientHeader
+	previousMaxActiveParticipants uint64
+	currentMaxActiveParticipants  uint64
+	genesisValidatorsRoot         [32]byte
+}
+
+// storeUpdate is a light client update of any kind, in which a nil finalized header or next sync committee
+// stands for an update that does not carry it.
+type storeUpdate struct {
+	attestedHeader          interfaces.LightClientHeader
+	nextSyncCommittee       *ethpb.SyncCommittee
+	nextSyncCommitteeBranch [][]byte
+	finalizedHeader         interfaces.LightClientHeader
+	finalityBranch          [][]byte
+	syncAggregate           *ethpb.SyncAggregate
+	signatureSlot           primitives.Slot
+}
+
+// NewStore initializes a light client store from a bootstrap of the trusted block root.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#initialize_light_client_store
+func NewStore(trustedBlockRoot [32]byte, bootstrap interfaces.LightClientBootstrap, genesisValidatorsRoot [32]byte) (*Store, error) {
+	if bootstrap == nil || bootstrap.Header() == nil || bootstrap.CurrentSyncCommittee() == nil {
+		return nil, errors.Wrap(ErrInvalidBootstrap, "nil bootstrap")
+	}
+	header := bootstrap.Header()
+	if err := verifyHeader(header); err != nil {
+		return nil, errors.Wrap(ErrInvalidBootstrap, err.Error())
+	}
+	headerRoot, err := header.Beacon().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if headerRoot != trustedBlockRoot {
+		return nil, errors.Wrapf(ErrInvalidBootstrap, "header root %#x does not match trusted block root %#x", headerRoot, trustedBlockRoot)
+	}
+
+	var branch [][]byte
+	gindex := uint64(currentSyncCommitteeGeneralizedIndex)
+	if bootstrap.Version() >= version.Electra {
+		b, err := bootstrap.CurrentSyncCommitteeBranchElectra()
+		if err != nil {
+			return nil, err
+		}
+		branch = branchToSlice(b[:])
+		gindex = currentSyncCommitteeGeneralizedIndexElectra
+	} else {
+		b, err := bootstrap.CurrentSyncCommitteeBranch()
+		if err != nil {
+			return nil, err
+		}
+		branch = branchToSlice(b[:])
+	}
+	committeeRoot, err := bootstrap.CurrentSyncCommittee().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if !trie.VerifyMerkleProof(header.Beacon().StateRoot, committeeRoot[:], gindex, branch) {
+		return nil, errors.Wrap(ErrInvalidBootstrap, "invalid current sync committee branch")
+	}
+
+	return &Store{
+		finalizedHeader:       header,
+		currentSyncCommittee:  bootstrap.CurrentSyncCommittee(),
+		optimisticHeader:      header,
+		genesisValidatorsRoot: genesisValidatorsRoot,
+	}, nil
+}
+
+// FinalizedHeader returns the latest finalized header known to the store.
+func (s *Store) FinalizedHeader() interfaces.LightClientHeader {
+	return s.finalizedHeader
+}
+
+// OptimisticHeader returns the latest header attested by a sufficient part of the sync committee.
+func (s *Store) OptimisticHeader() interfaces.LightClientHeader {
+	return s.optimisticHeader
+}
+
+// IsNextSyncCommitteeKnown reports whether the sync committee of the period after the finalized header's
+// period is known to the store.
+func (s *Store) IsNextSyncCommitteeKnown() bool {
+	return s.nextSyncCommittee != nil
+}
+
+// FinalizedPeriod returns the sync committee period of the finalized header.
+func (s *Store) FinalizedPeriod() uint64 {
+	return periodAtSlot(s.finalizedHeader.Beacon().Slot)
+}
+
+// ProcessUpdate validates the light client update and applies it to the store once it is final
+// enough to be.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_update
+func (s *Store) ProcessUpdate(update interfaces.LightClientUpdate, currentSlot primitives.Slot) error {
+	u, err := storeUpdateFromUpdate(update)
+	if err != nil {
+		return err
+	}
+	return s.processUpdate(u, currentSlot)
+}
+
+// ProcessFinalityUpdate validates the light client finality update and applies it to the store.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_finality_update
+func (s *Store) ProcessFinalityUpdate(update interfaces.LightClientFinalityUpdate, currentSlot primitives.Slot) error {
+	if update == nil || update.AttestedHeader() == nil || update.FinalizedHeader() == nil {
+		return errors.Wrap(ErrInvalidUpdate, "nil finality update")
+	}
+	finalityBranch := update.FinalityBranch()
+	return s.processUpdate(&storeUpdate{
+		attestedHeader:  update.AttestedHeader(),
+		finalizedHeader: update.FinalizedHeader(),
+		finalityBranch:  branchToSlice(finalityBranch[:]),
+		syncAggregate:   update.SyncAggregate(),
+		signatureSlot:   update.SignatureSlot(),
+	}, currentSlot)
+}
+
+// ProcessOptimisticUpdate validates the light client optimistic update and applies it to the store.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_optimistic_update
+func (s *Store) ProcessOptimisticUpdate(update interfaces.LightClientOptimisticUpdate, currentSlot primitives.Slot) error {
+	if update == nil || update.AttestedHeader() == nil {
+		return errors.Wrap(ErrInvalidUpdate, "nil optimistic update")
+	}
+	return s.processUpdate(&storeUpdate{
+		attestedHeader: update.AttestedHeader(),
+		syncAggregate:  update.SyncAggregate(),
+		signatureSlot:  update.SignatureSlot(),
+	}, currentSlot)
+}
+
+// ProcessForceUpdate applies the best valid update seen when no update could be finalized for a whole
+// sync committee period, so that the store does not get stuck. It returns true if the store was updated.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_store_force_update
+func (s *Store) ProcessForceUpdate(currentSlot primitives.Slot) (bool, error) {
+	updateTimeout := primitives.Slot(uint64(params.BeaconConfig().SlotsPerEpoch) * uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	if currentSlot <= s.finalizedHeader.Beacon().Slot+updateTimeout || s.bestValidUpdate == nil {
+		return false, nil
+	}
+	// Forced best update when there is no finality update in the period, the attested header is
+	// taken as finalized.
+	best := s.bestValidUpdate
+	if best.finalizedHeader == nil || best.finalizedHeader.Beacon().Slot <= s.finalizedHeader.Beacon().Slot {
+		best.finalizedHeader = best.attestedHeader
+	}
+	if err := s.applyUpdate(best); err != nil {
+		return false, err
+	}
+	s.bestValidUpdate = nil
+	return true, nil
+}
+
+func (s *Store) processUpdate(update *storeUpdate, currentSlot primitives.Slot) error {
+	if err := s.validateUpdate(update, currentSlot); err != nil {
+		return err
+	}
+
+	bits := update.syncAggregate.SyncCommitteeBits
+	if s.bestValidUpdate == nil || isBetterStoreUpdate(update, s.bestValidUpdate) {
+		s.bestValidUpdate = update
+	}
+	s.currentMaxActiveParticipants = max(s.currentMaxActiveParticipants, bits.Count())
+
+	// Update the optimistic header.
+	if bits.Count() > s.safetyThreshold() && update.attestedHeader.Beacon().Slot > s.optimisticHeader.Beacon().Slot {
+		s.optimisticHeader = update.attestedHeader
+	}
+
+	// Update the finalized header.
+	hasFinalizedNextSyncCommittee := !s.IsNextSyncCommitteeKnown() &&
+		update.isSyncCommitteeUpdate() && update.isFinalityUpdate() &&
+		periodAtSlot(update.finalizedHeader.Beacon().Slot) == periodAtSlot(update.attestedHeader.Beacon().Slot)
+	if bits.Count()*3 >= bits.Len()*2 &&
+		(update.finalizedSlot() > s.finalizedHeader.Beacon().Slot || hasFinalizedNextSyncCommittee) {
+		// Normal update through 2/3 threshold.
+		if err := s.applyUpdate(update); err != nil {
+			return err
+		}
+		s.bestValidUpdate = nil
+	}
+	return nil
+}
+
+// validateUpdate follows validate_light_client_update.
+func (s *Store) validateUpdate(update *storeUpdate, currentSlot primitives.Slot) error {
+	if update.attestedHeader == nil || update.syncAggregate == nil {
+		return errors.Wrap(ErrInvalidUpdate, "nil update")
+	}
+	// Verify sync committee has sufficient participants.
+	bits := update.syncAggregate.SyncCommitteeBits
+	if bits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return ErrInsufficientParticipants
+	}
+	if err := verifyHeader(update.attestedHeader); err != nil {
+		return errors.Wrapf(ErrInvalidUpdate, "attested header: %v", err)
+	}
+
+	// Verify update does not skip a sync committee period.
+	attestedSlot := update.attestedHeader.Beacon().Slot
+	finalizedSlot := update.finalizedSlot()
+	if currentSlot < update.signatureSlot || update.signatureSlot <= attestedSlot || attestedSlot < finalizedSlot {
+		return errors.Wrapf(ErrInvalidUpdate, "inconsistent slots: current %d, signature %d, attested %d, finalized %d",
+			currentSlot, update.signatureSlot, attestedSlot, finalizedSlot)
+	}
+	storePeriod := s.FinalizedPeriod()
+	signaturePeriod := periodAtSlot(update.signatureSlot)
+	if s.IsNextSyncCommitteeKnown() {
+		if signaturePeriod != storePeriod && signaturePeriod != storePeriod+1 {
+			return errors.Wrapf(ErrInvalidUpdate, "signature period %d is not the store period %d or the next one", signaturePeriod, storePeriod)
+		}
+	} else if signaturePeriod != storePeriod {
+		return errors.Wrapf(ErrInvalidUpdate, "signature period %d is not the store period %d", signaturePeriod, storePeriod)
+	}
+
+	// Verify update is relevant.
+	attestedPeriod := periodAtSlot(attestedSlot)
+	hasNextSyncCommittee := !s.IsNextSyncCommitteeKnown() && update.isSyncCommitteeUpdate() && attestedPeriod == storePeriod
+	if attestedSlot <= s.finalizedHeader.Beacon().Slot && !hasNextSyncCommittee {
+		return errors.Wrap(ErrInvalidUpdate, "update is not relevant")
+	}
+
+	// Verify that the finalized header, if present, actually is the finalized header saved in the
+	// state of the attested header.
+	if update.isFinalityUpdate() {
+		var finalizedRoot [32]byte
+		if finalizedSlot != params.BeaconConfig().GenesisSlot {
+			if err := verifyHeader(update.finalizedHeader); err != nil {
+				return errors.Wrapf(ErrInvalidUpdate, "finalized header: %v", err)
+			}
+			r, err := update.finalizedHeader.Beacon().HashTreeRoot()
+			if err != nil {
+				return err
+			}
+			finalizedRoot = r
+		}
+		if !verifyBranch(update.attestedHeader.Beacon().StateRoot, finalizedRoot, finalizedRootGeneralizedIndex, update.finalityBranch, fieldparams.FinalityBranchDepth) {
+			return errors.Wrap(ErrInvalidUpdate, "invalid finality branch")
+		}
+	}
+
+	// Verify that the next sync committee, if present, actually is the next sync committee saved in the
+	// state of the attested header.
+	if update.isSyncCommitteeUpdate() {
+		if attestedPeriod == storePeriod && s.IsNextSyncCommitteeKnown() {
+			if !syncCommitteesEqual(update.nextSyncCommittee, s.nextSyncCommittee) {
+				return errors.Wrap(ErrInvalidUpdate, "next sync committee does not match the known one")
+			}
+		}
+		committeeRoot, err := update.nextSyncCommittee.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		gindex, depth := uint64(nextSyncCommitteeGeneralizedIndex), fieldparams.SyncCommitteeBranchDepth
+		if len(update.nextSyncCommitteeBranch) == fieldparams.SyncCommitteeBranchDepthElectra {
+			gindex, depth = nextSyncCommitteeGeneralizedIndexElectra, fieldparams.SyncCommitteeBranchDepthElectra
+		}
+		if !verifyBranch(update.attestedHeader.Beacon().StateRoot, committeeRoot, gindex, update.nextSyncCommitteeBranch, depth) {
+			return errors.Wrap(ErrInvalidUpdate, "invalid next sync committee branch")
+		}
+	}
+
+	// Verify sync committee aggregate signature.
+	committee := s.currentSyncCommittee
+	if signaturePeriod != storePeriod {
+		committee = s.nextSyncCommittee
+	}
+	if err := s.verifySyncAggregate(committee, update); err != nil {
+		return err
+	}
+	return nil
+}
+
+// applyUpdate follows apply_light_client_update.
+func (s *Store) applyUpdate(update *storeUpdate) error {
+	storePeriod := s.FinalizedPeriod()
+	finalizedPeriod := periodAtSlot(update.finalizedSlot())
+	if !s.IsNextSyncCommitteeKnown() {
+		if finalizedPeriod != storePeriod {
+			return errors.Wrapf(ErrInvalidUpdate, "finalized period %d is not the store period %d", finalizedPeriod, storePeriod)
+		}
+		s.nextSyncCommittee = update.nextSyncCommittee
+	} else if finalizedPeriod == storePeriod+1 {
+		s.currentSyncCommittee = s.nextSyncCommittee
+		s.nextSyncCommittee = update.nextSyncCommittee
+		s.previousMaxActiveParticipants = s.currentMaxActiveParticipants
+		s.currentMaxActiveParticipants = 0
+	}
+	if update.finalizedSlot() > s.finalizedHeader.Beacon().Slot {
+		s.finalizedHeader = update.finalizedHeader
+		if s.finalizedHeader.Beacon().Slot > s.optimisticHeader.Beacon().Slot {
+			s.optimisticHeader = s.finalizedHeader
+		}
+	}
+	return nil
+}
+
+func (s *Store) safetyThreshold() uint64 {
+	return (s.previousMaxActiveParticipants + s.currentMaxActiveParticipants) / 2
+}
+
+func (s *Store) verifySyncAggregate(committee *ethpb.SyncCommittee, update *storeUpdate) error {
+	bits := update.syncAggregate.SyncCommitteeBits
+	if committee == nil || uint64(len(committee.Pubkeys)) != bits.Len() {
+		return errors.Wrap(ErrInvalidUpdate, "sync committee does not match the sync aggregate")
+	}
+	pubkeys := make([]bls.PublicKey, 0, bits.Count())
+	for _, i := range bits.BitIndices() {
+		pubkey, err := bls.PublicKeyFromBytes(committee.Pubkeys[i])
+		if err != nil {
+			return errors.Wrapf(err, "could not decode public key of sync committee member %d", i)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	forkVersionSlot := max(update.signatureSlot, 1) - 1
+	fork, err := forks.Fork(slots.ToEpoch(forkVersionSlot))
+	if err != nil {
+		return err
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, s.genesisValidatorsRoot[:])
+	if err != nil {
+		return err
+	}
+	signingRoot, err := signing.ComputeSigningRoot(update.attestedHeader.Beacon(), domain)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(update.syncAggregate.SyncCommitteeSignature)
+	if err != nil {
+		return errors.Wrap(err, "could not decode sync committee signature")
+	}
+	if !sig.FastAggregateVerify(pubkeys, signingRoot) {
+		return errors.Wrap(ErrInvalidUpdate, "invalid sync committee signature")
+	}
+	return nil
+}
+
+func storeUpdateFromUpdate(update interfaces.LightClientUpdate) (*storeUpdate, error) {
+	if update == nil || update.AttestedHeader() == nil {
+		return nil, errors.Wrap(ErrInvalidUpdate, "nil update")
+	}
+	u := &storeUpdate{
+		attestedHeader: update.AttestedHeader(),
+		syncAggregate:  update.SyncAggregate(),
+		signatureSlot:  update.SignatureSlot(),
+	}
+	finalityBranch := update.FinalityBranch()
+	if !isZeroBranch(branchToSlice(finalityBranch[:])) {
+		u.finalizedHeader = update.FinalizedHeader()
+		u.finalityBranch = branchToSlice(finalityBranch[:])
+	}
+	var nextSyncCommitteeBranch [][]byte
+	if update.Version() >= version.Electra {
+		b, err := update.NextSyncCommitteeBranchElectra()
+		if err != nil {
+			return nil, err
+		}
+		nextSyncCommitteeBranch = branchToSlice(b[:])
+	} else {
+		b, err := update.NextSyncCommitteeBranch()
+		if err != nil {
+			return nil, err
+		}
+		nextSyncCommitteeBranch = branchToSlice(b[:])
+	}
+	if !isZeroBranch(nextSyncCommitteeBranch) {
+		u.nextSyncCommittee = update.NextSyncCommittee()
+		u.nextSyncCommitteeBranch = nextSyncCommitteeBranch
+	}
+	return u, nil
+}
+
+func (u *storeUpdate) isSyncCommitteeUpdate() bool {
+	return u.nextSyncCommittee != nil
+}
+
+func (u *storeUpdate) isFinalityUpdate() bool {
+	return u.finalizedHeader != nil
+}
+
+func (u *storeUpdate) finalizedSlot() primitives.Slot {
+	if u.finalizedHeader == nil {
+		return params.BeaconConfig().GenesisSlot
+	}
+	return u.finalizedHeader.Beacon().Slot
+}
+
+// isBetterStoreUpdate follows is_better_update, as IsBetterUpdate does for updates computed by the beacon node.
+func isBetterStoreUpdate(newUpdate, oldUpdate *storeUpdate) bool {
+	// Compare supermajority (> 2/3) sync committee participation
+	maxActiveParticipants := newUpdate.syncAggregate.SyncCommitteeBits.Len()
+	newNumActiveParticipants := newUpdate.syncAggregate.SyncCommitteeBits.Count()
+	oldNumActiveParticipants := oldUpdate.syncAggregate.SyncCommitteeBits.Count()
+	newHasSupermajority := newNumActiveParticipants*3 >= maxActiveParticipants*2
+	oldHasSupermajority := oldNumActiveParticipants*3 >= maxActiveParticipants*2
+	if newHasSupermajority != oldHasSupermajority {
+		return newHasSupermajority
+	}
+	if !newHasSupermajority && newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Compare presence of relevant sync committee
+	newAttestedSlot, oldAttestedSlot := newUpdate.attestedHeader.Beacon().Slot, oldUpdate.attestedHeader.Beacon().Slot
+	newHasRelevantSyncCommittee := newUpdate.isSyncCommitteeUpdate() &&
+		periodAtSlot(newAttestedSlot) == periodAtSlot(newUpdate.signatureSlot)
+	oldHasRelevantSyncCommittee := oldUpdate.isSyncCommitteeUpdate() &&
+		periodAtSlot(oldAttestedSlot) == periodAtSlot(oldUpdate.signatureSlot)
+	if newHasRelevantSyncCommittee != oldHasRelevantSyncCommittee {
+		return newHasRelevantSyncCommittee
+	}
+
+	// Compare indication of any finality
+	newHasFinality := newUpdate.isFinalityUpdate()
+	oldHasFinality := oldUpdate.isFinalityUpdate()
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	// Compare sync committee finality
+	if newHasFinality {
+		newHasSyncCommitteeFinality := periodAtSlot(newUpdate.finalizedSlot()) == periodAtSlot(newAttestedSlot)
+		oldHasSyncCommitteeFinality := periodAtSlot(oldUpdate.finalizedSlot()) == periodAtSlot(oldAttestedSlot)
+		if newHasSyncCommitteeFinality != oldHasSyncCommitteeFinality {
+			return newHasSyncCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority
+	if newNumActiveParticipants != oldNumActiveParticipants {
+		return newNumActiveParticipants > oldNumActiveParticipants
+	}
+
+	// Tiebreaker 2: Prefer older data (fewer changes to best)
+	if newAttestedSlot != oldAttestedSlot {
+		return newAttestedSlot < oldAttestedSlot
+	}
+	return newUpdate.signatureSlot < oldUpdate.signatureSlot
+}
+
+// verifyHeader follows is_valid_light_client_header, checking that the execution payload header is
+// the one committed to in the beacon block body of the header.
+func verifyHeader(header interfaces.LightClientHeader) error {
+	epoch := slots.ToEpoch(header.Beacon().Slot)
+	if header.Version() < version.Capella {
+		if epoch >= params.BeaconConfig().CapellaForkEpoch {
+			return fmt.Errorf("header of epoch %d has no execution payload header", epoch)
+		}
+		return nil
+	}
+
+	execution, err := header.Execution()
+	if err != nil {
+		return err
+	}
+	b, err := header.ExecutionBranch()
+	if err != nil {
+		return err
+	}
+	branch := branchToSlice(b[:])
+
+	if epoch < params.BeaconConfig().DenebForkEpoch {
+		blobGasUsed, err := execution.BlobGasUsed()
+		if err == nil && blobGasUsed != 0 {
+			return fmt.Errorf("header of epoch %d has blob gas used", epoch)
+		}
+		excessBlobGas, err := execution.ExcessBlobGas()
+		if err == nil && excessBlobGas != 0 {
+			return fmt.Errorf("header of epoch %d has excess blob gas", epoch)
+		}
+	}
+
+	if epoch < params.BeaconConfig().CapellaForkEpoch {
+		executionRoot, err := execution.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		var emptyRoot [32]byte
+		if header.Version() >= version.Deneb {
+			emptyRoot, err = emptyPayloadHeaderDeneb().HashTreeRoot()
+		} else {
+			emptyRoot, err = emptyPayloadHeaderCapella().HashTreeRoot()
+		}
+		if err != nil {
+			return err
+		}
+		if executionRoot != emptyRoot || !isZeroBranch(branch) {
+			return fmt.Errorf("header of epoch %d has a non-empty execution payload header", epoch)
+		}
+		return nil
+	}
+
+	executionRoot, err := executionRoot(execution, epoch)
+	if err != nil {
+		return err
+	}
+	if !verifyBranch(header.Beacon().BodyRoot, executionRoot, executionPayloadGeneralizedIndex, branch, fieldparams.ExecutionBranchDepth) {
+		return errors.New("invalid execution branch")
+	}
+	return nil
+}
+
+// executionRoot follows get_lc_execution_root, hashing the execution payload header as it is committed to
+// in blocks of the epoch.
+func executionRoot(execution interfaces.ExecutionData, epoch primitives.Epoch) ([32]byte, error) {
+	if epoch >= params.BeaconConfig().DenebForkEpoch {
+		return execution.HashTreeRoot()
+	}
+	h, ok := execution.Proto().(*enginev1.ExecutionPayloadHeaderDeneb)
+	if !ok {
+		return execution.HashTreeRoot()
+	}
+	return (&enginev1.ExecutionPayloadHeaderCapella{
+		ParentHash:       h.ParentHash,
+		FeeRecipient:     h.FeeRecipient,
+		StateRoot:        h.StateRoot,
+		ReceiptsRoot:     h.ReceiptsRoot,
+		LogsBloom:        h.LogsBloom,
+		PrevRandao:       h.PrevRandao,
+		BlockNumber:      h.BlockNumber,
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Timestamp:        h.Timestamp,
+		ExtraData:        h.ExtraData,
+		BaseFeePerGas:    h.BaseFeePerGas,
+		BlockHash:        h.BlockHash,
+		TransactionsRoot: h.TransactionsRoot,
+		WithdrawalsRoot:  h.WithdrawalsRoot,
+	}).HashTreeRoot()
+}
+
+func verifyBranch(root []byte, leaf [32]byte, gindex uint64, branch [][]byte, depth int) bool {
+	if len(branch) != depth {
+		return false
+	}
+	return trie.VerifyMerkleProof(root, leaf[:], gindex, branch)
+}
+
+func branchToSlice(branch [][fieldparams.RootLength]byte) [][]byte {
+	s := make([][]byte, len(branch))
+	for i := range branch {
+		s[i] = branch[i][:]
+	}
+	return s
+}
+
+func syncCommitteesEqual(a, b *ethpb.SyncCommittee) bool {
+	if len(a.Pubkeys) != len(b.Pubkeys) || !bytes.Equal(a.AggregatePubkey, b.AggregatePubkey) {
+		return false
+	}
+	for i := range a.Pubkeys {
+		if !bytes.Equal(a.Pubkeys[i], b.Pubkeys[i]) {
+			return false
+		}
+	}
+	return true
+}
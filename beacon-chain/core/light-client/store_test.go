@@ -0,0 +1,271 @@
+package light_client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type testStoreChain struct {
+	keys      []common.SecretKey
+	committee *ethpb.SyncCommittee
+	gvr       [32]byte
+}
+
+func newTestStoreChain(t *testing.T) *testStoreChain {
+	keys := make([]common.SecretKey, 8)
+	for i := range keys {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = k
+	}
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = keys[i%len(keys)].PublicKey().Marshal()
+	}
+	return &testStoreChain{
+		keys:      keys,
+		committee: &ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: keys[0].PublicKey().Marshal()},
+		gvr:       [32]byte{'g', 'v', 'r'},
+	}
+}
+
+// state returns a state at the slot with the sync committees of the chain and the given finalized root.
+func (c *testStoreChain) state(t *testing.T, slot primitives.Slot, finalizedRoot [32]byte) state.BeaconState {
+	st, err := util.NewBeaconStateAltair(func(s *ethpb.BeaconStateAltair) error {
+		s.Slot = slot
+		s.CurrentSyncCommittee = c.committee
+		s.NextSyncCommittee = c.committee
+		s.FinalizedCheckpoint = &ethpb.Checkpoint{Epoch: slots.ToEpoch(slot), Root: finalizedRoot[:]}
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+// header returns the header of a block at the slot with the post state of c.state.
+func (c *testStoreChain) header(t *testing.T, slot primitives.Slot, finalizedRoot [32]byte) *ethpb.BeaconBlockHeader {
+	stateRoot, err := c.state(t, slot, finalizedRoot).HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	return &ethpb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  stateRoot[:],
+		BodyRoot:   make([]byte, 32),
+	}
+}
+
+func (c *testStoreChain) sign(t *testing.T, header *ethpb.BeaconBlockHeader, signatureSlot primitives.Slot, participants uint64) *ethpb.SyncAggregate {
+	fork, err := forks.Fork(slots.ToEpoch(signatureSlot - 1))
+	require.NoError(t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, c.gvr[:])
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(header, domain)
+	require.NoError(t, err)
+	keySigs := make([]common.Signature, len(c.keys))
+	for i, k := range c.keys {
+		keySigs[i] = k.Sign(root[:])
+	}
+	bits := bitfield.NewBitvector512()
+	sigs := make([]common.Signature, 0, participants)
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+		sigs = append(sigs, keySigs[i%uint64(len(keySigs))])
+	}
+	if participants == 0 {
+		return &ethpb.SyncAggregate{SyncCommitteeBits: bits, SyncCommitteeSignature: common.InfiniteSignature[:]}
+	}
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: bls.AggregateSignatures(sigs).Marshal(),
+	}
+}
+
+func zeroBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return branch
+}
+
+func (c *testStoreChain) store(t *testing.T) (*lightClient.Store, *ethpb.BeaconBlockHeader) {
+	header := c.header(t, 8, [32]byte{})
+	current, err := c.state(t, 8, [32]byte{}).CurrentSyncCommitteeProof(context.Background())
+	require.NoError(t, err)
+	bootstrap, err := lightclienttypes.NewWrappedBootstrap(&ethpb.LightClientBootstrapAltair{
+		Header:                     &ethpb.LightClientHeaderAltair{Beacon: header},
+		CurrentSyncCommittee:       c.committee,
+		CurrentSyncCommitteeBranch: current,
+	})
+	require.NoError(t, err)
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	s, err := lightClient.NewStore(root, bootstrap, c.gvr)
+	require.NoError(t, err)
+	return s, header
+}
+
+// update returns an update attested at the slot, finalizing a header at finalizedSlot and carrying the
+// next sync committee.
+func (c *testStoreChain) update(t *testing.T, attestedSlot, finalizedSlot primitives.Slot, participants uint64) *ethpb.LightClientUpdateAltair {
+	ctx := context.Background()
+	finalizedHeader := c.header(t, finalizedSlot, [32]byte{})
+	finalizedRoot, err := finalizedHeader.HashTreeRoot()
+	require.NoError(t, err)
+	attestedHeader := c.header(t, attestedSlot, finalizedRoot)
+	attestedState := c.state(t, attestedSlot, finalizedRoot)
+	next, err := attestedState.NextSyncCommitteeProof(ctx)
+	require.NoError(t, err)
+	finality, err := attestedState.FinalizedRootProof(ctx)
+	require.NoError(t, err)
+	return &ethpb.LightClientUpdateAltair{
+		AttestedHeader:          &ethpb.LightClientHeaderAltair{Beacon: attestedHeader},
+		NextSyncCommittee:       c.committee,
+		NextSyncCommitteeBranch: next,
+		FinalizedHeader:         &ethpb.LightClientHeaderAltair{Beacon: finalizedHeader},
+		FinalityBranch:          finality,
+		SyncAggregate:           c.sign(t, attestedHeader, attestedSlot+1, participants),
+		SignatureSlot:           attestedSlot + 1,
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	c := newTestStoreChain(t)
+	s, header := c.store(t)
+	require.Equal(t, header.Slot, s.FinalizedHeader().Beacon().Slot)
+	require.Equal(t, header.Slot, s.OptimisticHeader().Beacon().Slot)
+	require.Equal(t, false, s.IsNextSyncCommitteeKnown())
+
+	current, err := c.state(t, 8, [32]byte{}).CurrentSyncCommitteeProof(context.Background())
+	require.NoError(t, err)
+	bootstrap, err := lightclienttypes.NewWrappedBootstrap(&ethpb.LightClientBootstrapAltair{
+		Header:                     &ethpb.LightClientHeaderAltair{Beacon: header},
+		CurrentSyncCommittee:       c.committee,
+		CurrentSyncCommitteeBranch: current,
+	})
+	require.NoError(t, err)
+	_, err = lightClient.NewStore([32]byte{'a'}, bootstrap, c.gvr)
+	require.ErrorIs(t, err, lightClient.ErrInvalidBootstrap)
+
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	bootstrap, err = lightclienttypes.NewWrappedBootstrap(&ethpb.LightClientBootstrapAltair{
+		Header:                     &ethpb.LightClientHeaderAltair{Beacon: header},
+		CurrentSyncCommittee:       c.committee,
+		CurrentSyncCommitteeBranch: zeroBranch(fieldparams.SyncCommitteeBranchDepth),
+	})
+	require.NoError(t, err)
+	_, err = lightClient.NewStore(root, bootstrap, c.gvr)
+	require.ErrorContains(t, "invalid current sync committee branch", err)
+}
+
+func TestStore_ProcessUpdate(t *testing.T) {
+	c := newTestStoreChain(t)
+	s, _ := c.store(t)
+
+	update, err := lightclienttypes.NewWrappedUpdate(c.update(t, 100, 64, fieldparams.SyncCommitteeLength))
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessUpdate(update, 101))
+	require.Equal(t, primitives.Slot(64), s.FinalizedHeader().Beacon().Slot)
+	require.Equal(t, primitives.Slot(100), s.OptimisticHeader().Beacon().Slot)
+	require.Equal(t, true, s.IsNextSyncCommitteeKnown())
+
+	t.Run("invalid signature", func(t *testing.T) {
+		m := c.update(t, 200, 128, fieldparams.SyncCommitteeLength)
+		m.SyncAggregate = c.sign(t, c.header(t, 199, [32]byte{}), m.SignatureSlot, fieldparams.SyncCommitteeLength)
+		update, err := lightclienttypes.NewWrappedUpdate(m)
+		require.NoError(t, err)
+		require.ErrorContains(t, "invalid sync committee signature", s.ProcessUpdate(update, 201))
+	})
+	t.Run("invalid finality branch", func(t *testing.T) {
+		m := c.update(t, 200, 128, fieldparams.SyncCommitteeLength)
+		m.FinalizedHeader.Beacon.Slot = 127
+		update, err := lightclienttypes.NewWrappedUpdate(m)
+		require.NoError(t, err)
+		require.ErrorContains(t, "invalid finality branch", s.ProcessUpdate(update, 201))
+	})
+	t.Run("signature slot in the future", func(t *testing.T) {
+		update, err := lightclienttypes.NewWrappedUpdate(c.update(t, 200, 128, fieldparams.SyncCommitteeLength))
+		require.NoError(t, err)
+		require.ErrorIs(t, s.ProcessUpdate(update, 200), lightClient.ErrInvalidUpdate)
+	})
+	t.Run("no participants", func(t *testing.T) {
+		update, err := lightclienttypes.NewWrappedUpdate(c.update(t, 200, 128, 0))
+		require.NoError(t, err)
+		require.ErrorIs(t, s.ProcessUpdate(update, 201), lightClient.ErrInsufficientParticipants)
+	})
+}
+
+func TestStore_ProcessOptimisticUpdate(t *testing.T) {
+	c := newTestStoreChain(t)
+	s, _ := c.store(t)
+	update, err := lightclienttypes.NewWrappedUpdate(c.update(t, 100, 64, fieldparams.SyncCommitteeLength))
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessUpdate(update, 101))
+
+	attestedHeader := c.header(t, 120, [32]byte{})
+	optimisticUpdate, err := lightclienttypes.NewWrappedOptimisticUpdate(&ethpb.LightClientOptimisticUpdateAltair{
+		AttestedHeader: &ethpb.LightClientHeaderAltair{Beacon: attestedHeader},
+		SyncAggregate:  c.sign(t, attestedHeader, 121, fieldparams.SyncCommitteeLength),
+		SignatureSlot:  121,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessOptimisticUpdate(optimisticUpdate, 121))
+	require.Equal(t, primitives.Slot(120), s.OptimisticHeader().Beacon().Slot)
+	require.Equal(t, primitives.Slot(64), s.FinalizedHeader().Beacon().Slot)
+}
+
+func TestStore_ProcessFinalityUpdate(t *testing.T) {
+	c := newTestStoreChain(t)
+	s, _ := c.store(t)
+	m := c.update(t, 100, 64, fieldparams.SyncCommitteeLength)
+	finalityUpdate, err := lightclienttypes.NewWrappedFinalityUpdate(&ethpb.LightClientFinalityUpdateAltair{
+		AttestedHeader:  m.AttestedHeader,
+		FinalizedHeader: m.FinalizedHeader,
+		FinalityBranch:  m.FinalityBranch,
+		SyncAggregate:   m.SyncAggregate,
+		SignatureSlot:   m.SignatureSlot,
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessFinalityUpdate(finalityUpdate, 101))
+	require.Equal(t, primitives.Slot(64), s.FinalizedHeader().Beacon().Slot)
+	// A finality update does not carry the next sync committee.
+	require.Equal(t, false, s.IsNextSyncCommitteeKnown())
+}
+
+func TestStore_ProcessForceUpdate(t *testing.T) {
+	c := newTestStoreChain(t)
+	s, _ := c.store(t)
+
+	// Without supermajority participation, the update is only kept as the best valid update.
+	update, err := lightclienttypes.NewWrappedUpdate(c.update(t, 100, 64, 300))
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessUpdate(update, 101))
+	require.Equal(t, primitives.Slot(8), s.FinalizedHeader().Beacon().Slot)
+
+	updateTimeout := primitives.Slot(uint64(params.BeaconConfig().SlotsPerEpoch) * uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	updated, err := s.ProcessForceUpdate(8 + updateTimeout)
+	require.NoError(t, err)
+	require.Equal(t, false, updated)
+	updated, err = s.ProcessForceUpdate(9 + updateTimeout)
+	require.NoError(t, err)
+	require.Equal(t, true, updated)
+	require.Equal(t, primitives.Slot(64), s.FinalizedHeader().Beacon().Slot)
+	require.Equal(t, true, s.IsNextSyncCommitteeKnown())
+}
@@ -0,0 +1,232 @@
+package peerdas
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var (
+	// ErrCustodySubnetCountTooLarge is returned when the requested custody subnet count exceeds the number of subnets.
+	ErrCustodySubnetCountTooLarge = errors.New("custody subnet count larger than data column sidecar subnet count")
+	// ErrBlobsCommitmentsMismatch is returned when the number of blobs does not match the block's KZG commitments.
+	ErrBlobsCommitmentsMismatch = errors.New("number of blobs does not match the number of KZG commitments")
+	// ErrInvalidColumnIndex is returned when a data column sidecar index is out of range.
+	ErrInvalidColumnIndex = errors.New("data column index out of range")
+	// ErrMismatchLength is returned when the cells, commitments and proofs of a data column sidecar differ in length.
+	ErrMismatchLength = errors.New("mismatch length of cells, commitments and proofs")
+	// ErrNoKzgCommitments is returned when a data column sidecar carries no KZG commitment.
+	ErrNoKzgCommitments = errors.New("data column sidecar has no KZG commitments")
+)
+
+var maxUint256 = &uint256.Int{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64}
+
+// IsActive returns true if PeerDAS is active at the given slot.
+func IsActive(slot primitives.Slot) bool {
+	return slots.ToEpoch(slot) >= params.BeaconConfig().Eip7594ForkEpoch
+}
+
+// CustodySubnetCount returns the number of data column subnets the node custodies, which
+// is the minimal custody requirement unless the node was asked to custody all of them.
+func CustodySubnetCount() uint64 {
+	if flags.Get().SubscribeAllDataSubnets {
+		return params.BeaconConfig().DataColumnSidecarSubnetCount
+	}
+	return params.BeaconConfig().CustodyRequirement
+}
+
+// CustodyColumnSubnets computes the data column subnets a node with the given ID custodies.
+//
+// Spec pseudocode definition:
+//
+//	subnet_ids: List[uint64] = []
+//	current_id = uint256(node_id)
+//	while len(subnet_ids) < custody_subnet_count:
+//	    subnet_id = (
+//	        bytes_to_uint64(hash(uint_to_bytes(uint256(current_id)))[0:8])
+//	        % DATA_COLUMN_SIDECAR_SUBNET_COUNT
+//	    )
+//	    if subnet_id not in subnet_ids:
+//	        subnet_ids.append(subnet_id)
+//	    if current_id == UINT256_MAX:
+//	        # Overflow prevention
+//	        current_id = NodeID(0)
+//	    current_id += 1
+func CustodyColumnSubnets(nodeID enode.ID, custodySubnetCount uint64) (map[uint64]bool, error) {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	if custodySubnetCount > subnetCount {
+		return nil, ErrCustodySubnetCountTooLarge
+	}
+	subnets := make(map[uint64]bool, custodySubnetCount)
+	currentID := new(uint256.Int).SetBytes(nodeID.Bytes())
+	one := uint256.NewInt(1)
+	for uint64(len(subnets)) < custodySubnetCount {
+		// uint_to_bytes encodes the uint256 in little endian.
+		currentIDBytes := currentID.Bytes32()
+		h := hash.Hash(bytesutil.ReverseByteOrder(currentIDBytes[:]))
+		subnets[binary.LittleEndian.Uint64(h[:8])%subnetCount] = true
+		if currentID.Eq(maxUint256) {
+			currentID.Clear()
+		}
+		currentID.Add(currentID, one)
+	}
+	return subnets, nil
+}
+
+// CustodyColumns computes the data column indices a node with the given ID custodies.
+//
+// Spec pseudocode definition:
+//
+//	columns_per_subnet = NUMBER_OF_COLUMNS // DATA_COLUMN_SIDECAR_SUBNET_COUNT
+//	return sorted([
+//	    ColumnIndex(DATA_COLUMN_SIDECAR_SUBNET_COUNT * i + subnet_id)
+//	    for i in range(columns_per_subnet)
+//	    for subnet_id in subnet_ids
+//	])
+func CustodyColumns(nodeID enode.ID, custodySubnetCount uint64) (map[uint64]bool, error) {
+	subnets, err := CustodyColumnSubnets(nodeID, custodySubnetCount)
+	if err != nil {
+		return nil, err
+	}
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	columnsPerSubnet := fieldparams.NumberOfColumns / subnetCount
+	columns := make(map[uint64]bool, uint64(len(subnets))*columnsPerSubnet)
+	for i := uint64(0); i < columnsPerSubnet; i++ {
+		for subnet := range subnets {
+			columns[subnetCount*i+subnet] = true
+		}
+	}
+	return columns, nil
+}
+
+// ComputeSubnetForDataColumnSidecar returns the subnet on which the data column with the given index is gossiped.
+func ComputeSubnetForDataColumnSidecar(columnIndex uint64) uint64 {
+	return columnIndex % params.BeaconConfig().DataColumnSidecarSubnetCount
+}
+
+// DataColumnSidecars extends the blobs of the block and splits them into one data column sidecar
+// per column of the extended matrix.
+//
+// Spec pseudocode definition:
+//
+//	def get_data_column_sidecars(signed_block: SignedBeaconBlock,
+//	                             cells_and_kzg_proofs: Sequence[Tuple[
+//	    Vector[Cell, CELLS_PER_EXT_BLOB],
+//	    Vector[KZGProof, CELLS_PER_EXT_BLOB]]]) -> Sequence[DataColumnSidecar]:
+//	    blob_kzg_commitments = signed_block.message.body.blob_kzg_commitments
+//	    assert len(cells_and_kzg_proofs) == len(blob_kzg_commitments)
+//	    signed_block_header = compute_signed_block_header(signed_block)
+//	    kzg_commitments_inclusion_proof = compute_merkle_proof(
+//	        signed_block.message.body,
+//	        get_generalized_index(BeaconBlockBody, 'blob_kzg_commitments'),
+//	    )
+//
+//	    sidecars = []
+//	    for column_index in range(NUMBER_OF_COLUMNS):
+//	        column_cells, column_proofs = [], []
+//	        for cells, proofs in cells_and_kzg_proofs:
+//	            column_cells.append(cells[column_index])
+//	            column_proofs.append(proofs[column_index])
+//	        sidecars.append(DataColumnSidecar(
+//	            index=column_index,
+//	            column=column_cells,
+//	            kzg_commitments=blob_kzg_commitments,
+//	            kzg_proofs=column_proofs,
+//	            signed_block_header=signed_block_header,
+//	            kzg_commitments_inclusion_proof=kzg_commitments_inclusion_proof,
+//	        ))
+//	    return sidecars
+func DataColumnSidecars(signedBlock interfaces.ReadOnlySignedBeaconBlock, blobs [][]byte) ([]*ethpb.DataColumnSidecar, error) {
+	if signedBlock == nil || signedBlock.IsNil() || signedBlock.Version() < version.Deneb {
+		return nil, nil
+	}
+	body := signedBlock.Block().Body()
+	commitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return nil, err
+	}
+	if len(commitments) != len(blobs) {
+		return nil, ErrBlobsCommitmentsMismatch
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	header, err := signedBlock.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "signed block header")
+	}
+	inclusionProof, err := blocks.MerkleProofKZGCommitments(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "merkle proof KZG commitments")
+	}
+
+	cellsAndProofs := make([]kzg.CellsAndProofs, len(blobs))
+	for i := range blobs {
+		cellsAndProofs[i], err = kzg.ComputeCellsAndProofs(blobs[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "compute cells and proofs for blob %d", i)
+		}
+	}
+
+	sidecars := make([]*ethpb.DataColumnSidecar, fieldparams.NumberOfColumns)
+	for columnIndex := range sidecars {
+		column := make([][]byte, len(blobs))
+		proofs := make([][]byte, len(blobs))
+		for i := range cellsAndProofs {
+			column[i] = cellsAndProofs[i].Cells[columnIndex]
+			proofs[i] = cellsAndProofs[i].Proofs[columnIndex]
+		}
+		sidecars[columnIndex] = &ethpb.DataColumnSidecar{
+			Index:                        uint64(columnIndex),
+			Column:                       column,
+			KzgCommitments:               commitments,
+			KzgProofs:                    proofs,
+			SignedBlockHeader:            header,
+			KzgCommitmentsInclusionProof: inclusionProof,
+		}
+	}
+	return sidecars, nil
+}
+
+// VerifyDataColumnSidecar checks the structure of a data column sidecar.
+//
+// Spec pseudocode definition:
+//
+//	def verify_data_column_sidecar(sidecar: DataColumnSidecar) -> bool:
+//	    # The sidecar index must be within the valid range
+//	    if sidecar.index >= NUMBER_OF_COLUMNS:
+//	        return False
+//	    # A sidecar for zero blobs is invalid
+//	    if len(sidecar.kzg_commitments) == 0:
+//	        return False
+//	    # The column length must be equal to the number of commitments/proofs
+//	    if len(sidecar.column) != len(sidecar.kzg_commitments) or len(sidecar.column) != len(sidecar.kzg_proofs):
+//	        return False
+//	    return True
+func VerifyDataColumnSidecar(sidecar blocks.RODataColumn) error {
+	if sidecar.Index >= fieldparams.NumberOfColumns {
+		return ErrInvalidColumnIndex
+	}
+	if len(sidecar.KzgCommitments) == 0 {
+		return ErrNoKzgCommitments
+	}
+	if len(sidecar.Column) != len(sidecar.KzgCommitments) || len(sidecar.Column) != len(sidecar.KzgProofs) {
+		return ErrMismatchLength
+	}
+	return nil
+}
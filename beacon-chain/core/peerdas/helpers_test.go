@@ -0,0 +1,96 @@
+package peerdas_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestCustodyColumns(t *testing.T) {
+	var maxID enode.ID
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+	var seqID enode.ID
+	for i := range seqID {
+		seqID[i] = byte(i)
+	}
+	tests := []struct {
+		name     string
+		nodeID   enode.ID
+		expected []uint64
+	}{
+		{name: "zero node ID", nodeID: enode.ID{}, expected: []uint64{1, 17, 87, 102}},
+		{name: "max node ID wraps around", nodeID: maxID, expected: []uint64{1, 17, 47, 87}},
+		{name: "sequential node ID", nodeID: seqID, expected: []uint64{57, 84, 105, 113}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := peerdas.CustodyColumns(tt.nodeID, params.BeaconConfig().CustodyRequirement)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.expected), len(columns))
+			for _, c := range tt.expected {
+				require.Equal(t, true, columns[c])
+			}
+		})
+	}
+
+	columns, err := peerdas.CustodyColumns(seqID, params.BeaconConfig().DataColumnSidecarSubnetCount)
+	require.NoError(t, err)
+	require.Equal(t, fieldparams.NumberOfColumns, len(columns))
+
+	_, err = peerdas.CustodyColumns(seqID, params.BeaconConfig().DataColumnSidecarSubnetCount+1)
+	require.ErrorIs(t, err, peerdas.ErrCustodySubnetCountTooLarge)
+}
+
+func TestCustodySubnetCount(t *testing.T) {
+	resetFlags := flags.Get()
+	defer flags.Init(resetFlags)
+
+	flags.Init(&flags.GlobalFlags{})
+	require.Equal(t, params.BeaconConfig().CustodyRequirement, peerdas.CustodySubnetCount())
+	flags.Init(&flags.GlobalFlags{SubscribeAllDataSubnets: true})
+	require.Equal(t, params.BeaconConfig().DataColumnSidecarSubnetCount, peerdas.CustodySubnetCount())
+}
+
+func TestDataColumnSidecars(t *testing.T) {
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 3)
+	require.Equal(t, fieldparams.NumberOfColumns, len(columns))
+	for i, col := range columns {
+		require.Equal(t, uint64(i), col.Index)
+		require.Equal(t, 3, len(col.Column))
+		require.Equal(t, blk.Root(), col.BlockRoot())
+		require.NoError(t, peerdas.VerifyDataColumnSidecar(col))
+		require.NoError(t, blocks.VerifyKZGCommitmentsInclusionProof(col))
+	}
+	require.NoError(t, kzg.VerifyDataColumns(columns...))
+
+	_, err := peerdas.DataColumnSidecars(blk, nil)
+	require.ErrorIs(t, err, peerdas.ErrBlobsCommitmentsMismatch)
+}
+
+func TestVerifyDataColumnSidecar(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	col := columns[5]
+	col.Index = fieldparams.NumberOfColumns
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(col), peerdas.ErrInvalidColumnIndex)
+	col.Index = 5
+	col.KzgProofs = nil
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(col), peerdas.ErrMismatchLength)
+	col.KzgCommitments = nil
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(col), peerdas.ErrNoKzgCommitments)
+}
+
+func TestComputeSubnetForDataColumnSidecar(t *testing.T) {
+	count := params.BeaconConfig().DataColumnSidecarSubnetCount
+	require.Equal(t, uint64(3), peerdas.ComputeSubnetForDataColumnSidecar(3))
+	require.Equal(t, uint64(3), peerdas.ComputeSubnetForDataColumnSidecar(count+3))
+}
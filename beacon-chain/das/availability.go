@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -22,11 +23,15 @@ var (
 
 // LazilyPersistentStore is an implementation of AvailabilityStore to be used when batch syncing.
 // This implementation will hold any blobs passed to Persist until the IsDataAvailable is called for their
-// block, at which time they will undergo full verification and be saved to the disk.
+// block, at which time they will undergo full verification and be saved to the disk. When created
+// WithDataColumns, it does the same with the data columns passed to PersistColumns for blocks past the
+// PeerDAS fork.
 type LazilyPersistentStore struct {
-	store    *filesystem.BlobStorage
-	cache    *cache
-	verifier BlobBatchVerifier
+	store          *filesystem.BlobStorage
+	cache          *cache
+	verifier       BlobBatchVerifier
+	custody        map[uint64]bool
+	columnVerifier DataColumnBatchVerifier
 }
 
 var _ AvailabilityStore = &LazilyPersistentStore{}
@@ -42,12 +47,28 @@ type BlobBatchVerifier interface {
 
 // NewLazilyPersistentStore creates a new LazilyPersistentStore. This constructor should always be used
 // when creating a LazilyPersistentStore because it needs to initialize the cache under the hood.
-func NewLazilyPersistentStore(store *filesystem.BlobStorage, verifier BlobBatchVerifier) *LazilyPersistentStore {
-	return &LazilyPersistentStore{
+func NewLazilyPersistentStore(store *filesystem.BlobStorage, verifier BlobBatchVerifier, opts ...StoreOption) *LazilyPersistentStore {
+	s := &LazilyPersistentStore{
 		store:    store,
 		cache:    newCache(),
 		verifier: verifier,
 	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// StoreOption is a functional option for NewLazilyPersistentStore.
+type StoreOption func(*LazilyPersistentStore)
+
+// WithDataColumns makes the DA check of blocks past the PeerDAS fork require the given custody columns
+// instead of blobs, verified with the given verifier.
+func WithDataColumns(custody map[uint64]bool, verifier DataColumnBatchVerifier) StoreOption {
+	return func(s *LazilyPersistentStore) {
+		s.custody = custody
+		s.columnVerifier = verifier
+	}
 }
 
 // Persist adds blobs to the working blob cache. Blobs stored in this cache will be persisted
@@ -78,9 +99,35 @@ func (s *LazilyPersistentStore) Persist(current primitives.Slot, sc ...blocks.RO
 	return nil
 }
 
+// PersistColumns adds data columns to the working cache, as Persist does for blobs.
+func (s *LazilyPersistentStore) PersistColumns(current primitives.Slot, sc ...blocks.RODataColumn) error {
+	if len(sc) == 0 {
+		return nil
+	}
+	first := sc[0].BlockRoot()
+	for i := 1; i < len(sc); i++ {
+		if first != sc[i].BlockRoot() {
+			return errMixedRoots
+		}
+	}
+	if !withinColumnPeriod(slots.ToEpoch(sc[0].Slot()), slots.ToEpoch(current)) {
+		return nil
+	}
+	entry := s.cache.ensure(keyFromColumn(sc[0]))
+	for i := range sc {
+		if err := entry.stashColumn(&sc[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IsDataAvailable returns nil if all the commitments in the given block are persisted to the db and have been verified.
 // BlobSidecars already in the db are assumed to have been previously verified against the block.
 func (s *LazilyPersistentStore) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if s.custody != nil && peerdas.IsActive(b.Block().Slot()) {
+		return s.areDataColumnsAvailable(ctx, current, b)
+	}
 	blockCommitments, err := commitmentsToCheck(b, current)
 	if err != nil {
 		return errors.Wrapf(err, "could check data availability for block %#x", b.Root())
@@ -137,6 +184,49 @@ func (s *LazilyPersistentStore) IsDataAvailable(ctx context.Context, current pri
 	return nil
 }
 
+// areDataColumnsAvailable returns nil if all the custody columns of the given block are persisted to the db and
+// have been verified, when the block has commitments.
+func (s *LazilyPersistentStore) areDataColumnsAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if !withinColumnPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(current)) {
+		return nil
+	}
+	commitments, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrapf(err, "could not check data availability for block %#x", b.Root())
+	}
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	key := keyFromBlock(b)
+	entry := s.cache.ensure(key)
+	defer s.cache.delete(key)
+	root := b.Root()
+	stored, err := s.store.DataColumnIndices(root)
+	if err != nil {
+		return errors.Wrapf(err, "could not retrieve stored data columns for block %#x", root)
+	}
+	sidecars, err := entry.filterColumns(root, s.custody, stored)
+	if err != nil {
+		return errors.Wrap(err, "incomplete DataColumnSidecar batch")
+	}
+	verified, err := s.columnVerifier.VerifiedRODataColumns(ctx, b, sidecars)
+	if err != nil {
+		return errors.Wrapf(err, "invalid DataColumnSidecars received for block %#x", root)
+	}
+	for i := range verified {
+		if err := s.store.SaveDataColumn(verified[i]); err != nil {
+			return errors.Wrapf(err, "failed to save DataColumnSidecar index %d for block %#x", verified[i].Index, root)
+		}
+	}
+	return nil
+}
+
+// withinColumnPeriod returns true if data columns of the block epoch must still be available at the current epoch.
+func withinColumnPeriod(block, current primitives.Epoch) bool {
+	return block+params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest >= current
+}
+
 func commitmentsToCheck(b blocks.ROBlock, current primitives.Slot) (safeCommitmentArray, error) {
 	var ar safeCommitmentArray
 	if b.Version() < version.Deneb {
@@ -177,6 +177,39 @@ func TestLazyPersistOnceCommitted(t *testing.T) {
 	require.NoError(t, as.Persist(1, more...))
 }
 
+func TestLazilyPersistent_DataColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.Eip7594ForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	store := filesystem.NewEphemeralBlobStorage(t)
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	newVerifier := func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+		return (&verification.Initializer{}).NewDataColumnVerifier(dc, reqs)
+	}
+	bv := verification.NewDataColumnBatchVerifier(newVerifier, verification.InitsyncColumnSidecarRequirements)
+	as := NewLazilyPersistentStore(store, &mockBlobBatchVerifier{}, WithDataColumns(map[uint64]bool{1: true, 5: true}, bv))
+
+	// Column 5 is missing, and blobs are not required.
+	require.NoError(t, as.PersistColumns(1, columns[1], columns[2]))
+	require.ErrorIs(t, as.IsDataAvailable(ctx, 1, blk), errMissingColumns)
+
+	// Columns already stored are not required again.
+	require.NoError(t, store.SaveDataColumn(blocks.NewVerifiedRODataColumn(columns[5])))
+	require.NoError(t, as.PersistColumns(1, columns[1]))
+	require.ErrorIs(t, as.PersistColumns(1, columns[1]), ErrDuplicateSidecar)
+	require.NoError(t, as.IsDataAvailable(ctx, 1, blk))
+	stored, err := store.DataColumnIndices(blk.Root())
+	require.NoError(t, err)
+	require.Equal(t, true, stored[1])
+	require.Equal(t, false, stored[2])
+
+	// Without custody columns, the store keeps checking blobs.
+	as = NewLazilyPersistentStore(store, &mockBlobBatchVerifier{})
+	require.ErrorIs(t, as.IsDataAvailable(ctx, 1, blk), errMissingSidecar)
+}
+
 type mockBlobBatchVerifier struct {
 	t        *testing.T
 	scs      []blocks.ROBlob
@@ -13,8 +13,10 @@ import (
 var (
 	ErrDuplicateSidecar   = errors.New("duplicate sidecar stashed in AvailabilityStore")
 	errIndexOutOfBounds   = errors.New("sidecar.index > MAX_BLOBS_PER_BLOCK")
+	errColumnOutOfBounds  = errors.New("sidecar.index >= NUMBER_OF_COLUMNS")
 	errCommitmentMismatch = errors.New("KzgCommitment of sidecar in cache did not match block commitment")
 	errMissingSidecar     = errors.New("no sidecar in cache for block commitment")
+	errMissingColumns     = errors.New("no sidecar in cache for custody columns")
 )
 
 // cacheKey includes the slot so that we can easily iterate through the cache and compare
@@ -38,6 +40,11 @@ func keyFromSidecar(sc blocks.ROBlob) cacheKey {
 	return cacheKey{slot: sc.Slot(), root: sc.BlockRoot()}
 }
 
+// keyFromColumn is a convenience method for constructing a cacheKey from a DataColumnSidecar value.
+func keyFromColumn(dc blocks.RODataColumn) cacheKey {
+	return cacheKey{slot: dc.Slot(), root: dc.BlockRoot()}
+}
+
 // keyFromBlock is a convenience method for constructing a cacheKey from a ROBlock value.
 func keyFromBlock(b blocks.ROBlock) cacheKey {
 	return cacheKey{slot: b.Block().Slot(), root: b.Root()}
@@ -58,9 +65,10 @@ func (c *cache) delete(key cacheKey) {
 	delete(c.entries, key)
 }
 
-// cacheEntry holds a fixed-length cache of BlobSidecars.
+// cacheEntry holds a fixed-length cache of BlobSidecars, or of DataColumnSidecars past the PeerDAS fork.
 type cacheEntry struct {
 	scs         [fieldparams.MaxBlobsPerBlock]*blocks.ROBlob
+	dcs         [fieldparams.NumberOfColumns]*blocks.RODataColumn
 	diskSummary filesystem.BlobStorageSummary
 }
 
@@ -82,6 +90,40 @@ func (e *cacheEntry) stash(sc *blocks.ROBlob) error {
 	return nil
 }
 
+// stashColumn adds an item to the in-memory cache of DataColumnSidecars.
+// Only the first DataColumnSidecar of a given Index will be kept in the cache.
+func (e *cacheEntry) stashColumn(dc *blocks.RODataColumn) error {
+	if dc.Index >= fieldparams.NumberOfColumns {
+		return errors.Wrapf(errColumnOutOfBounds, "index=%d", dc.Index)
+	}
+	if e.dcs[dc.Index] != nil {
+		return errors.Wrapf(ErrDuplicateSidecar, "root=%#x, column=%d", dc.BlockRoot(), dc.Index)
+	}
+	e.dcs[dc.Index] = dc
+	return nil
+}
+
+// filterColumns returns the cached columns among the given custody columns that are not already stored, or an
+// error listing the ones missing from the cache.
+func (e *cacheEntry) filterColumns(root [32]byte, custody map[uint64]bool, stored [fieldparams.NumberOfColumns]bool) ([]blocks.RODataColumn, error) {
+	dcs := make([]blocks.RODataColumn, 0, len(custody))
+	var missing []uint64
+	for i := uint64(0); i < fieldparams.NumberOfColumns; i++ {
+		if !custody[i] || stored[i] {
+			continue
+		}
+		if e.dcs[i] == nil {
+			missing = append(missing, i)
+			continue
+		}
+		dcs = append(dcs, *e.dcs[i])
+	}
+	if len(missing) > 0 {
+		return nil, errors.Wrapf(errMissingColumns, "root=%#x, columns=%v", root, missing)
+	}
+	return dcs, nil
+}
+
 // filter evicts sidecars that are not committed to by the block and returns custom
 // errors if the cache is missing any of the commitments, or if the commitments in
 // the cache do not match those found in the block. If err is nil, then all expected
@@ -0,0 +1,184 @@
+package das
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxSamplingRounds bounds how many times a sample that could not be retrieved is requested again from another peer.
+const maxSamplingRounds = 3
+
+var (
+	// ErrSamplingFailed is returned when some sampled data columns could not be retrieved from any peer.
+	ErrSamplingFailed = errors.New("data column sampling failed")
+)
+
+// ColumnPeerSource provides the peers from which data columns may be sampled.
+type ColumnPeerSource interface {
+	// CustodiansByColumn returns, for each column index, the connected peers that advertise custody of it.
+	CustodiansByColumn() map[uint64][]peer.ID
+}
+
+// ColumnFetcher requests data columns for a given block root from a given peer.
+type ColumnFetcher interface {
+	FetchDataColumns(ctx context.Context, pid peer.ID, root [32]byte, columns []uint64) ([]blocks.RODataColumn, error)
+}
+
+// DataColumnBatchVerifier verifies that data columns retrieved while sampling are consistent with their block.
+type DataColumnBatchVerifier interface {
+	VerifiedRODataColumns(ctx context.Context, blk blocks.ROBlock, columns []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error)
+}
+
+// DataColumnSampler performs data availability sampling for blocks carrying blob commitments: on top of the
+// columns custodied by the node, a random set of columns is requested from peers custodying them, and the
+// block is only considered to be sampled once each of those columns has been retrieved and verified.
+type DataColumnSampler struct {
+	peers    ColumnPeerSource
+	fetcher  ColumnFetcher
+	verifier DataColumnBatchVerifier
+}
+
+// NewDataColumnSampler creates a new DataColumnSampler.
+func NewDataColumnSampler(peers ColumnPeerSource, fetcher ColumnFetcher, verifier DataColumnBatchVerifier) *DataColumnSampler {
+	return &DataColumnSampler{
+		peers:    peers,
+		fetcher:  fetcher,
+		verifier: verifier,
+	}
+}
+
+// Sample samples SAMPLES_PER_SLOT columns of the given block, chosen at random among the columns not in custody.
+// Columns in custody are expected to be made available independently and are never sampled.
+// It returns the sampled column indices, sorted.
+func (d *DataColumnSampler) Sample(ctx context.Context, blk blocks.ROBlock, custody map[uint64]bool) ([]uint64, error) {
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return nil, errors.Wrap(err, "blob kzg commitments")
+	}
+	if len(commitments) == 0 {
+		return nil, nil
+	}
+
+	columns := sampleColumns(custody, params.BeaconConfig().SamplesPerSlot)
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	if _, err := d.Retrieve(ctx, blk, columns); err != nil {
+		return columns, err
+	}
+	return columns, nil
+}
+
+// Retrieve requests the given columns of the block from the peers custodying them, asking another custodian when a
+// column could not be retrieved, and returns the columns which could be retrieved and verified. The error lists the
+// columns which could not.
+func (d *DataColumnSampler) Retrieve(ctx context.Context, blk blocks.ROBlock, columns []uint64) ([]blocks.VerifiedRODataColumn, error) {
+	pending := make(map[uint64]bool, len(columns))
+	for _, c := range columns {
+		pending[c] = true
+	}
+	custodians := d.peers.CustodiansByColumn()
+	tried := make(map[uint64]map[peer.ID]bool, len(columns))
+	retrieved := make([]blocks.VerifiedRODataColumn, 0, len(columns))
+	for round := 0; round < maxSamplingRounds && len(pending) > 0; round++ {
+		if err := ctx.Err(); err != nil {
+			return retrieved, err
+		}
+		for pid, requested := range assignSamples(pending, custodians, tried) {
+			retrieved = append(retrieved, d.retrieveFromPeer(ctx, blk, pid, requested, pending)...)
+		}
+	}
+
+	if len(pending) > 0 {
+		missing := make([]uint64, 0, len(pending))
+		for c := range pending {
+			missing = append(missing, c)
+		}
+		sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+		return retrieved, errors.Wrapf(ErrSamplingFailed, "missing columns %v", missing)
+	}
+	return retrieved, nil
+}
+
+// retrieveFromPeer requests the given columns from the peer, and removes from pending the ones that could be verified.
+func (d *DataColumnSampler) retrieveFromPeer(ctx context.Context, blk blocks.ROBlock, pid peer.ID, requested []uint64, pending map[uint64]bool) []blocks.VerifiedRODataColumn {
+	root := blk.Root()
+	logFields := log.Fields{
+		"peer":      pid,
+		"blockRoot": fmt.Sprintf("%#x", root),
+		"columns":   requested,
+	}
+	cols, err := d.fetcher.FetchDataColumns(ctx, pid, root, requested)
+	if err != nil {
+		log.WithError(err).WithFields(logFields).Debug("Could not fetch data columns")
+		return nil
+	}
+	verified, err := d.verifier.VerifiedRODataColumns(ctx, blk, cols)
+	if err != nil {
+		log.WithError(err).WithFields(logFields).Debug("Could not verify data columns")
+		return nil
+	}
+	retrieved := make([]blocks.VerifiedRODataColumn, 0, len(verified))
+	for _, col := range verified {
+		if pending[col.Index] {
+			delete(pending, col.Index)
+			retrieved = append(retrieved, col)
+		}
+	}
+	return retrieved
+}
+
+// assignSamples groups the pending columns by peer, choosing for each column a random custodian that has not been
+// asked for it yet. Columns without any such custodian are left unassigned.
+func assignSamples(pending map[uint64]bool, custodians map[uint64][]peer.ID, tried map[uint64]map[peer.ID]bool) map[peer.ID][]uint64 {
+	randGen := rand.NewGenerator()
+	assignments := make(map[peer.ID][]uint64)
+	for column := range pending {
+		candidates := make([]peer.ID, 0, len(custodians[column]))
+		for _, pid := range custodians[column] {
+			if !tried[column][pid] {
+				candidates = append(candidates, pid)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		pid := candidates[randGen.Intn(len(candidates))]
+		if tried[column] == nil {
+			tried[column] = make(map[peer.ID]bool)
+		}
+		tried[column][pid] = true
+		assignments[pid] = append(assignments[pid], column)
+	}
+	for pid := range assignments {
+		sort.Slice(assignments[pid], func(i, j int) bool { return assignments[pid][i] < assignments[pid][j] })
+	}
+	return assignments
+}
+
+// sampleColumns picks at most count random column indices which are not in custody, sorted.
+func sampleColumns(custody map[uint64]bool, count uint64) []uint64 {
+	candidates := make([]uint64, 0, params.BeaconConfig().NumberOfColumns)
+	for i := uint64(0); i < params.BeaconConfig().NumberOfColumns; i++ {
+		if !custody[i] {
+			candidates = append(candidates, i)
+		}
+	}
+	randGen := rand.NewGenerator()
+	randGen.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if uint64(len(candidates)) > count {
+		candidates = candidates[:count]
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	return candidates
+}
@@ -0,0 +1,171 @@
+package das
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockColumnPeers struct {
+	custodians map[uint64][]peer.ID
+}
+
+func (m *mockColumnPeers) CustodiansByColumn() map[uint64][]peer.ID {
+	return m.custodians
+}
+
+// mockColumnFetcher serves the columns each peer holds, and records the requests it received.
+type mockColumnFetcher struct {
+	columns  map[peer.ID]map[uint64]blocks.RODataColumn
+	requests map[peer.ID][][]uint64
+}
+
+func (m *mockColumnFetcher) FetchDataColumns(_ context.Context, pid peer.ID, _ [32]byte, columns []uint64) ([]blocks.RODataColumn, error) {
+	if m.requests == nil {
+		m.requests = make(map[peer.ID][][]uint64)
+	}
+	m.requests[pid] = append(m.requests[pid], columns)
+	held, ok := m.columns[pid]
+	if !ok {
+		return nil, errors.New("peer unreachable")
+	}
+	resp := make([]blocks.RODataColumn, 0, len(columns))
+	for _, c := range columns {
+		if col, ok := held[c]; ok {
+			resp = append(resp, col)
+		}
+	}
+	return resp, nil
+}
+
+type mockColumnVerifier struct {
+	err error
+}
+
+func (m *mockColumnVerifier) VerifiedRODataColumns(_ context.Context, _ blocks.ROBlock, columns []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	verified := make([]blocks.VerifiedRODataColumn, len(columns))
+	for i := range columns {
+		verified[i] = blocks.NewVerifiedRODataColumn(columns[i])
+	}
+	return verified, nil
+}
+
+// allColumnsPeers sets up peers that each custody every column, and optionally hold them.
+func allColumnsPeers(columns []blocks.RODataColumn, holding map[peer.ID]bool) (*mockColumnPeers, *mockColumnFetcher) {
+	peers := &mockColumnPeers{custodians: make(map[uint64][]peer.ID)}
+	fetcher := &mockColumnFetcher{columns: make(map[peer.ID]map[uint64]blocks.RODataColumn)}
+	for pid, holds := range holding {
+		for _, col := range columns {
+			peers.custodians[col.Index] = append(peers.custodians[col.Index], pid)
+		}
+		if !holds {
+			continue
+		}
+		fetcher.columns[pid] = make(map[uint64]blocks.RODataColumn)
+		for _, col := range columns {
+			fetcher.columns[pid][col.Index] = col
+		}
+	}
+	return peers, fetcher
+}
+
+func TestDataColumnSampler_Sample(t *testing.T) {
+	ctx := context.Background()
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	custody := map[uint64]bool{0: true, 1: true, 2: true, 3: true}
+
+	t.Run("no commitments", func(t *testing.T) {
+		noBlobs, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 0)
+		peers, fetcher := allColumnsPeers(columns, map[peer.ID]bool{"a": true})
+		sampler := NewDataColumnSampler(peers, fetcher, &mockColumnVerifier{})
+		sampled, err := sampler.Sample(ctx, noBlobs, custody)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(sampled))
+		require.Equal(t, 0, len(fetcher.requests))
+	})
+	t.Run("all samples available", func(t *testing.T) {
+		peers, fetcher := allColumnsPeers(columns, map[peer.ID]bool{"a": true, "b": true})
+		sampler := NewDataColumnSampler(peers, fetcher, &mockColumnVerifier{})
+		sampled, err := sampler.Sample(ctx, blk, custody)
+		require.NoError(t, err)
+		require.Equal(t, params.BeaconConfig().SamplesPerSlot, uint64(len(sampled)))
+		seen := make(map[uint64]bool)
+		for _, c := range sampled {
+			require.Equal(t, false, custody[c], "column %d in custody was sampled", c)
+			require.Equal(t, false, seen[c], "column %d sampled twice", c)
+			seen[c] = true
+		}
+	})
+	t.Run("retries another custodian", func(t *testing.T) {
+		// Only one of the two peers holds the columns, every sample must eventually be served by it.
+		peers, fetcher := allColumnsPeers(columns, map[peer.ID]bool{"a": false, "b": true})
+		sampler := NewDataColumnSampler(peers, fetcher, &mockColumnVerifier{})
+		_, err := sampler.Sample(ctx, blk, custody)
+		require.NoError(t, err)
+	})
+	t.Run("samples unavailable", func(t *testing.T) {
+		peers, fetcher := allColumnsPeers(columns, map[peer.ID]bool{"a": false, "b": false})
+		sampler := NewDataColumnSampler(peers, fetcher, &mockColumnVerifier{})
+		sampled, err := sampler.Sample(ctx, blk, custody)
+		require.ErrorIs(t, err, ErrSamplingFailed)
+		require.Equal(t, params.BeaconConfig().SamplesPerSlot, uint64(len(sampled)))
+		// Each custodian is only asked once for a given column.
+		for pid, reqs := range fetcher.requests {
+			asked := make(map[uint64]bool)
+			for _, req := range reqs {
+				for _, c := range req {
+					require.Equal(t, false, asked[c], "peer %s asked twice for column %d", pid, c)
+					asked[c] = true
+				}
+			}
+		}
+	})
+	t.Run("verification failure", func(t *testing.T) {
+		peers, fetcher := allColumnsPeers(columns, map[peer.ID]bool{"a": true})
+		sampler := NewDataColumnSampler(peers, fetcher, &mockColumnVerifier{err: errors.New("bad proof")})
+		_, err := sampler.Sample(ctx, blk, custody)
+		require.ErrorIs(t, err, ErrSamplingFailed)
+	})
+}
+
+func TestDataColumnSampler_Retrieve(t *testing.T) {
+	ctx := context.Background()
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	peers, fetcher := allColumnsPeers(columns[:4], map[peer.ID]bool{"a": false, "b": true})
+	sampler := NewDataColumnSampler(peers, fetcher, &mockColumnVerifier{})
+
+	retrieved, err := sampler.Retrieve(ctx, blk, []uint64{1, 3})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(retrieved))
+
+	// Column 9 has no custodian, the retrieved columns are still returned.
+	retrieved, err = sampler.Retrieve(ctx, blk, []uint64{2, 9})
+	require.ErrorContains(t, "missing columns [9]", err)
+	require.Equal(t, 1, len(retrieved))
+	require.Equal(t, uint64(2), retrieved[0].Index)
+}
+
+func TestSampleColumns(t *testing.T) {
+	custody := make(map[uint64]bool)
+	for i := uint64(0); i < params.BeaconConfig().NumberOfColumns-3; i++ {
+		custody[i] = true
+	}
+	// Fewer columns left than samples: all of them are sampled.
+	sampled := sampleColumns(custody, params.BeaconConfig().SamplesPerSlot)
+	require.DeepEqual(t, []uint64{125, 126, 127}, sampled)
+
+	sampled = sampleColumns(map[uint64]bool{}, 16)
+	require.Equal(t, 16, len(sampled))
+	for i := 1; i < len(sampled); i++ {
+		require.Equal(t, true, sampled[i-1] < sampled[i])
+	}
+}
@@ -0,0 +1,131 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// Entry types of the e2store format, see https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md.
+const (
+	TypeVersion                     uint16 = 0x3265
+	TypeCompressedSignedBeaconBlock uint16 = 0x0001
+	TypeCompressedBeaconState       uint16 = 0x0002
+	TypeSlotIndex                   uint16 = 0x3269
+)
+
+// headerSize is the size of an entry header: a 2 byte type, a 4 byte length and 2 reserved bytes.
+const headerSize = 8
+
+var errReservedNotZero = errors.New("reserved bytes of e2store entry header are not zero")
+
+// entry is an e2store record, with its offset from the beginning of the file.
+type entry struct {
+	typ    uint16
+	data   []byte
+	offset int64
+}
+
+// e2Writer appends e2store entries to a writer, keeping track of the offset of the next entry.
+type e2Writer struct {
+	w      io.Writer
+	offset int64
+}
+
+// write appends an entry and returns its offset.
+func (e *e2Writer) write(typ uint16, data []byte) (int64, error) {
+	if uint64(len(data)) > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("e2store entry of %d bytes is too large", len(data))
+	}
+	header := make([]byte, headerSize)
+	binary.LittleEndian.PutUint16(header[0:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	if _, err := e.w.Write(header); err != nil {
+		return 0, err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return 0, err
+	}
+	offset := e.offset
+	e.offset += int64(headerSize + len(data))
+	return offset, nil
+}
+
+// readEntries splits an e2store file into its entries.
+func readEntries(b []byte) ([]*entry, error) {
+	entries := make([]*entry, 0)
+	for offset := 0; offset < len(b); {
+		if len(b)-offset < headerSize {
+			return nil, fmt.Errorf("truncated e2store entry header at offset %d", offset)
+		}
+		header := b[offset : offset+headerSize]
+		if header[6] != 0 || header[7] != 0 {
+			return nil, errors.Wrapf(errReservedNotZero, "entry at offset %d", offset)
+		}
+		length := uint64(binary.LittleEndian.Uint32(header[2:6]))
+		start := uint64(offset + headerSize)
+		if start+length > uint64(len(b)) {
+			return nil, fmt.Errorf("truncated e2store entry at offset %d", offset)
+		}
+		entries = append(entries, &entry{
+			typ:    binary.LittleEndian.Uint16(header[0:2]),
+			data:   b[start : start+length],
+			offset: int64(offset),
+		})
+		offset = int(start + length)
+	}
+	return entries, nil
+}
+
+// compress encodes SSZ bytes with the snappy framing format used by era files.
+func compress(b []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := snappy.NewBufferedWriter(buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(b []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(b)))
+}
+
+// slotIndex maps the slots of an era to the offsets of their entries. Offsets are relative to the
+// beginning of the index entry, and are 0 for slots without an entry.
+type slotIndex struct {
+	startSlot uint64
+	offsets   []int64
+}
+
+func (i *slotIndex) marshal() []byte {
+	b := make([]byte, 8*(len(i.offsets)+2))
+	binary.LittleEndian.PutUint64(b[0:8], i.startSlot)
+	for j, o := range i.offsets {
+		binary.LittleEndian.PutUint64(b[8*(j+1):], uint64(o))
+	}
+	binary.LittleEndian.PutUint64(b[len(b)-8:], uint64(len(i.offsets)))
+	return b
+}
+
+func unmarshalSlotIndex(b []byte) (*slotIndex, error) {
+	if len(b) < 16 || len(b)%8 != 0 {
+		return nil, fmt.Errorf("invalid slot index size %d", len(b))
+	}
+	count := binary.LittleEndian.Uint64(b[len(b)-8:])
+	if count != uint64(len(b)/8-2) {
+		return nil, fmt.Errorf("slot index count %d does not match its size %d", count, len(b))
+	}
+	i := &slotIndex{startSlot: binary.LittleEndian.Uint64(b[0:8]), offsets: make([]int64, count)}
+	for j := range i.offsets {
+		i.offsets[j] = int64(binary.LittleEndian.Uint64(b[8*(j+1):]))
+	}
+	return i, nil
+}
@@ -0,0 +1,250 @@
+package era
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// Era is the content of an era file. Era N holds the blocks of the slots
+// [(N-1) * SLOTS_PER_HISTORICAL_ROOT, N * SLOTS_PER_HISTORICAL_ROOT) and the state at the end of that
+// range, at slot N * SLOTS_PER_HISTORICAL_ROOT. Era 0 only holds the genesis state.
+type Era struct {
+	Number uint64
+	Blocks []interfaces.ReadOnlySignedBeaconBlock
+	State  state.BeaconState
+}
+
+// StartSlot returns the slot of the state of an era.
+func StartSlot(era uint64) (primitives.Slot, error) {
+	return primitives.Slot(era).SafeMul(uint64(params.BeaconConfig().SlotsPerHistoricalRoot))
+}
+
+// FileName returns the standard name of the era file of a state, <config>-<era>-<short historical root>.era.
+func FileName(st state.ReadOnlyBeaconState) (string, error) {
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	if st.Slot()%perEra != 0 {
+		return "", fmt.Errorf("state slot %d is not at an era boundary", st.Slot())
+	}
+	root, err := historicalRoot(st)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%05d-%x.era", params.BeaconConfig().ConfigName, uint64(st.Slot()/perEra), root[:4]), nil
+}
+
+// NumberFromFileName parses the era number of a standard era file name.
+func NumberFromFileName(path string) (uint64, error) {
+	parts := strings.Split(strings.TrimSuffix(filepath.Base(path), ".era"), "-")
+	if len(parts) < 3 {
+		return 0, fmt.Errorf("%s is not named <config>-<era>-<root>.era", path)
+	}
+	n, err := strconv.ParseUint(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse era number of %s", path)
+	}
+	return n, nil
+}
+
+// historicalRoot is the root of the historical batch of an era state, which is the entry of the era in
+// historical_roots before Capella and the root of its historical summary after. The genesis validators root
+// is used for era 0.
+func historicalRoot(st state.ReadOnlyBeaconState) ([32]byte, error) {
+	if st.Slot() == 0 {
+		return bytesutil.ToBytes32(st.GenesisValidatorsRoot()), nil
+	}
+	batch := &ethpb.HistoricalBatch{BlockRoots: st.BlockRoots(), StateRoots: st.StateRoots()}
+	return batch.HashTreeRoot()
+}
+
+// Write writes the era group of a state at an era boundary and the blocks of the preceding era.
+// Blocks must be sorted by slot.
+func Write(w io.Writer, blks []interfaces.ReadOnlySignedBeaconBlock, st state.ReadOnlyBeaconState) error {
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	slot := st.Slot()
+	if slot%perEra != 0 {
+		return fmt.Errorf("state slot %d is not at an era boundary", slot)
+	}
+	if slot == 0 && len(blks) > 0 {
+		return errors.New("era 0 cannot contain blocks")
+	}
+
+	e := &e2Writer{w: w}
+	if _, err := e.write(TypeVersion, nil); err != nil {
+		return err
+	}
+
+	var blockIndex *slotIndex
+	if slot > 0 {
+		blockIndex = &slotIndex{startSlot: uint64(slot - perEra), offsets: make([]int64, perEra)}
+		for _, blk := range blks {
+			blkSlot := blk.Block().Slot()
+			if blkSlot < slot-perEra || blkSlot >= slot {
+				return fmt.Errorf("block at slot %d is outside of era ending at slot %d", blkSlot, slot)
+			}
+			i := blkSlot - (slot - perEra)
+			if blockIndex.offsets[i] != 0 {
+				return fmt.Errorf("more than one block at slot %d", blkSlot)
+			}
+			if blk.IsBlinded() {
+				return fmt.Errorf("block at slot %d is blinded, era files need full execution payloads", blkSlot)
+			}
+			enc, err := blk.MarshalSSZ()
+			if err != nil {
+				return errors.Wrapf(err, "could not marshal block at slot %d", blkSlot)
+			}
+			data, err := compress(enc)
+			if err != nil {
+				return err
+			}
+			offset, err := e.write(TypeCompressedSignedBeaconBlock, data)
+			if err != nil {
+				return err
+			}
+			blockIndex.offsets[i] = offset
+		}
+	}
+
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state")
+	}
+	data, err := compress(enc)
+	if err != nil {
+		return err
+	}
+	stateOffset, err := e.write(TypeCompressedBeaconState, data)
+	if err != nil {
+		return err
+	}
+
+	if blockIndex != nil {
+		for i, o := range blockIndex.offsets {
+			if o != 0 {
+				blockIndex.offsets[i] = o - e.offset
+			}
+		}
+		if _, err := e.write(TypeSlotIndex, blockIndex.marshal()); err != nil {
+			return err
+		}
+	}
+	stateIndex := &slotIndex{startSlot: uint64(slot), offsets: []int64{stateOffset - e.offset}}
+	_, err = e.write(TypeSlotIndex, stateIndex.marshal())
+	return err
+}
+
+// Read decodes an era file written by Write or by another client.
+func Read(b []byte) (*Era, error) {
+	entries, err := readEntries(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) < 3 || entries[0].typ != TypeVersion {
+		return nil, errors.New("era file does not start with a version entry")
+	}
+	byOffset := make(map[int64]*entry, len(entries))
+	for _, e := range entries {
+		byOffset[e.offset] = e
+	}
+	indexed := func(index *entry, rel int64, typ uint16) (*entry, error) {
+		e, ok := byOffset[index.offset+rel]
+		if !ok || e.typ != typ {
+			return nil, fmt.Errorf("no entry of type %#04x at offset %d", typ, index.offset+rel)
+		}
+		return e, nil
+	}
+
+	last := entries[len(entries)-1]
+	if last.typ != TypeSlotIndex {
+		return nil, errors.New("era file does not end with a state index")
+	}
+	stateIndex, err := unmarshalSlotIndex(last.data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode state index")
+	}
+	if len(stateIndex.offsets) != 1 {
+		return nil, fmt.Errorf("state index has %d entries instead of 1", len(stateIndex.offsets))
+	}
+	stateEntry, err := indexed(last, stateIndex.offsets[0], TypeCompressedBeaconState)
+	if err != nil {
+		return nil, err
+	}
+	st, err := decodeState(stateEntry.data)
+	if err != nil {
+		return nil, err
+	}
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	slot := st.Slot()
+	if uint64(slot) != stateIndex.startSlot || slot%perEra != 0 {
+		return nil, fmt.Errorf("state slot %d does not match era boundary %d of the state index", slot, stateIndex.startSlot)
+	}
+	era := &Era{Number: uint64(slot / perEra), State: st}
+	if slot == 0 {
+		return era, nil
+	}
+
+	blockIndexEntry := entries[len(entries)-2]
+	if blockIndexEntry.typ != TypeSlotIndex {
+		return nil, errors.New("era file has no block index")
+	}
+	blockIndex, err := unmarshalSlotIndex(blockIndexEntry.data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode block index")
+	}
+	if blockIndex.startSlot != uint64(slot-perEra) || len(blockIndex.offsets) != int(perEra) {
+		return nil, fmt.Errorf("block index does not cover the era ending at slot %d", slot)
+	}
+	for i, rel := range blockIndex.offsets {
+		if rel == 0 {
+			continue
+		}
+		blockEntry, err := indexed(blockIndexEntry, rel, TypeCompressedSignedBeaconBlock)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := decodeBlock(blockEntry.data)
+		if err != nil {
+			return nil, err
+		}
+		if want := primitives.Slot(blockIndex.startSlot) + primitives.Slot(i); blk.Block().Slot() != want {
+			return nil, fmt.Errorf("block at slot %d is indexed at slot %d", blk.Block().Slot(), want)
+		}
+		era.Blocks = append(era.Blocks, blk)
+	}
+	return era, nil
+}
+
+func decodeState(data []byte) (state.BeaconState, error) {
+	enc, err := decompress(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state")
+	}
+	u, err := detect.FromState(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect state version")
+	}
+	return u.UnmarshalBeaconState(enc)
+}
+
+func decodeBlock(data []byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	enc, err := decompress(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress block")
+	}
+	u, err := detect.FromBlock(enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect block version")
+	}
+	return u.UnmarshalBeaconBlock(enc)
+}
@@ -0,0 +1,132 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestReadEntries(t *testing.T) {
+	buf := &bytes.Buffer{}
+	e := &e2Writer{w: buf}
+	offset, err := e.write(TypeVersion, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	offset, err = e.write(TypeCompressedBeaconState, []byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(headerSize), offset)
+
+	entries, err := readEntries(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	assert.Equal(t, TypeVersion, entries[0].typ)
+	assert.Equal(t, 0, len(entries[0].data))
+	assert.Equal(t, TypeCompressedBeaconState, entries[1].typ)
+	assert.DeepEqual(t, []byte{1, 2, 3}, entries[1].data)
+	assert.Equal(t, int64(headerSize), entries[1].offset)
+
+	_, err = readEntries(buf.Bytes()[:buf.Len()-1])
+	require.ErrorContains(t, "truncated e2store entry", err)
+	b := buf.Bytes()
+	b[7] = 1
+	_, err = readEntries(b)
+	require.ErrorIs(t, err, errReservedNotZero)
+}
+
+func TestSlotIndex_RoundTrip(t *testing.T) {
+	i := &slotIndex{startSlot: 8192, offsets: []int64{-100, 0, -20}}
+	b := i.marshal()
+	require.Equal(t, 8*5, len(b))
+	assert.Equal(t, uint64(3), binary.LittleEndian.Uint64(b[len(b)-8:]))
+	decoded, err := unmarshalSlotIndex(b)
+	require.NoError(t, err)
+	assert.DeepEqual(t, i, decoded)
+
+	_, err = unmarshalSlotIndex(b[:len(b)-8])
+	require.ErrorContains(t, "does not match its size", err)
+}
+
+func TestWriteRead(t *testing.T) {
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+
+	st, _ := util.DeterministicGenesisState(t, 8)
+	require.NoError(t, st.SetSlot(2*perEra))
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	for _, slot := range []primitives.Slot{perEra, perEra + 3, 2*perEra - 1} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blks = append(blks, blk)
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(buf, blks, st))
+	e, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), e.Number)
+	assert.Equal(t, 2*perEra, e.State.Slot())
+	require.Equal(t, len(blks), len(e.Blocks))
+	for i, blk := range blks {
+		want, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		got, err := e.Blocks[i].Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	require.NoError(t, st.SetSlot(perEra))
+	require.ErrorContains(t, "outside of era", Write(&bytes.Buffer{}, blks[:1], st))
+	require.NoError(t, st.SetSlot(perEra+1))
+	require.ErrorContains(t, "not at an era boundary", Write(&bytes.Buffer{}, nil, st))
+}
+
+func TestWriteRead_Genesis(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 8)
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(buf, nil, st))
+	e, err := Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), e.Number)
+	assert.Equal(t, 0, len(e.Blocks))
+	want, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	got, err := e.State.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestFileName(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 8)
+	name, err := FileName(st)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("mainnet-00000-%x.era", st.GenesisValidatorsRoot()[:4]), name)
+	n, err := NumberFromFileName("/tmp/" + name)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), n)
+
+	require.NoError(t, st.SetSlot(3*params.BeaconConfig().SlotsPerHistoricalRoot))
+	batch := &ethpb.HistoricalBatch{BlockRoots: st.BlockRoots(), StateRoots: st.StateRoots()}
+	root, err := batch.HashTreeRoot()
+	require.NoError(t, err)
+	name, err = FileName(st)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("mainnet-00003-%x.era", root[:4]), name)
+
+	n, err = NumberFromFileName("holesky-testnet-01234-0a0b0c0d.era")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234), n)
+	_, err = NumberFromFileName("blocks.era")
+	require.ErrorContains(t, "is not named", err)
+}
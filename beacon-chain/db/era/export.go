@@ -0,0 +1,167 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// finalizedChecker considers the blocks of the finalized block index as canonical.
+type finalizedChecker struct {
+	beaconDB *kv.Store
+}
+
+func (c *finalizedChecker) IsCanonical(ctx context.Context, root [32]byte) (bool, error) {
+	return c.beaconDB.IsFinalizedBlock(ctx, root), nil
+}
+
+// finalizedSlot is the current slot of an offline database, so that states are only replayed up to
+// the finalized checkpoint.
+type finalizedSlot primitives.Slot
+
+func (s finalizedSlot) CurrentSlot() primitives.Slot {
+	return primitives.Slot(s)
+}
+
+// Exporter writes the finalized history of a beacon database to era files.
+type Exporter struct {
+	beaconDB  *kv.Store
+	history   *stategen.CanonicalHistory
+	finalized primitives.Slot
+}
+
+// NewExporter returns an exporter of the finalized history of a beacon database. The beacon node must not
+// use the database while it is exported.
+func NewExporter(ctx context.Context, beaconDB *kv.Store) (*Exporter, error) {
+	cp, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalized, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		beaconDB:  beaconDB,
+		history:   stategen.NewCanonicalHistory(beaconDB, &finalizedChecker{beaconDB: beaconDB}, finalizedSlot(finalized)),
+		finalized: finalized,
+	}, nil
+}
+
+// LastEra returns the last era whose state is finalized, which is the last era that can be exported.
+func (e *Exporter) LastEra() uint64 {
+	return uint64(e.finalized / params.BeaconConfig().SlotsPerHistoricalRoot)
+}
+
+// Export writes the eras from start to end, inclusive, to era files in a directory, and returns their paths.
+func (e *Exporter) Export(ctx context.Context, dir string, start, end uint64) ([]string, error) {
+	if start > end {
+		return nil, fmt.Errorf("start era %d is greater than end era %d", start, end)
+	}
+	if last := e.LastEra(); end > last {
+		return nil, fmt.Errorf("era %d is not finalized, the last finalized era is %d", end, last)
+	}
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrapf(err, "could not create directory %s", dir)
+	}
+	paths := make([]string, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		st, blks, err := e.era(ctx, n)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read era %d", n)
+		}
+		name, err := FileName(st)
+		if err != nil {
+			return nil, err
+		}
+		buf := &bytes.Buffer{}
+		if err := Write(buf, blks, st); err != nil {
+			return nil, errors.Wrapf(err, "could not encode era %d", n)
+		}
+		path := filepath.Join(dir, name)
+		if err := file.WriteFile(path, buf.Bytes()); err != nil {
+			return nil, errors.Wrapf(err, "could not write era %d", n)
+		}
+		log.WithFields(logrus.Fields{
+			"era":    n,
+			"blocks": len(blks),
+			"path":   path,
+		}).Info("Exported era")
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// era returns the state at the end of an era and the canonical blocks of the era, read from the database.
+func (e *Exporter) era(ctx context.Context, n uint64) (state.BeaconState, []interfaces.ReadOnlySignedBeaconBlock, error) {
+	if n == 0 {
+		st, err := e.beaconDB.GenesisState(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if st == nil || st.IsNil() {
+			return nil, nil, errors.New("database has no genesis state, it was probably checkpoint synced")
+		}
+		return st, nil, nil
+	}
+
+	slot, err := StartSlot(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The era state is the state at the era boundary before any block of the next era is applied.
+	st, err := e.history.ReplayerForSlot(slot-1).ReplayToSlot(ctx, slot)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not replay state to slot %d", slot)
+	}
+
+	// The block roots of the state are the roots of the blocks of the era, with the root of the previous
+	// block repeated for empty slots.
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	roots := st.BlockRoots()
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	var previous [32]byte
+	for s := slot - perEra; s < slot; s++ {
+		root := bytesutil.ToBytes32(roots[s%perEra])
+		// The genesis block is derived from the genesis state of era 0.
+		if s == 0 || root == previous {
+			continue
+		}
+		previous = root
+		blk, err := e.beaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if err := blocks.BeaconBlockIsNil(blk); err != nil {
+			return nil, nil, fmt.Errorf("block %#x at slot %d is missing from the database", root, s)
+		}
+		// An era starting with empty slots begins with the root of a block of the previous era.
+		if blk.Block().Slot() != s {
+			continue
+		}
+		if blk.IsBlinded() {
+			return nil, nil, fmt.Errorf("block at slot %d is blinded, the beacon node must run with --%s to export eras",
+				s, features.SaveFullExecutionPayloads.Name)
+		}
+		blks = append(blks, blk)
+	}
+	return st, blks, nil
+}
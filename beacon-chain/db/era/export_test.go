@@ -0,0 +1,172 @@
+package era
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// setupHistory saves a chain of blocks at the given slots on top of a genesis state, the post-state of
+// the last block of era 1, and finalizes the last block. A block must be at the last slot of era 1.
+func setupHistory(t *testing.T, beaconDB *kv.Store, blockSlots []primitives.Slot) [][32]byte {
+	ctx := context.Background()
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	genesis, _ := util.DeterministicGenesisState(t, 8)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genesis))
+	parent, err := beaconDB.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	roots := [][32]byte{parent}
+	bySlot := map[primitives.Slot][32]byte{0: parent}
+	var eraHeader *ethpb.BeaconBlockHeader
+	for _, slot := range blockSlots {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = bytesutil.SafeCopyBytes(parent[:])
+		b.Block.StateRoot = bytesutil.PadTo([]byte{byte(slot)}, 32)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, parent)
+		bySlot[slot] = parent
+		if slot == perEra-1 {
+			bodyRoot, err := b.Block.Body.HashTreeRoot()
+			require.NoError(t, err)
+			eraHeader = &ethpb.BeaconBlockHeader{
+				Slot:       slot,
+				ParentRoot: b.Block.ParentRoot,
+				StateRoot:  b.Block.StateRoot,
+				BodyRoot:   bodyRoot[:],
+			}
+		}
+	}
+	require.NotNil(t, eraHeader)
+
+	// The block roots of the state repeat the root of the previous block for empty slots.
+	st := genesis.Copy()
+	require.NoError(t, st.SetSlot(perEra-1))
+	require.NoError(t, st.SetLatestBlockHeader(eraHeader))
+	blockRoots := st.BlockRoots()
+	latest := bySlot[0]
+	for s := primitives.Slot(0); s < perEra-1; s++ {
+		if root, ok := bySlot[s]; ok {
+			latest = root
+		}
+		blockRoots[s] = bytesutil.SafeCopyBytes(latest[:])
+	}
+	require.NoError(t, st.SetBlockRoots(blockRoots))
+
+	eraRoot := bySlot[perEra-1]
+	require.NoError(t, beaconDB.SaveState(ctx, st, eraRoot))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: perEra - 1, Root: eraRoot[:]}))
+	head := roots[len(roots)-1]
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: blockSlots[len(blockSlots)-1], Root: head[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 257, Root: head[:]}))
+	return roots
+}
+
+func TestExportImport(t *testing.T) {
+	setupConfig(t)
+	ctx := context.Background()
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	source := setupDB(t)
+	roots := setupHistory(t, source, []primitives.Slot{1, 2, perEra - 1, perEra + 1})
+
+	exporter, err := NewExporter(ctx, source)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), exporter.LastEra())
+	_, err = exporter.Export(ctx, t.TempDir(), 0, 2)
+	require.ErrorContains(t, "era 2 is not finalized", err)
+	paths, err := exporter.Export(ctx, t.TempDir(), 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(paths))
+	// The metrics of the database cannot be registered twice.
+	require.NoError(t, source.Close())
+
+	target := setupDB(t)
+	defer func() {
+		require.NoError(t, target.Close())
+	}()
+	require.ErrorContains(t, "era 0 must be imported first", Import(ctx, target, paths[1:]))
+	// Paths are imported in era order.
+	require.NoError(t, Import(ctx, target, []string{paths[1], paths[0]}))
+
+	for _, root := range roots[:4] {
+		assert.Equal(t, true, target.HasBlock(ctx, root))
+		assert.Equal(t, true, target.IsFinalizedBlock(ctx, root))
+	}
+	assert.Equal(t, false, target.HasBlock(ctx, roots[4]))
+	st, err := target.State(ctx, roots[3])
+	require.NoError(t, err)
+	assert.Equal(t, perEra, st.Slot())
+	cp, err := target.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(256), cp.Epoch)
+	assert.DeepEqual(t, roots[3][:], cp.Root)
+	head, err := target.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, perEra-1, head.Block().Slot())
+
+	// Importing again is a no-op.
+	require.NoError(t, Import(ctx, target, paths))
+}
+
+func TestImport_NotCanonical(t *testing.T) {
+	setupConfig(t)
+	ctx := context.Background()
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	source := setupDB(t)
+	setupHistory(t, source, []primitives.Slot{1, perEra - 1, perEra + 1})
+	exporter, err := NewExporter(ctx, source)
+	require.NoError(t, err)
+	paths, err := exporter.Export(ctx, t.TempDir(), 0, 1)
+	require.NoError(t, err)
+	require.NoError(t, source.Close())
+
+	// An era file whose blocks do not match the block roots of its state is rejected.
+	e, err := readFile(t, paths[1])
+	require.NoError(t, err)
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 1
+	b.Block.ProposerIndex = 1
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	e.Blocks[0] = blk
+	target := setupDB(t)
+	defer func() {
+		require.NoError(t, target.Close())
+	}()
+	require.NoError(t, Import(ctx, target, paths[:1]))
+	require.ErrorContains(t, "is not in the block roots of the era state", importEra(ctx, target, e))
+}
+
+// setupDB opens a beacon database, which must be closed by the test.
+func setupDB(t *testing.T) *kv.Store {
+	beaconDB, err := kv.NewKVStore(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	return beaconDB
+}
+
+// setupConfig renames the mainnet config, so that its embedded genesis state is not used.
+func setupConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ConfigName = "era-test"
+	params.OverrideBeaconConfig(cfg)
+}
+
+func readFile(t *testing.T, path string) (*Era, error) {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return Read(b)
+}
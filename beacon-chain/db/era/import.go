@@ -0,0 +1,187 @@
+package era
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// Import saves the blocks and states of era files into a beacon database, so that a node can start with
+// the history they contain instead of backfilling it from peers. Eras are imported in order and must be
+// contiguous, starting with era 0 on an empty database. After each era, its state becomes the finalized
+// checkpoint of the database, so an interrupted import can be resumed from the next era.
+func Import(ctx context.Context, beaconDB *kv.Store, paths []string) error {
+	if _, err := beaconDB.OriginCheckpointBlockRoot(ctx); err == nil {
+		return errors.New("database was initialized from a checkpoint, eras can only be imported into a database synced from genesis")
+	} else if !errors.Is(err, kv.ErrNotFoundOriginBlockRoot) {
+		return err
+	}
+
+	numbers := make(map[string]uint64, len(paths))
+	for _, path := range paths {
+		n, err := NumberFromFileName(path)
+		if err != nil {
+			return err
+		}
+		numbers[path] = n
+	}
+	sorted := make([]string, len(paths))
+	copy(sorted, paths)
+	sort.SliceStable(sorted, func(i, j int) bool { return numbers[sorted[i]] < numbers[sorted[j]] })
+
+	for _, path := range sorted {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b, err := file.ReadFileAsBytes(path)
+		if err != nil {
+			return err
+		}
+		e, err := Read(b)
+		if err != nil {
+			return errors.Wrapf(err, "could not read era file %s", path)
+		}
+		if e.Number != numbers[path] {
+			return fmt.Errorf("era file %s contains era %d", path, e.Number)
+		}
+		if err := importEra(ctx, beaconDB, e); err != nil {
+			return errors.Wrapf(err, "could not import era %d", e.Number)
+		}
+		log.WithFields(logrus.Fields{
+			"era":    e.Number,
+			"blocks": len(e.Blocks),
+		}).Info("Imported era")
+	}
+	return nil
+}
+
+func importEra(ctx context.Context, beaconDB *kv.Store, e *Era) error {
+	// The genesis state of some networks is embedded, so the genesis block tells whether era 0 was imported.
+	_, err := beaconDB.GenesisBlockRoot(ctx)
+	if err != nil && !errors.Is(err, kv.ErrNotFoundGenesisBlockRoot) {
+		return err
+	}
+	hasGenesis := err == nil
+	if e.Number == 0 && !hasGenesis {
+		return beaconDB.SaveGenesisData(ctx, e.State)
+	}
+	if !hasGenesis {
+		return errors.New("era 0 must be imported first into an empty database")
+	}
+	genesis, err := beaconDB.GenesisState(ctx)
+	if err != nil {
+		return err
+	}
+	if genesis == nil || genesis.IsNil() {
+		return errors.New("database has a genesis block but no genesis state")
+	}
+	if e.Number == 0 {
+		want, err := genesis.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		got, err := e.State.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return errors.New("genesis state of era 0 does not match the genesis state of the database")
+		}
+		return nil
+	}
+	if gvr := e.State.GenesisValidatorsRoot(); !bytes.Equal(gvr, genesis.GenesisValidatorsRoot()) {
+		return fmt.Errorf("era state genesis validators root %#x does not match the database", gvr)
+	}
+
+	// Blocks must be the canonical blocks of the era state, and link to the history already in the database.
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	roots := e.State.BlockRoots()
+	for i, blk := range e.Blocks {
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		slot := blk.Block().Slot()
+		if root != bytesutil.ToBytes32(roots[slot%perEra]) {
+			return fmt.Errorf("block %#x at slot %d is not in the block roots of the era state", root, slot)
+		}
+		parent := blk.Block().ParentRoot()
+		if i > 0 {
+			previous, err := e.Blocks[i-1].Block().HashTreeRoot()
+			if err != nil {
+				return err
+			}
+			if parent != previous {
+				return fmt.Errorf("parent %#x of block at slot %d is not the previous block of the era", parent, slot)
+			}
+		} else if !beaconDB.HasBlock(ctx, parent) {
+			return fmt.Errorf("parent %#x of block at slot %d is not in the database, the previous era must be imported first", parent, slot)
+		}
+	}
+	if err := beaconDB.SaveBlocks(ctx, e.Blocks); err != nil {
+		return errors.Wrap(err, "could not save blocks")
+	}
+
+	// The era state is saved for the last block of the era, like the state of a checkpoint sync origin.
+	slot := e.State.Slot()
+	latest := bytesutil.ToBytes32(roots[(slot-1)%perEra])
+	if !beaconDB.HasBlock(ctx, latest) {
+		return fmt.Errorf("latest block %#x of the era is not in the database, the previous era must be imported first", latest)
+	}
+	if !beaconDB.HasState(ctx, latest) {
+		if err := beaconDB.SaveState(ctx, e.State, latest); err != nil {
+			return errors.Wrap(err, "could not save state")
+		}
+		if err := beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: latest[:]}); err != nil {
+			return errors.Wrap(err, "could not save state summary")
+		}
+	}
+	return finalize(ctx, beaconDB, &ethpb.Checkpoint{Epoch: slots.ToEpoch(slot), Root: latest[:]})
+}
+
+// finalize raises the justified and finalized checkpoints and the head of the database to an imported era.
+func finalize(ctx context.Context, beaconDB *kv.Store, cp *ethpb.Checkpoint) error {
+	finalized, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if finalized.Epoch >= cp.Epoch {
+		return nil
+	}
+	justified, err := beaconDB.JustifiedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if justified.Epoch < cp.Epoch {
+		if err := beaconDB.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+			return errors.Wrap(err, "could not save justified checkpoint")
+		}
+	}
+	if err := beaconDB.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	head, err := beaconDB.HeadBlock(ctx)
+	if err != nil {
+		return err
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	blk, err := beaconDB.Block(ctx, root)
+	if err != nil {
+		return err
+	}
+	if blocks.BeaconBlockIsNil(head) == nil && head.Block().Slot() >= blk.Block().Slot() {
+		return nil
+	}
+	return beaconDB.SaveHeadBlockRoot(ctx, root)
+}
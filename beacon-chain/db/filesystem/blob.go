@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
@@ -25,11 +26,12 @@ import (
 )
 
 var (
-	errIndexOutOfBounds    = errors.New("blob index in file name >= MaxBlobsPerBlock")
-	errEmptyBlobWritten    = errors.New("zero bytes written to disk when saving blob sidecar")
-	errSidecarEmptySSZData = errors.New("sidecar marshalled to an empty ssz byte slice")
-	errNoBasePath          = errors.New("BlobStorage base path not specified in init")
-	errInvalidRootString   = errors.New("Could not parse hex string as a [32]byte")
+	errIndexOutOfBounds         = errors.New("blob index in file name >= MaxBlobsPerBlock")
+	errEmptyBlobWritten         = errors.New("zero bytes written to disk when saving blob sidecar")
+	errSidecarEmptySSZData      = errors.New("sidecar marshalled to an empty ssz byte slice")
+	errNoBasePath               = errors.New("BlobStorage base path not specified in init")
+	errInvalidRootString        = errors.New("Could not parse hex string as a [32]byte")
+	errBlobStorageCacheNotReady = errors.New("BlobStorage cache is not warmed up yet")
 )
 
 const (
@@ -89,6 +91,13 @@ func NewBlobStorage(opts ...BlobStorageOption) (*BlobStorage, error) {
 		return nil, err
 	}
 	b.pruner = pruner
+	// Data columns have their own retention window, only extended by a longer blob retention.
+	columnRetention := max(b.retentionEpochs, params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
+	columnPruner, err := newDataColumnPruner(b.fs, columnRetention)
+	if err != nil {
+		return nil, err
+	}
+	b.columnPruner = columnPruner
 	return b, nil
 }
 
@@ -99,6 +108,7 @@ type BlobStorage struct {
 	fsync           bool
 	fs              afero.Fs
 	pruner          *blobPruner
+	columnPruner    *dataColumnPruner
 }
 
 // WarmCache runs the prune routine with an expiration of slot of 0, so nothing will be pruned, but the pruner's cache
@@ -112,6 +122,11 @@ func (bs *BlobStorage) WarmCache() {
 		if err := bs.pruner.warmCache(); err != nil {
 			log.WithError(err).Error("Error encountered while warming up blob pruner cache")
 		}
+		if bs.columnPruner != nil {
+			if err := bs.columnPruner.prune(0); err != nil {
+				log.WithError(err).Error("Error encountered while warming up data column pruner cache")
+			}
+		}
 		log.WithField("elapsed", time.Since(start)).Info("Blob filesystem cache warm-up complete.")
 	}()
 }
@@ -131,6 +146,21 @@ func (bs *BlobStorage) WaitForSummarizer(ctx context.Context) (BlobStorageSummar
 	return bs.pruner.waitForCache(ctx)
 }
 
+// Usage returns the number of blob sidecars stored on disk and their total size in bytes. The usage is
+// only known once the cache of the pruner is warmed up.
+func (bs *BlobStorage) Usage() (uint64, uint64, error) {
+	if bs == nil || bs.pruner == nil {
+		return 0, 0, ErrBlobStorageSummarizerUnavailable
+	}
+	select {
+	case <-bs.pruner.cacheReady:
+	default:
+		return 0, 0, errBlobStorageCacheNotReady
+	}
+	n := bs.pruner.cache.count()
+	return n, n * fieldparams.BlobSidecarSize, nil
+}
+
 // Save saves blobs given a list of sidecars.
 func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 	startTime := time.Now()
@@ -11,11 +11,13 @@ import (
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/spf13/afero"
 )
 
@@ -239,11 +241,36 @@ func BenchmarkPruning(b *testing.B) {
 	}
 }
 
+func TestBlobStorage_Usage(t *testing.T) {
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+	testSidecars, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+
+	bs := NewEphemeralBlobStorage(t)
+	for _, sc := range testSidecars {
+		require.NoError(t, bs.Save(sc))
+	}
+	n, size, err := bs.Usage()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+	require.Equal(t, uint64(2*fieldparams.BlobSidecarSize), size)
+
+	_, _, err = (&BlobStorage{}).Usage()
+	require.ErrorIs(t, err, ErrBlobStorageSummarizerUnavailable)
+}
+
 func TestNewBlobStorage(t *testing.T) {
 	_, err := NewBlobStorage()
 	require.ErrorIs(t, err, errNoBasePath)
 	_, err = NewBlobStorage(WithBasePath(path.Join(t.TempDir(), "good")))
 	require.NoError(t, err)
+
+	// Data columns are kept for MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS, even with a shorter blob retention.
+	bs, err := NewBlobStorage(WithBasePath(path.Join(t.TempDir(), "short")), WithBlobRetentionEpochs(1))
+	require.NoError(t, err)
+	want, err := slots.EpochStart(params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest + retentionBuffer)
+	require.NoError(t, err)
+	require.Equal(t, want, bs.columnPruner.windowSize)
 }
 
 func TestConfig_WithinRetentionPeriod(t *testing.T) {
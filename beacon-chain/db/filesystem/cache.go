@@ -111,6 +111,13 @@ func (s *blobStorageCache) evict(key [32]byte) {
 	}
 }
 
+// count returns the number of blob sidecars in the cache.
+func (s *blobStorageCache) count() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return uint64(s.nBlobs)
+}
+
 func (s *blobStorageCache) updateMetrics(delta float64) {
 	s.nBlobs += delta
 	blobDiskCount.Set(s.nBlobs)
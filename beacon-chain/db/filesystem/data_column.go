@@ -0,0 +1,269 @@
+package filesystem
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+// dataColumnsDir is the subdirectory of the blob storage base path holding data column sidecars, laid out
+// like blobs as <root>/<column index>.ssz. The blob pruner ignores it, since it is not named after a root.
+const dataColumnsDir = "data-columns"
+
+// dataColumnSlotOffset is the offset of the slot in a marshaled DataColumnSidecar: the index (8 bytes) and
+// the offsets of the 3 variable size lists (3*4 bytes) precede the signed block header, which starts with the slot.
+const dataColumnSlotOffset = 20
+
+var errColumnIndexOutOfBounds = errors.New("data column index in file name >= NUMBER_OF_COLUMNS")
+
+// SaveDataColumn saves a verified data column sidecar.
+func (bs *BlobStorage) SaveDataColumn(column blocks.VerifiedRODataColumn) error {
+	startTime := time.Now()
+	fname := dataColumnNamer{root: column.BlockRoot(), index: column.Index}
+	exists, err := afero.Exists(bs.fs, fname.path())
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.WithFields(logging.DataColumnFields(column.RODataColumn)).Debug("Ignoring a duplicate data column sidecar save attempt")
+		return nil
+	}
+	if bs.columnPruner != nil {
+		bs.columnPruner.notify(column.BlockRoot(), column.Slot())
+	}
+
+	sidecarData, err := column.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize data column sidecar")
+	} else if len(sidecarData) == 0 {
+		return errSidecarEmptySSZData
+	}
+	if err := bs.fs.MkdirAll(fname.dir(), directoryPermissions); err != nil {
+		return err
+	}
+	partPath := fname.partPath(fmt.Sprintf("%p", sidecarData))
+	partialFile, err := bs.fs.Create(partPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to create partial file")
+	}
+	n, err := partialFile.Write(sidecarData)
+	if err == nil && bs.fsync {
+		err = partialFile.Sync()
+	}
+	if closeErr := partialFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n != len(sidecarData) {
+		err = fmt.Errorf("failed to write the full bytes of sidecarData, wrote only %d of %d bytes", n, len(sidecarData))
+	}
+	if err == nil {
+		err = bs.fs.Rename(partPath, fname.path())
+	}
+	if err != nil {
+		if rmErr := bs.fs.Remove(partPath); rmErr != nil {
+			log.WithError(rmErr).WithField("partPath", partPath).Debug("Could not remove partial file")
+		}
+		return errors.Wrap(err, "failed to write data column sidecar")
+	}
+	dataColumnsWrittenCounter.Inc()
+	dataColumnSaveLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	return nil
+}
+
+// GetDataColumn retrieves a single DataColumnSidecar by its root and index.
+// Since only fully verified data columns are saved, the return value is always a VerifiedRODataColumn.
+func (bs *BlobStorage) GetDataColumn(root [32]byte, idx uint64) (blocks.VerifiedRODataColumn, error) {
+	encoded, err := afero.ReadFile(bs.fs, dataColumnNamer{root: root, index: idx}.path())
+	if err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	s := &ethpb.DataColumnSidecar{}
+	if err := s.UnmarshalSSZ(encoded); err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	ro, err := blocks.NewRODataColumnWithRoot(s, root)
+	if err != nil {
+		return blocks.VerifiedRODataColumn{}, err
+	}
+	return verification.DataColumnSidecarNoop(ro)
+}
+
+// DataColumnIndices returns a bitmap of the data column indices stored for the given root.
+func (bs *BlobStorage) DataColumnIndices(root [32]byte) ([fieldparams.NumberOfColumns]bool, error) {
+	var mask [fieldparams.NumberOfColumns]bool
+	entries, err := afero.ReadDir(bs.fs, dataColumnNamer{root: root}.dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return mask, nil
+		}
+		return mask, err
+	}
+	for i := range entries {
+		if entries[i].IsDir() || !filterSsz(entries[i].Name()) {
+			continue
+		}
+		u, err := idxFromPath(entries[i].Name())
+		if err != nil {
+			return mask, errors.Wrapf(err, "unexpected directory entry breaks listing, %s", entries[i].Name())
+		}
+		if u >= fieldparams.NumberOfColumns {
+			return mask, errColumnIndexOutOfBounds
+		}
+		mask[u] = true
+	}
+	return mask, nil
+}
+
+// RemoveDataColumns removes all data columns for a given root.
+func (bs *BlobStorage) RemoveDataColumns(root [32]byte) error {
+	return bs.fs.RemoveAll(dataColumnNamer{root: root}.dir())
+}
+
+type dataColumnNamer struct {
+	root  [32]byte
+	index uint64
+}
+
+func (p dataColumnNamer) dir() string {
+	return path.Join(dataColumnsDir, rootString(p.root))
+}
+
+func (p dataColumnNamer) partPath(entropy string) string {
+	return path.Join(p.dir(), fmt.Sprintf("%s-%d.%s", entropy, p.index, partExt))
+}
+
+func (p dataColumnNamer) path() string {
+	return path.Join(p.dir(), fmt.Sprintf("%d.%s", p.index, sszExt))
+}
+
+// dataColumnPruner removes the data columns of blocks which fell out of the retention window. It tracks the
+// slot of every stored root, which is read back from disk for the roots saved before the node started.
+type dataColumnPruner struct {
+	sync.Mutex
+	prunedBefore atomic.Uint64
+	windowSize   primitives.Slot
+	slots        map[[32]byte]primitives.Slot
+	fs           afero.Fs
+}
+
+func newDataColumnPruner(fs afero.Fs, retain primitives.Epoch) (*dataColumnPruner, error) {
+	r, err := slots.EpochStart(retain + retentionBuffer)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set retentionSlots")
+	}
+	return &dataColumnPruner{fs: fs, windowSize: r, slots: make(map[[32]byte]primitives.Slot)}, nil
+}
+
+// notify records the slot of the root and prunes old data columns in the background whenever the
+// retention window moves forward.
+func (p *dataColumnPruner) notify(root [32]byte, slot primitives.Slot) {
+	p.Lock()
+	p.slots[root] = slot
+	p.Unlock()
+	pruned := uint64(windowMin(slot, p.windowSize))
+	if p.prunedBefore.Swap(pruned) >= pruned {
+		return
+	}
+	go func() {
+		if err := p.prune(primitives.Slot(pruned)); err != nil {
+			log.WithError(err).Errorf("Failed to prune data columns from slot %d", slot)
+		}
+	}()
+}
+
+// prune removes the data columns of all roots with a slot lower than pruneBefore.
+func (p *dataColumnPruner) prune(pruneBefore primitives.Slot) error {
+	p.Lock()
+	defer p.Unlock()
+	exists, err := afero.DirExists(p.fs, dataColumnsDir)
+	if err != nil || !exists {
+		return err
+	}
+	entries, err := listDir(p.fs, dataColumnsDir)
+	if err != nil {
+		return errors.Wrap(err, "unable to list data columns directory")
+	}
+	pruned, failed := 0, 0
+	for _, dir := range filter(entries, filterRoot) {
+		n, err := p.tryPruneDir(path.Join(dataColumnsDir, dir), pruneBefore)
+		if err != nil {
+			failed++
+			log.WithError(err).WithField("directory", dir).Error("Unable to prune data column directory")
+		}
+		pruned += n
+	}
+	if pruneBefore > 0 {
+		log.WithFields(logrus.Fields{
+			"upToEpoch":    slots.ToEpoch(pruneBefore),
+			"filesRemoved": pruned,
+		}).Debug("Pruned old data columns")
+		dataColumnsPrunedCounter.Add(float64(pruned))
+	}
+	if failed > 0 {
+		return errors.Wrapf(errPruningFailures, "pruning failed for %d root directories", failed)
+	}
+	return nil
+}
+
+func (p *dataColumnPruner) tryPruneDir(dir string, pruneBefore primitives.Slot) (int, error) {
+	root, err := rootFromDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := listDir(p.fs, dir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list data columns in directory %s", dir)
+	}
+	slot, ok := p.slots[root]
+	if !ok {
+		sszFiles := filter(entries, filterSsz)
+		if len(sszFiles) == 0 {
+			return 0, nil
+		}
+		slot, err = dataColumnSlotFromFile(path.Join(dir, sszFiles[0]), p.fs)
+		if err != nil {
+			return 0, errors.Wrapf(err, "slot could not be read from data column file %s", sszFiles[0])
+		}
+		p.slots[root] = slot
+	}
+	if shouldRetain(slot, pruneBefore) {
+		return 0, nil
+	}
+	if err := p.fs.RemoveAll(dir); err != nil {
+		return 0, errors.Wrapf(err, "unable to remove data column directory %s", dir)
+	}
+	delete(p.slots, root)
+	return len(filter(entries, filterSsz)), nil
+}
+
+func dataColumnSlotFromFile(file string, fs afero.Fs) (primitives.Slot, error) {
+	f, err := fs.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Errorf("Could not close data column file")
+		}
+	}()
+	b := make([]byte, 8)
+	if _, err := f.ReadAt(b, dataColumnSlotOffset); err != nil {
+		return 0, err
+	}
+	return primitives.Slot(binary.LittleEndian.Uint64(b)), nil
+}
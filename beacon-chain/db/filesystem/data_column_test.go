@@ -0,0 +1,78 @@
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/spf13/afero"
+)
+
+func TestBlobStorage_SaveDataColumn(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 2)
+	verified := verification.FakeVerifyDataColumnSliceForTest(t, columns)
+
+	fs, bs := NewEphemeralBlobStorageWithFs(t)
+	require.NoError(t, bs.SaveDataColumn(verified[3]))
+	require.NoError(t, bs.SaveDataColumn(verified[3]))
+	require.NoError(t, bs.SaveDataColumn(verified[127]))
+
+	got, err := bs.GetDataColumn(verified[3].BlockRoot(), 3)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, verified[3].DataColumnSidecar, got.DataColumnSidecar)
+	_, err = bs.GetDataColumn(verified[3].BlockRoot(), 4)
+	require.NotNil(t, err)
+
+	indices, err := bs.DataColumnIndices(verified[3].BlockRoot())
+	require.NoError(t, err)
+	var expected [fieldparams.NumberOfColumns]bool
+	expected[3], expected[127] = true, true
+	require.Equal(t, expected, indices)
+
+	// Data columns don't show up as blobs.
+	blobIndices, err := bs.Indices(verified[3].BlockRoot())
+	require.NoError(t, err)
+	require.Equal(t, [fieldparams.MaxBlobsPerBlock]bool{}, blobIndices)
+
+	require.NoError(t, bs.RemoveDataColumns(verified[3].BlockRoot()))
+	indices, err = bs.DataColumnIndices(verified[3].BlockRoot())
+	require.NoError(t, err)
+	require.Equal(t, [fieldparams.NumberOfColumns]bool{}, indices)
+	exists, err := afero.DirExists(fs, dataColumnsDir)
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+}
+
+func TestDataColumnPruner(t *testing.T) {
+	_, oldColumns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	_, newColumns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{'a'}, 64, 1)
+	oldColumn := verification.FakeVerifyDataColumnSliceForTest(t, oldColumns)[0]
+	newColumn := verification.FakeVerifyDataColumnSliceForTest(t, newColumns)[0]
+
+	fs := afero.NewMemMapFs()
+	bs := &BlobStorage{fs: fs}
+	require.NoError(t, bs.SaveDataColumn(oldColumn))
+	require.NoError(t, bs.SaveDataColumn(newColumn))
+
+	// A fresh pruner reads the slots back from disk.
+	p, err := newDataColumnPruner(fs, 0)
+	require.NoError(t, err)
+	require.NoError(t, p.prune(0))
+	require.Equal(t, oldColumn.Slot(), p.slots[oldColumn.BlockRoot()])
+	require.Equal(t, newColumn.Slot(), p.slots[newColumn.BlockRoot()])
+
+	pruneBefore := primitives.Slot(params.BeaconConfig().SlotsPerEpoch)
+	require.NoError(t, p.prune(pruneBefore))
+	indices, err := bs.DataColumnIndices(oldColumn.BlockRoot())
+	require.NoError(t, err)
+	require.Equal(t, [fieldparams.NumberOfColumns]bool{}, indices)
+	indices, err = bs.DataColumnIndices(newColumn.BlockRoot())
+	require.NoError(t, err)
+	require.Equal(t, true, indices[newColumn.Index])
+	_, ok := p.slots[oldColumn.BlockRoot()]
+	require.Equal(t, false, ok)
+}
@@ -16,7 +16,11 @@ func NewEphemeralBlobStorage(t testing.TB) *BlobStorage {
 	if err != nil {
 		t.Fatal("test setup issue", err)
 	}
-	return &BlobStorage{fs: fs, pruner: pruner}
+	columnPruner, err := newDataColumnPruner(fs, params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
+	if err != nil {
+		t.Fatal("test setup issue", err)
+	}
+	return &BlobStorage{fs: fs, pruner: pruner, columnPruner: columnPruner}
 }
 
 // NewEphemeralBlobStorageWithFs can be used by tests that want access to the virtual filesystem
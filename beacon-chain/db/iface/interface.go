@@ -11,6 +11,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	rewardstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards/types"
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -61,6 +63,11 @@ type ReadOnlyDatabase interface {
 	// light client operations
 	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) (map[uint64]*ethpbv2.LightClientUpdateWithVersion, error)
 	LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdateWithVersion, error)
+	// Rewards indexer operations.
+	ValidatorRewards(ctx context.Context, indices []primitives.ValidatorIndex, start, end primitives.Epoch) ([]*rewardstypes.ValidatorEpochRewards, error)
+	LastIndexedRewardsEpoch(ctx context.Context) (primitives.Epoch, error)
+	// Validator monitor operations.
+	ValidatorMonitorHistory(ctx context.Context, idx primitives.ValidatorIndex, start, end primitives.Epoch) ([]*monitortypes.ValidatorEpochSummary, error)
 
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
@@ -99,6 +106,11 @@ type NoHeadAccessDatabase interface {
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
 	// light client operations
 	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdateWithVersion) error
+	// Rewards indexer operations.
+	SaveValidatorRewards(ctx context.Context, epoch primitives.Epoch, rewards []*rewardstypes.ValidatorEpochRewards) error
+	PruneValidatorRewards(ctx context.Context, before primitives.Epoch) error
+	// Validator monitor operations.
+	SaveValidatorMonitorHistory(ctx context.Context, summaries []*monitortypes.ValidatorEpochSummary) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
 }
@@ -162,10 +174,19 @@ type SlasherDatabase interface {
 	PruneProposalsAtEpoch(
 		ctx context.Context, maxEpoch primitives.Epoch,
 	) (numPruned uint, err error)
+	PruneDetectedSlashingsAtEpoch(
+		ctx context.Context, maxEpoch primitives.Epoch,
+	) (numPruned uint, err error)
 	HighestAttestations(
 		ctx context.Context,
 		indices []primitives.ValidatorIndex,
 	) ([]*ethpb.HighestAttestation, error)
+	SaveDetectedSlashings(
+		ctx context.Context, slashings []*slashertypes.DetectedSlashing,
+	) error
+	DetectedSlashings(
+		ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.DetectedSlashing, error)
 	DatabasePath() string
 	ClearDB() error
 	Migrate(ctx context.Context, headEpoch, maxPruningEpoch primitives.Epoch, batchSize int) error
@@ -20,6 +20,12 @@ var (
 	// Light Client Updates Bucket
 	lightClientUpdatesBucket = []byte("light-client-updates")
 
+	// Per validator and epoch rewards saved by the rewards indexer.
+	validatorRewardsBucket = []byte("validator-rewards")
+
+	// Per validator and epoch performance of the validators tracked by the validator monitor.
+	validatorMonitorHistoryBucket = []byte("validator-monitor-history")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
@@ -40,6 +46,7 @@ var (
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
+	lastIndexedRewardsEpochKey = []byte("last-indexed-rewards-epoch")
 
 	// Below keys are used to identify objects are to be fork compatible.
 	// Objects that are only compatible with specific forks should be prefixed with such keys.
@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Summaries are stored under the same keys as validator rewards, the validator index followed by the epoch.
+// The value is a byte of flags followed by six little endian uint64 values.
+const validatorMonitorSummaryLength = 49
+
+const (
+	summaryAttestationIncluded = 1 << iota
+	summaryTimelySource
+	summaryTimelyTarget
+	summaryTimelyHead
+)
+
+// SaveValidatorMonitorHistory saves the epoch summaries of validators tracked by the validator monitor.
+func (s *Store) SaveValidatorMonitorHistory(ctx context.Context, summaries []*monitortypes.ValidatorEpochSummary) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorMonitorHistory")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorMonitorHistoryBucket)
+		for _, summary := range summaries {
+			if err := bkt.Put(validatorRewardsKey(summary.ValidatorIndex, summary.Epoch), encodeValidatorEpochSummary(summary)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ValidatorMonitorHistory returns the saved epoch summaries of a validator from the start to the end epoch inclusive,
+// in increasing epoch order.
+func (s *Store) ValidatorMonitorHistory(
+	ctx context.Context,
+	idx primitives.ValidatorIndex,
+	start, end primitives.Epoch,
+) ([]*monitortypes.ValidatorEpochSummary, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorMonitorHistory")
+	defer span.End()
+
+	if start > end {
+		return nil, errors.Errorf("start epoch %d is greater than end epoch %d", start, end)
+	}
+	var summaries []*monitortypes.ValidatorEpochSummary
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(validatorMonitorHistoryBucket).Cursor()
+		endKey := validatorRewardsKey(idx, end)
+		for k, v := c.Seek(validatorRewardsKey(idx, start)); k != nil && bytes.Compare(k, endKey) <= 0; k, v = c.Next() {
+			summary, err := decodeValidatorEpochSummary(k, v)
+			if err != nil {
+				return err
+			}
+			summaries = append(summaries, summary)
+		}
+		return nil
+	})
+	return summaries, err
+}
+
+func encodeValidatorEpochSummary(summary *monitortypes.ValidatorEpochSummary) []byte {
+	enc := make([]byte, validatorMonitorSummaryLength)
+	if summary.AttestationIncluded {
+		enc[0] |= summaryAttestationIncluded
+	}
+	if summary.TimelySource {
+		enc[0] |= summaryTimelySource
+	}
+	if summary.TimelyTarget {
+		enc[0] |= summaryTimelyTarget
+	}
+	if summary.TimelyHead {
+		enc[0] |= summaryTimelyHead
+	}
+	for i, v := range []uint64{
+		summary.InclusionDistance,
+		summary.ProposedBlocks,
+		summary.SyncCommitteeExpected,
+		summary.SyncCommitteeIncluded,
+		summary.StartBalance,
+		summary.EndBalance,
+	} {
+		binary.LittleEndian.PutUint64(enc[1+i*8:], v)
+	}
+	return enc
+}
+
+func decodeValidatorEpochSummary(key, enc []byte) (*monitortypes.ValidatorEpochSummary, error) {
+	if len(key) != validatorRewardsKeyLength || len(enc) != validatorMonitorSummaryLength {
+		return nil, errors.Errorf("invalid validator monitor entry with key length %d and value length %d", len(key), len(enc))
+	}
+	field := func(i int) uint64 {
+		return binary.LittleEndian.Uint64(enc[1+i*8:])
+	}
+	return &monitortypes.ValidatorEpochSummary{
+		ValidatorIndex:        primitives.ValidatorIndex(binary.BigEndian.Uint64(key[:8])),
+		Epoch:                 primitives.Epoch(binary.BigEndian.Uint64(key[8:])),
+		AttestationIncluded:   enc[0]&summaryAttestationIncluded != 0,
+		TimelySource:          enc[0]&summaryTimelySource != 0,
+		TimelyTarget:          enc[0]&summaryTimelyTarget != 0,
+		TimelyHead:            enc[0]&summaryTimelyHead != 0,
+		InclusionDistance:     field(0),
+		ProposedBlocks:        field(1),
+		SyncCommitteeExpected: field(2),
+		SyncCommitteeIncluded: field(3),
+		StartBalance:          field(4),
+		EndBalance:            field(5),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ValidatorMonitorHistory(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	summaries := []*monitortypes.ValidatorEpochSummary{
+		{ValidatorIndex: 3, Epoch: 5, AttestationIncluded: true, InclusionDistance: 1, TimelySource: true, TimelyTarget: true, StartBalance: 32e9, EndBalance: 32e9 + 10},
+		{ValidatorIndex: 3, Epoch: 6, ProposedBlocks: 1, SyncCommitteeExpected: 64, SyncCommitteeIncluded: 60, StartBalance: 32e9 + 10, EndBalance: 32e9 - 5},
+		{ValidatorIndex: 3, Epoch: 7, AttestationIncluded: true, TimelyHead: true},
+		{ValidatorIndex: 4, Epoch: 6, AttestationIncluded: true},
+	}
+	require.NoError(t, db.SaveValidatorMonitorHistory(ctx, summaries))
+
+	got, err := db.ValidatorMonitorHistory(ctx, 3, 5, 6)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(got))
+	require.DeepEqual(t, summaries[0], got[0])
+	require.DeepEqual(t, summaries[1], got[1])
+	require.Equal(t, int64(-15), got[1].BalanceChange())
+
+	got, err = db.ValidatorMonitorHistory(ctx, 4, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.Equal(t, primitives.ValidatorIndex(4), got[0].ValidatorIndex)
+
+	got, err = db.ValidatorMonitorHistory(ctx, 5, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+
+	_, err = db.ValidatorMonitorHistory(ctx, 3, 6, 5)
+	require.ErrorContains(t, "greater than end epoch", err)
+}
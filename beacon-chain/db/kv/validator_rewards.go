@@ -0,0 +1,171 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+	rewardstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNotFoundRewardsIndex is returned when no epoch has been indexed by the rewards indexer.
+var ErrNotFoundRewardsIndex = errors.Wrap(ErrNotFound, "rewards index")
+
+// The rewards of a validator for an epoch are stored under the validator index followed by the epoch,
+// both big endian, so that the rewards of a validator over a range of epochs are contiguous.
+const validatorRewardsKeyLength = 16
+
+// The six reward components are each stored as a little endian int64.
+const validatorRewardsValueLength = 48
+
+// SaveValidatorRewards saves the rewards of validators for an epoch and marks the epoch as the last indexed one.
+func (s *Store) SaveValidatorRewards(ctx context.Context, epoch primitives.Epoch, rewards []*rewardstypes.ValidatorEpochRewards) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorRewards")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorRewardsBucket)
+		for _, r := range rewards {
+			if r.Epoch != epoch {
+				return errors.Errorf("rewards for epoch %d saved as epoch %d", r.Epoch, epoch)
+			}
+			if err := bkt.Put(validatorRewardsKey(r.ValidatorIndex, r.Epoch), encodeValidatorRewards(r)); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(chainMetadataBucket).Put(lastIndexedRewardsEpochKey, bytesutil.Uint64ToBytesBigEndian(uint64(epoch)))
+	})
+}
+
+// ValidatorRewards returns the indexed rewards of the given validators for the epochs from start to end inclusive,
+// ordered by validator index and epoch. Epochs in which a validator had no rewards or penalties are omitted.
+func (s *Store) ValidatorRewards(
+	ctx context.Context,
+	indices []primitives.ValidatorIndex,
+	start, end primitives.Epoch,
+) ([]*rewardstypes.ValidatorEpochRewards, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorRewards")
+	defer span.End()
+
+	if start > end {
+		return nil, errors.Errorf("start epoch %d is greater than end epoch %d", start, end)
+	}
+	sorted := make([]primitives.ValidatorIndex, len(indices))
+	copy(sorted, indices)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	var rewards []*rewardstypes.ValidatorEpochRewards
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(validatorRewardsBucket).Cursor()
+		for i, idx := range sorted {
+			if i > 0 && idx == sorted[i-1] {
+				continue
+			}
+			endKey := validatorRewardsKey(idx, end)
+			for k, v := c.Seek(validatorRewardsKey(idx, start)); k != nil && bytes.Compare(k, endKey) <= 0; k, v = c.Next() {
+				r, err := decodeValidatorRewards(k, v)
+				if err != nil {
+					return err
+				}
+				rewards = append(rewards, r)
+			}
+		}
+		return nil
+	})
+	return rewards, err
+}
+
+// PruneValidatorRewards deletes the indexed rewards of every validator for the epochs before the given epoch.
+// The rewards of each validator are contiguous and ordered by epoch, so only the oldest entries of each
+// validator are visited.
+func (s *Store) PruneValidatorRewards(ctx context.Context, before primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.PruneValidatorRewards")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorRewardsBucket)
+		c := bkt.Cursor()
+		var stale [][]byte
+		for k, _ := c.First(); k != nil; {
+			if len(k) != validatorRewardsKeyLength {
+				return errors.Errorf("invalid validator rewards key length %d", len(k))
+			}
+			if primitives.Epoch(binary.BigEndian.Uint64(k[8:])) < before {
+				stale = append(stale, bytes.Clone(k))
+				k, _ = c.Next()
+				continue
+			}
+			idx := binary.BigEndian.Uint64(k[:8])
+			if idx == math.MaxUint64 {
+				break
+			}
+			k, _ = c.Seek(validatorRewardsKey(primitives.ValidatorIndex(idx+1), 0))
+		}
+		for _, k := range stale {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LastIndexedRewardsEpoch returns the last epoch saved by the rewards indexer.
+func (s *Store) LastIndexedRewardsEpoch(ctx context.Context) (primitives.Epoch, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastIndexedRewardsEpoch")
+	defer span.End()
+
+	var epoch primitives.Epoch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(lastIndexedRewardsEpochKey)
+		if enc == nil {
+			return ErrNotFoundRewardsIndex
+		}
+		epoch = primitives.Epoch(binary.BigEndian.Uint64(enc))
+		return nil
+	})
+	return epoch, err
+}
+
+func validatorRewardsKey(idx primitives.ValidatorIndex, epoch primitives.Epoch) []byte {
+	key := make([]byte, validatorRewardsKeyLength)
+	binary.BigEndian.PutUint64(key[:8], uint64(idx))
+	binary.BigEndian.PutUint64(key[8:], uint64(epoch))
+	return key
+}
+
+func encodeValidatorRewards(r *rewardstypes.ValidatorEpochRewards) []byte {
+	enc := make([]byte, validatorRewardsValueLength)
+	for i, v := range []int64{r.Source, r.Target, r.Head, r.Inactivity, r.SyncCommittee, r.Proposer} {
+		binary.LittleEndian.PutUint64(enc[i*8:], uint64(v))
+	}
+	return enc
+}
+
+func decodeValidatorRewards(key, enc []byte) (*rewardstypes.ValidatorEpochRewards, error) {
+	if len(key) != validatorRewardsKeyLength || len(enc) != validatorRewardsValueLength {
+		return nil, errors.Errorf("invalid validator rewards entry with key length %d and value length %d", len(key), len(enc))
+	}
+	component := func(i int) int64 {
+		return int64(binary.LittleEndian.Uint64(enc[i*8:])) // lint:ignore uintcast -- Stored from an int64.
+	}
+	return &rewardstypes.ValidatorEpochRewards{
+		ValidatorIndex: primitives.ValidatorIndex(binary.BigEndian.Uint64(key[:8])),
+		Epoch:          primitives.Epoch(binary.BigEndian.Uint64(key[8:])),
+		Source:         component(0),
+		Target:         component(1),
+		Head:           component(2),
+		Inactivity:     component(3),
+		SyncCommittee:  component(4),
+		Proposer:       component(5),
+	}, nil
+}
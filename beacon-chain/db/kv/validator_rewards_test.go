@@ -0,0 +1,77 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	rewardstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ValidatorRewards(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	_, err := db.LastIndexedRewardsEpoch(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for epoch := primitives.Epoch(10); epoch <= 12; epoch++ {
+		rewards := []*rewardstypes.ValidatorEpochRewards{
+			{ValidatorIndex: 1, Epoch: epoch, Source: 10, Target: 20, Head: 5, Proposer: int64(epoch)},
+			{ValidatorIndex: 2, Epoch: epoch, Source: -10, Target: -20, Inactivity: -3, SyncCommittee: -7},
+		}
+		require.NoError(t, db.SaveValidatorRewards(ctx, epoch, rewards))
+	}
+	last, err := db.LastIndexedRewardsEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(12), last)
+
+	got, err := db.ValidatorRewards(ctx, []primitives.ValidatorIndex{2, 1, 3}, 11, 20)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(got))
+	require.DeepEqual(t, &rewardstypes.ValidatorEpochRewards{ValidatorIndex: 1, Epoch: 11, Source: 10, Target: 20, Head: 5, Proposer: 11}, got[0])
+	require.Equal(t, primitives.Epoch(12), got[1].Epoch)
+	require.DeepEqual(t, &rewardstypes.ValidatorEpochRewards{ValidatorIndex: 2, Epoch: 11, Source: -10, Target: -20, Inactivity: -3, SyncCommittee: -7}, got[2])
+	require.Equal(t, int64(-40), got[3].Total())
+
+	got, err = db.ValidatorRewards(ctx, []primitives.ValidatorIndex{1}, 10, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.Equal(t, primitives.Epoch(10), got[0].Epoch)
+
+	_, err = db.ValidatorRewards(ctx, []primitives.ValidatorIndex{1}, 11, 10)
+	require.ErrorContains(t, "greater than end epoch", err)
+
+	require.ErrorContains(t, "saved as epoch", db.SaveValidatorRewards(ctx, 13, []*rewardstypes.ValidatorEpochRewards{{Epoch: 14}}))
+}
+
+func TestStore_PruneValidatorRewards(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveValidatorRewards(ctx, 11, []*rewardstypes.ValidatorEpochRewards{{ValidatorIndex: 2, Epoch: 11, Head: 1}}))
+	for epoch := primitives.Epoch(10); epoch <= 14; epoch++ {
+		rewards := []*rewardstypes.ValidatorEpochRewards{
+			{ValidatorIndex: 1, Epoch: epoch, Source: 10},
+			{ValidatorIndex: 3, Epoch: epoch, Target: -20},
+		}
+		require.NoError(t, db.SaveValidatorRewards(ctx, epoch, rewards))
+	}
+
+	require.NoError(t, db.PruneValidatorRewards(ctx, 13))
+	got, err := db.ValidatorRewards(ctx, []primitives.ValidatorIndex{1, 2, 3}, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(got))
+	for i, want := range []struct {
+		idx   primitives.ValidatorIndex
+		epoch primitives.Epoch
+	}{{1, 13}, {1, 14}, {3, 13}, {3, 14}} {
+		require.Equal(t, want.idx, got[i].ValidatorIndex)
+		require.Equal(t, want.epoch, got[i].Epoch)
+	}
+	// Pruning does not change the last indexed epoch.
+	last, err := db.LastIndexedRewardsEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(14), last)
+}
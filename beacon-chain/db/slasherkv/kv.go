@@ -78,6 +78,7 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			attestationDataRootsBucket,
 			proposalRecordsBucket,
 			slasherChunksBucket,
+			detectedSlashingsBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -157,6 +157,32 @@ func (s *Store) PruneProposalsAtEpoch(
 	return
 }
 
+// PruneDetectedSlashingsAtEpoch deletes all detected slashings from the slasher DB with an offense epoch
+// less than or equal to the specified epoch.
+func (s *Store) PruneDetectedSlashingsAtEpoch(
+	_ context.Context, maxEpoch primitives.Epoch,
+) (numPruned uint, err error) {
+	encodedEndPruneEpoch := make([]byte, 8)
+	binary.BigEndian.PutUint64(encodedEndPruneEpoch, uint64(maxEpoch))
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(detectedSlashingsBucket).Cursor()
+		// Detected slashings are keyed by (epoch ++ root), so the cursor visits them in epoch order.
+		for k, _ := c.First(); k != nil; k, _ = c.First() {
+			if uint64PrefixGreaterThan(k, encodedEndPruneEpoch) {
+				return nil
+			}
+			if err := c.Delete(); err != nil {
+				return err
+			}
+			slasherDetectedSlashingsPrunedTotal.Inc()
+			numPruned++
+		}
+		return nil
+	})
+	return
+}
+
 func slotFromProposalKey(key []byte) primitives.Slot {
 	return primitives.Slot(binary.BigEndian.Uint64(key[:8]))
 }
@@ -8,6 +8,7 @@ import (
 	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -216,3 +217,39 @@ func TestStore_PruneAttestations_OK(t *testing.T) {
 		}
 	})
 }
+
+func TestStore_PruneDetectedSlashingsAtEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	numPruned, err := beaconDB.PruneDetectedSlashingsAtEpoch(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint(0), numPruned)
+
+	detected := make([]*slashertypes.DetectedSlashing, 0)
+	for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+		slot, err := slots.EpochStart(epoch)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			detected = append(detected, &slashertypes.DetectedSlashing{
+				Epoch: epoch,
+				ProposerSlashing: &ethpb.ProposerSlashing{
+					Header_1: createProposalWrapper(t, slot, primitives.ValidatorIndex(i), []byte{1}).SignedBeaconBlockHeader,
+					Header_2: createProposalWrapper(t, slot, primitives.ValidatorIndex(i), []byte{2}).SignedBeaconBlockHeader,
+				},
+			})
+		}
+	}
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, detected))
+
+	numPruned, err = beaconDB.PruneDetectedSlashingsAtEpoch(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint(4), numPruned)
+
+	slashings, err := beaconDB.DetectedSlashings(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(slashings))
+	for _, slashing := range slashings {
+		require.Equal(t, true, slashing.Epoch > 2)
+	}
+}
@@ -24,4 +24,8 @@ var (
 	// value: (encoded) SignedBlockHeaderWrapper
 	proposalRecordsBucket = []byte("proposal-records")
 	slasherChunksBucket   = []byte("slasher-chunks")
+
+	// key: (encoded) Epoch + slashing root
+	// value: slashing kind + (compressed) slashing
+	detectedSlashingsBucket = []byte("detected-slashings")
 )
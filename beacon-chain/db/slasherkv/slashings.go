@@ -0,0 +1,165 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Kinds of slashings stored in the detected slashings bucket, as the first byte of the value.
+const (
+	proposerSlashingKind byte = iota
+	attesterSlashingKind
+	attesterSlashingElectraKind
+)
+
+// SaveDetectedSlashings saves the slashings detected by the slasher, keyed by the epoch of the offense.
+// Saving the same slashing twice is a no-op.
+func (s *Store) SaveDetectedSlashings(ctx context.Context, slashings []*slashertypes.DetectedSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveDetectedSlashings")
+	defer span.End()
+
+	encodedKeys := make([][]byte, len(slashings))
+	encodedSlashings := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		key, enc, err := encodeDetectedSlashing(slashing)
+		if err != nil {
+			return err
+		}
+		encodedKeys[i] = key
+		encodedSlashings[i] = enc
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(detectedSlashingsBucket)
+		for i := range slashings {
+			if err := bkt.Put(encodedKeys[i], encodedSlashings[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DetectedSlashings returns the slashings detected by the slasher for offenses
+// between the start and end epochs, inclusive, in epoch order.
+func (s *Store) DetectedSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.DetectedSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DetectedSlashings")
+	defer span.End()
+
+	if startEpoch > endEpoch {
+		return nil, fmt.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+
+	slashings := make([]*slashertypes.DetectedSlashing, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(detectedSlashingsBucket).Cursor()
+		for k, v := c.Seek(encodeTargetEpoch(startEpoch)); k != nil; k, v = c.Next() {
+			if primitives.Epoch(binary.BigEndian.Uint64(k[:8])) > endEpoch {
+				break
+			}
+			slashing, err := decodeDetectedSlashing(k, v)
+			if err != nil {
+				return err
+			}
+			slashings = append(slashings, slashing)
+		}
+		return nil
+	})
+	return slashings, err
+}
+
+// Encodes a detected slashing into its key, the epoch concatenated with the slashing root,
+// and its value, the slashing kind concatenated with the compressed slashing.
+func encodeDetectedSlashing(slashing *slashertypes.DetectedSlashing) ([]byte, []byte, error) {
+	if slashing == nil {
+		return nil, nil, errors.New("nil detected slashing")
+	}
+
+	var (
+		kind    byte
+		encoded []byte
+		root    [32]byte
+		err     error
+	)
+	switch {
+	case slashing.ProposerSlashing != nil:
+		kind = proposerSlashingKind
+		if encoded, err = slashing.ProposerSlashing.MarshalSSZ(); err != nil {
+			return nil, nil, err
+		}
+		root, err = slashing.ProposerSlashing.HashTreeRoot()
+	case slashing.AttesterSlashing != nil:
+		switch slashing.AttesterSlashing.(type) {
+		case *ethpb.AttesterSlashing:
+			kind = attesterSlashingKind
+		case *ethpb.AttesterSlashingElectra:
+			kind = attesterSlashingElectraKind
+		default:
+			return nil, nil, fmt.Errorf("unsupported attester slashing type %T", slashing.AttesterSlashing)
+		}
+		if encoded, err = slashing.AttesterSlashing.MarshalSSZ(); err != nil {
+			return nil, nil, err
+		}
+		root, err = slashing.AttesterSlashing.HashTreeRoot()
+	default:
+		return nil, nil, errors.New("detected slashing has no attester or proposer slashing")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := append(encodeTargetEpoch(slashing.Epoch), root[:]...)
+	value := append([]byte{kind}, snappy.Encode(nil, encoded)...)
+	return key, value, nil
+}
+
+// Decodes a detected slashing from its key and value in the detected slashings bucket.
+func decodeDetectedSlashing(key, value []byte) (*slashertypes.DetectedSlashing, error) {
+	if len(key) != 8+rootSize {
+		return nil, fmt.Errorf("wrong length for detected slashing key, want %d, got %d", 8+rootSize, len(key))
+	}
+	if len(value) < 1 {
+		return nil, errors.New("empty detected slashing record")
+	}
+
+	decoded, err := snappy.Decode(nil, value[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	slashing := &slashertypes.DetectedSlashing{Epoch: primitives.Epoch(binary.BigEndian.Uint64(key[:8]))}
+	switch value[0] {
+	case proposerSlashingKind:
+		ps := &ethpb.ProposerSlashing{}
+		if err := ps.UnmarshalSSZ(decoded); err != nil {
+			return nil, err
+		}
+		slashing.ProposerSlashing = ps
+	case attesterSlashingKind:
+		as := &ethpb.AttesterSlashing{}
+		if err := as.UnmarshalSSZ(decoded); err != nil {
+			return nil, err
+		}
+		slashing.AttesterSlashing = as
+	case attesterSlashingElectraKind:
+		as := &ethpb.AttesterSlashingElectra{}
+		if err := as.UnmarshalSSZ(decoded); err != nil {
+			return nil, err
+		}
+		slashing.AttesterSlashing = as
+	default:
+		return nil, fmt.Errorf("unknown detected slashing kind %d", value[0])
+	}
+	return slashing, nil
+}
@@ -0,0 +1,76 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_DetectedSlashings_SaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	doubleVote := &ethpb.AttesterSlashing{
+		Attestation_1: createAttestationWrapper(1, 3, []uint64{4}, []byte{1}).IndexedAttestation.(*ethpb.IndexedAttestation),
+		Attestation_2: createAttestationWrapper(2, 3, []uint64{4}, []byte{2}).IndexedAttestation.(*ethpb.IndexedAttestation),
+	}
+	surroundVote := &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: []uint64{5},
+			Data:             doubleVote.Attestation_1.Data,
+			Signature:        doubleVote.Attestation_1.Signature,
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: []uint64{5},
+			Data:             createAttestationWrapper(0, 6, nil, nil).IndexedAttestation.GetData(),
+			Signature:        doubleVote.Attestation_1.Signature,
+		},
+	}
+	doubleProposal := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 64, 7, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 64, 7, []byte{2}).SignedBeaconBlockHeader,
+	}
+
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, []*slashertypes.DetectedSlashing{
+		{Epoch: 6, AttesterSlashing: surroundVote},
+		{Epoch: 3, AttesterSlashing: doubleVote},
+		{Epoch: 2, ProposerSlashing: doubleProposal},
+	}))
+	// Saving a slashing twice does not duplicate it.
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, []*slashertypes.DetectedSlashing{
+		{Epoch: 3, AttesterSlashing: doubleVote},
+	}))
+
+	slashings, err := beaconDB.DetectedSlashings(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(slashings))
+	require.Equal(t, primitives.Epoch(2), slashings[0].Epoch)
+	require.DeepEqual(t, doubleProposal, slashings[0].ProposerSlashing)
+	require.Equal(t, primitives.Epoch(3), slashings[1].Epoch)
+	require.DeepEqual(t, doubleVote, slashings[1].AttesterSlashing)
+	require.Equal(t, primitives.Epoch(6), slashings[2].Epoch)
+	require.DeepEqual(t, surroundVote, slashings[2].AttesterSlashing)
+
+	slashings, err = beaconDB.DetectedSlashings(ctx, 3, 5)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	require.DeepEqual(t, doubleVote, slashings[0].AttesterSlashing)
+
+	slashings, err = beaconDB.DetectedSlashings(ctx, 7, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+
+	_, err = beaconDB.DetectedSlashings(ctx, 5, 4)
+	require.ErrorContains(t, "start epoch 5 is greater than end epoch 4", err)
+}
+
+func TestStore_SaveDetectedSlashings_Empty(t *testing.T) {
+	beaconDB := setupDB(t)
+	require.ErrorContains(t, "no attester or proposer slashing", beaconDB.SaveDetectedSlashings(
+		context.Background(), []*slashertypes.DetectedSlashing{{Epoch: 1}},
+	))
+}
@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strings"
@@ -83,6 +84,10 @@ const (
 	ExchangeCapabilities = "engine_exchangeCapabilities"
 	// GetBlobsV1 request string for JSON-RPC.
 	GetBlobsV1 = "engine_getBlobsV1"
+	// GetClientVersionV1 request string for JSON-RPC.
+	GetClientVersionV1 = "engine_getClientVersionV1"
+	// prysmClientCode is the client code of Prysm in engine_getClientVersionV1.
+	prysmClientCode = "PM"
 	// Defines the seconds before timing out engine endpoints with non-block execution semantics.
 	defaultEngineTimeout = time.Second
 )
@@ -118,6 +123,7 @@ type EngineCaller interface {
 	GetPayload(ctx context.Context, payloadId [8]byte, slot primitives.Slot) (*blocks.GetPayloadResponse, error)
 	ExecutionBlockByHash(ctx context.Context, hash common.Hash, withTxs bool) (*pb.ExecutionBlock, error)
 	GetTerminalBlockHash(ctx context.Context, transitionTime uint64) ([]byte, bool, error)
+	GetClientVersion(ctx context.Context) ([]*pb.ClientVersionV1, error)
 }
 
 var ErrEmptyBlockHash = errors.New("Block hash is empty 0x0000...")
@@ -189,6 +195,7 @@ func (s *Service) NewPayload(ctx context.Context, payload interfaces.ExecutionDa
 	case pb.PayloadStatus_INVALID:
 		return result.LatestValidHash, ErrInvalidPayloadStatus
 	case pb.PayloadStatus_VALID:
+		s.lastValidPayload.Store(time.Now().UnixNano())
 		return result.LatestValidHash, nil
 	default:
 		return nil, ErrUnknownPayloadStatus
@@ -497,6 +504,39 @@ func (s *Service) HeaderByNumber(ctx context.Context, number *big.Int) (*types.H
 	return hdr, err
 }
 
+// GetClientVersion identifies the execution client by calling engine_getClientVersionV1 via JSON-RPC.
+// Several versions may be returned if the execution client is a multiplexer. No version is returned
+// if the execution client does not support the method.
+func (s *Service) GetClientVersion(ctx context.Context) ([]*pb.ClientVersionV1, error) {
+	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.GetClientVersion")
+	defer span.End()
+	if !s.capabilityCache.has(GetClientVersionV1) {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineTimeout)
+	defer cancel()
+	var result []*pb.ClientVersionV1
+	err := s.rpcClient.CallContext(ctx, &result, GetClientVersionV1, PrysmClientVersion())
+	return result, handleRPCError(err)
+}
+
+// PrysmClientVersion identifies this beacon node in the format of engine_getClientVersionV1.
+func PrysmClientVersion() *pb.ClientVersionV1 {
+	commit := "0x00000000"
+	if c := version.GitCommit(); len(c) >= 8 {
+		if _, err := hex.DecodeString(c[:8]); err == nil {
+			commit = "0x" + c[:8]
+		}
+	}
+	return &pb.ClientVersionV1{
+		Code:    prysmClientCode,
+		Name:    "Prysm",
+		Version: version.SemanticVersion(),
+		Commit:  commit,
+	}
+}
+
 // GetBlobs returns the blob and proof from the execution engine for the given versioned hashes.
 func (s *Service) GetBlobs(ctx context.Context, versionedHashes []common.Hash) ([]*pb.BlobAndProof, error) {
 	ctx, span := trace.StartSpan(ctx, "powchain.engine-api-client.GetBlobs")
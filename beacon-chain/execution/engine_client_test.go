@@ -2395,6 +2395,50 @@ func Test_ExchangeCapabilities(t *testing.T) {
 	})
 }
 
+func TestGetClientVersion(t *testing.T) {
+	ctx := context.Background()
+	t.Run("not supported", func(t *testing.T) {
+		service := &Service{capabilityCache: &capabilityCache{}}
+		versions, err := service.GetClientVersion(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(versions))
+	})
+	t.Run("returns the execution client version", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			defer func() {
+				require.NoError(t, r.Body.Close())
+			}()
+			req := struct {
+				Method string                `json:"method"`
+				Params []*pb.ClientVersionV1 `json:"params"`
+			}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, GetClientVersionV1, req.Method)
+			require.Equal(t, 1, len(req.Params))
+			require.Equal(t, "PM", req.Params[0].Code)
+			resp := map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"result": []*pb.ClientVersionV1{
+					{Code: "GE", Name: "Geth", Version: "1.14.8", Commit: "0xa9523b64"},
+				},
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		}))
+		defer srv.Close()
+		rpcClient, err := rpc.DialHTTP(srv.URL)
+		require.NoError(t, err)
+		defer rpcClient.Close()
+
+		service := &Service{capabilityCache: &capabilityCache{capabilities: map[string]interface{}{GetClientVersionV1: nil}}}
+		service.rpcClient = rpcClient
+		versions, err := service.GetClientVersion(ctx)
+		require.NoError(t, err)
+		require.DeepEqual(t, []*pb.ClientVersionV1{{Code: "GE", Name: "Geth", Version: "1.14.8", Commit: "0xa9523b64"}}, versions)
+	})
+}
+
 func TestReconstructBlobSidecars(t *testing.T) {
 	client := &Service{capabilityCache: &capabilityCache{}}
 	b := util.NewBeaconBlockDeneb()
@@ -11,6 +11,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -81,6 +82,7 @@ type ChainInfoFetcher interface {
 	ExecutionClientConnected() bool
 	ExecutionClientEndpoint() string
 	ExecutionClientConnectionErr() error
+	LastValidPayloadTime() time.Time
 }
 
 // POWBlockFetcher defines a struct that can retrieve mainchain blocks.
@@ -160,6 +162,7 @@ type Service struct {
 	verifierWaiter          *verification.InitializerWaiter
 	blobVerifier            verification.NewBlobVerifier
 	capabilityCache         *capabilityCache
+	lastValidPayload        atomic.Int64 // Unix time in nanoseconds of the last payload found valid by newPayload.
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
@@ -303,6 +306,16 @@ func (s *Service) ExecutionClientConnectionErr() error {
 	return s.runError
 }
 
+// LastValidPayloadTime returns when the execution client last found a payload valid in newPayload, or the
+// zero time if it has not since the node started.
+func (s *Service) LastValidPayloadTime() time.Time {
+	t := s.lastValidPayload.Load()
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t)
+}
+
 func (s *Service) updateBeaconNodeStats() {
 	bs := clientstats.BeaconNodeStats{}
 	if s.ExecutionClientConnected() {
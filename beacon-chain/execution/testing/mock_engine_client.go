@@ -38,6 +38,13 @@ type EngineClient struct {
 	ErrGetPayload               error
 	BlobSidecars                []blocks.VerifiedROBlob
 	ErrorBlobSidecars           error
+	ClientVersion               []*pb.ClientVersionV1
+	ErrClientVersion            error
+}
+
+// GetClientVersion --
+func (e *EngineClient) GetClientVersion(_ context.Context) ([]*pb.ClientVersionV1, error) {
+	return e.ClientVersion, e.ErrClientVersion
 }
 
 // NewPayload --
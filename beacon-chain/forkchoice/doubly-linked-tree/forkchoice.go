@@ -3,7 +3,6 @@ package doublylinkedtree
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice"
@@ -50,10 +49,16 @@ func (f *ForkChoice) NodeCount() int {
 // It firsts computes validator's balance changes then recalculates block tree from leaves to root.
 func (f *ForkChoice) Head(
 	ctx context.Context,
-) ([32]byte, error) {
+) (root [32]byte, err error) {
 	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.Head")
 	defer span.End()
 
+	je := f.journalBegin(journalHead, true)
+	defer func() {
+		je.root(root)
+		f.journalEnd(je, err)
+	}()
+
 	calledHeadCount.Inc()
 
 	if err := f.updateBalances(); err != nil {
@@ -70,7 +75,7 @@ func (f *ForkChoice) Head(
 
 	jc := f.JustifiedCheckpoint()
 	fc := f.FinalizedCheckpoint()
-	currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(f.store.genesisTime), 0))
+	currentEpoch := slots.ToEpoch(f.store.currentSlot())
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch, currentEpoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
@@ -83,6 +88,10 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.ProcessAttestation")
 	defer span.End()
 
+	je := f.journalBegin(journalProcessAttestation, false)
+	je.attestation(validatorIndices, blockRoot, targetEpoch)
+	defer f.journalEnd(je, nil)
+
 	for _, index := range validatorIndices {
 		// Validator indices will grow the vote cache.
 		for index >= uint64(len(f.votes)) {
@@ -104,10 +113,13 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 }
 
 // InsertNode processes a new block by inserting it to the fork choice store.
-func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, roblock consensus_blocks.ROBlock) error {
+func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, roblock consensus_blocks.ROBlock) (err error) {
 	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.InsertNode")
 	defer span.End()
 
+	je := f.journalBegin(journalInsertNode, true)
+	defer func() { f.journalEnd(je, err) }()
+
 	jc := state.CurrentJustifiedCheckpoint()
 	if jc == nil {
 		return errInvalidNilCheckpoint
@@ -118,12 +130,16 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 		return errInvalidNilCheckpoint
 	}
 	finalizedEpoch := fc.Epoch
+	je.insert(roblock, justifiedEpoch, finalizedEpoch)
 	node, err := f.store.insert(ctx, roblock, justifiedEpoch, finalizedEpoch)
 	if err != nil {
 		return err
 	}
+	je.inserted(node)
 
 	jc, fc = f.store.pullTips(state, node, jc, fc)
+	je.pulledTips(f.store, node)
+	je.checkpoints(jc, fc)
 	if err := f.updateCheckpoints(ctx, jc, fc); err != nil {
 		_, remErr := f.store.removeNode(ctx, node)
 		if remErr != nil {
@@ -335,7 +351,11 @@ func (f *ForkChoice) ProposerBoost() [fieldparams.RootLength]byte {
 }
 
 // SetOptimisticToValid sets the node with the given root as a fully validated node
-func (f *ForkChoice) SetOptimisticToValid(ctx context.Context, root [fieldparams.RootLength]byte) error {
+func (f *ForkChoice) SetOptimisticToValid(ctx context.Context, root [fieldparams.RootLength]byte) (err error) {
+	je := f.journalBegin(journalSetOptimisticToValid, false)
+	je.root(root)
+	defer func() { f.journalEnd(je, err) }()
+
 	node, ok := f.store.nodeByRoot[root]
 	if !ok || node == nil {
 		return errors.Wrap(ErrNilNode, "could not set node to valid")
@@ -360,13 +380,21 @@ func (f *ForkChoice) FinalizedCheckpoint() *forkchoicetypes.Checkpoint {
 
 // SetOptimisticToInvalid removes a block with an invalid execution payload from fork choice store
 func (f *ForkChoice) SetOptimisticToInvalid(ctx context.Context, root, parentRoot, payloadHash [fieldparams.RootLength]byte) ([][32]byte, error) {
-	return f.store.setOptimisticToInvalid(ctx, root, parentRoot, payloadHash)
+	je := f.journalBegin(journalSetOptimisticToInvalid, false)
+	je.invalidPayload(root, parentRoot, payloadHash)
+	invalidRoots, err := f.store.setOptimisticToInvalid(ctx, root, parentRoot, payloadHash)
+	f.journalEnd(je, err)
+	return invalidRoots, err
 }
 
 // InsertSlashedIndex adds the given slashed validator index to the
 // store-tracked list. Votes from these validators are not accounted for
 // in forkchoice.
 func (f *ForkChoice) InsertSlashedIndex(_ context.Context, index primitives.ValidatorIndex) {
+	je := f.journalBegin(journalInsertSlashedIndex, false)
+	je.validatorIndex(index)
+	defer f.journalEnd(je, nil)
+
 	// return early if the index was already included:
 	if f.store.slashedIndices[index] {
 		return
@@ -396,7 +424,11 @@ func (f *ForkChoice) InsertSlashedIndex(_ context.Context, index primitives.Vali
 }
 
 // UpdateJustifiedCheckpoint sets the justified checkpoint to the given one
-func (f *ForkChoice) UpdateJustifiedCheckpoint(ctx context.Context, jc *forkchoicetypes.Checkpoint) error {
+func (f *ForkChoice) UpdateJustifiedCheckpoint(ctx context.Context, jc *forkchoicetypes.Checkpoint) (err error) {
+	je := f.journalBegin(journalJustifiedCheckpoint, false)
+	je.checkpoint(jc)
+	defer func() { f.journalEnd(je, err) }()
+
 	if jc == nil {
 		return errInvalidNilCheckpoint
 	}
@@ -409,7 +441,11 @@ func (f *ForkChoice) UpdateJustifiedCheckpoint(ctx context.Context, jc *forkchoi
 }
 
 // UpdateFinalizedCheckpoint sets the finalized checkpoint to the given one
-func (f *ForkChoice) UpdateFinalizedCheckpoint(fc *forkchoicetypes.Checkpoint) error {
+func (f *ForkChoice) UpdateFinalizedCheckpoint(fc *forkchoicetypes.Checkpoint) (err error) {
+	je := f.journalBegin(journalFinalizedCheckpoint, false)
+	je.checkpoint(fc)
+	defer func() { f.journalEnd(je, err) }()
+
 	if fc == nil {
 		return errInvalidNilCheckpoint
 	}
@@ -473,25 +509,41 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 		return nil
 	}
 	for i := len(chain) - 1; i > 0; i-- {
-		if _, err := f.store.insert(ctx,
-			chain[i].Block,
-			chain[i].JustifiedCheckpoint.Epoch, chain[i].FinalizedCheckpoint.Epoch); err != nil {
-			return err
-		}
-		if err := f.updateCheckpoints(ctx, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint); err != nil {
+		if err := f.insertChainBlock(ctx, chain[i]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// insertChainBlock inserts a block of the chain given to InsertChain and updates the checkpoints of the store.
+func (f *ForkChoice) insertChainBlock(ctx context.Context, b *forkchoicetypes.BlockAndCheckpoints) (err error) {
+	je := f.journalBegin(journalInsertNode, true)
+	defer func() { f.journalEnd(je, err) }()
+
+	je.insert(b.Block, b.JustifiedCheckpoint.Epoch, b.FinalizedCheckpoint.Epoch)
+	node, err := f.store.insert(ctx, b.Block, b.JustifiedCheckpoint.Epoch, b.FinalizedCheckpoint.Epoch)
+	if err != nil {
+		return err
+	}
+	je.inserted(node)
+	je.checkpoints(b.JustifiedCheckpoint, b.FinalizedCheckpoint)
+	return f.updateCheckpoints(ctx, b.JustifiedCheckpoint, b.FinalizedCheckpoint)
+}
+
 // SetGenesisTime sets the genesisTime tracked by forkchoice
 func (f *ForkChoice) SetGenesisTime(genesisTime uint64) {
+	je := f.journalBegin(journalSetGenesisTime, false)
+	je.genesisTime(genesisTime)
+	defer f.journalEnd(je, nil)
 	f.store.genesisTime = genesisTime
 }
 
 // SetOriginRoot sets the genesis block root
 func (f *ForkChoice) SetOriginRoot(root [32]byte) {
+	je := f.journalBegin(journalSetOriginRoot, false)
+	je.root(root)
+	defer f.journalEnd(je, nil)
 	f.store.originRoot = root
 }
 
@@ -600,6 +652,7 @@ func (f *ForkChoice) updateJustifiedBalances(ctx context.Context, root [32]byte)
 	if err != nil {
 		return errors.Wrap(err, "could not get justified balances")
 	}
+	f.journalEntry.balancesFetched(balances)
 	f.justifiedBalances = balances
 	f.store.committeeWeight = 0
 	f.numActiveValidators = 0
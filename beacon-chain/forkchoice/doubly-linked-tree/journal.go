@@ -0,0 +1,761 @@
+package doublylinkedtree
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensus_blocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+const (
+	// DefaultJournalMaxFileSize is the size in bytes after which a journal file is rotated.
+	DefaultJournalMaxFileSize = 256 << 20
+	// DefaultJournalMaxFiles is the number of journal files kept in the journal directory.
+	DefaultJournalMaxFiles = 8
+
+	// journalBufferSize is the number of entries waiting to be written after
+	// which the journal is stopped rather than stalling fork choice.
+	journalBufferSize = 1 << 16
+
+	journalFilePrefix     = "forkchoice-"
+	journalFileSuffix     = ".jsonl"
+	journalFileTimeLayout = "20060102T150405.000000000Z"
+)
+
+// Types of the journal entries, one per call modifying the store.
+const (
+	journalSnapshot               = "snapshot"
+	journalInsertNode             = "insert_node"
+	journalProcessAttestation     = "process_attestation"
+	journalNewSlot                = "new_slot"
+	journalHead                   = "head"
+	journalJustifiedCheckpoint    = "update_justified_checkpoint"
+	journalFinalizedCheckpoint    = "update_finalized_checkpoint"
+	journalProposerBoost          = "proposer_boost"
+	journalSetOptimisticToValid   = "set_optimistic_to_valid"
+	journalSetOptimisticToInvalid = "set_optimistic_to_invalid"
+	journalInsertSlashedIndex     = "insert_slashed_index"
+	journalSetGenesisTime         = "set_genesis_time"
+	journalSetOriginRoot          = "set_origin_root"
+)
+
+// Journal appends every call modifying a fork choice store to JSON lines
+// files, so that the store can be rebuilt offline as it was at any moment of
+// its history with ReplayJournal. Files are rotated once they reach the
+// maximum file size, and each of them starts with a snapshot of the store.
+// Entries are encoded and written by a background goroutine, so that the
+// calls to the store only queue them.
+type Journal struct {
+	sync.Mutex
+	dir         string
+	maxFileSize uint64
+	maxFiles    int
+	entries     chan *journalEntry
+	done        chan struct{} // closed once the writer has closed the journal.
+	full        atomic.Bool   // set by the writer when the current file must be rotated.
+	failed      atomic.Bool   // set by the writer when it could not write an entry.
+	err         error         // error of the writer closing the journal.
+	// Owned by the callers, under the lock.
+	boostRoot [32]byte // last journaled proposer boost root.
+	headRoot  [32]byte // last journaled head root.
+	closed    bool
+	// Owned by the writer.
+	file     *os.File
+	w        *bufio.Writer
+	size     uint64
+	created  time.Time // creation time of the current file.
+	rotating bool      // a snapshot to rotate the current file has been requested.
+}
+
+// JournalOption configures a Journal.
+type JournalOption func(*Journal)
+
+// WithJournalMaxFileSize sets the size in bytes after which a journal file is rotated.
+func WithJournalMaxFileSize(size uint64) JournalOption {
+	return func(j *Journal) {
+		j.maxFileSize = size
+	}
+}
+
+// WithJournalMaxFiles sets the number of journal files kept, older files are deleted on rotation.
+func WithJournalMaxFiles(n int) JournalOption {
+	return func(j *Journal) {
+		j.maxFiles = n
+	}
+}
+
+// NewJournal creates a journal writing to the given directory. It starts
+// writing once it is attached to a store with SetJournal.
+func NewJournal(dir string, opts ...JournalOption) (*Journal, error) {
+	j := &Journal{dir: dir, maxFileSize: DefaultJournalMaxFileSize, maxFiles: DefaultJournalMaxFiles}
+	for _, o := range opts {
+		o(j)
+	}
+	if j.maxFiles < 1 {
+		return nil, errors.New("the journal must keep at least one file")
+	}
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrapf(err, "could not create journal directory %s", dir)
+	}
+	j.entries = make(chan *journalEntry, journalBufferSize)
+	j.done = make(chan struct{})
+	go j.run()
+	return j, nil
+}
+
+// SetJournal attaches a journal to the store and writes a snapshot of the store
+// to it. The journal must not be shared between stores.
+func (f *ForkChoice) SetJournal(j *Journal) error {
+	f.journal = j
+	if j == nil {
+		return nil
+	}
+	j.Lock()
+	defer j.Unlock()
+	if j.closed {
+		return errors.New("journal is closed")
+	}
+	t := journalTime(f.store)
+	if err := j.openFile(t); err != nil {
+		return err
+	}
+	j.boostRoot = f.store.proposerBoostRoot
+	if f.store.headNode != nil {
+		j.headRoot = f.store.headNode.root
+	}
+	j.send(&journalEntry{Time: t.UnixMilli(), Type: journalSnapshot, Snapshot: f.snapshot()})
+	return nil
+}
+
+// Close writes the queued entries and closes the journal, later calls to the store are not journaled.
+func (j *Journal) Close() error {
+	j.Lock()
+	j.stop()
+	j.Unlock()
+	<-j.done
+	return j.err
+}
+
+// stop stops queuing entries, the writer closes the journal once it has written the queued ones.
+func (j *Journal) stop() {
+	if j.closed {
+		return
+	}
+	j.closed = true
+	close(j.entries)
+}
+
+// send queues entries for the writer. The journal is stopped when the writer
+// falls too far behind, rather than stalling the calls to the store.
+func (j *Journal) send(entries ...*journalEntry) {
+	for _, e := range entries {
+		select {
+		case j.entries <- e:
+		default:
+			log.Error("Fork choice journal is falling behind, journaling is stopped")
+			j.stop()
+			return
+		}
+	}
+}
+
+// run writes the queued entries until the journal is stopped. Write errors are
+// logged and stop the journal, the store keeps working.
+func (j *Journal) run() {
+	defer close(j.done)
+	for e := range j.entries {
+		if j.failed.Load() {
+			continue
+		}
+		if err := j.writeEntry(e); err != nil {
+			log.WithError(err).Error("Could not write fork choice journal, journaling is stopped")
+			j.failed.Store(true)
+			if err := j.closeFile(); err != nil {
+				log.WithError(err).Error("Could not close fork choice journal")
+			}
+		}
+	}
+	j.err = j.closeFile()
+}
+
+// writeEntry writes an entry, in a new file when it is the snapshot requested
+// to rotate the current one.
+func (j *Journal) writeEntry(e *journalEntry) error {
+	if e.rotate {
+		if err := j.closeFile(); err != nil {
+			return err
+		}
+		if err := j.openFile(time.UnixMilli(e.Time)); err != nil {
+			return err
+		}
+		j.rotating = false
+	}
+	if err := j.write(e); err != nil {
+		return err
+	}
+	// Flush at least once per slot, and right away when something went wrong or a file starts.
+	if e.Failed || e.Type == journalNewSlot || e.Type == journalSnapshot {
+		if err := j.w.Flush(); err != nil {
+			return errors.Wrap(err, "could not flush journal")
+		}
+	}
+	if j.size >= j.maxFileSize && !j.rotating {
+		j.rotating = true
+		j.full.Store(true)
+	}
+	return nil
+}
+
+// openFile opens a new journal file and deletes the oldest files.
+func (j *Journal) openFile(t time.Time) error {
+	// File names must be unique and sorted, even when rotating several times within the same millisecond.
+	if !t.After(j.created) {
+		t = j.created.Add(time.Nanosecond)
+	}
+	j.created = t
+	name := filepath.Join(j.dir, journalFilePrefix+t.UTC().Format(journalFileTimeLayout)+journalFileSuffix)
+	fl, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "could not create journal file")
+	}
+	j.file, j.w, j.size = fl, bufio.NewWriter(fl), 0
+	files, err := JournalFiles(j.dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > j.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return errors.Wrap(err, "could not delete old journal file")
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+func (j *Journal) closeFile() error {
+	if j.file == nil {
+		return nil
+	}
+	fl := j.file
+	j.file = nil
+	if err := j.w.Flush(); err != nil {
+		return errors.Wrap(err, "could not flush journal")
+	}
+	return fl.Close()
+}
+
+func (j *Journal) write(e *journalEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "could not encode journal entry")
+	}
+	b = append(b, '\n')
+	if _, err := j.w.Write(b); err != nil {
+		return errors.Wrap(err, "could not write journal entry")
+	}
+	j.size += uint64(len(b))
+	return nil
+}
+
+// append queues the entry of a finished call. A failed call may have partially
+// modified the store, so the entry is followed by a snapshot of the store, as
+// is the first entry queued once the writer asks for the file to be rotated.
+func (j *Journal) append(f *ForkChoice, e *journalEntry, callErr error) {
+	j.Lock()
+	defer j.Unlock()
+	if j.closed {
+		return
+	}
+	if j.failed.Load() {
+		j.stop()
+		return
+	}
+	e.Failed = callErr != nil
+	var entries []*journalEntry
+	// The head is computed several times per slot, it is journaled when it changes.
+	if e.Type != journalHead || e.Failed || *e.Root != j.headRoot {
+		entries = append(entries, e)
+		if e.Type == journalHead && !e.Failed {
+			j.headRoot = *e.Root
+		}
+	}
+	if rotate := j.full.Swap(false); e.Failed || rotate {
+		entries = append(entries, &journalEntry{Time: e.Time, Type: journalSnapshot, Snapshot: f.snapshot(), rotate: rotate})
+	}
+	if boost := f.store.proposerBoostRoot; boost != j.boostRoot {
+		j.boostRoot = boost
+		root := journalRoot(boost)
+		entries = append(entries, &journalEntry{Time: e.Time, Type: journalProposerBoost, Root: &root})
+	}
+	j.send(entries...)
+}
+
+// JournalFiles returns the journal files in the given directory, oldest first.
+func JournalFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read journal directory")
+	}
+	var files []string
+	for _, e := range entries {
+		if _, ok := journalFileTime(e.Name()); ok && !e.IsDir() {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// journalFileTime returns the time at which the journal file with the given name was created.
+func journalFileTime(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, journalFilePrefix) || !strings.HasSuffix(name, journalFileSuffix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(journalFileTimeLayout, strings.TrimSuffix(strings.TrimPrefix(name, journalFilePrefix), journalFileSuffix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// journalTime returns the current time of the store, in the millisecond
+// precision of the journal.
+func journalTime(s *Store) time.Time {
+	now := time.Now()
+	if s.now != nil {
+		now = s.now()
+	}
+	return time.UnixMilli(now.UnixMilli())
+}
+
+// journalBegin starts the entry of a call modifying the store, it returns nil
+// when the store is not journaled. When pin is set, the clock of the store is
+// frozen until journalEnd so that the call is replayed at the exact same time.
+func (f *ForkChoice) journalBegin(typ string, pin bool) *journalEntry {
+	if f.journal == nil {
+		return nil
+	}
+	t := journalTime(f.store)
+	e := &journalEntry{Time: t.UnixMilli(), Type: typ}
+	if pin {
+		e.pinned, e.restoreNow = true, f.store.now
+		f.store.now = func() time.Time { return t }
+	}
+	f.journalEntry = e
+	return e
+}
+
+// journalEnd appends the entry of a finished call to the journal and restores the clock of the store.
+func (f *ForkChoice) journalEnd(e *journalEntry, err error) {
+	if e == nil {
+		return
+	}
+	if e.pinned {
+		f.store.now = e.restoreNow
+	}
+	f.journalEntry = nil
+	f.journal.append(f, e, err)
+}
+
+// journalEntry is a line of the journal.
+type journalEntry struct {
+	Time        int64                      `json:"time"` // unix milliseconds.
+	Type        string                     `json:"type"`
+	Failed      bool                       `json:"failed,omitempty"`
+	Insert      *journalInsert             `json:"insert,omitempty"`
+	Attestation *journalAttestation        `json:"attestation,omitempty"`
+	Slot        *primitives.Slot           `json:"slot,omitempty"`
+	Checkpoint  *journalCheckpoint         `json:"checkpoint,omitempty"`
+	Root        *journalRoot               `json:"root,omitempty"`
+	Invalid     *journalInvalidPayload     `json:"invalid,omitempty"`
+	Index       *primitives.ValidatorIndex `json:"index,omitempty"`
+	GenesisTime *uint64                    `json:"genesis_time,omitempty"`
+	Balances    journalBalances            `json:"balances,omitempty"` // justified balances fetched during the call.
+	Snapshot    *journalStore              `json:"snapshot,omitempty"`
+
+	pinned     bool
+	restoreNow func() time.Time
+	rotate     bool // the entry starts a new file.
+}
+
+// journalInsert records the insertion of a block, with the outcome of pulling
+// up its tips which depends on the post-state of the block.
+type journalInsert struct {
+	Slot                primitives.Slot    `json:"slot"`
+	Root                journalRoot        `json:"root"`
+	ParentRoot          journalRoot        `json:"parent_root"`
+	PayloadHash         journalRoot        `json:"payload_hash"`
+	JustifiedEpoch      primitives.Epoch   `json:"justified_epoch"`
+	FinalizedEpoch      primitives.Epoch   `json:"finalized_epoch"`
+	Pulled              *journalPulledTips `json:"pulled,omitempty"`
+	JustifiedCheckpoint journalCheckpoint  `json:"justified_checkpoint"`
+	FinalizedCheckpoint journalCheckpoint  `json:"finalized_checkpoint"`
+}
+
+// journalPulledTips are the checkpoints of the node and the unrealized checkpoints of the store after pulling up tips.
+type journalPulledTips struct {
+	JustifiedEpoch                primitives.Epoch  `json:"justified_epoch"`
+	FinalizedEpoch                primitives.Epoch  `json:"finalized_epoch"`
+	UnrealizedJustifiedEpoch      primitives.Epoch  `json:"unrealized_justified_epoch"`
+	UnrealizedFinalizedEpoch      primitives.Epoch  `json:"unrealized_finalized_epoch"`
+	UnrealizedJustifiedCheckpoint journalCheckpoint `json:"unrealized_justified_checkpoint"`
+	UnrealizedFinalizedCheckpoint journalCheckpoint `json:"unrealized_finalized_checkpoint"`
+}
+
+type journalAttestation struct {
+	Indices     []uint64         `json:"indices"`
+	Root        journalRoot      `json:"root"`
+	TargetEpoch primitives.Epoch `json:"target_epoch"`
+}
+
+type journalInvalidPayload struct {
+	Root          journalRoot `json:"root"`
+	ParentRoot    journalRoot `json:"parent_root"`
+	LastValidHash journalRoot `json:"last_valid_hash"`
+}
+
+type journalCheckpoint struct {
+	Epoch primitives.Epoch `json:"epoch"`
+	Root  journalRoot      `json:"root"`
+}
+
+func newJournalCheckpoint(cp *forkchoicetypes.Checkpoint) journalCheckpoint {
+	return journalCheckpoint{Epoch: cp.Epoch, Root: cp.Root}
+}
+
+func (cp journalCheckpoint) checkpoint() *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: cp.Epoch, Root: cp.Root}
+}
+
+func (cp journalCheckpoint) proto() *ethpb.Checkpoint {
+	return &ethpb.Checkpoint{Epoch: cp.Epoch, Root: bytesutil.SafeCopyBytes(cp.Root[:])}
+}
+
+// journalRoot is a 32 bytes root encoded in hex.
+type journalRoot [32]byte
+
+// MarshalText --
+func (r journalRoot) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%#x", r[:])), nil
+}
+
+// UnmarshalText --
+func (r *journalRoot) UnmarshalText(b []byte) error {
+	root, err := bytesutil.DecodeHexWithLength(string(b), len(r))
+	if err != nil {
+		return err
+	}
+	copy(r[:], root)
+	return nil
+}
+
+// journalBalances are encoded as runs of [balance, count], effective balances being mostly equal.
+type journalBalances []uint64
+
+// MarshalJSON --
+func (b journalBalances) MarshalJSON() ([]byte, error) {
+	runs := make([][2]uint64, 0)
+	for _, v := range b {
+		if len(runs) > 0 && runs[len(runs)-1][0] == v {
+			runs[len(runs)-1][1]++
+			continue
+		}
+		runs = append(runs, [2]uint64{v, 1})
+	}
+	return json.Marshal(runs)
+}
+
+// UnmarshalJSON --
+func (b *journalBalances) UnmarshalJSON(data []byte) error {
+	var runs [][2]uint64
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return err
+	}
+	balances := make([]uint64, 0)
+	for _, r := range runs {
+		for i := uint64(0); i < r[1]; i++ {
+			balances = append(balances, r[0])
+		}
+	}
+	*b = balances
+	return nil
+}
+
+func (e *journalEntry) insert(roblock consensus_blocks.ROBlock, justifiedEpoch, finalizedEpoch primitives.Epoch) {
+	if e == nil {
+		return
+	}
+	e.Insert = &journalInsert{
+		Slot:           roblock.Block().Slot(),
+		Root:           roblock.Root(),
+		ParentRoot:     roblock.Block().ParentRoot(),
+		JustifiedEpoch: justifiedEpoch,
+		FinalizedEpoch: finalizedEpoch,
+	}
+}
+
+func (e *journalEntry) inserted(n *Node) {
+	if e == nil {
+		return
+	}
+	e.Insert.PayloadHash = n.payloadHash
+}
+
+func (e *journalEntry) pulledTips(s *Store, n *Node) {
+	if e == nil {
+		return
+	}
+	e.Insert.Pulled = &journalPulledTips{
+		JustifiedEpoch:                n.justifiedEpoch,
+		FinalizedEpoch:                n.finalizedEpoch,
+		UnrealizedJustifiedEpoch:      n.unrealizedJustifiedEpoch,
+		UnrealizedFinalizedEpoch:      n.unrealizedFinalizedEpoch,
+		UnrealizedJustifiedCheckpoint: newJournalCheckpoint(s.unrealizedJustifiedCheckpoint),
+		UnrealizedFinalizedCheckpoint: newJournalCheckpoint(s.unrealizedFinalizedCheckpoint),
+	}
+}
+
+func (e *journalEntry) checkpoints(jc, fc *ethpb.Checkpoint) {
+	if e == nil {
+		return
+	}
+	e.Insert.JustifiedCheckpoint = journalCheckpoint{Epoch: jc.Epoch, Root: bytesutil.ToBytes32(jc.Root)}
+	e.Insert.FinalizedCheckpoint = journalCheckpoint{Epoch: fc.Epoch, Root: bytesutil.ToBytes32(fc.Root)}
+}
+
+func (e *journalEntry) balancesFetched(balances []uint64) {
+	if e == nil {
+		return
+	}
+	e.Balances = balances
+}
+
+// journalStore is the full content of the store.
+type journalStore struct {
+	GenesisTime                   uint64                      `json:"genesis_time"`
+	OriginRoot                    journalRoot                 `json:"origin_root"`
+	JustifiedCheckpoint           journalCheckpoint           `json:"justified_checkpoint"`
+	PrevJustifiedCheckpoint       journalCheckpoint           `json:"previous_justified_checkpoint"`
+	FinalizedCheckpoint           journalCheckpoint           `json:"finalized_checkpoint"`
+	UnrealizedJustifiedCheckpoint journalCheckpoint           `json:"unrealized_justified_checkpoint"`
+	UnrealizedFinalizedCheckpoint journalCheckpoint           `json:"unrealized_finalized_checkpoint"`
+	ProposerBoostRoot             journalRoot                 `json:"proposer_boost_root"`
+	PreviousProposerBoostRoot     journalRoot                 `json:"previous_proposer_boost_root"`
+	PreviousProposerBoostScore    uint64                      `json:"previous_proposer_boost_score"`
+	CommitteeWeight               uint64                      `json:"committee_weight"`
+	NumActiveValidators           uint64                      `json:"num_active_validators"`
+	AllTipsAreInvalid             bool                        `json:"all_tips_are_invalid"`
+	ReceivedBlocksLastEpoch       []primitives.Slot           `json:"received_blocks_last_epoch"`
+	SlashedIndices                []primitives.ValidatorIndex `json:"slashed_indices"`
+	// Nodes of the tree in preorder, the first one is the tree root.
+	Nodes []*journalNode `json:"nodes"`
+	// Nodes still referenced by the store after being pruned or removed from the tree.
+	Detached          []*journalNode   `json:"detached,omitempty"`
+	Head              *journalRoot     `json:"head,omitempty"`
+	HighestNode       *journalRoot     `json:"highest_received_node,omitempty"`
+	NodeByPayload     [][2]journalRoot `json:"node_by_payload"` // [payload hash, root] pairs.
+	VoteRoots         []journalRoot    `json:"vote_roots"`
+	Votes             [][3]uint64      `json:"votes"` // [current root index, next root index, next epoch].
+	Balances          journalBalances  `json:"balances"`
+	JustifiedBalances journalBalances  `json:"justified_balances"`
+
+	votes []Vote // copy of the votes, encoded into VoteRoots and Votes by MarshalJSON.
+}
+
+// MarshalJSON encodes the votes of the snapshot, which is left to the writer
+// of the journal rather than done under the fork choice lock.
+func (snap *journalStore) MarshalJSON() ([]byte, error) {
+	if snap.Votes == nil {
+		rootIndex := make(map[[32]byte]uint64)
+		index := func(r [32]byte) uint64 {
+			i, ok := rootIndex[r]
+			if !ok {
+				i = uint64(len(snap.VoteRoots))
+				rootIndex[r] = i
+				snap.VoteRoots = append(snap.VoteRoots, r)
+			}
+			return i
+		}
+		snap.Votes = make([][3]uint64, len(snap.votes))
+		for i, v := range snap.votes {
+			snap.Votes[i] = [3]uint64{index(v.currentRoot), index(v.nextRoot), uint64(v.nextEpoch)}
+		}
+	}
+	type store journalStore
+	return json.Marshal((*store)(snap))
+}
+
+type journalNode struct {
+	Slot                     primitives.Slot  `json:"slot"`
+	Root                     journalRoot      `json:"root"`
+	ParentRoot               journalRoot      `json:"parent_root"`
+	PayloadHash              journalRoot      `json:"payload_hash"`
+	Target                   *journalRoot     `json:"target,omitempty"`
+	BestDescendant           *journalRoot     `json:"best_descendant,omitempty"`
+	JustifiedEpoch           primitives.Epoch `json:"justified_epoch"`
+	UnrealizedJustifiedEpoch primitives.Epoch `json:"unrealized_justified_epoch"`
+	FinalizedEpoch           primitives.Epoch `json:"finalized_epoch"`
+	UnrealizedFinalizedEpoch primitives.Epoch `json:"unrealized_finalized_epoch"`
+	Balance                  uint64           `json:"balance"`
+	Weight                   uint64           `json:"weight"`
+	Optimistic               bool             `json:"optimistic"`
+	Timestamp                uint64           `json:"timestamp"`
+}
+
+// snapshot returns the full content of the store. The balances are shared with
+// the store, which replaces them rather than modifying them, and the votes are
+// copied.
+func (f *ForkChoice) snapshot() *journalStore {
+	s := f.store
+	snap := &journalStore{
+		GenesisTime:                   s.genesisTime,
+		OriginRoot:                    s.originRoot,
+		JustifiedCheckpoint:           newJournalCheckpoint(s.justifiedCheckpoint),
+		PrevJustifiedCheckpoint:       newJournalCheckpoint(s.prevJustifiedCheckpoint),
+		FinalizedCheckpoint:           newJournalCheckpoint(s.finalizedCheckpoint),
+		UnrealizedJustifiedCheckpoint: newJournalCheckpoint(s.unrealizedJustifiedCheckpoint),
+		UnrealizedFinalizedCheckpoint: newJournalCheckpoint(s.unrealizedFinalizedCheckpoint),
+		ProposerBoostRoot:             s.proposerBoostRoot,
+		PreviousProposerBoostRoot:     s.previousProposerBoostRoot,
+		PreviousProposerBoostScore:    s.previousProposerBoostScore,
+		CommitteeWeight:               s.committeeWeight,
+		NumActiveValidators:           f.numActiveValidators,
+		AllTipsAreInvalid:             s.allTipsAreInvalid,
+		ReceivedBlocksLastEpoch:       append([]primitives.Slot(nil), s.receivedBlocksLastEpoch[:]...),
+		Balances:                      f.balances,
+		JustifiedBalances:             f.justifiedBalances,
+		votes:                         append([]Vote(nil), f.votes...),
+	}
+	for idx := range s.slashedIndices {
+		snap.SlashedIndices = append(snap.SlashedIndices, idx)
+	}
+	sort.Slice(snap.SlashedIndices, func(i, j int) bool { return snap.SlashedIndices[i] < snap.SlashedIndices[j] })
+
+	inTree := make(map[*Node]bool, len(s.nodeByRoot))
+	var nodes []*Node
+	if s.treeRootNode != nil {
+		stack := []*Node{s.treeRootNode}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			nodes = append(nodes, n)
+			inTree[n] = true
+			for i := len(n.children) - 1; i >= 0; i-- {
+				stack = append(stack, n.children[i])
+			}
+		}
+	}
+	detached := make(map[*Node]bool)
+	ref := func(n *Node) *journalRoot {
+		if n == nil {
+			return nil
+		}
+		if !inTree[n] && !detached[n] {
+			detached[n] = true
+			snap.Detached = append(snap.Detached, newJournalNode(n, nil, nil))
+		}
+		root := journalRoot(n.root)
+		return &root
+	}
+	for _, n := range nodes {
+		snap.Nodes = append(snap.Nodes, newJournalNode(n, ref(n.target), ref(n.bestDescendant)))
+	}
+	snap.Head = ref(s.headNode)
+	snap.HighestNode = ref(s.highestReceivedNode)
+	for payloadHash, n := range s.nodeByPayload {
+		snap.NodeByPayload = append(snap.NodeByPayload, [2]journalRoot{payloadHash, *ref(n)})
+	}
+	sort.Slice(snap.NodeByPayload, func(i, j int) bool {
+		return string(snap.NodeByPayload[i][0][:]) < string(snap.NodeByPayload[j][0][:])
+	})
+	return snap
+}
+
+func newJournalNode(n *Node, target, bestDescendant *journalRoot) *journalNode {
+	jn := &journalNode{
+		Slot:                     n.slot,
+		Root:                     n.root,
+		PayloadHash:              n.payloadHash,
+		Target:                   target,
+		BestDescendant:           bestDescendant,
+		JustifiedEpoch:           n.justifiedEpoch,
+		UnrealizedJustifiedEpoch: n.unrealizedJustifiedEpoch,
+		FinalizedEpoch:           n.finalizedEpoch,
+		UnrealizedFinalizedEpoch: n.unrealizedFinalizedEpoch,
+		Balance:                  n.balance,
+		Weight:                   n.weight,
+		Optimistic:               n.optimistic,
+		Timestamp:                n.timestamp,
+	}
+	if n.parent != nil {
+		jn.ParentRoot = n.parent.root
+	}
+	return jn
+}
+
+func (e *journalEntry) root(r [32]byte) {
+	if e == nil {
+		return
+	}
+	root := journalRoot(r)
+	e.Root = &root
+}
+
+func (e *journalEntry) attestation(indices []uint64, root [32]byte, targetEpoch primitives.Epoch) {
+	if e == nil {
+		return
+	}
+	// The indices are copied as the entry is written after the call returns.
+	e.Attestation = &journalAttestation{Indices: append([]uint64(nil), indices...), Root: root, TargetEpoch: targetEpoch}
+}
+
+func (e *journalEntry) slot(slot primitives.Slot) {
+	if e == nil {
+		return
+	}
+	e.Slot = &slot
+}
+
+func (e *journalEntry) checkpoint(cp *forkchoicetypes.Checkpoint) {
+	if e == nil || cp == nil {
+		return
+	}
+	jc := newJournalCheckpoint(cp)
+	e.Checkpoint = &jc
+}
+
+func (e *journalEntry) invalidPayload(root, parentRoot, lastValidHash [32]byte) {
+	if e == nil {
+		return
+	}
+	e.Invalid = &journalInvalidPayload{Root: root, ParentRoot: parentRoot, LastValidHash: lastValidHash}
+}
+
+func (e *journalEntry) validatorIndex(index primitives.ValidatorIndex) {
+	if e == nil {
+		return
+	}
+	e.Index = &index
+}
+
+func (e *journalEntry) genesisTime(t uint64) {
+	if e == nil {
+		return
+	}
+	e.GenesisTime = &t
+}
@@ -0,0 +1,352 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+var (
+	errJournalNoSnapshot = errors.New("journal does not start with a snapshot")
+	errJournalDiverged   = errors.New("replayed store diverged from the journal")
+	errJournalTooRecent  = errors.New("journal starts after the requested time")
+)
+
+// ReplayJournal rebuilds a fork choice store from a journal, as it was right
+// after the last entry journaled at or before until, a zero time replaying the
+// whole journal. The journal is read from the readers in order and must start
+// with a snapshot. The clock of the returned store is frozen at the time of the
+// last replayed entry. The head is only journaled when it changes, so votes
+// counted by later computations of the same head are applied by the next call
+// to Head. The chain config must be the one of the journaled node.
+func ReplayJournal(ctx context.Context, until time.Time, readers ...io.Reader) (*ForkChoice, error) {
+	f := New()
+	var balances []uint64
+	f.SetBalancesByRooter(func(context.Context, [32]byte) ([]uint64, error) {
+		return balances, nil
+	})
+	started := false
+	for _, r := range readers {
+		dec := json.NewDecoder(r)
+		for {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			e := &journalEntry{}
+			err := dec.Decode(e)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				// The node stopped while writing the last entry of the file.
+				log.WithError(err).Warn("Journal file ends with a truncated entry")
+				break
+			}
+			if err != nil {
+				return nil, errors.Wrap(err, "could not decode journal entry")
+			}
+			if !until.IsZero() && e.Time > until.UnixMilli() {
+				if !started {
+					return nil, errJournalTooRecent
+				}
+				return f, nil
+			}
+			if !started && e.Type != journalSnapshot {
+				return nil, errJournalNoSnapshot
+			}
+			started = true
+			t := time.UnixMilli(e.Time)
+			f.store.now = func() time.Time { return t }
+			balances = e.Balances
+			if err := f.replay(ctx, e); err != nil {
+				return nil, errors.Wrapf(err, "could not replay %s entry at %s", e.Type, t.UTC())
+			}
+		}
+	}
+	if !started {
+		return nil, errJournalNoSnapshot
+	}
+	return f, nil
+}
+
+// ReplayJournalDir rebuilds a fork choice store from the journal files in the
+// given directory, starting from the newest file created at or before until.
+// See ReplayJournal.
+func ReplayJournalDir(ctx context.Context, dir string, until time.Time) (*ForkChoice, error) {
+	files, err := JournalFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	start := -1
+	for i, name := range files {
+		t, _ := journalFileTime(filepath.Base(name))
+		if until.IsZero() || !t.After(until) {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, errJournalTooRecent
+	}
+	readers := make([]io.Reader, 0, len(files)-start)
+	for _, name := range files[start:] {
+		fl, err := os.Open(name) // #nosec G304
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open journal file")
+		}
+		defer func() {
+			if err := fl.Close(); err != nil {
+				log.WithError(err).Error("Could not close journal file")
+			}
+		}()
+		readers = append(readers, fl)
+	}
+	return ReplayJournal(ctx, until, readers...)
+}
+
+// replay applies a journal entry to the store. Failed calls are followed by a
+// snapshot of the store and are not replayed.
+func (f *ForkChoice) replay(ctx context.Context, e *journalEntry) error {
+	if e.Failed {
+		return nil
+	}
+	switch e.Type {
+	case journalSnapshot:
+		if e.Snapshot == nil {
+			return errors.New("missing snapshot")
+		}
+		return f.restore(e.Snapshot)
+	case journalInsertNode:
+		if e.Insert == nil {
+			return errors.New("missing inserted node")
+		}
+		return f.replayInsert(ctx, e.Insert)
+	case journalProcessAttestation:
+		if e.Attestation == nil {
+			return errors.New("missing attestation")
+		}
+		f.ProcessAttestation(ctx, e.Attestation.Indices, e.Attestation.Root, e.Attestation.TargetEpoch)
+		return nil
+	case journalNewSlot:
+		if e.Slot == nil {
+			return errors.New("missing slot")
+		}
+		return f.NewSlot(ctx, *e.Slot)
+	case journalHead:
+		if e.Root == nil {
+			return errors.New("missing head root")
+		}
+		head, err := f.Head(ctx)
+		if err != nil {
+			return err
+		}
+		if head != *e.Root {
+			return errors.Wrapf(errJournalDiverged, "head is %#x instead of %#x", head, e.Root[:])
+		}
+		return nil
+	case journalJustifiedCheckpoint:
+		if e.Checkpoint == nil {
+			return errors.New("missing checkpoint")
+		}
+		return f.UpdateJustifiedCheckpoint(ctx, e.Checkpoint.checkpoint())
+	case journalFinalizedCheckpoint:
+		if e.Checkpoint == nil {
+			return errors.New("missing checkpoint")
+		}
+		return f.UpdateFinalizedCheckpoint(e.Checkpoint.checkpoint())
+	case journalProposerBoost:
+		if e.Root == nil {
+			return errors.New("missing proposer boost root")
+		}
+		if boost := f.store.proposerBoostRoot; boost != *e.Root {
+			return errors.Wrapf(errJournalDiverged, "proposer boost root is %#x instead of %#x", boost, e.Root[:])
+		}
+		return nil
+	case journalSetOptimisticToValid:
+		if e.Root == nil {
+			return errors.New("missing root")
+		}
+		return f.SetOptimisticToValid(ctx, *e.Root)
+	case journalSetOptimisticToInvalid:
+		if e.Invalid == nil {
+			return errors.New("missing invalid payload")
+		}
+		_, err := f.SetOptimisticToInvalid(ctx, e.Invalid.Root, e.Invalid.ParentRoot, e.Invalid.LastValidHash)
+		return err
+	case journalInsertSlashedIndex:
+		if e.Index == nil {
+			return errors.New("missing validator index")
+		}
+		f.InsertSlashedIndex(ctx, *e.Index)
+		return nil
+	case journalSetGenesisTime:
+		if e.GenesisTime == nil {
+			return errors.New("missing genesis time")
+		}
+		f.SetGenesisTime(*e.GenesisTime)
+		return nil
+	case journalSetOriginRoot:
+		if e.Root == nil {
+			return errors.New("missing root")
+		}
+		f.SetOriginRoot(*e.Root)
+		return nil
+	default:
+		return fmt.Errorf("unknown journal entry type %q", e.Type)
+	}
+}
+
+// replayInsert inserts a node as InsertNode did, using the journaled outcome of
+// pulling up its tips instead of the post-state of the block.
+func (f *ForkChoice) replayInsert(ctx context.Context, in *journalInsert) error {
+	node, ok := f.store.nodeByRoot[in.Root]
+	if !ok {
+		n := &Node{
+			slot:                     in.Slot,
+			root:                     in.Root,
+			parent:                   f.store.nodeByRoot[in.ParentRoot],
+			justifiedEpoch:           in.JustifiedEpoch,
+			unrealizedJustifiedEpoch: in.JustifiedEpoch,
+			finalizedEpoch:           in.FinalizedEpoch,
+			unrealizedFinalizedEpoch: in.FinalizedEpoch,
+			optimistic:               true,
+			payloadHash:              in.PayloadHash,
+			timestamp:                f.store.currentTime(),
+		}
+		var err error
+		if node, err = f.store.insertNode(ctx, n); err != nil {
+			return err
+		}
+	}
+	if p := in.Pulled; p != nil {
+		node.justifiedEpoch, node.finalizedEpoch = p.JustifiedEpoch, p.FinalizedEpoch
+		node.unrealizedJustifiedEpoch, node.unrealizedFinalizedEpoch = p.UnrealizedJustifiedEpoch, p.UnrealizedFinalizedEpoch
+		if *f.store.unrealizedJustifiedCheckpoint != *p.UnrealizedJustifiedCheckpoint.checkpoint() {
+			f.store.unrealizedJustifiedCheckpoint = p.UnrealizedJustifiedCheckpoint.checkpoint()
+		}
+		if *f.store.unrealizedFinalizedCheckpoint != *p.UnrealizedFinalizedCheckpoint.checkpoint() {
+			f.store.unrealizedFinalizedCheckpoint = p.UnrealizedFinalizedCheckpoint.checkpoint()
+		}
+	}
+	jc, fc := in.JustifiedCheckpoint, in.FinalizedCheckpoint
+	return f.updateCheckpoints(ctx, jc.proto(), fc.proto())
+}
+
+// restore replaces the content of the store by the given snapshot.
+func (f *ForkChoice) restore(snap *journalStore) error {
+	s := f.store
+	s.genesisTime = snap.GenesisTime
+	s.originRoot = snap.OriginRoot
+	s.justifiedCheckpoint = snap.JustifiedCheckpoint.checkpoint()
+	s.prevJustifiedCheckpoint = snap.PrevJustifiedCheckpoint.checkpoint()
+	s.finalizedCheckpoint = snap.FinalizedCheckpoint.checkpoint()
+	s.unrealizedJustifiedCheckpoint = snap.UnrealizedJustifiedCheckpoint.checkpoint()
+	s.unrealizedFinalizedCheckpoint = snap.UnrealizedFinalizedCheckpoint.checkpoint()
+	s.proposerBoostRoot = snap.ProposerBoostRoot
+	s.previousProposerBoostRoot = snap.PreviousProposerBoostRoot
+	s.previousProposerBoostScore = snap.PreviousProposerBoostScore
+	s.committeeWeight = snap.CommitteeWeight
+	s.allTipsAreInvalid = snap.AllTipsAreInvalid
+	if len(snap.ReceivedBlocksLastEpoch) != fieldparams.SlotsPerEpoch {
+		return fmt.Errorf("snapshot has %d received block slots, expected %d", len(snap.ReceivedBlocksLastEpoch), fieldparams.SlotsPerEpoch)
+	}
+	copy(s.receivedBlocksLastEpoch[:], snap.ReceivedBlocksLastEpoch)
+	s.slashedIndices = make(map[primitives.ValidatorIndex]bool, len(snap.SlashedIndices))
+	for _, idx := range snap.SlashedIndices {
+		s.slashedIndices[idx] = true
+	}
+
+	s.nodeByRoot = make(map[[fieldparams.RootLength]byte]*Node, len(snap.Nodes))
+	s.nodeByPayload = make(map[[fieldparams.RootLength]byte]*Node, len(snap.NodeByPayload))
+	s.treeRootNode = nil
+	detached := make(map[[32]byte]*Node, len(snap.Detached))
+	for _, jn := range snap.Detached {
+		detached[jn.Root] = jn.node()
+	}
+	lookup := func(r *journalRoot) (*Node, error) {
+		if r == nil {
+			return nil, nil
+		}
+		if n, ok := s.nodeByRoot[*r]; ok {
+			return n, nil
+		}
+		if n, ok := detached[*r]; ok {
+			return n, nil
+		}
+		return nil, fmt.Errorf("snapshot references unknown node %#x", r[:])
+	}
+	for i, jn := range snap.Nodes {
+		n := jn.node()
+		if i > 0 {
+			parent, ok := s.nodeByRoot[jn.ParentRoot]
+			if !ok {
+				return fmt.Errorf("snapshot node %#x has unknown parent %#x", jn.Root[:], jn.ParentRoot[:])
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		} else {
+			s.treeRootNode = n
+		}
+		s.nodeByRoot[n.root] = n
+	}
+	var err error
+	for _, jn := range snap.Nodes {
+		n := s.nodeByRoot[jn.Root]
+		if n.target, err = lookup(jn.Target); err != nil {
+			return err
+		}
+		if n.bestDescendant, err = lookup(jn.BestDescendant); err != nil {
+			return err
+		}
+	}
+	if s.headNode, err = lookup(snap.Head); err != nil {
+		return err
+	}
+	if s.highestReceivedNode, err = lookup(snap.HighestNode); err != nil {
+		return err
+	}
+	for _, p := range snap.NodeByPayload {
+		root := p[1]
+		n, err := lookup(&root)
+		if err != nil {
+			return err
+		}
+		s.nodeByPayload[p[0]] = n
+	}
+
+	f.votes = make([]Vote, len(snap.Votes))
+	for i, v := range snap.Votes {
+		if v[0] >= uint64(len(snap.VoteRoots)) || v[1] >= uint64(len(snap.VoteRoots)) {
+			return fmt.Errorf("vote of validator %d references an unknown root", i)
+		}
+		f.votes[i] = Vote{currentRoot: snap.VoteRoots[v[0]], nextRoot: snap.VoteRoots[v[1]], nextEpoch: primitives.Epoch(v[2])}
+	}
+	f.balances = snap.Balances
+	f.justifiedBalances = snap.JustifiedBalances
+	f.numActiveValidators = snap.NumActiveValidators
+	return nil
+}
+
+// node returns the node described by the journal, without its links to other nodes.
+func (jn *journalNode) node() *Node {
+	return &Node{
+		slot:                     jn.Slot,
+		root:                     jn.Root,
+		payloadHash:              jn.PayloadHash,
+		justifiedEpoch:           jn.JustifiedEpoch,
+		unrealizedJustifiedEpoch: jn.UnrealizedJustifiedEpoch,
+		finalizedEpoch:           jn.FinalizedEpoch,
+		unrealizedFinalizedEpoch: jn.UnrealizedFinalizedEpoch,
+		balance:                  jn.Balance,
+		weight:                   jn.Weight,
+		optimistic:               jn.Optimistic,
+		timestamp:                jn.Timestamp,
+	}
+}
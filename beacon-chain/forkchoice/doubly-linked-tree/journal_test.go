@@ -0,0 +1,188 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// journaledStore returns a store with a controlled clock, 64 validators and a
+// journal writing to a temporary directory.
+func journaledStore(t *testing.T, opts ...JournalOption) (*ForkChoice, *Journal, func(slot primitives.Slot, secs uint64), string) {
+	f := setup(0, 0)
+	genesis := uint64(time.Now().Add(-time.Hour).Unix())
+	f.SetGenesisTime(genesis)
+	now := time.Unix(int64(genesis), 0)
+	f.SetTimeSource(func() time.Time { return now })
+	setTime := func(slot primitives.Slot, secs uint64) {
+		now = time.Unix(int64(genesis+uint64(slot)*params.BeaconConfig().SecondsPerSlot+secs), 0)
+	}
+	balances := make([]uint64, 64)
+	for i := range balances {
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	f.SetBalancesByRooter(func(context.Context, [32]byte) ([]uint64, error) { return balances, nil })
+
+	dir := t.TempDir()
+	j, err := NewJournal(dir, opts...)
+	require.NoError(t, err)
+	require.NoError(t, f.SetJournal(j))
+	return f, j, setTime, dir
+}
+
+func storeContent(t *testing.T, f *ForkChoice) string {
+	b, err := json.Marshal(f.snapshot())
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestJournal_ReplayRebuildsStore(t *testing.T) {
+	ctx := context.Background()
+	f, j, setTime, dir := journaledStore(t)
+	zeroHash := params.BeaconConfig().ZeroHash
+	require.NoError(t, f.UpdateJustifiedCheckpoint(ctx, &forkchoicetypes.Checkpoint{Root: zeroHash}))
+
+	setTime(1, 1)
+	require.NoError(t, f.NewSlot(ctx, 1))
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), zeroHash, indexToHash(101), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	require.Equal(t, indexToHash(1), f.ProposerBoost())
+	setTime(1, 4)
+	f.ProcessAttestation(ctx, []uint64{0, 1, 2, 3}, indexToHash(1), 0)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(1), head)
+	mid, midContent := time.Unix(f.store.now().Unix(), 0), storeContent(t, f)
+
+	setTime(2, 5)
+	require.NoError(t, f.NewSlot(ctx, 2))
+	st, roblock, err = prepareForkchoiceState(ctx, 2, indexToHash(2), indexToHash(1), indexToHash(102), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	st, roblock, err = prepareForkchoiceState(ctx, 2, indexToHash(3), zeroHash, indexToHash(103), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	f.ProcessAttestation(ctx, []uint64{4, 5, 6}, indexToHash(3), 0)
+	f.InsertSlashedIndex(ctx, 1)
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(1)))
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	_, err = f.SetOptimisticToInvalid(ctx, indexToHash(3), zeroHash, zeroHash)
+	require.NoError(t, err)
+	// A failed call is journaled along with a snapshot of the store.
+	require.ErrorIs(t, f.SetOptimisticToValid(ctx, indexToHash(30)), ErrNilNode)
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	endContent := storeContent(t, f)
+	require.NotEqual(t, midContent, endContent)
+	require.NoError(t, j.Close())
+	// Calls after closing the journal are not journaled.
+	f.ProcessAttestation(ctx, []uint64{7}, indexToHash(2), 0)
+
+	replayed, err := ReplayJournalDir(ctx, dir, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, endContent, storeContent(t, replayed))
+	replayed, err = ReplayJournalDir(ctx, dir, mid)
+	require.NoError(t, err)
+	require.Equal(t, midContent, storeContent(t, replayed))
+	head, err = replayed.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(1), head)
+
+	files, err := JournalFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files))
+	_, err = ReplayJournal(ctx, mid.Add(-time.Hour), openJournalFile(t, files[0]))
+	require.ErrorIs(t, err, errJournalTooRecent)
+	_, err = ReplayJournal(ctx, time.Time{}, strings.NewReader(`{"time":1,"type":"new_slot","slot":1}`))
+	require.ErrorIs(t, err, errJournalNoSnapshot)
+}
+
+func TestJournal_ReplayDetectsDivergence(t *testing.T) {
+	ctx := context.Background()
+	f, j, setTime, dir := journaledStore(t)
+	setTime(1, 1)
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, indexToHash(101), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	// The head is journaled when it changes.
+	_, err = f.Head(ctx)
+	require.NoError(t, err)
+	require.NoError(t, j.Close())
+
+	files, err := JournalFiles(dir)
+	require.NoError(t, err)
+	b, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(b), `"type":"head"`))
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	e := &journalEntry{}
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), e))
+	require.Equal(t, journalHead, e.Type)
+	e.root(indexToHash(2))
+	tampered, err := json.Marshal(e)
+	require.NoError(t, err)
+	lines[len(lines)-1] = string(tampered)
+	_, err = ReplayJournal(ctx, time.Time{}, strings.NewReader(strings.Join(lines, "\n")))
+	require.ErrorIs(t, err, errJournalDiverged)
+
+	// A truncated last entry is ignored.
+	truncated := strings.Join(lines[:len(lines)-1], "\n") + "\n" + lines[len(lines)-1][:10]
+	_, err = ReplayJournal(ctx, time.Time{}, strings.NewReader(truncated))
+	require.NoError(t, err)
+}
+
+func TestJournal_Rotation(t *testing.T) {
+	ctx := context.Background()
+	f, j, setTime, dir := journaledStore(t, WithJournalMaxFileSize(1), WithJournalMaxFiles(3))
+	_, err := NewJournal(t.TempDir(), WithJournalMaxFiles(0))
+	require.ErrorContains(t, "at least one file", err)
+
+	for i := uint64(1); i <= 5; i++ {
+		// Files are rotated by the first call after the writer finds the current one full.
+		waitForRotation(t, j)
+		setTime(primitives.Slot(i), 0)
+		require.NoError(t, f.NewSlot(ctx, primitives.Slot(i)))
+		f.ProcessAttestation(ctx, []uint64{i}, params.BeaconConfig().ZeroHash, 0)
+	}
+	require.NoError(t, j.Close())
+	files, err := JournalFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(files))
+
+	// Every file starts with a snapshot, files before the deleted ones are not needed.
+	replayed, err := ReplayJournalDir(ctx, dir, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, storeContent(t, f), storeContent(t, replayed))
+	_, err = ReplayJournalDir(ctx, dir, time.Unix(int64(f.store.genesisTime), 0))
+	require.ErrorIs(t, err, errJournalTooRecent)
+}
+
+// waitForRotation waits for the writer of the journal to ask for the current file to be rotated.
+func waitForRotation(t *testing.T, j *Journal) {
+	deadline := time.Now().Add(10 * time.Second)
+	for !j.full.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("journal file was not rotated")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func openJournalFile(t *testing.T, name string) *os.File {
+	fl, err := os.Open(name)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, fl.Close()) })
+	return fl
+}
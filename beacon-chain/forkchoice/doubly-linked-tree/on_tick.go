@@ -29,7 +29,11 @@ import (
 //	    ancestor_at_finalized_slot = get_ancestor(store, store.best_justified_checkpoint.root, finalized_slot)
 //	    if ancestor_at_finalized_slot == store.finalized_checkpoint.root:
 //	        store.justified_checkpoint = store.best_justified_checkpoint
-func (f *ForkChoice) NewSlot(ctx context.Context, slot primitives.Slot) error {
+func (f *ForkChoice) NewSlot(ctx context.Context, slot primitives.Slot) (err error) {
+	je := f.journalBegin(journalNewSlot, false)
+	je.slot(slot)
+	defer func() { f.journalEnd(je, err) }()
+
 	// Reset proposer boost root
 	f.store.proposerBoostRoot = [32]byte{}
 
@@ -1,8 +1,6 @@
 package doublylinkedtree
 
 import (
-	"time"
-
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
@@ -42,7 +40,7 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 		return
 	}
 
-	if head.slot != slots.CurrentSlot(f.store.genesisTime) {
+	if head.slot != f.store.currentSlot() {
 		return
 	}
 
@@ -83,7 +81,7 @@ func (f *ForkChoice) ShouldOverrideFCU() (override bool) {
 	}
 
 	// Return early if we are checking before 10 seconds into the slot
-	secs, err := slots.SecondsSinceSlotStart(head.slot, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot, f.store.genesisTime, f.store.currentTime())
 	if err != nil {
 		log.WithError(err).Error("could not check current slot")
 		return true
@@ -112,7 +110,7 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	}
 
 	// Only reorg blocks from the previous slot.
-	if head.slot+1 != slots.CurrentSlot(f.store.genesisTime) {
+	if head.slot+1 != f.store.currentSlot() {
 		return head.root
 	}
 	// Do not reorg on epoch boundaries
@@ -153,7 +151,7 @@ func (f *ForkChoice) GetProposerHead() [32]byte {
 	}
 
 	// Only reorg if we are proposing early
-	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, uint64(time.Now().Unix()))
+	secs, err := slots.SecondsSinceSlotStart(head.slot+1, f.store.genesisTime, f.store.currentTime())
 	if err != nil {
 		log.WithError(err).Error("could not check if proposing early")
 		return head.root
@@ -0,0 +1,62 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"time"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+)
+
+// SetTimeSource replaces the system clock used by the store to compute the
+// current slot, the timeliness of inserted blocks and the late block reorg
+// decisions. It is meant for tools replaying fork choice offline, a running
+// beacon node always uses the system clock.
+func (f *ForkChoice) SetTimeSource(now func() time.Time) {
+	f.store.now = now
+}
+
+// InsertDumpedNode inserts a node as described by a fork choice dump, without
+// its block or post-state. The node keeps the checkpoints, payload hash,
+// validity and timestamp of the dump, the checkpoints of the store are left
+// untouched. Its parent must already be in the store, unless the store is
+// empty, in which case the node becomes the tree root.
+func (f *ForkChoice) InsertDumpedNode(ctx context.Context, dumped *forkchoice2.Node) error {
+	ctx, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.InsertDumpedNode")
+	defer span.End()
+
+	if dumped == nil {
+		return ErrNilNode
+	}
+	root := bytesutil.ToBytes32(dumped.BlockRoot)
+	if _, ok := f.store.nodeByRoot[root]; ok {
+		return nil
+	}
+	n := &Node{
+		slot:                     dumped.Slot,
+		root:                     root,
+		parent:                   f.store.nodeByRoot[bytesutil.ToBytes32(dumped.ParentRoot)],
+		justifiedEpoch:           dumped.JustifiedEpoch,
+		unrealizedJustifiedEpoch: dumped.UnrealizedJustifiedEpoch,
+		finalizedEpoch:           dumped.FinalizedEpoch,
+		unrealizedFinalizedEpoch: dumped.UnrealizedFinalizedEpoch,
+		optimistic:               dumped.Validity != forkchoice2.Valid,
+		payloadHash:              bytesutil.ToBytes32(dumped.ExecutionBlockHash),
+		timestamp:                dumped.Timestamp,
+	}
+	_, err := f.store.insertNode(ctx, n)
+	return err
+}
+
+// SetUnrealizedCheckpoints sets the unrealized justified and finalized
+// checkpoints of the store, which are realized at the next epoch boundary.
+func (f *ForkChoice) SetUnrealizedCheckpoints(uj, uf *forkchoicetypes.Checkpoint) error {
+	if uj == nil || uf == nil {
+		return errInvalidNilCheckpoint
+	}
+	f.store.unrealizedJustifiedCheckpoint = uj
+	f.store.unrealizedFinalizedCheckpoint = uf
+	return nil
+}
@@ -0,0 +1,77 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_InsertDumpedNode(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	driftGenesisTime(f, 3, 0)
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, indexToHash(101), 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	st, roblock, err = prepareForkchoiceState(ctx, 2, indexToHash(2), indexToHash(1), indexToHash(102), 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(1)))
+	dump, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+
+	g := New()
+	g.SetGenesisTime(f.store.genesisTime)
+	for _, n := range dump.ForkChoiceNodes {
+		require.NoError(t, g.InsertDumpedNode(ctx, n))
+	}
+	// Inserting a known node is a no-op.
+	require.NoError(t, g.InsertDumpedNode(ctx, dump.ForkChoiceNodes[1]))
+	require.Equal(t, f.NodeCount(), g.NodeCount())
+	got, err := g.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, dump.ForkChoiceNodes, got.ForkChoiceNodes)
+
+	root, parent := indexToHash(3), indexToHash(30)
+	orphan := &forkchoice2.Node{Slot: 3, BlockRoot: root[:], ParentRoot: parent[:]}
+	require.ErrorIs(t, g.InsertDumpedNode(ctx, orphan), errInvalidParentRoot)
+	require.ErrorIs(t, g.InsertDumpedNode(ctx, nil), ErrNilNode)
+}
+
+func TestForkChoice_SetTimeSource(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	genesis := uint64(time.Now().Add(-time.Hour).Unix())
+	f.SetGenesisTime(genesis)
+	slot := uint64(2)
+	now := time.Unix(int64(genesis+slot*params.BeaconConfig().SecondsPerSlot+1), 0)
+	f.SetTimeSource(func() time.Time { return now })
+	require.Equal(t, uint64(now.Unix()), f.store.currentTime())
+	require.Equal(t, slot, uint64(f.store.currentSlot()))
+
+	// A block of the current slot received one second into the slot is boosted.
+	root := indexToHash(2)
+	n := &forkchoice2.Node{Slot: 2, BlockRoot: root[:], ParentRoot: params.BeaconConfig().ZeroHash[:], Timestamp: uint64(now.Unix())}
+	require.NoError(t, f.InsertDumpedNode(ctx, n))
+	require.Equal(t, indexToHash(2), f.ProposerBoost())
+	early, err := f.store.nodeByRoot[indexToHash(2)].arrivedEarly(genesis)
+	require.NoError(t, err)
+	require.Equal(t, true, early)
+}
+
+func TestForkChoice_SetUnrealizedCheckpoints(t *testing.T) {
+	f := setup(0, 0)
+	require.ErrorIs(t, f.SetUnrealizedCheckpoints(nil, &forkchoicetypes.Checkpoint{}), errInvalidNilCheckpoint)
+	uj := &forkchoicetypes.Checkpoint{Epoch: 2, Root: indexToHash(2)}
+	uf := &forkchoicetypes.Checkpoint{Epoch: 1, Root: indexToHash(1)}
+	require.NoError(t, f.SetUnrealizedCheckpoints(uj, uf))
+	dump, err := f.ForkChoiceDump(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uj.Epoch, dump.UnrealizedJustifiedCheckpoint.Epoch)
+	require.Equal(t, uf.Epoch, dump.UnrealizedFinalizedCheckpoint.Epoch)
+}
@@ -44,7 +44,7 @@ func (s *Store) head(ctx context.Context) ([32]byte, error) {
 	if bestDescendant == nil {
 		bestDescendant = justifiedNode
 	}
-	currentEpoch := slots.EpochsSinceGenesis(time.Unix(int64(s.genesisTime), 0))
+	currentEpoch := slots.ToEpoch(s.currentSlot())
 	if !bestDescendant.viableForHead(s.justifiedCheckpoint.Epoch, currentEpoch) {
 		s.allTipsAreInvalid = true
 		return [32]byte{}, fmt.Errorf("head at slot %d with weight %d is not eligible, finalizedEpoch, justified Epoch %d, %d != %d, %d",
@@ -99,8 +99,15 @@ func (s *Store) insert(ctx context.Context,
 		unrealizedFinalizedEpoch: finalizedEpoch,
 		optimistic:               true,
 		payloadHash:              payloadHash,
-		timestamp:                uint64(time.Now().Unix()),
+		timestamp:                s.currentTime(),
 	}
+	return s.insertNode(ctx, n)
+}
+
+// insertNode links the given node to its parent, applies proposer boost when
+// the node is timely and updates the best descendants of the tree.
+func (s *Store) insertNode(ctx context.Context, n *Node) (*Node, error) {
+	root, slot, parent, payloadHash := n.root, n.slot, n.parent, n.payloadHash
 
 	// Set the node's target checkpoint
 	if slot%params.BeaconConfig().SlotsPerEpoch == 0 {
@@ -128,12 +135,12 @@ func (s *Store) insert(ctx context.Context,
 	} else {
 		parent.children = append(parent.children, n)
 		// Apply proposer boost
-		timeNow := uint64(time.Now().Unix())
+		timeNow := s.currentTime()
 		if timeNow < s.genesisTime {
 			return n, nil
 		}
 		secondsIntoSlot := (timeNow - s.genesisTime) % params.BeaconConfig().SecondsPerSlot
-		currentSlot := slots.CurrentSlot(s.genesisTime)
+		currentSlot := s.currentSlot()
 		boostThreshold := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
 		isFirstBlock := s.proposerBoostRoot == [32]byte{}
 		if currentSlot == slot && secondsIntoSlot < boostThreshold && isFirstBlock {
@@ -156,7 +163,7 @@ func (s *Store) insert(ctx context.Context,
 	nodeCount.Set(float64(len(s.nodeByRoot)))
 
 	// Only update received block slot if it's within epoch from current time.
-	if slot+params.BeaconConfig().SlotsPerEpoch > slots.CurrentSlot(s.genesisTime) {
+	if slot+params.BeaconConfig().SlotsPerEpoch > s.currentSlot() {
 		s.receivedBlocksLastEpoch[slot%params.BeaconConfig().SlotsPerEpoch] = slot
 	}
 	// Update highest slot tracking.
@@ -277,7 +284,7 @@ func (f *ForkChoice) HighestReceivedBlockDelay() primitives.Slot {
 // ReceivedBlocksLastEpoch returns the number of blocks received in the last epoch
 func (f *ForkChoice) ReceivedBlocksLastEpoch() (uint64, error) {
 	count := uint64(0)
-	lowerBound := slots.CurrentSlot(f.store.genesisTime)
+	lowerBound := f.store.currentSlot()
 	var err error
 	if lowerBound > fieldparams.SlotsPerEpoch {
 		lowerBound, err = lowerBound.SafeSub(fieldparams.SlotsPerEpoch)
@@ -293,3 +300,20 @@ func (f *ForkChoice) ReceivedBlocksLastEpoch() (uint64, error) {
 	}
 	return count, nil
 }
+
+// currentTime returns the current unix time in seconds, as seen by the store.
+func (s *Store) currentTime() uint64 {
+	if s.now != nil {
+		return uint64(s.now().Unix())
+	}
+	return uint64(time.Now().Unix())
+}
+
+// currentSlot returns the slot of the current time, as seen by the store.
+func (s *Store) currentSlot() primitives.Slot {
+	now := s.currentTime()
+	if now < s.genesisTime {
+		return 0
+	}
+	return primitives.Slot((now - s.genesisTime) / params.BeaconConfig().SecondsPerSlot)
+}
@@ -0,0 +1,32 @@
+package lightclient
+
+import "time"
+
+// Option configures the light client service.
+type Option func(s *Service) error
+
+// WithProvider sets the provider of the light client data.
+func WithProvider(p Provider) Option {
+	return func(s *Service) error {
+		s.cfg.provider = p
+		return nil
+	}
+}
+
+// WithTrustedBlockRoot sets the root of the block the light client starts syncing from.
+func WithTrustedBlockRoot(root [32]byte) Option {
+	return func(s *Service) error {
+		s.cfg.trustedBlockRoot = root
+		return nil
+	}
+}
+
+// WithGenesis sets the genesis time and genesis validators root of the network. The provider must serve the
+// same genesis, as the signatures of the light client data are verified against the genesis validators root.
+func WithGenesis(genesisTime time.Time, genesisValidatorsRoot [32]byte) Option {
+	return func(s *Service) error {
+		s.cfg.genesisTime = genesisTime
+		s.cfg.genesisValidatorsRoot = genesisValidatorsRoot
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package lightclient
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+// Provider serves the light client data which the light client syncs from.
+type Provider interface {
+	Genesis(ctx context.Context) (time.Time, [32]byte, error)
+	Bootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error)
+	Updates(ctx context.Context, startPeriod, count uint64) ([]interfaces.LightClientUpdate, error)
+	FinalityUpdate(ctx context.Context) (interfaces.LightClientFinalityUpdate, error)
+	OptimisticUpdate(ctx context.Context) (interfaces.LightClientOptimisticUpdate, error)
+}
+
+// RESTProvider fetches light client data from the light client endpoints of a beacon node's Beacon API.
+type RESTProvider struct {
+	c *beacon.Client
+}
+
+var _ Provider = &RESTProvider{}
+
+// NewRESTProvider returns a provider for the beacon node at the given host.
+func NewRESTProvider(host string, opts ...client.ClientOpt) (*RESTProvider, error) {
+	c, err := beacon.NewClient(host, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &RESTProvider{c: c}, nil
+}
+
+// Genesis returns the genesis time and genesis validators root of the beacon node's chain.
+func (p *RESTProvider) Genesis(ctx context.Context) (time.Time, [32]byte, error) {
+	g, err := p.c.GetGenesis(ctx)
+	if err != nil {
+		return time.Time{}, [32]byte{}, err
+	}
+	genesisTime, err := strconv.ParseUint(g.GenesisTime, 10, 64)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrapf(err, "could not parse genesis time %s", g.GenesisTime)
+	}
+	gvr, err := bytesutil.DecodeHexWithLength(g.GenesisValidatorsRoot, 32)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not decode genesis validators root")
+	}
+	return time.Unix(int64(genesisTime), 0), bytesutil.ToBytes32(gvr), nil
+}
+
+// Bootstrap returns the light client bootstrap of the block root.
+func (p *RESTProvider) Bootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error) {
+	resp, err := p.c.GetLightClientBootstrap(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	bootstrap, err := resp.Data.ToConsensus()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode light client bootstrap")
+	}
+	m, err := lightclient.BootstrapToProto(bootstrap)
+	if err != nil {
+		return nil, err
+	}
+	return lightclienttypes.NewWrappedBootstrap(m)
+}
+
+// Updates returns the best light client updates of count sync committee periods, starting with startPeriod.
+func (p *RESTProvider) Updates(ctx context.Context, startPeriod, count uint64) ([]interfaces.LightClientUpdate, error) {
+	resp, err := p.c.GetLightClientUpdatesByRange(ctx, startPeriod, count)
+	if err != nil {
+		return nil, err
+	}
+	updates := make([]interfaces.LightClientUpdate, 0, len(resp))
+	for _, r := range resp {
+		if r == nil {
+			continue
+		}
+		update, err := r.Data.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode light client update")
+		}
+		m, err := lightclient.UpdateToProto(update)
+		if err != nil {
+			return nil, err
+		}
+		wrapped, err := lightclienttypes.NewWrappedUpdate(m)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, wrapped)
+	}
+	return updates, nil
+}
+
+// FinalityUpdate returns the latest light client finality update known to the beacon node.
+func (p *RESTProvider) FinalityUpdate(ctx context.Context) (interfaces.LightClientFinalityUpdate, error) {
+	resp, err := p.c.GetLightClientFinalityUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update, err := resp.Data.ToConsensus()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode light client finality update")
+	}
+	m, err := lightclient.FinalityUpdateToProto(update)
+	if err != nil {
+		return nil, err
+	}
+	return lightclienttypes.NewWrappedFinalityUpdate(m)
+}
+
+// OptimisticUpdate returns the latest light client optimistic update known to the beacon node.
+func (p *RESTProvider) OptimisticUpdate(ctx context.Context) (interfaces.LightClientOptimisticUpdate, error) {
+	resp, err := p.c.GetLightClientOptimisticUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update, err := resp.Data.ToConsensus()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode light client optimistic update")
+	}
+	m, err := lightclient.OptimisticUpdateToProto(update)
+	if err != nil {
+		return nil, err
+	}
+	return lightclienttypes.NewWrappedOptimisticUpdate(m)
+}
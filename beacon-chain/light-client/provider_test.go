@@ -0,0 +1,43 @@
+package lightclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestRESTProvider_FinalityUpdate(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestDeneb(false)
+	update, err := lightclient.NewLightClientFinalityUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+	require.NoError(t, err)
+	data, err := structs.LightClientFinalityUpdateFromConsensus(update)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/beacon/light_client/finality_update", r.URL.Path)
+		httputil.WriteJson(w, &structs.LightClientFinalityUpdateResponse{Version: "deneb", Data: data})
+	}))
+	defer srv.Close()
+
+	p, err := NewRESTProvider(srv.URL)
+	require.NoError(t, err)
+	got, err := p.FinalityUpdate(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, version.Deneb, got.Version())
+	require.Equal(t, update.SignatureSlot, got.SignatureSlot())
+	require.Equal(t, l.AttestedBlock.Block().Slot(), got.AttestedHeader().Beacon().Slot)
+
+	execution, err := got.AttestedHeader().Execution()
+	require.NoError(t, err)
+	payload, err := l.AttestedBlock.Block().Body().Execution()
+	require.NoError(t, err)
+	require.DeepEqual(t, payload.BlockHash(), execution.BlockHash())
+}
@@ -0,0 +1,118 @@
+package lightclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// RegisterRoutes registers the endpoints serving the state of the light client.
+func (s *Service) RegisterRoutes(router *http.ServeMux) {
+	router.HandleFunc("GET /prysm/v1/light_client/header", s.GetHeader)
+	router.HandleFunc("GET /prysm/v1/light_client/finality", s.GetFinality)
+	router.HandleFunc("GET /prysm/v1/light_client/execution_payload_header", s.GetExecutionPayloadHeader)
+}
+
+// GetHeader returns the latest header attested by the sync committee, which is the head of the light client.
+func (s *Service) GetHeader(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.GetHeader")
+	defer span.End()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.store == nil {
+		httputil.HandleError(w, errNotInitialized.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	header := s.store.OptimisticHeader()
+	data, err := structs.LightClientHeaderFromConsensus(header)
+	if err != nil {
+		httputil.HandleError(w, "Could not convert light client header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.LightClientHeaderResponse{
+		Version: version.String(header.Version()),
+		Data:    data,
+	})
+}
+
+// GetFinality returns the latest finalized header of the light client, along with its sync committee period.
+func (s *Service) GetFinality(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.GetFinality")
+	defer span.End()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.store == nil {
+		httputil.HandleError(w, errNotInitialized.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	header := s.store.FinalizedHeader()
+	data, err := structs.LightClientHeaderFromConsensus(header)
+	if err != nil {
+		httputil.HandleError(w, "Could not convert light client header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.LightClientFinalityResponse{
+		Data: &structs.LightClientFinality{
+			Version:                version.String(header.Version()),
+			Header:                 data,
+			Period:                 strconv.FormatUint(s.store.FinalizedPeriod(), 10),
+			NextSyncCommitteeKnown: s.store.IsNextSyncCommitteeKnown(),
+		},
+	})
+}
+
+// GetExecutionPayloadHeader returns the execution payload header of the head of the light client, which is
+// verified against the body root of the head block.
+func (s *Service) GetExecutionPayloadHeader(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "lightclient.GetExecutionPayloadHeader")
+	defer span.End()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.store == nil {
+		httputil.HandleError(w, errNotInitialized.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	header := s.store.OptimisticHeader()
+	if header.Version() < version.Capella {
+		httputil.HandleError(w, "Light client head has no execution payload header", http.StatusNotFound)
+		return
+	}
+	execution, err := header.Execution()
+	if err != nil {
+		httputil.HandleError(w, "Could not get execution payload header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var payload any
+	switch e := execution.Proto().(type) {
+	case *enginev1.ExecutionPayloadHeaderCapella:
+		payload, err = structs.ExecutionPayloadHeaderCapellaFromConsensus(e)
+	case *enginev1.ExecutionPayloadHeaderDeneb:
+		payload, err = structs.ExecutionPayloadHeaderDenebFromConsensus(e)
+	default:
+		httputil.HandleError(w, "Unsupported execution payload header", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not convert execution payload header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		httputil.HandleError(w, "Could not marshal execution payload header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.LightClientExecutionPayloadHeaderResponse{
+		Version: version.String(header.Version()),
+		Data:    data,
+	})
+}
@@ -0,0 +1,64 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_GetHeader(t *testing.T) {
+	bootstrap, root := testBootstrap(t)
+	p := &mockProvider{}
+	s, err := NewService(context.Background(), WithProvider(p), WithTrustedBlockRoot(root), testGenesis)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/light_client/header", nil)
+	writer := httptest.NewRecorder()
+	s.GetHeader(writer, request)
+	require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+
+	p.bootstrap = bootstrap
+	require.NoError(t, s.initialize())
+	writer = httptest.NewRecorder()
+	s.GetHeader(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.LightClientHeaderResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, "altair", resp.Version)
+	header := &structs.LightClientHeader{}
+	require.NoError(t, json.Unmarshal(resp.Data, header))
+	require.Equal(t, "8", header.Beacon.Slot)
+}
+
+func TestService_GetFinality(t *testing.T) {
+	bootstrap, root := testBootstrap(t)
+	s, err := NewService(context.Background(), WithProvider(&mockProvider{bootstrap: bootstrap}), WithTrustedBlockRoot(root), testGenesis)
+	require.NoError(t, err)
+	require.NoError(t, s.initialize())
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/light_client/finality", nil)
+	writer := httptest.NewRecorder()
+	s.GetFinality(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.LightClientFinalityResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, "0", resp.Data.Period)
+	require.Equal(t, false, resp.Data.NextSyncCommitteeKnown)
+}
+
+func TestService_GetExecutionPayloadHeader_PreCapella(t *testing.T) {
+	bootstrap, root := testBootstrap(t)
+	s, err := NewService(context.Background(), WithProvider(&mockProvider{bootstrap: bootstrap}), WithTrustedBlockRoot(root), testGenesis)
+	require.NoError(t, err)
+	require.NoError(t, s.initialize())
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/light_client/execution_payload_header", nil)
+	writer := httptest.NewRecorder()
+	s.GetExecutionPayloadHeader(writer, request)
+	require.Equal(t, http.StatusNotFound, writer.Code)
+}
@@ -0,0 +1,246 @@
+// Package lightclient defines a service which follows the beacon chain as a light client, verifying the
+// light client data served by a beacon node without processing blocks or storing states.
+package lightclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errNotInitialized  = errors.New("light client store is not initialized")
+	errGenesisMismatch = errors.New("light client data provider is on another network")
+)
+
+type config struct {
+	provider              Provider
+	trustedBlockRoot      [32]byte
+	genesisTime           time.Time
+	genesisValidatorsRoot [32]byte
+}
+
+// Service syncs a light client store from the trusted block root to the head of the chain.
+type Service struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	cfg         *config
+	lock        sync.RWMutex
+	store       *lightclient.Store
+	genesisTime time.Time
+	err         error
+}
+
+// NewService returns a light client service configured with the options.
+func NewService(ctx context.Context, opts ...Option) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		cfg:    &config{},
+	}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.cfg.provider == nil {
+		cancel()
+		return nil, errors.New("no light client data provider configured")
+	}
+	if s.cfg.trustedBlockRoot == [32]byte{} {
+		cancel()
+		return nil, errors.New("no trusted block root configured")
+	}
+	if s.cfg.genesisTime.IsZero() || s.cfg.genesisValidatorsRoot == [32]byte{} {
+		cancel()
+		return nil, errors.New("no genesis configured")
+	}
+	return s, nil
+}
+
+// Start the light client service.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the light client service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status returns an error until the light client store is initialized from the trusted block root.
+func (s *Service) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.err != nil {
+		return s.err
+	}
+	if s.store == nil {
+		return errNotInitialized
+	}
+	return nil
+}
+
+func (s *Service) run() {
+	retryInterval := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	for {
+		err := s.initialize()
+		if err == nil {
+			break
+		}
+		if errors.Is(err, errGenesisMismatch) {
+			// Retrying is pointless, none of the data served by the provider can be verified.
+			log.WithError(err).Error("Could not initialize light client store")
+			s.lock.Lock()
+			s.err = err
+			s.lock.Unlock()
+			return
+		}
+		log.WithError(err).Error("Could not initialize light client store, retrying")
+		select {
+		case <-time.After(retryInterval):
+		case <-s.ctx.Done():
+			return
+		}
+	}
+
+	s.sync(slots.CurrentSlot(uint64(s.genesisTime.Unix())))
+	ticker := slots.NewSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			s.sync(slot)
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// initialize checks that the provider follows the configured network and initializes the light client store
+// from the bootstrap of the trusted block root.
+func (s *Service) initialize() error {
+	genesisTime, gvr, err := s.cfg.provider.Genesis(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not fetch genesis")
+	}
+	if !genesisTime.Equal(s.cfg.genesisTime) || gvr != s.cfg.genesisValidatorsRoot {
+		return errors.Wrapf(
+			errGenesisMismatch,
+			"provider genesis time %d and genesis validators root %#x, expected %d and %#x",
+			genesisTime.Unix(), gvr, s.cfg.genesisTime.Unix(), s.cfg.genesisValidatorsRoot,
+		)
+	}
+	bootstrap, err := s.cfg.provider.Bootstrap(s.ctx, s.cfg.trustedBlockRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not fetch light client bootstrap of block root %#x", s.cfg.trustedBlockRoot)
+	}
+	store, err := lightclient.NewStore(s.cfg.trustedBlockRoot, bootstrap, s.cfg.genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.store = store
+	s.genesisTime = s.cfg.genesisTime
+	log.WithFields(logrus.Fields{
+		"trustedBlockRoot": fmt.Sprintf("%#x", s.cfg.trustedBlockRoot),
+		"slot":             store.FinalizedHeader().Beacon().Slot,
+	}).Info("Initialized light client store")
+	return nil
+}
+
+// sync brings the light client store up to date at the slot: the best updates of the sync committee periods
+// which the store is behind on are applied first, then the latest finality and optimistic updates.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/light-client.md#light-client-sync-process
+func (s *Service) sync(currentSlot primitives.Slot) {
+	s.lock.RLock()
+	finalizedPeriod := s.store.FinalizedPeriod()
+	nextSyncCommitteeKnown := s.store.IsNextSyncCommitteeKnown()
+	s.lock.RUnlock()
+
+	// Light client data is fetched without holding the lock, so that the store can be read meanwhile.
+	var updates []interfaces.LightClientUpdate
+	currentPeriod := slots.SyncCommitteePeriod(slots.ToEpoch(currentSlot))
+	if !nextSyncCommitteeKnown || finalizedPeriod+1 < currentPeriod {
+		count := uint64(1)
+		if currentPeriod > finalizedPeriod {
+			count = min(currentPeriod-finalizedPeriod+1, params.BeaconConfig().MaxRequestLightClientUpdates)
+		}
+		var err error
+		updates, err = s.cfg.provider.Updates(s.ctx, finalizedPeriod, count)
+		if err != nil {
+			log.WithError(err).Error("Could not fetch light client updates")
+		}
+	}
+	finalityUpdate, err := s.cfg.provider.FinalityUpdate(s.ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not fetch light client finality update")
+	}
+	optimisticUpdate, err := s.cfg.provider.OptimisticUpdate(s.ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not fetch light client optimistic update")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	finalizedSlot := s.store.FinalizedHeader().Beacon().Slot
+	optimisticSlot := s.store.OptimisticHeader().Beacon().Slot
+
+	for _, update := range updates {
+		if err := s.store.ProcessUpdate(update, currentSlot); err != nil {
+			log.WithError(err).WithField("attestedSlot", update.AttestedHeader().Beacon().Slot).Debug("Could not process light client update")
+		}
+	}
+	if finalityUpdate != nil {
+		if err := s.store.ProcessFinalityUpdate(finalityUpdate, currentSlot); err != nil {
+			log.WithError(err).Debug("Could not process light client finality update")
+		}
+	}
+	if optimisticUpdate != nil {
+		if err := s.store.ProcessOptimisticUpdate(optimisticUpdate, currentSlot); err != nil {
+			log.WithError(err).Debug("Could not process light client optimistic update")
+		}
+	}
+	if updated, err := s.store.ProcessForceUpdate(currentSlot); err != nil {
+		log.WithError(err).Error("Could not force light client store update")
+	} else if updated {
+		log.WithField("slot", s.store.FinalizedHeader().Beacon().Slot).Warn("No finality update for a whole sync committee period, forced light client store update")
+	}
+
+	if header := s.store.FinalizedHeader().Beacon(); header.Slot != finalizedSlot {
+		log.WithFields(logrus.Fields{
+			"slot":   header.Slot,
+			"root":   headerRoot(header),
+			"period": s.store.FinalizedPeriod(),
+		}).Info("Light client finalized header updated")
+	}
+	if header := s.store.OptimisticHeader().Beacon(); header.Slot != optimisticSlot {
+		log.WithFields(logrus.Fields{
+			"slot": header.Slot,
+			"root": headerRoot(header),
+		}).Info("Light client head updated")
+	}
+}
+
+func headerRoot(header *ethpb.BeaconBlockHeader) string {
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%#x", root)
+}
@@ -0,0 +1,141 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+var (
+	errUnavailable            = errors.New("unavailable")
+	testGenesisTime           = time.Unix(1606824023, 0)
+	testGenesisValidatorsRoot = [32]byte{'g'}
+	testGenesis               = WithGenesis(testGenesisTime, testGenesisValidatorsRoot)
+)
+
+type mockProvider struct {
+	bootstrap    interfaces.LightClientBootstrap
+	otherNetwork bool
+}
+
+func (p *mockProvider) Genesis(context.Context) (time.Time, [32]byte, error) {
+	if p.otherNetwork {
+		return testGenesisTime, [32]byte{'o'}, nil
+	}
+	return testGenesisTime, testGenesisValidatorsRoot, nil
+}
+
+func (p *mockProvider) Bootstrap(context.Context, [32]byte) (interfaces.LightClientBootstrap, error) {
+	if p.bootstrap == nil {
+		return nil, errUnavailable
+	}
+	return p.bootstrap, nil
+}
+
+func (*mockProvider) Updates(context.Context, uint64, uint64) ([]interfaces.LightClientUpdate, error) {
+	return nil, errUnavailable
+}
+
+func (*mockProvider) FinalityUpdate(context.Context) (interfaces.LightClientFinalityUpdate, error) {
+	return nil, errUnavailable
+}
+
+func (*mockProvider) OptimisticUpdate(context.Context) (interfaces.LightClientOptimisticUpdate, error) {
+	return nil, errUnavailable
+}
+
+// testBootstrap returns a bootstrap of a header at slot 8 and the root of the header.
+func testBootstrap(t *testing.T) (interfaces.LightClientBootstrap, [32]byte) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = key.PublicKey().Marshal()
+	}
+	committee := &ethpb.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: key.PublicKey().Marshal()}
+	st, err := util.NewBeaconStateAltair(func(s *ethpb.BeaconStateAltair) error {
+		s.Slot = 8
+		s.CurrentSyncCommittee = committee
+		return nil
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	require.NoError(t, err)
+
+	header := &ethpb.BeaconBlockHeader{
+		Slot:       8,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  stateRoot[:],
+		BodyRoot:   make([]byte, 32),
+	}
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	bootstrap, err := lightclienttypes.NewWrappedBootstrap(&ethpb.LightClientBootstrapAltair{
+		Header:                     &ethpb.LightClientHeaderAltair{Beacon: header},
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: branch,
+	})
+	require.NoError(t, err)
+	return bootstrap, root
+}
+
+func TestNewService(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewService(ctx, WithTrustedBlockRoot([32]byte{'a'}), testGenesis)
+	require.ErrorContains(t, "no light client data provider configured", err)
+	_, err = NewService(ctx, WithProvider(&mockProvider{}), testGenesis)
+	require.ErrorContains(t, "no trusted block root configured", err)
+	_, err = NewService(ctx, WithProvider(&mockProvider{}), WithTrustedBlockRoot([32]byte{'a'}))
+	require.ErrorContains(t, "no genesis configured", err)
+}
+
+func TestService_Initialize(t *testing.T) {
+	bootstrap, root := testBootstrap(t)
+	p := &mockProvider{}
+	s, err := NewService(context.Background(), WithProvider(p), WithTrustedBlockRoot(root), testGenesis)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, "could not fetch light client bootstrap", s.initialize())
+	require.ErrorIs(t, s.Status(), errNotInitialized)
+
+	p.bootstrap = bootstrap
+	require.NoError(t, s.initialize())
+	require.NoError(t, s.Status())
+
+	// Syncing without any light client data available leaves the store as it was.
+	s.sync(100)
+	require.Equal(t, uint64(8), uint64(s.store.OptimisticHeader().Beacon().Slot))
+}
+
+func TestService_RejectsProviderOfAnotherNetwork(t *testing.T) {
+	bootstrap, root := testBootstrap(t)
+	p := &mockProvider{bootstrap: bootstrap, otherNetwork: true}
+	s, err := NewService(context.Background(), WithProvider(p), WithTrustedBlockRoot(root), testGenesis)
+	require.NoError(t, err)
+	require.ErrorIs(t, s.initialize(), errGenesisMismatch)
+
+	// The service gives up instead of retrying.
+	done := make(chan struct{})
+	go func() {
+		s.run()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Light client service did not stop")
+	}
+	require.ErrorIs(t, s.Status(), errGenesisMismatch)
+}
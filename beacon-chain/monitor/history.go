@@ -0,0 +1,78 @@
+package monitor
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// pendingSummary returns the summary of a tracked validator for an epoch which has not been saved yet,
+// or nil if there is none.
+// It assumes that a read lock is held on the monitor service.
+func (s *Service) pendingSummary(idx primitives.ValidatorIndex, epoch primitives.Epoch) *monitortypes.ValidatorEpochSummary {
+	return s.history[idx][epoch]
+}
+
+// updateHistory creates the epoch summaries of the tracked validators which are active in the epoch of the
+// given post-block state, and updates their end balances.
+func (s *Service) updateHistory(st state.BeaconState, epoch primitives.Epoch) {
+	s.Lock()
+	defer s.Unlock()
+	for idx := range s.TrackedValidators {
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			continue
+		}
+		summary := s.pendingSummary(idx, epoch)
+		if summary == nil {
+			val, err := st.ValidatorAtIndexReadOnly(idx)
+			if err != nil || !helpers.IsActiveValidatorUsingTrie(val, epoch) {
+				continue
+			}
+			summary = &monitortypes.ValidatorEpochSummary{
+				ValidatorIndex: idx,
+				Epoch:          epoch,
+				StartBalance:   balance,
+			}
+			if epoch > 0 {
+				if prev := s.pendingSummary(idx, epoch-1); prev != nil {
+					summary.StartBalance = prev.EndBalance
+				}
+			}
+			if s.history[idx] == nil {
+				s.history[idx] = make(map[primitives.Epoch]*monitortypes.ValidatorEpochSummary)
+			}
+			s.history[idx][epoch] = summary
+		}
+		summary.EndBalance = balance
+	}
+}
+
+// flushHistory saves the summaries of the epochs whose attestations can no longer be included
+// in blocks of the given epoch, and removes them from the pending summaries.
+func (s *Service) flushHistory(ctx context.Context, epoch primitives.Epoch) {
+	if epoch < 2 {
+		return
+	}
+	s.Lock()
+	var summaries []*monitortypes.ValidatorEpochSummary
+	for _, epochs := range s.history {
+		for e, summary := range epochs {
+			if e < epoch-1 {
+				summaries = append(summaries, summary)
+				delete(epochs, e)
+			}
+		}
+	}
+	s.Unlock()
+
+	if len(summaries) == 0 || s.config.BeaconDB == nil {
+		return
+	}
+	if err := s.config.BeaconDB.SaveValidatorMonitorHistory(ctx, summaries); err != nil {
+		log.WithError(err).Error("Could not save validator monitor history")
+	}
+}
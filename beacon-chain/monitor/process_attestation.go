@@ -93,7 +93,7 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 			inclusionSlotGauge.WithLabelValues(fmt.Sprintf("%d", idx)).Set(float64(latestPerf.inclusionSlot))
 			aggregatedPerf.totalDistance += uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
 
-			if state.Version() == version.Altair {
+			if state.Version() >= version.Altair {
 				targetIdx := params.BeaconConfig().TimelyTargetFlagIndex
 				sourceIdx := params.BeaconConfig().TimelySourceFlagIndex
 				headIdx := params.BeaconConfig().TimelyHeadFlagIndex
@@ -146,6 +146,14 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 					aggregatedPerf.totalCorrectTarget++
 				}
 			}
+			summary := s.pendingSummary(primitives.ValidatorIndex(idx), slots.ToEpoch(latestPerf.attestedSlot))
+			if summary != nil && !summary.AttestationIncluded {
+				summary.AttestationIncluded = true
+				summary.InclusionDistance = uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
+				summary.TimelySource = latestPerf.timelySource
+				summary.TimelyTarget = latestPerf.timelyTarget
+				summary.TimelyHead = latestPerf.timelyHead
+			}
 			logFields["correctHead"] = latestPerf.timelyHead
 			logFields["correctSource"] = latestPerf.timelySource
 			logFields["correctTarget"] = latestPerf.timelyTarget
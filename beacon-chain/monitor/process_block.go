@@ -54,9 +54,11 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.ReadOnlySignedB
 		s.updateSyncCommitteeTrackedVals(st)
 	}
 
+	s.updateHistory(st, currEpoch)
 	s.processSyncAggregate(st, blk)
 	s.processProposedBlock(st, root, blk)
 	s.processAttestations(ctx, st, blk)
+	s.flushHistory(ctx, currEpoch)
 
 	if blk.Slot()%(AggregateReportingPeriod*params.BeaconConfig().SlotsPerEpoch) == 0 {
 		s.logAggregatedPerformance()
@@ -88,6 +90,10 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 		aggPerf.totalProposedCount++
 		s.aggregatedPerformance[blk.ProposerIndex()] = aggPerf
 
+		if summary := s.pendingSummary(blk.ProposerIndex(), slots.ToEpoch(blk.Slot())); summary != nil {
+			summary.ProposedBlocks++
+		}
+
 		parentRoot := blk.ParentRoot()
 		log.WithFields(logrus.Fields{
 			"proposerIndex": blk.ProposerIndex(),
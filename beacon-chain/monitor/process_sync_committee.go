@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/sirupsen/logrus"
 )
 
@@ -65,6 +66,11 @@ func (s *Service) processSyncAggregate(state state.BeaconState, blk interfaces.R
 			aggPerf.totalSyncCommitteeContributions += uint64(contrib)
 			s.aggregatedPerformance[validatorIdx] = aggPerf
 
+			if summary := s.pendingSummary(validatorIdx, slots.ToEpoch(blk.Slot())); summary != nil {
+				summary.SyncCommitteeExpected += uint64(len(committeeIndices))
+				summary.SyncCommitteeIncluded += uint64(contrib)
+			}
+
 			syncCommitteeContributionCounter.WithLabelValues(
 				fmt.Sprintf("%d", validatorIdx)).Add(float64(contrib))
 
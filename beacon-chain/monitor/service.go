@@ -12,6 +12,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -59,6 +61,8 @@ type ValidatorMonitorConfig struct {
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
 	InitialSyncComplete chan struct{}
+	// BeaconDB persists the epoch summaries of the tracked validators, if set.
+	BeaconDB db.NoHeadAccessDatabase
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -70,13 +74,14 @@ type Service struct {
 	isLogging bool
 
 	// Locks access to TrackedValidators, latestPerformance, aggregatedPerformance,
-	// trackedSyncedCommitteeIndices and lastSyncedEpoch
+	// trackedSyncedCommitteeIndices, history and lastSyncedEpoch
 	sync.RWMutex
 
 	TrackedValidators           map[primitives.ValidatorIndex]bool
 	latestPerformance           map[primitives.ValidatorIndex]ValidatorLatestPerformance
 	aggregatedPerformance       map[primitives.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[primitives.ValidatorIndex][]primitives.CommitteeIndex
+	history                     map[primitives.ValidatorIndex]map[primitives.Epoch]*monitortypes.ValidatorEpochSummary
 	lastSyncedEpoch             primitives.Epoch
 }
 
@@ -91,6 +96,7 @@ func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []p
 		latestPerformance:           make(map[primitives.ValidatorIndex]ValidatorLatestPerformance),
 		aggregatedPerformance:       make(map[primitives.ValidatorIndex]ValidatorAggregatedPerformance),
 		trackedSyncCommitteeIndices: make(map[primitives.ValidatorIndex][]primitives.CommitteeIndex),
+		history:                     make(map[primitives.ValidatorIndex]map[primitives.Epoch]*monitortypes.ValidatorEpochSummary),
 		isLogging:                   false,
 	}
 	for _, idx := range tracked {
@@ -155,19 +161,26 @@ func (s *Service) run() {
 // and validatorAggregatedPerformance for each tracked validator.
 func (s *Service) initializePerformanceStructures(state state.BeaconState, epoch primitives.Epoch) {
 	for idx := range s.TrackedValidators {
-		balance, err := state.BalanceAtIndex(idx)
-		if err != nil {
-			log.WithError(err).WithField("validatorIndex", idx).Error(
-				"Could not fetch starting balance, skipping aggregated logs.")
-			balance = 0
-		}
-		s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
-			startEpoch:   epoch,
-			startBalance: balance,
-		}
-		s.latestPerformance[idx] = ValidatorLatestPerformance{
-			balance: balance,
-		}
+		s.initializePerformance(state, idx, epoch)
+	}
+}
+
+// initializePerformance initializes the validatorLatestPerformance and
+// validatorAggregatedPerformance of a tracked validator.
+// It assumes the caller holds the service Lock
+func (s *Service) initializePerformance(state state.BeaconState, idx primitives.ValidatorIndex, epoch primitives.Epoch) {
+	balance, err := state.BalanceAtIndex(idx)
+	if err != nil {
+		log.WithError(err).WithField("validatorIndex", idx).Error(
+			"Could not fetch starting balance, skipping aggregated logs.")
+		balance = 0
+	}
+	s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+		startEpoch:   epoch,
+		startBalance: balance,
+	}
+	s.latestPerformance[idx] = ValidatorLatestPerformance{
+		balance: balance,
 	}
 }
 
@@ -274,16 +287,22 @@ func (s *Service) updateSyncCommitteeTrackedVals(state state.BeaconState) {
 	s.Lock()
 	defer s.Unlock()
 	for idx := range s.TrackedValidators {
-		syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(state, idx)
-		if err != nil {
-			log.WithError(err).WithField("validatorIndex", idx).Error(
-				"Sync committee assignments will not be reported")
-			delete(s.trackedSyncCommitteeIndices, idx)
-		} else if len(syncIdx) == 0 {
-			delete(s.trackedSyncCommitteeIndices, idx)
-		} else {
-			s.trackedSyncCommitteeIndices[idx] = syncIdx
-		}
+		s.updateSyncCommitteeIndices(state, idx)
 	}
 	s.lastSyncedEpoch = slots.ToEpoch(state.Slot())
 }
+
+// updateSyncCommitteeIndices updates the sync committee assignments of a tracked validator.
+// It assumes the caller holds the service Lock
+func (s *Service) updateSyncCommitteeIndices(state state.BeaconState, idx primitives.ValidatorIndex) {
+	syncIdx, err := helpers.CurrentPeriodSyncSubcommitteeIndices(state, idx)
+	if err != nil {
+		log.WithError(err).WithField("validatorIndex", idx).Error(
+			"Sync committee assignments will not be reported")
+		delete(s.trackedSyncCommitteeIndices, idx)
+	} else if len(syncIdx) == 0 {
+		delete(s.trackedSyncCommitteeIndices, idx)
+	} else {
+		s.trackedSyncCommitteeIndices[idx] = syncIdx
+	}
+}
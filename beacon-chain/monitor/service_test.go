@@ -13,6 +13,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -94,6 +95,7 @@ func setupService(t *testing.T) *Service {
 			HeadFetcher:         chainService,
 			AttestationNotifier: chainService.OperationNotifier(),
 			InitialSyncComplete: make(chan struct{}),
+			BeaconDB:            beaconDB,
 		},
 
 		ctx:                         context.Background(),
@@ -101,6 +103,7 @@ func setupService(t *testing.T) *Service {
 		latestPerformance:           latestPerformance,
 		aggregatedPerformance:       aggregatedPerformance,
 		trackedSyncCommitteeIndices: trackedSyncCommitteeIndices,
+		history:                     make(map[primitives.ValidatorIndex]map[primitives.Epoch]*monitortypes.ValidatorEpochSummary),
 		lastSyncedEpoch:             0,
 	}
 }
@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// TrackedValidatorIndices returns the indices of the tracked validators in increasing order.
+func (s *Service) TrackedValidatorIndices() []primitives.ValidatorIndex {
+	s.RLock()
+	defer s.RUnlock()
+	tracked := make([]primitives.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		tracked = append(tracked, idx)
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	return tracked
+}
+
+// TrackValidators starts tracking the given validators, without restarting the service.
+// Their aggregated performance is reported from the current head epoch.
+func (s *Service) TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if st == nil || st.IsNil() {
+		return errors.New("head state is nil")
+	}
+	for _, idx := range indices {
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			return errors.Errorf("validator index %d does not exist", idx)
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	epoch := slots.ToEpoch(st.Slot())
+	for _, idx := range indices {
+		if s.trackedIndex(idx) {
+			continue
+		}
+		s.TrackedValidators[idx] = true
+		s.initializePerformance(st, idx, epoch)
+		s.updateSyncCommitteeIndices(st, idx)
+	}
+	log.WithFields(logrus.Fields{
+		"validatorIndices": indices,
+	}).Info("Started tracking validators")
+	return nil
+}
+
+// UntrackValidators stops tracking the given validators. Their pending epoch summaries are discarded.
+func (s *Service) UntrackValidators(indices []primitives.ValidatorIndex) {
+	s.Lock()
+	defer s.Unlock()
+	for _, idx := range indices {
+		delete(s.TrackedValidators, idx)
+		delete(s.latestPerformance, idx)
+		delete(s.aggregatedPerformance, idx)
+		delete(s.trackedSyncCommitteeIndices, idx)
+		delete(s.history, idx)
+	}
+	log.WithFields(logrus.Fields{
+		"validatorIndices": indices,
+	}).Info("Stopped tracking validators")
+}
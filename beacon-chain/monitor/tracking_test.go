@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestTrackValidators(t *testing.T) {
+	s := setupService(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.TrackValidators(ctx, []primitives.ValidatorIndex{0, 3, 12}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{0, 1, 2, 3, 12, 15}, s.TrackedValidatorIndices())
+	require.Equal(t, uint64(32000000000), s.latestPerformance[3].balance)
+	require.Equal(t, uint64(32000000000), s.aggregatedPerformance[3].startBalance)
+	// Validator 0 is the first member of the sync committee of the head state.
+	require.DeepEqual(t, []primitives.CommitteeIndex{0}, s.trackedSyncCommitteeIndices[0])
+	// Validators which are already tracked keep their performance.
+	require.Equal(t, uint64(31900000000), s.latestPerformance[12].balance)
+
+	require.ErrorContains(t, "does not exist", s.TrackValidators(ctx, []primitives.ValidatorIndex{256}))
+
+	s.UntrackValidators([]primitives.ValidatorIndex{1, 3})
+	require.DeepEqual(t, []primitives.ValidatorIndex{0, 2, 12, 15}, s.TrackedValidatorIndices())
+	_, ok := s.latestPerformance[1]
+	require.Equal(t, false, ok)
+	_, ok = s.trackedSyncCommitteeIndices[1]
+	require.Equal(t, false, ok)
+}
+
+func TestHistory(t *testing.T) {
+	s := setupService(t)
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, 256)
+	require.NoError(t, st.SetSlot(2))
+	require.NoError(t, st.SetCurrentParticipationBits(bytes.Repeat([]byte{0xff}, 13)))
+
+	s.updateHistory(st, 0)
+	require.NoError(t, st.UpdateBalancesAtIndex(2, 32000000100))
+	s.updateHistory(st, 0)
+
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			BeaconBlockRoot: bytesutil.PadTo([]byte("hello-world"), 32),
+			Source:          &ethpb.Checkpoint{Root: bytesutil.PadTo([]byte("hello-world"), 32)},
+			Target:          &ethpb.Checkpoint{Root: bytesutil.PadTo([]byte("hello-world"), 32)},
+		},
+		// Validators 12 and 2.
+		AggregationBits: bitfield.Bitlist{0b11, 0b1},
+	}
+	s.processIncludedAttestation(ctx, st, att)
+
+	// Epoch 0 can still be included in epoch 1.
+	s.flushHistory(ctx, 1)
+	got, err := s.config.BeaconDB.ValidatorMonitorHistory(ctx, 2, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(got))
+
+	s.flushHistory(ctx, 2)
+	got, err = s.config.BeaconDB.ValidatorMonitorHistory(ctx, 2, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.Equal(t, true, got[0].AttestationIncluded)
+	require.Equal(t, uint64(1), got[0].InclusionDistance)
+	require.Equal(t, true, got[0].TimelyHead)
+	require.Equal(t, int64(100), got[0].BalanceChange())
+
+	got, err = s.config.BeaconDB.ValidatorMonitorHistory(ctx, 15, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.Equal(t, false, got[0].AttestationIncluded)
+	require.Equal(t, 0, len(s.history[15]))
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ValidatorEpochSummary is the performance of a tracked validator in an epoch, as observed by the validator monitor.
+type ValidatorEpochSummary struct {
+	ValidatorIndex primitives.ValidatorIndex
+	Epoch          primitives.Epoch
+	// AttestationIncluded is false if no attestation of the validator for the epoch was included in a block.
+	AttestationIncluded bool
+	// InclusionDistance is the number of slots between the attestation and its first inclusion.
+	InclusionDistance uint64
+	TimelySource      bool
+	TimelyTarget      bool
+	TimelyHead        bool
+	ProposedBlocks    uint64
+	// SyncCommitteeExpected is the number of sync committee contributions expected from the validator
+	// in the blocks of the epoch, of which SyncCommitteeIncluded were included.
+	SyncCommitteeExpected uint64
+	SyncCommitteeIncluded uint64
+	// StartBalance is the balance of the validator at the end of the previous epoch, or when the validator
+	// started being tracked. EndBalance is its balance after the last block of the epoch.
+	StartBalance uint64
+	EndBalance   uint64
+}
+
+// BalanceChange is the change of the balance of the validator over the epoch, in Gwei.
+func (s *ValidatorEpochSummary) BalanceChange() int64 {
+	return int64(s.EndBalance) - int64(s.StartBalance) // lint:ignore uintcast -- Balances fit in an int64.
+}
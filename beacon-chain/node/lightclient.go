@@ -0,0 +1,189 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/light-client"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/prometheus"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/prysmaticlabs/prysm/v5/runtime/debug"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// LightClientNode runs the beacon node binary as a standalone light client. It only verifies the light client
+// data served by another beacon node, without a database, a state transition or a p2p network.
+type LightClientNode struct {
+	cliCtx   *cli.Context
+	ctx      context.Context
+	cancel   context.CancelFunc
+	services *runtime.ServiceRegistry
+	lock     sync.RWMutex
+	stop     chan struct{}
+}
+
+// NewLightClient creates a standalone light client node, configured by the light client flags. The genesis state
+// path is optional for the public networks.
+func NewLightClient(cliCtx *cli.Context, cancel context.CancelFunc, genesisStatePath string) (*LightClientNode, error) {
+	if err := configureBeacon(cliCtx); err != nil {
+		return nil, errors.Wrap(err, "could not set beacon configuration options")
+	}
+	params.BeaconConfig().InitializeForkSchedule()
+
+	if !cliCtx.IsSet(flags.LightClientTrustedBlockRoot.Name) {
+		return nil, fmt.Errorf("--%s is required in standalone light client mode", flags.LightClientTrustedBlockRoot.Name)
+	}
+	trustedBlockRoot, err := bytesutil.DecodeHexWithLength(cliCtx.String(flags.LightClientTrustedBlockRoot.Name), 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode --%s", flags.LightClientTrustedBlockRoot.Name)
+	}
+	if !cliCtx.IsSet(flags.LightClientBeaconAPI.Name) {
+		return nil, fmt.Errorf("--%s is required in standalone light client mode", flags.LightClientBeaconAPI.Name)
+	}
+	var clientOpts []client.ClientOpt
+	if cliCtx.IsSet(cmd.ApiTimeoutFlag.Name) {
+		clientOpts = append(clientOpts, client.WithTimeout(cliCtx.Duration(cmd.ApiTimeoutFlag.Name)))
+	}
+	genesisTime, genesisValidatorsRoot, err := lightClientGenesis(genesisStatePath)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := lightclient.NewRESTProvider(cliCtx.String(flags.LightClientBeaconAPI.Name), clientOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create light client data provider")
+	}
+
+	n := &LightClientNode{
+		cliCtx:   cliCtx,
+		ctx:      cliCtx.Context,
+		cancel:   cancel,
+		services: runtime.NewServiceRegistry(),
+		stop:     make(chan struct{}),
+	}
+
+	log.Debugln("Registering Light Client Service")
+	svc, err := lightclient.NewService(
+		n.ctx,
+		lightclient.WithProvider(provider),
+		lightclient.WithTrustedBlockRoot(bytesutil.ToBytes32(trustedBlockRoot)),
+		lightclient.WithGenesis(genesisTime, genesisValidatorsRoot),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create light client service")
+	}
+	if err := n.services.RegisterService(svc); err != nil {
+		return nil, errors.Wrap(err, "could not register light client service")
+	}
+
+	log.Debugln("Registering HTTP Service")
+	router := http.NewServeMux()
+	svc.RegisterRoutes(router)
+	httpService, err := newHTTPService(n.ctx, cliCtx, router)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create HTTP service")
+	}
+	if err := n.services.RegisterService(httpService); err != nil {
+		return nil, errors.Wrap(err, "could not register HTTP service")
+	}
+
+	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
+		log.Debugln("Registering Prometheus Service")
+		service := prometheus.NewService(
+			fmt.Sprintf("%s:%d", cliCtx.String(cmd.MonitoringHostFlag.Name), cliCtx.Int(flags.MonitoringPortFlag.Name)),
+			n.services,
+		)
+		logrus.AddHook(prometheus.NewLogrusCollector())
+		if err := n.services.RegisterService(service); err != nil {
+			return nil, errors.Wrap(err, "could not register prometheus service")
+		}
+	}
+	return n, nil
+}
+
+// lightClientGenesis returns the genesis time and genesis validators root of the network, from the genesis state
+// file or, for the public networks, from the network config.
+func lightClientGenesis(path string) (time.Time, [32]byte, error) {
+	if path != "" {
+		b, err := file.ReadFileAsBytes(path)
+		if err != nil {
+			return time.Time{}, [32]byte{}, errors.Wrapf(err, "could not read genesis state %s", path)
+		}
+		cf, err := detect.FromState(b)
+		if err != nil {
+			return time.Time{}, [32]byte{}, errors.Wrap(err, "could not detect genesis state version")
+		}
+		st, err := cf.UnmarshalBeaconState(b)
+		if err != nil {
+			return time.Time{}, [32]byte{}, errors.Wrap(err, "could not unmarshal genesis state")
+		}
+		return time.Unix(int64(st.GenesisTime()), 0), bytesutil.ToBytes32(st.GenesisValidatorsRoot()), nil
+	}
+	cfg := params.BeaconConfig()
+	switch cfg.ConfigName {
+	case params.MainnetName, params.SepoliaName, params.HoleskyName:
+		return time.Unix(int64(cfg.GenesisTime), 0), cfg.GenesisValidatorsRoot, nil
+	default:
+		return time.Time{}, [32]byte{}, fmt.Errorf("a genesis state is required in standalone light client mode on the %s network", cfg.ConfigName)
+	}
+}
+
+// Start the light client node and wait until it is stopped.
+func (n *LightClientNode) Start() {
+	n.lock.Lock()
+
+	log.WithFields(logrus.Fields{
+		"version": version.Version(),
+	}).Info("Starting standalone light client")
+
+	n.services.StartAll()
+
+	stop := n.stop
+	n.lock.Unlock()
+
+	go func() {
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigc)
+		<-sigc
+		log.Info("Got interrupt, shutting down...")
+		debug.Exit(n.cliCtx) // Ensure trace and CPU profile data are flushed.
+		go n.Close()
+		for i := 10; i > 0; i-- {
+			<-sigc
+			if i > 1 {
+				log.WithField("times", i-1).Info("Already shutting down, interrupt more to panic")
+			}
+		}
+		panic("Panic closing the light client")
+	}()
+
+	// Wait for stop channel to be closed.
+	<-stop
+}
+
+// Close handles graceful shutdown of the light client node.
+func (n *LightClientNode) Close() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	log.Info("Stopping standalone light client")
+	n.services.StopAll()
+	n.cancel()
+	close(n.stop)
+}
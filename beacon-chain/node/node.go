@@ -43,7 +43,11 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc"
+	monitorprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/monitor"
+	nodeprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -114,6 +118,7 @@ type BeaconNode struct {
 	GenesisInitializer      genesis.Initializer
 	CheckpointInitializer   checkpoint.Initializer
 	forkChoicer             forkchoice.ForkChoicer
+	forkChoiceJournal       *doublylinkedtree.Journal
 	clockWaiter             startup.ClockWaiter
 	BackfillOpts            []backfill.ServiceOption
 	initialSyncComplete     chan struct{}
@@ -167,7 +172,11 @@ func New(cliCtx *cli.Context, cancel context.CancelFunc, opts ...Option) (*Beaco
 
 	synchronizer := startup.NewClockSynchronizer()
 	beacon.clockWaiter = synchronizer
-	beacon.forkChoicer = doublylinkedtree.New()
+	forkChoicer := doublylinkedtree.New()
+	if err := beacon.startForkChoiceJournal(cliCtx, forkChoicer); err != nil {
+		return nil, err
+	}
+	beacon.forkChoicer = forkChoicer
 
 	depositAddress, err := execution.DepositContractAddress()
 	if err != nil {
@@ -360,6 +369,11 @@ func registerServices(cliCtx *cli.Context, beacon *BeaconNode, synchronizer *sta
 		return errors.Wrap(err, "could not register builder service")
 	}
 
+	log.Debugln("Registering Validator Monitoring Service")
+	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
+		return errors.Wrap(err, "could not register validator monitoring service")
+	}
+
 	log.Debugln("Registering RPC Service")
 	router := http.NewServeMux()
 	if err := beacon.registerRPCService(router); err != nil {
@@ -371,9 +385,9 @@ func registerServices(cliCtx *cli.Context, beacon *BeaconNode, synchronizer *sta
 		return errors.Wrap(err, "could not register HTTP service")
 	}
 
-	log.Debugln("Registering Validator Monitoring Service")
-	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
-		return errors.Wrap(err, "could not register validator monitoring service")
+	log.Debugln("Registering Rewards Indexer Service")
+	if err := beacon.registerRewardsIndexerService(beacon.initialSyncComplete); err != nil {
+		return errors.Wrap(err, "could not register rewards indexer service")
 	}
 
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
@@ -453,6 +467,11 @@ func (b *BeaconNode) Close() {
 
 	log.Info("Stopping beacon node")
 	b.services.StopAll()
+	if b.forkChoiceJournal != nil {
+		if err := b.forkChoiceJournal.Close(); err != nil {
+			log.WithError(err).Error("Failed to close fork choice journal")
+		}
+	}
 	if err := b.db.Close(); err != nil {
 		log.WithError(err).Error("Failed to close database")
 	}
@@ -665,6 +684,27 @@ func (b *BeaconNode) startStateGen(ctx context.Context, bfs coverage.AvailableBl
 	return nil
 }
 
+// startForkChoiceJournal journals the calls to the fork choice store when a journal directory is set.
+func (b *BeaconNode) startForkChoiceJournal(cliCtx *cli.Context, fc *doublylinkedtree.ForkChoice) error {
+	dir := cliCtx.String(flags.ForkChoiceJournalDir.Name)
+	if dir == "" {
+		return nil
+	}
+	j, err := doublylinkedtree.NewJournal(dir,
+		doublylinkedtree.WithJournalMaxFileSize(cliCtx.Uint64(flags.ForkChoiceJournalMaxFileSize.Name)<<20),
+		doublylinkedtree.WithJournalMaxFiles(cliCtx.Int(flags.ForkChoiceJournalMaxFiles.Name)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create fork choice journal")
+	}
+	if err := fc.SetJournal(j); err != nil {
+		return errors.Wrap(err, "could not start fork choice journal")
+	}
+	b.forkChoiceJournal = j
+	log.WithField("dir", dir).Info("Journaling fork choice")
+	return nil
+}
+
 func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	bootstrapNodeAddrs, dataDir, err := registration.P2PPreregistration(cliCtx)
 	if err != nil {
@@ -752,6 +792,7 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithSlashingPool(b.slashingsPool),
 		blockchain.WithBLSToExecPool(b.blsToExecPool),
 		blockchain.WithP2PBroadcaster(b.fetchP2P()),
+		blockchain.WithNodeID(b.fetchP2P().NodeID()),
 		blockchain.WithStateNotifier(b),
 		blockchain.WithAttestationService(attService),
 		blockchain.WithStateGen(b.stateGen),
@@ -847,6 +888,8 @@ func (b *BeaconNode) registerSyncService(initialSyncComplete chan struct{}, bFil
 		regularsync.WithVerifierWaiter(b.verifyInitWaiter),
 		regularsync.WithAvailableBlocker(bFillStore),
 	)
+	chainService.SetDataColumnSampler(rs)
+	chainService.SetDataColumnRequester(rs)
 	return b.services.RegisterService(rs)
 }
 
@@ -921,10 +964,19 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 	}
 
 	var slasherService *slasher.Service
+	var slasherQuerier slasherprysm.Querier
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
 			return err
 		}
+		slasherQuerier = slasherService.Querier()
+	}
+
+	// The validator monitor is optional, its endpoints report that it is disabled when it is not registered.
+	var validatorMonitor monitorprysm.ValidatorTracker
+	var monitorService *monitor.Service
+	if err := b.services.FetchService(&monitorService); err == nil {
+		validatorMonitor = monitorService
 	}
 
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -951,6 +1003,10 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 	mockEth1DataVotes := b.cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	enableDebugRPCEndpoints := !b.cliCtx.Bool(flags.DisableDebugRPCEndpoints.Name)
+	healthThresholds, err := nodeprysm.ParseHealthThresholds(b.cliCtx.StringSlice(flags.HealthThresholds.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not parse health thresholds")
+	}
 
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
@@ -975,6 +1031,7 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		FinalizationFetcher:       chainService,
 		BlockReceiver:             chainService,
 		BlobReceiver:              chainService,
+		DataColumnReceiver:        chainService,
 		AttestationReceiver:       chainService,
 		GenesisTimeFetcher:        chainService,
 		GenesisFetcher:            chainService,
@@ -1003,6 +1060,12 @@ func (b *BeaconNode) registerRPCService(router *http.ServeMux) error {
 		BlobStorage:               b.BlobStorage,
 		TrackedValidatorsCache:    b.trackedValidatorsCache,
 		PayloadIDCache:            b.payloadIDCache,
+		EventReplayBufferSize:     b.cliCtx.Int(flags.EventReplayBufferSize.Name),
+		ValidatorMonitor:          validatorMonitor,
+		Slasher:                   slasherQuerier,
+		ServiceStatusFetcher:      b.services,
+		BeaconDBPath:              b.db.DatabasePath(),
+		HealthThresholds:          healthThresholds,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -1032,12 +1095,21 @@ func (b *BeaconNode) registerPrometheusService(_ *cli.Context) error {
 }
 
 func (b *BeaconNode) registerHTTPService(router *http.ServeMux) error {
-	host := b.cliCtx.String(flags.HTTPServerHost.Name)
-	port := b.cliCtx.Int(flags.HTTPServerPort.Name)
+	g, err := newHTTPService(b.ctx, b.cliCtx, router)
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(g)
+}
+
+// newHTTPService creates the HTTP server of the Beacon API, configured by the HTTP server flags.
+func newHTTPService(ctx context.Context, cliCtx *cli.Context, router *http.ServeMux) (*httprest.Server, error) {
+	host := cliCtx.String(flags.HTTPServerHost.Name)
+	port := cliCtx.Int(flags.HTTPServerPort.Name)
 	address := net.JoinHostPort(host, strconv.Itoa(port))
 	var allowedOrigins []string
-	if b.cliCtx.IsSet(flags.HTTPServerCorsDomain.Name) {
-		allowedOrigins = strings.Split(b.cliCtx.String(flags.HTTPServerCorsDomain.Name), ",")
+	if cliCtx.IsSet(flags.HTTPServerCorsDomain.Name) {
+		allowedOrigins = strings.Split(cliCtx.String(flags.HTTPServerCorsDomain.Name), ",")
 	} else {
 		allowedOrigins = strings.Split(flags.HTTPServerCorsDomain.Value, ",")
 	}
@@ -1052,14 +1124,10 @@ func (b *BeaconNode) registerHTTPService(router *http.ServeMux) error {
 		httprest.WithHTTPAddr(address),
 		httprest.WithMiddlewares(middlewares),
 	}
-	if b.cliCtx.IsSet(cmd.ApiTimeoutFlag.Name) {
-		opts = append(opts, httprest.WithTimeout(b.cliCtx.Duration(cmd.ApiTimeoutFlag.Name)))
-	}
-	g, err := httprest.New(b.ctx, opts...)
-	if err != nil {
-		return err
+	if cliCtx.IsSet(cmd.ApiTimeoutFlag.Name) {
+		opts = append(opts, httprest.WithTimeout(cliCtx.Duration(cmd.ApiTimeoutFlag.Name)))
 	}
-	return b.services.RegisterService(g)
+	return httprest.New(ctx, opts...)
 }
 
 func (b *BeaconNode) registerDeterministicGenesisService() error {
@@ -1090,7 +1158,7 @@ func (b *BeaconNode) registerDeterministicGenesisService() error {
 
 func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan struct{}) error {
 	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
-	if cliSlice == nil {
+	if cliSlice == nil && !b.cliCtx.Bool(cmd.EnableValidatorMonitorFlag.Name) {
 		return nil
 	}
 	tracked := make([]primitives.ValidatorIndex, len(cliSlice))
@@ -1108,6 +1176,7 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 		StateGen:            b.stateGen,
 		HeadFetcher:         chainService,
 		InitialSyncComplete: initialSyncComplete,
+		BeaconDB:            b.db,
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
@@ -1116,6 +1185,26 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 	return b.services.RegisterService(svc)
 }
 
+func (b *BeaconNode) registerRewardsIndexerService(initialSyncComplete chan struct{}) error {
+	if !b.cliCtx.Bool(flags.EnableRewardsIndexer.Name) {
+		return nil
+	}
+
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := rewards.NewService(b.ctx, &rewards.Config{
+		BeaconDB:            b.db,
+		ReplayerBuilder:     stategen.NewCanonicalHistory(b.db, chainService, chainService),
+		StateNotifier:       b,
+		InitialSyncComplete: initialSyncComplete,
+		StartEpoch:          primitives.Epoch(b.cliCtx.Uint64(flags.RewardsIndexerStartEpoch.Name)),
+		RetentionEpochs:     primitives.Epoch(b.cliCtx.Uint64(flags.RewardsIndexerRetentionEpochs.Name)),
+	})
+	return b.services.RegisterService(svc)
+}
+
 func (b *BeaconNode) registerBuilderService(cliCtx *cli.Context) error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -0,0 +1,49 @@
+package p2p
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/v5/crypto/ecdsa"
+)
+
+// NodeID returns the discovery node ID of the local beacon node, from which
+// the data columns it custodies are derived.
+func (s *Service) NodeID() enode.ID {
+	if s.privKey == nil {
+		return enode.ID{}
+	}
+	return enode.PubkeyToIDV4(&s.privKey.PublicKey)
+}
+
+// CustodyCountFromRemotePeer returns the number of data column subnets the remote
+// peer advertises in its ENR. Peers with no known record, or with an invalid entry,
+// are assumed to custody the minimum required number of subnets.
+func (s *Service) CustodyCountFromRemotePeer(pid peer.ID) uint64 {
+	custodyRequirement := params.BeaconConfig().CustodyRequirement
+	record, err := s.peers.ENR(pid)
+	if err != nil || record == nil {
+		return custodyRequirement
+	}
+	count, err := custodySubnetCount(record)
+	if err != nil {
+		log.WithError(err).WithField("peerID", pid).Debug("Could not read custody subnet count from peer ENR")
+		return custodyRequirement
+	}
+	return count
+}
+
+// ConvertPeerIDToNodeID converts a libp2p peer ID into the discovery node ID of the
+// same secp256k1 key.
+func ConvertPeerIDToNodeID(pid peer.ID) (enode.ID, error) {
+	pubKey, err := pid.ExtractPublicKey()
+	if err != nil {
+		return enode.ID{}, errors.Wrap(err, "could not extract public key from peer ID")
+	}
+	ecdsaKey, err := ecdsaprysm.ConvertFromInterfacePubKey(pubKey)
+	if err != nil {
+		return enode.ID{}, errors.Wrap(err, "could not convert public key")
+	}
+	return enode.PubkeyToIDV4(ecdsaKey), nil
+}
@@ -377,6 +377,7 @@ func (s *Service) createLocalNode(
 
 	localNode = initializeAttSubnets(localNode)
 	localNode = initializeSyncCommSubnets(localNode)
+	localNode = initializeCustodySubnetCount(localNode)
 
 	if s.cfg != nil && s.cfg.HostAddress != "" {
 		hostIP := net.ParseIP(s.cfg.HostAddress)
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
@@ -29,6 +30,7 @@ var syncCommsSubnetCount = params.BeaconConfig().SyncCommitteeSubnetCount
 
 var attSubnetEnrKey = params.BeaconNetworkConfig().AttSubnetKey
 var syncCommsSubnetEnrKey = params.BeaconNetworkConfig().SyncCommsSubnetKey
+var custodySubnetCountEnrKey = params.BeaconNetworkConfig().CustodySubnetCountKey
 
 // The value used with the subnet, in order
 // to create an appropriate key to retrieve
@@ -44,6 +46,13 @@ const syncLockerVal = 100
 // chosen more than sync and attestation subnet combined.
 const blobSubnetLockerVal = 110
 
+// The value used with the data column sidecar subnet, in order
+// to create an appropriate key to retrieve
+// the relevant lock. This is used to differentiate
+// data column subnets from others. This is deliberately
+// chosen more than the blob subnets lock values.
+const dataColumnSubnetLockerVal = 128
+
 // FindPeersWithSubnet performs a network search for peers
 // subscribed to a particular subnet. Then it tries to connect
 // with those peers. This method will block until either:
@@ -72,6 +81,8 @@ func (s *Service) FindPeersWithSubnet(ctx context.Context, topic string,
 		iterator = filterNodes(ctx, iterator, s.filterPeerForAttSubnet(index))
 	case strings.Contains(topic, GossipSyncCommitteeMessage):
 		iterator = filterNodes(ctx, iterator, s.filterPeerForSyncSubnet(index))
+	case strings.Contains(topic, GossipDataColumnSidecarMessage):
+		iterator = filterNodes(ctx, iterator, s.filterPeerForDataColumnsSubnet(index))
 	default:
 		return false, errors.New("no subnet exists for provided topic")
 	}
@@ -153,6 +164,24 @@ func (s *Service) filterPeerForSyncSubnet(index uint64) func(node *enode.Node) b
 	}
 }
 
+// returns a method with filters peers specifically for a particular data column subnet.
+func (s *Service) filterPeerForDataColumnsSubnet(index uint64) func(node *enode.Node) bool {
+	return func(node *enode.Node) bool {
+		if !s.filterPeer(node) {
+			return false
+		}
+		count, err := custodySubnetCount(node.Record())
+		if err != nil {
+			return false
+		}
+		subnets, err := peerdas.CustodyColumnSubnets(node.ID(), count)
+		if err != nil {
+			return false
+		}
+		return subnets[index]
+	}
+}
+
 // lower threshold to broadcast object compared to searching
 // for a subnet. So that even in the event of poor peer
 // connectivity, we can still broadcast an attestation.
@@ -288,6 +317,30 @@ func initializeSyncCommSubnets(node *enode.LocalNode) *enode.LocalNode {
 	return node
 }
 
+// Initializes the custody subnet count entry of the node's ENR, advertising
+// the number of data column subnets the beacon node custodies.
+func initializeCustodySubnetCount(node *enode.LocalNode) *enode.LocalNode {
+	entry := enr.WithEntry(custodySubnetCountEnrKey, peerdas.CustodySubnetCount())
+	node.Set(entry)
+	return node
+}
+
+// Reads the custody subnet count entry from a node's ENR. Nodes which do not
+// advertise the entry are expected to custody the minimum required number of subnets.
+func custodySubnetCount(record *enr.Record) (uint64, error) {
+	var count uint64
+	if err := record.Load(enr.WithEntry(custodySubnetCountEnrKey, &count)); err != nil {
+		if enr.IsNotFound(err) {
+			return params.BeaconConfig().CustodyRequirement, nil
+		}
+		return 0, err
+	}
+	if count > params.BeaconConfig().DataColumnSidecarSubnetCount {
+		return 0, errors.Wrapf(peerdas.ErrCustodySubnetCountTooLarge, "custody subnet count %d", count)
+	}
+	return count, nil
+}
+
 // Reads the attestation subnets entry from a node's ENR and determines
 // the committee indices of the attestation subnets the node is subscribed to.
 func attSubnets(record *enr.Record) (map[uint64]bool, error) {
@@ -3,6 +3,7 @@ package testing
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/control"
@@ -12,6 +13,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/metadata"
 	"google.golang.org/protobuf/proto"
@@ -50,6 +52,16 @@ func (_ *FakeP2P) PeerID() peer.ID {
 	return "fake"
 }
 
+// NodeID -- fake.
+func (_ *FakeP2P) NodeID() enode.ID {
+	return enode.ID{}
+}
+
+// CustodyCountFromRemotePeer -- fake.
+func (_ *FakeP2P) CustodyCountFromRemotePeer(peer.ID) uint64 {
+	return params.BeaconConfig().CustodyRequirement
+}
+
 // ENR returns the enr of the local peer.
 func (_ *FakeP2P) ENR() *enr.Record {
 	return new(enr.Record)
@@ -148,6 +160,11 @@ func (_ *FakeP2P) BroadcastBlob(_ context.Context, _ uint64, _ *ethpb.BlobSideca
 	return nil
 }
 
+// BroadcastDataColumn -- fake.
+func (_ *FakeP2P) BroadcastDataColumn(_ context.Context, _ uint64, _ *ethpb.DataColumnSidecar) error {
+	return nil
+}
+
 // InterceptPeerDial -- fake.
 func (_ *FakeP2P) InterceptPeerDial(peer.ID) (allow bool) {
 	return true
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 )
 
 // MockPeerManager is mock of the PeerManager interface.
@@ -17,6 +19,7 @@ type MockPeerManager struct {
 	BHost             host.Host
 	DiscoveryAddr     []multiaddr.Multiaddr
 	FailDiscoveryAddr bool
+	Enode             enode.ID
 }
 
 // Disconnect .
@@ -29,6 +32,11 @@ func (m *MockPeerManager) PeerID() peer.ID {
 	return m.PID
 }
 
+// NodeID .
+func (m *MockPeerManager) NodeID() enode.ID {
+	return m.Enode
+}
+
 // Host .
 func (m *MockPeerManager) Host() host.Host {
 	return m.BHost
@@ -57,3 +65,8 @@ func (_ MockPeerManager) FindPeersWithSubnet(_ context.Context, _ string, _ uint
 
 // AddPingMethod .
 func (_ MockPeerManager) AddPingMethod(_ func(ctx context.Context, id peer.ID) error) {}
+
+// CustodyCountFromRemotePeer .
+func (_ MockPeerManager) CustodyCountFromRemotePeer(peer.ID) uint64 {
+	return params.BeaconConfig().CustodyRequirement
+}
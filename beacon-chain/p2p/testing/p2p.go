@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -25,6 +26,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/v5/crypto/ecdsa"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/metadata"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
@@ -190,6 +193,12 @@ func (p *TestP2P) BroadcastBlob(context.Context, uint64, *ethpb.BlobSidecar) err
 	return nil
 }
 
+// BroadcastDataColumn broadcasts a data column sidecar for mock.
+func (p *TestP2P) BroadcastDataColumn(context.Context, uint64, *ethpb.DataColumnSidecar) error {
+	p.BroadcastCalled.Store(true)
+	return nil
+}
+
 // SetStreamHandler for RPC.
 func (p *TestP2P) SetStreamHandler(topic string, handler network.StreamHandler) {
 	p.BHost.SetStreamHandler(protocol.ID(topic), handler)
@@ -259,6 +268,21 @@ func (p *TestP2P) PeerID() peer.ID {
 	return p.BHost.ID()
 }
 
+// NodeID returns the discovery node ID of the local peer, derived from
+// the host's key when it is a secp256k1 key.
+func (p *TestP2P) NodeID() enode.ID {
+	pubKey, err := ecdsaprysm.ConvertFromInterfacePubKey(p.BHost.Peerstore().PubKey(p.BHost.ID()))
+	if err != nil {
+		return enode.ID{}
+	}
+	return enode.PubkeyToIDV4(pubKey)
+}
+
+// CustodyCountFromRemotePeer mocks the p2p func.
+func (_ *TestP2P) CustodyCountFromRemotePeer(peer.ID) uint64 {
+	return params.BeaconConfig().CustodyRequirement
+}
+
 // Host returns the libp2p host of the
 // local peer.
 func (p *TestP2P) Host() host.Host {
@@ -80,6 +80,29 @@ func TestBlobSidecarsByRootReq_MarshalSSZ(t *testing.T) {
 	}
 }
 
+func TestDataColumnSidecarsByRootReq_MarshalSSZ(t *testing.T) {
+	ids := make([]*eth.DataColumnIdentifier, 10)
+	for i := range ids {
+		ids[i] = &eth.DataColumnIdentifier{
+			BlockRoot: bytesutil.PadTo([]byte{byte(i)}, 32),
+			Index:     uint64(i),
+		}
+	}
+	r := DataColumnSidecarsByRootReq(ids)
+	by, err := r.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, r.SizeSSZ(), len(by))
+
+	got := &DataColumnSidecarsByRootReq{}
+	require.NoError(t, got.UnmarshalSSZ(by))
+	require.Equal(t, len(ids), len(*got))
+	for i, gid := range *got {
+		require.DeepEqual(t, ids[i], gid)
+	}
+
+	require.ErrorIs(t, got.UnmarshalSSZ(append(by, 0)), ssz.ErrIncorrectByteSize)
+}
+
 func TestBeaconBlockByRootsReq_Limit(t *testing.T) {
 	fixedRoots := make([][32]byte, 0)
 	for i := uint64(0); i < params.BeaconConfig().MaxRequestBlocks+100; i++ {
@@ -112,6 +135,8 @@ func TestErrorResponse_Limit(t *testing.T) {
 func TestRoundTripSerialization(t *testing.T) {
 	roundTripTestBlocksByRootReq(t)
 	roundTripTestErrorMessage(t)
+	roundTripTestLightClientBootstrapReq(t)
+	roundTripTestLightClientUpdatesByRangeReq(t)
 }
 
 func roundTripTestBlocksByRootReq(t *testing.T) {
@@ -142,6 +167,32 @@ func roundTripTestErrorMessage(t *testing.T) {
 	assert.DeepEqual(t, []byte(newVal), errMsg)
 }
 
+func roundTripTestLightClientBootstrapReq(t *testing.T) {
+	req := LightClientBootstrapReq{'r', 'o', 'o', 't'}
+
+	marshalledObj, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, 32, len(marshalledObj))
+	newVal := LightClientBootstrapReq{}
+
+	require.NoError(t, newVal.UnmarshalSSZ(marshalledObj))
+	assert.Equal(t, req, newVal)
+	require.ErrorIs(t, newVal.UnmarshalSSZ(marshalledObj[1:]), ssz.ErrSize)
+}
+
+func roundTripTestLightClientUpdatesByRangeReq(t *testing.T) {
+	req := LightClientUpdatesByRangeReq{StartPeriod: 300, Count: 8}
+
+	marshalledObj, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{44, 1, 0, 0, 0, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0}, marshalledObj)
+	newVal := LightClientUpdatesByRangeReq{}
+
+	require.NoError(t, newVal.UnmarshalSSZ(marshalledObj))
+	assert.Equal(t, req, newVal)
+	require.ErrorIs(t, newVal.UnmarshalSSZ(marshalledObj[1:]), ssz.ErrSize)
+}
+
 func TestSSZBytes_HashTreeRoot(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,6 @@
+/*
+Package rewards defines an optional runtime service which indexes the rewards and
+penalties of every validator for each finalized epoch into the database, so that
+they can be queried over ranges of epochs without replaying states.
+*/
+package rewards
@@ -0,0 +1,317 @@
+package rewards
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	coreblocks "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	rewardstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// indexer computes the rewards of validators by applying the finalized blocks to a state, one epoch at a time.
+// The rewards of an epoch are only known once its attestations can no longer be included, so the
+// rewards of epoch n are saved after the blocks of epoch n+1 have been applied.
+type indexer struct {
+	db         db.NoHeadAccessDatabase
+	replayer   stategen.ReplayerBuilder
+	startEpoch primitives.Epoch
+	cursor     *cursor
+}
+
+// cursor is the progress of the indexer, kept between runs so that the state does not need to be replayed
+// every time an epoch is finalized.
+type cursor struct {
+	// st is the state at the last slot of the epoch before epoch, before processing the epoch.
+	st state.BeaconState
+	// epoch is the next epoch whose blocks will be applied.
+	epoch primitives.Epoch
+	// rewards are the block rewards of the epoch before epoch, or nil if they are incomplete
+	// because the indexer started at epoch.
+	rewards map[primitives.ValidatorIndex]*rewardstypes.ValidatorEpochRewards
+}
+
+// indexUntil indexes the epochs whose rewards are final given the finalized epoch, which are the epochs
+// before finalized-1 as the blocks of the following epoch are needed.
+func (ix *indexer) indexUntil(ctx context.Context, finalized primitives.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "rewards.indexUntil")
+	defer span.End()
+
+	if ix.cursor == nil {
+		c, err := ix.initialCursor(ctx)
+		if err != nil {
+			return err
+		}
+		ix.cursor = c
+	}
+	for ix.cursor.epoch < finalized {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		start := time.Now()
+		c, err := ix.advance(ctx, ix.cursor)
+		if err != nil {
+			epoch := ix.cursor.epoch
+			// Start over from the database on the next run, the cursor state may have been partially processed.
+			ix.cursor = nil
+			return errors.Wrapf(err, "could not apply blocks of epoch %d", epoch)
+		}
+		if ix.cursor.rewards != nil {
+			indexEpochDuration.Observe(float64(time.Since(start).Milliseconds()))
+			log.WithField("epoch", ix.cursor.epoch-1).Debug("Indexed validator rewards")
+		}
+		ix.cursor = c
+	}
+	return nil
+}
+
+// initialCursor returns the cursor for the epoch after the last indexed epoch.
+func (ix *indexer) initialCursor(ctx context.Context) (*cursor, error) {
+	start := ix.startEpoch
+	if altairEpoch := params.BeaconConfig().AltairForkEpoch; start < altairEpoch {
+		start = altairEpoch
+	}
+	last, err := ix.db.LastIndexedRewardsEpoch(ctx)
+	switch {
+	case err == nil:
+		if last+1 > start {
+			start = last + 1
+		}
+	case errors.Is(err, db.ErrNotFound):
+	default:
+		return nil, errors.Wrap(err, "could not get last indexed rewards epoch")
+	}
+	originRoot, err := ix.db.OriginCheckpointBlockRoot(ctx)
+	switch {
+	case err == nil:
+		origin, err := ix.db.Block(ctx, originRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get origin checkpoint block")
+		}
+		if originEpoch := slots.ToEpoch(origin.Block().Slot()); start <= originEpoch {
+			start = originEpoch + 1
+		}
+	case errors.Is(err, db.ErrNotFound):
+	default:
+		return nil, errors.Wrap(err, "could not get origin checkpoint block root")
+	}
+
+	var slot primitives.Slot
+	if start > 0 {
+		slot, err = slots.EpochEnd(start - 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	st, err := ix.replayer.ReplayerForSlot(slot).ReplayBlocks(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not replay state at slot %d", slot)
+	}
+	return &cursor{st: st, epoch: start}, nil
+}
+
+// advance applies the blocks of the cursor's epoch and saves the rewards of the epoch before it.
+func (ix *indexer) advance(ctx context.Context, c *cursor) (*cursor, error) {
+	startSlot, err := slots.EpochStart(c.epoch)
+	if err != nil {
+		return nil, err
+	}
+	endSlot, err := slots.EpochEnd(c.epoch)
+	if err != nil {
+		return nil, err
+	}
+	blks, err := ix.finalizedBlocks(ctx, startSlot, endSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	st := c.st
+	rewards := make(map[primitives.ValidatorIndex]*rewardstypes.ValidatorEpochRewards)
+	for _, blk := range blks {
+		if blk.Block().Slot() <= st.Slot() {
+			continue
+		}
+		st, err = stategen.ReplayProcessSlots(ctx, st, blk.Block().Slot())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", blk.Block().Slot())
+		}
+		if err := blockRewards(ctx, st, blk.Block(), c.epoch, rewards); err != nil {
+			return nil, errors.Wrapf(err, "could not compute rewards of block at slot %d", blk.Block().Slot())
+		}
+		_, st, err = transition.ProcessBlockNoVerifyAnySig(ctx, st, blk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process block at slot %d", blk.Block().Slot())
+		}
+	}
+	if st.Slot() < endSlot {
+		st, err = stategen.ReplayProcessSlots(ctx, st, endSlot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", endSlot)
+		}
+	}
+
+	if c.rewards != nil {
+		epoch := c.epoch - 1
+		if err := attestationRewards(ctx, st, epoch, c.rewards); err != nil {
+			return nil, errors.Wrap(err, "could not compute attestation rewards")
+		}
+		records := make([]*rewardstypes.ValidatorEpochRewards, 0, len(c.rewards))
+		for _, r := range c.rewards {
+			if !r.IsZero() {
+				records = append(records, r)
+			}
+		}
+		if err := ix.db.SaveValidatorRewards(ctx, epoch, records); err != nil {
+			return nil, errors.Wrap(err, "could not save validator rewards")
+		}
+		lastIndexedEpoch.Set(float64(epoch))
+	}
+	return &cursor{st: st, epoch: c.epoch + 1, rewards: rewards}, nil
+}
+
+// finalizedBlocks returns the finalized blocks between the given slots, in increasing slot order.
+func (ix *indexer) finalizedBlocks(ctx context.Context, start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blks, roots, err := ix.db.Blocks(ctx, filters.NewFilter().SetStartSlot(start).SetEndSlot(end))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get blocks between slots %d and %d", start, end)
+	}
+	finalized := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, blk := range blks {
+		if ix.db.IsFinalizedBlock(ctx, roots[i]) {
+			finalized = append(finalized, blk)
+		}
+	}
+	sort.Slice(finalized, func(i, j int) bool {
+		return finalized[i].Block().Slot() < finalized[j].Block().Slot()
+	})
+	return finalized, nil
+}
+
+// blockRewards adds the sync committee rewards and penalties of the block, as well as the rewards of
+// its proposer for including attestations, slashings and sync committee messages.
+// The state is the pre-state of the block, after processing its slot.
+func blockRewards(
+	ctx context.Context,
+	st state.BeaconState,
+	blk interfaces.ReadOnlyBeaconBlock,
+	epoch primitives.Epoch,
+	rewards map[primitives.ValidatorIndex]*rewardstypes.ValidatorEpochRewards,
+) error {
+	if st.Version() < version.Altair {
+		return nil
+	}
+	rewardsOf := func(idx primitives.ValidatorIndex) *rewardstypes.ValidatorEpochRewards {
+		r, ok := rewards[idx]
+		if !ok {
+			r = &rewardstypes.ValidatorEpochRewards{ValidatorIndex: idx, Epoch: epoch}
+			rewards[idx] = r
+		}
+		return r
+	}
+	proposer := blk.ProposerIndex()
+
+	// The proposer rewards for operations are obtained from the balance of the proposer before and
+	// after processing them on a copy of the state.
+	before, err := st.BalanceAtIndex(proposer)
+	if err != nil {
+		return err
+	}
+	opsSt, err := altair.ProcessAttestationsNoVerifySignature(ctx, st.Copy(), blk)
+	if err != nil {
+		return err
+	}
+	opsSt, err = coreblocks.ProcessAttesterSlashings(ctx, opsSt, blk.Body().AttesterSlashings(), validators.SlashValidator)
+	if err != nil {
+		return err
+	}
+	opsSt, err = coreblocks.ProcessProposerSlashings(ctx, opsSt, blk.Body().ProposerSlashings(), validators.SlashValidator)
+	if err != nil {
+		return err
+	}
+	after, err := opsSt.BalanceAtIndex(proposer)
+	if err != nil {
+		return err
+	}
+	rewardsOf(proposer).Proposer += int64(after) - int64(before) // lint:ignore uintcast -- Balances fit in an int64.
+
+	sa, err := blk.Body().SyncAggregate()
+	if err != nil {
+		return err
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return err
+	}
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return err
+	}
+	proposerReward, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < sa.SyncCommitteeBits.Len() && i < uint64(len(committee.Pubkeys)); i++ {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(committee.Pubkeys[i]))
+		if !ok {
+			return errors.New("sync committee member is not in the state")
+		}
+		if sa.SyncCommitteeBits.BitAt(i) {
+			rewardsOf(idx).SyncCommittee += int64(participantReward) // lint:ignore uintcast -- Rewards fit in an int64.
+			rewardsOf(proposer).Proposer += int64(proposerReward)    // lint:ignore uintcast -- Rewards fit in an int64.
+		} else {
+			rewardsOf(idx).SyncCommittee -= int64(participantReward) // lint:ignore uintcast -- Rewards fit in an int64.
+		}
+	}
+	return nil
+}
+
+// attestationRewards adds the attestation rewards and penalties of the given epoch, computed the same way
+// as by the attestation rewards API from the state at the last slot of the following epoch.
+func attestationRewards(
+	ctx context.Context,
+	st state.BeaconState,
+	epoch primitives.Epoch,
+	rewards map[primitives.ValidatorIndex]*rewardstypes.ValidatorEpochRewards,
+) error {
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize precompute validators")
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return errors.Wrap(err, "could not process epoch participation")
+	}
+	deltas, err := altair.AttestationsDelta(st, bal, vals)
+	if err != nil {
+		return errors.Wrap(err, "could not get attestations delta")
+	}
+	for i, d := range deltas {
+		idx := primitives.ValidatorIndex(i)
+		r, ok := rewards[idx]
+		if !ok {
+			r = &rewardstypes.ValidatorEpochRewards{ValidatorIndex: idx, Epoch: epoch}
+			rewards[idx] = r
+		}
+		r.Source += int64(d.SourceReward) - int64(d.SourcePenalty) // lint:ignore uintcast -- Rewards fit in an int64.
+		r.Target += int64(d.TargetReward) - int64(d.TargetPenalty) // lint:ignore uintcast -- Rewards fit in an int64.
+		r.Head += int64(d.HeadReward)                              // lint:ignore uintcast -- Rewards fit in an int64.
+		r.Inactivity -= int64(d.InactivityPenalty)                 // lint:ignore uintcast -- Penalties fit in an int64.
+	}
+	return nil
+}
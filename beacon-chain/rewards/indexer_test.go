@@ -0,0 +1,114 @@
+package rewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type genesisReplayer struct {
+	st state.BeaconState
+}
+
+func (r *genesisReplayer) ReplayerForSlot(primitives.Slot) stategen.Replayer {
+	return r
+}
+
+func (r *genesisReplayer) ReplayBlocks(context.Context) (state.BeaconState, error) {
+	return r.st.Copy(), nil
+}
+
+func (r *genesisReplayer) ReplayToSlot(context.Context, primitives.Slot) (state.BeaconState, error) {
+	return r.st.Copy(), nil
+}
+
+func TestIndexer_IndexUntil(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	committee, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(committee))
+	require.NoError(t, genesis.SetNextSyncCommittee(committee))
+	genesisBlk := util.NewBeaconBlockAltair()
+	stRoot, err := genesis.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesisBlk.Block.StateRoot = stRoot[:]
+	util.SaveBlock(t, ctx, beaconDB, genesisBlk)
+	genesisRoot, err := genesisBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	// Build a chain with a block in every slot of the first three epochs.
+	st := genesis.Copy()
+	var root [32]byte
+	end := 3 * params.BeaconConfig().SlotsPerEpoch
+	for slot := primitives.Slot(1); slot <= end; slot++ {
+		b, err := util.GenerateFullBlockAltair(st, keys, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		root, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: end, Root: root[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: root[:]}))
+
+	ix := &indexer{db: beaconDB, replayer: &genesisReplayer{st: genesis}}
+	require.NoError(t, ix.indexUntil(ctx, 3))
+	last, err := beaconDB.LastIndexedRewardsEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(1), last)
+
+	indices := make([]primitives.ValidatorIndex, 64)
+	for i := range indices {
+		indices[i] = primitives.ValidatorIndex(i)
+	}
+	rewards, err := beaconDB.ValidatorRewards(ctx, indices, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 64, len(rewards))
+
+	syncMembers := make(map[primitives.ValidatorIndex]bool)
+	for _, pk := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		require.Equal(t, true, ok)
+		syncMembers[idx] = true
+	}
+	var proposerRewards int64
+	for _, r := range rewards {
+		require.Equal(t, primitives.Epoch(1), r.Epoch)
+		// Every validator attested in a block of the next slot.
+		require.Equal(t, true, r.Source > 0 && r.Target > 0 && r.Head > 0)
+		require.Equal(t, int64(0), r.Inactivity)
+		// The generated blocks do not have any sync committee participation.
+		if syncMembers[r.ValidatorIndex] {
+			require.Equal(t, true, r.SyncCommittee < 0)
+		}
+		proposerRewards += r.Proposer
+	}
+	require.Equal(t, true, proposerRewards > 0)
+
+	// Indexing resumes from the cursor without indexing the epochs again.
+	require.NoError(t, ix.indexUntil(ctx, 3))
+	require.Equal(t, primitives.Epoch(3), ix.cursor.epoch)
+}
@@ -0,0 +1,20 @@
+package rewards
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	log = logrus.WithField("prefix", "rewards")
+
+	lastIndexedEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rewards_indexer_last_indexed_epoch",
+		Help: "The last epoch whose validator rewards were indexed.",
+	})
+	indexEpochDuration = promauto.NewSummary(prometheus.SummaryOpts{
+		Name: "rewards_indexer_epoch_milliseconds",
+		Help: "Time it takes to index the validator rewards of an epoch.",
+	})
+)
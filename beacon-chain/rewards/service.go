@@ -0,0 +1,149 @@
+package rewards
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+var errContextClosedWhileWaiting = errors.New("context closed while waiting for initial sync to complete")
+
+// Config for the rewards indexer service.
+type Config struct {
+	BeaconDB            db.NoHeadAccessDatabase
+	ReplayerBuilder     stategen.ReplayerBuilder
+	StateNotifier       statefeed.Notifier
+	InitialSyncComplete chan struct{}
+	// StartEpoch is the first epoch to index. Epochs before the Altair fork or before
+	// the checkpoint sync origin are never indexed.
+	StartEpoch primitives.Epoch
+	// RetentionEpochs is the number of indexed epochs kept in the database, older epochs are pruned.
+	// All indexed epochs are kept when it is zero.
+	RetentionEpochs primitives.Epoch
+}
+
+// Service indexes the rewards of validators for every finalized epoch.
+type Service struct {
+	cfg     *Config
+	ctx     context.Context
+	cancel  context.CancelFunc
+	indexer *indexer
+	running atomic.Bool
+	// prunedBefore is the epoch before which the rewards were last pruned.
+	prunedBefore primitives.Epoch
+}
+
+// NewService creates a new rewards indexer service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:     cfg,
+		ctx:     ctx,
+		cancel:  cancel,
+		indexer: &indexer{db: cfg.BeaconDB, replayer: cfg.ReplayerBuilder, startEpoch: cfg.StartEpoch},
+	}
+}
+
+// Start the rewards indexer.
+func (s *Service) Start() {
+	log.WithFields(logrus.Fields{
+		"startEpoch":      s.cfg.StartEpoch,
+		"retentionEpochs": s.cfg.RetentionEpochs,
+	}).Info("Starting service")
+	go s.run()
+}
+
+// Stop the rewards indexer.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.running.Store(false)
+	return nil
+}
+
+// Status of the rewards indexer.
+func (s *Service) Status() error {
+	if s.running.Load() {
+		return nil
+	}
+	return errors.New("not running")
+}
+
+// run waits for the initial sync to complete, indexes the epochs finalized so far and then
+// indexes newly finalized epochs as they are finalized.
+func (s *Service) run() {
+	select {
+	case <-s.cfg.InitialSyncComplete:
+	case <-s.ctx.Done():
+		log.WithError(errContextClosedWhileWaiting).Debug("Context closed, exiting goroutine")
+		return
+	}
+	s.running.Store(true)
+
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	s.index()
+	for {
+		select {
+		case e := <-stateChannel:
+			if e.Type == statefeed.FinalizedCheckpoint {
+				s.index()
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		}
+	}
+}
+
+func (s *Service) index() {
+	cp, err := s.cfg.BeaconDB.FinalizedCheckpoint(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get finalized checkpoint")
+		return
+	}
+	if err := s.indexer.indexUntil(s.ctx, primitives.Epoch(cp.Epoch)); err != nil {
+		log.WithError(err).Error("Could not index validator rewards")
+	}
+	if err := s.prune(); err != nil {
+		log.WithError(err).Error("Could not prune validator rewards")
+	}
+}
+
+// prune deletes the rewards of the epochs older than the retention period before the last indexed epoch.
+func (s *Service) prune() error {
+	if s.cfg.RetentionEpochs == 0 {
+		return nil
+	}
+	last, err := s.cfg.BeaconDB.LastIndexedRewardsEpoch(s.ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if last < s.cfg.RetentionEpochs {
+		return nil
+	}
+	before := last - s.cfg.RetentionEpochs + 1
+	if before <= s.prunedBefore {
+		return nil
+	}
+	if err := s.cfg.BeaconDB.PruneValidatorRewards(s.ctx, before); err != nil {
+		return err
+	}
+	s.prunedBefore = before
+	log.WithField("beforeEpoch", before).Debug("Pruned validator rewards")
+	return nil
+}
@@ -0,0 +1,36 @@
+package rewards
+
+import (
+	"context"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	rewardstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	d := testDB.SetupDB(t)
+	s := NewService(ctx, &Config{BeaconDB: d, RetentionEpochs: 3})
+
+	// Nothing is pruned before an epoch was indexed.
+	require.NoError(t, s.prune())
+
+	for epoch := primitives.Epoch(1); epoch <= 6; epoch++ {
+		rewards := []*rewardstypes.ValidatorEpochRewards{{ValidatorIndex: 1, Epoch: epoch, Source: 1}}
+		require.NoError(t, d.SaveValidatorRewards(ctx, epoch, rewards))
+	}
+	require.NoError(t, s.prune())
+	require.Equal(t, primitives.Epoch(4), s.prunedBefore)
+	got, err := d.ValidatorRewards(ctx, []primitives.ValidatorIndex{1}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(got))
+	require.Equal(t, primitives.Epoch(4), got[0].Epoch)
+
+	// Without a retention period every indexed epoch is kept.
+	s = NewService(ctx, &Config{BeaconDB: d})
+	require.NoError(t, s.prune())
+	require.Equal(t, primitives.Epoch(0), s.prunedBefore)
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ValidatorEpochRewards is the breakdown of the rewards and penalties of a validator for an epoch, in Gwei.
+// Penalties are negative. Attestation inclusion delay rewards only existed before Altair, from which
+// the indexer starts, and the rewards of proposers for including attestations are part of Proposer.
+type ValidatorEpochRewards struct {
+	ValidatorIndex primitives.ValidatorIndex
+	Epoch          primitives.Epoch
+	Source         int64
+	Target         int64
+	Head           int64
+	Inactivity     int64
+	SyncCommittee  int64
+	Proposer       int64
+}
+
+// Total of the rewards and penalties.
+func (r *ValidatorEpochRewards) Total() int64 {
+	return r.Source + r.Target + r.Head + r.Inactivity + r.SyncCommittee + r.Proposer
+}
+
+// IsZero reports whether the validator had no rewards or penalties in the epoch,
+// as is the case for validators which are not active.
+func (r *ValidatorEpochRewards) IsZero() bool {
+	return r.Source == 0 && r.Target == 0 && r.Head == 0 && r.Inactivity == 0 && r.SyncCommittee == 0 && r.Proposer == 0
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+)
+
+// ClientVersions identifies the beacon node and its execution client in the format of engine_getClientVersionV1.
+// The execution client version is nil if the execution client could not be identified.
+func ClientVersions(ctx context.Context, engine execution.EngineCaller) (beaconNode, executionClient *enginev1.ClientVersionV1) {
+	beaconNode = execution.PrysmClientVersion()
+	if engine == nil {
+		return beaconNode, nil
+	}
+	versions, err := engine.GetClientVersion(ctx)
+	if err != nil {
+		log.WithError(err).Debug("Could not get execution client version")
+		return beaconNode, nil
+	}
+	if len(versions) == 0 {
+		return beaconNode, nil
+	}
+	return beaconNode, versions[0]
+}
@@ -20,7 +20,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/validator"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	beaconprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/beacon"
+	monitorprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/monitor"
 	nodeprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/slasher"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
 	validatorprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/validator"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
@@ -70,6 +72,8 @@ func (s *Service) endpoints(
 	endpoints = append(endpoints, s.prysmBeaconEndpoints(ch, stater, coreService)...)
 	endpoints = append(endpoints, s.prysmNodeEndpoints()...)
 	endpoints = append(endpoints, s.prysmValidatorEndpoints(stater, coreService)...)
+	endpoints = append(endpoints, s.prysmMonitorEndpoints()...)
+	endpoints = append(endpoints, s.prysmSlasherEndpoints()...)
 	if enableDebug {
 		endpoints = append(endpoints, s.debugEndpoints(stater)...)
 	}
@@ -386,6 +390,7 @@ func (s *Service) nodeEndpoints() []endpoint {
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		ExecutionEngineCaller:     s.cfg.ExecutionEngineCaller,
 	}
 
 	const namespace = "node"
@@ -444,6 +449,15 @@ func (s *Service) nodeEndpoints() []endpoint {
 			handler: server.GetVersion,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/eth/v2/node/version",
+			name:     namespace + ".GetVersionV2",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetVersionV2,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/eth/v1/node/health",
 			name:     namespace + ".GetHealth",
@@ -519,6 +533,15 @@ func (s *Service) beaconEndpoints(
 			handler: server.GetStateRoot,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/eth/v1/beacon/states/{state_id}/proof",
+			name:     namespace + ".GetStateProof",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetStateProof,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/eth/v1/beacon/states/{state_id}/sync_committees",
 			name:     namespace + ".GetSyncCommittees",
@@ -966,6 +989,10 @@ func (s *Service) eventsEndpoints() []endpoint {
 		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
 		TrackedValidatorsCache: s.cfg.TrackedValidatorsCache,
 	}
+	if s.cfg.EventReplayBufferSize > 0 {
+		server.EventHistory = events.NewEventHistory(s.cfg.EventReplayBufferSize)
+		go server.EventHistory.Record(s.ctx, s.cfg.StateNotifier, s.cfg.OperationNotifier)
+	}
 
 	const namespace = "events"
 	return []endpoint{
@@ -1074,10 +1101,23 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 		MetadataProvider:          s.cfg.MetadataProvider,
 		HeadFetcher:               s.cfg.HeadFetcher,
 		ExecutionChainInfoFetcher: s.cfg.ExecutionChainInfoFetcher,
+		ServiceStatusFetcher:      s.cfg.ServiceStatusFetcher,
+		BlobStorage:               s.cfg.BlobStorage,
+		DatabasePath:              s.cfg.BeaconDBPath,
+		HealthThresholds:          s.cfg.HealthThresholds,
 	}
 
 	const namespace = "prysm.node"
 	return []endpoint{
+		{
+			template: "/prysm/v1/node/health/detailed",
+			name:     namespace + ".GetDetailedHealth",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetDetailedHealth,
+			methods: []string{http.MethodGet},
+		},
 		{
 			template: "/prysm/node/trusted_peers",
 			name:     namespace + ".ListTrustedPeer",
@@ -1139,6 +1179,7 @@ func (s *Service) prysmNodeEndpoints() []endpoint {
 
 func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *core.Service) []endpoint {
 	server := &validatorprysm.Server{
+		BeaconDB:         s.cfg.BeaconDB,
 		ChainInfoFetcher: s.cfg.ChainInfoFetcher,
 		Stater:           stater,
 		CoreService:      coreService,
@@ -1184,5 +1225,101 @@ func (s *Service) prysmValidatorEndpoints(stater lookup.Stater, coreService *cor
 			handler: server.GetActiveSetChanges,
 			methods: []string{http.MethodGet},
 		},
+		{
+			template: "/prysm/v1/validators/rewards",
+			name:     namespace + ".GetRewards",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetRewards,
+			methods: []string{http.MethodGet},
+		},
+	}
+}
+
+func (s *Service) prysmMonitorEndpoints() []endpoint {
+	server := &monitorprysm.Server{
+		BeaconDB:           s.cfg.BeaconDB,
+		ValidatorMonitor:   s.cfg.ValidatorMonitor,
+		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
+	}
+
+	const namespace = "prysm.monitor"
+	return []endpoint{
+		{
+			template: "/prysm/v1/monitor/validators",
+			name:     namespace + ".GetTrackedValidators",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetTrackedValidators,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/monitor/validators",
+			name:     namespace + ".TrackValidators",
+			middleware: []middleware.Middleware{
+				middleware.ContentTypeHandler([]string{api.JsonMediaType}),
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.TrackValidators,
+			methods: []string{http.MethodPost},
+		},
+		{
+			template: "/prysm/v1/monitor/validators/{index}",
+			name:     namespace + ".UntrackValidator",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.UntrackValidator,
+			methods: []string{http.MethodDelete},
+		},
+		{
+			template: "/prysm/v1/monitor/validators/{index}/history",
+			name:     namespace + ".GetValidatorHistory",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetValidatorHistory,
+			methods: []string{http.MethodGet},
+		},
+	}
+}
+
+func (s *Service) prysmSlasherEndpoints() []endpoint {
+	server := &slasherprysm.Server{
+		Slasher:            s.cfg.Slasher,
+		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
+	}
+
+	const namespace = "prysm.slasher"
+	return []endpoint{
+		{
+			template: "/prysm/v1/slasher/validators/{index}/spans",
+			name:     namespace + ".GetValidatorSpans",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetValidatorSpans,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/slasher/validators/{index}/proposals",
+			name:     namespace + ".GetValidatorProposals",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetValidatorProposals,
+			methods: []string{http.MethodGet},
+		},
+		{
+			template: "/prysm/v1/slasher/slashings",
+			name:     namespace + ".GetDetectedSlashings",
+			middleware: []middleware.Middleware{
+				middleware.AcceptHeaderHandler([]string{api.JsonMediaType}),
+			},
+			handler: server.GetDetectedSlashings,
+			methods: []string{http.MethodGet},
+		},
 	}
 }
@@ -27,6 +27,7 @@ func Test_endpoints(t *testing.T) {
 		"/eth/v1/beacon/states/{state_id}/committees":                {http.MethodGet},
 		"/eth/v1/beacon/states/{state_id}/sync_committees":           {http.MethodGet},
 		"/eth/v1/beacon/states/{state_id}/randao":                    {http.MethodGet},
+		"/eth/v1/beacon/states/{state_id}/proof":                     {http.MethodGet},
 		"/eth/v1/beacon/headers":                                     {http.MethodGet},
 		"/eth/v1/beacon/headers/{block_id}":                          {http.MethodGet},
 		"/eth/v1/beacon/blinded_blocks":                              {http.MethodPost},
@@ -88,6 +89,7 @@ func Test_endpoints(t *testing.T) {
 		"/eth/v1/node/peers/{peer_id}": {http.MethodGet},
 		"/eth/v1/node/peer_count":      {http.MethodGet},
 		"/eth/v1/node/version":         {http.MethodGet},
+		"/eth/v2/node/version":         {http.MethodGet},
 		"/eth/v1/node/syncing":         {http.MethodGet},
 		"/eth/v1/node/health":          {http.MethodGet},
 	}
@@ -124,6 +126,7 @@ func Test_endpoints(t *testing.T) {
 
 	prysmNodeRoutes := map[string][]string{
 		"/prysm/node/trusted_peers":              {http.MethodGet, http.MethodPost},
+		"/prysm/v1/node/health/detailed":         {http.MethodGet},
 		"/prysm/v1/node/trusted_peers":           {http.MethodGet, http.MethodPost},
 		"/prysm/node/trusted_peers/{peer_id}":    {http.MethodDelete},
 		"/prysm/v1/node/trusted_peers/{peer_id}": {http.MethodDelete},
@@ -134,6 +137,19 @@ func Test_endpoints(t *testing.T) {
 		"/prysm/v1/validators/performance":        {http.MethodPost},
 		"/prysm/v1/validators/participation":      {http.MethodGet},
 		"/prysm/v1/validators/active_set_changes": {http.MethodGet},
+		"/prysm/v1/validators/rewards":            {http.MethodGet},
+	}
+
+	prysmMonitorRoutes := map[string][]string{
+		"/prysm/v1/monitor/validators":                 {http.MethodGet, http.MethodPost},
+		"/prysm/v1/monitor/validators/{index}":         {http.MethodDelete},
+		"/prysm/v1/monitor/validators/{index}/history": {http.MethodGet},
+	}
+
+	prysmSlasherRoutes := map[string][]string{
+		"/prysm/v1/slasher/validators/{index}/spans":     {http.MethodGet},
+		"/prysm/v1/slasher/validators/{index}/proposals": {http.MethodGet},
+		"/prysm/v1/slasher/slashings":                    {http.MethodGet},
 	}
 
 	s := &Service{cfg: &Config{}}
@@ -147,7 +163,7 @@ func Test_endpoints(t *testing.T) {
 			actualRoutes[e.template] = e.methods
 		}
 	}
-	expectedRoutes := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, rewardsRoutes, lightClientRoutes, blobRoutes, prysmValidatorRoutes, prysmNodeRoutes, prysmBeaconRoutes)
+	expectedRoutes := combineMaps(beaconRoutes, builderRoutes, configRoutes, debugRoutes, eventsRoutes, nodeRoutes, validatorRoutes, rewardsRoutes, lightClientRoutes, blobRoutes, prysmValidatorRoutes, prysmNodeRoutes, prysmBeaconRoutes, prysmMonitorRoutes, prysmSlasherRoutes)
 
 	assert.Equal(t, true, maps.EqualFunc(expectedRoutes, actualRoutes, func(actualMethods []string, expectedMethods []string) bool {
 		return slices.Equal(expectedMethods, actualMethods)
@@ -17,12 +17,17 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/multiproof"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
+// maxStateProofIndices is the maximum number of nodes which can be proven in a single state proof request.
+const maxStateProofIndices = 256
+
 type syncCommitteeStateRequest struct {
 	epoch   *primitives.Epoch
 	stateId []byte
@@ -144,6 +149,107 @@ func (s *Server) GetRandao(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
+// GetStateProof returns a Merkle multiproof of the nodes of the state identified by state_id. The nodes are
+// selected by generalized index with the gindex query parameter, or by JSON path with the path query parameter,
+// e.g. "validators[5].effective_balance". The leaves are returned in the order of the requested gindices, followed
+// by those of the requested paths, and the proof nodes are ordered by decreasing generalized index as defined in
+// the consensus specs' get_helper_indices.
+func (s *Server) GetStateProof(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetStateProof")
+	defer span.End()
+
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	rawGindices := r.URL.Query()["gindex"]
+	paths := r.URL.Query()["path"]
+	if len(rawGindices)+len(paths) == 0 {
+		httputil.HandleError(w, "At least one gindex or path is required", http.StatusBadRequest)
+		return
+	}
+	if len(rawGindices)+len(paths) > maxStateProofIndices {
+		httputil.HandleError(w, fmt.Sprintf("Cannot prove more than %d nodes at once", maxStateProofIndices), http.StatusBadRequest)
+		return
+	}
+	gindices := make([]uint64, 0, len(rawGindices)+len(paths))
+	for _, raw := range rawGindices {
+		gindex, ok := shared.ValidateUint(w, "gindex", raw)
+		if !ok {
+			return
+		}
+		if gindex == 0 {
+			httputil.HandleError(w, "gindex 0 is invalid", http.StatusBadRequest)
+			return
+		}
+		gindices = append(gindices, gindex)
+	}
+
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	for _, path := range paths {
+		gindex, err := st.GeneralizedIndex(path)
+		if err != nil {
+			httputil.HandleError(w, fmt.Sprintf("Invalid path %s: %v", path, err), http.StatusBadRequest)
+			return
+		}
+		gindices = append(gindices, gindex)
+	}
+	proof, err := st.Multiproof(ctx, gindices)
+	if err != nil {
+		if errors.Is(err, multiproof.ErrNoChildren) {
+			httputil.HandleError(w, "Could not prove the requested nodes: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		httputil.HandleError(w, "Could not compute proof: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not compute state root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isFinalized := s.FinalizationFetcher.IsFinalized(ctx, blockRoot)
+
+	data := &structs.StateProof{
+		StateRoot: hexutil.Encode(stateRoot[:]),
+		Gindices:  make([]string, len(proof.Indices)),
+		Leaves:    make([]string, len(proof.Leaves)),
+		Proof:     make([]string, len(proof.Proof)),
+	}
+	for i, gindex := range proof.Indices {
+		data.Gindices[i] = strconv.FormatUint(gindex, 10)
+	}
+	for i, leaf := range proof.Leaves {
+		data.Leaves[i] = hexutil.Encode(leaf[:])
+	}
+	for i, node := range proof.Proof {
+		data.Proof[i] = hexutil.Encode(node[:])
+	}
+	resp := &structs.GetStateProofResponse{
+		Version:             version.String(st.Version()),
+		Data:                data,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+	}
+	httputil.WriteJson(w, resp)
+}
+
 // GetSyncCommittees retrieves the sync committees for the given epoch.
 // If the epoch is not passed in, then the sync committees for the epoch of the state will be obtained.
 func (s *Server) GetSyncCommittees(w http.ResponseWriter, r *http.Request) {
@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/multiproof"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -122,6 +123,93 @@ func TestGetStateRoot(t *testing.T) {
 	})
 }
 
+func TestGetStateProof(t *testing.T) {
+	ctx := context.Background()
+	fakeState, _ := util.DeterministicGenesisStateAltair(t, 32)
+	stateRoot, err := fakeState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater: &testutil.MockStater{
+			BeaconStateRoot: stateRoot[:],
+			BeaconState:     fakeState,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+
+	t.Run("gindex and path", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/proof?gindex=105&path=validators[7].effective_balance", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateProof(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetStateProofResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "altair", resp.Version)
+		assert.Equal(t, hexutil.Encode(stateRoot[:]), resp.Data.StateRoot)
+		require.Equal(t, 2, len(resp.Data.Gindices))
+		assert.Equal(t, "105", resp.Data.Gindices[0])
+		finalizedRoot := fakeState.FinalizedCheckpoint().Root
+		assert.Equal(t, hexutil.Encode(finalizedRoot), resp.Data.Leaves[0])
+
+		p := &multiproof.Multiproof{}
+		for i, raw := range resp.Data.Gindices {
+			gindex, err := strconv.ParseUint(raw, 10, 64)
+			require.NoError(t, err)
+			p.Indices = append(p.Indices, gindex)
+			leaf, err := hexutil.Decode(resp.Data.Leaves[i])
+			require.NoError(t, err)
+			p.Leaves = append(p.Leaves, [32]byte(leaf))
+		}
+		for _, raw := range resp.Data.Proof {
+			node, err := hexutil.Decode(raw)
+			require.NoError(t, err)
+			p.Proof = append(p.Proof, [32]byte(node))
+		}
+		valid, err := p.Verify(stateRoot)
+		require.NoError(t, err)
+		assert.Equal(t, true, valid)
+	})
+	t.Run("no gindex or path", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/proof", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateProof(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "At least one gindex or path is required", e.Message)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/proof?path=validators.foo", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateProof(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid path validators.foo", e.Message)
+	})
+	t.Run("gindex below a leaf", func(t *testing.T) {
+		// The children of the slot leaf do not exist.
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/proof?gindex=68", nil)
+		request.SetPathValue("state_id", "head")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.GetStateProof(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
 func TestGetRandao(t *testing.T) {
 	mixCurrent := bytesutil.ToBytes32([]byte("current"))
 	mixOld := bytesutil.ToBytes32([]byte("old"))
@@ -151,6 +151,10 @@ func TestGetSpec(t *testing.T) {
 	config.UnsetDepositRequestsStartIndex = 92
 	config.MaxDepositRequestsPerPayload = 93
 	config.MaxPendingDepositsPerEpoch = 94
+	config.Eip7594ForkEpoch = 95
+	config.CustodyRequirement = 96
+	config.SamplesPerSlot = 97
+	config.MinEpochsForDataColumnSidecarsRequest = 98
 
 	var dbp [4]byte
 	copy(dbp[:], []byte{'0', '0', '0', '1'})
@@ -189,7 +193,7 @@ func TestGetSpec(t *testing.T) {
 	data, ok := resp.Data.(map[string]interface{})
 	require.Equal(t, true, ok)
 
-	assert.Equal(t, 155, len(data))
+	assert.Equal(t, 159, len(data))
 	for k, v := range data {
 		t.Run(k, func(t *testing.T) {
 			switch k {
@@ -526,6 +530,14 @@ func TestGetSpec(t *testing.T) {
 				assert.Equal(t, "93", v)
 			case "MAX_PENDING_DEPOSITS_PER_EPOCH":
 				assert.Equal(t, "94", v)
+			case "EIP7594_FORK_EPOCH":
+				assert.Equal(t, "95", v)
+			case "CUSTODY_REQUIREMENT":
+				assert.Equal(t, "96", v)
+			case "SAMPLES_PER_SLOT":
+				assert.Equal(t, "97", v)
+			case "MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS":
+				assert.Equal(t, "98", v)
 			default:
 				t.Errorf("Incorrect key: %s", k)
 			}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -62,6 +63,8 @@ const (
 	LightClientFinalityUpdateTopic = "light_client_finality_update"
 	// LightClientOptimisticUpdateTopic represents a new light client optimistic update event topic.
 	LightClientOptimisticUpdateTopic = "light_client_optimistic_update"
+	// EventGapTopic represents the event sent to a resuming client when events it missed can't be replayed.
+	EventGapTopic = "event_gap"
 )
 
 var (
@@ -110,14 +113,31 @@ func topicsForFeed(em map[feed.EventType]string) map[string]bool {
 
 type topicRequest struct {
 	topics        map[string]bool
+	filter        *eventFilter
 	needStateFeed bool
 	needOpsFeed   bool
+	// lastEventID is the id of the last event the client saw before reconnecting, if replayFromID is set.
+	lastEventID  uint64
+	replayFromID bool
 }
 
 func (req *topicRequest) requested(topic string) bool {
 	return req.topics[topic]
 }
 
+// forReplay returns a copy of the request used to serialize replayed events. Payload attributes are left out
+// because they describe the current head rather than the head at the time of the replayed event.
+func (req *topicRequest) forReplay() *topicRequest {
+	cp := *req
+	cp.topics = make(map[string]bool, len(req.topics))
+	for topic := range req.topics {
+		if replayable(topic) {
+			cp.topics[topic] = true
+		}
+	}
+	return &cp
+}
+
 func newTopicRequest(topics []string) (*topicRequest, error) {
 	req := &topicRequest{topics: make(map[string]bool)}
 	for _, name := range topics {
@@ -151,6 +171,19 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	topics.filter, err = newEventFilter(r.URL.Query())
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if topics.filter != nil {
+		topics.filter.attestingIndices = s.attestingIndices
+	}
+	topics.lastEventID, topics.replayFromID, err = lastEventIDFromRequest(r)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	timeout := s.EventWriteTimeout
 	if timeout == 0 {
@@ -206,11 +239,26 @@ func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.Cance
 		stateSub := s.StateNotifier.StateFeed().Subscribe(eventsChan)
 		defer stateSub.Unsubscribe()
 	}
+	// Replay happens after subscribing to the feeds so that no events are missed in between. Events which arrive
+	// on the feeds while replaying may also be in the history, so we skip live events that were already replayed.
+	replayed, err := es.replay(ctx, req, s)
+	if err != nil {
+		return err
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case event := <-eventsChan:
+			// Filtering happens before the event is serialized or queued, so that events the client
+			// isn't interested in don't take up space in the outbox.
+			if !req.filter.matches(ctx, event) {
+				continue
+			}
+			id := s.EventHistory.record(event)
+			if id != 0 && id <= replayed {
+				continue
+			}
 			lr, err := s.lazyReaderForEvent(ctx, event, req)
 			if err != nil {
 				if !errors.Is(err, errNotRequested) {
@@ -218,6 +266,7 @@ func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.Cance
 				}
 				continue
 			}
+			lr = withEventID(id, lr)
 			// If the client can't keep up, the outbox will eventually completely fill, at which
 			// safeWrite will error, and we'll hit the below return statement, at which point the deferred
 			// Unsuscribe calls will be made and the event feed will stop writing to this channel.
@@ -237,6 +286,40 @@ func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.Cance
 	}
 }
 
+// replay queues the events from the server's history that the client missed before reconnecting,
+// returning the id of the last replayed event. When some of the missed events are no longer retained,
+// an event gap event is queued first so that the client knows its view of the stream is incomplete.
+func (es *eventStreamer) replay(ctx context.Context, req *topicRequest, s *Server) (uint64, error) {
+	if !req.replayFromID {
+		return 0, nil
+	}
+	var last uint64
+	replayReq := req.forReplay()
+	missed, gap := s.EventHistory.since(req.lastEventID, replayReq)
+	if gap {
+		gapEvent := &structs.EventGapEvent{LastEventID: strconv.FormatUint(req.lastEventID, 10)}
+		if err := es.safeWrite(ctx, func() io.Reader { return jsonMarshalReader(EventGapTopic, gapEvent) }); err != nil {
+			return 0, err
+		}
+	}
+	for _, e := range missed {
+		last = e.id
+		if !req.filter.matches(ctx, e.event) {
+			continue
+		}
+		lr, err := s.lazyReaderForEvent(ctx, e.event, replayReq)
+		if err != nil {
+			log.WithField("event_id", e.id).WithError(err).Debug("Could not replay event.")
+			continue
+		}
+		if err := es.safeWrite(ctx, withEventID(e.id, lr)); err != nil {
+			return 0, err
+		}
+	}
+	log.WithField("replayed_events", len(missed)).Debug("Replayed missed events to reconnecting client.")
+	return last, nil
+}
+
 func (es *eventStreamer) safeWrite(ctx context.Context, rf func() io.Reader) error {
 	if rf == nil {
 		return nil
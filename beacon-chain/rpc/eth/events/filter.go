@@ -0,0 +1,417 @@
+package events
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Query parameter names accepted by StreamEvents to filter events server-side.
+const (
+	ValidatorIndicesFilter = "validator_indices"
+	CommitteeIndexFilter   = "committee_index"
+	ProposerIndexFilter    = "proposer_index"
+	BlockRootFilter        = "block_root"
+	BlobIndexFilter        = "blob_index"
+)
+
+var errInvalidFilter = errors.New("invalid event filter")
+
+// eventFilter restricts the events sent to a client based on the values of fields in the event payload.
+// Each filter is only applied to events which carry the corresponding field; events of topics without that
+// field are not affected by it. Multiple values for the same filter match if any of them match,
+// and all filters which apply to an event must match for the event to be sent.
+type eventFilter struct {
+	validatorIndices map[primitives.ValidatorIndex]bool
+	committeeIndices map[primitives.CommitteeIndex]bool
+	proposerIndices  map[primitives.ValidatorIndex]bool
+	blockRoots       map[[fieldparams.RootLength]byte]bool
+	blobIndices      map[uint64]bool
+	// attestingIndices resolves the validators of an unaggregated attestation from its committee.
+	attestingIndices func(context.Context, eth.Att) ([]uint64, error)
+}
+
+// newEventFilter parses the filter query parameters. A nil filter is returned if no filters were given.
+// Values may be given either by repeating the parameter or as a comma separated list.
+func newEventFilter(q url.Values) (*eventFilter, error) {
+	f := &eventFilter{}
+	empty := true
+	vals, err := uintsFromQuery(q, ValidatorIndicesFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) > 0 {
+		empty = false
+		f.validatorIndices = make(map[primitives.ValidatorIndex]bool, len(vals))
+		for _, v := range vals {
+			f.validatorIndices[primitives.ValidatorIndex(v)] = true
+		}
+	}
+	vals, err = uintsFromQuery(q, CommitteeIndexFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) > 0 {
+		empty = false
+		f.committeeIndices = make(map[primitives.CommitteeIndex]bool, len(vals))
+		for _, v := range vals {
+			f.committeeIndices[primitives.CommitteeIndex(v)] = true
+		}
+	}
+	vals, err = uintsFromQuery(q, ProposerIndexFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) > 0 {
+		empty = false
+		f.proposerIndices = make(map[primitives.ValidatorIndex]bool, len(vals))
+		for _, v := range vals {
+			f.proposerIndices[primitives.ValidatorIndex(v)] = true
+		}
+	}
+	vals, err = uintsFromQuery(q, BlobIndexFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) > 0 {
+		empty = false
+		f.blobIndices = make(map[uint64]bool, len(vals))
+		for _, v := range vals {
+			f.blobIndices[v] = true
+		}
+	}
+	roots := splitQueryValues(q[BlockRootFilter])
+	if len(roots) > 0 {
+		empty = false
+		f.blockRoots = make(map[[fieldparams.RootLength]byte]bool, len(roots))
+		for _, r := range roots {
+			b, err := hexutil.Decode(r)
+			if err != nil || len(b) != fieldparams.RootLength {
+				return nil, errors.Wrapf(errInvalidFilter, "%s value %s is not a valid root", BlockRootFilter, r)
+			}
+			f.blockRoots[bytesutil.ToBytes32(b)] = true
+		}
+	}
+	if empty {
+		return nil, nil
+	}
+	return f, nil
+}
+
+func splitQueryValues(raw []string) []string {
+	var vals []string
+	for _, r := range raw {
+		for _, v := range strings.Split(r, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				vals = append(vals, v)
+			}
+		}
+	}
+	return vals
+}
+
+func uintsFromQuery(q url.Values, name string) ([]uint64, error) {
+	raw := splitQueryValues(q[name])
+	vals := make([]uint64, 0, len(raw))
+	for _, r := range raw {
+		v, err := strconv.ParseUint(r, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(errInvalidFilter, "%s value %s is not a valid unsigned integer", name, r)
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+func (f *eventFilter) matchValidator(indices ...primitives.ValidatorIndex) bool {
+	if f.validatorIndices == nil {
+		return true
+	}
+	for _, idx := range indices {
+		if f.validatorIndices[idx] {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *eventFilter) matchCommittee(indices ...primitives.CommitteeIndex) bool {
+	if f.committeeIndices == nil {
+		return true
+	}
+	for _, idx := range indices {
+		if f.committeeIndices[idx] {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *eventFilter) matchProposer(idx primitives.ValidatorIndex) bool {
+	return f.proposerIndices == nil || f.proposerIndices[idx]
+}
+
+func (f *eventFilter) matchBlockRoot(root []byte) bool {
+	if f.blockRoots == nil {
+		return true
+	}
+	if len(root) != fieldparams.RootLength {
+		return false
+	}
+	return f.blockRoots[bytesutil.ToBytes32(root)]
+}
+
+func (f *eventFilter) matchBlobIndex(idx uint64) bool {
+	return f.blobIndices == nil || f.blobIndices[idx]
+}
+
+// matches reports whether the event should be sent to the client. A nil filter matches every event.
+func (f *eventFilter) matches(ctx context.Context, event *feed.Event) bool {
+	if f == nil || event == nil || event.Data == nil {
+		return true
+	}
+	switch v := event.Data.(type) {
+	case *operation.UnAggregatedAttReceivedData:
+		if v.Attestation == nil || v.Attestation.GetData() == nil {
+			return false
+		}
+		return f.matchAttestationCommittee(v.Attestation) &&
+			f.matchBlockRoot(v.Attestation.GetData().BeaconBlockRoot) &&
+			f.matchAttester(ctx, v.Attestation)
+	case *operation.AggregatedAttReceivedData:
+		if v.Attestation == nil || v.Attestation.Aggregate == nil || v.Attestation.Aggregate.Data == nil {
+			return false
+		}
+		agg := v.Attestation.Aggregate
+		return f.matchValidator(v.Attestation.AggregatorIndex) &&
+			f.matchAttestationCommittee(agg) &&
+			f.matchBlockRoot(agg.Data.BeaconBlockRoot)
+	case *operation.ExitReceivedData:
+		if v.Exit == nil || v.Exit.Exit == nil {
+			return false
+		}
+		return f.matchValidator(v.Exit.Exit.ValidatorIndex)
+	case *operation.SyncCommitteeContributionReceivedData:
+		if v.Contribution == nil || v.Contribution.Message == nil {
+			return false
+		}
+		msg := v.Contribution.Message
+		if msg.Contribution != nil && !f.matchBlockRoot(msg.Contribution.BlockRoot) {
+			return false
+		}
+		return f.matchValidator(msg.AggregatorIndex)
+	case *operation.BLSToExecutionChangeReceivedData:
+		if v.Change == nil || v.Change.Message == nil {
+			return false
+		}
+		return f.matchValidator(v.Change.Message.ValidatorIndex)
+	case *operation.BlobSidecarReceivedData:
+		if v.Blob == nil {
+			return false
+		}
+		root := v.Blob.BlockRoot()
+		return f.matchProposer(v.Blob.ProposerIndex()) &&
+			f.matchBlockRoot(root[:]) &&
+			f.matchBlobIndex(v.Blob.Index)
+	case *operation.AttesterSlashingReceivedData:
+		if v.AttesterSlashing == nil || v.AttesterSlashing.FirstAttestation() == nil || v.AttesterSlashing.SecondAttestation() == nil {
+			return false
+		}
+		first := v.AttesterSlashing.FirstAttestation().GetAttestingIndices()
+		second := v.AttesterSlashing.SecondAttestation().GetAttestingIndices()
+		indices := make([]primitives.ValidatorIndex, 0, len(first)+len(second))
+		for _, idx := range first {
+			indices = append(indices, primitives.ValidatorIndex(idx))
+		}
+		for _, idx := range second {
+			indices = append(indices, primitives.ValidatorIndex(idx))
+		}
+		return f.matchValidator(indices...)
+	case *operation.ProposerSlashingReceivedData:
+		if v.ProposerSlashing == nil || v.ProposerSlashing.Header_1 == nil || v.ProposerSlashing.Header_1.Header == nil {
+			return false
+		}
+		idx := v.ProposerSlashing.Header_1.Header.ProposerIndex
+		return f.matchValidator(idx) && f.matchProposer(idx)
+	case *ethpb.EventHead:
+		return f.matchBlockRoot(v.Block)
+	case *ethpb.EventFinalizedCheckpoint:
+		return f.matchBlockRoot(v.Block)
+	case *ethpb.EventChainReorg:
+		return f.matchBlockRoot(v.NewHeadBlock)
+	case *statefeed.BlockProcessedData:
+		if v.SignedBlock == nil || v.SignedBlock.IsNil() {
+			return false
+		}
+		if f.blockRoots != nil {
+			root := v.BlockRoot
+			if root == [fieldparams.RootLength]byte{} {
+				r, err := v.SignedBlock.Block().HashTreeRoot()
+				if err != nil {
+					return false
+				}
+				root = r
+			}
+			if !f.matchBlockRoot(root[:]) {
+				return false
+			}
+		}
+		return f.matchProposer(v.SignedBlock.Block().ProposerIndex())
+	default:
+		return true
+	}
+}
+
+// matchAttestationCommittee matches the committee indices of an attestation. Post-electra attestations
+// carry their committee indices in the committee bits rather than in the attestation data.
+func (f *eventFilter) matchAttestationCommittee(att eth.Att) bool {
+	if f.committeeIndices == nil {
+		return true
+	}
+	bits := att.CommitteeBitsVal().BitIndices()
+	indices := make([]primitives.CommitteeIndex, len(bits))
+	for i, b := range bits {
+		indices[i] = primitives.CommitteeIndex(b)
+	}
+	return f.matchCommittee(indices...)
+}
+
+// matchAttester matches the validator of an unaggregated attestation, which only carries its position in the
+// committee. Attestations whose committee can't be resolved don't match.
+func (f *eventFilter) matchAttester(ctx context.Context, att eth.Att) bool {
+	if f.validatorIndices == nil {
+		return true
+	}
+	if f.attestingIndices == nil {
+		return false
+	}
+	indices, err := f.attestingIndices(ctx, att)
+	if err != nil {
+		log.WithError(err).Debug("Could not resolve the validator of an unaggregated attestation")
+		return false
+	}
+	vals := make([]primitives.ValidatorIndex, len(indices))
+	for i, idx := range indices {
+		vals[i] = primitives.ValidatorIndex(idx)
+	}
+	return f.matchValidator(vals...)
+}
+
+// attesterCacheSize is the number of unaggregated attestations whose attesting indices are kept for other streams.
+const attesterCacheSize = 4096
+
+// attesterIndexer resolves the validators of unaggregated attestations for the event filters of all streams.
+// The same attestation event is sent to every stream, so its attesting indices are resolved once and kept for the
+// other streams. Committees are read from the committee cache with the attester seed of their epoch, which is taken
+// from the head state once per epoch; the committee is only computed from the head state when it isn't cached.
+type attesterIndexer struct {
+	sync.Mutex
+	seeds    map[primitives.Epoch][32]byte
+	resolved *lru.Cache
+}
+
+// attestingIndices resolves the validators of an unaggregated attestation.
+func (s *Server) attestingIndices(ctx context.Context, att eth.Att) ([]uint64, error) {
+	return s.attesters.attestingIndices(ctx, s.HeadFetcher, att)
+}
+
+func (a *attesterIndexer) attestingIndices(ctx context.Context, head blockchain.HeadFetcher, att eth.Att) ([]uint64, error) {
+	a.Lock()
+	if a.resolved == nil {
+		resolved, err := lru.New(attesterCacheSize)
+		if err != nil {
+			a.Unlock()
+			return nil, err
+		}
+		a.resolved = resolved
+	}
+	resolved := a.resolved
+	a.Unlock()
+	if indices, ok := resolved.Get(att); ok {
+		return indices.([]uint64), nil
+	}
+
+	committeeIndex, err := att.GetCommitteeIndex()
+	if err != nil {
+		return nil, err
+	}
+	committee, err := a.committee(ctx, head, att.GetData().Slot, committeeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation committee")
+	}
+	indices, err := attestation.AttestingIndices(att, committee)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Add(att, indices)
+	return indices, nil
+}
+
+// committee returns the beacon committee of the given slot and committee index, preferring the committee cache.
+func (a *attesterIndexer) committee(
+	ctx context.Context,
+	head blockchain.HeadFetcher,
+	slot primitives.Slot,
+	committeeIndex primitives.CommitteeIndex,
+) ([]primitives.ValidatorIndex, error) {
+	epoch := slots.ToEpoch(slot)
+	a.Lock()
+	seed, ok := a.seeds[epoch]
+	a.Unlock()
+	if ok {
+		committee, err := helpers.CachedBeaconCommittee(ctx, seed, slot, committeeIndex)
+		if err != nil {
+			return nil, err
+		}
+		if committee != nil {
+			return committee, nil
+		}
+	}
+
+	if head == nil {
+		return nil, errors.New("no head fetcher")
+	}
+	st, err := head.HeadStateReadOnly(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	// The seed of an epoch is only final once the head reached it, earlier it may still change with the randao mix.
+	if !ok && slots.ToEpoch(st.Slot()) >= epoch {
+		seed, err = helpers.Seed(st, epoch, params.BeaconConfig().DomainBeaconAttester)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get attester seed")
+		}
+		a.Lock()
+		if a.seeds == nil {
+			a.seeds = make(map[primitives.Epoch][32]byte)
+		}
+		for e := range a.seeds {
+			if e+1 < epoch {
+				delete(a.seeds, e)
+			}
+		}
+		a.seeds[epoch] = seed
+		a.Unlock()
+	}
+	return helpers.BeaconCommitteeFromState(ctx, st, slot, committeeIndex)
+}
@@ -0,0 +1,245 @@
+package events
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestNewEventFilter(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		f, err := newEventFilter(url.Values{"topics": []string{HeadTopic}})
+		require.NoError(t, err)
+		require.Equal(t, true, f == nil)
+	})
+	t.Run("repeated and comma separated", func(t *testing.T) {
+		f, err := newEventFilter(url.Values{ValidatorIndicesFilter: []string{"1,2", "3"}})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(f.validatorIndices))
+		require.Equal(t, true, f.validatorIndices[2])
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		_, err := newEventFilter(url.Values{CommitteeIndexFilter: []string{"foo"}})
+		require.ErrorIs(t, err, errInvalidFilter)
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		_, err := newEventFilter(url.Values{BlockRootFilter: []string{"0x1234"}})
+		require.ErrorIs(t, err, errInvalidFilter)
+	})
+}
+
+func TestEventFilter_Matches(t *testing.T) {
+	root := bytesutil.PadTo([]byte{'a'}, 32)
+	otherRoot := bytesutil.PadTo([]byte{'b'}, 32)
+
+	att := util.HydrateAttestation(&eth.Attestation{})
+	att.Data.CommitteeIndex = 3
+	att.Data.BeaconBlockRoot = root
+	attEvent := &feed.Event{
+		Type: operation.UnaggregatedAttReceived,
+		Data: &operation.UnAggregatedAttReceivedData{Attestation: att},
+	}
+	exitEvent := &feed.Event{
+		Type: operation.ExitReceived,
+		Data: &operation.ExitReceivedData{
+			Exit: &eth.SignedVoluntaryExit{Exit: &eth.VoluntaryExit{ValidatorIndex: 7}},
+		},
+	}
+	sc := util.HydrateBlobSidecar(&eth.BlobSidecar{Index: 2})
+	sc.SignedBlockHeader.Header.ProposerIndex = 5
+	ro, err := blocks.NewROBlob(sc)
+	require.NoError(t, err)
+	vblob := blocks.NewVerifiedROBlob(ro)
+	blobEvent := &feed.Event{
+		Type: operation.BlobSidecarReceived,
+		Data: &operation.BlobSidecarReceivedData{Blob: &vblob},
+	}
+	blobRoot := vblob.BlockRoot()
+	headEvent := &feed.Event{
+		Type: statefeed.NewHead,
+		Data: &ethpb.EventHead{Block: root},
+	}
+	blk := util.HydrateSignedBeaconBlock(&eth.SignedBeaconBlock{})
+	blk.Block.ProposerIndex = 5
+	sb, err := blocks.NewSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	blockEvent := &feed.Event{
+		Type: statefeed.BlockProcessed,
+		Data: &statefeed.BlockProcessedData{SignedBlock: sb, BlockRoot: bytesutil.ToBytes32(root)},
+	}
+
+	cases := []struct {
+		name     string
+		query    url.Values
+		event    *feed.Event
+		expected bool
+	}{
+		{
+			name:     "committee index matches attestation",
+			query:    url.Values{CommitteeIndexFilter: []string{"3"}},
+			event:    attEvent,
+			expected: true,
+		},
+		{
+			name:     "committee index does not match attestation",
+			query:    url.Values{CommitteeIndexFilter: []string{"4"}},
+			event:    attEvent,
+			expected: false,
+		},
+		{
+			name:     "block root does not match attestation",
+			query:    url.Values{BlockRootFilter: []string{hexutil.Encode(otherRoot)}},
+			event:    attEvent,
+			expected: false,
+		},
+		{
+			name:     "validator index matches exit",
+			query:    url.Values{ValidatorIndicesFilter: []string{"1,7"}},
+			event:    exitEvent,
+			expected: true,
+		},
+		{
+			name:     "validator index does not match exit",
+			query:    url.Values{ValidatorIndicesFilter: []string{"1"}},
+			event:    exitEvent,
+			expected: false,
+		},
+		{
+			name:     "committee index does not apply to exit",
+			query:    url.Values{CommitteeIndexFilter: []string{"1"}},
+			event:    exitEvent,
+			expected: true,
+		},
+		{
+			name:     "blob index and proposer match blob",
+			query:    url.Values{BlobIndexFilter: []string{"2"}, ProposerIndexFilter: []string{"5"}, BlockRootFilter: []string{hexutil.Encode(blobRoot[:])}},
+			event:    blobEvent,
+			expected: true,
+		},
+		{
+			name:     "blob index does not match blob",
+			query:    url.Values{BlobIndexFilter: []string{"1"}},
+			event:    blobEvent,
+			expected: false,
+		},
+		{
+			name:     "block root matches head",
+			query:    url.Values{BlockRootFilter: []string{hexutil.Encode(otherRoot), hexutil.Encode(root)}},
+			event:    headEvent,
+			expected: true,
+		},
+		{
+			name:     "block root does not match head",
+			query:    url.Values{BlockRootFilter: []string{hexutil.Encode(otherRoot)}},
+			event:    headEvent,
+			expected: false,
+		},
+		{
+			name:     "proposer matches block",
+			query:    url.Values{ProposerIndexFilter: []string{"5"}, BlockRootFilter: []string{hexutil.Encode(root)}},
+			event:    blockEvent,
+			expected: true,
+		},
+		{
+			name:     "proposer does not match block",
+			query:    url.Values{ProposerIndexFilter: []string{"6"}},
+			event:    blockEvent,
+			expected: false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := newEventFilter(c.query)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, f.matches(context.Background(), c.event))
+		})
+	}
+}
+
+func TestEventFilter_NilMatchesAll(t *testing.T) {
+	var f *eventFilter
+	require.Equal(t, true, f.matches(context.Background(), &feed.Event{Type: statefeed.NewHead, Data: &ethpb.EventHead{}}))
+}
+
+func TestEventFilter_MatchesUnaggregatedAttester(t *testing.T) {
+	att := util.HydrateAttestation(&eth.Attestation{})
+	attEvent := &feed.Event{
+		Type: operation.UnaggregatedAttReceived,
+		Data: &operation.UnAggregatedAttReceivedData{Attestation: att},
+	}
+	f, err := newEventFilter(url.Values{ValidatorIndicesFilter: []string{"4"}})
+	require.NoError(t, err)
+	// Without a resolver the attester is unknown.
+	require.Equal(t, false, f.matches(context.Background(), attEvent))
+
+	f.attestingIndices = func(context.Context, eth.Att) ([]uint64, error) { return []uint64{4}, nil }
+	require.Equal(t, true, f.matches(context.Background(), attEvent))
+	f.attestingIndices = func(context.Context, eth.Att) ([]uint64, error) { return []uint64{5}, nil }
+	require.Equal(t, false, f.matches(context.Background(), attEvent))
+	f.attestingIndices = func(context.Context, eth.Att) ([]uint64, error) { return nil, errors.New("no committee") }
+	require.Equal(t, false, f.matches(context.Background(), attEvent))
+}
+
+// countingHeadFetcher counts the head state lookups of the attester indexer.
+type countingHeadFetcher struct {
+	*mock.ChainService
+	calls int
+}
+
+func (c *countingHeadFetcher) HeadStateReadOnly(ctx context.Context) (state.ReadOnlyBeaconState, error) {
+	c.calls++
+	return c.ChainService.HeadStateReadOnly(ctx)
+}
+
+func TestAttesterIndexer_ResolvesOnceFromCommitteeCache(t *testing.T) {
+	helpers.ClearCache()
+	t.Cleanup(helpers.ClearCache)
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 1, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(committee))
+	helpers.ClearCache()
+	newAtt := func() *eth.Attestation {
+		att := util.HydrateAttestation(&eth.Attestation{AggregationBits: bitfield.NewBitlist(uint64(len(committee)))})
+		att.Data.Slot = 1
+		att.AggregationBits.SetBitAt(0, true)
+		return att
+	}
+	head := &countingHeadFetcher{ChainService: &mock.ChainService{State: st}}
+	a := &attesterIndexer{}
+
+	att := newAtt()
+	indices, err := a.attestingIndices(ctx, head, att)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{uint64(committee[0])}, indices)
+	require.Equal(t, 1, head.calls)
+
+	// The same event delivered to another stream is not resolved again.
+	indices, err = a.attestingIndices(ctx, head, att)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{uint64(committee[0])}, indices)
+	require.Equal(t, 1, head.calls)
+
+	// Other attestations of the epoch are resolved from the committee cache filled by the head state lookup.
+	indices, err = a.attestingIndices(ctx, head, newAtt())
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{uint64(committee[0])}, indices)
+	require.Equal(t, 1, head.calls)
+}
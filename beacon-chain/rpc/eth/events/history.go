@@ -0,0 +1,195 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+)
+
+var errInvalidLastEventID = errors.New("invalid Last-Event-ID header")
+
+type historyEntry struct {
+	id    uint64
+	event *feed.Event
+}
+
+// historyRing is a fixed size ring buffer of events for a single topic, ordered by id.
+type historyRing struct {
+	entries   []historyEntry
+	start     int
+	evictedID uint64
+}
+
+// push adds the entry to the ring, returning the entry which was evicted to make room for it, if any.
+func (r *historyRing) push(e historyEntry, size int) (historyEntry, bool) {
+	if len(r.entries) < size {
+		r.entries = append(r.entries, e)
+		return historyEntry{}, false
+	}
+	evicted := r.entries[r.start]
+	r.entries[r.start] = e
+	r.start = (r.start + 1) % len(r.entries)
+	r.evictedID = evicted.id
+	return evicted, true
+}
+
+func (r *historyRing) since(id uint64) []historyEntry {
+	var out []historyEntry
+	for i := 0; i < len(r.entries); i++ {
+		e := r.entries[(r.start+i)%len(r.entries)]
+		if e.id > id {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// EventHistory assigns ids to events sent on the state and operation feeds and keeps a bounded number
+// of recent events per topic, so that clients reconnecting with a Last-Event-ID header can be sent
+// the events they missed.
+//
+// The same feed event is delivered to the recorder and to every event stream, so ids are assigned
+// to the event pointer by whichever of them sees the event first. Ids are seeded from the wall clock,
+// so they keep increasing across restarts of the node and a client reconnecting after a restart is sent
+// every retained event, after an event gap event as the events sent before the restart are lost.
+type EventHistory struct {
+	sync.Mutex
+	size   int
+	seed   uint64
+	nextID uint64
+	rings  map[string]*historyRing
+	ids    map[*feed.Event]uint64
+}
+
+// NewEventHistory creates an event history retaining up to size events per topic.
+func NewEventHistory(size int) *EventHistory {
+	seed := uint64(time.Now().UnixNano())
+	return &EventHistory{
+		size:   size,
+		seed:   seed,
+		nextID: seed,
+		rings:  make(map[string]*historyRing),
+		ids:    make(map[*feed.Event]uint64),
+	}
+}
+
+// Record subscribes to the state and operation feeds and records every event until the context is canceled.
+func (h *EventHistory) Record(ctx context.Context, stn statefeed.Notifier, opn opfeed.Notifier) {
+	eventsChan := make(chan *feed.Event, DefaultEventFeedDepth)
+	stateSub := stn.StateFeed().Subscribe(eventsChan)
+	defer stateSub.Unsubscribe()
+	opsSub := opn.OperationFeed().Subscribe(eventsChan)
+	defer opsSub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Debug("Event history state feed subscription closed")
+			return
+		case err := <-opsSub.Err():
+			log.WithError(err).Debug("Event history operation feed subscription closed")
+			return
+		case event := <-eventsChan:
+			h.record(event)
+		}
+	}
+}
+
+// record returns the id of the event, assigning one and adding the event to the history if it has not been seen yet.
+// Events which can't be replayed are not retained and are given an id of zero.
+func (h *EventHistory) record(event *feed.Event) uint64 {
+	if h == nil || event == nil {
+		return 0
+	}
+	topic := topicForEvent(event)
+	if !replayable(topic) {
+		return 0
+	}
+	h.Lock()
+	defer h.Unlock()
+	if id, ok := h.ids[event]; ok {
+		return id
+	}
+	h.nextID++
+	id := h.nextID
+	ring, ok := h.rings[topic]
+	if !ok {
+		ring = &historyRing{}
+		h.rings[topic] = ring
+	}
+	if evicted, ok := ring.push(historyEntry{id: id, event: event}, h.size); ok {
+		delete(h.ids, evicted.event)
+	}
+	h.ids[event] = id
+	return id
+}
+
+// since returns the retained events for the requested topics which have an id greater than the given id, in id order.
+// The boolean is true when events after the id may have been missed: they were evicted from the history, or sent
+// before the history was started, for example by the node before it restarted.
+func (h *EventHistory) since(id uint64, req *topicRequest) ([]historyEntry, bool) {
+	if h == nil {
+		return nil, true
+	}
+	h.Lock()
+	defer h.Unlock()
+	var out []historyEntry
+	gap := id < h.seed
+	for topic, ring := range h.rings {
+		if !req.requested(topic) {
+			continue
+		}
+		gap = gap || ring.evictedID > id
+		out = append(out, ring.since(id)...)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].id < out[j].id
+	})
+	return out, gap
+}
+
+// replayable reports whether events of the topic can be sent again after the fact. Payload attributes
+// are computed from the head state at the time they are sent, so replaying them would be misleading.
+func replayable(topic string) bool {
+	return topic != InvalidTopic && topic != PayloadAttributesTopic
+}
+
+// lastEventIDFromRequest parses the Last-Event-ID header. The boolean is false when the header is not set.
+func lastEventIDFromRequest(r *http.Request) (uint64, bool, error) {
+	raw := strings.TrimSpace(r.Header.Get(api.LastEventIDHeader))
+	if raw == "" {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, errors.Wrapf(errInvalidLastEventID, "%s is not a valid event id", raw)
+	}
+	return id, true, nil
+}
+
+// withEventID prefixes the serialized event with an sse id field.
+func withEventID(id uint64, lr lazyReader) lazyReader {
+	if id == 0 || lr == nil {
+		return lr
+	}
+	return func() io.Reader {
+		r := lr()
+		if r == nil {
+			return nil
+		}
+		return io.MultiReader(strings.NewReader(fmt.Sprintf("id: %d\n", id)), r)
+	}
+}
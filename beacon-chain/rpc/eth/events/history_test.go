@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	sse "github.com/r3labs/sse/v2"
+)
+
+func headEvent(slot primitives.Slot) *feed.Event {
+	return &feed.Event{
+		Type: statefeed.NewHead,
+		Data: &ethpb.EventHead{
+			Slot:                      slot,
+			Block:                     make([]byte, 32),
+			State:                     make([]byte, 32),
+			PreviousDutyDependentRoot: make([]byte, 32),
+			CurrentDutyDependentRoot:  make([]byte, 32),
+		},
+	}
+}
+
+func TestEventHistory_Record(t *testing.T) {
+	h := NewEventHistory(2)
+	first := headEvent(1)
+	id := h.record(first)
+	require.NotEqual(t, uint64(0), id)
+	// The same event is given the same id by every caller.
+	require.Equal(t, id, h.record(first))
+	// Payload attributes are not retained.
+	require.Equal(t, uint64(0), h.record(&feed.Event{Type: statefeed.MissedSlot}))
+
+	second, third := headEvent(2), headEvent(3)
+	secondID := h.record(second)
+	thirdID := h.record(third)
+	require.Equal(t, id+1, secondID)
+	require.Equal(t, id+2, thirdID)
+
+	req, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	// The first event was evicted when the third was recorded, a client which did not see it has a gap.
+	missed, gap := h.since(id-1, req)
+	require.Equal(t, true, gap)
+	require.Equal(t, 2, len(missed))
+	require.Equal(t, secondID, missed[0].id)
+	require.Equal(t, thirdID, missed[1].id)
+	missed, gap = h.since(id, req)
+	require.Equal(t, false, gap)
+	require.Equal(t, 2, len(missed))
+	missed, gap = h.since(secondID, req)
+	require.Equal(t, false, gap)
+	require.Equal(t, 1, len(missed))
+	require.Equal(t, thirdID, missed[0].id)
+
+	// Events sent before the history was started, for example before a restart, are lost.
+	_, gap = h.since(0, req)
+	require.Equal(t, true, gap)
+
+	req, err = newTopicRequest([]string{BlockTopic})
+	require.NoError(t, err)
+	missed, gap = h.since(id-1, req)
+	require.Equal(t, false, gap)
+	require.Equal(t, 0, len(missed))
+}
+
+func TestStreamEvents_ReplaysFromLastEventID(t *testing.T) {
+	testSync := newStreamTestSync(t)
+	defer testSync.cleanup()
+	stn := mockChain.NewEventFeedWrapper()
+	opn := mockChain.NewEventFeedWrapper()
+	s := &Server{
+		StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+		OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+		EventWriteTimeout: testEventWriteTimeout,
+		EventHistory:      NewEventHistory(8),
+	}
+	missed := []*feed.Event{headEvent(1), headEvent(2), headEvent(3)}
+	ids := make([]uint64, len(missed))
+	for i, ev := range missed {
+		ids[i] = s.EventHistory.record(ev)
+	}
+
+	topics, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	request := topics.testHttpRequest(testSync.ctx, t)
+	request.Header.Set(api.LastEventIDHeader, fmt.Sprintf("%d", ids[0]))
+	w := NewStreamingResponseWriterRecorder(testSync.ctx)
+	go func() {
+		s.StreamEvents(w, request)
+		testSync.markDone()
+	}()
+
+	sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+	// The events after the last event id are replayed with their ids.
+	for i := 1; i < len(missed); i++ {
+		ev, err := sseR.ReadEvent()
+		require.NoError(t, err)
+		require.Equal(t, true, strings.HasPrefix(string(ev), fmt.Sprintf("id: %d\n", ids[i])))
+	}
+
+	// Live events are sent with newly assigned ids.
+	live := headEvent(4)
+	require.NoError(t, stn.WaitForSubscription(testSync.ctx))
+	s.StateNotifier.StateFeed().Send(live)
+	ev, err := sseR.ReadEvent()
+	require.NoError(t, err)
+	lr, err := s.lazyReaderForEvent(testSync.ctx, live, topics)
+	require.NoError(t, err)
+	exb, err := io.ReadAll(lr())
+	require.NoError(t, err)
+	expected := fmt.Sprintf("id: %d\n%s", ids[2]+1, string(exb[:len(exb)-2]))
+	require.Equal(t, expected, string(ev))
+}
+
+func TestStreamEvents_EventGap(t *testing.T) {
+	testSync := newStreamTestSync(t)
+	defer testSync.cleanup()
+	stn := mockChain.NewEventFeedWrapper()
+	opn := mockChain.NewEventFeedWrapper()
+	s := &Server{
+		StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+		OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+		EventWriteTimeout: testEventWriteTimeout,
+		EventHistory:      NewEventHistory(1),
+	}
+	first := s.EventHistory.record(headEvent(1))
+	second := s.EventHistory.record(headEvent(2))
+
+	topics, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	request := topics.testHttpRequest(testSync.ctx, t)
+	// The event after the last event id was evicted.
+	request.Header.Set(api.LastEventIDHeader, fmt.Sprintf("%d", first-1))
+	w := NewStreamingResponseWriterRecorder(testSync.ctx)
+	go func() {
+		s.StreamEvents(w, request)
+		testSync.markDone()
+	}()
+
+	sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+	ev, err := sseR.ReadEvent()
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("event: %s\ndata: {\"last_event_id\":\"%d\"}", EventGapTopic, first-1), string(ev))
+	ev, err = sseR.ReadEvent()
+	require.NoError(t, err)
+	require.Equal(t, true, strings.HasPrefix(string(ev), fmt.Sprintf("id: %d\n", second)))
+}
+
+func TestLastEventIDFromRequest_Invalid(t *testing.T) {
+	topics, err := newTopicRequest([]string{HeadTopic})
+	require.NoError(t, err)
+	req := topics.testHttpRequest(context.Background(), t)
+	req.Header.Set(api.LastEventIDHeader, "abc")
+	_, _, err = lastEventIDFromRequest(req)
+	require.ErrorIs(t, err, errInvalidLastEventID)
+}
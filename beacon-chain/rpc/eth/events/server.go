@@ -23,4 +23,6 @@ type Server struct {
 	KeepAliveInterval      time.Duration
 	EventFeedDepth         int
 	EventWriteTimeout      time.Duration
+	EventHistory           *EventHistory
+	attesters              attesterIndexer
 }
@@ -166,6 +166,19 @@ func (s *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
 	if rawBbFactor != "" {
 		bbFactor = &wrapperspb.UInt64Value{Value: bbValue}
 	}
+	// Prysm specific limits which the proposer puts on the builder bids for its block.
+	_, minBid, ok := shared.UintFromQuery(w, r, "builder_min_bid_gwei", false)
+	if !ok {
+		return
+	}
+	_, maxBidDifference, ok := shared.UintFromQuery(w, r, "builder_max_bid_difference_gwei", false)
+	if !ok {
+		return
+	}
+	_, builderTimeout, ok := shared.UintFromQuery(w, r, "builder_timeout_ms", false)
+	if !ok {
+		return
+	}
 
 	slot, valid := shared.ValidateUint(w, "slot", rawSlot)
 	if !valid {
@@ -194,11 +207,15 @@ func (s *Server) ProduceBlockV3(w http.ResponseWriter, r *http.Request) {
 	}
 
 	s.produceBlockV3(ctx, w, r, &eth.BlockRequest{
-		Slot:               primitives.Slot(slot),
-		RandaoReveal:       randaoReveal,
-		Graffiti:           graffiti,
-		SkipMevBoost:       false,
-		BuilderBoostFactor: bbFactor,
+		Slot:                        primitives.Slot(slot),
+		RandaoReveal:                randaoReveal,
+		Graffiti:                    graffiti,
+		SkipMevBoost:                false,
+		BuilderBoostFactor:          bbFactor,
+		BuilderMinBidGwei:           primitives.Gwei(minBid),
+		BuilderMaxBidDifferenceGwei: primitives.Gwei(maxBidDifference),
+		BuilderRelays:               r.URL.Query()["builder_relays"],
+		BuilderTimeoutMs:            builderTimeout,
 	}, any)
 }
 
@@ -1253,6 +1253,46 @@ func TestProduceBlockV3(t *testing.T) {
 		require.Equal(t, "phase0", writer.Header().Get(api.VersionHeader))
 		require.Equal(t, "", writer.Header().Get(api.ConsensusBlockValueHeader))
 	})
+	t.Run("builder bid limits", func(t *testing.T) {
+		var block *structs.SignedBeaconBlock
+		err := json.Unmarshal([]byte(rpctesting.Phase0Block), &block)
+		require.NoError(t, err)
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), &eth.BlockRequest{
+			Slot:                        1,
+			RandaoReveal:                bRandao,
+			Graffiti:                    bGraffiti,
+			SkipMevBoost:                false,
+			BuilderMinBidGwei:           100,
+			BuilderMaxBidDifferenceGwei: 2000,
+			BuilderRelays:               []string{"a.example.com", "b.example.com"},
+			BuilderTimeoutMs:            500,
+		}).Return(
+			func() (*eth.GenericBeaconBlock, error) {
+				return block.Message.ToGeneric()
+			}())
+		server := &Server{
+			V1Alpha1Server: v1alpha1Server,
+			SyncChecker:    syncChecker,
+		}
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://foo.example/eth/v3/validator/blocks/1?randao_reveal=%s&graffiti=%s"+
+			"&builder_min_bid_gwei=100&builder_max_bid_difference_gwei=2000&builder_relays=a.example.com&builder_relays=b.example.com&builder_timeout_ms=500", randao, graffiti), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.ProduceBlockV3(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("invalid builder bid limit", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: syncChecker,
+		}
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://foo.example/eth/v3/validator/blocks/1?randao_reveal=%s&builder_min_bid_gwei=foo", randao), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.ProduceBlockV3(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "builder_min_bid_gwei is invalid", writer.Body.String())
+	})
 	t.Run("Altair", func(t *testing.T) {
 		var block *structs.SignedBeaconBlockAltair
 		err := json.Unmarshal([]byte(rpctesting.AltairBlock), &block)
@@ -0,0 +1,157 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// defaultHistoryEpochs is the number of epochs returned when no epoch range is requested.
+	defaultHistoryEpochs = 100
+	// maxHistoryEpochs is the maximum number of epochs that can be requested at once.
+	maxHistoryEpochs = 4096
+)
+
+const errMonitorDisabled = "Validator monitor is not enabled, use --monitor-indices or --enable-validator-monitor"
+
+// GetTrackedValidators returns the indices of the validators tracked by the validator monitor.
+func (s *Server) GetTrackedValidators(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "monitor.GetTrackedValidators")
+	defer span.End()
+
+	if s.ValidatorMonitor == nil {
+		httputil.HandleError(w, errMonitorDisabled, http.StatusServiceUnavailable)
+		return
+	}
+	httputil.WriteJson(w, trackedValidatorsResponse(s.ValidatorMonitor.TrackedValidatorIndices()))
+}
+
+// TrackValidators adds validators to the set of validators tracked by the validator monitor.
+// Tracked validators are not persisted across restarts, use --monitor-indices for that.
+func (s *Server) TrackValidators(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "monitor.TrackValidators")
+	defer span.End()
+
+	if s.ValidatorMonitor == nil {
+		httputil.HandleError(w, errMonitorDisabled, http.StatusServiceUnavailable)
+		return
+	}
+	var req structs.MonitorValidatorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.HandleError(w, "Could not decode JSON request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Indices) == 0 {
+		httputil.HandleError(w, "No validator indices provided", http.StatusBadRequest)
+		return
+	}
+	indices := make([]primitives.ValidatorIndex, len(req.Indices))
+	for i, raw := range req.Indices {
+		idx, ok := shared.ValidateUint(w, fmt.Sprintf("indices[%d]", i), raw)
+		if !ok {
+			return
+		}
+		indices[i] = primitives.ValidatorIndex(idx)
+	}
+	if err := s.ValidatorMonitor.TrackValidators(ctx, indices); err != nil {
+		httputil.HandleError(w, "Could not track validators: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	httputil.WriteJson(w, trackedValidatorsResponse(s.ValidatorMonitor.TrackedValidatorIndices()))
+}
+
+// UntrackValidator removes a validator from the set of validators tracked by the validator monitor.
+func (s *Server) UntrackValidator(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "monitor.UntrackValidator")
+	defer span.End()
+
+	if s.ValidatorMonitor == nil {
+		httputil.HandleError(w, errMonitorDisabled, http.StatusServiceUnavailable)
+		return
+	}
+	_, idx, ok := shared.UintFromRoute(w, r, "index")
+	if !ok {
+		return
+	}
+	s.ValidatorMonitor.UntrackValidators([]primitives.ValidatorIndex{primitives.ValidatorIndex(idx)})
+	httputil.WriteJson(w, trackedValidatorsResponse(s.ValidatorMonitor.TrackedValidatorIndices()))
+}
+
+// GetValidatorHistory returns the per-epoch summaries of a validator persisted by the validator monitor.
+// Without an epoch range, the summaries of the last 100 epochs are returned.
+func (s *Server) GetValidatorHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "monitor.GetValidatorHistory")
+	defer span.End()
+
+	_, idx, ok := shared.UintFromRoute(w, r, "index")
+	if !ok {
+		return
+	}
+	rawTo, to, ok := shared.UintFromQuery(w, r, "to_epoch", false)
+	if !ok {
+		return
+	}
+	if rawTo == "" {
+		to = uint64(slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot()))
+	}
+	rawFrom, from, ok := shared.UintFromQuery(w, r, "from_epoch", false)
+	if !ok {
+		return
+	}
+	if rawFrom == "" {
+		from = 0
+		if to >= defaultHistoryEpochs {
+			from = to - defaultHistoryEpochs + 1
+		}
+	}
+	if from > to {
+		httputil.HandleError(w, "from_epoch must not be greater than to_epoch", http.StatusBadRequest)
+		return
+	}
+	if to-from >= maxHistoryEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Requested epoch range exceeds the limit of %d epochs", maxHistoryEpochs), http.StatusBadRequest)
+		return
+	}
+
+	summaries, err := s.BeaconDB.ValidatorMonitorHistory(ctx, primitives.ValidatorIndex(idx), primitives.Epoch(from), primitives.Epoch(to))
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator monitor history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.ValidatorMonitorEpochSummary, len(summaries))
+	for i, sum := range summaries {
+		data[i] = &structs.ValidatorMonitorEpochSummary{
+			ValidatorIndex:        strconv.FormatUint(uint64(sum.ValidatorIndex), 10),
+			Epoch:                 strconv.FormatUint(uint64(sum.Epoch), 10),
+			AttestationIncluded:   sum.AttestationIncluded,
+			InclusionDistance:     strconv.FormatUint(sum.InclusionDistance, 10),
+			TimelySource:          sum.TimelySource,
+			TimelyTarget:          sum.TimelyTarget,
+			TimelyHead:            sum.TimelyHead,
+			ProposedBlocks:        strconv.FormatUint(sum.ProposedBlocks, 10),
+			SyncCommitteeExpected: strconv.FormatUint(sum.SyncCommitteeExpected, 10),
+			SyncCommitteeIncluded: strconv.FormatUint(sum.SyncCommitteeIncluded, 10),
+			StartBalance:          strconv.FormatUint(sum.StartBalance, 10),
+			EndBalance:            strconv.FormatUint(sum.EndBalance, 10),
+			BalanceChange:         strconv.FormatInt(sum.BalanceChange(), 10),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetValidatorMonitorHistoryResponse{Data: data})
+}
+
+func trackedValidatorsResponse(indices []primitives.ValidatorIndex) *structs.GetMonitoredValidatorsResponse {
+	data := make([]string, len(indices))
+	for i, idx := range indices {
+		data[i] = strconv.FormatUint(uint64(idx), 10)
+	}
+	return &structs.GetMonitoredValidatorsResponse{Data: data}
+}
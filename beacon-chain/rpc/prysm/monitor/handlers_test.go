@@ -0,0 +1,147 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	monitortypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mockTracker struct {
+	tracked    map[primitives.ValidatorIndex]bool
+	validators uint64
+}
+
+func (m *mockTracker) TrackedValidatorIndices() []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(m.tracked))
+	for idx := range m.tracked {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func (m *mockTracker) TrackValidators(_ context.Context, indices []primitives.ValidatorIndex) error {
+	for _, idx := range indices {
+		if uint64(idx) >= m.validators {
+			return errors.New("validator index does not exist")
+		}
+	}
+	for _, idx := range indices {
+		m.tracked[idx] = true
+	}
+	return nil
+}
+
+func (m *mockTracker) UntrackValidators(indices []primitives.ValidatorIndex) {
+	for _, idx := range indices {
+		delete(m.tracked, idx)
+	}
+}
+
+func TestServer_TrackedValidators(t *testing.T) {
+	t.Run("monitor disabled", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/monitor/validators", nil)
+		writer := httptest.NewRecorder()
+		s.GetTrackedValidators(writer, request)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+
+	s := &Server{ValidatorMonitor: &mockTracker{tracked: map[primitives.ValidatorIndex]bool{3: true}, validators: 10}}
+	t.Run("track", func(t *testing.T) {
+		body, err := json.Marshal(&structs.MonitorValidatorsRequest{Indices: []string{"7", "1"}})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/monitor/validators", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.TrackValidators(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetMonitoredValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []string{"1", "3", "7"}, resp.Data)
+	})
+	t.Run("track unknown validator", func(t *testing.T) {
+		body, err := json.Marshal(&structs.MonitorValidatorsRequest{Indices: []string{"10"}})
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/monitor/validators", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		s.TrackValidators(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("untrack", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://example.com/prysm/v1/monitor/validators/3", nil)
+		request.SetPathValue("index", "3")
+		writer := httptest.NewRecorder()
+		s.UntrackValidator(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetMonitoredValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []string{"1", "7"}, resp.Data)
+	})
+}
+
+func TestServer_GetValidatorHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	slot := 150 * params.BeaconConfig().SlotsPerEpoch
+	s := &Server{BeaconDB: beaconDB, GenesisTimeFetcher: &mockChain.ChainService{Slot: &slot}}
+
+	var summaries []*monitortypes.ValidatorEpochSummary
+	for epoch := primitives.Epoch(40); epoch < 150; epoch++ {
+		summaries = append(summaries, &monitortypes.ValidatorEpochSummary{
+			ValidatorIndex:      5,
+			Epoch:               epoch,
+			AttestationIncluded: true,
+			InclusionDistance:   1,
+			TimelyHead:          true,
+			StartBalance:        32000000000,
+			EndBalance:          32000000100,
+		})
+	}
+	require.NoError(t, beaconDB.SaveValidatorMonitorHistory(ctx, summaries))
+
+	t.Run("default range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/monitor/validators/5/history", nil)
+		request.SetPathValue("index", "5")
+		writer := httptest.NewRecorder()
+		s.GetValidatorHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorMonitorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 99, len(resp.Data))
+		assert.Equal(t, "51", resp.Data[0].Epoch)
+		assert.Equal(t, true, resp.Data[0].TimelyHead)
+		assert.Equal(t, "100", resp.Data[0].BalanceChange)
+	})
+	t.Run("range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/monitor/validators/5/history?from_epoch=30&to_epoch=41", nil)
+		request.SetPathValue("index", "5")
+		writer := httptest.NewRecorder()
+		s.GetValidatorHistory(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorMonitorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "40", resp.Data[0].Epoch)
+	})
+	t.Run("range too large", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/monitor/validators/5/history?from_epoch=0&to_epoch=5000", nil)
+		request.SetPathValue("index", "5")
+		writer := httptest.NewRecorder()
+		s.GetValidatorHistory(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
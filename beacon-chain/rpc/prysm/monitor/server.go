@@ -0,0 +1,22 @@
+package monitor
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// ValidatorTracker changes the validators tracked by the validator monitor at runtime.
+type ValidatorTracker interface {
+	TrackedValidatorIndices() []primitives.ValidatorIndex
+	TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	UntrackValidators(indices []primitives.ValidatorIndex)
+}
+
+type Server struct {
+	BeaconDB           db.ReadOnlyDatabase
+	ValidatorMonitor   ValidatorTracker
+	GenesisTimeFetcher blockchain.TimeFetcher
+}
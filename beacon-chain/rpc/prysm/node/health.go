@@ -0,0 +1,308 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	log "github.com/sirupsen/logrus"
+)
+
+// Statuses of the detailed health report and of its checks, from best to worst.
+const (
+	HealthPass = "pass"
+	HealthWarn = "warn"
+	HealthFail = "fail"
+)
+
+// Names of the checks of the detailed health report. The thresholds of a check can be set with the
+// <name>_warn and <name>_fail query parameters, which override those the node was started with.
+const (
+	checkServices    = "services"
+	checkExecution   = "execution"
+	checkPeers       = "peers"
+	checkSubnets     = "subnets"
+	checkDBSize      = "db_size"
+	checkBlobStorage = "blob_storage"
+	checkHeadLag     = "head_lag"
+)
+
+// healthThreshold holds the warn and fail limits of a check. Checks which degrade as their value grows
+// warn and fail above their limits, and a limit of 0 disables them. Checks which degrade as their value
+// shrinks warn and fail below their limits.
+type healthThreshold struct {
+	warn, fail uint64
+	below      bool
+}
+
+func (t *healthThreshold) status(v uint64) string {
+	if t.below {
+		switch {
+		case v < t.fail:
+			return HealthFail
+		case v < t.warn:
+			return HealthWarn
+		}
+		return HealthPass
+	}
+	switch {
+	case t.fail > 0 && v > t.fail:
+		return HealthFail
+	case t.warn > 0 && v > t.warn:
+		return HealthWarn
+	}
+	return HealthPass
+}
+
+func (t *healthThreshold) check(name string, v uint64) *structs.HealthCheck {
+	c := &structs.HealthCheck{Name: name, Status: t.status(v), Value: strconv.FormatUint(v, 10)}
+	if t.below || t.warn > 0 {
+		c.WarnThreshold = strconv.FormatUint(t.warn, 10)
+	}
+	if t.below || t.fail > 0 {
+		c.FailThreshold = strconv.FormatUint(t.fail, 10)
+	}
+	return c
+}
+
+// defaultHealthThresholds returns the thresholds used when none are given at startup or in the request. The execution
+// check is measured in seconds since the last valid payload, the head lag in slots and sizes in bytes.
+func defaultHealthThresholds() map[string]*healthThreshold {
+	cfg := params.BeaconConfig()
+	return map[string]*healthThreshold{
+		checkExecution:   {warn: 3 * cfg.SecondsPerSlot, fail: uint64(cfg.SlotsPerEpoch) * cfg.SecondsPerSlot},
+		checkPeers:       {warn: 10, fail: 1, below: true},
+		checkSubnets:     {warn: cfg.AttestationSubnetCount, fail: cfg.AttestationSubnetCount / 2, below: true},
+		checkDBSize:      {},
+		checkBlobStorage: {},
+		checkHeadLag:     {warn: 2, fail: uint64(cfg.SlotsPerEpoch)},
+	}
+}
+
+// ParseHealthThresholds parses thresholds of the form <name>_warn=<value> or <name>_fail=<value>, as
+// given to the node at startup, into the limits they set keyed by <name>_warn or <name>_fail.
+func ParseHealthThresholds(values []string) (map[string]uint64, error) {
+	defaults := defaultHealthThresholds()
+	thresholds := make(map[string]uint64, len(values))
+	for _, value := range values {
+		key, raw, ok := strings.Cut(strings.TrimSpace(value), "=")
+		if !ok {
+			return nil, errors.Errorf("health threshold %q is not of the form <check>_warn=<value> or <check>_fail=<value>", value)
+		}
+		name, found := strings.CutSuffix(key, "_warn")
+		if !found {
+			name, found = strings.CutSuffix(key, "_fail")
+		}
+		if _, known := defaults[name]; !found || !known {
+			return nil, errors.Errorf("unknown health threshold %q", key)
+		}
+		v, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value of health threshold %q", key)
+		}
+		thresholds[key] = v
+	}
+	return thresholds, nil
+}
+
+// healthThresholds returns the default thresholds, overridden by those the node was started with and then by
+// those of the query parameters.
+func (s *Server) healthThresholds(w http.ResponseWriter, r *http.Request) (map[string]*healthThreshold, bool) {
+	thresholds := defaultHealthThresholds()
+	for name, t := range thresholds {
+		if v, ok := s.HealthThresholds[name+"_warn"]; ok {
+			t.warn = v
+		}
+		if v, ok := s.HealthThresholds[name+"_fail"]; ok {
+			t.fail = v
+		}
+		raw, v, ok := shared.UintFromQuery(w, r, name+"_warn", false)
+		if !ok {
+			return nil, false
+		}
+		if raw != "" {
+			t.warn = v
+		}
+		raw, v, ok = shared.UintFromQuery(w, r, name+"_fail", false)
+		if !ok {
+			return nil, false
+		}
+		if raw != "" {
+			t.fail = v
+		}
+	}
+	return thresholds, true
+}
+
+// GetDetailedHealth reports the status of the services of the beacon node along with checks of the execution
+// client, peers, subnets, storage and head, each one passing, warning or failing against its thresholds.
+// The response status is 503 when any check fails, so that it can be used as a readiness probe.
+func (s *Server) GetDetailedHealth(w http.ResponseWriter, r *http.Request) {
+	_, span := trace.StartSpan(r.Context(), "node.GetDetailedHealth")
+	defer span.End()
+
+	thresholds, ok := s.healthThresholds(w, r)
+	if !ok {
+		return
+	}
+
+	services := s.servicesHealth()
+	checks := []*structs.HealthCheck{servicesCheck(services)}
+	if s.ExecutionChainInfoFetcher != nil {
+		checks = append(checks, s.executionCheck(thresholds[checkExecution]))
+	}
+	if s.PeersFetcher != nil {
+		checks = append(checks, s.peersCheck(thresholds[checkPeers]), s.subnetsCheck(thresholds[checkSubnets]))
+	}
+	if s.DatabasePath != "" {
+		checks = append(checks, s.dbSizeCheck(thresholds[checkDBSize]))
+	}
+	if s.BlobStorage != nil {
+		checks = append(checks, s.blobStorageCheck(thresholds[checkBlobStorage]))
+	}
+	if s.HeadFetcher != nil && s.GenesisTimeFetcher != nil {
+		checks = append(checks, s.headLagCheck(thresholds[checkHeadLag]))
+	}
+
+	status := HealthPass
+	for _, c := range checks {
+		if c.Status == HealthFail || (c.Status == HealthWarn && status == HealthPass) {
+			status = c.Status
+		}
+	}
+	resp := &structs.GetDetailedHealthResponse{
+		Data: &structs.DetailedHealth{Status: status, Services: services, Checks: checks},
+	}
+	if status != HealthFail {
+		httputil.WriteJson(w, resp)
+		return
+	}
+	w.Header().Set("Content-Type", api.JsonMediaType)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.WithError(err).Error("Could not write response message")
+	}
+}
+
+func (s *Server) servicesHealth() []*structs.ServiceHealth {
+	services := make([]*structs.ServiceHealth, 0)
+	if s.ServiceStatusFetcher == nil {
+		return services
+	}
+	for kind, err := range s.ServiceStatusFetcher.Statuses() {
+		sh := &structs.ServiceHealth{Name: kind.String(), Status: HealthPass}
+		if err != nil {
+			sh.Status = HealthFail
+			sh.Error = err.Error()
+		}
+		services = append(services, sh)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return services
+}
+
+func servicesCheck(services []*structs.ServiceHealth) *structs.HealthCheck {
+	failing := 0
+	for _, sh := range services {
+		if sh.Status == HealthFail {
+			failing++
+		}
+	}
+	c := &structs.HealthCheck{Name: checkServices, Status: HealthPass, Value: strconv.Itoa(failing)}
+	if failing > 0 {
+		c.Status = HealthFail
+		c.Message = fmt.Sprintf("%d services report an error", failing)
+	}
+	return c
+}
+
+// executionCheck measures the seconds elapsed since the execution client last found a payload valid.
+func (s *Server) executionCheck(t *healthThreshold) *structs.HealthCheck {
+	if !s.ExecutionChainInfoFetcher.ExecutionClientConnected() {
+		c := &structs.HealthCheck{Name: checkExecution, Status: HealthFail, Message: "Execution client is not connected"}
+		if err := s.ExecutionChainInfoFetcher.ExecutionClientConnectionErr(); err != nil {
+			c.Message += ": " + err.Error()
+		}
+		return c
+	}
+	last := s.ExecutionChainInfoFetcher.LastValidPayloadTime()
+	if last.IsZero() {
+		return &structs.HealthCheck{
+			Name:    checkExecution,
+			Status:  HealthWarn,
+			Message: "No payload was found valid by the execution client since the node started",
+		}
+	}
+	c := t.check(checkExecution, uint64(time.Since(last).Seconds()))
+	c.Details = map[string]string{"last_valid_payload": strconv.FormatInt(last.Unix(), 10)}
+	return c
+}
+
+func (s *Server) peersCheck(t *healthThreshold) *structs.HealthCheck {
+	peerStatus := s.PeersFetcher.Peers()
+	c := t.check(checkPeers, uint64(len(peerStatus.Connected())))
+	c.Details = map[string]string{
+		"inbound":  strconv.Itoa(len(peerStatus.InboundConnected())),
+		"outbound": strconv.Itoa(len(peerStatus.OutboundConnected())),
+	}
+	return c
+}
+
+// subnetsCheck counts the attestation subnets which at least one connected peer is subscribed to.
+func (s *Server) subnetsCheck(t *healthThreshold) *structs.HealthCheck {
+	peerStatus := s.PeersFetcher.Peers()
+	subnets := params.BeaconConfig().AttestationSubnetCount
+	covered := uint64(0)
+	for i := uint64(0); i < subnets; i++ {
+		if len(peerStatus.SubscribedToSubnet(i)) > 0 {
+			covered++
+		}
+	}
+	c := t.check(checkSubnets, covered)
+	c.Details = map[string]string{"subnets": strconv.FormatUint(subnets, 10)}
+	return c
+}
+
+func (s *Server) dbSizeCheck(t *healthThreshold) *structs.HealthCheck {
+	info, err := os.Stat(filepath.Join(s.DatabasePath, kv.DatabaseFileName))
+	if err != nil {
+		return &structs.HealthCheck{Name: checkDBSize, Status: HealthWarn, Message: "Could not get database size: " + err.Error()}
+	}
+	return t.check(checkDBSize, uint64(info.Size()))
+}
+
+func (s *Server) blobStorageCheck(t *healthThreshold) *structs.HealthCheck {
+	n, size, err := s.BlobStorage.Usage()
+	if err != nil {
+		return &structs.HealthCheck{Name: checkBlobStorage, Status: HealthWarn, Message: "Could not get blob storage usage: " + err.Error()}
+	}
+	c := t.check(checkBlobStorage, size)
+	c.Details = map[string]string{"blobs": strconv.FormatUint(n, 10)}
+	return c
+}
+
+// headLagCheck measures the number of slots between the head and the current slot.
+func (s *Server) headLagCheck(t *healthThreshold) *structs.HealthCheck {
+	current, head := s.GenesisTimeFetcher.CurrentSlot(), s.HeadFetcher.HeadSlot()
+	lag := uint64(0)
+	if current > head {
+		lag = uint64(current - head)
+	}
+	c := t.check(checkHeadLag, lag)
+	c.Details = map[string]string{"head_slot": strconv.FormatUint(uint64(head), 10)}
+	return c
+}
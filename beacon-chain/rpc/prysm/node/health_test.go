@@ -0,0 +1,190 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	corenet "github.com/libp2p/go-libp2p/core/network"
+	libp2ptest "github.com/libp2p/go-libp2p/p2p/host/peerstore/test"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
+	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mockServiceStatuses map[reflect.Type]error
+
+func (m mockServiceStatuses) Statuses() map[reflect.Type]error {
+	return m
+}
+
+type serviceA struct{}
+type serviceB struct{}
+
+func healthServer(t *testing.T, statuses mockServiceStatuses) *Server {
+	peerFetcher := &mockp2p.MockPeersProvider{}
+	peerFetcher.ClearPeers()
+	peerStatus := peerFetcher.Peers()
+	for i, id := range libp2ptest.GeneratePeerIDs(3) {
+		direction := corenet.DirInbound
+		if i == 0 {
+			direction = corenet.DirOutbound
+		}
+		peerStatus.Add(nil, id, nil, direction)
+		peerStatus.SetConnectionState(id, peers.PeerConnected)
+	}
+	slot := primitives.Slot(10)
+	return &Server{
+		ServiceStatusFetcher:      statuses,
+		PeersFetcher:              peerFetcher,
+		ExecutionChainInfoFetcher: &testutil.MockExecutionChainInfoFetcher{LastValidPayload: time.Now()},
+		HeadFetcher:               &mock.ChainService{},
+		GenesisTimeFetcher:        &mock.ChainService{Slot: &slot},
+	}
+}
+
+func checksByName(resp *structs.GetDetailedHealthResponse) map[string]*structs.HealthCheck {
+	checks := make(map[string]*structs.HealthCheck)
+	for _, c := range resp.Data.Checks {
+		checks[c.Name] = c
+	}
+	return checks
+}
+
+func TestGetDetailedHealth(t *testing.T) {
+	s := healthServer(t, mockServiceStatuses{
+		reflect.TypeOf(&serviceB{}): nil,
+		reflect.TypeOf(&serviceA{}): nil,
+	})
+
+	t.Run("default thresholds", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/health/detailed", nil)
+		writer := httptest.NewRecorder()
+		s.GetDetailedHealth(writer, request)
+		// No peer is subscribed to a subnet.
+		require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+		resp := &structs.GetDetailedHealthResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, HealthFail, resp.Data.Status)
+		require.Equal(t, 2, len(resp.Data.Services))
+		assert.Equal(t, "*node.serviceA", resp.Data.Services[0].Name)
+		assert.Equal(t, HealthPass, resp.Data.Services[0].Status)
+
+		checks := checksByName(resp)
+		assert.Equal(t, HealthPass, checks[checkServices].Status)
+		assert.Equal(t, HealthPass, checks[checkExecution].Status)
+		assert.Equal(t, HealthWarn, checks[checkPeers].Status)
+		assert.Equal(t, "3", checks[checkPeers].Value)
+		assert.Equal(t, "2", checks[checkPeers].Details["inbound"])
+		assert.Equal(t, "1", checks[checkPeers].Details["outbound"])
+		assert.Equal(t, HealthFail, checks[checkSubnets].Status)
+		assert.Equal(t, HealthWarn, checks[checkHeadLag].Status)
+		assert.Equal(t, "10", checks[checkHeadLag].Value)
+	})
+	t.Run("custom thresholds", func(t *testing.T) {
+		request := httptest.NewRequest(
+			http.MethodGet,
+			"http://example.com/prysm/v1/node/health/detailed?peers_warn=2&subnets_warn=0&subnets_fail=0&head_lag_warn=20",
+			nil,
+		)
+		writer := httptest.NewRecorder()
+		s.GetDetailedHealth(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetDetailedHealthResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, HealthPass, resp.Data.Status)
+		checks := checksByName(resp)
+		assert.Equal(t, "2", checks[checkPeers].WarnThreshold)
+		assert.Equal(t, "20", checks[checkHeadLag].WarnThreshold)
+	})
+	t.Run("startup thresholds", func(t *testing.T) {
+		s := healthServer(t, mockServiceStatuses{})
+		s.HealthThresholds = map[string]uint64{"peers_warn": 2, "subnets_warn": 0, "subnets_fail": 0, "head_lag_warn": 20}
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/health/detailed", nil)
+		writer := httptest.NewRecorder()
+		s.GetDetailedHealth(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetDetailedHealthResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		checks := checksByName(resp)
+		assert.Equal(t, HealthPass, checks[checkPeers].Status)
+		assert.Equal(t, "20", checks[checkHeadLag].WarnThreshold)
+
+		// Query parameters override the thresholds the node was started with.
+		request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/health/detailed?peers_warn=5", nil)
+		writer = httptest.NewRecorder()
+		s.GetDetailedHealth(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp = &structs.GetDetailedHealthResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		checks = checksByName(resp)
+		assert.Equal(t, HealthWarn, checks[checkPeers].Status)
+		assert.Equal(t, "5", checks[checkPeers].WarnThreshold)
+		assert.Equal(t, "20", checks[checkHeadLag].WarnThreshold)
+	})
+	t.Run("invalid threshold", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/node/health/detailed?peers_warn=foo", nil)
+		writer := httptest.NewRecorder()
+		s.GetDetailedHealth(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestGetDetailedHealth_ServiceError(t *testing.T) {
+	s := healthServer(t, mockServiceStatuses{
+		reflect.TypeOf(&serviceA{}): errors.New("not running"),
+	})
+	request := httptest.NewRequest(
+		http.MethodGet,
+		"http://example.com/prysm/v1/node/health/detailed?peers_warn=0&peers_fail=0&subnets_warn=0&subnets_fail=0&head_lag_warn=0",
+		nil,
+	)
+	writer := httptest.NewRecorder()
+	s.GetDetailedHealth(writer, request)
+	require.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	resp := &structs.GetDetailedHealthResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, HealthFail, resp.Data.Status)
+	require.Equal(t, 1, len(resp.Data.Services))
+	assert.Equal(t, "not running", resp.Data.Services[0].Error)
+	checks := checksByName(resp)
+	assert.Equal(t, HealthFail, checks[checkServices].Status)
+	assert.Equal(t, "1", checks[checkServices].Value)
+}
+
+func TestParseHealthThresholds(t *testing.T) {
+	thresholds, err := ParseHealthThresholds([]string{"peers_warn=20", " head_lag_fail=16"})
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[string]uint64{"peers_warn": 20, "head_lag_fail": 16}, thresholds)
+
+	_, err = ParseHealthThresholds([]string{"peers_warn"})
+	require.ErrorContains(t, "is not of the form", err)
+	_, err = ParseHealthThresholds([]string{"peers=20"})
+	require.ErrorContains(t, "unknown health threshold", err)
+	_, err = ParseHealthThresholds([]string{"services_fail=1"})
+	require.ErrorContains(t, "unknown health threshold", err)
+	_, err = ParseHealthThresholds([]string{"peers_warn=-1"})
+	require.ErrorContains(t, "invalid value of health threshold", err)
+}
+
+func TestHealthThreshold_Status(t *testing.T) {
+	above := &healthThreshold{warn: 2, fail: 5}
+	assert.Equal(t, HealthPass, above.status(2))
+	assert.Equal(t, HealthWarn, above.status(3))
+	assert.Equal(t, HealthFail, above.status(6))
+	assert.Equal(t, HealthPass, (&healthThreshold{}).status(100))
+
+	below := &healthThreshold{warn: 5, fail: 2, below: true}
+	assert.Equal(t, HealthPass, below.status(5))
+	assert.Equal(t, HealthWarn, below.status(4))
+	assert.Equal(t, HealthFail, below.status(1))
+}
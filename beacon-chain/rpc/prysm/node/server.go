@@ -1,13 +1,21 @@
 package node
 
 import (
+	"reflect"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
 )
 
+// ServiceStatusFetcher returns the status of every service registered in the beacon node.
+type ServiceStatusFetcher interface {
+	Statuses() map[reflect.Type]error
+}
+
 type Server struct {
 	SyncChecker               sync.Checker
 	OptimisticModeFetcher     blockchain.OptimisticModeFetcher
@@ -18,4 +26,8 @@ type Server struct {
 	GenesisTimeFetcher        blockchain.TimeFetcher
 	HeadFetcher               blockchain.HeadFetcher
 	ExecutionChainInfoFetcher execution.ChainInfoFetcher
+	ServiceStatusFetcher      ServiceStatusFetcher
+	BlobStorage               *filesystem.BlobStorage
+	DatabasePath              string
+	HealthThresholds          map[string]uint64
 }
@@ -0,0 +1,216 @@
+package slasher
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// defaultEpochs is the number of epochs of spans and proposals returned when no epoch range is requested.
+	defaultEpochs = 100
+	// maxProposalEpochs is the maximum number of epochs of proposals that can be requested at once.
+	maxProposalEpochs = 256
+	// maxSlashingEpochs is the maximum number of epochs of detected slashings that can be requested at once,
+	// and the number of epochs returned when no epoch range is requested.
+	maxSlashingEpochs = 4096
+)
+
+const errSlasherDisabled = "Slasher is not enabled, use --slasher"
+
+// GetValidatorSpans returns the min and max spans of a validator recorded by the slasher.
+// The spans are kept in a ring of history length epochs ending at the last epoch the slasher wrote for the
+// validator, which lags behind the clock while the slasher catches up. Without an epoch range, the spans of
+// the last 100 epochs up to that epoch are returned, and a requested range is narrowed to the stored epochs.
+func (s *Server) GetValidatorSpans(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetValidatorSpans")
+	defer span.End()
+
+	if s.Slasher == nil {
+		httputil.HandleError(w, errSlasherDisabled, http.StatusServiceUnavailable)
+		return
+	}
+	_, idx, ok := shared.UintFromRoute(w, r, "index")
+	if !ok {
+		return
+	}
+	written, found, err := s.Slasher.LastEpochWritten(ctx, primitives.ValidatorIndex(idx))
+	if err != nil {
+		httputil.HandleError(w, "Could not get last epoch written: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	history := uint64(s.Slasher.HistoryLength())
+	from, to, ok := s.epochRange(w, r, uint64(written), min(defaultEpochs, history))
+	if !ok {
+		return
+	}
+	if !found {
+		httputil.WriteJson(w, &structs.GetSlasherValidatorSpansResponse{Data: []*structs.SlasherEpochSpans{}})
+		return
+	}
+	if uint64(written) >= history {
+		from = max(from, uint64(written)-history+1)
+	}
+	to = min(to, uint64(written))
+	if from > to {
+		httputil.WriteJson(w, &structs.GetSlasherValidatorSpansResponse{Data: []*structs.SlasherEpochSpans{}})
+		return
+	}
+
+	spans, err := s.Slasher.ValidatorSpans(ctx, primitives.ValidatorIndex(idx), primitives.Epoch(from), primitives.Epoch(to), written)
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator spans: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetSlasherValidatorSpansResponse{Data: SpansData(spans)})
+}
+
+// GetValidatorProposals returns the block headers proposed by a validator which are stored by the slasher.
+// Without an epoch range, the proposals of the last 100 epochs are returned.
+func (s *Server) GetValidatorProposals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetValidatorProposals")
+	defer span.End()
+
+	if s.Slasher == nil {
+		httputil.HandleError(w, errSlasherDisabled, http.StatusServiceUnavailable)
+		return
+	}
+	_, idx, ok := shared.UintFromRoute(w, r, "index")
+	if !ok {
+		return
+	}
+	from, to, ok := s.epochRange(w, r, s.currentEpoch(), defaultEpochs)
+	if !ok {
+		return
+	}
+	if to-from >= maxProposalEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Requested epoch range exceeds the limit of %d epochs", maxProposalEpochs), http.StatusBadRequest)
+		return
+	}
+
+	proposals, err := s.Slasher.ValidatorProposalsInEpochs(ctx, primitives.ValidatorIndex(idx), primitives.Epoch(from), primitives.Epoch(to))
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator proposals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetSlasherValidatorProposalsResponse{Data: ProposalsData(proposals)})
+}
+
+// GetDetectedSlashings returns the slashings detected by the slasher, with the conflicting attestations
+// or block headers. Without an epoch range, the slashings of the last 4096 epochs are returned, which is also
+// the longest range that can be requested.
+func (s *Server) GetDetectedSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.GetDetectedSlashings")
+	defer span.End()
+
+	if s.Slasher == nil {
+		httputil.HandleError(w, errSlasherDisabled, http.StatusServiceUnavailable)
+		return
+	}
+	from, to, ok := s.epochRange(w, r, s.currentEpoch(), maxSlashingEpochs)
+	if !ok {
+		return
+	}
+	if to-from >= maxSlashingEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Requested epoch range exceeds the limit of %d epochs", maxSlashingEpochs), http.StatusBadRequest)
+		return
+	}
+
+	detected, err := s.Slasher.DetectedSlashings(ctx, primitives.Epoch(from), primitives.Epoch(to))
+	if err != nil {
+		httputil.HandleError(w, "Could not get detected slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := SlashingsData(detected)
+	if err != nil {
+		httputil.HandleError(w, "Could not convert detected slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.GetDetectedSlashingsResponse{Data: data})
+}
+
+// currentEpoch returns the epoch of the clock.
+func (s *Server) currentEpoch() uint64 {
+	return uint64(slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot()))
+}
+
+// epochRange parses the optional from_epoch and to_epoch query parameters. The range ends at the given
+// epoch by default, and starts defaultEpochs before its end, or at genesis if it is shorter.
+func (s *Server) epochRange(w http.ResponseWriter, r *http.Request, end, defaultEpochs uint64) (uint64, uint64, bool) {
+	rawTo, to, ok := shared.UintFromQuery(w, r, "to_epoch", false)
+	if !ok {
+		return 0, 0, false
+	}
+	if rawTo == "" {
+		to = end
+	}
+	rawFrom, from, ok := shared.UintFromQuery(w, r, "from_epoch", false)
+	if !ok {
+		return 0, 0, false
+	}
+	if rawFrom == "" {
+		from = 0
+		if to >= defaultEpochs {
+			from = to - defaultEpochs + 1
+		}
+	}
+	if from > to {
+		httputil.HandleError(w, "from_epoch must not be greater than to_epoch", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// SpansData converts the spans of a validator to their JSON representation.
+func SpansData(spans []*slashertypes.EpochSpans) []*structs.SlasherEpochSpans {
+	data := make([]*structs.SlasherEpochSpans, len(spans))
+	for i, sp := range spans {
+		data[i] = &structs.SlasherEpochSpans{
+			Epoch:   strconv.FormatUint(uint64(sp.Epoch), 10),
+			MinSpan: strconv.FormatUint(uint64(sp.MinSpan), 10),
+			MaxSpan: strconv.FormatUint(uint64(sp.MaxSpan), 10),
+		}
+	}
+	return data
+}
+
+// ProposalsData converts the proposals of a validator to their JSON representation.
+func ProposalsData(proposals []*slashertypes.SignedBlockHeaderWrapper) []*structs.SlasherProposal {
+	data := make([]*structs.SlasherProposal, len(proposals))
+	for i, p := range proposals {
+		data[i] = &structs.SlasherProposal{
+			HeaderRoot:   hexutil.Encode(p.HeaderRoot[:]),
+			SignedHeader: structs.SignedBeaconBlockHeaderFromConsensus(p.SignedBeaconBlockHeader),
+		}
+	}
+	return data
+}
+
+// SlashingsData converts detected slashings to their JSON representation, grouped by kind.
+func SlashingsData(detected []*slashertypes.DetectedSlashing) (*structs.DetectedSlashings, error) {
+	data := &structs.DetectedSlashings{
+		AttesterSlashings: make([]*structs.DetectedAttesterSlashing, 0),
+		ProposerSlashings: make([]*structs.DetectedProposerSlashing, 0),
+	}
+	for _, d := range detected {
+		if d.ProposerSlashing != nil {
+			data.ProposerSlashings = append(data.ProposerSlashings, structs.DetectedProposerSlashingFromConsensus(d.Epoch, d.ProposerSlashing))
+			continue
+		}
+		slashing, err := structs.DetectedAttesterSlashingFromConsensus(d.Epoch, d.AttesterSlashing)
+		if err != nil {
+			return nil, err
+		}
+		data.AttesterSlashings = append(data.AttesterSlashings, slashing)
+	}
+	return data, nil
+}
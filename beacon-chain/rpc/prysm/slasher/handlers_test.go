@@ -0,0 +1,198 @@
+package slasher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slasherservice "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func header(t *testing.T, slot primitives.Slot, proposer primitives.ValidatorIndex, stateRoot byte) *slashertypes.SignedBlockHeaderWrapper {
+	h := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{Slot: slot, ProposerIndex: proposer, StateRoot: bytesOf(stateRoot)},
+	})
+	root, err := h.Header.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.SignedBlockHeaderWrapper{SignedBeaconBlockHeader: h, HeaderRoot: root}
+}
+
+func bytesOf(b byte) []byte {
+	r := make([]byte, 32)
+	r[0] = b
+	return r
+}
+
+func TestServer_Disabled(t *testing.T) {
+	s := &Server{}
+	for _, h := range []http.HandlerFunc{s.GetValidatorSpans, s.GetValidatorProposals, s.GetDetectedSlashings} {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings", nil)
+		writer := httptest.NewRecorder()
+		h(writer, request)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	}
+}
+
+func TestServer_GetValidatorSpans(t *testing.T) {
+	slasherDB := dbTest.SetupSlasherDB(t)
+	require.NoError(t, slasherDB.SaveLastEpochWrittenForValidators(context.Background(), map[primitives.ValidatorIndex]primitives.Epoch{1: 10}))
+	slot := primitives.Slot(10 * params.BeaconConfig().SlotsPerEpoch)
+	s := &Server{
+		Slasher:            slasherservice.NewQuerier(slasherDB, slasherservice.NewParams(4, 2, 16)),
+		GenesisTimeFetcher: &mockChain.ChainService{Slot: &slot},
+	}
+	spans := func(t *testing.T, s *Server, url string, index string) []*structs.SlasherEpochSpans {
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		request.SetPathValue("index", index)
+		writer := httptest.NewRecorder()
+		s.GetValidatorSpans(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetSlasherValidatorSpansResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp.Data
+	}
+
+	t.Run("default range", func(t *testing.T) {
+		data := spans(t, s, "http://example.com/prysm/v1/slasher/validators/1/spans?from_epoch=8", "1")
+		require.Equal(t, 3, len(data))
+		assert.DeepEqual(t, &structs.SlasherEpochSpans{Epoch: "8", MinSpan: "65535", MaxSpan: "0"}, data[0])
+		assert.Equal(t, "10", data[2].Epoch)
+	})
+	t.Run("slasher behind the clock", func(t *testing.T) {
+		// The clock is at epoch 40 but the slasher only wrote up to epoch 10: the window ends there.
+		ahead := primitives.Slot(40 * params.BeaconConfig().SlotsPerEpoch)
+		s := &Server{Slasher: s.Slasher, GenesisTimeFetcher: &mockChain.ChainService{Slot: &ahead}}
+		data := spans(t, s, "http://example.com/prysm/v1/slasher/validators/1/spans", "1")
+		require.Equal(t, 11, len(data))
+		assert.Equal(t, "0", data[0].Epoch)
+		assert.Equal(t, "10", data[10].Epoch)
+
+		data = spans(t, s, "http://example.com/prysm/v1/slasher/validators/1/spans?from_epoch=9&to_epoch=40", "1")
+		require.Equal(t, 2, len(data))
+		assert.Equal(t, "9", data[0].Epoch)
+		assert.Equal(t, "10", data[1].Epoch)
+	})
+	t.Run("range narrowed to the history", func(t *testing.T) {
+		require.NoError(t, slasherDB.SaveLastEpochWrittenForValidators(context.Background(), map[primitives.ValidatorIndex]primitives.Epoch{2: 20}))
+		data := spans(t, s, "http://example.com/prysm/v1/slasher/validators/2/spans?from_epoch=1&to_epoch=6", "2")
+		require.Equal(t, 2, len(data))
+		assert.Equal(t, "5", data[0].Epoch)
+		assert.Equal(t, "6", data[1].Epoch)
+
+		data = spans(t, s, "http://example.com/prysm/v1/slasher/validators/2/spans?from_epoch=1&to_epoch=4", "2")
+		require.Equal(t, 0, len(data))
+	})
+	t.Run("nothing written", func(t *testing.T) {
+		data := spans(t, s, "http://example.com/prysm/v1/slasher/validators/3/spans", "3")
+		require.Equal(t, 0, len(data))
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/1/spans?from_epoch=5&to_epoch=4", nil)
+		request.SetPathValue("index", "1")
+		writer := httptest.NewRecorder()
+		s.GetValidatorSpans(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
+
+func TestServer_GetValidatorProposals(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbTest.SetupSlasherDB(t)
+	proposal := header(t, 33, 5, 1)
+	require.NoError(t, slasherDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{proposal, header(t, 34, 6, 2)}))
+	slot := primitives.Slot(2 * params.BeaconConfig().SlotsPerEpoch)
+	s := &Server{
+		Slasher:            slasherservice.NewQuerier(slasherDB, slasherservice.DefaultParams()),
+		GenesisTimeFetcher: &mockChain.ChainService{Slot: &slot},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/5/proposals", nil)
+	request.SetPathValue("index", "5")
+	writer := httptest.NewRecorder()
+	s.GetValidatorProposals(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetSlasherValidatorProposalsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, hexutil.Encode(proposal.HeaderRoot[:]), resp.Data[0].HeaderRoot)
+	assert.Equal(t, "33", resp.Data[0].SignedHeader.Message.Slot)
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/5/proposals?from_epoch=0&to_epoch=256", nil)
+	request.SetPathValue("index", "5")
+	writer = httptest.NewRecorder()
+	s.GetValidatorProposals(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestServer_GetDetectedSlashings(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbTest.SetupSlasherDB(t)
+	att := func(source, target primitives.Epoch, root byte) *ethpb.IndexedAttestation {
+		return util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{3},
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: bytesOf(root),
+				Source:          &ethpb.Checkpoint{Epoch: source},
+				Target:          &ethpb.Checkpoint{Epoch: target},
+			},
+		})
+	}
+	require.NoError(t, slasherDB.SaveDetectedSlashings(ctx, []*slashertypes.DetectedSlashing{
+		{Epoch: 2, AttesterSlashing: &ethpb.AttesterSlashing{Attestation_1: att(1, 2, 1), Attestation_2: att(1, 2, 2)}},
+		{Epoch: 5, AttesterSlashing: &ethpb.AttesterSlashing{Attestation_1: att(2, 3, 1), Attestation_2: att(1, 5, 1)}},
+		{Epoch: 1, ProposerSlashing: &ethpb.ProposerSlashing{
+			Header_1: header(t, 33, 5, 1).SignedBeaconBlockHeader,
+			Header_2: header(t, 33, 5, 2).SignedBeaconBlockHeader,
+		}},
+	}))
+	slot := primitives.Slot(10 * params.BeaconConfig().SlotsPerEpoch)
+	s := &Server{
+		Slasher:            slasherservice.NewQuerier(slasherDB, slasherservice.DefaultParams()),
+		GenesisTimeFetcher: &mockChain.ChainService{Slot: &slot},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings", nil)
+	writer := httptest.NewRecorder()
+	s.GetDetectedSlashings(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &structs.GetDetectedSlashingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data.AttesterSlashings))
+	require.Equal(t, 1, len(resp.Data.ProposerSlashings))
+	assert.Equal(t, structs.DoubleVote, resp.Data.AttesterSlashings[0].Kind)
+	assert.Equal(t, "phase0", resp.Data.AttesterSlashings[0].Version)
+	assert.Equal(t, structs.SurroundVote, resp.Data.AttesterSlashings[1].Kind)
+	slashing := &structs.AttesterSlashing{}
+	require.NoError(t, json.Unmarshal(resp.Data.AttesterSlashings[1].Slashing, slashing))
+	assert.Equal(t, "5", slashing.Attestation2.Data.Target.Epoch)
+	assert.Equal(t, "1", resp.Data.ProposerSlashings[0].Epoch)
+	assert.Equal(t, "33", resp.Data.ProposerSlashings[0].Slashing.SignedHeader1.Message.Slot)
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings?from_epoch=3&to_epoch=10", nil)
+	writer = httptest.NewRecorder()
+	s.GetDetectedSlashings(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &structs.GetDetectedSlashingsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data.AttesterSlashings))
+	require.Equal(t, 0, len(resp.Data.ProposerSlashings))
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings?from_epoch=0&to_epoch=4096", nil)
+	writer = httptest.NewRecorder()
+	s.GetDetectedSlashings(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.StringContains(t, "limit of 4096 epochs", writer.Body.String())
+}
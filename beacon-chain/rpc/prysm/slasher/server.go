@@ -0,0 +1,29 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Querier reads the attestation and proposal history recorded by the slasher, and the slashings it detected.
+type Querier interface {
+	HistoryLength() primitives.Epoch
+	LastEpochWritten(ctx context.Context, validatorIndex primitives.ValidatorIndex) (primitives.Epoch, bool, error)
+	ValidatorSpans(
+		ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch, currentEpoch primitives.Epoch,
+	) ([]*slashertypes.EpochSpans, error)
+	ValidatorProposalsInEpochs(
+		ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.SignedBlockHeaderWrapper, error)
+	DetectedSlashings(
+		ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.DetectedSlashing, error)
+}
+
+type Server struct {
+	Slasher            Querier
+	GenesisTimeFetcher blockchain.TimeFetcher
+}
@@ -5,21 +5,26 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/io/logs"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -35,18 +40,22 @@ import (
 // providing RPC endpoints for verifying a beacon node's sync status, genesis and
 // version information, and services the node implements and runs.
 type Server struct {
-	LogsStreamer         logs.Streamer
-	StreamLogsBufferSize int
-	SyncChecker          sync.Checker
-	Server               *grpc.Server
-	BeaconDB             db.ReadOnlyDatabase
-	PeersFetcher         p2p.PeersProvider
-	PeerManager          p2p.PeerManager
-	GenesisTimeFetcher   blockchain.TimeFetcher
-	GenesisFetcher       blockchain.GenesisFetcher
-	POWChainInfoFetcher  execution.ChainInfoFetcher
-	BeaconMonitoringHost string
-	BeaconMonitoringPort int
+	LogsStreamer          logs.Streamer
+	StreamLogsBufferSize  int
+	SyncChecker           chainSync.Checker
+	Server                *grpc.Server
+	BeaconDB              db.ReadOnlyDatabase
+	PeersFetcher          p2p.PeersProvider
+	PeerManager           p2p.PeerManager
+	GenesisTimeFetcher    blockchain.TimeFetcher
+	GenesisFetcher        blockchain.GenesisFetcher
+	POWChainInfoFetcher   execution.ChainInfoFetcher
+	ExecutionEngineCaller execution.EngineCaller
+	BeaconMonitoringHost  string
+	BeaconMonitoringPort  int
+	versionLock           sync.Mutex
+	version               *ethpb.Version
+	versionExpiry         time.Time
 }
 
 // GetHealth checks the health of the node
@@ -109,11 +118,39 @@ func (ns *Server) GetGenesis(ctx context.Context, _ *empty.Empty) (*ethpb.Genesi
 	}, nil
 }
 
-// GetVersion checks the version information of the beacon node.
-func (_ *Server) GetVersion(_ context.Context, _ *empty.Empty) (*ethpb.Version, error) {
-	return &ethpb.Version{
-		Version: version.Version(),
-	}, nil
+// GetVersion checks the version information of the beacon node. The metadata holds the JSON encoded
+// versions of the beacon node and its execution client, in the format of the /eth/v2/node/version endpoint.
+// The execution client version only changes when the client is restarted, so it is cached for an epoch
+// rather than requested from the execution client on every call.
+func (ns *Server) GetVersion(ctx context.Context, _ *empty.Empty) (*ethpb.Version, error) {
+	ns.versionLock.Lock()
+	defer ns.versionLock.Unlock()
+
+	if ns.version != nil && time.Now().Before(ns.versionExpiry) {
+		return ns.version, nil
+	}
+	beaconNode, executionClient := core.ClientVersions(ctx, ns.ExecutionEngineCaller)
+	versions := &structs.VersionV2{BeaconNode: structs.ClientVersionV1FromConsensus(beaconNode)}
+	if executionClient != nil {
+		versions.ExecutionClient = structs.ClientVersionV1FromConsensus(executionClient)
+	}
+	metadata, err := json.Marshal(versions)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not encode client versions: %v", err)
+	}
+	v := &ethpb.Version{
+		Version:  version.Version(),
+		Metadata: string(metadata),
+	}
+	// Without an execution client version, ask again on the next call rather than reporting none for an epoch.
+	if executionClient != nil {
+		ns.version, ns.versionExpiry = v, time.Now().Add(epochDuration())
+	}
+	return v, nil
+}
+
+func epochDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second
 }
 
 // ListImplementedServices lists the services implemented and enabled by this node.
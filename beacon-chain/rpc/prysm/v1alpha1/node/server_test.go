@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -9,13 +10,16 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	dbutil "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	mockExecution "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	mockP2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -77,6 +81,64 @@ func TestNodeServer_GetVersion(t *testing.T) {
 	res, err := ns.GetVersion(context.Background(), &emptypb.Empty{})
 	require.NoError(t, err)
 	assert.Equal(t, v, res.Version)
+
+	versions := &structs.VersionV2{}
+	require.NoError(t, json.Unmarshal([]byte(res.Metadata), versions))
+	require.NotNil(t, versions.BeaconNode)
+	assert.Equal(t, "PM", versions.BeaconNode.Code)
+	assert.Equal(t, version.SemanticVersion(), versions.BeaconNode.Version)
+	assert.Equal(t, (*structs.ClientVersionV1)(nil), versions.ExecutionClient)
+}
+
+func TestNodeServer_GetVersion_ExecutionClient(t *testing.T) {
+	ns := &Server{
+		ExecutionEngineCaller: &mockExecution.EngineClient{
+			ClientVersion: []*enginev1.ClientVersionV1{{Code: "GE", Name: "Geth", Version: "1.14.0", Commit: "0xfa4ff922"}},
+		},
+	}
+	res, err := ns.GetVersion(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+
+	versions := &structs.VersionV2{}
+	require.NoError(t, json.Unmarshal([]byte(res.Metadata), versions))
+	require.NotNil(t, versions.ExecutionClient)
+	assert.Equal(t, "GE", versions.ExecutionClient.Code)
+	assert.Equal(t, "Geth", versions.ExecutionClient.Name)
+	assert.Equal(t, "1.14.0", versions.ExecutionClient.Version)
+	assert.Equal(t, "0xfa4ff922", versions.ExecutionClient.Commit)
+}
+
+func TestNodeServer_GetVersion_CachesExecutionClientVersion(t *testing.T) {
+	engine := &mockExecution.EngineClient{ErrClientVersion: errors.New("connection refused")}
+	ns := &Server{ExecutionEngineCaller: engine}
+
+	// A version without the execution client is not cached.
+	res, err := ns.GetVersion(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	versions := &structs.VersionV2{}
+	require.NoError(t, json.Unmarshal([]byte(res.Metadata), versions))
+	assert.Equal(t, (*structs.ClientVersionV1)(nil), versions.ExecutionClient)
+
+	engine.ErrClientVersion = nil
+	engine.ClientVersion = []*enginev1.ClientVersionV1{{Code: "GE", Name: "Geth", Version: "1.14.0", Commit: "0xfa4ff922"}}
+	res, err = ns.GetVersion(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(res.Metadata), versions))
+	require.NotNil(t, versions.ExecutionClient)
+	assert.Equal(t, "1.14.0", versions.ExecutionClient.Version)
+
+	// Within the epoch, the execution client is not asked again.
+	engine.ClientVersion = []*enginev1.ClientVersionV1{{Code: "GE", Name: "Geth", Version: "1.14.1", Commit: "0xaa4ff922"}}
+	res, err = ns.GetVersion(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(res.Metadata), versions))
+	assert.Equal(t, "1.14.0", versions.ExecutionClient.Version)
+
+	ns.versionExpiry = time.Now()
+	res, err = ns.GetVersion(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(res.Metadata), versions))
+	assert.Equal(t, "1.14.1", versions.ExecutionClient.Version)
 }
 
 func TestNodeServer_GetImplementedServices(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 	blockfeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
@@ -98,7 +99,7 @@ func (vs *Server) GetBeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (
 		builderBoostFactor = primitives.Gwei(req.BuilderBoostFactor.Value)
 	}
 
-	resp, err := vs.BuildBlockParallel(ctx, sBlk, head, req.SkipMevBoost, builderBoostFactor)
+	resp, err := vs.BuildBlockParallel(ctx, sBlk, head, req.SkipMevBoost, builderBoostFactor, builderBidLimitsFromRequest(req))
 	log.WithFields(logrus.Fields{
 		"slot":               req.Slot,
 		"sinceSlotStartTime": time.Since(t),
@@ -178,7 +179,7 @@ func (vs *Server) getParentState(ctx context.Context, slot primitives.Slot) (sta
 	return head, parentRoot, err
 }
 
-func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState, skipMevBoost bool, builderBoostFactor primitives.Gwei) (*ethpb.GenericBeaconBlock, error) {
+func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.SignedBeaconBlock, head state.BeaconState, skipMevBoost bool, builderBoostFactor primitives.Gwei, limits *builderBidLimits) (*ethpb.GenericBeaconBlock, error) {
 	// Build consensus fields in background
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -236,12 +237,21 @@ func (vs *Server) BuildBlockParallel(ctx context.Context, sBlk interfaces.Signed
 		// There's no reason to try to get a builder bid if local override is true.
 		var builderBid builderapi.Bid
 		if !(local.OverrideBuilder || skipMevBoost) {
-			builderBid, err = vs.getBuilderPayloadAndBlobs(ctx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+			builderCtx, cancel := limits.context(ctx)
+			builderBid, err = vs.getBuilderPayloadAndBlobs(builderCtx, sBlk.Block().Slot(), sBlk.Block().ProposerIndex())
+			cancel()
 			if err != nil {
 				builderGetPayloadMissCount.Inc()
 				log.WithError(err).Error("Could not get builder payload")
 			}
 		}
+		if builderBid != nil && !builderBid.IsNil() {
+			if err := limits.check(primitives.WeiToGwei(local.Bid), primitives.WeiToGwei(builderBid.Value())); err != nil {
+				builderBidRejectedCount.Inc()
+				log.WithError(err).Warn("Proposer: using local execution payload because the builder bid was rejected by the proposer settings")
+				builderBid = nil
+			}
+		}
 
 		winningBid, bundle, err = setExecutionData(ctx, sBlk, local, builderBid, builderBoostFactor)
 		if err != nil {
@@ -302,7 +312,12 @@ func (vs *Server) ProposeBeaconBlock(ctx context.Context, req *ethpb.GenericSign
 		errChan <- nil
 	}()
 
-	if err := vs.broadcastAndReceiveBlobs(ctx, sidecars, root); err != nil {
+	// Once PeerDAS is active the blobs are only distributed as data columns, blob sidecars are no longer gossiped.
+	if peerdas.IsActive(block.Block().Slot()) {
+		if err := vs.broadcastAndReceiveDataColumns(ctx, block, sidecars, root); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast/receive data columns: %v", err)
+		}
+	} else if err := vs.broadcastAndReceiveBlobs(ctx, sidecars, root); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not broadcast/receive blobs: %v", err)
 	}
 
@@ -399,6 +414,42 @@ func (vs *Server) broadcastAndReceiveBlobs(ctx context.Context, sidecars []*ethp
 	return eg.Wait()
 }
 
+// broadcastAndReceiveDataColumns builds the data column sidecars from the blobs of the block, then handles their
+// broadcasting and reception.
+func (vs *Server) broadcastAndReceiveDataColumns(ctx context.Context, block interfaces.SignedBeaconBlock, blobSidecars []*ethpb.BlobSidecar, root [32]byte) error {
+	if len(blobSidecars) == 0 {
+		return nil
+	}
+	blobs := make([][]byte, 0, len(blobSidecars))
+	for _, sc := range blobSidecars {
+		blobs = append(blobs, sc.Blob)
+	}
+	sidecars, err := peerdas.DataColumnSidecars(block, blobs)
+	if err != nil {
+		return errors.Wrap(err, "data column sidecars")
+	}
+
+	eg, eCtx := errgroup.WithContext(ctx)
+	for _, sc := range sidecars {
+		sCar := sc
+		eg.Go(func() error {
+			subnet := peerdas.ComputeSubnetForDataColumnSidecar(sCar.Index)
+			if err := vs.P2P.BroadcastDataColumn(eCtx, subnet, sCar); err != nil {
+				return errors.Wrap(err, "broadcast data column failed")
+			}
+			roColumn, err := blocks.NewRODataColumnWithRoot(sCar, root)
+			if err != nil {
+				return errors.Wrap(err, "RODataColumn creation failed")
+			}
+			if err := vs.DataColumnReceiver.ReceiveDataColumn(ctx, blocks.NewVerifiedRODataColumn(roColumn)); err != nil {
+				return errors.Wrap(err, "receive data column failed")
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
 // PrepareBeaconProposer caches and updates the fee recipient for the given proposer.
 func (vs *Server) PrepareBeaconProposer(
 	_ context.Context, request *ethpb.PrepareBeaconProposerRequest,
@@ -42,6 +42,10 @@ var (
 		Name: "builder_get_payload_miss_count",
 		Help: "The number of get payload misses for validator requests to builder",
 	})
+	builderBidRejectedCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "builder_bid_rejected_count",
+		Help: "The number of builder bids rejected by the builder bid limits of the proposer",
+	})
 )
 
 // emptyTransactionsRoot represents the returned value of ssz.TransactionsRoot([][]byte{}) and
@@ -2,14 +2,17 @@ package validator
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/sirupsen/logrus"
 )
 
@@ -98,3 +101,51 @@ func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
 
 	return false, nil
 }
+
+// builderBidLimits is the circuit breaker which a proposer puts on the builder bids for its block.
+// Zero values and an empty relay list disable the respective limit.
+type builderBidLimits struct {
+	minBid        primitives.Gwei
+	maxDifference primitives.Gwei
+	relays        []string
+	timeout       time.Duration
+}
+
+// builderBidLimitsFromRequest returns the builder bid limits of the block request.
+func builderBidLimitsFromRequest(req *ethpb.BlockRequest) *builderBidLimits {
+	return &builderBidLimits{
+		minBid:        req.BuilderMinBidGwei,
+		maxDifference: req.BuilderMaxBidDifferenceGwei,
+		relays:        req.BuilderRelays,
+		timeout:       time.Duration(req.BuilderTimeoutMs) * time.Millisecond,
+	}
+}
+
+// context returns a copy of the context which only requests bids from the allowed relays, and which is
+// cancelled once the timeout of the proposer has passed so that the local payload is used instead.
+func (l *builderBidLimits) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l == nil {
+		return context.WithCancel(ctx)
+	}
+	ctx = builder.WithAllowedRelays(ctx, l.relays)
+	if l.timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, l.timeout)
+}
+
+// check returns an error if the builder bid is below the minimum bid of the proposer, or exceeds the
+// value of the local payload by more than the maximum difference allowed by the proposer.
+func (l *builderBidLimits) check(localValue, builderValue primitives.Gwei) error {
+	if l == nil {
+		return nil
+	}
+	if builderValue < l.minBid {
+		return errors.Errorf("builder bid of %d gwei is below the minimum bid of %d gwei", builderValue, l.minBid)
+	}
+	if l.maxDifference > 0 && builderValue > localValue && builderValue-localValue > l.maxDifference {
+		return errors.Errorf("builder bid of %d gwei exceeds the local payload value of %d gwei by more than %d gwei",
+			builderValue, localValue, l.maxDifference)
+	}
+	return nil
+}
@@ -73,6 +73,37 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 	require.Equal(t, false, b)
 }
 
+func TestBuilderBidLimits_check(t *testing.T) {
+	limits := builderBidLimitsFromRequest(&ethpb.BlockRequest{
+		BuilderMinBidGwei:           100,
+		BuilderMaxBidDifferenceGwei: 1000,
+	})
+	require.NoError(t, limits.check(50, 150))
+	require.NoError(t, limits.check(500, 1500))
+	require.NoError(t, limits.check(2000, 1500))
+	require.ErrorContains(t, "below the minimum bid of 100 gwei", limits.check(50, 99))
+	require.ErrorContains(t, "by more than 1000 gwei", limits.check(500, 1501))
+
+	// Without limits, every bid is accepted.
+	require.NoError(t, builderBidLimitsFromRequest(&ethpb.BlockRequest{}).check(0, 1<<40))
+	var noLimits *builderBidLimits
+	require.NoError(t, noLimits.check(0, 1<<40))
+}
+
+func TestBuilderBidLimits_context(t *testing.T) {
+	limits := builderBidLimitsFromRequest(&ethpb.BlockRequest{BuilderTimeoutMs: 200})
+	ctx, cancel := limits.context(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.Equal(t, true, ok)
+	require.Equal(t, true, time.Until(deadline) <= 200*time.Millisecond)
+
+	ctx, cancel = builderBidLimitsFromRequest(&ethpb.BlockRequest{}).context(context.Background())
+	defer cancel()
+	_, ok = ctx.Deadline()
+	require.Equal(t, false, ok)
+}
+
 func TestServer_validatorRegistered(t *testing.T) {
 	b, err := builder.NewService(context.Background())
 	require.NoError(t, err)
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+)
+
+// maxRewardsRecords is the maximum number of validator epochs that can be requested at once.
+const maxRewardsRecords = 100000
+
+// GetRewards returns the indexed rewards and penalties of the requested validators for a range of epochs.
+// Rewards are only available for the epochs indexed by the rewards indexer, enabled with --enable-rewards-indexer.
+func (s *Server) GetRewards(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.GetRewards")
+	defer span.End()
+
+	_, from, ok := shared.UintFromQuery(w, r, "from_epoch", true)
+	if !ok {
+		return
+	}
+	_, to, ok := shared.UintFromQuery(w, r, "to_epoch", true)
+	if !ok {
+		return
+	}
+	if from > to {
+		httputil.HandleError(w, "from_epoch must not be greater than to_epoch", http.StatusBadRequest)
+		return
+	}
+	rawIndices := r.URL.Query()["indices"]
+	if len(rawIndices) == 0 {
+		httputil.HandleError(w, "indices is required", http.StatusBadRequest)
+		return
+	}
+	// to-from+1 overflows for the full epoch range, so the range is checked on its own before dividing.
+	if to-from >= maxRewardsRecords || uint64(len(rawIndices)) > maxRewardsRecords/(to-from+1) {
+		httputil.HandleError(
+			w,
+			fmt.Sprintf("Requested validators and epochs exceed the limit of %d records", maxRewardsRecords),
+			http.StatusBadRequest,
+		)
+		return
+	}
+	indices := make([]primitives.ValidatorIndex, len(rawIndices))
+	for i, raw := range rawIndices {
+		idx, ok := shared.ValidateUint(w, "indices", raw)
+		if !ok {
+			return
+		}
+		indices[i] = primitives.ValidatorIndex(idx)
+	}
+
+	last, err := s.BeaconDB.LastIndexedRewardsEpoch(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			httputil.HandleError(w, "No validator rewards have been indexed", http.StatusNotFound)
+			return
+		}
+		httputil.HandleError(w, "Could not get last indexed rewards epoch: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rewards, err := s.BeaconDB.ValidatorRewards(ctx, indices, primitives.Epoch(from), primitives.Epoch(to))
+	if err != nil {
+		httputil.HandleError(w, "Could not get validator rewards: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := make([]*structs.ValidatorEpochRewards, len(rewards))
+	for i, r := range rewards {
+		data[i] = &structs.ValidatorEpochRewards{
+			ValidatorIndex: strconv.FormatUint(uint64(r.ValidatorIndex), 10),
+			Epoch:          strconv.FormatUint(uint64(r.Epoch), 10),
+			Source:         strconv.FormatInt(r.Source, 10),
+			Target:         strconv.FormatInt(r.Target, 10),
+			Head:           strconv.FormatInt(r.Head, 10),
+			Inactivity:     strconv.FormatInt(r.Inactivity, 10),
+			SyncCommittee:  strconv.FormatInt(r.SyncCommittee, 10),
+			Proposer:       strconv.FormatInt(r.Proposer, 10),
+			Total:          strconv.FormatInt(r.Total(), 10),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetValidatorRewardsResponse{
+		LastIndexedEpoch: strconv.FormatUint(uint64(last), 10),
+		Data:             data,
+	})
+}
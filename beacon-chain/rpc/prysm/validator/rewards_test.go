@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	rewardstypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/rewards/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestServer_GetRewards(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+
+	t.Run("not indexed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/rewards?from_epoch=1&to_epoch=2&indices=1", nil)
+		writer := httptest.NewRecorder()
+		s.GetRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+
+	for epoch := primitives.Epoch(1); epoch <= 3; epoch++ {
+		require.NoError(t, beaconDB.SaveValidatorRewards(ctx, epoch, []*rewardstypes.ValidatorEpochRewards{
+			{ValidatorIndex: 1, Epoch: epoch, Source: 100, Target: 200, Head: 50, Proposer: 1000},
+			{ValidatorIndex: 2, Epoch: epoch, Source: -100, Target: -200, SyncCommittee: -20},
+		}))
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/rewards?from_epoch=2&to_epoch=5&indices=2&indices=1", nil)
+		writer := httptest.NewRecorder()
+		s.GetRewards(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "3", resp.LastIndexedEpoch)
+		require.Equal(t, 4, len(resp.Data))
+		assert.DeepEqual(t, &structs.ValidatorEpochRewards{
+			ValidatorIndex: "1",
+			Epoch:          "2",
+			Source:         "100",
+			Target:         "200",
+			Head:           "50",
+			Inactivity:     "0",
+			SyncCommittee:  "0",
+			Proposer:       "1000",
+			Total:          "1350",
+		}, resp.Data[0])
+		assert.Equal(t, "2", resp.Data[2].ValidatorIndex)
+		assert.Equal(t, "-320", resp.Data[3].Total)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/rewards?from_epoch=3&to_epoch=2&indices=1", nil)
+		writer := httptest.NewRecorder()
+		s.GetRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("no indices", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/rewards?from_epoch=1&to_epoch=2", nil)
+		writer := httptest.NewRecorder()
+		s.GetRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("too many records", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/rewards?from_epoch=0&to_epoch=100000&indices=1&indices=2", nil)
+		writer := httptest.NewRecorder()
+		s.GetRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "exceed the limit", writer.Body.String())
+	})
+	t.Run("full epoch range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/validators/rewards?from_epoch=0&to_epoch=18446744073709551615&indices=1", nil)
+		writer := httptest.NewRecorder()
+		s.GetRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "exceed the limit", writer.Body.String())
+	})
+}
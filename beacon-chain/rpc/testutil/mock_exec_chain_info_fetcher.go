@@ -2,12 +2,14 @@ package testutil
 
 import (
 	"math/big"
+	"time"
 )
 
 // MockExecutionChainInfoFetcher is a fake implementation of the powchain.ChainInfoFetcher
 type MockExecutionChainInfoFetcher struct {
-	CurrEndpoint string
-	CurrError    error
+	CurrEndpoint     string
+	CurrError        error
+	LastValidPayload time.Time
 }
 
 func (*MockExecutionChainInfoFetcher) GenesisExecutionChainInfo() (uint64, *big.Int) {
@@ -25,3 +27,7 @@ func (m *MockExecutionChainInfoFetcher) ExecutionClientEndpoint() string {
 func (m *MockExecutionChainInfoFetcher) ExecutionClientConnectionErr() error {
 	return m.CurrError
 }
+
+func (m *MockExecutionChainInfoFetcher) LastValidPayloadTime() time.Time {
+	return m.LastValidPayload
+}
@@ -5,9 +5,11 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
 // Verifies attester slashings, logs them, and submits them to the slashing operations pool
@@ -56,6 +58,16 @@ func (s *Service) processAttesterSlashings(
 		processedSlashings[root] = slashing
 	}
 
+	detected := make([]*slashertypes.DetectedSlashing, 0, len(processedSlashings))
+	for _, slashing := range processedSlashings {
+		epoch := slashing.FirstAttestation().GetData().Target.Epoch
+		if second := slashing.SecondAttestation().GetData().Target.Epoch; second > epoch {
+			epoch = second
+		}
+		detected = append(detected, &slashertypes.DetectedSlashing{Epoch: epoch, AttesterSlashing: slashing})
+	}
+	s.saveDetectedSlashings(ctx, detected)
+
 	return processedSlashings, nil
 }
 
@@ -73,6 +85,7 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		return err
 	}
 
+	detected := make([]*slashertypes.DetectedSlashing, 0, len(slashings))
 	for _, slashing := range slashings {
 		// Verify the signature of the first block.
 		if err := s.verifyBlockSignature(ctx, slashing.Header_1); err != nil {
@@ -97,11 +110,28 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, slashing); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
+
+		detected = append(detected, &slashertypes.DetectedSlashing{
+			Epoch:            slots.ToEpoch(slashing.Header_1.Header.Slot),
+			ProposerSlashing: slashing,
+		})
 	}
+	s.saveDetectedSlashings(ctx, detected)
 
 	return nil
 }
 
+// Saves the verified slashings to the slasher database, so that they can be queried later on.
+// A failure to save them does not prevent them from being included on chain.
+func (s *Service) saveDetectedSlashings(ctx context.Context, slashings []*slashertypes.DetectedSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	if err := s.serviceCfg.Database.SaveDetectedSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error("Could not save detected slashings")
+	}
+}
+
 func (s *Service) verifyBlockSignature(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error {
 	parentState, err := s.serviceCfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(header.Header.ParentRoot))
 	if err != nil {
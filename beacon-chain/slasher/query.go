@@ -0,0 +1,161 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Querier reads the attestation and proposal history recorded by the slasher, and the slashings it detected.
+type Querier struct {
+	s *Service
+}
+
+// NewQuerier returns a querier over a slasher database, for example one opened by an offline tool.
+func NewQuerier(database db.SlasherDatabase, params *Parameters) *Querier {
+	return &Querier{s: &Service{params: params, serviceCfg: &ServiceConfig{Database: database}}}
+}
+
+// Querier returns a querier over the database of the slasher service.
+func (s *Service) Querier() *Querier {
+	return &Querier{s: s}
+}
+
+// HistoryLength is the number of epochs of spans kept by the slasher.
+func (q *Querier) HistoryLength() primitives.Epoch {
+	return q.s.params.historyLength
+}
+
+// LastEpochWritten returns the last epoch the slasher wrote the spans of a validator for, which ends the
+// ring of history length epochs of its spans. It returns false if no spans were written for the validator.
+func (q *Querier) LastEpochWritten(ctx context.Context, validatorIndex primitives.ValidatorIndex) (primitives.Epoch, bool, error) {
+	written, err := q.s.serviceCfg.Database.LastEpochWrittenForValidators(ctx, []primitives.ValidatorIndex{validatorIndex})
+	if err != nil {
+		return 0, false, errors.Wrap(err, "could not get last epoch written")
+	}
+	if len(written) == 0 {
+		return 0, false, nil
+	}
+	return written[0].Epoch, true, nil
+}
+
+// ValidatorSpans returns the min and max spans of a validator between the start and end epochs, inclusive.
+// Spans are stored in a ring of history length epochs ending at the current epoch, in which older epochs
+// are overwritten by newer ones, so the range must be within the last history length epochs.
+func (q *Querier) ValidatorSpans(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch, currentEpoch primitives.Epoch,
+) ([]*slashertypes.EpochSpans, error) {
+	ctx, span := trace.StartSpan(ctx, "Slasher.ValidatorSpans")
+	defer span.End()
+
+	if startEpoch > endEpoch {
+		return nil, fmt.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+	if endEpoch > currentEpoch {
+		return nil, fmt.Errorf("end epoch %d is after the current epoch %d", endEpoch, currentEpoch)
+	}
+	if startEpoch+q.s.params.historyLength <= currentEpoch {
+		return nil, fmt.Errorf(
+			"start epoch %d is outside the slasher history length %d before the current epoch %d",
+			startEpoch, q.s.params.historyLength, currentEpoch,
+		)
+	}
+
+	// Collect the chunk indices of the epochs, in order and without duplicates.
+	chunkIndexes := make([]uint64, 0)
+	seen := make(map[uint64]bool)
+	numEpochs := uint64(endEpoch-startEpoch) + 1
+	for i := uint64(0); i < numEpochs; i++ {
+		chunkIndex := q.s.params.chunkIndex(startEpoch + primitives.Epoch(i))
+		if !seen[chunkIndex] {
+			seen[chunkIndex] = true
+			chunkIndexes = append(chunkIndexes, chunkIndex)
+		}
+	}
+
+	validatorChunkIndex := q.s.params.validatorChunkIndex(validatorIndex)
+	minChunks, err := q.s.loadChunksFromDisk(ctx, validatorChunkIndex, slashertypes.MinSpan, chunkIndexes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load min span chunks")
+	}
+	maxChunks, err := q.s.loadChunksFromDisk(ctx, validatorChunkIndex, slashertypes.MaxSpan, chunkIndexes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load max span chunks")
+	}
+
+	spans := make([]*slashertypes.EpochSpans, 0, numEpochs)
+	for i := uint64(0); i < numEpochs; i++ {
+		epoch := startEpoch + primitives.Epoch(i)
+		chunkIndex := q.s.params.chunkIndex(epoch)
+		cellIndex := q.s.params.cellIndex(validatorIndex, epoch)
+		minChunk, maxChunk := minChunks[chunkIndex].Chunk(), maxChunks[chunkIndex].Chunk()
+		if cellIndex >= uint64(len(minChunk)) || cellIndex >= uint64(len(maxChunk)) {
+			return nil, fmt.Errorf("cell index %d out of bounds", cellIndex)
+		}
+		spans = append(spans, &slashertypes.EpochSpans{
+			Epoch:   epoch,
+			MinSpan: minChunk[cellIndex],
+			MaxSpan: maxChunk[cellIndex],
+		})
+	}
+	return spans, nil
+}
+
+// ValidatorProposals returns the block headers proposed by a validator between the start and
+// end slots, inclusive, which are still stored by the slasher.
+func (q *Querier) ValidatorProposals(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex, startSlot, endSlot primitives.Slot,
+) ([]*slashertypes.SignedBlockHeaderWrapper, error) {
+	ctx, span := trace.StartSpan(ctx, "Slasher.ValidatorProposals")
+	defer span.End()
+
+	if startSlot > endSlot {
+		return nil, fmt.Errorf("start slot %d is greater than end slot %d", startSlot, endSlot)
+	}
+
+	proposals := make([]*slashertypes.SignedBlockHeaderWrapper, 0)
+	for i := uint64(0); i <= uint64(endSlot-startSlot); i++ {
+		slot := startSlot + primitives.Slot(i)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		proposal, err := q.s.serviceCfg.Database.BlockProposalForValidator(ctx, validatorIndex, slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get proposal at slot %d", slot)
+		}
+		if proposal != nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, nil
+}
+
+// ValidatorProposalsInEpochs returns the block headers proposed by a validator between the start
+// and end epochs, inclusive, which are still stored by the slasher.
+func (q *Querier) ValidatorProposalsInEpochs(
+	ctx context.Context, validatorIndex primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.SignedBlockHeaderWrapper, error) {
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		return nil, err
+	}
+	endSlot, err := slots.EpochEnd(endEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return q.ValidatorProposals(ctx, validatorIndex, startSlot, endSlot)
+}
+
+// DetectedSlashings returns the slashings detected by the slasher for offenses between the start
+// and end epochs, inclusive.
+func (q *Querier) DetectedSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.DetectedSlashing, error) {
+	return q.s.serviceCfg.Database.DetectedSlashings(ctx, startEpoch, endEpoch)
+}
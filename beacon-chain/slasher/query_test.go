@@ -0,0 +1,93 @@
+package slasher
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestQuerier_ValidatorSpans(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	s := &Service{
+		params:                         NewParams(4, 2, 16),
+		serviceCfg:                     &ServiceConfig{Database: slasherDB},
+		latestEpochUpdatedForValidator: make(map[primitives.ValidatorIndex]primitives.Epoch),
+	}
+
+	att := createAttestationWrapperEmptySig(t, 1, 3, []uint64{1}, nil)
+	_, err := s.checkSlashableAttestations(ctx, 4, []*slashertypes.IndexedAttestationWrapper{att})
+	require.NoError(t, err)
+
+	q := s.Querier()
+	spans, err := q.ValidatorSpans(ctx, 1, 0, 4, 4)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.EpochSpans{
+		{Epoch: 0, MinSpan: 3, MaxSpan: 0},
+		{Epoch: 1, MinSpan: math.MaxUint16, MaxSpan: 0},
+		{Epoch: 2, MinSpan: math.MaxUint16, MaxSpan: 1},
+		{Epoch: 3, MinSpan: math.MaxUint16, MaxSpan: 0},
+		{Epoch: 4, MinSpan: math.MaxUint16, MaxSpan: 0},
+	}, spans)
+
+	// Another validator of the same chunk has no spans.
+	spans, err = q.ValidatorSpans(ctx, 0, 0, 0, 4)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.EpochSpans{{Epoch: 0, MinSpan: math.MaxUint16, MaxSpan: 0}}, spans)
+
+	// The spans of epoch 0 were overwritten by those of epoch 16.
+	_, err = q.ValidatorSpans(ctx, 1, 0, 16, 16)
+	require.ErrorContains(t, "start epoch 0 is outside the slasher history length 16", err)
+	_, err = q.ValidatorSpans(ctx, 1, 0, 5, 4)
+	require.ErrorContains(t, "end epoch 5 is after the current epoch 4", err)
+	_, err = q.ValidatorSpans(ctx, 1, 2, 1, 4)
+	require.ErrorContains(t, "start epoch 2 is greater than end epoch 1", err)
+}
+
+func TestQuerier_ValidatorProposals(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	require.NoError(t, slasherDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 3, 1, []byte{1}),
+		createProposalWrapper(t, 40, 1, []byte{2}),
+		createProposalWrapper(t, 41, 2, []byte{3}),
+	}))
+
+	q := NewQuerier(slasherDB, DefaultParams())
+	proposals, err := q.ValidatorProposals(ctx, 1, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(proposals))
+	require.Equal(t, primitives.Slot(3), proposals[0].SignedBeaconBlockHeader.Header.Slot)
+	require.Equal(t, primitives.Slot(40), proposals[1].SignedBeaconBlockHeader.Header.Slot)
+
+	proposals, err = q.ValidatorProposalsInEpochs(ctx, 1, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(proposals))
+	require.Equal(t, primitives.Slot(40), proposals[0].SignedBeaconBlockHeader.Header.Slot)
+}
+
+func TestQuerier_DetectedSlashings(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 40, 1, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 40, 1, []byte{2}).SignedBeaconBlockHeader,
+	}
+	s := &Service{serviceCfg: &ServiceConfig{Database: slasherDB}}
+	s.saveDetectedSlashings(ctx, []*slashertypes.DetectedSlashing{{Epoch: 1, ProposerSlashing: slashing}})
+
+	slashings, err := s.Querier().DetectedSlashings(ctx, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	require.DeepEqual(t, slashing, slashings[0].ProposerSlashing)
+
+	slashings, err = s.Querier().DetectedSlashings(ctx, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+}
@@ -70,3 +70,23 @@ type AttestedEpochForValidator struct {
 	ValidatorIndex primitives.ValidatorIndex
 	Epoch          primitives.Epoch
 }
+
+// DetectedSlashing is a slashing detected by the slasher, either an attester or a proposer slashing,
+// stored with the epoch of the offense. For attester slashings, it is the highest target epoch of the
+// two attestations. For proposer slashings, it is the epoch of the proposals.
+type DetectedSlashing struct {
+	Epoch            primitives.Epoch
+	AttesterSlashing ethpb.AttSlashing
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
+// EpochSpans are the min and max spans of a validator at an epoch, as stored by the slasher.
+// The min span is the smallest distance between the epoch and the target of an attestation
+// of the validator whose source is after the epoch, MaxUint16 if there is none. The max span
+// is the largest distance between the epoch and the target of an attestation of the validator
+// whose source is before the epoch, 0 if there is none.
+type EpochSpans struct {
+	Epoch   primitives.Epoch
+	MinSpan uint16
+	MaxSpan uint16
+}
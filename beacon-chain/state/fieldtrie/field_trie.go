@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stateutil"
 	multi_value_slice "github.com/prysmaticlabs/prysm/v5/container/multi-value-slice"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/multiproof"
 	pmath "github.com/prysmaticlabs/prysm/v5/math"
 )
 
@@ -255,6 +256,25 @@ func (f *FieldTrie) TrieRoot() ([32]byte, error) {
 	}
 }
 
+// ProofNode returns the root node of the trie for building Merkle proofs, with the length mixed in for list fields.
+// The expand function is used to descend below the leaves of the trie into the elements of composite arrays.
+// Callers must hold the trie's read lock for as long as the node is in use.
+func (f *FieldTrie) ProofNode(expand multiproof.ExpandFunc) (multiproof.Node, error) {
+	if f.Empty() {
+		return nil, ErrEmptyFieldTrie
+	}
+	switch f.dataType {
+	case types.BasicArray:
+		return multiproof.FromLayers(f.fieldLayers, nil), nil
+	case types.CompositeArray:
+		return multiproof.MixIn(multiproof.FromLayers(f.fieldLayers, expand), uint64(len(f.fieldLayers[0]))), nil
+	case types.CompressedArray:
+		return multiproof.MixIn(multiproof.FromLayers(f.fieldLayers, nil), uint64(f.numOfElems)), nil
+	default:
+		return nil, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(f.dataType).Name())
+	}
+}
+
 // FieldReference returns the underlying field reference
 // object for the trie.
 func (f *FieldTrie) FieldReference() *stateutil.Reference {
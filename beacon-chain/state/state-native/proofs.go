@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/binary"
 
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/fieldtrie"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native/types"
 	"github.com/prysmaticlabs/prysm/v5/container/trie"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/multiproof"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 )
 
@@ -106,3 +109,68 @@ func (b *BeaconState) FinalizedRootProof(ctx context.Context) ([][]byte, error)
 	proof = append(proof, branch...)
 	return proof, nil
 }
+
+// GeneralizedIndex of the node at the given path of the beacon state, as described in multiproof.GeneralizedIndex.
+func (b *BeaconState) GeneralizedIndex(path string) (uint64, error) {
+	var empty interface{}
+	switch b.version {
+	case version.Phase0:
+		empty = &ethpb.BeaconState{}
+	case version.Altair:
+		empty = &ethpb.BeaconStateAltair{}
+	case version.Bellatrix:
+		empty = &ethpb.BeaconStateBellatrix{}
+	case version.Capella:
+		empty = &ethpb.BeaconStateCapella{}
+	case version.Deneb:
+		empty = &ethpb.BeaconStateDeneb{}
+	case version.Electra:
+		empty = &ethpb.BeaconStateElectra{}
+	default:
+		return 0, errNotSupported("GeneralizedIndex", b.version)
+	}
+	return multiproof.GeneralizedIndex(empty, path)
+}
+
+// Multiproof crafts a Merkle multiproof of the nodes at the given generalized indices
+// of the state's Merkle trie representation. The field tries of the state are used for
+// the fields which have them, so that large fields such as the validator registry
+// don't need to be hashed again.
+func (b *BeaconState) Multiproof(ctx context.Context, gindices []uint64) (*multiproof.Multiproof, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.initializeMerkleLayers(ctx); err != nil {
+		return nil, err
+	}
+	if err := b.recomputeDirtyFields(ctx); err != nil {
+		return nil, err
+	}
+
+	// Field tries may be shared with other states, so they
+	// are read locked until the proof has been crafted.
+	tries := make(map[int]*fieldtrie.FieldTrie)
+	for field, fTrie := range b.stateFieldLeaves {
+		if _, ok := fieldMap[field]; !ok || b.rebuildTrie[field] {
+			continue
+		}
+		fTrieMutex := fTrie.RWMutex
+		fTrieMutex.RLock()
+		defer fTrieMutex.RUnlock()
+		if !fTrie.Empty() {
+			tries[field.RealPosition()] = fTrie
+		}
+	}
+
+	st := b.ToProtoUnsafe()
+	root := multiproof.FromByteLayers(b.merkleLayers, func(position uint64) (multiproof.Node, error) {
+		pos := int(position)
+		if fTrie, ok := tries[pos]; ok {
+			return fTrie.ProofNode(func(index uint64) (multiproof.Node, error) {
+				return multiproof.ElementFromValue(st, pos, index)
+			})
+		}
+		return multiproof.FieldFromValue(st, pos)
+	})
+	return multiproof.Prove(root, gindices)
+}
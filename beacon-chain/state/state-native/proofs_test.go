@@ -2,11 +2,13 @@ package state_native_test
 
 import (
 	"context"
+	"encoding/binary"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/multiproof"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
@@ -164,3 +166,49 @@ func TestBeaconStateMerkleProofs_bellatrix(t *testing.T) {
 		require.Equal(t, true, valid)
 	})
 }
+
+func TestBeaconStateMultiproof(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateDeneb(t, 64)
+	// Hash the state so that the field tries are populated, then update a validator and a balance
+	// so that the proof is built from the recomputed tries.
+	_, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	val, err := st.ValidatorAtIndex(5)
+	require.NoError(t, err)
+	val.EffectiveBalance = 12345
+	require.NoError(t, st.UpdateValidatorAtIndex(5, val))
+	require.NoError(t, st.UpdateBalancesAtIndex(9, 777))
+
+	paths := []string{
+		"validators[5].effective_balance",
+		"validators[6]",
+		"validators.__len__",
+		"balances[9]",
+		"block_roots[3]",
+		"latest_execution_payload_header.block_hash",
+		"inactivity_scores[1]",
+		"finalized_checkpoint.root",
+	}
+	gindices := make([]uint64, len(paths))
+	for i, path := range paths {
+		gindices[i], err = st.GeneralizedIndex(path)
+		require.NoError(t, err)
+	}
+	require.Equal(t, statenative.FinalizedRootGeneralizedIndex(), gindices[len(gindices)-1])
+
+	proof, err := st.Multiproof(ctx, gindices)
+	require.NoError(t, err)
+	root, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	valid, err := proof.Verify(root)
+	require.NoError(t, err)
+	require.Equal(t, true, valid)
+	require.Equal(t, uint64(12345), binary.LittleEndian.Uint64(proof.Leaves[0][:8]))
+	require.Equal(t, uint64(64), binary.LittleEndian.Uint64(proof.Leaves[2][:8]))
+	// Four balances are packed in each chunk.
+	require.Equal(t, uint64(777), binary.LittleEndian.Uint64(proof.Leaves[3][8:16]))
+
+	_, err = st.GeneralizedIndex("validators[5].foo")
+	require.ErrorIs(t, err, multiproof.ErrInvalidPath)
+}
@@ -0,0 +1,142 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+var errColumnBlockMismatch = errors.New("sampled data column does not belong to the block")
+
+// columnSamplingSource gives the data column sampler access to the p2p layer of the sync service.
+type columnSamplingSource struct {
+	s *Service
+}
+
+var _ das.ColumnPeerSource = &columnSamplingSource{}
+var _ das.ColumnFetcher = &columnSamplingSource{}
+var _ das.DataColumnBatchVerifier = &columnSamplingSource{}
+
+// CustodiansByColumn computes the columns custodied by each connected peer from its node ID and the custody
+// subnet count it advertises.
+func (c *columnSamplingSource) CustodiansByColumn() map[uint64][]peer.ID {
+	custodians := make(map[uint64][]peer.ID)
+	for _, pid := range c.s.cfg.p2p.Peers().Connected() {
+		nodeID, err := p2p.ConvertPeerIDToNodeID(pid)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not compute node ID of peer")
+			continue
+		}
+		columns, err := peerdas.CustodyColumns(nodeID, c.s.cfg.p2p.CustodyCountFromRemotePeer(pid))
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not compute custody columns of peer")
+			continue
+		}
+		for column := range columns {
+			custodians[column] = append(custodians[column], pid)
+		}
+	}
+	return custodians
+}
+
+// FetchDataColumns requests the given columns of the block root from the peer.
+func (c *columnSamplingSource) FetchDataColumns(ctx context.Context, pid peer.ID, root [32]byte, columns []uint64) ([]blocks.RODataColumn, error) {
+	req := make(p2ptypes.DataColumnSidecarsByRootReq, 0, len(columns))
+	for _, column := range columns {
+		req = append(req, &eth.DataColumnIdentifier{BlockRoot: root[:], Index: column})
+	}
+	return SendDataColumnSidecarByRoot(ctx, c.s.cfg.clock, c.s.cfg.p2p, pid, c.s.ctxMap, &req)
+}
+
+// VerifiedRODataColumns verifies sampled columns against their block, which has already been validated.
+func (c *columnSamplingSource) VerifiedRODataColumns(_ context.Context, blk blocks.ROBlock, columns []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	verified := make([]blocks.VerifiedRODataColumn, 0, len(columns))
+	for _, column := range columns {
+		if column.BlockRoot() != blk.Root() {
+			return nil, errors.Wrapf(errColumnBlockMismatch, "root=%#x index=%d", column.BlockRoot(), column.Index)
+		}
+		vf := c.s.newColumnVerifier(column, verification.ByRootColumnSidecarRequirements)
+		if err := vf.DataColumnIndexInBounds(); err != nil {
+			return nil, err
+		}
+		if err := vf.SidecarInclusionProven(); err != nil {
+			return nil, err
+		}
+		if err := vf.SidecarKzgProofVerified(); err != nil {
+			return nil, err
+		}
+		vc, err := vf.VerifiedRODataColumn()
+		if err != nil {
+			return nil, err
+		}
+		verified = append(verified, vc)
+	}
+	return verified, nil
+}
+
+// SampleDataColumns runs data availability sampling for the given block, when PeerDAS is active, on the columns
+// which are neither custodied nor already stored by the node. It is run by the data availability check of the
+// blockchain service, which does not import the block when sampling fails.
+func (s *Service) SampleDataColumns(ctx context.Context, blk blocks.ROBlock) error {
+	sampler := s.dataColumnSampler.Load()
+	if !peerdas.IsActive(blk.Block().Slot()) || sampler == nil {
+		return nil
+	}
+	available, err := peerdas.CustodyColumns(s.cfg.p2p.NodeID(), peerdas.CustodySubnetCount())
+	if err != nil {
+		return errors.Wrap(err, "could not compute custody columns")
+	}
+	if s.cfg.blobStorage != nil {
+		stored, err := s.cfg.blobStorage.DataColumnIndices(blk.Root())
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve stored data column indices")
+		}
+		for idx, ok := range stored {
+			if ok {
+				available[uint64(idx)] = true
+			}
+		}
+	}
+	fields := logrus.Fields{
+		"slot":      blk.Block().Slot(),
+		"blockRoot": fmt.Sprintf("%#x", blk.Root()),
+	}
+	sampled, err := sampler.Sample(ctx, blk, available)
+	if err != nil {
+		dataColumnSamplingCount.WithLabelValues("failure").Inc()
+		log.WithError(err).WithFields(fields).WithField("columns", sampled).Warn("Data column sampling failed")
+		return err
+	}
+	if len(sampled) == 0 {
+		return nil
+	}
+	dataColumnSamplingCount.WithLabelValues("success").Inc()
+	log.WithFields(fields).WithField("columns", sampled).Debug("Data column sampling succeeded")
+	return nil
+}
+
+// RequestDataColumns requests from peers by root the given columns of the block, which the node custodies but did
+// not receive over gossip, and hands the ones retrieved to the blockchain service like gossiped columns.
+func (s *Service) RequestDataColumns(ctx context.Context, blk blocks.ROBlock, columns []uint64) error {
+	sampler := s.dataColumnSampler.Load()
+	if sampler == nil {
+		return nil
+	}
+	retrieved, err := sampler.Retrieve(ctx, blk, columns)
+	for _, column := range retrieved {
+		if err := s.cfg.chain.ReceiveDataColumn(ctx, column); err != nil {
+			return errors.Wrapf(err, "could not receive data column %d", column.Index)
+		}
+	}
+	return err
+}
@@ -50,8 +50,8 @@ func (s *Service) registerForUpcomingFork(currEpoch primitives.Epoch) error {
 	// In preparation for the upcoming fork
 	// in the following epoch, the node
 	// will subscribe the new topics in advance.
+	nextEpoch := currEpoch + 1
 	if isNextForkEpoch {
-		nextEpoch := currEpoch + 1
 		digest, err := forks.ForkDigestFromEpoch(nextEpoch, genRoot[:])
 		if err != nil {
 			return errors.Wrap(err, "could not retrieve fork digest")
@@ -66,6 +66,27 @@ func (s *Service) registerForUpcomingFork(currEpoch primitives.Epoch) error {
 		if nextEpoch == params.BeaconConfig().DenebForkEpoch {
 			s.registerRPCHandlersDeneb()
 		}
+		if nextEpoch == params.BeaconConfig().Eip7594ForkEpoch {
+			s.registerRPCHandlersPeerDAS()
+		}
+		return nil
+	}
+	// EIP-7594 does not come with a new fork version, so its topics are
+	// registered under the current digest ahead of the activation epoch.
+	if nextEpoch == params.BeaconConfig().Eip7594ForkEpoch {
+		digest, err := forks.ForkDigestFromEpoch(nextEpoch, genRoot[:])
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve fork digest")
+		}
+		exist, err := s.dataColumnSubscribersExist(digest)
+		if err != nil {
+			return errors.Wrap(err, "could not check data column subscribers")
+		}
+		if exist {
+			return nil
+		}
+		s.registerDataColumnSubscribers(digest)
+		s.registerRPCHandlersPeerDAS()
 	}
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
@@ -322,6 +323,13 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		}
 		response.bwb = bwb
 	}
+	if response.err == nil {
+		bwb, err := f.fetchDataColumnsFromPeers(ctx, response.bwb, append([]peer.ID{response.pid}, peers...))
+		if err != nil {
+			response.err = err
+		}
+		response.bwb = bwb
+	}
 	return response
 }
 
@@ -403,6 +411,10 @@ func countCommitments(bwb []blocks2.BlockWithROBlobs, retentionStart primitives.
 		if b.Block.Version() < version.Deneb {
 			continue
 		}
+		// Data columns are fetched instead of blobs past the PeerDAS fork.
+		if peerdas.IsActive(slot) {
+			continue
+		}
 		if slot < retentionStart {
 			continue
 		}
@@ -0,0 +1,184 @@
+package initialsync
+
+import (
+	"context"
+	"sort"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	prysmsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	blocks2 "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	p2ppb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/sirupsen/logrus"
+)
+
+var errMissingDataColumns = errors.New("data columns unavailable for processing blocks with kzg commitments")
+
+// fetchDataColumnsFromPeers fetches the custody columns of the blocks past the PeerDAS fork which have commitments.
+// Each peer is asked for the missing columns it custodies over the slot range of those blocks, and a column is
+// only accepted from a peer which returned it for all of them.
+func (f *blocksFetcher) fetchDataColumnsFromPeers(ctx context.Context, bwb []blocks2.BlockWithROBlobs, peers []peer.ID) ([]blocks2.BlockWithROBlobs, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchDataColumnsFromPeers")
+	defer span.End()
+	if !peerdas.IsActive(f.clock.CurrentSlot()) {
+		return bwb, nil
+	}
+	windowStart, err := prysmsync.DataColumnsRPCMinValidSlot(f.clock.CurrentSlot())
+	if err != nil {
+		return nil, err
+	}
+	needed := blocksNeedingColumns(bwb, windowStart)
+	if len(needed) == 0 {
+		return bwb, nil
+	}
+	custody, err := peerdas.CustodyColumns(f.p2p.NodeID(), peerdas.CustodySubnetCount())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute custody columns")
+	}
+	missing := make(map[uint64]bool, len(custody))
+	for c := range custody {
+		missing[c] = true
+	}
+	roots := make(map[[32]byte]bool, len(needed))
+	for _, i := range needed {
+		roots[bwb[i].Block.Root()] = true
+	}
+	start := bwb[needed[0]].Block.Block().Slot()
+	count := uint64(bwb[needed[len(needed)-1]].Block.Block().Slot()-start) + 1
+
+	fetched := make(map[[32]byte][]blocks2.RODataColumn, len(needed))
+	for _, p := range dedupPeers(peers) {
+		if len(missing) == 0 {
+			break
+		}
+		columns := f.custodiedColumns(p, missing)
+		if len(columns) == 0 {
+			continue
+		}
+		req := &p2ppb.DataColumnSidecarsByRangeRequest{StartSlot: start, Count: count, Columns: columns}
+		dcs, err := f.requestDataColumns(ctx, req, p)
+		if err != nil {
+			log.WithField("peer", p).WithError(err).Debug("Could not request data columns by range from peer")
+			continue
+		}
+		f.p2p.Peers().Scorers().BlockProviderScorer().Touch(p)
+		for c, byRoot := range completeColumns(roots, dcs) {
+			for root, dc := range byRoot {
+				fetched[root] = append(fetched[root], dc)
+			}
+			delete(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errors.Wrapf(errMissingDataColumns, "columns %v for slots %d-%d", sortedColumns(missing), start, start+primitives.Slot(count)-1)
+	}
+	for _, i := range needed {
+		columns := fetched[bwb[i].Block.Root()]
+		sort.Slice(columns, func(a, b int) bool { return columns[a].Index < columns[b].Index })
+		bwb[i].Columns = columns
+	}
+	return bwb, nil
+}
+
+// blocksNeedingColumns returns the positions of the blocks past the PeerDAS fork with commitments, from the start
+// of the retention window.
+func blocksNeedingColumns(bwb []blocks2.BlockWithROBlobs, retentionStart primitives.Slot) []int {
+	needed := make([]int, 0, len(bwb))
+	for i := range bwb {
+		b := bwb[i].Block
+		slot := b.Block().Slot()
+		if b.Version() < version.Deneb || !peerdas.IsActive(slot) || slot < retentionStart {
+			continue
+		}
+		commits, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		needed = append(needed, i)
+	}
+	return needed
+}
+
+// completeColumns groups the data columns of the response by index and block root, keeping only the columns
+// returned for each of the given block roots.
+func completeColumns(roots map[[32]byte]bool, dcs []blocks2.RODataColumn) map[uint64]map[[32]byte]blocks2.RODataColumn {
+	byColumn := make(map[uint64]map[[32]byte]blocks2.RODataColumn)
+	for _, dc := range dcs {
+		root := dc.BlockRoot()
+		if !roots[root] {
+			continue
+		}
+		if byColumn[dc.Index] == nil {
+			byColumn[dc.Index] = make(map[[32]byte]blocks2.RODataColumn, len(roots))
+		}
+		byColumn[dc.Index][root] = dc
+	}
+	for c, byRoot := range byColumn {
+		if len(byRoot) != len(roots) {
+			delete(byColumn, c)
+		}
+	}
+	return byColumn
+}
+
+// custodiedColumns returns the given columns which the peer custodies, sorted.
+func (f *blocksFetcher) custodiedColumns(pid peer.ID, columns map[uint64]bool) []uint64 {
+	nodeID, err := p2p.ConvertPeerIDToNodeID(pid)
+	if err != nil {
+		log.WithError(err).WithField("peer", pid).Debug("Could not compute node ID of peer")
+		return nil
+	}
+	custody, err := peerdas.CustodyColumns(nodeID, f.p2p.CustodyCountFromRemotePeer(pid))
+	if err != nil {
+		log.WithError(err).WithField("peer", pid).Debug("Could not compute custody columns of peer")
+		return nil
+	}
+	custodied := make(map[uint64]bool, len(columns))
+	for c := range columns {
+		if custody[c] {
+			custodied[c] = true
+		}
+	}
+	return sortedColumns(custodied)
+}
+
+func sortedColumns(columns map[uint64]bool) []uint64 {
+	sorted := make([]uint64, 0, len(columns))
+	for c := range columns {
+		sorted = append(sorted, c)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// requestDataColumns is a wrapper for handling DataColumnSidecarsByRangeRequest requests/streams.
+func (f *blocksFetcher) requestDataColumns(ctx context.Context, req *p2ppb.DataColumnSidecarsByRangeRequest, pid peer.ID) ([]blocks2.RODataColumn, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	l := f.peerLock(pid)
+	l.Lock()
+	log.WithFields(logrus.Fields{
+		"peer":     pid,
+		"start":    req.StartSlot,
+		"count":    req.Count,
+		"columns":  req.Columns,
+		"capacity": f.rateLimiter.Remaining(pid.String()),
+		"score":    f.p2p.Peers().Scorers().BlockProviderScorer().FormatScorePretty(pid),
+	}).Debug("Requesting data columns")
+	// As for blobs, data column requests are accounted for with the block rate limit.
+	if f.rateLimiter.Remaining(pid.String()) < int64(req.Count) {
+		if err := f.waitForBandwidth(pid, req.Count); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+	}
+	f.rateLimiter.Add(pid.String(), int64(req.Count))
+	l.Unlock()
+	return prysmsync.SendDataColumnSidecarsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
+}
@@ -0,0 +1,44 @@
+package initialsync
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestBlocksNeedingColumns(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.Eip7594ForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	preFork, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 10, 1)
+	noCommitments, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 33, 0)
+	withColumns, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 34, 1)
+	latest, _ := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 40, 1)
+	bwb := []blocks.BlockWithROBlobs{{Block: preFork}, {Block: noCommitments}, {Block: withColumns}, {Block: latest}}
+
+	require.DeepEqual(t, []int{2, 3}, blocksNeedingColumns(bwb, 0))
+	require.DeepEqual(t, []int{3}, blocksNeedingColumns(bwb, 35))
+	// Blobs are not requested for the blocks past the fork.
+	require.Equal(t, 1, len(countCommitments(bwb, 0)))
+}
+
+func TestCompleteColumns(t *testing.T) {
+	one, oneColumns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	two, twoColumns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 2, 1)
+	_, otherColumns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 3, 1)
+	roots := map[[32]byte]bool{one.Root(): true, two.Root(): true}
+
+	// Column 5 is only returned for one of the blocks, and the columns of unknown blocks are ignored.
+	dcs := []blocks.RODataColumn{oneColumns[3], oneColumns[5], twoColumns[3], otherColumns[5]}
+	complete := completeColumns(roots, dcs)
+	require.Equal(t, 1, len(complete))
+	require.Equal(t, 2, len(complete[3]))
+	col := complete[3][two.Root()]
+	require.Equal(t, uint64(3), col.Index)
+	require.Equal(t, two.Root(), col.BlockRoot())
+}
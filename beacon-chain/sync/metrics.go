@@ -89,6 +89,13 @@ var (
 			Buckets: []float64{5, 10, 50, 100, 150, 250, 500, 1000, 2000},
 		},
 	)
+	rpcDataColumnsByRangeResponseLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "rpc_data_columns_by_range_response_latency_milliseconds",
+			Help:    "Captures total time to respond to rpc DataColumnsByRange requests in a milliseconds distribution",
+			Buckets: []float64{5, 10, 50, 100, 150, 250, 500, 1000, 2000},
+		},
+	)
 	arrivalBlockPropagationHistogram = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "block_arrival_latency_milliseconds",
@@ -150,6 +157,18 @@ var (
 			Help: "Time to verify gossiped blob sidecars",
 		},
 	)
+	dataColumnSidecarArrivalGossipSummary = promauto.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "gossip_data_column_sidecar_arrival_milliseconds",
+			Help: "Time for gossiped data column sidecars to arrive",
+		},
+	)
+	dataColumnSidecarVerificationGossipSummary = promauto.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "gossip_data_column_sidecar_verification_milliseconds",
+			Help: "Time to verify gossiped data column sidecars",
+		},
+	)
 	pendingAttCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "gossip_pending_attestations_total",
 		Help: "increased when receiving a new pending attestation",
@@ -170,6 +189,19 @@ var (
 			Help: "The number of blob sidecars that were dropped due to missing parent block",
 		},
 	)
+	missingParentDataColumnSidecarCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_missing_parent_data_column_sidecar_total",
+			Help: "The number of data column sidecars that were dropped due to missing parent block",
+		},
+	)
+	dataColumnSamplingCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "data_column_sampling_total",
+			Help: "The number of blocks for which data column sampling was run, by result",
+		},
+		[]string{"result"},
+	)
 
 	blobRecoveredFromELTotal = promauto.NewCounter(
 		prometheus.CounterOpts{
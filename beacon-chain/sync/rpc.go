@@ -14,6 +14,7 @@ import (
 	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
@@ -54,6 +55,9 @@ func (s *Service) registerRPCHandlers() {
 		if currEpoch >= params.BeaconConfig().DenebForkEpoch {
 			s.registerRPCHandlersDeneb()
 		}
+		if currEpoch >= params.BeaconConfig().Eip7594ForkEpoch {
+			s.registerRPCHandlersPeerDAS()
+		}
 		return
 	}
 	s.registerRPC(
@@ -96,6 +100,24 @@ func (s *Service) registerRPCHandlersAltair() {
 		p2p.RPCMetaDataTopicV2,
 		s.metaDataHandler,
 	)
+	if features.Get().EnableLightClient {
+		s.registerRPC(
+			p2p.RPCLightClientBootstrapTopicV1,
+			s.lightClientBootstrapRPCHandler,
+		)
+		s.registerRPC(
+			p2p.RPCLightClientUpdatesByRangeTopicV1,
+			s.lightClientUpdatesByRangeRPCHandler,
+		)
+		s.registerRPC(
+			p2p.RPCLightClientFinalityUpdateTopicV1,
+			s.lightClientFinalityUpdateRPCHandler,
+		)
+		s.registerRPC(
+			p2p.RPCLightClientOptimisticUpdateTopicV1,
+			s.lightClientOptimisticUpdateRPCHandler,
+		)
+	}
 }
 
 func (s *Service) registerRPCHandlersDeneb() {
@@ -109,6 +131,17 @@ func (s *Service) registerRPCHandlersDeneb() {
 	)
 }
 
+func (s *Service) registerRPCHandlersPeerDAS() {
+	s.registerRPC(
+		p2p.RPCDataColumnSidecarsByRangeTopicV1,
+		s.dataColumnSidecarsByRangeRPCHandler,
+	)
+	s.registerRPC(
+		p2p.RPCDataColumnSidecarsByRootTopicV1,
+		s.dataColumnSidecarByRootRPCHandler,
+	)
+}
+
 // Remove all v1 Stream handlers that are no longer supported
 // from altair onwards.
 func (s *Service) unregisterPhase0Handlers() {
@@ -191,9 +224,10 @@ func (s *Service) registerRPC(baseTopic string, handle rpcHandler) {
 		// Increment message received counter.
 		messageReceivedCounter.WithLabelValues(topic).Inc()
 
-		// since metadata requests do not have any data in the payload, we
+		// since metadata and light client update requests do not have any data in the payload, we
 		// do not decode anything.
-		if baseTopic == p2p.RPCMetaDataTopicV1 || baseTopic == p2p.RPCMetaDataTopicV2 {
+		if baseTopic == p2p.RPCMetaDataTopicV1 || baseTopic == p2p.RPCMetaDataTopicV2 ||
+			baseTopic == p2p.RPCLightClientFinalityUpdateTopicV1 || baseTopic == p2p.RPCLightClientOptimisticUpdateTopicV1 {
 			if err := handle(ctx, base, stream); err != nil {
 				messageFailedProcessingCounter.WithLabelValues(topic).Inc()
 				if !errors.Is(err, p2ptypes.ErrWrongForkDigestVersion) {
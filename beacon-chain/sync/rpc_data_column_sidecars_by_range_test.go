@@ -0,0 +1,161 @@
+package sync
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	types "github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestDataColumnsByRangeValidation(t *testing.T) {
+	cfg := params.BeaconConfig()
+	repositionFutureEpochs(cfg)
+	cfg.Eip7594ForkEpoch = cfg.DenebForkEpoch
+	undo, err := params.SetActiveWithUndo(cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, undo())
+	}()
+	forkSlot, err := slots.EpochStart(params.BeaconConfig().Eip7594ForkEpoch)
+	require.NoError(t, err)
+	minReqSlots, err := slots.EpochStart(params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest)
+	require.NoError(t, err)
+	defaultCurrent := forkSlot + 100 + minReqSlots
+	defaultMinStart, err := DataColumnsRPCMinValidSlot(defaultCurrent)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		current types.Slot
+		req     *ethpb.DataColumnSidecarsByRangeRequest
+		start   types.Slot
+		end     types.Slot
+		batch   uint64
+		err     error
+	}{
+		{
+			name:    "zero count",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultMinStart, Count: 0, Columns: []uint64{0}},
+			err:     p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name:    "no columns",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultMinStart, Count: 10},
+			err:     p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name:    "column out of range",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultMinStart, Count: 10, Columns: []uint64{params.BeaconConfig().NumberOfColumns}},
+			err:     p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name:    "duplicate columns",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultMinStart, Count: 10, Columns: []uint64{3, 3}},
+			err:     p2ptypes.ErrInvalidRequest,
+		},
+		{
+			name:    "count times columns over max",
+			current: defaultCurrent,
+			req: &ethpb.DataColumnSidecarsByRangeRequest{
+				StartSlot: defaultMinStart,
+				Count:     params.BeaconConfig().MaxRequestDataColumnSidecars/2 + 1,
+				Columns:   []uint64{0, 1},
+			},
+			err: p2ptypes.ErrMaxDataColumnReqExceeded,
+		},
+		{
+			name:    "start before min valid slot",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultMinStart - 10, Count: 20, Columns: []uint64{0, 1}},
+			start:   defaultMinStart,
+			end:     defaultMinStart + 9,
+			batch:   20,
+		},
+		{
+			name:    "start after current",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultCurrent + 1, Count: 10, Columns: []uint64{0}},
+			start:   defaultCurrent,
+			end:     defaultCurrent,
+			batch:   0,
+		},
+		{
+			name:    "end capped at current",
+			current: defaultCurrent,
+			req:     &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: defaultCurrent - 5, Count: 10, Columns: []uint64{0}},
+			start:   defaultCurrent - 5,
+			end:     defaultCurrent,
+			batch:   10,
+		},
+		{
+			name:    "batch limited by requested columns",
+			current: defaultCurrent,
+			req: &ethpb.DataColumnSidecarsByRangeRequest{
+				StartSlot: defaultMinStart,
+				Count:     100,
+				Columns:   allColumnIndices(),
+			},
+			start: defaultMinStart,
+			end:   defaultMinStart + 99,
+			batch: dataColumnBatchLimit(params.BeaconConfig().NumberOfColumns),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rp, err := validateDataColumnsByRange(c.req, c.current)
+			if c.err != nil {
+				require.Equal(t, true, errors.Is(err, c.err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.start, rp.start)
+			require.Equal(t, c.end, rp.end)
+			require.Equal(t, c.batch, rp.size)
+		})
+	}
+}
+
+func TestDataColumnsRPCMinValidSlot(t *testing.T) {
+	cfg := params.BeaconConfig().Copy()
+	cfg.Eip7594ForkEpoch = math.MaxUint64
+	undo, err := params.SetActiveWithUndo(cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, undo())
+	}()
+	minSlot, err := DataColumnsRPCMinValidSlot(1000)
+	require.NoError(t, err)
+	require.Equal(t, types.Slot(math.MaxUint64), minSlot)
+
+	cfg = params.BeaconConfig().Copy()
+	cfg.Eip7594ForkEpoch = 10
+	cfg.MinEpochsForDataColumnSidecarsRequest = 100
+	params.OverrideBeaconConfig(cfg)
+
+	// Shortly after the fork, the fork epoch is the lower bound.
+	minSlot, err = DataColumnsRPCMinValidSlot(slots.UnsafeEpochStart(50))
+	require.NoError(t, err)
+	require.Equal(t, slots.UnsafeEpochStart(10), minSlot)
+	// Past the retention window, only the last MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS epochs are served.
+	minSlot, err = DataColumnsRPCMinValidSlot(slots.UnsafeEpochStart(500))
+	require.NoError(t, err)
+	require.Equal(t, slots.UnsafeEpochStart(400), minSlot)
+}
+
+func allColumnIndices() []uint64 {
+	columns := make([]uint64, params.BeaconConfig().NumberOfColumns)
+	for i := range columns {
+		columns[i] = uint64(i)
+	}
+	return columns
+}
@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	p2pTypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestValidateDataColumnsByRootRequest(t *testing.T) {
+	root := make([]byte, 32)
+	require.NoError(t, validateDataColumnsByRootRequest(p2pTypes.DataColumnSidecarsByRootReq{
+		{BlockRoot: root, Index: 0},
+		{BlockRoot: root, Index: params.BeaconConfig().NumberOfColumns - 1},
+	}))
+
+	err := validateDataColumnsByRootRequest(p2pTypes.DataColumnSidecarsByRootReq{
+		{BlockRoot: root, Index: params.BeaconConfig().NumberOfColumns},
+	})
+	require.ErrorIs(t, err, p2pTypes.ErrInvalidRequest)
+
+	tooMany := make(p2pTypes.DataColumnSidecarsByRootReq, params.BeaconConfig().MaxRequestDataColumnSidecars+1)
+	for i := range tooMany {
+		tooMany[i] = &ethpb.DataColumnIdentifier{BlockRoot: root}
+	}
+	require.ErrorIs(t, validateDataColumnsByRootRequest(tooMany), p2pTypes.ErrMaxDataColumnReqExceeded)
+}
+
+func TestDataColumnSidecarsByRoot_RoundTrip(t *testing.T) {
+	cfg := params.BeaconConfig()
+	repositionFutureEpochs(cfg)
+	cfg.Eip7594ForkEpoch = cfg.DenebForkEpoch
+	undo, err := params.SetActiveWithUndo(cfg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, undo())
+	}()
+
+	forkSlot, err := slots.EpochStart(params.BeaconConfig().Eip7594ForkEpoch)
+	require.NoError(t, err)
+	current := forkSlot + 10
+	genesis := time.Now().Add(-time.Duration(uint64(current)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	clock := startup.NewClock(genesis, [32]byte{})
+
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, forkSlot+1, 2)
+	bs := filesystem.NewEphemeralBlobStorage(t)
+	stored := []uint64{1, 7, 42}
+	for _, idx := range stored {
+		require.NoError(t, bs.SaveDataColumn(blocks.NewVerifiedRODataColumn(columns[idx])))
+	}
+
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	s := &Service{
+		cfg:         &config{p2p: p2, chain: &mock.ChainService{}, clock: clock, blobStorage: bs},
+		rateLimiter: newRateLimiter(p2),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	topic := p2p.RPCDataColumnSidecarsByRootTopicV1 + p2.Encoding().ProtocolSuffix()
+	p2.SetStreamHandler(topic, func(stream network.Stream) {
+		defer wg.Done()
+		req := new(p2pTypes.DataColumnSidecarsByRootReq)
+		require.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+		require.NoError(t, s.dataColumnSidecarByRootRPCHandler(context.Background(), req, stream))
+	})
+
+	// Column 3 is not stored and is silently skipped by the responder.
+	req := p2pTypes.DataColumnSidecarsByRootReq{
+		{BlockRoot: blk.RootSlice(), Index: 42},
+		{BlockRoot: blk.RootSlice(), Index: 3},
+		{BlockRoot: blk.RootSlice(), Index: 1},
+		{BlockRoot: blk.RootSlice(), Index: 7},
+	}
+	ctxMap, err := ContextByteVersionsForValRoot([32]byte{})
+	require.NoError(t, err)
+	got, err := SendDataColumnSidecarByRoot(context.Background(), clock, p1, p2.PeerID(), ctxMap, &req)
+	require.NoError(t, err)
+	wg.Wait()
+
+	require.Equal(t, len(stored), len(got))
+	for i, col := range got {
+		require.Equal(t, blk.Root(), col.BlockRoot())
+		require.Equal(t, stored[i], col.Index)
+		require.DeepEqual(t, columns[stored[i]].Column, col.Column)
+	}
+}
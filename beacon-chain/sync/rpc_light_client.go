@@ -0,0 +1,260 @@
+package sync
+
+import (
+	"context"
+	"math"
+
+	libp2pcore "github.com/libp2p/go-libp2p/core"
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// lightClientBootstrapRPCHandler handles the /eth2/beacon_chain/req/light_client_bootstrap/1/ RPC request.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#getlightclientbootstrap
+func (s *Service) lightClientBootstrapRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.lightClientBootstrapRPCHandler")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", p2p.LightClientBootstrapName[1:]) // slice the leading slash off the name var
+
+	req, ok := msg.(*types.LightClientBootstrapReq)
+	if !ok {
+		return errors.New("message is not type LightClientBootstrapReq")
+	}
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	blockRoot := [32]byte(*req)
+	bootstrap, err := s.lightClientBootstrap(ctx, blockRoot)
+	if err != nil {
+		if errors.Is(err, types.ErrResourceUnavailable) {
+			s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+			return err
+		}
+		log.WithError(err).Debug("Could not create light client bootstrap")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+
+	SetStreamWriteDeadline(stream, defaultWriteDuration)
+	if err := WriteLightClientChunk(stream, s.cfg.clock, s.cfg.p2p.Encoding(), bootstrap.Header().Beacon().Slot, bootstrap); err != nil {
+		log.WithError(err).Debug("Could not send a chunked response")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientBootstrap returns the light client bootstrap of a finalized checkpoint root, which are the only
+// bootstraps served: building a bootstrap requires the post state of the block, and serving any root would let peers
+// trigger arbitrary state replays. Built bootstraps are cached as the same checkpoints are requested by many peers.
+func (s *Service) lightClientBootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error) {
+	if bootstrap := s.lcCache.bootstrap(blockRoot); bootstrap != nil {
+		return bootstrap, nil
+	}
+	if !s.cfg.chain.IsFinalized(ctx, blockRoot) {
+		return nil, types.ErrResourceUnavailable
+	}
+	blk, err := s.cfg.beaconDB.Block(ctx, blockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve block")
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, types.ErrResourceUnavailable
+	}
+	checkpoint, err := s.isFinalizedCheckpointRoot(ctx, blockRoot, blk)
+	if err != nil {
+		return nil, err
+	}
+	if !checkpoint {
+		return nil, types.ErrResourceUnavailable
+	}
+	st, err := s.cfg.stateGen.StateByRoot(ctx, blockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve state")
+	}
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, st, blk)
+	if err != nil {
+		return nil, err
+	}
+	m, err := lightclient.BootstrapToProto(bootstrap)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := lightclienttypes.NewWrappedBootstrap(m)
+	if err != nil {
+		return nil, err
+	}
+	s.lcCache.addBootstrap(blockRoot, wrapped)
+	return wrapped, nil
+}
+
+// isFinalizedCheckpointRoot returns true if a finalized block is the root of a checkpoint, which is the block at the
+// start of an epoch, or the last block before it when the first slots of the epoch were skipped. The latter is only
+// known from the next block of the finalized chain, unless the block is the latest finalized checkpoint root.
+func (s *Service) isFinalizedCheckpointRoot(ctx context.Context, blockRoot [32]byte, blk interfaces.ReadOnlySignedBeaconBlock) (bool, error) {
+	slot := blk.Block().Slot()
+	if slots.IsEpochStart(slot) {
+		return true, nil
+	}
+	if finalized := s.cfg.chain.FinalizedCheckpt(); finalized != nil && bytesutil.ToBytes32(finalized.Root) == blockRoot {
+		return true, nil
+	}
+	child, err := s.cfg.beaconDB.FinalizedChildBlock(ctx, blockRoot)
+	if err != nil {
+		return false, errors.Wrap(err, "could not retrieve finalized child block")
+	}
+	if child == nil || child.IsNil() {
+		return false, nil
+	}
+	boundary, err := slots.EpochStart(slots.ToEpoch(slot) + 1)
+	if err != nil {
+		return false, err
+	}
+	return child.Block().Slot() > boundary, nil
+}
+
+// lightClientUpdatesByRangeRPCHandler handles the /eth2/beacon_chain/req/light_client_updates_by_range/1/ RPC request.
+// The best update of each requested sync committee period is served, stopping at the first period without one.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#lightclientupdatesbyrange
+func (s *Service) lightClientUpdatesByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.lightClientUpdatesByRangeRPCHandler")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, respTimeout)
+	defer cancel()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", p2p.LightClientUpdatesByRangeName[1:]) // slice the leading slash off the name var
+
+	req, ok := msg.(*types.LightClientUpdatesByRangeReq)
+	if !ok {
+		return errors.New("message is not type LightClientUpdatesByRangeReq")
+	}
+	if req.Count == 0 {
+		s.cfg.p2p.Peers().Scorers().BadResponsesScorer().Increment(stream.Conn().RemotePeer())
+		s.writeErrorResponseToStream(responseCodeInvalidRequest, types.ErrInvalidRequest.Error(), stream)
+		return types.ErrInvalidRequest
+	}
+	count := min(req.Count, params.BeaconConfig().MaxRequestLightClientUpdates)
+	if err := s.rateLimiter.validateRequest(stream, count); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, int64(count))
+
+	endPeriod := req.StartPeriod + count - 1
+	if endPeriod < req.StartPeriod {
+		endPeriod = math.MaxUint64
+	}
+	updates, err := s.cfg.beaconDB.LightClientUpdates(ctx, req.StartPeriod, endPeriod)
+	if err != nil {
+		log.WithError(err).Debug("Could not retrieve light client updates")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+
+	for period := req.StartPeriod; period <= endPeriod; period++ {
+		update, ok := updates[period]
+		if !ok || update.Data == nil {
+			break
+		}
+		m, err := lightclient.UpdateToProto(update.Data)
+		if err != nil {
+			log.WithError(err).WithField("period", period).Debug("Could not convert light client update")
+			s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+			tracing.AnnotateError(span, err)
+			return err
+		}
+		wrapped, err := lightclienttypes.NewWrappedUpdate(m)
+		if err != nil {
+			s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+			tracing.AnnotateError(span, err)
+			return err
+		}
+		SetStreamWriteDeadline(stream, defaultWriteDuration)
+		if err := WriteLightClientChunk(stream, s.cfg.clock, s.cfg.p2p.Encoding(), wrapped.AttestedHeader().Beacon().Slot, wrapped); err != nil {
+			log.WithError(err).Debug("Could not send a chunked response")
+			s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+			tracing.AnnotateError(span, err)
+			return err
+		}
+		if period == math.MaxUint64 {
+			break
+		}
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientFinalityUpdateRPCHandler handles the /eth2/beacon_chain/req/light_client_finality_update/1/ RPC request.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#getlightclientfinalityupdate
+func (s *Service) lightClientFinalityUpdateRPCHandler(ctx context.Context, _ interface{}, stream libp2pcore.Stream) error {
+	_, span := trace.StartSpan(ctx, "sync.lightClientFinalityUpdateRPCHandler")
+	defer span.End()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", p2p.LightClientFinalityUpdateName[1:]) // slice the leading slash off the name var
+
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	update := s.lcCache.latestFinalityUpdate()
+	if update == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+		return types.ErrResourceUnavailable
+	}
+	SetStreamWriteDeadline(stream, defaultWriteDuration)
+	if err := WriteLightClientChunk(stream, s.cfg.clock, s.cfg.p2p.Encoding(), update.AttestedHeader().Beacon().Slot, update); err != nil {
+		log.WithError(err).Debug("Could not send a chunked response")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
+
+// lightClientOptimisticUpdateRPCHandler handles the /eth2/beacon_chain/req/light_client_optimistic_update/1/ RPC request.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#getlightclientoptimisticupdate
+func (s *Service) lightClientOptimisticUpdateRPCHandler(ctx context.Context, _ interface{}, stream libp2pcore.Stream) error {
+	_, span := trace.StartSpan(ctx, "sync.lightClientOptimisticUpdateRPCHandler")
+	defer span.End()
+	SetRPCStreamDeadlines(stream)
+	log := log.WithField("handler", p2p.LightClientOptimisticUpdateName[1:]) // slice the leading slash off the name var
+
+	if err := s.rateLimiter.validateRequest(stream, 1); err != nil {
+		return err
+	}
+	s.rateLimiter.add(stream, 1)
+
+	update := s.lcCache.latestOptimisticUpdate()
+	if update == nil {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+		return types.ErrResourceUnavailable
+	}
+	SetStreamWriteDeadline(stream, defaultWriteDuration)
+	if err := WriteLightClientChunk(stream, s.cfg.clock, s.cfg.p2p.Encoding(), update.AttestedHeader().Beacon().Slot, update); err != nil {
+		log.WithError(err).Debug("Could not send a chunked response")
+		s.writeErrorResponseToStream(responseCodeServerError, types.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	closeStream(stream, log)
+	return nil
+}
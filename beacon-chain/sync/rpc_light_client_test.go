@@ -0,0 +1,321 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	db "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func testLightClientUpdate(t *testing.T) *ethpbv2.LightClientUpdate {
+	l := util.NewTestLightClient(t).SetupTestAltair()
+	update, err := lightclient.NewLightClientUpdateFromBeaconState(l.Ctx, l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+	require.NoError(t, err)
+	return update
+}
+
+func TestLightClientBootstrapRPCHandler(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	ctx := context.Background()
+	d := db.SetupDB(t)
+	l := util.NewTestLightClient(t).SetupTestAltair()
+	bootstrap, err := lightclient.NewLightClientBootstrapFromBeaconState(ctx, l.State, l.Block)
+	require.NoError(t, err)
+	m, err := lightclient.BootstrapToProto(bootstrap)
+	require.NoError(t, err)
+	wrapped, err := lightclienttypes.NewWrappedBootstrap(m)
+	require.NoError(t, err)
+
+	cached, notFinalized := [32]byte{'a'}, [32]byte{'b'}
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 1
+	util.SaveBlock(t, ctx, d, blk)
+	notCheckpoint, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	chain := &mock.ChainService{
+		Genesis:        time.Now(),
+		FinalizedRoots: map[[32]byte]bool{notCheckpoint: true},
+	}
+	r := &Service{
+		cfg: &config{
+			beaconDB: d,
+			p2p:      p1,
+			chain:    chain,
+			clock:    startup.NewClock(chain.Genesis, chain.ValidatorsRoot),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	r.lcCache.addBootstrap(cached, wrapped)
+
+	pcl := protocol.ID(p2p.RPCLightClientBootstrapTopicV1 + p1.Encoding().ProtocolSuffix())
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10, 10, time.Second, false)
+
+	// Cached bootstraps are served without reading the database.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		_, err := readContextFromStream(stream)
+		require.NoError(t, err)
+		res := &ethpb.LightClientBootstrapAltair{}
+		require.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, res))
+		assert.Equal(t, l.Block.Block().Slot(), res.Header.Beacon.Slot)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := types.LightClientBootstrapReq(cached)
+	require.NoError(t, r.lightClientBootstrapRPCHandler(ctx, &req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	// Roots which are not finalized or not a checkpoint root are not served.
+	for _, root := range [][32]byte{notFinalized, notCheckpoint} {
+		wg.Add(1)
+		p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+			defer wg.Done()
+			expectFailure(t, responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+		})
+		stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+		require.NoError(t, err)
+		req := types.LightClientBootstrapReq(root)
+		require.ErrorIs(t, r.lightClientBootstrapRPCHandler(ctx, &req, stream), types.ErrResourceUnavailable)
+		if util.WaitTimeout(&wg, 1*time.Second) {
+			t.Fatal("Did not receive stream within 1 sec")
+		}
+	}
+}
+
+func TestIsFinalizedCheckpointRoot(t *testing.T) {
+	ctx := context.Background()
+	d := db.SetupDB(t)
+	spe := params.BeaconConfig().SlotsPerEpoch
+
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, d, genesis)
+	require.NoError(t, d.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	// The first slot of epoch 1 is skipped, the block before it is the checkpoint root of the epoch.
+	roots := make(map[primitives.Slot][32]byte)
+	blks := make(map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock)
+	parent := genesisRoot
+	for _, slot := range []primitives.Slot{1, 2, spe - 1, spe + 1, 2 * spe} {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, d, b)
+		signed, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		roots[slot], blks[slot], parent = root, signed, root
+	}
+	require.NoError(t, d.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 2 * spe, Root: parent[:]}))
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: parent[:]}))
+
+	r := &Service{cfg: &config{beaconDB: d, chain: &mock.ChainService{FinalizedCheckPoint: &ethpb.Checkpoint{Epoch: 2, Root: parent[:]}}}}
+	for slot, want := range map[primitives.Slot]bool{1: false, 2: false, spe - 1: true, spe + 1: false, 2 * spe: true} {
+		got, err := r.isFinalizedCheckpointRoot(ctx, roots[slot], blks[slot])
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "slot %d", slot)
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+
+	ctx := context.Background()
+	d := db.SetupDB(t)
+	update := testLightClientUpdate(t)
+	// Period 3 is missing, so only the updates of periods 1 and 2 are served.
+	for _, period := range []uint64{1, 2, 4} {
+		require.NoError(t, d.SaveLightClientUpdate(ctx, period, &ethpbv2.LightClientUpdateWithVersion{
+			Version: ethpbv2.Version_ALTAIR,
+			Data:    update,
+		}))
+	}
+
+	chain := &mock.ChainService{Genesis: time.Now(), ValidatorsRoot: [32]byte{}}
+	r := &Service{
+		cfg: &config{
+			beaconDB: d,
+			p2p:      p1,
+			chain:    chain,
+			clock:    startup.NewClock(chain.Genesis, chain.ValidatorsRoot),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1 + p1.Encoding().ProtocolSuffix())
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10, 10, time.Second, false)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for i := 0; i < 2; i++ {
+			expectSuccess(t, stream)
+			_, err := readContextFromStream(stream)
+			require.NoError(t, err)
+			res := &ethpb.LightClientUpdateAltair{}
+			require.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, res))
+			assert.Equal(t, update.SignatureSlot, res.SignatureSlot)
+		}
+		_, _, err := ReadStatusCode(stream, p2.Encoding())
+		require.ErrorContains(t, "EOF", err)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &types.LightClientUpdatesByRangeReq{StartPeriod: 1, Count: 4}
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(ctx, req, stream))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler_ZeroCount(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	r := &Service{
+		cfg: &config{
+			beaconDB: db.SetupDB(t),
+			p2p:      p1,
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1 + p1.Encoding().ProtocolSuffix())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeInvalidRequest, types.ErrInvalidRequest.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &types.LightClientUpdatesByRangeReq{StartPeriod: 1, Count: 0}
+	require.ErrorIs(t, r.lightClientUpdatesByRangeRPCHandler(context.Background(), req, stream), types.ErrInvalidRequest)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+	badResponses, err := p1.Peers().Scorers().BadResponsesScorer().Count(p2.BHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, badResponses)
+}
+
+func TestLightClientFinalityUpdateRPCHandler_Unavailable(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	r := &Service{
+		cfg: &config{
+			p2p: p1,
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+
+	pcl := protocol.ID(p2p.RPCLightClientFinalityUpdateTopicV1 + p1.Encoding().ProtocolSuffix())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.lightClientFinalityUpdateRPCHandler(context.Background(), new(interface{}), stream), types.ErrResourceUnavailable)
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientCache_ForwardOptimisticUpdate(t *testing.T) {
+	update := testLightClientUpdate(t)
+	optimisticUpdate := func(slot primitives.Slot) interfaces.LightClientOptimisticUpdate {
+		m, err := lightclient.OptimisticUpdateToProto(&ethpbv2.LightClientOptimisticUpdate{
+			AttestedHeader: update.AttestedHeader,
+			SyncAggregate:  update.SyncAggregate,
+			SignatureSlot:  update.SignatureSlot,
+		})
+		require.NoError(t, err)
+		m.(*ethpb.LightClientOptimisticUpdateAltair).AttestedHeader.Beacon.Slot = slot
+		wrapped, err := lightclienttypes.NewWrappedOptimisticUpdate(m)
+		require.NoError(t, err)
+		return wrapped
+	}
+
+	var c lightClientCache
+	assert.Equal(t, true, c.forwardOptimisticUpdate(optimisticUpdate(10)))
+	assert.Equal(t, false, c.isNewerOptimisticUpdate(optimisticUpdate(10)))
+	assert.Equal(t, false, c.forwardOptimisticUpdate(optimisticUpdate(9)))
+	assert.Equal(t, true, c.forwardOptimisticUpdate(optimisticUpdate(11)))
+}
+
+func TestLightClientCache_ForwardFinalityUpdate(t *testing.T) {
+	update := testLightClientUpdate(t)
+	finalityUpdate := func(slot primitives.Slot, participants uint64) interfaces.LightClientFinalityUpdate {
+		m, err := lightclient.FinalityUpdateToProto(&ethpbv2.LightClientFinalityUpdate{
+			AttestedHeader:  update.AttestedHeader,
+			FinalizedHeader: update.FinalizedHeader,
+			FinalityBranch:  update.FinalityBranch,
+			SyncAggregate:   update.SyncAggregate,
+			SignatureSlot:   update.SignatureSlot,
+		})
+		require.NoError(t, err)
+		u := m.(*ethpb.LightClientFinalityUpdateAltair)
+		u.FinalizedHeader.Beacon.Slot = slot
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		u.SyncAggregate.SyncCommitteeBits = bits
+		wrapped, err := lightclienttypes.NewWrappedFinalityUpdate(m)
+		require.NoError(t, err)
+		return wrapped
+	}
+
+	var c lightClientCache
+	assert.Equal(t, true, c.forwardFinalityUpdate(finalityUpdate(10, 300)))
+	assert.Equal(t, false, c.forwardFinalityUpdate(finalityUpdate(10, 300)))
+	assert.Equal(t, false, c.forwardFinalityUpdate(finalityUpdate(9, 512)))
+	// Same finalized header, but reaching supermajority participation.
+	assert.Equal(t, true, c.forwardFinalityUpdate(finalityUpdate(10, 400)))
+	assert.Equal(t, false, c.forwardFinalityUpdate(finalityUpdate(10, 512)))
+	assert.Equal(t, true, c.forwardFinalityUpdate(finalityUpdate(11, 1)))
+}
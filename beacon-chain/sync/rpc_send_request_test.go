@@ -529,6 +529,49 @@ func TestBlobValidatorFromRootReq(t *testing.T) {
 	}
 }
 
+func TestDataColumnValidatorFromRootReq(t *testing.T) {
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	otherRoot := bytesutil.PadTo([]byte("other"), 32)
+	cases := []struct {
+		name     string
+		ids      []*ethpb.DataColumnIdentifier
+		response []blocks.RODataColumn
+		err      error
+	}{
+		{
+			name:     "expected",
+			ids:      []*ethpb.DataColumnIdentifier{{BlockRoot: blk.RootSlice(), Index: 0}, {BlockRoot: blk.RootSlice(), Index: 5}},
+			response: []blocks.RODataColumn{columns[0], columns[5]},
+		},
+		{
+			name:     "wrong root",
+			ids:      []*ethpb.DataColumnIdentifier{{BlockRoot: otherRoot, Index: 0}},
+			response: []blocks.RODataColumn{columns[0]},
+			err:      errUnrequestedDataColumn,
+		},
+		{
+			name:     "wrong index",
+			ids:      []*ethpb.DataColumnIdentifier{{BlockRoot: blk.RootSlice(), Index: 0}},
+			response: []blocks.RODataColumn{columns[1]},
+			err:      errUnrequestedDataColumn,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := p2pTypes.DataColumnSidecarsByRootReq(c.ids)
+			vf := dataColumnValidatorFromRootReq(&r)
+			for _, dc := range c.response {
+				err := vf(dc)
+				if c.err != nil {
+					require.ErrorIs(t, err, c.err)
+					return
+				}
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestBlobValidatorFromRangeReq(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -607,6 +650,50 @@ func TestBlobValidatorFromRangeReq(t *testing.T) {
 	}
 }
 
+func TestDataColumnValidatorFromRangeReq(t *testing.T) {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 14, 1)
+	cases := []struct {
+		name     string
+		req      *ethpb.DataColumnSidecarsByRangeRequest
+		response blocks.RODataColumn
+		err      error
+	}{
+		{
+			name:     "valid",
+			req:      &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: 10, Count: 10, Columns: []uint64{2, 5}},
+			response: columns[5],
+		},
+		{
+			name:     "invalid - before",
+			req:      &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: 15, Count: 10, Columns: []uint64{5}},
+			response: columns[5],
+			err:      errDataColumnResponseOutOfBounds,
+		},
+		{
+			name:     "invalid - after, at boundary",
+			req:      &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: 10, Count: 4, Columns: []uint64{5}},
+			response: columns[5],
+			err:      errDataColumnResponseOutOfBounds,
+		},
+		{
+			name:     "invalid - unrequested column",
+			req:      &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: 10, Count: 10, Columns: []uint64{2, 5}},
+			response: columns[3],
+			err:      errUnrequestedDataColumn,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := dataColumnValidatorFromRangeReq(c.req)(c.response)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestSeqBlobValid(t *testing.T) {
 	one, oneBlobs := generateTestBlockWithSidecars(t, [32]byte{}, 0, 3)
 	r1, err := one.Block.HashTreeRoot()
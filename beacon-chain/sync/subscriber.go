@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
@@ -123,6 +125,20 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 				digest,
 			)
 		}
+		if features.Get().EnableLightClient {
+			s.subscribe(
+				p2p.LightClientFinalityUpdateTopicFormat,
+				s.validateLightClientFinalityUpdate,
+				s.lightClientFinalityUpdateSubscriber,
+				digest,
+			)
+			s.subscribe(
+				p2p.LightClientOptimisticUpdateTopicFormat,
+				s.validateLightClientOptimisticUpdate,
+				s.lightClientOptimisticUpdateSubscriber,
+				digest,
+			)
+		}
 	}
 
 	// New Gossip Topic in Capella
@@ -145,6 +161,56 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 			params.BeaconConfig().BlobsidecarSubnetCount,
 		)
 	}
+
+	// New Gossip Topic in EIP-7594
+	if epoch >= params.BeaconConfig().Eip7594ForkEpoch {
+		s.registerDataColumnSubscribers(digest)
+	}
+}
+
+// registerDataColumnSubscribers subscribes to the data column subnets custodied by this node.
+func (s *Service) registerDataColumnSubscribers(digest [4]byte) {
+	subnets, err := s.custodySubnets()
+	if err != nil {
+		log.WithError(err).Error("Could not compute custody subnets, not subscribing to data column topics")
+		return
+	}
+	s.subscribeStaticWithSubnetIndices(
+		p2p.DataColumnSubnetTopicFormat,
+		s.validateDataColumn,   /* validator */
+		s.dataColumnSubscriber, /* message handler */
+		digest,
+		subnets,
+	)
+}
+
+// dataColumnSubscribersExist checks whether the data column subnets have already been subscribed to for the given digest.
+func (s *Service) dataColumnSubscribersExist(digest [4]byte) (bool, error) {
+	subnets, err := s.custodySubnets()
+	if err != nil {
+		return false, err
+	}
+	for _, subnet := range subnets {
+		topic := s.addDigestAndIndexToTopic(p2p.DataColumnSubnetTopicFormat, digest, subnet) + s.cfg.p2p.Encoding().ProtocolSuffix()
+		if !s.subHandler.topicExists(topic) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// custodySubnets returns the sorted data column subnets this node custodies, derived from its node ID.
+func (s *Service) custodySubnets() ([]uint64, error) {
+	subnets, err := peerdas.CustodyColumnSubnets(s.cfg.p2p.NodeID(), peerdas.CustodySubnetCount())
+	if err != nil {
+		return nil, fmt.Errorf("custody column subnets: %w", err)
+	}
+	indices := make([]uint64, 0, len(subnets))
+	for subnet := range subnets {
+		indices = append(indices, subnet)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
 }
 
 // subscribe to a given topic with a given validator and subscription handler.
@@ -324,6 +390,16 @@ func (s *Service) wrapAndReportValidation(topic string, v wrappedVal) (string, p
 // subscribe to a static subnet with the given topic and index. A given validator and subscription handler is
 // used to handle messages from the subnet. The base protobuf message is used to initialize new messages for decoding.
 func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal, handle subHandler, digest [4]byte, subnetCount uint64) {
+	subnets := make([]uint64, 0, subnetCount)
+	for i := uint64(0); i < subnetCount; i++ {
+		subnets = append(subnets, i)
+	}
+	s.subscribeStaticWithSubnetIndices(topic, validator, handle, digest, subnets)
+}
+
+// subscribeStaticWithSubnetIndices subscribes to a static set of subnets for the given topic, which
+// need not be contiguous, e.g. the data column subnets a node custodies.
+func (s *Service) subscribeStaticWithSubnetIndices(topic string, validator wrappedVal, handle subHandler, digest [4]byte, subnets []uint64) {
 	genRoot := s.cfg.clock.GenesisValidatorsRoot()
 	_, e, err := forks.RetrieveForkDataFromDigest(digest, genRoot[:])
 	if err != nil {
@@ -335,7 +411,7 @@ func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal,
 		// Impossible condition as it would mean topic does not exist.
 		panic(fmt.Sprintf("%s is not mapped to any message in GossipTopicMappings", topic))
 	}
-	for i := uint64(0); i < subnetCount; i++ {
+	for _, i := range subnets {
 		s.subscribeWithBase(s.addDigestAndIndexToTopic(topic, digest, i), validator, handle)
 	}
 	genesis := s.cfg.clock.GenesisTime()
@@ -359,7 +435,7 @@ func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal,
 				if !valid {
 					log.Warnf("Attestation subnets with digest %#x are no longer valid, unsubscribing from all of them.", digest)
 					// Unsubscribes from all our current subnets.
-					for i := uint64(0); i < subnetCount; i++ {
+					for _, i := range subnets {
 						fullTopic := fmt.Sprintf(topic, digest, i) + s.cfg.p2p.Encoding().ProtocolSuffix()
 						s.unSubscribeFromTopic(fullTopic)
 					}
@@ -367,7 +443,7 @@ func (s *Service) subscribeStaticWithSubnets(topic string, validator wrappedVal,
 					return
 				}
 				// Check every slot that there are enough peers
-				for i := uint64(0); i < subnetCount; i++ {
+				for _, i := range subnets {
 					if !s.validPeersExist(s.addDigestAndIndexToTopic(topic, digest, i)) {
 						log.Debugf("No peers found subscribed to attestation gossip subnet with "+
 							"committee index %d. Searching network for peers subscribed to the subnet.", i)
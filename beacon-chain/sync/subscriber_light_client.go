@@ -0,0 +1,265 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	lightclient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v5/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientBootstrapCacheSize is the number of light client bootstraps cached. Each bootstrap holds a full sync
+// committee, about 25kB.
+const lightClientBootstrapCacheSize = 64
+
+// lightClientCache holds the latest light client updates computed from our own chain, which are served
+// over RPC and which gossiped updates must match, the latest updates forwarded on gossip, and the bootstraps
+// served over RPC.
+type lightClientCache struct {
+	sync.RWMutex
+	finalityUpdate            interfaces.LightClientFinalityUpdate
+	optimisticUpdate          interfaces.LightClientOptimisticUpdate
+	forwardedFinalityUpdate   interfaces.LightClientFinalityUpdate
+	forwardedOptimisticUpdate interfaces.LightClientOptimisticUpdate
+	bootstraps                *lru.Cache
+}
+
+// bootstrap returns the cached bootstrap of the block root, or nil.
+func (c *lightClientCache) bootstrap(root [32]byte) interfaces.LightClientBootstrap {
+	c.RLock()
+	defer c.RUnlock()
+	if c.bootstraps == nil {
+		return nil
+	}
+	v, ok := c.bootstraps.Get(root)
+	if !ok {
+		return nil
+	}
+	bootstrap, ok := v.(interfaces.LightClientBootstrap)
+	if !ok {
+		return nil
+	}
+	return bootstrap
+}
+
+func (c *lightClientCache) addBootstrap(root [32]byte, bootstrap interfaces.LightClientBootstrap) {
+	c.Lock()
+	defer c.Unlock()
+	if c.bootstraps == nil {
+		c.bootstraps = lruwrpr.New(lightClientBootstrapCacheSize)
+	}
+	c.bootstraps.Add(root, bootstrap)
+}
+
+func (c *lightClientCache) latestFinalityUpdate() interfaces.LightClientFinalityUpdate {
+	c.RLock()
+	defer c.RUnlock()
+	return c.finalityUpdate
+}
+
+func (c *lightClientCache) latestOptimisticUpdate() interfaces.LightClientOptimisticUpdate {
+	c.RLock()
+	defer c.RUnlock()
+	return c.optimisticUpdate
+}
+
+func (c *lightClientCache) setFinalityUpdate(update interfaces.LightClientFinalityUpdate) {
+	c.Lock()
+	defer c.Unlock()
+	c.finalityUpdate = update
+}
+
+func (c *lightClientCache) setOptimisticUpdate(update interfaces.LightClientOptimisticUpdate) {
+	c.Lock()
+	defer c.Unlock()
+	c.optimisticUpdate = update
+}
+
+// forwardFinalityUpdate records the finality update as forwarded if it is newer than all previously
+// forwarded ones, returning false otherwise.
+func (c *lightClientCache) forwardFinalityUpdate(update interfaces.LightClientFinalityUpdate) bool {
+	c.Lock()
+	defer c.Unlock()
+	if !isNewerFinalityUpdate(update, c.forwardedFinalityUpdate) {
+		return false
+	}
+	c.forwardedFinalityUpdate = update
+	return true
+}
+
+// forwardOptimisticUpdate records the optimistic update as forwarded if it is newer than all previously
+// forwarded ones, returning false otherwise.
+func (c *lightClientCache) forwardOptimisticUpdate(update interfaces.LightClientOptimisticUpdate) bool {
+	c.Lock()
+	defer c.Unlock()
+	if !isNewerOptimisticUpdate(update, c.forwardedOptimisticUpdate) {
+		return false
+	}
+	c.forwardedOptimisticUpdate = update
+	return true
+}
+
+func (c *lightClientCache) isNewerFinalityUpdate(update interfaces.LightClientFinalityUpdate) bool {
+	c.RLock()
+	defer c.RUnlock()
+	return isNewerFinalityUpdate(update, c.forwardedFinalityUpdate)
+}
+
+func (c *lightClientCache) isNewerOptimisticUpdate(update interfaces.LightClientOptimisticUpdate) bool {
+	c.RLock()
+	defer c.RUnlock()
+	return isNewerOptimisticUpdate(update, c.forwardedOptimisticUpdate)
+}
+
+// isNewerFinalityUpdate reports whether the update finalizes a later header than the forwarded one, or the
+// same header with supermajority participation which the forwarded one lacks.
+func isNewerFinalityUpdate(update, forwarded interfaces.LightClientFinalityUpdate) bool {
+	if forwarded == nil {
+		return true
+	}
+	slot, forwardedSlot := update.FinalizedHeader().Beacon().Slot, forwarded.FinalizedHeader().Beacon().Slot
+	if slot != forwardedSlot {
+		return slot > forwardedSlot
+	}
+	return hasSupermajority(update.SyncAggregate().SyncCommitteeBits.Count(), update.SyncAggregate().SyncCommitteeBits.Len()) &&
+		!hasSupermajority(forwarded.SyncAggregate().SyncCommitteeBits.Count(), forwarded.SyncAggregate().SyncCommitteeBits.Len())
+}
+
+// isNewerOptimisticUpdate reports whether the update attests a later header than the forwarded one.
+func isNewerOptimisticUpdate(update, forwarded interfaces.LightClientOptimisticUpdate) bool {
+	if forwarded == nil {
+		return true
+	}
+	return update.AttestedHeader().Beacon().Slot > forwarded.AttestedHeader().Beacon().Slot
+}
+
+func hasSupermajority(participants, size uint64) bool {
+	return participants*3 > size*2
+}
+
+// lightClientUpdateDue returns the time after which light client updates signed at the slot may be
+// forwarded, one third into the signature slot.
+func (s *Service) lightClientUpdateDue(signatureSlot primitives.Slot) time.Time {
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	return slots.StartTime(uint64(s.cfg.clock.GenesisTime().Unix()), signatureSlot).
+		Add(slotDuration / time.Duration(params.BeaconConfig().IntervalsPerSlot))
+}
+
+// lightClientUpdatesRoutine caches the light client updates sent by the blockchain service for each
+// processed block and broadcasts them.
+func (s *Service) lightClientUpdatesRoutine() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-stateChannel:
+			switch ev.Type {
+			case statefeed.LightClientFinalityUpdate:
+				data, ok := ev.Data.(*ethpbv2.LightClientFinalityUpdateWithVersion)
+				if !ok || data.Data == nil {
+					log.Errorf("Received incorrect type of light client finality update: %T", ev.Data)
+					continue
+				}
+				if err := s.handleLocalLightClientFinalityUpdate(data.Data); err != nil {
+					log.WithError(err).Error("Could not handle light client finality update")
+				}
+			case statefeed.LightClientOptimisticUpdate:
+				data, ok := ev.Data.(*ethpbv2.LightClientOptimisticUpdateWithVersion)
+				if !ok || data.Data == nil {
+					log.Errorf("Received incorrect type of light client optimistic update: %T", ev.Data)
+					continue
+				}
+				if err := s.handleLocalLightClientOptimisticUpdate(data.Data); err != nil {
+					log.WithError(err).Error("Could not handle light client optimistic update")
+				}
+			}
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier for light client updates")
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Service) handleLocalLightClientFinalityUpdate(data *ethpbv2.LightClientFinalityUpdate) error {
+	m, err := lightclient.FinalityUpdateToProto(data)
+	if err != nil {
+		return err
+	}
+	update, err := lightclienttypes.NewWrappedFinalityUpdate(m)
+	if err != nil {
+		return err
+	}
+	s.lcCache.setFinalityUpdate(update)
+	s.broadcastLightClientUpdate(m, update.SignatureSlot(), func() bool {
+		return s.lcCache.forwardFinalityUpdate(update)
+	})
+	return nil
+}
+
+func (s *Service) handleLocalLightClientOptimisticUpdate(data *ethpbv2.LightClientOptimisticUpdate) error {
+	m, err := lightclient.OptimisticUpdateToProto(data)
+	if err != nil {
+		return err
+	}
+	update, err := lightclienttypes.NewWrappedOptimisticUpdate(m)
+	if err != nil {
+		return err
+	}
+	s.lcCache.setOptimisticUpdate(update)
+	s.broadcastLightClientUpdate(m, update.SignatureSlot(), func() bool {
+		return s.lcCache.forwardOptimisticUpdate(update)
+	})
+	return nil
+}
+
+// broadcastLightClientUpdate broadcasts the update once it is due, unless a newer one has been forwarded by then.
+func (s *Service) broadcastLightClientUpdate(msg proto.Message, signatureSlot primitives.Slot, forward func() bool) {
+	if s.cfg.initialSync.Syncing() {
+		return
+	}
+	go func() {
+		if wait := time.Until(s.lightClientUpdateDue(signatureSlot)); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		if !forward() {
+			return
+		}
+		if err := s.cfg.p2p.Broadcast(s.ctx, msg); err != nil {
+			log.WithError(err).Debug("Could not broadcast light client update")
+		}
+	}()
+}
+
+func (*Service) lightClientFinalityUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	if _, err := lightclienttypes.NewWrappedFinalityUpdate(msg); err != nil {
+		return errors.Wrap(err, "incorrect type of light client finality update received")
+	}
+	return nil
+}
+
+func (*Service) lightClientOptimisticUpdateSubscriber(_ context.Context, msg proto.Message) error {
+	if _, err := lightclienttypes.NewWrappedOptimisticUpdate(msg); err != nil {
+		return errors.Wrap(err, "incorrect type of light client optimistic update received")
+	}
+	return nil
+}
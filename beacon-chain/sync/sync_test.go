@@ -14,10 +14,12 @@ func TestMain(m *testing.M) {
 
 	resetFlags := flags.Get()
 	flags.Init(&flags.GlobalFlags{
-		BlockBatchLimit:            64,
-		BlockBatchLimitBurstFactor: 10,
-		BlobBatchLimit:             8,
-		BlobBatchLimitBurstFactor:  2,
+		BlockBatchLimit:                 64,
+		BlockBatchLimitBurstFactor:      10,
+		BlobBatchLimit:                  8,
+		BlobBatchLimitBurstFactor:       2,
+		DataColumnBatchLimit:            256,
+		DataColumnBatchLimitBurstFactor: 2,
 	})
 	defer func() {
 		flags.Init(resetFlags)
@@ -0,0 +1,271 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	mockSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestValidateDataColumn_FromSelf(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	s := &Service{cfg: &config{p2p: p}}
+	result, err := s.validateDataColumn(ctx, s.cfg.p2p.PeerID(), nil)
+	require.NoError(t, err)
+	require.Equal(t, result, pubsub.ValidationAccept)
+}
+
+func TestValidateDataColumn_InitSync(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	s := &Service{cfg: &config{p2p: p, initialSync: &mockSync.Sync{IsSyncing: true}}}
+	result, err := s.validateDataColumn(ctx, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, result, pubsub.ValidationIgnore)
+}
+
+func TestValidateDataColumn_InvalidTopic(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	s := &Service{cfg: &config{p2p: p, initialSync: &mockSync.Sync{}}}
+	result, err := s.validateDataColumn(ctx, "", &pubsub.Message{
+		Message: &pb.Message{},
+	})
+	require.ErrorIs(t, errInvalidTopic, err)
+	require.Equal(t, result, pubsub.ValidationReject)
+}
+
+func TestValidateDataColumn_InvalidTopicIndex(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0)}
+	s := &Service{cfg: &config{p2p: p, initialSync: &mockSync.Sync{}, clock: startup.NewClock(chainService.Genesis, chainService.ValidatorsRoot)}}
+	s.newColumnVerifier = testNewColumnVerifier()
+
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, chainService.CurrentSlot()+1, 1)
+	msg := columns[0].DataColumnSidecar
+	buf := new(bytes.Buffer)
+	_, err := p.Encoding().EncodeGossip(buf, msg)
+	require.NoError(t, err)
+
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(msg)]
+	digest, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic = s.addDigestAndIndexToTopic(topic, digest, 1)
+	result, err := s.validateDataColumn(ctx, "", &pubsub.Message{
+		Message: &pb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		}})
+	require.ErrorContains(t, "/eth2/f5a5fd42/data_column_sidecar_1", err)
+	require.Equal(t, result, pubsub.ValidationReject)
+}
+
+func TestValidateDataColumn_AlreadySeenInCache(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0)}
+	s := &Service{
+		seenDataColumnCache: lruwrpr.New(10),
+		cfg:                 &config{chain: chainService, p2p: p, initialSync: &mockSync.Sync{}, clock: startup.NewClock(chainService.Genesis, chainService.ValidatorsRoot)}}
+	s.newColumnVerifier = testNewColumnVerifier()
+
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, chainService.CurrentSlot()+1, 1)
+	col := columns[3]
+	buf := new(bytes.Buffer)
+	_, err := p.Encoding().EncodeGossip(buf, col.DataColumnSidecar)
+	require.NoError(t, err)
+
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(col.DataColumnSidecar)]
+	digest, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic = s.addDigestAndIndexToTopic(topic, digest, 3)
+
+	s.setSeenDataColumnIndex(col.Slot(), col.ProposerIndex(), col.Index)
+	result, err := s.validateDataColumn(ctx, "", &pubsub.Message{
+		Message: &pb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		}})
+	require.NoError(t, err)
+	require.Equal(t, result, pubsub.ValidationIgnore)
+
+	// A different column of the same block is not deduplicated.
+	require.Equal(t, false, s.hasSeenDataColumnIndex(col.Slot(), col.ProposerIndex(), col.Index+1))
+}
+
+func TestValidateDataColumn_ErrorPathsWithMock(t *testing.T) {
+	tests := []struct {
+		error    error
+		verifier verification.NewDataColumnVerifier
+		result   pubsub.ValidationResult
+	}{
+		{
+			error: errors.New("data column index out of bound"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrDataColumnIndexInBounds: errors.New("data column index out of bound")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("slot too early"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSlotTooEarly: errors.New("slot too early")}
+			},
+			result: pubsub.ValidationIgnore,
+		},
+		{
+			error: errors.New("slot above finalized"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSlotAboveFinalized: errors.New("slot above finalized")}
+			},
+			result: pubsub.ValidationIgnore,
+		},
+		{
+			error: errors.New("valid proposer signature"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrValidProposerSignature: errors.New("valid proposer signature")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("sidecar parent seen"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarParentSeen: errors.New("sidecar parent seen")}
+			},
+			result: pubsub.ValidationIgnore,
+		},
+		{
+			error: errors.New("sidecar parent valid"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarParentValid: errors.New("sidecar parent valid")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("sidecar parent slot lower"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarParentSlotLower: errors.New("sidecar parent slot lower")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("descends from finalized"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarDescendsFromFinalized: errors.New("descends from finalized")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("inclusion proven"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarInclusionProven: errors.New("inclusion proven")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("kzg proof verified"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarKzgProofVerified: errors.New("kzg proof verified")}
+			},
+			result: pubsub.ValidationReject,
+		},
+		{
+			error: errors.New("sidecar proposer expected"),
+			verifier: func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+				return &verification.MockDataColumnVerifier{ErrSidecarProposerExpected: errors.New("sidecar proposer expected")}
+			},
+			result: pubsub.ValidationReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.error.Error(), func(t *testing.T) {
+			ctx := context.Background()
+			p := p2ptest.NewTestP2P(t)
+			chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0)}
+			s := &Service{
+				seenDataColumnCache: lruwrpr.New(10),
+				seenPendingBlocks:   make(map[[32]byte]bool),
+				cfg:                 &config{chain: chainService, p2p: p, initialSync: &mockSync.Sync{}, clock: startup.NewClock(chainService.Genesis, chainService.ValidatorsRoot)}}
+			s.newColumnVerifier = tt.verifier
+
+			_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, chainService.CurrentSlot()+1, 1)
+			msg := columns[0].DataColumnSidecar
+			buf := new(bytes.Buffer)
+			_, err := p.Encoding().EncodeGossip(buf, msg)
+			require.NoError(t, err)
+
+			topic := p2p.GossipTypeMapping[reflect.TypeOf(msg)]
+			digest, err := s.currentForkDigest()
+			require.NoError(t, err)
+			topic = s.addDigestAndIndexToTopic(topic, digest, 0)
+			result, err := s.validateDataColumn(ctx, "", &pubsub.Message{
+				Message: &pb.Message{
+					Data:  buf.Bytes(),
+					Topic: &topic,
+				}})
+			require.ErrorContains(t, tt.error.Error(), err)
+			require.Equal(t, result, tt.result)
+		})
+	}
+}
+
+func TestValidateDataColumn_Accept(t *testing.T) {
+	ctx := context.Background()
+	p := p2ptest.NewTestP2P(t)
+	chainService := &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0)}
+	s := &Service{
+		seenDataColumnCache: lruwrpr.New(10),
+		cfg:                 &config{chain: chainService, p2p: p, initialSync: &mockSync.Sync{}, clock: startup.NewClock(chainService.Genesis, chainService.ValidatorsRoot)}}
+
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, chainService.CurrentSlot()+1, 2)
+	col := columns[5]
+	s.newColumnVerifier = func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+		return &verification.MockDataColumnVerifier{CbVerifiedRODataColumn: func() (blocks.VerifiedRODataColumn, error) {
+			return blocks.NewVerifiedRODataColumn(dc), nil
+		}}
+	}
+	buf := new(bytes.Buffer)
+	_, err := p.Encoding().EncodeGossip(buf, col.DataColumnSidecar)
+	require.NoError(t, err)
+
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(col.DataColumnSidecar)]
+	digest, err := s.currentForkDigest()
+	require.NoError(t, err)
+	topic = s.addDigestAndIndexToTopic(topic, digest, 5)
+	msg := &pubsub.Message{
+		Message: &pb.Message{
+			Data:  buf.Bytes(),
+			Topic: &topic,
+		}}
+	result, err := s.validateDataColumn(ctx, "", msg)
+	require.NoError(t, err)
+	require.Equal(t, result, pubsub.ValidationAccept)
+	verified, ok := msg.ValidatorData.(blocks.VerifiedRODataColumn)
+	require.Equal(t, true, ok)
+	require.Equal(t, col.Index, verified.Index)
+	require.Equal(t, col.BlockRoot(), verified.BlockRoot())
+}
+
+func testNewColumnVerifier() verification.NewDataColumnVerifier {
+	return func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+		return &verification.MockDataColumnVerifier{}
+	}
+}
@@ -0,0 +1,132 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	lightclienttypes "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate validates a light client finality update received on gossip.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_finality_update
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	pb, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclienttypes.NewWrappedFinalityUpdate(pb)
+	if err != nil {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+
+	// [IGNORE] The finalized header is newer than the one of any previously forwarded update.
+	if !s.lcCache.isNewerFinalityUpdate(update) {
+		return pubsub.ValidationIgnore, nil
+	}
+	// [IGNORE] The update is received after one third of the signature slot has passed.
+	if s.isEarlyLightClientUpdate(update.SignatureSlot()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	// [IGNORE] The update matches the locally computed one exactly.
+	if !sameLightClientUpdate(update, s.lcCache.latestFinalityUpdate()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	if !s.lcCache.forwardFinalityUpdate(update) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	msg.ValidatorData = m
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate validates a light client optimistic update received on gossip.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_optimistic_update
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	pb, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclienttypes.NewWrappedOptimisticUpdate(pb)
+	if err != nil {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+
+	// [IGNORE] The attested header is newer than the one of any previously forwarded update.
+	if !s.lcCache.isNewerOptimisticUpdate(update) {
+		return pubsub.ValidationIgnore, nil
+	}
+	// [IGNORE] The update is received after one third of the signature slot has passed.
+	if s.isEarlyLightClientUpdate(update.SignatureSlot()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	// [IGNORE] The update matches the locally computed one exactly.
+	if !sameLightClientUpdate(update, s.lcCache.latestOptimisticUpdate()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	if !s.lcCache.forwardOptimisticUpdate(update) {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	msg.ValidatorData = m
+	return pubsub.ValidationAccept, nil
+}
+
+// isEarlyLightClientUpdate reports whether an update signed at the slot arrived before it was due,
+// allowing for the maximum gossip clock disparity.
+func (s *Service) isEarlyLightClientUpdate(signatureSlot primitives.Slot) bool {
+	due := s.lightClientUpdateDue(signatureSlot).Add(-params.BeaconConfig().MaximumGossipClockDisparityDuration())
+	return prysmTime.Now().Before(due)
+}
+
+// sameLightClientUpdate reports whether both updates have the same SSZ encoding.
+func sameLightClientUpdate(received, local ssz.Marshaler) bool {
+	if local == nil {
+		return false
+	}
+	receivedBytes, err := received.MarshalSSZ()
+	if err != nil {
+		return false
+	}
+	localBytes, err := local.MarshalSSZ()
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(receivedBytes, localBytes)
+}
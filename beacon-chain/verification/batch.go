@@ -92,3 +92,61 @@ func (batch *BlobBatchVerifier) verifyOneBlob(sc blocks.ROBlob) (blocks.Verified
 
 	return bv.VerifiedROBlob()
 }
+
+// NewDataColumnBatchVerifier initializes a data column batch verifier, which verifies data column sidecars
+// retrieved over RPC for a block that has already been verified, with a new DataColumnVerifier for each column.
+func NewDataColumnBatchVerifier(newVerifier NewDataColumnVerifier, reqs []Requirement) *DataColumnBatchVerifier {
+	return &DataColumnBatchVerifier{
+		newVerifier: newVerifier,
+		reqs:        reqs,
+	}
+}
+
+// DataColumnBatchVerifier is the data column counterpart of BlobBatchVerifier.
+type DataColumnBatchVerifier struct {
+	newVerifier NewDataColumnVerifier
+	reqs        []Requirement
+}
+
+// VerifiedRODataColumns satisfies the das.DataColumnBatchVerifier interface, used by das.AvailabilityStore.
+func (batch *DataColumnBatchVerifier) VerifiedRODataColumns(_ context.Context, blk blocks.ROBlock, columns []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	blkSig := blk.Signature()
+	for i := range columns {
+		if blkSig != bytesutil.ToBytes96(columns[i].SignedBlockHeader.Signature) {
+			return nil, ErrBatchSignatureMismatch
+		}
+		if blk.Root() != columns[i].BlockRoot() {
+			return nil, ErrBatchBlockRootMismatch
+		}
+	}
+	vs := make([]blocks.VerifiedRODataColumn, len(columns))
+	for i := range columns {
+		vc, err := batch.verifyOneColumn(columns[i])
+		if err != nil {
+			return nil, err
+		}
+		vs[i] = vc
+	}
+	return vs, nil
+}
+
+func (batch *DataColumnBatchVerifier) verifyOneColumn(column blocks.RODataColumn) (blocks.VerifiedRODataColumn, error) {
+	vc := blocks.VerifiedRODataColumn{}
+	cv := batch.newVerifier(column, batch.reqs)
+	// The signature of the block was verified before the DA check, and VerifiedRODataColumns made sure it matches.
+	cv.SatisfyRequirement(RequireValidProposerSignature)
+
+	if err := cv.DataColumnIndexInBounds(); err != nil {
+		return vc, err
+	}
+	if err := cv.SidecarInclusionProven(); err != nil {
+		return vc, err
+	}
+	if err := cv.SidecarKzgProofVerified(); err != nil {
+		return vc, err
+	}
+	return cv.VerifiedRODataColumn()
+}
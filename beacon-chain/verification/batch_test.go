@@ -7,8 +7,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestBatchVerifier(t *testing.T) {
@@ -187,3 +189,29 @@ func TestBatchVerifier(t *testing.T) {
 		})
 	}
 }
+
+func TestDataColumnBatchVerifier(t *testing.T) {
+	ctx := context.Background()
+	ini := &Initializer{}
+	nv := func(dc blocks.RODataColumn, reqs []Requirement) DataColumnVerifier {
+		return ini.NewDataColumnVerifier(dc, reqs)
+	}
+	blk, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	bv := NewDataColumnBatchVerifier(nv, InitsyncColumnSidecarRequirements)
+
+	verified, err := bv.VerifiedRODataColumns(ctx, blk, []blocks.RODataColumn{columns[3], columns[9]})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(verified))
+	require.Equal(t, uint64(3), verified[0].Index)
+	require.Equal(t, uint64(9), verified[1].Index)
+
+	_, otherColumns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 2, 1)
+	_, err = bv.VerifiedRODataColumns(ctx, blk, []blocks.RODataColumn{otherColumns[3]})
+	require.ErrorIs(t, err, ErrBatchBlockRootMismatch)
+
+	tampered, err := blocks.NewRODataColumnWithRoot(proto.Clone(columns[3].DataColumnSidecar).(*ethpb.DataColumnSidecar), blk.Root())
+	require.NoError(t, err)
+	tampered.Column[0][0] ^= 1
+	_, err = bv.VerifiedRODataColumns(ctx, blk, []blocks.RODataColumn{columns[4], tampered})
+	require.ErrorIs(t, err, ErrSidecarKzgProofInvalid)
+}
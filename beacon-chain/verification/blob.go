@@ -27,6 +27,7 @@ const (
 	RequireSidecarInclusionProven
 	RequireSidecarKzgProofVerified
 	RequireSidecarProposerExpected
+	RequireDataColumnIndexInBounds
 )
 
 var allSidecarRequirements = []Requirement{
@@ -0,0 +1,115 @@
+package verification
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// testColumn returns a deep copy of a data column sidecar for a block with a single blob, so that it can be tampered with.
+func testColumn(t *testing.T, index uint64) blocks.RODataColumn {
+	_, columns := util.GenerateTestDenebBlockWithColumns(t, [32]byte{}, 1, 1)
+	col, err := blocks.NewRODataColumnWithRoot(proto.Clone(columns[index].DataColumnSidecar).(*ethpb.DataColumnSidecar), columns[index].BlockRoot())
+	require.NoError(t, err)
+	return col
+}
+
+func TestDataColumnIndexInBounds(t *testing.T) {
+	ini := &Initializer{}
+	col := testColumn(t, 3)
+	v := ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.NoError(t, v.DataColumnIndexInBounds())
+	require.Equal(t, true, v.results.executed(RequireDataColumnIndexInBounds))
+	require.NoError(t, v.results.result(RequireDataColumnIndexInBounds))
+
+	col.Index = fieldparams.NumberOfColumns
+	v = ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.DataColumnIndexInBounds(), ErrColumnIndexInvalid)
+	require.NotNil(t, v.results.result(RequireDataColumnIndexInBounds))
+
+	col.Index = 3
+	col.KzgProofs = nil
+	v = ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.DataColumnIndexInBounds(), ErrColumnIndexInvalid)
+}
+
+func TestColumnSidecarInclusionProven(t *testing.T) {
+	ini := &Initializer{}
+	col := testColumn(t, 0)
+	v := ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.NoError(t, v.SidecarInclusionProven())
+	require.NoError(t, v.results.result(RequireSidecarInclusionProven))
+
+	col.SignedBlockHeader.Header.BodyRoot[0] ^= 255
+	v = ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarInclusionProven(), ErrSidecarInclusionProofInvalid)
+	require.NotNil(t, v.results.result(RequireSidecarInclusionProven))
+}
+
+func TestColumnSidecarKzgProofVerified(t *testing.T) {
+	ini := &Initializer{}
+	col := testColumn(t, 9)
+	v := ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.NoError(t, v.SidecarKzgProofVerified())
+	require.NoError(t, v.results.result(RequireSidecarKzgProofVerified))
+
+	col.Column[0][5] ^= 1
+	v = ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarKzgProofVerified(), ErrSidecarKzgProofInvalid)
+	require.NotNil(t, v.results.result(RequireSidecarKzgProofVerified))
+}
+
+func TestColumnSidecarParentSlotLower(t *testing.T) {
+	col := testColumn(t, 0)
+	cases := []struct {
+		name   string
+		fcSlot primitives.Slot
+		fcErr  error
+		err    error
+	}{
+		{name: "not in fc", fcErr: errors.New("not in forkchoice"), err: ErrSlotNotAfterParent},
+		{name: "in fc, slot lower", fcSlot: col.Slot() - 1},
+		{name: "in fc, slot equal", fcSlot: col.Slot(), err: ErrSlotNotAfterParent},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ini := &Initializer{shared: &sharedResources{fc: &mockForkchoicer{SlotCB: func(_ [32]byte) (primitives.Slot, error) {
+				return c.fcSlot, c.fcErr
+			}}}}
+			v := ini.NewDataColumnVerifier(col, GossipColumnSidecarRequirements)
+			err := v.SidecarParentSlotLower()
+			require.Equal(t, true, v.results.executed(RequireSidecarParentSlotLower))
+			if c.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestVerifiedRODataColumn(t *testing.T) {
+	ini := &Initializer{}
+	col := testColumn(t, 1)
+	v := ini.NewDataColumnVerifier(col, ByRootColumnSidecarRequirements)
+	_, err := v.VerifiedRODataColumn()
+	require.ErrorIs(t, err, ErrColumnInvalid)
+
+	require.NoError(t, v.DataColumnIndexInBounds())
+	require.NoError(t, v.SidecarInclusionProven())
+	_, err = v.VerifiedRODataColumn()
+	require.ErrorIs(t, err, ErrColumnInvalid)
+
+	require.NoError(t, v.SidecarKzgProofVerified())
+	verified, err := v.VerifiedRODataColumn()
+	require.NoError(t, err)
+	require.Equal(t, col.BlockRoot(), verified.BlockRoot())
+	require.Equal(t, col.Index, verified.Index)
+}
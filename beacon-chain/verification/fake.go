@@ -42,3 +42,21 @@ func FakeVerifySliceForTest(t *testing.T, b []blocks.ROBlob) []blocks.VerifiedRO
 	}
 	return vbs
 }
+
+// DataColumnSidecarNoop is a FAKE verification function that simply launders a RODataColumn->VerifiedRODataColumn.
+// It is meant for data columns loaded from storage, which only holds fully verified data columns.
+func DataColumnSidecarNoop(dc blocks.RODataColumn) (blocks.VerifiedRODataColumn, error) {
+	return blocks.NewVerifiedRODataColumn(dc), nil
+}
+
+// FakeVerifyDataColumnSliceForTest can be used by tests that need a []VerifiedRODataColumn but don't want to do all the
+// expensive set up to perform full validation.
+func FakeVerifyDataColumnSliceForTest(t *testing.T, dcs []blocks.RODataColumn) []blocks.VerifiedRODataColumn {
+	// log so that t is truly required
+	t.Log("producing fake []VerifiedRODataColumn for a test")
+	vdcs := make([]blocks.VerifiedRODataColumn, len(dcs))
+	for i := range dcs {
+		vdcs[i] = blocks.NewVerifiedRODataColumn(dcs[i])
+	}
+	return vdcs
+}
@@ -57,6 +57,16 @@ func (ini *Initializer) NewBlobVerifier(b blocks.ROBlob, reqs []Requirement) *RO
 	}
 }
 
+// NewDataColumnVerifier creates a DataColumnVerifier for a single data column sidecar, with the given set of requirements.
+func (ini *Initializer) NewDataColumnVerifier(dc blocks.RODataColumn, reqs []Requirement) *RODataColumnVerifier {
+	return &RODataColumnVerifier{
+		sharedResources:        ini.shared,
+		column:                 dc,
+		results:                newResults(reqs...),
+		verifyColumnCommitment: kzg.VerifyDataColumns,
+	}
+}
+
 // InitializerWaiter provides an Initializer once all dependent resources are ready
 // via the WaitForInitializer method.
 type InitializerWaiter struct {
@@ -29,3 +29,24 @@ type BlobVerifier interface {
 // NewBlobVerifier is a function signature that can be used by code that needs to be
 // able to mock Initializer.NewBlobVerifier without complex setup.
 type NewBlobVerifier func(b blocks.ROBlob, reqs []Requirement) BlobVerifier
+
+// DataColumnVerifier defines the methods implemented by the RODataColumnVerifier.
+type DataColumnVerifier interface {
+	VerifiedRODataColumn() (blocks.VerifiedRODataColumn, error)
+	DataColumnIndexInBounds() (err error)
+	NotFromFutureSlot() (err error)
+	SlotAboveFinalized() (err error)
+	ValidProposerSignature(ctx context.Context) (err error)
+	SidecarParentSeen(parentSeen func([32]byte) bool) (err error)
+	SidecarParentValid(badParent func([32]byte) bool) (err error)
+	SidecarParentSlotLower() (err error)
+	SidecarDescendsFromFinalized() (err error)
+	SidecarInclusionProven() (err error)
+	SidecarKzgProofVerified() (err error)
+	SidecarProposerExpected(ctx context.Context) (err error)
+	SatisfyRequirement(Requirement)
+}
+
+// NewDataColumnVerifier is a function signature that can be used by code that needs to be
+// able to mock Initializer.NewDataColumnVerifier without complex setup.
+type NewDataColumnVerifier func(dc blocks.RODataColumn, reqs []Requirement) DataColumnVerifier
@@ -29,6 +29,8 @@ func (r Requirement) String() string {
 		return "RequireSidecarKzgProofVerified"
 	case RequireSidecarProposerExpected:
 		return "RequireSidecarProposerExpected"
+	case RequireDataColumnIndexInBounds:
+		return "RequireDataColumnIndexInBounds"
 	default:
 		return unknownRequirementName
 	}
@@ -28,6 +28,18 @@ var (
 		Usage: "A MEV builder relay string http endpoint, this will be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
 		Value: "",
 	}
+	// MevRelayEndpoints provides HTTP access endpoints to several MEV relays, whose bids are compared.
+	MevRelayEndpoints = &cli.StringSliceFlag{
+		Name: "http-mev-relays",
+		Usage: "A comma-separated list of MEV relay http endpoints. Headers are requested from every relay and the highest bid is used, " +
+			"without running a separate mev-boost sidecar. Can be combined with --http-mev-relay",
+	}
+	// MevRelayGetHeaderTimeoutMs sets the deadline for the relays to return their bids when several relays are used.
+	MevRelayGetHeaderTimeoutMs = &cli.Uint64Flag{
+		Name:  "http-mev-relays-get-header-timeout-ms",
+		Usage: "Time in milliseconds given to the relays of --http-mev-relays to return their bids",
+		Value: 950,
+	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
 		Name:  "max-builder-consecutive-missed-slots",
 		Usage: "Number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction",
@@ -154,6 +166,20 @@ var (
 		Aliases: []string{"grpc-gateway-corsdomain"},
 	}
 
+	// EventReplayBufferSize specifies the number of events retained per topic for clients reconnecting to the event stream.
+	EventReplayBufferSize = &cli.IntFlag{
+		Name:  "event-replay-buffer-size",
+		Usage: "Number of recent events retained per topic and replayed to event stream clients reconnecting with a Last-Event-ID header. Set to 0 to disable event ids and replay.",
+		Value: 64,
+	}
+
+	// HealthThresholds sets the thresholds of the checks of the detailed health report.
+	HealthThresholds = &cli.StringSliceFlag{
+		Name: "health-thresholds",
+		Usage: "Comma-separated thresholds of the checks of /prysm/v1/node/health/detailed, of the form <check>_warn=<value> or <check>_fail=<value>, " +
+			"e.g. peers_warn=20,head_lag_fail=16. The <check>_warn and <check>_fail query parameters of a request override them",
+	}
+
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -204,6 +230,18 @@ var (
 		Usage: "The factor by which blob batch limit may increase on burst.",
 		Value: 2,
 	}
+	// DataColumnBatchLimit specifies the requested data column batch size.
+	DataColumnBatchLimit = &cli.IntFlag{
+		Name:  "data-column-batch-limit",
+		Usage: "The amount of data column sidecars the local peer is bounded to request and respond to in a batch.",
+		Value: 4096,
+	}
+	// DataColumnBatchLimitBurstFactor specifies the factor by which data column batch size may increase.
+	DataColumnBatchLimitBurstFactor = &cli.IntFlag{
+		Name:  "data-column-batch-limit-burst-factor",
+		Usage: "The factor by which data column batch limit may increase on burst.",
+		Value: 2,
+	}
 	// DisableDebugRPCEndpoints disables the debug Beacon API namespace.
 	DisableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "disable-debug-rpc-endpoints",
@@ -214,6 +252,11 @@ var (
 		Name:  "subscribe-all-subnets",
 		Usage: "Subscribe to all possible attestation and sync subnets.",
 	}
+	// SubscribeAllDataSubnets defines a flag to specify whether to custody and subscribe to all data column subnets or not.
+	SubscribeAllDataSubnets = &cli.BoolFlag{
+		Name:  "subscribe-all-data-subnets",
+		Usage: "Custody and subscribe to all data column sidecar subnets once PeerDAS is active, instead of the minimum custody requirement.",
+	}
 	// HistoricalSlasherNode is a set of beacon node flags required for performing historical detection with a slasher.
 	HistoricalSlasherNode = &cli.BoolFlag{
 		Name:  "historical-slasher-node",
@@ -296,4 +339,57 @@ var (
 		Usage: "Directory for the slasher database",
 		Value: cmd.DefaultDataDir(),
 	}
+	// EnableRewardsIndexer enables the background indexing of validator rewards.
+	EnableRewardsIndexer = &cli.BoolFlag{
+		Name: "enable-rewards-indexer",
+		Usage: "Indexes the rewards and penalties of every validator for each finalized epoch into the database, " +
+			"to be served by the /prysm/v1/validators/rewards endpoint. The index grows with the number of active validators.",
+	}
+	// RewardsIndexerStartEpoch defines the first epoch indexed by the rewards indexer.
+	RewardsIndexerStartEpoch = &cli.Uint64Flag{
+		Name:  "rewards-indexer-start-epoch",
+		Usage: "First epoch indexed by the rewards indexer. Epochs before the Altair fork and before the checkpoint sync origin are never indexed.",
+	}
+	// RewardsIndexerRetentionEpochs defines the number of epochs kept by the rewards indexer.
+	RewardsIndexerRetentionEpochs = &cli.Uint64Flag{
+		Name: "rewards-indexer-retention-epochs",
+		Usage: "Number of indexed epochs of validator rewards kept in the database, the rewards of older epochs are pruned. " +
+			"The default of 8192 epochs is about 36 days, set to 0 to keep every indexed epoch.",
+		Value: 8192,
+	}
+	// StandaloneLightClient runs the beacon node as a standalone light client.
+	StandaloneLightClient = &cli.BoolFlag{
+		Name: "standalone-light-client",
+		Usage: "Runs as a light client which follows the chain from the trusted block root, verifying the light client data " +
+			"served by the beacon node of --light-client-beacon-api. No database is opened, and no blocks or states are processed. " +
+			"The genesis of the network is read from --genesis-state, which is only optional on mainnet, sepolia and holesky.",
+	}
+	// LightClientTrustedBlockRoot defines the block root the standalone light client starts syncing from.
+	LightClientTrustedBlockRoot = &cli.StringFlag{
+		Name:  "light-client-trusted-block-root",
+		Usage: "Hex encoded root of a recent finalized block, which the standalone light client trusts to start syncing from.",
+	}
+	// LightClientBeaconAPI defines the beacon node serving light client data to the standalone light client.
+	LightClientBeaconAPI = &cli.StringFlag{
+		Name:  "light-client-beacon-api",
+		Usage: "Beacon API URL of the beacon node serving light client data to the standalone light client, e.g. http://localhost:3500.",
+	}
+	// ForkChoiceJournalDir enables the fork choice journal, written to the given directory.
+	ForkChoiceJournalDir = &cli.StringFlag{
+		Name: "forkchoice-journal-dir",
+		Usage: "Journals every block, attestation, slot tick, checkpoint update and payload invalidation processed by fork choice " +
+			"into rotating files in this directory, from which `prysmctl forkchoice rebuild` can rebuild the fork choice store at any past moment.",
+	}
+	// ForkChoiceJournalMaxFileSize defines the size after which a fork choice journal file is rotated.
+	ForkChoiceJournalMaxFileSize = &cli.Uint64Flag{
+		Name:  "forkchoice-journal-max-file-size",
+		Usage: "Size in megabytes after which a fork choice journal file is rotated.",
+		Value: 256,
+	}
+	// ForkChoiceJournalMaxFiles defines the number of fork choice journal files kept.
+	ForkChoiceJournalMaxFiles = &cli.IntFlag{
+		Name:  "forkchoice-journal-max-files",
+		Usage: "Number of fork choice journal files kept, the oldest files are deleted on rotation.",
+		Value: 8,
+	}
 )
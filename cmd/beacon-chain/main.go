@@ -52,6 +52,8 @@ var appFlags = []cli.Flag{
 	flags.HTTPServerHost,
 	flags.HTTPServerPort,
 	flags.HTTPServerCorsDomain,
+	flags.EventReplayBufferSize,
+	flags.HealthThresholds,
 	flags.MinSyncPeers,
 	flags.ContractDeploymentBlock,
 	flags.SetGCPercent,
@@ -59,12 +61,15 @@ var appFlags = []cli.Flag{
 	flags.BlockBatchLimitBurstFactor,
 	flags.BlobBatchLimit,
 	flags.BlobBatchLimitBurstFactor,
+	flags.DataColumnBatchLimit,
+	flags.DataColumnBatchLimitBurstFactor,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
 	flags.DisableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
+	flags.SubscribeAllDataSubnets,
 	flags.HistoricalSlasherNode,
 	flags.ChainID,
 	flags.NetworkID,
@@ -77,6 +82,8 @@ var appFlags = []cli.Flag{
 	flags.TerminalBlockHashOverride,
 	flags.TerminalBlockHashActivationEpochOverride,
 	flags.MevRelayEndpoint,
+	flags.MevRelayEndpoints,
+	flags.MevRelayGetHeaderTimeoutMs,
 	flags.MaxBuilderEpochMissedSlots,
 	flags.MaxBuilderConsecutiveMissedSlots,
 	flags.EngineEndpointTimeoutSeconds,
@@ -134,6 +141,7 @@ var appFlags = []cli.Flag{
 	cmd.RestoreSourceFileFlag,
 	cmd.RestoreTargetDirFlag,
 	cmd.ValidatorMonitorIndicesFlag,
+	cmd.EnableValidatorMonitorFlag,
 	cmd.ApiTimeoutFlag,
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
@@ -141,6 +149,15 @@ var appFlags = []cli.Flag{
 	genesis.StatePath,
 	genesis.BeaconAPIURL,
 	flags.SlasherDirFlag,
+	flags.EnableRewardsIndexer,
+	flags.RewardsIndexerStartEpoch,
+	flags.RewardsIndexerRetentionEpochs,
+	flags.StandaloneLightClient,
+	flags.LightClientTrustedBlockRoot,
+	flags.LightClientBeaconAPI,
+	flags.ForkChoiceJournalDir,
+	flags.ForkChoiceJournalMaxFileSize,
+	flags.ForkChoiceJournalMaxFiles,
 	flags.JwtId,
 	storage.BlobStoragePathFlag,
 	storage.BlobRetentionEpochFlag,
@@ -290,6 +307,15 @@ func startNode(ctx *cli.Context, cancel context.CancelFunc) error {
 		gethlog.Root().SetHandler(glogger)
 	}
 
+	if ctx.Bool(flags.StandaloneLightClient.Name) {
+		lc, err := node.NewLightClient(ctx, cancel, ctx.String(genesis.StatePath.Name))
+		if err != nil {
+			return fmt.Errorf("unable to start light client: %w", err)
+		}
+		lc.Start()
+		return nil
+	}
+
 	blockchainFlagOpts, err := blockchaincmd.FlagOptions(ctx)
 	if err != nil {
 		return err
@@ -76,6 +76,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreSourceFileFlag,
 			cmd.RestoreTargetDirFlag,
 			cmd.ValidatorMonitorIndicesFlag,
+			cmd.EnableValidatorMonitorFlag,
 			cmd.ApiTimeoutFlag,
 		},
 	},
@@ -106,6 +107,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HTTPServerHost,
 			flags.HTTPServerPort,
 			flags.HTTPServerCorsDomain,
+			flags.EventReplayBufferSize,
+			flags.HealthThresholds,
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
@@ -115,8 +118,11 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BlockBatchLimitBurstFactor,
 			flags.BlobBatchLimit,
 			flags.BlobBatchLimitBurstFactor,
+			flags.DataColumnBatchLimit,
+			flags.DataColumnBatchLimitBurstFactor,
 			flags.DisableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
+			flags.SubscribeAllDataSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
 			flags.NetworkID,
@@ -125,10 +131,21 @@ var appHelpFlagGroups = []flagGroup{
 			flags.MinPeersPerSubnet,
 			flags.MaxConcurrentDials,
 			flags.MevRelayEndpoint,
+			flags.MevRelayEndpoints,
+			flags.MevRelayGetHeaderTimeoutMs,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
+			flags.EnableRewardsIndexer,
+			flags.RewardsIndexerStartEpoch,
+			flags.RewardsIndexerRetentionEpochs,
+			flags.StandaloneLightClient,
+			flags.LightClientTrustedBlockRoot,
+			flags.LightClientBeaconAPI,
+			flags.ForkChoiceJournalDir,
+			flags.ForkChoiceJournalMaxFileSize,
+			flags.ForkChoiceJournalMaxFiles,
 			flags.LocalBlockValueBoost,
 			flags.MinBuilderBid,
 			flags.MinBuilderDiff,
@@ -254,6 +254,12 @@ var (
 		Name:  "monitor-indices",
 		Usage: "List of validator indices to track performance",
 	}
+	// EnableValidatorMonitorFlag enables the validator monitor without any tracked validator,
+	// so that validators can be tracked at runtime through the API.
+	EnableValidatorMonitorFlag = &cli.BoolFlag{
+		Name:  "enable-validator-monitor",
+		Usage: "Enables the validator monitor even if no validator index is given with --monitor-indices, so that validators can be tracked at runtime through the API",
+	}
 
 	// RestoreSourceFileFlag specifies the filepath to the backed-up database file
 	// which will be used to restore the database.
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var eraFlags = struct {
+	Path            string
+	EraDir          string
+	StartEra        uint64
+	EndEra          uint64
+	ChainConfigFile string
+}{}
+
+var (
+	eraPathFlag = &cli.StringFlag{
+		Name:        "path",
+		Usage:       "path to directory containing beaconchain.db. The beacon node must be stopped",
+		Destination: &eraFlags.Path,
+		Required:    true,
+	}
+	eraDirFlag = &cli.StringFlag{
+		Name:        "era-dir",
+		Usage:       "directory of the era files",
+		Destination: &eraFlags.EraDir,
+		Required:    true,
+	}
+	eraChainConfigFileFlag = &cli.StringFlag{
+		Name:        "chain-config-file",
+		Usage:       "path to the chain config of the network, mainnet is used by default",
+		Destination: &eraFlags.ChainConfigFile,
+	}
+)
+
+var exportEraCmd = &cli.Command{
+	Name: "export-era",
+	Usage: "export the finalized blocks and states of the beacon db to era files, one file per " +
+		"SLOTS_PER_HISTORICAL_ROOT slots. Blocks must be stored with their full execution payloads",
+	Action: func(c *cli.Context) error {
+		if err := exportEraAction(c); err != nil {
+			return errors.Wrap(err, "could not export eras")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		eraPathFlag,
+		eraDirFlag,
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export",
+			Destination: &eraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export",
+			Destination: &eraFlags.EndEra,
+			DefaultText: "last finalized era",
+		},
+		eraChainConfigFileFlag,
+	},
+}
+
+var importEraCmd = &cli.Command{
+	Name: "import-era",
+	Usage: "import the blocks and states of the era files of a directory into a beacon db, so that the " +
+		"beacon node starts with their history instead of backfilling it from peers",
+	Action: func(c *cli.Context) error {
+		if err := importEraAction(c); err != nil {
+			return errors.Wrap(err, "could not import eras")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		eraPathFlag,
+		eraDirFlag,
+		eraChainConfigFileFlag,
+	},
+}
+
+func exportEraAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	f := &eraFlags
+	if err := loadEraChainConfig(); err != nil {
+		return err
+	}
+
+	// Opening the database creates it when it does not exist, which is not wanted for an export.
+	exists, err := file.Exists(filepath.Join(f.Path, kv.DatabaseFileName), file.Regular)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no %s found in %s", kv.DatabaseFileName, f.Path)
+	}
+	beaconDB, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at path %s", f.Path)
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+
+	exporter, err := era.NewExporter(ctx, beaconDB)
+	if err != nil {
+		return err
+	}
+	end := exporter.LastEra()
+	if cliCtx.IsSet("end-era") {
+		end = f.EndEra
+	}
+	paths, err := exporter.Export(ctx, f.EraDir, f.StartEra, end)
+	if err != nil {
+		return err
+	}
+	log.WithField("files", len(paths)).WithField("dir", f.EraDir).Info("Exported eras")
+	return nil
+}
+
+func importEraAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	f := &eraFlags
+	if err := loadEraChainConfig(); err != nil {
+		return err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(f.EraDir, "*.era"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no era files found in %s", f.EraDir)
+	}
+	beaconDB, err := kv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at path %s", f.Path)
+	}
+	defer func() {
+		if err := beaconDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	if err := era.Import(ctx, beaconDB, paths); err != nil {
+		return err
+	}
+	log.WithField("files", len(paths)).WithField("path", f.Path).Info("Imported eras")
+	return nil
+}
+
+func loadEraChainConfig() error {
+	if eraFlags.ChainConfigFile == "" {
+		return nil
+	}
+	if err := params.LoadChainConfigFile(eraFlags.ChainConfigFile, nil); err != nil {
+		return errors.Wrap(err, "could not load chain config file")
+	}
+	return nil
+}
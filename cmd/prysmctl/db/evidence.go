@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	slasherprysm "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var evidenceFlags = struct {
+	Path             string
+	FromEpoch        uint64
+	ToEpoch          uint64
+	ValidatorIndices cli.Uint64Slice
+	Output           string
+}{}
+
+var evidenceCmd = &cli.Command{
+	Name: "slasher-evidence-export",
+	Usage: "export the slashings detected by the slasher, and optionally the spans and proposals of validators, " +
+		"as JSON for a range of epochs. The beacon node must be stopped",
+	Action: func(c *cli.Context) error {
+		if err := evidenceAction(c); err != nil {
+			return errors.Wrap(err, "could not export slasher evidence")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "db-path-directory",
+			Usage:       "path to directory containing slasher.db",
+			Destination: &evidenceFlags.Path,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "from-epoch",
+			Usage:       "first epoch of the range to export",
+			Destination: &evidenceFlags.FromEpoch,
+		},
+		&cli.Uint64Flag{
+			Name:        "to-epoch",
+			Usage:       "last epoch of the range to export",
+			Destination: &evidenceFlags.ToEpoch,
+			Required:    true,
+		},
+		&cli.Uint64SliceFlag{
+			Name: "validator-indices",
+			Usage: "validators whose spans and proposals are exported, can be repeated. Spans are only exported " +
+				"for the epochs of the range which are within the slasher history length of the last epoch written for the validator",
+			Destination: &evidenceFlags.ValidatorIndices,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the JSON file to write, the evidence is printed to stdout by default",
+			Destination: &evidenceFlags.Output,
+		},
+	},
+}
+
+// slasherEvidence is the JSON document written by the slasher evidence export.
+type slasherEvidence struct {
+	FromEpoch  string                     `json:"from_epoch"`
+	ToEpoch    string                     `json:"to_epoch"`
+	Slashings  *structs.DetectedSlashings `json:"slashings"`
+	Validators []*validatorEvidence       `json:"validators,omitempty"`
+}
+
+type validatorEvidence struct {
+	Index     string                       `json:"index"`
+	Spans     []*structs.SlasherEpochSpans `json:"spans"`
+	Proposals []*structs.SlasherProposal   `json:"proposals"`
+}
+
+func evidenceAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	f := &evidenceFlags
+	from, to := primitives.Epoch(f.FromEpoch), primitives.Epoch(f.ToEpoch)
+	if from > to {
+		return fmt.Errorf("--from-epoch %d is greater than --to-epoch %d", from, to)
+	}
+
+	// Opening the database creates it when it does not exist, which is not wanted for an export.
+	exists, err := file.Exists(filepath.Join(f.Path, slasherkv.DatabaseFileName), file.Regular)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no %s found in %s", slasherkv.DatabaseFileName, f.Path)
+	}
+	d, err := slasherkv.NewKVStore(ctx, f.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at path %s", f.Path)
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	q := slasher.NewQuerier(d, slasher.DefaultParams())
+
+	detected, err := q.DetectedSlashings(ctx, from, to)
+	if err != nil {
+		return errors.Wrap(err, "could not get detected slashings")
+	}
+	slashings, err := slasherprysm.SlashingsData(detected)
+	if err != nil {
+		return err
+	}
+	evidence := &slasherEvidence{
+		FromEpoch: strconv.FormatUint(uint64(from), 10),
+		ToEpoch:   strconv.FormatUint(uint64(to), 10),
+		Slashings: slashings,
+	}
+
+	for _, idx := range f.ValidatorIndices.Value() {
+		spans, err := validatorSpans(ctx, q, primitives.ValidatorIndex(idx), from, to)
+		if err != nil {
+			return errors.Wrapf(err, "could not get spans of validator %d", idx)
+		}
+		proposals, err := q.ValidatorProposalsInEpochs(ctx, primitives.ValidatorIndex(idx), from, to)
+		if err != nil {
+			return errors.Wrapf(err, "could not get proposals of validator %d", idx)
+		}
+		evidence.Validators = append(evidence.Validators, &validatorEvidence{
+			Index:     strconv.FormatUint(idx, 10),
+			Spans:     slasherprysm.SpansData(spans),
+			Proposals: slasherprysm.ProposalsData(proposals),
+		})
+	}
+
+	enc, err := json.MarshalIndent(evidence, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal evidence")
+	}
+	if f.Output == "" {
+		_, err = fmt.Fprintln(cliCtx.App.Writer, string(enc))
+		return err
+	}
+	if err := file.WriteFile(f.Output, enc); err != nil {
+		return errors.Wrapf(err, "could not write evidence to %s", f.Output)
+	}
+	log.WithFields(log.Fields{
+		"attesterSlashings": len(slashings.AttesterSlashings),
+		"proposerSlashings": len(slashings.ProposerSlashings),
+		"path":              f.Output,
+	}).Info("Exported slasher evidence")
+	return nil
+}
+
+// validatorSpans returns the spans of a validator for the epochs of the range which are still stored.
+// The spans are kept in a ring of history length epochs, ending at the last epoch the slasher wrote for
+// the validator, so the range is narrowed to that window.
+func validatorSpans(
+	ctx context.Context, q *slasher.Querier, idx primitives.ValidatorIndex, from, to primitives.Epoch,
+) ([]*slashertypes.EpochSpans, error) {
+	current, ok, err := q.LastEpochWritten(ctx, idx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return []*slashertypes.EpochSpans{}, nil
+	}
+	if history := q.HistoryLength(); current >= history {
+		from = max(from, current-history+1)
+	}
+	to = min(to, current)
+	if from > to {
+		return []*slashertypes.EpochSpans{}, nil
+	}
+	return q.ValidatorSpans(ctx, idx, from, to, current)
+}
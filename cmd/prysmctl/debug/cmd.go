@@ -0,0 +1,13 @@
+package debug
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "debug",
+		Usage: "commands to debug the beacon chain state transition",
+		Subcommands: []*cli.Command{
+			replayCmd,
+		},
+	},
+}
@@ -0,0 +1,149 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldDiff lists the changes of a state field between two processing stages.
+type fieldDiff struct {
+	name string
+	// changes describes the changed values, such as "[12] 32000000000 -> 32000001000".
+	// Only the first changes are kept, total counts all of them.
+	changes []string
+	total   int
+}
+
+func (d *fieldDiff) add(change string, maxEntries int) {
+	if len(d.changes) < maxEntries {
+		d.changes = append(d.changes, change)
+	}
+	d.total++
+}
+
+// diffStates returns the fields of the pre-state which are changed in the post-state, in field order.
+// Both states must be protobuf messages of the same type, otherwise nil is returned.
+func diffStates(pre, post proto.Message, maxEntries int) []*fieldDiff {
+	preMsg, postMsg := pre.ProtoReflect(), post.ProtoReflect()
+	if preMsg.Descriptor().FullName() != postMsg.Descriptor().FullName() {
+		return nil
+	}
+	var diffs []*fieldDiff
+	fields := preMsg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		d := &fieldDiff{name: string(fd.Name())}
+		diffField(d, fd, preMsg.Get(fd), postMsg.Get(fd), maxEntries)
+		if d.total > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+func diffField(d *fieldDiff, fd protoreflect.FieldDescriptor, a, b protoreflect.Value, maxEntries int) {
+	switch {
+	case fd.IsList():
+		diffList(d, fd, a.List(), b.List(), maxEntries)
+	case fd.Kind() == protoreflect.MessageKind:
+		if changes := diffMessage(a.Message(), b.Message()); len(changes) > 0 {
+			d.add(strings.Join(changes, ", "), maxEntries)
+		}
+	case fd.Kind() == protoreflect.BytesKind:
+		diffBytes(d, a.Bytes(), b.Bytes(), maxEntries)
+	default:
+		if !a.Equal(b) {
+			d.add(fmt.Sprintf("%v -> %v", a.Interface(), b.Interface()), maxEntries)
+		}
+	}
+}
+
+func diffList(d *fieldDiff, fd protoreflect.FieldDescriptor, a, b protoreflect.List, maxEntries int) {
+	if a.Len() != b.Len() {
+		d.add(fmt.Sprintf("length %d -> %d", a.Len(), b.Len()), maxEntries)
+	}
+	n := a.Len()
+	if b.Len() < n {
+		n = b.Len()
+	}
+	for i := 0; i < n; i++ {
+		x, y := a.Get(i), b.Get(i)
+		switch fd.Kind() {
+		case protoreflect.MessageKind:
+			if changes := diffMessage(x.Message(), y.Message()); len(changes) > 0 {
+				d.add(fmt.Sprintf("[%d] %s", i, strings.Join(changes, ", ")), maxEntries)
+			}
+		case protoreflect.BytesKind:
+			if !bytes.Equal(x.Bytes(), y.Bytes()) {
+				d.add(fmt.Sprintf("[%d] %s -> %s", i, formatBytes(x.Bytes()), formatBytes(y.Bytes())), maxEntries)
+			}
+		default:
+			if !x.Equal(y) {
+				d.add(fmt.Sprintf("[%d] %v -> %v", i, x.Interface(), y.Interface()), maxEntries)
+			}
+		}
+	}
+	for i := n; i < b.Len(); i++ {
+		d.add(fmt.Sprintf("[%d] added", i), maxEntries)
+	}
+}
+
+// diffBytes reports a changed byte string as a whole, except for long byte strings of the same length,
+// such as participation flags, which are reported byte by byte.
+func diffBytes(d *fieldDiff, a, b []byte, maxEntries int) {
+	if bytes.Equal(a, b) {
+		return
+	}
+	if len(a) != len(b) || len(a) <= 32 {
+		d.add(fmt.Sprintf("%s -> %s", formatBytes(a), formatBytes(b)), maxEntries)
+		return
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			d.add(fmt.Sprintf("[%d] %#04x -> %#04x", i, a[i], b[i]), maxEntries)
+		}
+	}
+}
+
+// diffMessage describes the changed fields of a nested message, such as a validator or a checkpoint.
+func diffMessage(a, b protoreflect.Message) []string {
+	if proto.Equal(a.Interface(), b.Interface()) {
+		return nil
+	}
+	var changes []string
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		x, y := a.Get(fd), b.Get(fd)
+		switch {
+		case fd.IsList() || fd.Kind() == protoreflect.MessageKind:
+			d := &fieldDiff{}
+			diffField(d, fd, x, y, 1)
+			if d.total > 0 {
+				changes = append(changes, fmt.Sprintf("%s changed", fd.Name()))
+			}
+		case fd.Kind() == protoreflect.BytesKind:
+			if !bytes.Equal(x.Bytes(), y.Bytes()) {
+				changes = append(changes, fmt.Sprintf("%s: %s -> %s", fd.Name(), formatBytes(x.Bytes()), formatBytes(y.Bytes())))
+			}
+		default:
+			if !x.Equal(y) {
+				changes = append(changes, fmt.Sprintf("%s: %v -> %v", fd.Name(), x.Interface(), y.Interface()))
+			}
+		}
+	}
+	return changes
+}
+
+// formatBytes returns the hex representation of short byte strings and an abbreviation of long ones.
+func formatBytes(b []byte) string {
+	if len(b) <= 32 {
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%s..%x (%d bytes)", hexutil.Encode(b[:4]), b[len(b)-4:], len(b))
+}
@@ -0,0 +1,264 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var replayFlags = struct {
+	PreState         string
+	DataDir          string
+	Slot             uint64
+	EndSlot          uint64
+	Blocks           cli.StringSlice
+	ChainConfigFile  string
+	VerifySignatures bool
+	MaxEntries       uint64
+}{}
+
+var replayCmd = &cli.Command{
+	Name: "replay",
+	Usage: "Applies blocks to a pre-state stage by stage and prints the state fields changed by every stage. " +
+		"The pre-state and the blocks are read from SSZ files or from a beacon node database.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := replayAction(cliCtx); err != nil {
+			return errors.Wrap(err, "could not replay blocks")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "pre-state",
+			Usage:       "path to an SSZ encoded beacon state to apply the blocks to",
+			Destination: &replayFlags.PreState,
+		},
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "path to the directory containing beaconchain.db, to read the pre-state and the blocks from. The beacon node must be stopped",
+			Destination: &replayFlags.DataDir,
+		},
+		&cli.Uint64Flag{
+			Name:        "slot",
+			Usage:       "slot of the pre-state to read from the database",
+			Destination: &replayFlags.Slot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "last slot of the canonical blocks to read from the database, when no --block is given",
+			Destination: &replayFlags.EndSlot,
+		},
+		&cli.StringSliceFlag{
+			Name:        "block",
+			Usage:       "path to an SSZ encoded signed beacon block to apply, can be repeated. Blocks are applied in the given order",
+			Destination: &replayFlags.Blocks,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, mainnet is used by default",
+			Destination: &replayFlags.ChainConfigFile,
+		},
+		&cli.BoolFlag{
+			Name:        "verify-signatures",
+			Usage:       "verify the signatures of the blocks",
+			Destination: &replayFlags.VerifySignatures,
+			Value:       true,
+		},
+		&cli.Uint64Flag{
+			Name:        "max-changes",
+			Usage:       "maximum number of changed elements printed per state field",
+			Destination: &replayFlags.MaxEntries,
+			Value:       10,
+		},
+	},
+}
+
+func replayAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	f := &replayFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+
+	var beaconDB *kv.Store
+	var history *stategen.CanonicalHistory
+	if f.DataDir != "" {
+		var err error
+		beaconDB, err = kv.NewKVStore(ctx, f.DataDir)
+		if err != nil {
+			return errors.Wrap(err, "could not open database")
+		}
+		defer func() {
+			if err := beaconDB.Close(); err != nil {
+				log.WithError(err).Error("Could not close database")
+			}
+		}()
+		history, err = canonicalHistory(ctx, beaconDB)
+		if err != nil {
+			return err
+		}
+	}
+
+	var st state.BeaconState
+	var err error
+	switch {
+	case f.PreState != "":
+		st, err = loadState(f.PreState)
+	case history != nil:
+		st, err = history.ReplayerForSlot(primitives.Slot(f.Slot)).ReplayToSlot(ctx, primitives.Slot(f.Slot))
+	default:
+		return errors.New("either --pre-state or --datadir is required")
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get pre-state")
+	}
+
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	switch {
+	case len(f.Blocks.Value()) > 0:
+		for _, path := range f.Blocks.Value() {
+			blk, err := loadBlock(path)
+			if err != nil {
+				return errors.Wrapf(err, "could not load block %s", path)
+			}
+			blks = append(blks, blk)
+		}
+	case history != nil:
+		blks, err = canonicalBlocks(ctx, beaconDB, history, st.Slot(), primitives.Slot(f.EndSlot))
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("either --block or --datadir is required")
+	}
+	if len(blks) == 0 {
+		return errors.New("no blocks to apply")
+	}
+
+	s := &stepper{w: cliCtx.App.Writer, maxEntries: int(f.MaxEntries), verifySignatures: f.VerifySignatures}
+	fmt.Fprintf(s.w, "Pre-state at slot %d, %d validators\n", st.Slot(), st.NumValidators())
+	for _, blk := range blks {
+		st, err = s.applyBlock(ctx, st, blk)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadState(path string) (state.BeaconState, error) {
+	b, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	u, err := detect.FromState(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect state version")
+	}
+	return u.UnmarshalBeaconState(b)
+}
+
+func loadBlock(path string) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	b, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, err
+	}
+	u, err := detect.FromBlock(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not detect block version")
+	}
+	return u.UnmarshalBeaconBlock(b)
+}
+
+// canonicalBlocks returns the canonical blocks of the database in the slot range (from, to].
+func canonicalBlocks(ctx context.Context, beaconDB *kv.Store, h *stategen.CanonicalHistory, from, to primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	if to <= from {
+		return nil, fmt.Errorf("--end-slot must be greater than the pre-state slot %d", from)
+	}
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	for slot := from + 1; slot <= to; slot++ {
+		root, err := h.BlockRootForSlot(ctx, slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get canonical block root at slot %d", slot)
+		}
+		blk, err := beaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get block %#x", root)
+		}
+		// The root of the last block before the slot is returned for skipped slots.
+		if blk.Block().Slot() == slot {
+			blks = append(blks, blk)
+		}
+	}
+	return blks, nil
+}
+
+// canonicalChecker considers the finalized blocks and the ancestors of the database head block
+// down to the finalized checkpoint as canonical.
+type canonicalChecker struct {
+	beaconDB *kv.Store
+	roots    map[[32]byte]bool
+}
+
+func (c *canonicalChecker) IsCanonical(ctx context.Context, root [32]byte) (bool, error) {
+	return c.roots[root] || c.beaconDB.IsFinalizedBlock(ctx, root), nil
+}
+
+// headSlot is the current slot of an offline database, so that states can be replayed up to its head.
+type headSlot primitives.Slot
+
+func (s headSlot) CurrentSlot() primitives.Slot {
+	return primitives.Slot(s)
+}
+
+func canonicalHistory(ctx context.Context, beaconDB *kv.Store) (*stategen.CanonicalHistory, error) {
+	head, err := beaconDB.HeadBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	if err := blocks.BeaconBlockIsNil(head); err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	cp, err := beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalized := bytesutil.ToBytes32(cp.Root)
+
+	roots := make(map[[32]byte]bool)
+	root, err := head.Block().HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	for blk := head; ; {
+		roots[root] = true
+		if root == finalized || blk.Block().Slot() == 0 {
+			break
+		}
+		root = blk.Block().ParentRoot()
+		blk, err = beaconDB.Block(ctx, root)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get block %#x", root)
+		}
+		if blocks.BeaconBlockIsNil(blk) != nil {
+			break
+		}
+	}
+	checker := &canonicalChecker{beaconDB: beaconDB, roots: roots}
+	return stategen.NewCanonicalHistory(beaconDB, checker, headSlot(head.Block().Slot())), nil
+}
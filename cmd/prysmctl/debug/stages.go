@@ -0,0 +1,267 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	coreblocks "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	coretime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"google.golang.org/protobuf/proto"
+)
+
+// stageFn applies a single processing step of a block to the state.
+type stageFn func(ctx context.Context, st state.BeaconState, blk interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error)
+
+// blockStages are the processing steps of a block, in the order of transition.ProcessBlockForStateRoot.
+var blockStages = []struct {
+	name string
+	fn   stageFn
+}{
+	{name: "block_header", fn: processBlockHeader},
+	{name: "execution_payload", fn: processExecutionPayload},
+	{name: "randao", fn: processRandao},
+	{name: "eth1_data", fn: processEth1Data},
+	{name: "operations", fn: processOperations},
+	{name: "sync_aggregate", fn: processSyncAggregate},
+}
+
+// stepper applies blocks to a state stage by stage, and prints the state fields changed by every stage.
+type stepper struct {
+	w                io.Writer
+	maxEntries       int
+	verifySignatures bool
+}
+
+// applyBlock applies the slots before the block and the block to the state. It fails at the first
+// failing stage, if the block signatures are invalid or if the post-state root does not match the block.
+func (s *stepper) applyBlock(ctx context.Context, st state.BeaconState, blk interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block root")
+	}
+	slot := blk.Block().Slot()
+	fmt.Fprintf(s.w, "Block at slot %d, root %#x, proposer %d, %s\n", slot, root, blk.Block().ProposerIndex(), version.String(blk.Version()))
+
+	if st.Slot() < slot {
+		st, err = s.processSlots(ctx, st, slot)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if st.Version() != blk.Version() {
+		return nil, fmt.Errorf("state and block are different versions: %s != %s", version.String(st.Version()), version.String(blk.Version()))
+	}
+	if s.verifySignatures {
+		if err := s.verifyBlockSignatures(ctx, st, blk); err != nil {
+			return nil, errors.Wrapf(err, "block at slot %d", slot)
+		}
+	}
+	for _, stage := range blockStages {
+		pre, err := snapshot(st)
+		if err != nil {
+			return nil, err
+		}
+		st, err = stage.fn(ctx, st, blk)
+		if err != nil {
+			fmt.Fprintf(s.w, "  %s: FAILED: %v\n", stage.name, err)
+			return nil, errors.Wrapf(err, "block at slot %d failed in %s", slot, stage.name)
+		}
+		if err := s.report(stage.name, pre, st); err != nil {
+			return nil, err
+		}
+	}
+
+	postRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute post-state root")
+	}
+	if stateRoot := blk.Block().StateRoot(); postRoot != stateRoot {
+		fmt.Fprintf(s.w, "  state_root: MISMATCH: computed %#x, block has %#x\n", postRoot, stateRoot)
+		return nil, fmt.Errorf("block at slot %d has state root %#x, computed %#x", slot, stateRoot, postRoot)
+	}
+	fmt.Fprintf(s.w, "  state_root: %#x\n", postRoot)
+	return st, nil
+}
+
+// processSlots mirrors transition.ProcessSlotsCore, so that the changes of slot processing and
+// of epoch processing are reported separately. Consecutive slots are reported together.
+func (s *stepper) processSlots(ctx context.Context, st state.BeaconState, slot primitives.Slot) (state.BeaconState, error) {
+	pre, err := snapshot(st)
+	if err != nil {
+		return nil, err
+	}
+	from := st.Slot()
+	for st.Slot() < slot {
+		current := st.Slot()
+		st, err = transition.ProcessSlot(ctx, st)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slot %d", current)
+		}
+		if coretime.CanProcessEpoch(st) {
+			if err := s.report(fmt.Sprintf("process_slots %d..%d", from, st.Slot()), pre, st); err != nil {
+				return nil, err
+			}
+			pre, err = snapshot(st)
+			if err != nil {
+				return nil, err
+			}
+			st, err = transition.ProcessEpoch(ctx, st)
+			if err != nil {
+				fmt.Fprintf(s.w, "  process_epoch: FAILED: %v\n", err)
+				return nil, errors.Wrapf(err, "could not process epoch at slot %d", current)
+			}
+			if err := s.report("process_epoch", pre, st); err != nil {
+				return nil, err
+			}
+			if pre, err = snapshot(st); err != nil {
+				return nil, err
+			}
+			from = current + 1
+		}
+		if err := st.SetSlot(st.Slot() + 1); err != nil {
+			return nil, err
+		}
+		v := st.Version()
+		st, err = transition.UpgradeState(ctx, st)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not upgrade state at slot %d", current+1)
+		}
+		if st.Version() != v {
+			fmt.Fprintf(s.w, "  upgrade: %s -> %s\n", version.String(v), version.String(st.Version()))
+			if pre, err = snapshot(st); err != nil {
+				return nil, err
+			}
+		}
+	}
+	name := "process_slots"
+	if from < slot {
+		name = fmt.Sprintf("process_slots %d..%d", from, slot-1)
+	}
+	return st, s.report(name, pre, st)
+}
+
+// verifyBlockSignatures verifies the block, RANDAO, attestation and BLS change signatures of the block
+// on a copy of the state. Invalid signatures are reported individually.
+func (s *stepper) verifyBlockSignatures(ctx context.Context, st state.BeaconState, blk interfaces.ReadOnlySignedBeaconBlock) error {
+	set, _, err := transition.ProcessBlockNoVerifyAnySig(ctx, st.Copy(), blk)
+	if err != nil {
+		// The failing stage is reported when the block is processed.
+		fmt.Fprintln(s.w, "  signatures: not verified, block processing fails")
+		return nil
+	}
+	valid, err := set.VerifyVerbosely()
+	if err == nil && !valid {
+		err = errors.New("signature in block failed to verify")
+	}
+	if err != nil {
+		fmt.Fprintf(s.w, "  signatures: FAILED: %v\n", err)
+		return errors.Wrap(err, "invalid signatures")
+	}
+	fmt.Fprintf(s.w, "  signatures: %d valid\n", len(set.Signatures))
+	return nil
+}
+
+// report prints the state fields which differ between the pre-state and the post-state of a stage.
+func (s *stepper) report(name string, pre proto.Message, post state.BeaconState) error {
+	p, err := snapshot(post)
+	if err != nil {
+		return err
+	}
+	diffs := diffStates(pre, p, s.maxEntries)
+	if len(diffs) == 0 {
+		fmt.Fprintf(s.w, "  %s: no changes\n", name)
+		return nil
+	}
+	fmt.Fprintf(s.w, "  %s:\n", name)
+	for _, d := range diffs {
+		if d.total == 1 && len(d.changes) == 1 {
+			fmt.Fprintf(s.w, "    %s: %s\n", d.name, d.changes[0])
+			continue
+		}
+		fmt.Fprintf(s.w, "    %s: %d changes\n", d.name, d.total)
+		for _, c := range d.changes {
+			fmt.Fprintf(s.w, "      %s\n", c)
+		}
+		if d.total > len(d.changes) {
+			fmt.Fprintf(s.w, "      ... %d more\n", d.total-len(d.changes))
+		}
+	}
+	return nil
+}
+
+// snapshot returns a copy of the state as a protobuf message.
+func snapshot(st state.BeaconState) (proto.Message, error) {
+	m, ok := st.ToProto().(proto.Message)
+	if !ok {
+		return nil, errors.New("state is not a protobuf message")
+	}
+	return m, nil
+}
+
+func processBlockHeader(ctx context.Context, st state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	blk := signed.Block()
+	bodyRoot, err := blk.Body().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash tree root beacon block body")
+	}
+	parentRoot := blk.ParentRoot()
+	return coreblocks.ProcessBlockHeaderNoVerify(ctx, st, blk.Slot(), blk.ProposerIndex(), parentRoot[:], bodyRoot[:])
+}
+
+func processExecutionPayload(_ context.Context, st state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	body := signed.Block().Body()
+	enabled, err := coreblocks.IsExecutionEnabled(st, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check if execution is enabled")
+	}
+	if !enabled {
+		return st, nil
+	}
+	executionData, err := body.Execution()
+	if err != nil {
+		return nil, err
+	}
+	if st.Version() >= version.Capella {
+		st, err = coreblocks.ProcessWithdrawals(st, executionData)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not process withdrawals")
+		}
+	}
+	if err := coreblocks.ProcessPayload(st, body); err != nil {
+		return nil, errors.Wrap(err, "could not process execution data")
+	}
+	return st, nil
+}
+
+func processRandao(_ context.Context, st state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	reveal := signed.Block().Body().RandaoReveal()
+	return coreblocks.ProcessRandaoNoVerify(st, reveal[:])
+}
+
+func processEth1Data(ctx context.Context, st state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	return coreblocks.ProcessEth1DataInBlock(ctx, st, signed.Block().Body().Eth1Data())
+}
+
+func processOperations(ctx context.Context, st state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	return transition.ProcessOperationsNoVerifyAttsSigs(ctx, st, signed.Block())
+}
+
+func processSyncAggregate(ctx context.Context, st state.BeaconState, signed interfaces.ReadOnlySignedBeaconBlock) (state.BeaconState, error) {
+	if signed.Block().Version() == version.Phase0 {
+		return st, nil
+	}
+	sa, err := signed.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate from block")
+	}
+	st, _, err = altair.ProcessSyncAggregate(ctx, st, sa)
+	return st, err
+}
@@ -0,0 +1,92 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStepper_ApplyBlock(t *testing.T) {
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	committee, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(committee))
+	require.NoError(t, genesis.SetNextSyncCommittee(committee))
+
+	slot := params.BeaconConfig().SlotsPerEpoch + 1
+	b, err := util.GenerateFullBlockAltair(genesis.Copy(), keys, util.DefaultBlockGenConfig(), slot)
+	require.NoError(t, err)
+	wsb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	expected, err := transition.ExecuteStateTransition(ctx, genesis.Copy(), wsb)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	s := &stepper{w: out, maxEntries: 3, verifySignatures: true}
+	post, err := s.applyBlock(ctx, genesis.Copy(), wsb)
+	require.NoError(t, err)
+	expectedRoot, err := expected.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	postRoot, err := post.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expectedRoot, postRoot)
+
+	output := out.String()
+	assert.StringContains(t, "process_slots 0..31:", output)
+	assert.StringContains(t, "process_epoch:", output)
+	assert.StringContains(t, "process_slots 32..32:", output)
+	assert.StringContains(t, "signatures: ", output)
+	assert.StringContains(t, "block_header:", output)
+	assert.StringContains(t, "latest_block_header: slot: 0 -> 33", output)
+	assert.StringContains(t, "randao_mixes: ", output)
+	assert.StringContains(t, "execution_payload: no changes", output)
+	assert.StringContains(t, "... ", output)
+	assert.StringContains(t, "state_root: ", output)
+}
+
+func TestStepper_ApplyBlock_Failures(t *testing.T) {
+	ctx := context.Background()
+	genesis, keys := util.DeterministicGenesisStateAltair(t, 64)
+	committee, err := altair.NextSyncCommittee(ctx, genesis)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SetCurrentSyncCommittee(committee))
+	require.NoError(t, genesis.SetNextSyncCommittee(committee))
+	b, err := util.GenerateFullBlockAltair(genesis.Copy(), keys, util.DefaultBlockGenConfig(), 1)
+	require.NoError(t, err)
+
+	t.Run("state root mismatch", func(t *testing.T) {
+		bad := util.NewBeaconBlockAltair()
+		bad.Block = b.Block
+		bad.Block.StateRoot = bytes.Repeat([]byte{1}, 32)
+		bad.Signature = b.Signature
+		wsb, err := blocks.NewSignedBeaconBlock(bad)
+		require.NoError(t, err)
+		out := &bytes.Buffer{}
+		s := &stepper{w: out, maxEntries: 3}
+		_, err = s.applyBlock(ctx, genesis.Copy(), wsb)
+		require.ErrorContains(t, "has state root", err)
+		assert.StringContains(t, "state_root: MISMATCH", out.String())
+	})
+	t.Run("failing stage", func(t *testing.T) {
+		bad := util.NewBeaconBlockAltair()
+		bad.Block = b.Block
+		bad.Block.ProposerIndex = primitives.ValidatorIndex(63)
+		wsb, err := blocks.NewSignedBeaconBlock(bad)
+		require.NoError(t, err)
+		out := &bytes.Buffer{}
+		s := &stepper{w: out, maxEntries: 3}
+		_, err = s.applyBlock(ctx, genesis.Copy(), wsb)
+		require.ErrorContains(t, "failed in block_header", err)
+		assert.StringContains(t, "block_header: FAILED", out.String())
+	})
+}
@@ -0,0 +1,15 @@
+package forkchoice
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "forkchoice",
+		Usage: "commands to investigate fork choice decisions offline",
+		Subcommands: []*cli.Command{
+			simulateCmd,
+			rebuildCmd,
+			convertCmd,
+		},
+	},
+}
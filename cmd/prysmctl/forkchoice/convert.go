@@ -0,0 +1,471 @@
+package forkchoice
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var convertFlags = struct {
+	Events         string
+	BeaconNodeHost string
+	Timeout        time.Duration
+}{}
+
+var convertCmd = &cli.Command{
+	Name: "convert",
+	Usage: "Converts a recording of the /eth/v1/events stream with the block, attestation, head and finalized_checkpoint " +
+		"topics into a recording that the simulate command accepts as --recording. The stream can be recorded with " +
+		"curl -sN -H 'Accept: text/event-stream' 'http://localhost:3500/eth/v1/events?topics=block,attestation,head,finalized_checkpoint' | ts '%.s', " +
+		"where ts prefixes every line with the unix time it was received at. Without these times, blocks are received at " +
+		"the start of their slot and attestations at the attestation deadline. The stream does not include parent roots, " +
+		"checkpoints, committees and balances, which are looked up on a beacon node that still has the recorded blocks.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := convertAction(cliCtx); err != nil {
+			return errors.Wrap(err, "could not convert events stream")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "events",
+			Usage:       "path to the recording of the /eth/v1/events stream",
+			Destination: &convertFlags.Events,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &convertFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-host (uses duration format, ex: 2m31s). default: 2m",
+			Destination: &convertFlags.Timeout,
+			Value:       time.Minute * 2,
+		},
+	},
+}
+
+func convertAction(cliCtx *cli.Context) error {
+	f := &convertFlags
+	c, err := client.NewClient(f.BeaconNodeHost, client.WithTimeout(f.Timeout))
+	if err != nil {
+		return err
+	}
+	r, err := os.Open(f.Events) // #nosec G304
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", f.Events)
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.WithError(err).Error("Could not close file")
+		}
+	}()
+	return convertEvents(cliCtx.Context, r, cliCtx.App.Writer, &nodeLookup{c: c})
+}
+
+// Topics of the events stream.
+const (
+	blockTopic               = "block"
+	attestationTopic         = "attestation"
+	finalizedCheckpointTopic = "finalized_checkpoint"
+)
+
+// beaconLookup provides what a recording needs and the events stream does not include.
+type beaconLookup interface {
+	genesisTime(ctx context.Context) (uint64, error)
+	blockHeader(ctx context.Context, root string) (*structs.BeaconBlockHeader, error)
+	finalityCheckpoints(ctx context.Context, stateRoot string) (*structs.FinalityCheckpoints, error)
+	committees(ctx context.Context, slot primitives.Slot) ([]*structs.Committee, error)
+	balances(ctx context.Context, stateRoot string) ([]string, error)
+}
+
+// streamEvent is an event of the events stream, with the unix time it was received at when it was recorded.
+type streamEvent struct {
+	time  uint64
+	timed bool
+	name  string
+	data  string
+}
+
+// convertEvents writes the recording of the events stream read from r to w, in the format of scenarioFromRecording.
+// The balances and checkpoints of the state of the first block are written before it, as the initial ones of the store.
+// Head events are skipped, as the simulation computes the head.
+func convertEvents(ctx context.Context, r io.Reader, w io.Writer, lookup beaconLookup) error {
+	genesisTime, err := lookup.genesisTime(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis time")
+	}
+	c := &eventsConverter{
+		lookup:      lookup,
+		genesisTime: genesisTime,
+		enc:         json.NewEncoder(w),
+		committees:  make(map[primitives.Slot]map[string][]string),
+	}
+	return readEventStream(r, func(e *streamEvent) error {
+		if err := c.convert(ctx, e); err != nil {
+			return errors.Wrapf(err, "could not convert %s event", e.name)
+		}
+		return nil
+	})
+}
+
+// readEventStream calls fn for every event of a recording of an events stream. Lines may be prefixed with
+// the unix time they were received at, in seconds.
+func readEventStream(r io.Reader, fn func(*streamEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	e := &streamEvent{}
+	var data []string
+	dispatch := func() error {
+		defer func() {
+			e = &streamEvent{}
+			data = nil
+		}()
+		if e.name == "" || len(data) == 0 {
+			return nil
+		}
+		e.data = strings.Join(data, "\n")
+		return fn(e)
+	}
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		if t, rest, ok := receiveTime(line); ok {
+			if !e.timed {
+				e.time, e.timed = t, true
+			}
+			line = rest
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return err
+			}
+		case field == "event":
+			e.name = value
+		case field == "data":
+			data = append(data, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "could not read events stream")
+	}
+	return dispatch()
+}
+
+// receiveTime parses the unix time a line is prefixed with.
+func receiveTime(line string) (uint64, string, bool) {
+	prefix, rest, _ := strings.Cut(line, " ")
+	t, err := strconv.ParseFloat(prefix, 64)
+	if err != nil || t < 0 {
+		return 0, line, false
+	}
+	return uint64(t), rest, true
+}
+
+type eventsConverter struct {
+	lookup      beaconLookup
+	genesisTime uint64
+	enc         *json.Encoder
+	anchored    bool
+	lastTime    uint64
+	warned      bool
+	// committees of the recent slots, by committee index.
+	committees map[primitives.Slot]map[string][]string
+}
+
+func (c *eventsConverter) convert(ctx context.Context, e *streamEvent) error {
+	switch e.name {
+	case blockTopic:
+		ev := &structs.BlockEvent{}
+		if err := json.Unmarshal([]byte(e.data), ev); err != nil {
+			return err
+		}
+		slot, err := strconv.ParseUint(ev.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid slot")
+		}
+		return c.block(ctx, c.eventTime(e, primitives.Slot(slot), 0), ev)
+	case attestationTopic:
+		att := &structs.AttestationElectra{}
+		if err := json.Unmarshal([]byte(e.data), att); err != nil {
+			return err
+		}
+		if att.Data == nil || att.Data.Target == nil {
+			return errors.New("missing attestation data")
+		}
+		slot, err := strconv.ParseUint(att.Data.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid slot")
+		}
+		deadline := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+		return c.attestation(ctx, c.eventTime(e, primitives.Slot(slot), deadline), att)
+	case finalizedCheckpointTopic:
+		ev := &structs.FinalizedCheckpointEvent{}
+		if err := json.Unmarshal([]byte(e.data), ev); err != nil {
+			return err
+		}
+		t := c.lastTime
+		if e.timed {
+			t = e.time
+		}
+		return c.write(&recordedEvent{
+			Time:       strconv.FormatUint(t, 10),
+			Type:       recordedFinalizedCheckpoint,
+			Checkpoint: &structs.Checkpoint{Epoch: ev.Epoch, Root: ev.Block},
+		})
+	default:
+		return nil
+	}
+}
+
+// eventTime returns the time an event was received at, or the given seconds into its slot when it was not recorded.
+func (c *eventsConverter) eventTime(e *streamEvent, slot primitives.Slot, secs uint64) uint64 {
+	if e.timed {
+		return e.time
+	}
+	if !c.warned {
+		log.Warn("The events stream has no receive times, blocks are received at the start of their slot and attestations at the attestation deadline")
+		c.warned = true
+	}
+	return uint64(slots.StartTime(c.genesisTime, slot).Unix()) + secs
+}
+
+func (c *eventsConverter) block(ctx context.Context, t uint64, ev *structs.BlockEvent) error {
+	header, err := c.lookup.blockHeader(ctx, ev.Block)
+	if err != nil {
+		return errors.Wrapf(err, "could not get header of block %s", ev.Block)
+	}
+	cps, err := c.lookup.finalityCheckpoints(ctx, header.StateRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not get finality checkpoints of block %s", ev.Block)
+	}
+	if cps.CurrentJustified == nil || cps.Finalized == nil {
+		return fmt.Errorf("missing finality checkpoints of block %s", ev.Block)
+	}
+	ts := strconv.FormatUint(t, 10)
+	if !c.anchored {
+		balances, err := c.lookup.balances(ctx, header.StateRoot)
+		if err != nil {
+			return errors.Wrapf(err, "could not get balances of block %s", ev.Block)
+		}
+		initial := []*recordedEvent{
+			{Time: ts, Type: recordedBalances, Balances: balances},
+			{Time: ts, Type: recordedJustifiedCheckpoint, Checkpoint: cps.CurrentJustified},
+			{Time: ts, Type: recordedFinalizedCheckpoint, Checkpoint: cps.Finalized},
+		}
+		for _, rec := range initial {
+			if err := c.write(rec); err != nil {
+				return err
+			}
+		}
+		c.anchored = true
+	}
+	validity := forkchoice2.Valid
+	if ev.ExecutionOptimistic {
+		validity = forkchoice2.Optimistic
+	}
+	return c.write(&recordedEvent{
+		Time: ts,
+		Type: recordedBlock,
+		Block: &structs.ForkChoiceNode{
+			Slot:           ev.Slot,
+			BlockRoot:      ev.Block,
+			ParentRoot:     header.ParentRoot,
+			JustifiedEpoch: cps.CurrentJustified.Epoch,
+			FinalizedEpoch: cps.Finalized.Epoch,
+			Validity:       validity.String(),
+		},
+	})
+}
+
+func (c *eventsConverter) attestation(ctx context.Context, t uint64, att *structs.AttestationElectra) error {
+	indices, err := c.attesters(ctx, att)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	return c.write(&recordedEvent{
+		Time: strconv.FormatUint(t, 10),
+		Type: recordedAttestation,
+		Attestation: &recordedAttestationFields{
+			BlockRoot:        att.Data.BeaconBlockRoot,
+			TargetEpoch:      att.Data.Target.Epoch,
+			ValidatorIndices: indices,
+		},
+	})
+}
+
+// attesters returns the indices of the validators of an attestation, from the committees of its slot. The
+// committees of an attestation since Electra are given by its committee bits.
+func (c *eventsConverter) attesters(ctx context.Context, att *structs.AttestationElectra) ([]string, error) {
+	slot, err := strconv.ParseUint(att.Data.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid slot")
+	}
+	committees, err := c.slotCommittees(ctx, primitives.Slot(slot))
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	if att.CommitteeBits == "" {
+		members = committees[att.Data.CommitteeIndex]
+	} else {
+		b, err := hexutil.Decode(att.CommitteeBits)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid committee bits")
+		}
+		for _, i := range bitfield.Bitvector64(b).BitIndices() {
+			members = append(members, committees[strconv.Itoa(i)]...)
+		}
+	}
+	b, err := hexutil.Decode(att.AggregationBits)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid aggregation bits")
+	}
+	bits := bitfield.Bitlist(b)
+	if bits.Len() != uint64(len(members)) {
+		return nil, fmt.Errorf("aggregation bits have length %d but the committees have %d validators", bits.Len(), len(members))
+	}
+	indices := make([]string, 0, bits.Count())
+	for i, v := range members {
+		if bits.BitAt(uint64(i)) {
+			indices = append(indices, v)
+		}
+	}
+	return indices, nil
+}
+
+// slotCommittees returns the committees of a slot by committee index. Attestations are received within an epoch of
+// their slot, so the committees of older slots are dropped.
+func (c *eventsConverter) slotCommittees(ctx context.Context, slot primitives.Slot) (map[string][]string, error) {
+	if committees, ok := c.committees[slot]; ok {
+		return committees, nil
+	}
+	list, err := c.lookup.committees(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get committees of slot %d", slot)
+	}
+	committees := make(map[string][]string, len(list))
+	for _, committee := range list {
+		committees[committee.Index] = committee.Validators
+	}
+	for s := range c.committees {
+		if s+2*params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(c.committees, s)
+		}
+	}
+	c.committees[slot] = committees
+	return committees, nil
+}
+
+func (c *eventsConverter) write(rec *recordedEvent) error {
+	t, err := strconv.ParseUint(rec.Time, 10, 64)
+	if err != nil {
+		return err
+	}
+	c.lastTime = max(c.lastTime, t)
+	return c.enc.Encode(rec)
+}
+
+// nodeLookup looks up what a recording needs on a beacon node.
+type nodeLookup struct {
+	c *client.Client
+}
+
+func (l *nodeLookup) get(ctx context.Context, path string, resp interface{}, opts ...client.ReqOption) error {
+	b, err := l.c.Get(ctx, path, opts...)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, resp)
+}
+
+func (l *nodeLookup) genesisTime(ctx context.Context) (uint64, error) {
+	resp := &structs.GetGenesisResponse{}
+	if err := l.get(ctx, "/eth/v1/beacon/genesis", resp); err != nil {
+		return 0, err
+	}
+	if resp.Data == nil {
+		return 0, errors.New("empty genesis response")
+	}
+	return strconv.ParseUint(resp.Data.GenesisTime, 10, 64)
+}
+
+func (l *nodeLookup) blockHeader(ctx context.Context, root string) (*structs.BeaconBlockHeader, error) {
+	resp := &structs.GetBlockHeaderResponse{}
+	if err := l.get(ctx, "/eth/v1/beacon/headers/"+root, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil || resp.Data.Header == nil || resp.Data.Header.Message == nil {
+		return nil, errors.New("empty block header response")
+	}
+	return resp.Data.Header.Message, nil
+}
+
+func (l *nodeLookup) finalityCheckpoints(ctx context.Context, stateRoot string) (*structs.FinalityCheckpoints, error) {
+	resp := &structs.GetFinalityCheckpointsResponse{}
+	if err := l.get(ctx, "/eth/v1/beacon/states/"+stateRoot+"/finality_checkpoints", resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty finality checkpoints response")
+	}
+	return resp.Data, nil
+}
+
+func (l *nodeLookup) committees(ctx context.Context, slot primitives.Slot) ([]*structs.Committee, error) {
+	s := strconv.FormatUint(uint64(slot), 10)
+	resp := &structs.GetCommitteesResponse{}
+	if err := l.get(ctx, "/eth/v1/beacon/states/"+s+"/committees", resp, client.WithQueryParams(url.Values{"slot": {s}})); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// balances returns the effective balances of the active validators of a state, indexed by validator.
+func (l *nodeLookup) balances(ctx context.Context, stateRoot string) ([]string, error) {
+	resp := &structs.GetValidatorsResponse{}
+	if err := l.get(ctx, "/eth/v1/beacon/states/"+stateRoot+"/validators", resp); err != nil {
+		return nil, err
+	}
+	balances := make([]string, len(resp.Data))
+	for i := range balances {
+		balances[i] = "0"
+	}
+	for _, v := range resp.Data {
+		idx, err := strconv.ParseUint(v.Index, 10, 64)
+		if err != nil || idx >= uint64(len(balances)) || v.Validator == nil {
+			return nil, fmt.Errorf("invalid validator %q", v.Index)
+		}
+		if strings.HasPrefix(v.Status, "active") {
+			balances[idx] = v.Validator.EffectiveBalance
+		}
+	}
+	return balances, nil
+}
@@ -0,0 +1,102 @@
+package forkchoice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+type mockLookup struct {
+	parents map[string]string
+}
+
+func (*mockLookup) genesisTime(context.Context) (uint64, error) {
+	return testGenesisTime, nil
+}
+
+func (m *mockLookup) blockHeader(_ context.Context, root string) (*structs.BeaconBlockHeader, error) {
+	parent, ok := m.parents[root]
+	if !ok {
+		return nil, fmt.Errorf("unknown block %s", root)
+	}
+	return &structs.BeaconBlockHeader{ParentRoot: parent, StateRoot: root}, nil
+}
+
+func (*mockLookup) finalityCheckpoints(context.Context, string) (*structs.FinalityCheckpoints, error) {
+	cp := &structs.Checkpoint{Epoch: "0", Root: testRoot(1)}
+	return &structs.FinalityCheckpoints{CurrentJustified: cp, Finalized: cp}, nil
+}
+
+func (*mockLookup) committees(_ context.Context, slot primitives.Slot) ([]*structs.Committee, error) {
+	return []*structs.Committee{
+		{Index: "0", Slot: fmt.Sprint(slot), Validators: []string{"10", "11", "12", "13"}},
+		{Index: "1", Slot: fmt.Sprint(slot), Validators: []string{"20", "21", "22", "23"}},
+	}, nil
+}
+
+func (*mockLookup) balances(context.Context, string) ([]string, error) {
+	balances := make([]string, 32)
+	for i := range balances {
+		balances[i] = fmt.Sprint(params.BeaconConfig().MaxEffectiveBalance)
+	}
+	return balances, nil
+}
+
+func streamLines(t uint64, event, data string) string {
+	if t == 0 {
+		return fmt.Sprintf("event: %s\ndata: %s\n\n", event, data)
+	}
+	return fmt.Sprintf("%d.123 event: %s\n%d.123 data: %s\n%d.123\n", t, event, t, data, t)
+}
+
+func attestationData(slot primitives.Slot, root byte, index int) string {
+	return fmt.Sprintf(`{"slot":"%d","index":"%d","beacon_block_root":"%s","source":{"epoch":"0","root":"%s"},"target":{"epoch":"0","root":"%s"}}`,
+		slot, index, testRoot(root), testRoot(1), testRoot(1))
+}
+
+func TestConvertEvents(t *testing.T) {
+	lookup := &mockLookup{parents: map[string]string{testRoot(2): testRoot(1), testRoot(3): testRoot(2)}}
+	stream := streamLines(slotTime(1, 1), "block", fmt.Sprintf(`{"slot":"1","block":"%s","execution_optimistic":false}`, testRoot(2))) +
+		streamLines(slotTime(1, 2), "head", fmt.Sprintf(`{"slot":"1","block":"%s"}`, testRoot(2))) +
+		streamLines(slotTime(1, 4), "attestation",
+			fmt.Sprintf(`{"aggregation_bits":"0x15","data":%s,"signature":"0x"}`, attestationData(1, 2, 0))) +
+		streamLines(slotTime(1, 5), "attestation",
+			fmt.Sprintf(`{"aggregation_bits":"0x2201","committee_bits":"0x0300000000000000","data":%s,"signature":"0x"}`, attestationData(1, 2, 0))) +
+		streamLines(0, "block", fmt.Sprintf(`{"slot":"2","block":"%s","execution_optimistic":true}`, testRoot(3))) +
+		streamLines(slotTime(2, 3), "finalized_checkpoint", fmt.Sprintf(`{"block":"%s","state":"%s","epoch":"0"}`, testRoot(1), testRoot(1)))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, convertEvents(context.Background(), strings.NewReader(stream), out, lookup))
+	sc, err := scenarioFromRecording(out)
+	require.NoError(t, err)
+
+	require.DeepEqual(t, bytesutil.PadTo([]byte{2}, 32), sc.anchor.BlockRoot)
+	require.Equal(t, 32, len(sc.balances))
+	require.Equal(t, primitives.Epoch(0), sc.justified.Epoch)
+	require.Equal(t, primitives.Epoch(0), sc.finalized.Epoch)
+	require.Equal(t, 4, len(sc.events))
+	require.Equal(t, attestationEvent, sc.events[0].kind)
+	require.Equal(t, slotTime(1, 4), sc.events[0].time)
+	require.DeepEqual(t, []uint64{10, 12}, sc.events[0].indices)
+	require.DeepEqual(t, []uint64{11, 21}, sc.events[1].indices)
+	// The block without a receive time is received at the start of its slot.
+	require.Equal(t, blockEvent, sc.events[2].kind)
+	require.Equal(t, slotTime(2, 0), sc.events[2].time)
+	require.DeepEqual(t, bytesutil.PadTo([]byte{2}, 32), sc.events[2].node.ParentRoot)
+	require.Equal(t, finalizedEvent, sc.events[3].kind)
+
+	_, err = scenarioFromRecording(strings.NewReader(stream))
+	require.ErrorContains(t, "use the convert command", err)
+
+	stream = streamLines(0, "block", fmt.Sprintf(`{"slot":"5","block":"%s","execution_optimistic":false}`, testRoot(9)))
+	err = convertEvents(context.Background(), strings.NewReader(stream), &bytes.Buffer{}, lookup)
+	require.ErrorContains(t, "could not get header of block", err)
+}
@@ -0,0 +1,126 @@
+package forkchoice
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/urfave/cli/v2"
+)
+
+var rebuildFlags = struct {
+	JournalDir      string
+	Time            string
+	ChainConfigFile string
+}{}
+
+var rebuildCmd = &cli.Command{
+	Name: "rebuild",
+	Usage: "Rebuilds the fork choice store of a beacon node from its fork choice journal, as it was at the given time, " +
+		"and prints it in the format of the /eth/v1/debug/fork_choice endpoint, which the simulate command accepts as --dump.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := rebuildAction(cliCtx); err != nil {
+			return errors.Wrap(err, "could not rebuild fork choice")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "journal-dir",
+			Usage:       "directory of the fork choice journal, as given to --forkchoice-journal-dir",
+			Destination: &rebuildFlags.JournalDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "time",
+			Usage:       "RFC 3339 or unix time at which the store is rebuilt, defaults to the end of the journal",
+			Destination: &rebuildFlags.Time,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, mainnet is used by default",
+			Destination: &rebuildFlags.ChainConfigFile,
+		},
+	},
+}
+
+func rebuildAction(cliCtx *cli.Context) error {
+	f := &rebuildFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+	until, err := parseRebuildTime(f.Time)
+	if err != nil {
+		return err
+	}
+	fc, err := doublylinkedtree.ReplayJournalDir(cliCtx.Context, f.JournalDir, until)
+	if err != nil {
+		return err
+	}
+	dump, err := fc.ForkChoiceDump(cliCtx.Context)
+	if err != nil {
+		return errors.Wrap(err, "could not dump fork choice")
+	}
+	enc := json.NewEncoder(cliCtx.App.Writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dumpResponse(dump))
+}
+
+func parseRebuildTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected an RFC 3339 or unix time", s)
+	}
+	return t, nil
+}
+
+// dumpResponse converts a fork choice dump like the /eth/v1/debug/fork_choice endpoint does.
+func dumpResponse(dump *forkchoice2.Dump) *structs.GetForkChoiceDumpResponse {
+	nodes := make([]*structs.ForkChoiceNode, len(dump.ForkChoiceNodes))
+	for i, n := range dump.ForkChoiceNodes {
+		nodes[i] = &structs.ForkChoiceNode{
+			Slot:               fmt.Sprintf("%d", n.Slot),
+			BlockRoot:          hexutil.Encode(n.BlockRoot),
+			ParentRoot:         hexutil.Encode(n.ParentRoot),
+			JustifiedEpoch:     fmt.Sprintf("%d", n.JustifiedEpoch),
+			FinalizedEpoch:     fmt.Sprintf("%d", n.FinalizedEpoch),
+			Weight:             fmt.Sprintf("%d", n.Weight),
+			ExecutionBlockHash: hexutil.Encode(n.ExecutionBlockHash),
+			Validity:           n.Validity.String(),
+			ExtraData: &structs.ForkChoiceNodeExtraData{
+				UnrealizedJustifiedEpoch: fmt.Sprintf("%d", n.UnrealizedJustifiedEpoch),
+				UnrealizedFinalizedEpoch: fmt.Sprintf("%d", n.UnrealizedFinalizedEpoch),
+				Balance:                  fmt.Sprintf("%d", n.Balance),
+				ExecutionOptimistic:      n.ExecutionOptimistic,
+				TimeStamp:                fmt.Sprintf("%d", n.Timestamp),
+			},
+		}
+	}
+	return &structs.GetForkChoiceDumpResponse{
+		JustifiedCheckpoint: structs.CheckpointFromConsensus(dump.JustifiedCheckpoint),
+		FinalizedCheckpoint: structs.CheckpointFromConsensus(dump.FinalizedCheckpoint),
+		ForkChoiceNodes:     nodes,
+		ExtraData: &structs.ForkChoiceDumpExtraData{
+			UnrealizedJustifiedCheckpoint: structs.CheckpointFromConsensus(dump.UnrealizedJustifiedCheckpoint),
+			UnrealizedFinalizedCheckpoint: structs.CheckpointFromConsensus(dump.UnrealizedFinalizedCheckpoint),
+			ProposerBoostRoot:             hexutil.Encode(dump.ProposerBoostRoot),
+			PreviousProposerBoostRoot:     hexutil.Encode(dump.PreviousProposerBoostRoot),
+			HeadRoot:                      hexutil.Encode(dump.HeadRoot),
+		},
+	}
+}
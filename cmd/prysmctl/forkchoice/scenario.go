@@ -0,0 +1,411 @@
+package forkchoice
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type eventKind int
+
+const (
+	blockEvent eventKind = iota
+	attestationEvent
+	justifiedEvent
+	finalizedEvent
+	unrealizedJustifiedEvent
+	unrealizedFinalizedEvent
+	balancesEvent
+)
+
+// Event types of a recording.
+const (
+	recordedBlock                         = "block"
+	recordedAttestation                   = "attestation"
+	recordedJustifiedCheckpoint           = "justified_checkpoint"
+	recordedFinalizedCheckpoint           = "finalized_checkpoint"
+	recordedUnrealizedJustifiedCheckpoint = "unrealized_justified_checkpoint"
+	recordedUnrealizedFinalizedCheckpoint = "unrealized_finalized_checkpoint"
+	recordedBalances                      = "balances"
+)
+
+var errNoAnchor = errors.New("no block to anchor the fork choice store")
+
+// event is something fork choice learnt about at a given time, in unix seconds.
+type event struct {
+	time        uint64
+	kind        eventKind
+	node        *forkchoice2.Node
+	indices     []uint64
+	root        [32]byte
+	targetEpoch primitives.Epoch
+	checkpoint  *forkchoicetypes.Checkpoint
+	balances    []uint64
+}
+
+// scenario is the input of a simulation: the anchor of the store and the events to replay on top of it.
+type scenario struct {
+	anchor              *forkchoice2.Node
+	justified           *forkchoicetypes.Checkpoint
+	finalized           *forkchoicetypes.Checkpoint
+	unrealizedJustified *forkchoicetypes.Checkpoint
+	unrealizedFinalized *forkchoicetypes.Checkpoint
+	balances            []uint64
+	events              []*event
+}
+
+// recordedEvent is a line of a recording, in JSON lines format.
+type recordedEvent struct {
+	Time        string                     `json:"time"`
+	Type        string                     `json:"type"`
+	Block       *structs.ForkChoiceNode    `json:"block,omitempty"`
+	Attestation *recordedAttestationFields `json:"attestation,omitempty"`
+	Checkpoint  *structs.Checkpoint        `json:"checkpoint,omitempty"`
+	Balances    []string                   `json:"balances,omitempty"`
+}
+
+type recordedAttestationFields struct {
+	BlockRoot        string   `json:"block_root"`
+	TargetEpoch      string   `json:"target_epoch"`
+	ValidatorIndices []string `json:"validator_indices"`
+}
+
+// scenarioFromDump builds a scenario from the response of the fork choice debug endpoint. The first node of
+// the dump anchors the store and the other nodes are inserted at the time they were received. A dump only
+// records the balance voting directly for each node, so every node with a balance gets a synthetic
+// validator that votes for it at the attestation deadline of its slot, or when it arrived if later.
+// The total active balance defaults to the sum of these balances.
+func scenarioFromDump(r io.Reader, genesisTime, activeBalance uint64) (*scenario, error) {
+	dump := &structs.GetForkChoiceDumpResponse{}
+	if err := json.NewDecoder(r).Decode(dump); err != nil {
+		return nil, errors.Wrap(err, "could not decode fork choice dump")
+	}
+	if len(dump.ForkChoiceNodes) == 0 {
+		return nil, errNoAnchor
+	}
+	sc := &scenario{}
+	var err error
+	if sc.justified, err = checkpointFromJSON(dump.JustifiedCheckpoint); err != nil {
+		return nil, errors.Wrap(err, "invalid justified checkpoint")
+	}
+	if sc.finalized, err = checkpointFromJSON(dump.FinalizedCheckpoint); err != nil {
+		return nil, errors.Wrap(err, "invalid finalized checkpoint")
+	}
+	var previousBoost [32]byte
+	if dump.ExtraData != nil {
+		if sc.unrealizedJustified, err = checkpointFromJSON(dump.ExtraData.UnrealizedJustifiedCheckpoint); err != nil {
+			return nil, errors.Wrap(err, "invalid unrealized justified checkpoint")
+		}
+		if sc.unrealizedFinalized, err = checkpointFromJSON(dump.ExtraData.UnrealizedFinalizedCheckpoint); err != nil {
+			return nil, errors.Wrap(err, "invalid unrealized finalized checkpoint")
+		}
+		if dump.ExtraData.PreviousProposerBoostRoot != "" {
+			if previousBoost, err = rootFromJSON(dump.ExtraData.PreviousProposerBoostRoot); err != nil {
+				return nil, errors.Wrap(err, "invalid previous proposer boost root")
+			}
+		}
+	}
+
+	nodes := make([]*forkchoice2.Node, len(dump.ForkChoiceNodes))
+	total := uint64(0)
+	for i, n := range dump.ForkChoiceNodes {
+		if nodes[i], err = nodeFromJSON(n); err != nil {
+			return nil, errors.Wrapf(err, "invalid fork choice node %d", i)
+		}
+		// Nodes without a timestamp are received at the start of their slot.
+		if nodes[i].Timestamp == 0 {
+			nodes[i].Timestamp = genesisTime + uint64(nodes[i].Slot)*params.BeaconConfig().SecondsPerSlot
+		}
+		total += nodes[i].Balance
+	}
+	if activeBalance == 0 {
+		activeBalance = total
+	}
+	// The balance of the last boosted node includes the proposer score, which is not a vote.
+	boostScore := activeBalance / uint64(params.BeaconConfig().SlotsPerEpoch) * params.BeaconConfig().ProposerScoreBoost / 100
+	sc.anchor = nodes[0]
+	voted := uint64(0)
+	for _, n := range nodes {
+		balance := n.Balance
+		if bytesutil.ToBytes32(n.BlockRoot) == previousBoost && previousBoost != [32]byte{} {
+			balance -= min(balance, boostScore)
+		}
+		if n != sc.anchor {
+			sc.events = append(sc.events, &event{time: n.Timestamp, kind: blockEvent, node: n})
+		}
+		if balance == 0 {
+			continue
+		}
+		deadline := genesisTime + uint64(n.Slot)*params.BeaconConfig().SecondsPerSlot +
+			params.BeaconConfig().SecondsPerSlot/params.BeaconConfig().IntervalsPerSlot
+		sc.events = append(sc.events, &event{
+			time:        max(deadline, n.Timestamp),
+			kind:        attestationEvent,
+			indices:     []uint64{uint64(len(sc.balances))},
+			root:        bytesutil.ToBytes32(n.BlockRoot),
+			targetEpoch: slots.ToEpoch(n.Slot),
+		})
+		sc.balances = append(sc.balances, balance)
+		voted += balance
+	}
+	// A validator that never votes holds the rest of the active balance.
+	if activeBalance > voted {
+		sc.balances = append(sc.balances, activeBalance-voted)
+	}
+	sortEvents(sc.events)
+	return sc, nil
+}
+
+// scenarioFromRecording builds a scenario from a recording in JSON lines format. Each line has the unix
+// time in seconds at which the event was received and one of the following types:
+//   - block: a fork choice node, as returned by the fork choice debug endpoint. The first block anchors the store.
+//   - attestation: the attesting validator indices, the block root and the target epoch of an attestation.
+//   - justified_checkpoint, finalized_checkpoint, unrealized_justified_checkpoint and
+//     unrealized_finalized_checkpoint: a checkpoint of the store.
+//   - balances: the effective balances of the justified state, indexed by validator.
+//
+// The checkpoints and balances recorded before the first block are the initial ones of the store. The events
+// stream of the beacon API lacks parent roots and validator indices, so its recordings go through convertEvents first.
+func scenarioFromRecording(r io.Reader) (*scenario, error) {
+	sc := &scenario{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		rec := &recordedEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			if strings.Contains(scanner.Text(), "event:") {
+				return nil, fmt.Errorf("line %d is from a recording of the events stream, use the convert command on it first", line)
+			}
+			return nil, errors.Wrapf(err, "could not decode line %d", line)
+		}
+		e, err := eventFromRecord(rec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid event on line %d", line)
+		}
+		if sc.anchor == nil && sc.setInitial(e) {
+			continue
+		}
+		sc.events = append(sc.events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read recording")
+	}
+	if sc.anchor == nil {
+		return nil, errNoAnchor
+	}
+	sortEvents(sc.events)
+	return sc, nil
+}
+
+// setInitial records the anchor block and the checkpoints and balances that precede it as the initial
+// state of the store, it returns false for the events to replay.
+func (sc *scenario) setInitial(e *event) bool {
+	switch e.kind {
+	case blockEvent:
+		sc.anchor = e.node
+	case justifiedEvent:
+		sc.justified = e.checkpoint
+	case finalizedEvent:
+		sc.finalized = e.checkpoint
+	case unrealizedJustifiedEvent:
+		sc.unrealizedJustified = e.checkpoint
+	case unrealizedFinalizedEvent:
+		sc.unrealizedFinalized = e.checkpoint
+	case balancesEvent:
+		sc.balances = e.balances
+	default:
+		return false
+	}
+	return true
+}
+
+func eventFromRecord(rec *recordedEvent) (*event, error) {
+	t, err := strconv.ParseUint(rec.Time, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid time")
+	}
+	e := &event{time: t}
+	switch rec.Type {
+	case recordedBlock:
+		if rec.Block == nil {
+			return nil, errors.New("missing block")
+		}
+		e.kind = blockEvent
+		e.node, err = nodeFromJSON(rec.Block)
+		if err != nil {
+			return nil, err
+		}
+		// The node is received at the time of the event.
+		e.node.Timestamp = t
+	case recordedAttestation:
+		if rec.Attestation == nil {
+			return nil, errors.New("missing attestation")
+		}
+		e.kind = attestationEvent
+		if e.root, err = rootFromJSON(rec.Attestation.BlockRoot); err != nil {
+			return nil, errors.Wrap(err, "invalid block root")
+		}
+		target, err := strconv.ParseUint(rec.Attestation.TargetEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid target epoch")
+		}
+		e.targetEpoch = primitives.Epoch(target)
+		if e.indices, err = uintsFromJSON(rec.Attestation.ValidatorIndices); err != nil {
+			return nil, errors.Wrap(err, "invalid validator index")
+		}
+	case recordedJustifiedCheckpoint, recordedFinalizedCheckpoint,
+		recordedUnrealizedJustifiedCheckpoint, recordedUnrealizedFinalizedCheckpoint:
+		e.kind = map[string]eventKind{
+			recordedJustifiedCheckpoint:           justifiedEvent,
+			recordedFinalizedCheckpoint:           finalizedEvent,
+			recordedUnrealizedJustifiedCheckpoint: unrealizedJustifiedEvent,
+			recordedUnrealizedFinalizedCheckpoint: unrealizedFinalizedEvent,
+		}[rec.Type]
+		if rec.Checkpoint == nil {
+			return nil, errors.New("missing checkpoint")
+		}
+		if e.checkpoint, err = checkpointFromJSON(rec.Checkpoint); err != nil {
+			return nil, err
+		}
+	case recordedBalances:
+		e.kind = balancesEvent
+		if e.balances, err = uintsFromJSON(rec.Balances); err != nil {
+			return nil, errors.Wrap(err, "invalid balance")
+		}
+	default:
+		return nil, fmt.Errorf("unknown event type %q", rec.Type)
+	}
+	return e, nil
+}
+
+// sortEvents orders events by time. Events received at the same time keep their order.
+func sortEvents(events []*event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].time < events[j].time
+	})
+}
+
+func nodeFromJSON(n *structs.ForkChoiceNode) (*forkchoice2.Node, error) {
+	if n == nil {
+		return nil, errors.New("nil node")
+	}
+	node := &forkchoice2.Node{}
+	var err error
+	if node.BlockRoot, err = hexutil.Decode(n.BlockRoot); err != nil {
+		return nil, errors.Wrap(err, "invalid block root")
+	}
+	if node.ParentRoot, err = hexutil.Decode(n.ParentRoot); err != nil {
+		return nil, errors.Wrap(err, "invalid parent root")
+	}
+	if n.ExecutionBlockHash != "" {
+		if node.ExecutionBlockHash, err = hexutil.Decode(n.ExecutionBlockHash); err != nil {
+			return nil, errors.Wrap(err, "invalid execution block hash")
+		}
+	}
+	slot, err := strconv.ParseUint(n.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid slot")
+	}
+	node.Slot = primitives.Slot(slot)
+	justified, err := strconv.ParseUint(n.JustifiedEpoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid justified epoch")
+	}
+	node.JustifiedEpoch = primitives.Epoch(justified)
+	finalized, err := strconv.ParseUint(n.FinalizedEpoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid finalized epoch")
+	}
+	node.FinalizedEpoch = primitives.Epoch(finalized)
+	node.UnrealizedJustifiedEpoch, node.UnrealizedFinalizedEpoch = node.JustifiedEpoch, node.FinalizedEpoch
+	switch n.Validity {
+	case forkchoice2.Valid.String():
+		node.Validity = forkchoice2.Valid
+	case forkchoice2.Invalid.String():
+		node.Validity = forkchoice2.Invalid
+	default:
+		node.Validity = forkchoice2.Optimistic
+	}
+	if n.ExtraData == nil {
+		return node, nil
+	}
+	if n.ExtraData.UnrealizedJustifiedEpoch != "" {
+		uj, err := strconv.ParseUint(n.ExtraData.UnrealizedJustifiedEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid unrealized justified epoch")
+		}
+		node.UnrealizedJustifiedEpoch = primitives.Epoch(uj)
+	}
+	if n.ExtraData.UnrealizedFinalizedEpoch != "" {
+		uf, err := strconv.ParseUint(n.ExtraData.UnrealizedFinalizedEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid unrealized finalized epoch")
+		}
+		node.UnrealizedFinalizedEpoch = primitives.Epoch(uf)
+	}
+	if n.ExtraData.Balance != "" {
+		if node.Balance, err = strconv.ParseUint(n.ExtraData.Balance, 10, 64); err != nil {
+			return nil, errors.Wrap(err, "invalid balance")
+		}
+	}
+	if n.ExtraData.TimeStamp != "" {
+		if node.Timestamp, err = strconv.ParseUint(n.ExtraData.TimeStamp, 10, 64); err != nil {
+			return nil, errors.Wrap(err, "invalid timestamp")
+		}
+	}
+	return node, nil
+}
+
+func checkpointFromJSON(cp *structs.Checkpoint) (*forkchoicetypes.Checkpoint, error) {
+	if cp == nil {
+		return nil, nil
+	}
+	epoch, err := strconv.ParseUint(cp.Epoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid epoch")
+	}
+	root, err := rootFromJSON(cp.Root)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid root")
+	}
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(epoch), Root: root}, nil
+}
+
+func rootFromJSON(s string) ([32]byte, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if len(b) != 32 {
+		return [32]byte{}, fmt.Errorf("root has length %d", len(b))
+	}
+	return bytesutil.ToBytes32(b), nil
+}
+
+func uintsFromJSON(values []string) ([]uint64, error) {
+	parsed := make([]uint64, len(values))
+	for i, v := range values {
+		var err error
+		if parsed[i], err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return parsed, nil
+}
@@ -0,0 +1,214 @@
+package forkchoice
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var simulateFlags = struct {
+	Dump            string
+	Recording       string
+	GenesisTime     uint64
+	ActiveBalance   uint64
+	StartSlot       uint64
+	EndSlot         uint64
+	BlockArrivals   cli.StringSlice
+	ChainConfigFile string
+}{}
+
+var simulateCmd = &cli.Command{
+	Name: "simulate",
+	Usage: "Replays a fork choice dump or a recording of blocks and attestations on a standalone fork choice store, " +
+		"and prints the head, proposer boost, late block reorg decisions and weights at every interval of every slot.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := simulateAction(cliCtx); err != nil {
+			return errors.Wrap(err, "could not simulate fork choice")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "dump",
+			Usage:       "path to the JSON response of the /eth/v1/debug/fork_choice endpoint",
+			Destination: &simulateFlags.Dump,
+		},
+		&cli.StringFlag{
+			Name: "recording",
+			Usage: "path to a JSON lines recording of blocks, attestations, checkpoints and balances, with the unix time " +
+				"they were received at. The first block anchors the store. The convert command writes one from a recording " +
+				"of the /eth/v1/events stream",
+			Destination: &simulateFlags.Recording,
+		},
+		&cli.Uint64Flag{
+			Name:        "genesis-time",
+			Usage:       "unix time of the genesis of the network",
+			Destination: &simulateFlags.GenesisTime,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "active-balance",
+			Usage:       "total active balance in gwei when replaying a dump, defaults to the balance of the votes in the dump",
+			Destination: &simulateFlags.ActiveBalance,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-slot",
+			Usage:       "first slot to tick through, defaults to the slot after the anchor block",
+			Destination: &simulateFlags.StartSlot,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-slot",
+			Usage:       "last slot to tick through, defaults to the slot after the last event",
+			Destination: &simulateFlags.EndSlot,
+		},
+		&cli.StringSliceFlag{
+			Name: "block-arrival",
+			Usage: "overrides when a block is received, as <slot or block root>=<seconds into its slot>, can be repeated. " +
+				"For example --block-arrival=9000000=5 receives the block of slot 9000000 five seconds into the slot",
+			Destination: &simulateFlags.BlockArrivals,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to the chain config of the network, mainnet is used by default",
+			Destination: &simulateFlags.ChainConfigFile,
+		},
+	},
+}
+
+func simulateAction(cliCtx *cli.Context) error {
+	f := &simulateFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+
+	var sc *scenario
+	var err error
+	switch {
+	case f.Dump != "" && f.Recording != "":
+		return errors.New("only one of --dump and --recording can be given")
+	case f.Dump != "":
+		sc, err = loadScenario(f.Dump, func(r io.Reader) (*scenario, error) {
+			return scenarioFromDump(r, f.GenesisTime, f.ActiveBalance)
+		})
+	case f.Recording != "":
+		sc, err = loadScenario(f.Recording, scenarioFromRecording)
+	default:
+		return errors.New("either --dump or --recording is required")
+	}
+	if err != nil {
+		return err
+	}
+	arrivals, err := parseBlockArrivals(f.BlockArrivals.Value())
+	if err != nil {
+		return err
+	}
+	arrivals.apply(sc, f.GenesisTime)
+
+	start, end := simulationSlots(sc, f.GenesisTime)
+	if f.StartSlot != 0 {
+		start = primitives.Slot(f.StartSlot)
+	}
+	if f.EndSlot != 0 {
+		end = primitives.Slot(f.EndSlot)
+	}
+	if end < start {
+		return fmt.Errorf("end slot %d is before start slot %d", end, start)
+	}
+	s := newSimulator(cliCtx.App.Writer, f.GenesisTime, sc.balances)
+	return s.run(cliCtx.Context, sc, start, end)
+}
+
+func loadScenario(path string, load func(io.Reader) (*scenario, error)) (*scenario, error) {
+	r, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open %s", path)
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.WithError(err).Error("Could not close file")
+		}
+	}()
+	return load(r)
+}
+
+// simulationSlots returns the default slots to tick through: from the slot after the anchor up to the slot
+// after the last event, so that the proposer decision on top of the last block is shown.
+func simulationSlots(sc *scenario, genesisTime uint64) (primitives.Slot, primitives.Slot) {
+	start := sc.anchor.Slot + 1
+	end := start
+	if len(sc.events) > 0 {
+		last := sc.events[len(sc.events)-1].time
+		if last > genesisTime {
+			end = max(end, primitives.Slot((last-genesisTime)/params.BeaconConfig().SecondsPerSlot)+1)
+		}
+	}
+	return start, end
+}
+
+// blockArrivals overrides the time at which blocks are received, in seconds into their slot.
+type blockArrivals struct {
+	bySlot map[primitives.Slot]uint64
+	byRoot map[[32]byte]uint64
+}
+
+func parseBlockArrivals(values []string) (*blockArrivals, error) {
+	a := &blockArrivals{bySlot: make(map[primitives.Slot]uint64), byRoot: make(map[[32]byte]uint64)}
+	for _, v := range values {
+		key, secs, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid block arrival %q, expected <slot or block root>=<seconds into its slot>", v)
+		}
+		seconds, err := strconv.ParseUint(secs, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid seconds in block arrival %q", v)
+		}
+		if strings.HasPrefix(key, "0x") {
+			root, err := rootFromJSON(key)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid block root in block arrival %q", v)
+			}
+			a.byRoot[root] = seconds
+			continue
+		}
+		slot, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot in block arrival %q", v)
+		}
+		a.bySlot[primitives.Slot(slot)] = seconds
+	}
+	return a, nil
+}
+
+// apply moves the overridden blocks of the scenario to their new arrival time.
+func (a *blockArrivals) apply(sc *scenario, genesisTime uint64) {
+	if len(a.bySlot) == 0 && len(a.byRoot) == 0 {
+		return
+	}
+	for _, e := range sc.events {
+		if e.kind != blockEvent {
+			continue
+		}
+		secs, ok := a.byRoot[bytesutil.ToBytes32(e.node.BlockRoot)]
+		if !ok {
+			secs, ok = a.bySlot[e.node.Slot]
+		}
+		if !ok {
+			continue
+		}
+		e.time = uint64(slots.StartTime(genesisTime, e.node.Slot).Unix()) + secs
+		e.node.Timestamp = e.time
+	}
+	sortEvents(sc.events)
+}
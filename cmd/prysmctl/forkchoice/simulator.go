@@ -0,0 +1,284 @@
+package forkchoice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// simulator replays a scenario on a standalone fork choice store. Time only moves forward when the
+// simulator processes an event or ticks, and the store sees the simulated time instead of the system clock.
+type simulator struct {
+	w        io.Writer
+	fc       *doublylinkedtree.ForkChoice
+	genesis  uint64
+	now      uint64
+	balances []uint64
+	uj, uf   *forkchoicetypes.Checkpoint
+	head     [32]byte
+	// pending holds the attestations received before the block they vote for.
+	pending map[[32]byte][]*event
+}
+
+func newSimulator(w io.Writer, genesisTime uint64, balances []uint64) *simulator {
+	s := &simulator{
+		w:        w,
+		fc:       doublylinkedtree.New(),
+		genesis:  genesisTime,
+		balances: balances,
+		pending:  make(map[[32]byte][]*event),
+	}
+	s.fc.SetGenesisTime(genesisTime)
+	s.fc.SetTimeSource(func() time.Time { return time.Unix(int64(s.now), 0) })
+	s.fc.SetBalancesByRooter(func(context.Context, [32]byte) ([]uint64, error) { return s.balances, nil })
+	return s
+}
+
+// tickOffsets returns the seconds into the slot at which the simulator ticks: the start of every interval
+// and the threshold after which the late block reorg heuristics consider the attestations of the slot processed.
+func tickOffsets() []uint64 {
+	interval := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+	offsets := make([]uint64, 0, params.BeaconConfig().IntervalsPerSlot+1)
+	for i := uint64(0); i < params.BeaconConfig().IntervalsPerSlot; i++ {
+		offsets = append(offsets, i*interval)
+	}
+	if doublylinkedtree.ProcessAttestationsThreshold%interval != 0 {
+		offsets = append(offsets, doublylinkedtree.ProcessAttestationsThreshold)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+	return offsets
+}
+
+// anchor inserts the anchor block of the scenario and initializes the checkpoints of the store. The
+// checkpoints default to the anchor block.
+func (s *simulator) anchor(ctx context.Context, sc *scenario) error {
+	s.now = sc.anchor.Timestamp
+	if err := s.fc.InsertDumpedNode(ctx, sc.anchor); err != nil {
+		return errors.Wrap(err, "could not insert anchor block")
+	}
+	root := bytesutil.ToBytes32(sc.anchor.BlockRoot)
+	anchorCheckpoint := &forkchoicetypes.Checkpoint{Epoch: slots.ToEpoch(sc.anchor.Slot), Root: root}
+	justified, finalized := anchorCheckpoint, anchorCheckpoint
+	if sc.justified != nil {
+		justified = sc.justified
+	}
+	if sc.finalized != nil {
+		finalized = sc.finalized
+	}
+	s.uj, s.uf = justified, finalized
+	if sc.unrealizedJustified != nil {
+		s.uj = sc.unrealizedJustified
+	}
+	if sc.unrealizedFinalized != nil {
+		s.uf = sc.unrealizedFinalized
+	}
+	if err := s.fc.UpdateJustifiedCheckpoint(ctx, justified); err != nil {
+		return err
+	}
+	if err := s.fc.UpdateFinalizedCheckpoint(finalized); err != nil {
+		return err
+	}
+	if err := s.fc.SetUnrealizedCheckpoints(s.uj, s.uf); err != nil {
+		return err
+	}
+	s.head = root
+	fmt.Fprintf(s.w, "Anchor block %#x at slot %d, justified epoch %d, finalized epoch %d, %d validators\n",
+		bytesutil.Trunc(root[:]), sc.anchor.Slot, justified.Epoch, finalized.Epoch, len(s.balances))
+	return nil
+}
+
+// run replays the events of the scenario, ticking through the slots in [start, end].
+func (s *simulator) run(ctx context.Context, sc *scenario, start, end primitives.Slot) error {
+	if err := s.anchor(ctx, sc); err != nil {
+		return err
+	}
+	events := sc.events
+	offsets := tickOffsets()
+	for slot := start; slot <= end; slot++ {
+		slotStart := s.genesis + uint64(slot)*params.BeaconConfig().SecondsPerSlot
+		for _, offset := range offsets {
+			t := slotStart + offset
+			for len(events) > 0 && events[0].time < t {
+				if err := s.process(ctx, events[0]); err != nil {
+					return err
+				}
+				events = events[1:]
+			}
+			s.now = t
+			if offset == 0 {
+				if err := s.fc.NewSlot(ctx, slot); err != nil {
+					return errors.Wrapf(err, "could not process new slot %d", slot)
+				}
+			}
+			// Events received right at the tick come after the slot started.
+			for len(events) > 0 && events[0].time == t {
+				if err := s.process(ctx, events[0]); err != nil {
+					return err
+				}
+				events = events[1:]
+			}
+			if err := s.tick(ctx, slot, offset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// process applies an event to the store at the time it was received.
+func (s *simulator) process(ctx context.Context, e *event) error {
+	s.now = max(s.now, e.time)
+	switch e.kind {
+	case blockEvent:
+		root := bytesutil.ToBytes32(e.node.BlockRoot)
+		parent := bytesutil.ToBytes32(e.node.ParentRoot)
+		if !s.fc.HasNode(parent) {
+			fmt.Fprintf(s.w, "  block %#x at slot %d ignored, unknown parent %#x\n",
+				bytesutil.Trunc(root[:]), e.node.Slot, bytesutil.Trunc(parent[:]))
+			return nil
+		}
+		if err := s.fc.InsertDumpedNode(ctx, e.node); err != nil {
+			return errors.Wrapf(err, "could not insert block %#x", root)
+		}
+		secs, err := slots.SecondsSinceSlotStart(e.node.Slot, s.genesis, e.node.Timestamp)
+		if err != nil {
+			return errors.Wrapf(err, "could not compute arrival time of block %#x", root)
+		}
+		fmt.Fprintf(s.w, "  block %#x at slot %d received %ds into its slot, proposer boost: %v\n",
+			bytesutil.Trunc(root[:]), e.node.Slot, secs, s.fc.ProposerBoost() == root)
+		for _, att := range s.pending[root] {
+			s.fc.ProcessAttestation(ctx, att.indices, att.root, att.targetEpoch)
+		}
+		delete(s.pending, root)
+	case attestationEvent:
+		if !s.fc.HasNode(e.root) {
+			s.pending[e.root] = append(s.pending[e.root], e)
+			return nil
+		}
+		s.fc.ProcessAttestation(ctx, e.indices, e.root, e.targetEpoch)
+	case justifiedEvent:
+		if err := s.fc.UpdateJustifiedCheckpoint(ctx, e.checkpoint); err != nil {
+			return errors.Wrap(err, "could not update justified checkpoint")
+		}
+		fmt.Fprintf(s.w, "  justified checkpoint updated to epoch %d\n", e.checkpoint.Epoch)
+	case finalizedEvent:
+		if err := s.fc.UpdateFinalizedCheckpoint(e.checkpoint); err != nil {
+			return errors.Wrap(err, "could not update finalized checkpoint")
+		}
+		fmt.Fprintf(s.w, "  finalized checkpoint updated to epoch %d\n", e.checkpoint.Epoch)
+	case unrealizedJustifiedEvent, unrealizedFinalizedEvent:
+		if e.kind == unrealizedJustifiedEvent {
+			s.uj = e.checkpoint
+		} else {
+			s.uf = e.checkpoint
+		}
+		if err := s.fc.SetUnrealizedCheckpoints(s.uj, s.uf); err != nil {
+			return errors.Wrap(err, "could not update unrealized checkpoints")
+		}
+	case balancesEvent:
+		s.balances = e.balances
+		// Reload the justified balances of the store.
+		if err := s.fc.UpdateJustifiedCheckpoint(ctx, s.fc.JustifiedCheckpoint()); err != nil {
+			return errors.Wrap(err, "could not update balances")
+		}
+	}
+	return nil
+}
+
+// tick recomputes the head and prints the view of the store at the given time into the slot.
+func (s *simulator) tick(ctx context.Context, slot primitives.Slot, offset uint64) error {
+	prefix := fmt.Sprintf("slot %d +%ds:", slot, offset)
+	head, err := s.fc.Head(ctx)
+	if err != nil {
+		fmt.Fprintf(s.w, "%s could not compute head: %v\n", prefix, err)
+		return nil
+	}
+	if head != s.head {
+		if err := s.printHeadChange(ctx, prefix, s.head, head); err != nil {
+			return err
+		}
+		s.head = head
+	}
+	headSlot, err := s.fc.Slot(head)
+	if err != nil {
+		return err
+	}
+	headWeight, err := s.fc.Weight(head)
+	if err != nil {
+		return err
+	}
+	boost := s.fc.ProposerBoost()
+	boostInfo := "none"
+	if boost != [32]byte{} {
+		boostInfo = fmt.Sprintf("%#x", bytesutil.Trunc(boost[:]))
+	}
+	fmt.Fprintf(s.w, "%s head %#x slot %d weight %d, proposer boost %s, justified epoch %d, finalized epoch %d, tips %s\n",
+		prefix, bytesutil.Trunc(head[:]), headSlot, headWeight, boostInfo,
+		s.fc.JustifiedCheckpoint().Epoch, s.fc.FinalizedCheckpoint().Epoch, s.tipWeights())
+
+	if offset == 0 {
+		if proposerHead := s.fc.GetProposerHead(); proposerHead != head {
+			fmt.Fprintf(s.w, "%s proposer would reorg the late head %#x and build on %#x\n",
+				prefix, bytesutil.Trunc(head[:]), bytesutil.Trunc(proposerHead[:]))
+		}
+	}
+	if offset == doublylinkedtree.ProcessAttestationsThreshold && s.fc.ShouldOverrideFCU() {
+		fmt.Fprintf(s.w, "%s head %#x is late and weak, the forkchoice update would be overridden with its parent\n",
+			prefix, bytesutil.Trunc(head[:]))
+	}
+	return nil
+}
+
+func (s *simulator) printHeadChange(ctx context.Context, prefix string, oldHead, newHead [32]byte) error {
+	oldSlot, err := s.fc.Slot(oldHead)
+	if err != nil {
+		// The old head was pruned, it was finalized away.
+		fmt.Fprintf(s.w, "%s head changed to %#x\n", prefix, bytesutil.Trunc(newHead[:]))
+		return nil
+	}
+	ancestor, err := s.fc.AncestorRoot(ctx, newHead, oldSlot)
+	if err != nil {
+		return errors.Wrap(err, "could not get ancestor of new head")
+	}
+	if ancestor == oldHead {
+		fmt.Fprintf(s.w, "%s head changed from %#x to %#x\n", prefix, bytesutil.Trunc(oldHead[:]), bytesutil.Trunc(newHead[:]))
+		return nil
+	}
+	common, commonSlot, err := s.fc.CommonAncestor(ctx, oldHead, newHead)
+	if err != nil {
+		return errors.Wrap(err, "could not get common ancestor of old and new head")
+	}
+	fmt.Fprintf(s.w, "%s reorg from %#x to %#x, common ancestor %#x at slot %d, depth %d\n", prefix,
+		bytesutil.Trunc(oldHead[:]), bytesutil.Trunc(newHead[:]), bytesutil.Trunc(common[:]), commonSlot, oldSlot-commonSlot)
+	return nil
+}
+
+// tipWeights formats the weight of every leaf of the store, ordered by slot.
+func (s *simulator) tipWeights() string {
+	roots, tipSlots := s.fc.Tips()
+	idx := make([]int, len(roots))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return tipSlots[idx[i]] < tipSlots[idx[j]] })
+	tips := make([]string, 0, len(roots))
+	for _, i := range idx {
+		w, err := s.fc.Weight(roots[i])
+		if err != nil {
+			continue
+		}
+		tips = append(tips, fmt.Sprintf("%#x=%d", bytesutil.Trunc(roots[i][:]), w))
+	}
+	return "[" + strings.Join(tips, " ") + "]"
+}
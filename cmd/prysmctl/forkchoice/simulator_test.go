@@ -0,0 +1,208 @@
+package forkchoice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	forkchoice2 "github.com/prysmaticlabs/prysm/v5/consensus-types/forkchoice"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+const testGenesisTime = uint64(1606824023)
+
+func testRoot(i byte) string {
+	return fmt.Sprintf("%#x", bytesutil.PadTo([]byte{i}, 32))
+}
+
+func slotTime(slot primitives.Slot, secs uint64) uint64 {
+	return testGenesisTime + uint64(slot)*params.BeaconConfig().SecondsPerSlot + secs
+}
+
+func recordedBlockLine(t uint64, slot primitives.Slot, root, parent byte) string {
+	return fmt.Sprintf(`{"time":"%d","type":"block","block":{"slot":"%d","block_root":"%s","parent_root":"%s","justified_epoch":"0","finalized_epoch":"0","validity":"valid"}}`,
+		t, slot, testRoot(root), testRoot(parent))
+}
+
+func recordedAttestationLine(t uint64, root byte, indices ...int) string {
+	idx := make([]string, len(indices))
+	for i, v := range indices {
+		idx[i] = fmt.Sprintf(`"%d"`, v)
+	}
+	return fmt.Sprintf(`{"time":"%d","type":"attestation","attestation":{"block_root":"%s","target_epoch":"0","validator_indices":[%s]}}`,
+		t, testRoot(root), strings.Join(idx, ","))
+}
+
+// lateBlockRecording has an on time block at slot 1 voted by four validators, and a late block at slot 2
+// that nobody votes for.
+func lateBlockRecording() string {
+	balances := make([]string, 64)
+	for i := range balances {
+		balances[i] = fmt.Sprintf(`"%d"`, params.BeaconConfig().MaxEffectiveBalance)
+	}
+	lines := []string{
+		fmt.Sprintf(`{"time":"%d","type":"balances","balances":[%s]}`, testGenesisTime, strings.Join(balances, ",")),
+		recordedBlockLine(testGenesisTime, 0, 1, 0),
+		recordedBlockLine(slotTime(1, 1), 1, 2, 1),
+		recordedAttestationLine(slotTime(1, 4), 2, 0, 1, 2, 3),
+		recordedBlockLine(slotTime(2, 5), 2, 3, 2),
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestScenarioFromRecording(t *testing.T) {
+	sc, err := scenarioFromRecording(strings.NewReader(lateBlockRecording()))
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), sc.anchor.Slot)
+	// The balances recorded before the anchor block are the initial balances.
+	require.Equal(t, 64, len(sc.balances))
+	require.Equal(t, 3, len(sc.events))
+	require.Equal(t, blockEvent, sc.events[0].kind)
+	require.Equal(t, slotTime(1, 1), sc.events[0].node.Timestamp)
+	require.DeepEqual(t, []uint64{0, 1, 2, 3}, sc.events[1].indices)
+
+	_, err = scenarioFromRecording(strings.NewReader(`{"time":"1","type":"head"}`))
+	require.ErrorContains(t, "unknown event type", err)
+	_, err = scenarioFromRecording(strings.NewReader(recordedAttestationLine(1, 2, 0)))
+	require.ErrorIs(t, err, errNoAnchor)
+}
+
+func TestScenarioFromDump(t *testing.T) {
+	dump := fmt.Sprintf(`{
+		"justified_checkpoint": {"epoch": "0", "root": "%[1]s"},
+		"finalized_checkpoint": {"epoch": "0", "root": "%[1]s"},
+		"fork_choice_nodes": [
+			{"slot": "0", "block_root": "%[1]s", "parent_root": "%[4]s", "justified_epoch": "0", "finalized_epoch": "0", "validity": "valid",
+			 "extra_data": {"balance": "0", "timestamp": "%[5]d"}},
+			{"slot": "1", "block_root": "%[2]s", "parent_root": "%[1]s", "justified_epoch": "0", "finalized_epoch": "0", "validity": "valid",
+			 "extra_data": {"balance": "100", "timestamp": "%[6]d"}},
+			{"slot": "2", "block_root": "%[3]s", "parent_root": "%[2]s", "justified_epoch": "0", "finalized_epoch": "0", "validity": "optimistic",
+			 "extra_data": {"balance": "500"}}
+		],
+		"extra_data": {"previous_proposer_boost_root": "%[3]s"}
+	}`, testRoot(1), testRoot(2), testRoot(3), testRoot(0), testGenesisTime, slotTime(1, 6))
+
+	activeBalance := 1000 * uint64(params.BeaconConfig().SlotsPerEpoch)
+	sc, err := scenarioFromDump(strings.NewReader(dump), testGenesisTime, activeBalance)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), sc.anchor.Slot)
+	require.Equal(t, primitives.Epoch(0), sc.justified.Epoch)
+	// The proposer score of 400 is removed from the balance of the boosted node, the last validator holds the
+	// balance that did not vote.
+	require.DeepEqual(t, []uint64{100, 100, activeBalance - 200}, sc.balances)
+
+	require.Equal(t, 4, len(sc.events))
+	// The block of slot 1 arrived after the attestation deadline, its votes come with it.
+	require.Equal(t, blockEvent, sc.events[0].kind)
+	require.Equal(t, slotTime(1, 6), sc.events[0].time)
+	require.Equal(t, attestationEvent, sc.events[1].kind)
+	require.Equal(t, slotTime(1, 6), sc.events[1].time)
+	// The block of slot 2 has no timestamp, it is received at the start of its slot.
+	require.Equal(t, blockEvent, sc.events[2].kind)
+	require.Equal(t, slotTime(2, 0), sc.events[2].time)
+	require.Equal(t, attestationEvent, sc.events[3].kind)
+	require.Equal(t, slotTime(2, 4), sc.events[3].time)
+	require.DeepEqual(t, []uint64{1}, sc.events[3].indices)
+}
+
+func TestBlockArrivals(t *testing.T) {
+	_, err := parseBlockArrivals([]string{"12"})
+	require.ErrorContains(t, "invalid block arrival", err)
+	_, err = parseBlockArrivals([]string{"0x01=2"})
+	require.ErrorContains(t, "invalid block root", err)
+
+	sc, err := scenarioFromRecording(strings.NewReader(lateBlockRecording()))
+	require.NoError(t, err)
+	arrivals, err := parseBlockArrivals([]string{"2=1", testRoot(2) + "=9"})
+	require.NoError(t, err)
+	arrivals.apply(sc, testGenesisTime)
+	var blockTimes []uint64
+	for _, e := range sc.events {
+		if e.kind == blockEvent {
+			blockTimes = append(blockTimes, e.node.Timestamp)
+		}
+	}
+	require.DeepEqual(t, []uint64{slotTime(1, 9), slotTime(2, 1)}, blockTimes)
+	for i := 1; i < len(sc.events); i++ {
+		require.Equal(t, true, sc.events[i-1].time <= sc.events[i].time)
+	}
+}
+
+func TestSimulator_LateBlockReorg(t *testing.T) {
+	sc, err := scenarioFromRecording(strings.NewReader(lateBlockRecording()))
+	require.NoError(t, err)
+	var out bytes.Buffer
+	s := newSimulator(&out, testGenesisTime, sc.balances)
+	require.NoError(t, s.run(context.Background(), sc, 1, 3))
+	got := out.String()
+
+	root2, root3 := bytesutil.PadTo([]byte{2}, 32), bytesutil.PadTo([]byte{3}, 32)
+	require.StringContains(t, "64 validators", got)
+	require.StringContains(t, fmt.Sprintf("block %#x at slot 1 received 1s into its slot, proposer boost: true", bytesutil.Trunc(root2)), got)
+	require.StringContains(t, fmt.Sprintf("block %#x at slot 2 received 5s into its slot, proposer boost: false", bytesutil.Trunc(root3)), got)
+	require.StringContains(t, fmt.Sprintf("slot 2 +8s: head changed from %#x to %#x", bytesutil.Trunc(root2), bytesutil.Trunc(root3)), got)
+	require.StringContains(t, fmt.Sprintf("slot 2 +10s: head %#x is late and weak", bytesutil.Trunc(root3)), got)
+	require.StringContains(t, fmt.Sprintf("slot 3 +0s: proposer would reorg the late head %#x and build on %#x", bytesutil.Trunc(root3), bytesutil.Trunc(root2)), got)
+}
+
+func TestSimulator_ProposerBoostReorg(t *testing.T) {
+	// Two competing blocks at slot 2: the timely one is boosted and takes the head from the late one.
+	lines := []string{
+		recordedBlockLine(testGenesisTime, 0, 1, 0),
+		recordedBlockLine(slotTime(1, 11), 1, 2, 1),
+		recordedBlockLine(slotTime(2, 1), 2, 3, 1),
+	}
+	sc, err := scenarioFromRecording(strings.NewReader(strings.Join(lines, "\n")))
+	require.NoError(t, err)
+	balances := make([]uint64, 64)
+	for i := range balances {
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	sc.balances = balances
+	var out bytes.Buffer
+	s := newSimulator(&out, testGenesisTime, sc.balances)
+	require.NoError(t, s.run(context.Background(), sc, 1, 2))
+	got := out.String()
+
+	root1, root2, root3 := bytesutil.PadTo([]byte{1}, 32), bytesutil.PadTo([]byte{2}, 32), bytesutil.PadTo([]byte{3}, 32)
+	require.StringContains(t, fmt.Sprintf("slot 2 +4s: reorg from %#x to %#x, common ancestor %#x at slot 0, depth 1",
+		bytesutil.Trunc(root2), bytesutil.Trunc(root3), bytesutil.Trunc(root1)), got)
+	require.StringContains(t, fmt.Sprintf("proposer boost %#x", bytesutil.Trunc(root3)), got)
+}
+
+func TestRebuild(t *testing.T) {
+	ctx := context.Background()
+	_, err := parseRebuildTime("yesterday")
+	require.ErrorContains(t, "invalid time", err)
+	until, err := parseRebuildTime("2024-01-02T03:04:05Z")
+	require.NoError(t, err)
+	require.Equal(t, int64(1704164645), until.Unix())
+	until, err = parseRebuildTime("1704164645")
+	require.NoError(t, err)
+	require.Equal(t, int64(1704164645), until.Unix())
+
+	dir := t.TempDir()
+	fc := doublylinkedtree.New()
+	fc.SetGenesisTime(testGenesisTime)
+	root := bytesutil.PadTo([]byte{1}, 32)
+	require.NoError(t, fc.InsertDumpedNode(ctx, &forkchoice2.Node{Slot: 0, BlockRoot: root, ParentRoot: make([]byte, 32)}))
+	j, err := doublylinkedtree.NewJournal(dir)
+	require.NoError(t, err)
+	require.NoError(t, fc.SetJournal(j))
+	require.NoError(t, j.Close())
+
+	rebuilt, err := doublylinkedtree.ReplayJournalDir(ctx, dir, time.Time{})
+	require.NoError(t, err)
+	dump, err := rebuilt.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	resp := dumpResponse(dump)
+	require.Equal(t, 1, len(resp.ForkChoiceNodes))
+	require.Equal(t, testRoot(1), resp.ForkChoiceNodes[0].BlockRoot)
+}
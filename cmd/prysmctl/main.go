@@ -5,6 +5,8 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/checkpointsync"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/db"
+	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/debug"
+	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/forkchoice"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/p2p"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/testnet"
 	"github.com/prysmaticlabs/prysm/v5/cmd/prysmctl/validator"
@@ -28,6 +30,8 @@ func main() {
 func init() {
 	prysmctlCommands = append(prysmctlCommands, checkpointsync.Commands...)
 	prysmctlCommands = append(prysmctlCommands, db.Commands...)
+	prysmctlCommands = append(prysmctlCommands, debug.Commands...)
+	prysmctlCommands = append(prysmctlCommands, forkchoice.Commands...)
 	prysmctlCommands = append(prysmctlCommands, p2p.Commands...)
 	prysmctlCommands = append(prysmctlCommands, testnet.Commands...)
 	prysmctlCommands = append(prysmctlCommands, weaksubjectivity.Commands...)
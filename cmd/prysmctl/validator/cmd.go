@@ -70,6 +70,75 @@ var (
 		Aliases: []string{"t"},
 		Usage:   "keymanager API bearer token, note: currently required but may be removed in the future, this is the same token as the web ui token.",
 	}
+
+	SplitKeystoresFlag = &cli.StringFlag{
+		Name:     "keystores",
+		Usage:    "path to an EIP-2335 keystore, or to a directory of keystores, whose keys to split into shares",
+		Required: true,
+	}
+
+	SplitKeystorePasswordFileFlag = &cli.StringFlag{
+		Name:     "keystore-password-file",
+		Usage:    "path to a file containing the password of the keystores to split",
+		Required: true,
+	}
+
+	SplitThresholdFlag = &cli.Uint64Flag{
+		Name:     "threshold",
+		Usage:    "number of share holders needed to sign, which must be a majority of them",
+		Required: true,
+	}
+
+	ShareHolderURLsFlag = &cli.StringSliceFlag{
+		Name:     "share-holder-urls",
+		Usage:    "http(s)://host:port urls on which the share holders serve partial signatures to each other, in share id order. One share is created per url",
+		Required: true,
+	}
+
+	SharePasswordFileFlag = &cli.StringFlag{
+		Name:  "share-password-file",
+		Usage: "path to a file containing the password of the share keystores, defaults to the password of the keystores to split",
+	}
+
+	SplitOutputDirFlag = &cli.StringFlag{
+		Name:  "output-dir",
+		Usage: "directory in which a share-<id> directory is written for every share holder",
+		Value: "threshold-shares",
+	}
+
+	SourceHostFlag = &cli.StringFlag{
+		Name:     "source-validator-host",
+		Usage:    "host:port for the keymanager API of the validator client to migrate the keys from",
+		Required: true,
+	}
+
+	SourceTokenFlag = &cli.StringFlag{
+		Name:  "source-token",
+		Usage: "keymanager API bearer token of the validator client to migrate the keys from",
+	}
+
+	DestinationHostFlag = &cli.StringFlag{
+		Name:     "destination-validator-host",
+		Usage:    "host:port for the keymanager API of the validator client to migrate the keys to",
+		Required: true,
+	}
+
+	DestinationTokenFlag = &cli.StringFlag{
+		Name:  "destination-token",
+		Usage: "keymanager API bearer token of the validator client to migrate the keys to",
+	}
+
+	MigrateKeystoresFlag = &cli.StringFlag{
+		Name:     "keystores",
+		Usage:    "path to an EIP-2335 keystore, or to a directory of keystores, of the keys to migrate",
+		Required: true,
+	}
+
+	MigrateKeystorePasswordFileFlag = &cli.StringFlag{
+		Name:     "keystore-password-file",
+		Usage:    "path to a file containing the password of the keystores of the keys to migrate",
+		Required: true,
+	}
 )
 
 var Commands = []*cli.Command{
@@ -144,6 +213,53 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			{
+				Name:  "split-keystore",
+				Usage: "Split the keys of EIP-2335 keystores into shares for the threshold keymanager, so that a validator can be run by several share holders of which none holds its key.",
+				Flags: []cli.Flag{
+					cmd.ConfigFileFlag,
+					SplitKeystoresFlag,
+					SplitKeystorePasswordFileFlag,
+					SplitThresholdFlag,
+					ShareHolderURLsFlag,
+					SharePasswordFileFlag,
+					SplitOutputDirFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := splitKeystores(cliCtx); err != nil {
+						log.WithError(err).Fatal("Could not split keystores")
+					}
+					return nil
+				},
+			},
+			{
+				Name: "migrate-keys",
+				Usage: "Move keys from a validator client to another through the keymanager API, along with their slashing protection history. " +
+					"The keys are deleted from the source, and imported into the destination once two full epochs passed in which the beacon node did not see them sign. " +
+					"A failed migration is rolled back.",
+				Flags: []cli.Flag{
+					cmd.ConfigFileFlag,
+					SourceHostFlag,
+					SourceTokenFlag,
+					DestinationHostFlag,
+					DestinationTokenFlag,
+					MigrateKeystoresFlag,
+					MigrateKeystorePasswordFileFlag,
+					BeaconHostFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := migrateKeys(cliCtx); err != nil {
+						log.WithError(err).Fatal("Could not migrate keys")
+					}
+					return nil
+				},
+			},
 			{
 				Name:    "exit",
 				Aliases: []string{"e", "voluntary-exit"},
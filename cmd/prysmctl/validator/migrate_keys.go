@@ -0,0 +1,425 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/client/validator"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/rpc"
+	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// waitUntil blocks until the given time, or until the context is done.
+var waitUntil = func(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// migrationKey is a key to migrate, along with its EIP-2335 keystore.
+type migrationKey struct {
+	pubKey   string
+	keystore string
+}
+
+// keyMigration moves keys from the validator client of a source keymanager API to the validator client of a
+// destination keymanager API. It records its progress, so that a failed migration can be rolled back.
+type keyMigration struct {
+	source      *validator.Client
+	destination *validator.Client
+	beacon      *beacon.Client
+	keys        []*migrationKey
+	password    string
+
+	deletedFromSource     bool
+	sourceProtection      string
+	importedToDestination bool
+}
+
+// migrateKeys moves keys from a source validator client to a destination validator client, along with their
+// slashing protection history, waiting for two full epochs in between in which neither client holds the keys,
+// and in which the beacon node must not have seen the keys sign.
+func migrateKeys(cliCtx *cli.Context) error {
+	ctx, span := trace.StartSpan(cliCtx.Context, "prysmctl.migrateKeys")
+	defer span.End()
+
+	for _, f := range []string{SourceTokenFlag.Name, DestinationTokenFlag.Name} {
+		if !cliCtx.IsSet(f) {
+			return errNoFlag(f)
+		}
+	}
+	if cliCtx.String(SourceHostFlag.Name) == cliCtx.String(DestinationHostFlag.Name) {
+		return errors.New("source and destination validator clients must be different")
+	}
+	password, err := file.ReadFileAsBytes(cliCtx.String(MigrateKeystorePasswordFileFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not read keystore password file")
+	}
+	m := &keyMigration{password: strings.TrimSpace(string(password))}
+	m.keys, err = readMigrationKeys(cliCtx.String(MigrateKeystoresFlag.Name), m.password)
+	if err != nil {
+		return err
+	}
+	m.source, err = validator.NewClient(cliCtx.String(SourceHostFlag.Name), client.WithAuthenticationToken(cliCtx.String(SourceTokenFlag.Name)))
+	if err != nil {
+		return errors.Wrap(err, "invalid source validator client host")
+	}
+	m.destination, err = validator.NewClient(cliCtx.String(DestinationHostFlag.Name), client.WithAuthenticationToken(cliCtx.String(DestinationTokenFlag.Name)))
+	if err != nil {
+		return errors.Wrap(err, "invalid destination validator client host")
+	}
+	m.beacon, err = beacon.NewClient(cliCtx.String(BeaconHostFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "invalid beacon node host")
+	}
+	return m.run(ctx)
+}
+
+// readMigrationKeys reads a keystore, or the keystores of a directory, and checks that they can be decrypted.
+func readMigrationKeys(path, password string) ([]*migrationKey, error) {
+	paths, err := keystorePaths(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*migrationKey, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for i, p := range paths {
+		b, err := file.ReadFileAsBytes(p)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read keystore")
+		}
+		keystore := &keymanager.Keystore{}
+		if err := json.Unmarshal(b, keystore); err != nil {
+			return nil, errors.Wrapf(err, "could not decode keystore %s", p)
+		}
+		// The keys are only deleted from the source if they can be imported into the destination.
+		secretKey, err := keystorev4.New().Decrypt(keystore.Crypto, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt keystore %s", p)
+		}
+		sk, err := bls.SecretKeyFromBytes(secretKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid secret key in keystore %s", p)
+		}
+		pubKey := fmt.Sprintf("%#x", sk.PublicKey().Marshal())
+		if keystore.Pubkey != "" && !strings.EqualFold(strings.TrimPrefix(keystore.Pubkey, "0x"), pubKey[2:]) {
+			return nil, fmt.Errorf("keystore %s holds the key of %s, not of %s", p, pubKey, keystore.Pubkey)
+		}
+		if seen[pubKey] {
+			return nil, fmt.Errorf("key %s is in several keystores", pubKey)
+		}
+		seen[pubKey] = true
+		keys[i] = &migrationKey{pubKey: pubKey, keystore: string(b)}
+	}
+	return keys, nil
+}
+
+// run migrates the keys, and rolls the migration back if one of its steps fails.
+func (m *keyMigration) run(ctx context.Context) error {
+	if err := m.migrate(ctx); err != nil {
+		log.WithError(err).Error("Key migration failed, rolling back")
+		// The rollback must not be interrupted by the cancellation which may have failed the migration.
+		if rollbackErr := m.rollback(context.WithoutCancel(ctx)); rollbackErr != nil {
+			return errors.Wrapf(err, "key migration failed and could not be rolled back (%v), the keys may not be "+
+				"held by any validator client", rollbackErr)
+		}
+		return errors.Wrap(err, "key migration failed and was rolled back")
+	}
+	log.WithField("keys", len(m.keys)).Info("Migrated keys to the destination validator client")
+	return nil
+}
+
+// migrate runs the steps of the migration.
+func (m *keyMigration) migrate(ctx context.Context) error {
+	pubKeys := m.pubKeys()
+
+	log.WithField("keys", len(pubKeys)).Info("Checking that the keys are only held by the source validator client")
+	if err := checkKeys(ctx, m.source, pubKeys, true); err != nil {
+		return errors.Wrap(err, "source validator client")
+	}
+	if err := checkKeys(ctx, m.destination, pubKeys, false); err != nil {
+		return errors.Wrap(err, "destination validator client")
+	}
+	genesis, epochDuration, err := m.epochTiming(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Deleting the keys from the source validator client")
+	// Whether keys were deleted is unknown if the request fails, so a rollback imports them again.
+	m.deletedFromSource = true
+	deleted, err := m.source.DeleteKeystores(ctx, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not delete keys from the source validator client")
+	}
+	m.sourceProtection = deleted.SlashingProtection
+	if err := checkStatuses(pubKeys, deleted.Data, keymanager.StatusDeleted); err != nil {
+		return errors.Wrap(err, "source validator client")
+	}
+	if _, err := parseProtection(m.sourceProtection); err != nil {
+		return errors.Wrap(err, "invalid slashing protection history from the source validator client")
+	}
+
+	// Two full epochs must elapse after the deletion, so that no message of the source validator client is still
+	// in flight when the destination validator client starts signing, and so that the keys can be checked not to
+	// be live in either of them: a key which signed is still used by the source or by another validator client.
+	current := uint64(time.Since(genesis) / epochDuration)
+	until := genesis.Add(time.Duration(current+3) * epochDuration)
+	log.WithField("until", until.Format(time.RFC3339)).Info("Waiting for two full epochs without signatures")
+	if err := waitUntil(ctx, until); err != nil {
+		return errors.Wrap(err, "interrupted while waiting for two full epochs")
+	}
+	if err := checkKeys(ctx, m.source, pubKeys, false); err != nil {
+		return errors.Wrap(err, "source validator client")
+	}
+	if err := m.checkLiveness(ctx, primitives.Epoch(current+1), primitives.Epoch(current+2)); err != nil {
+		return err
+	}
+
+	log.Info("Importing the keys and their slashing protection history into the destination validator client")
+	m.importedToDestination = true
+	if err := importKeys(ctx, m.destination, m.keys, m.password, m.sourceProtection, keymanager.StatusImported); err != nil {
+		return errors.Wrap(err, "destination validator client")
+	}
+	if err := checkKeys(ctx, m.destination, pubKeys, true); err != nil {
+		return errors.Wrap(err, "destination validator client")
+	}
+	return nil
+}
+
+// rollback removes the keys from the destination validator client, and imports them back into the source validator
+// client along with the slashing protection history of both.
+func (m *keyMigration) rollback(ctx context.Context) error {
+	if !m.deletedFromSource {
+		log.Info("The source validator client still holds the keys, nothing to roll back")
+		return nil
+	}
+	pubKeys := m.pubKeys()
+	protection := m.sourceProtection
+	if m.importedToDestination {
+		log.Info("Deleting the keys from the destination validator client")
+		deleted, err := m.destination.DeleteKeystores(ctx, pubKeys)
+		if err != nil {
+			return errors.Wrap(err, "could not delete keys from the destination validator client")
+		}
+		if err := checkStatuses(pubKeys, deleted.Data, keymanager.StatusDeleted, keymanager.StatusNotActive, keymanager.StatusNotFound); err != nil {
+			return errors.Wrap(err, "destination validator client")
+		}
+		protection, err = mergeProtection(protection, deleted.SlashingProtection)
+		if err != nil {
+			return err
+		}
+	}
+	if protection == "" {
+		// The deletion request may have failed before reaching the source validator client.
+		if err := checkKeys(ctx, m.source, pubKeys, true); err == nil {
+			log.Info("The source validator client still holds the keys, nothing to roll back")
+			return nil
+		}
+		// Not importing the keys back is safer than importing them without their history.
+		return errors.New("no slashing protection history of the keys to import back into the source validator client")
+	}
+	log.Info("Importing the keys and their slashing protection history back into the source validator client")
+	// Keys which could not be deleted from the source are still held by it.
+	if err := importKeys(ctx, m.source, m.keys, m.password, protection, keymanager.StatusImported, keymanager.StatusDuplicate); err != nil {
+		return errors.Wrap(err, "source validator client")
+	}
+	return checkKeys(ctx, m.source, pubKeys, true)
+}
+
+func (m *keyMigration) pubKeys() []string {
+	pubKeys := make([]string, len(m.keys))
+	for i, k := range m.keys {
+		pubKeys[i] = k.pubKey
+	}
+	return pubKeys
+}
+
+// checkLiveness checks that the beacon node has not seen any of the keys participate in the given epochs.
+// Keys which are not known to the beacon node yet, for example because their deposit is pending, can't be live.
+func (m *keyMigration) checkLiveness(ctx context.Context, epochs ...primitives.Epoch) error {
+	validators, err := m.beacon.GetValidators(ctx, beacon.IdHead, m.pubKeys())
+	if err != nil {
+		return errors.Wrap(err, "could not get the validators of the keys from the beacon node")
+	}
+	if len(validators.Data) == 0 {
+		return nil
+	}
+	indices := make([]string, len(validators.Data))
+	pubKeys := make(map[string]string, len(validators.Data))
+	for i, v := range validators.Data {
+		if v.Validator == nil {
+			return fmt.Errorf("beacon node returned validator %s without its public key", v.Index)
+		}
+		indices[i] = v.Index
+		pubKeys[v.Index] = v.Validator.Pubkey
+	}
+	for _, epoch := range epochs {
+		log.WithField("epoch", epoch).Info("Checking that the keys did not sign in the epoch")
+		liveness, err := m.beacon.GetLiveness(ctx, epoch, indices)
+		if err != nil {
+			return errors.Wrapf(err, "could not get the liveness of the keys in epoch %d from the beacon node", epoch)
+		}
+		for _, l := range liveness.Data {
+			if l.IsLive {
+				return fmt.Errorf("key %s of validator %s was live in epoch %d after it was deleted from the source "+
+					"validator client, it may be used by another validator client", pubKeys[l.Index], l.Index, epoch)
+			}
+		}
+	}
+	return nil
+}
+
+// epochTiming returns the genesis time and the duration of an epoch of the chain of the beacon node.
+func (m *keyMigration) epochTiming(ctx context.Context) (time.Time, time.Duration, error) {
+	genesis, err := m.beacon.GetGenesis(ctx)
+	if err != nil {
+		return time.Time{}, 0, errors.Wrap(err, "could not get genesis from the beacon node")
+	}
+	genesisTime, err := strconv.ParseInt(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.Wrapf(err, "invalid genesis time %s", genesis.GenesisTime)
+	}
+	spec, err := m.beacon.GetConfigSpec(ctx)
+	if err != nil {
+		return time.Time{}, 0, errors.Wrap(err, "could not get config spec from the beacon node")
+	}
+	data, ok := spec.Data.(map[string]interface{})
+	if !ok {
+		return time.Time{}, 0, errors.New("invalid config spec of the beacon node")
+	}
+	values := make(map[string]uint64, 2)
+	for _, name := range []string{"SECONDS_PER_SLOT", "SLOTS_PER_EPOCH"} {
+		v, ok := data[name].(string)
+		if !ok {
+			return time.Time{}, 0, fmt.Errorf("config spec of the beacon node has no %s", name)
+		}
+		values[name], err = strconv.ParseUint(v, 10, 64)
+		if err != nil || values[name] == 0 {
+			return time.Time{}, 0, fmt.Errorf("invalid %s %s in config spec of the beacon node", name, v)
+		}
+	}
+	epochDuration := time.Duration(values["SECONDS_PER_SLOT"]*values["SLOTS_PER_EPOCH"]) * time.Second
+	return time.Unix(genesisTime, 0), epochDuration, nil
+}
+
+// checkKeys checks that a validator client holds all the keys, or none of them.
+func checkKeys(ctx context.Context, c *validator.Client, pubKeys []string, held bool) error {
+	resp, err := c.GetLocalValidatorKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list keys")
+	}
+	keys := make(map[string]bool, len(resp.Data))
+	for _, k := range resp.Data {
+		keys[strings.ToLower(k.ValidatingPubkey)] = true
+	}
+	for _, pubKey := range pubKeys {
+		if keys[pubKey] != held {
+			if held {
+				return fmt.Errorf("key %s is not held", pubKey)
+			}
+			return fmt.Errorf("key %s is already held", pubKey)
+		}
+	}
+	return nil
+}
+
+// checkStatuses checks that the keymanager API returned one of the expected statuses for every key.
+func checkStatuses(pubKeys []string, statuses []*keymanager.KeyStatus, expected ...keymanager.KeyStatusType) error {
+	if len(statuses) != len(pubKeys) {
+		return fmt.Errorf("got %d statuses for %d keys", len(statuses), len(pubKeys))
+	}
+	for i, status := range statuses {
+		ok := false
+		for _, e := range expected {
+			ok = ok || status.Status == e
+		}
+		if !ok {
+			return fmt.Errorf("key %s has status %s: %s", pubKeys[i], status.Status, status.Message)
+		}
+	}
+	return nil
+}
+
+// importKeys imports the keys into a validator client, along with their slashing protection history.
+func importKeys(
+	ctx context.Context,
+	c *validator.Client,
+	keys []*migrationKey,
+	password, protection string,
+	expected ...keymanager.KeyStatusType,
+) error {
+	keystores := make([]string, len(keys))
+	passwords := make([]string, len(keys))
+	pubKeys := make([]string, len(keys))
+	for i, k := range keys {
+		keystores[i] = k.keystore
+		passwords[i] = password
+		pubKeys[i] = k.pubKey
+	}
+	resp, err := c.ImportKeystores(ctx, &rpc.ImportKeystoresRequest{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: protection,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not import keys")
+	}
+	return checkStatuses(pubKeys, resp.Data, expected...)
+}
+
+func parseProtection(protection string) (*format.EIPSlashingProtectionFormat, error) {
+	if protection == "" {
+		return nil, errors.New("no slashing protection history")
+	}
+	f := &format.EIPSlashingProtectionFormat{}
+	if err := json.Unmarshal([]byte(protection), f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// mergeProtection merges two EIP-3076 slashing protection histories, either of which can be empty.
+func mergeProtection(a, b string) (string, error) {
+	if a == "" || b == "" {
+		return a + b, nil
+	}
+	fa, err := parseProtection(a)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid slashing protection history")
+	}
+	fb, err := parseProtection(b)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid slashing protection history")
+	}
+	merged, err := slashingprotection.MergeStandardProtectionJSON(fa, fb)
+	if err != nil {
+		return "", errors.Wrap(err, "could not merge slashing protection histories")
+	}
+	enc, err := json.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(enc), nil
+}
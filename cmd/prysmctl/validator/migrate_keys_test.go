@@ -0,0 +1,281 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/rpc"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+	"github.com/urfave/cli/v2"
+)
+
+// fakeKeymanagerAPI serves the keystore endpoints of the keymanager API of a validator client.
+type fakeKeymanagerAPI struct {
+	sync.Mutex
+	keys       map[string]bool
+	protection map[string][]*format.SignedBlock
+	failImport bool
+}
+
+func newFakeKeymanagerAPI(t *testing.T, keys ...string) (*fakeKeymanagerAPI, *httptest.Server) {
+	api := &fakeKeymanagerAPI{keys: make(map[string]bool), protection: make(map[string][]*format.SignedBlock)}
+	for _, k := range keys {
+		api.keys[k] = true
+		api.protection[k] = []*format.SignedBlock{{Slot: "10", SigningRoot: fmt.Sprintf("%#x", [32]byte{1})}}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.Lock()
+		defer api.Unlock()
+		require.Equal(t, "/eth/v1/keystores", r.URL.Path)
+		var resp interface{}
+		switch r.Method {
+		case http.MethodGet:
+			list := &rpc.ListKeystoresResponse{}
+			for k := range api.keys {
+				list.Data = append(list.Data, &rpc.Keystore{ValidatingPubkey: k})
+			}
+			resp = list
+		case http.MethodDelete:
+			req := &rpc.DeleteKeystoresRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			deleted := &rpc.DeleteKeystoresResponse{}
+			protection := protectionJSON()
+			for _, k := range req.Pubkeys {
+				status := keymanager.StatusNotFound
+				if api.keys[k] {
+					status = keymanager.StatusDeleted
+					delete(api.keys, k)
+				}
+				deleted.Data = append(deleted.Data, &keymanager.KeyStatus{Status: status})
+				if blocks, ok := api.protection[k]; ok {
+					protection.Data = append(protection.Data, &format.ProtectionData{Pubkey: k, SignedBlocks: blocks})
+				}
+			}
+			enc, err := json.Marshal(protection)
+			require.NoError(t, err)
+			deleted.SlashingProtection = string(enc)
+			resp = deleted
+		case http.MethodPost:
+			req := &rpc.ImportKeystoresRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			imported := &rpc.ImportKeystoresResponse{}
+			protection := &format.EIPSlashingProtectionFormat{}
+			require.NoError(t, json.Unmarshal([]byte(req.SlashingProtection), protection))
+			for _, d := range protection.Data {
+				api.protection[d.Pubkey] = d.SignedBlocks
+			}
+			for _, ks := range req.Keystores {
+				keystore := &keymanager.Keystore{}
+				require.NoError(t, json.Unmarshal([]byte(ks), keystore))
+				k := "0x" + keystore.Pubkey
+				switch {
+				case api.failImport:
+					imported.Data = append(imported.Data, &keymanager.KeyStatus{Status: keymanager.StatusError, Message: "disk full"})
+				case api.keys[k]:
+					imported.Data = append(imported.Data, &keymanager.KeyStatus{Status: keymanager.StatusDuplicate})
+				default:
+					api.keys[k] = true
+					imported.Data = append(imported.Data, &keymanager.KeyStatus{Status: keymanager.StatusImported})
+				}
+			}
+			resp = imported
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	return api, srv
+}
+
+func protectionJSON() *format.EIPSlashingProtectionFormat {
+	f := &format.EIPSlashingProtectionFormat{}
+	f.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	f.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [32]byte{})
+	return f
+}
+
+// fakeBeaconAPI serves the genesis, spec, validators and liveness endpoints of a beacon node.
+type fakeBeaconAPI struct {
+	sync.Mutex
+	// indices are the validator indices of the known public keys.
+	indices map[string]string
+	// live are the validator indices which were live in an epoch.
+	live map[string]map[string]bool
+	// livenessEpochs are the epochs of the liveness requests.
+	livenessEpochs []string
+}
+
+func newFakeBeaconAPI(t *testing.T, genesis time.Time) (*fakeBeaconAPI, *httptest.Server) {
+	api := &fakeBeaconAPI{indices: make(map[string]string), live: make(map[string]map[string]bool)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.Lock()
+		defer api.Unlock()
+		var resp interface{}
+		switch {
+		case r.URL.Path == "/eth/v1/beacon/genesis":
+			resp = &structs.GetGenesisResponse{Data: &structs.Genesis{GenesisTime: strconv.FormatInt(genesis.Unix(), 10)}}
+		case r.URL.Path == "/eth/v1/config/spec":
+			resp = &structs.GetSpecResponse{Data: map[string]string{"SECONDS_PER_SLOT": "12", "SLOTS_PER_EPOCH": "32"}}
+		case r.URL.Path == "/eth/v1/beacon/states/head/validators":
+			req := &structs.GetValidatorsRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			validators := &structs.GetValidatorsResponse{}
+			for _, id := range req.Ids {
+				if idx, ok := api.indices[id]; ok {
+					validators.Data = append(validators.Data, &structs.ValidatorContainer{Index: idx, Validator: &structs.Validator{Pubkey: id}})
+				}
+			}
+			resp = validators
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/validator/liveness/"):
+			epoch := strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/liveness/")
+			api.livenessEpochs = append(api.livenessEpochs, epoch)
+			var indices []string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&indices))
+			liveness := &structs.GetLivenessResponse{}
+			for _, idx := range indices {
+				liveness.Data = append(liveness.Data, &structs.Liveness{Index: idx, IsLive: api.live[epoch][idx]})
+			}
+			resp = liveness
+		default:
+			t.Fatalf("unexpected request to %s", r.URL.Path)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	return api, srv
+}
+
+func TestMigrateKeys(t *testing.T) {
+	dir := t.TempDir()
+	passwordPath := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordPath, []byte("migration-password\n"), 0600))
+	keystoresDir := filepath.Join(dir, "keystores")
+	pubKeys := make([]string, 2)
+	for i := range pubKeys {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		pubKeys[i] = fmt.Sprintf("%#x", sk.PublicKey().Marshal())
+		require.NoError(t, writeShareKeystore(keystoresDir, fmt.Sprintf("keystore-%d.json", i), sk, "migration-password"))
+	}
+	// The chain is half way through epoch 10.
+	genesis := time.Now().Add(-(10*384 + 192) * time.Second).Truncate(time.Second)
+	beacon, beaconSrv := newFakeBeaconAPI(t, genesis)
+	defer beaconSrv.Close()
+	// Only the first key has been activated.
+	beacon.indices[pubKeys[0]] = "3"
+
+	var waitedUntil time.Time
+	defer func(w func(context.Context, time.Time) error) { waitUntil = w }(waitUntil)
+	waitUntil = func(_ context.Context, t time.Time) error {
+		waitedUntil = t
+		return nil
+	}
+
+	run := func(source, destination *httptest.Server) error {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		for name, value := range map[string]string{
+			SourceHostFlag.Name:                  source.URL,
+			SourceTokenFlag.Name:                 "source-token",
+			DestinationHostFlag.Name:             destination.URL,
+			DestinationTokenFlag.Name:            "destination-token",
+			MigrateKeystoresFlag.Name:            keystoresDir,
+			MigrateKeystorePasswordFileFlag.Name: passwordPath,
+			BeaconHostFlag.Name:                  beaconSrv.URL,
+		} {
+			set.String(name, value, "")
+			require.NoError(t, set.Set(name, value))
+		}
+		return migrateKeys(cli.NewContext(&app, set, nil))
+	}
+
+	t.Run("migrates keys and slashing protection", func(t *testing.T) {
+		source, sourceSrv := newFakeKeymanagerAPI(t, pubKeys...)
+		defer sourceSrv.Close()
+		destination, destinationSrv := newFakeKeymanagerAPI(t)
+		defer destinationSrv.Close()
+
+		require.NoError(t, run(sourceSrv, destinationSrv))
+		assert.Equal(t, 0, len(source.keys))
+		for _, k := range pubKeys {
+			assert.Equal(t, true, destination.keys[k])
+			require.Equal(t, 1, len(destination.protection[k]))
+			assert.Equal(t, "10", destination.protection[k][0].Slot)
+		}
+		// Epochs 11 and 12 are the full epochs after the deletion, in which the keys were not live.
+		assert.Equal(t, genesis.Add(13*384*time.Second), waitedUntil)
+		assert.DeepEqual(t, []string{"11", "12"}, beacon.livenessEpochs)
+	})
+
+	t.Run("rolls back when a key was live", func(t *testing.T) {
+		source, sourceSrv := newFakeKeymanagerAPI(t, pubKeys...)
+		defer sourceSrv.Close()
+		destination, destinationSrv := newFakeKeymanagerAPI(t)
+		defer destinationSrv.Close()
+		beacon.live["12"] = map[string]bool{"3": true}
+		defer delete(beacon.live, "12")
+
+		err := run(sourceSrv, destinationSrv)
+		require.ErrorContains(t, "was rolled back", err)
+		assert.ErrorContains(t, fmt.Sprintf("key %s of validator 3 was live in epoch 12", pubKeys[0]), err)
+		assert.Equal(t, 0, len(destination.keys))
+		for _, k := range pubKeys {
+			assert.Equal(t, true, source.keys[k])
+		}
+	})
+
+	t.Run("rolls back a failed import", func(t *testing.T) {
+		source, sourceSrv := newFakeKeymanagerAPI(t, pubKeys...)
+		defer sourceSrv.Close()
+		destination, destinationSrv := newFakeKeymanagerAPI(t)
+		defer destinationSrv.Close()
+		destination.failImport = true
+
+		err := run(sourceSrv, destinationSrv)
+		require.ErrorContains(t, "was rolled back", err)
+		assert.ErrorContains(t, "disk full", err)
+		assert.Equal(t, 0, len(destination.keys))
+		for _, k := range pubKeys {
+			assert.Equal(t, true, source.keys[k])
+			require.Equal(t, 1, len(source.protection[k]))
+		}
+	})
+
+	t.Run("does not delete keys already held by the destination", func(t *testing.T) {
+		source, sourceSrv := newFakeKeymanagerAPI(t, pubKeys...)
+		defer sourceSrv.Close()
+		_, destinationSrv := newFakeKeymanagerAPI(t, pubKeys[1])
+		defer destinationSrv.Close()
+
+		err := run(sourceSrv, destinationSrv)
+		require.ErrorContains(t, fmt.Sprintf("key %s is already held", pubKeys[1]), err)
+		assert.Equal(t, 2, len(source.keys))
+	})
+
+	t.Run("wrong keystore password", func(t *testing.T) {
+		_, sourceSrv := newFakeKeymanagerAPI(t, pubKeys...)
+		defer sourceSrv.Close()
+		_, destinationSrv := newFakeKeymanagerAPI(t)
+		defer destinationSrv.Close()
+		require.NoError(t, os.WriteFile(passwordPath, []byte("wrong"), 0600))
+		defer func() {
+			require.NoError(t, os.WriteFile(passwordPath, []byte("migration-password"), 0600))
+		}()
+
+		assert.ErrorContains(t, "could not decrypt keystore", run(sourceSrv, destinationSrv))
+	})
+}
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// thresholdConfigFileName is the name of the configuration written for every share holder.
+const thresholdConfigFileName = "threshold.json"
+
+// splitKeystores splits the keys of EIP-2335 keystores into shares, and writes for every share holder a directory
+// with the keystores of its shares and the threshold configuration of its validator client.
+func splitKeystores(cliCtx *cli.Context) error {
+	t := cliCtx.Uint64(SplitThresholdFlag.Name)
+	addresses := cliCtx.StringSlice(ShareHolderURLsFlag.Name)
+	shares := uint64(len(addresses))
+	// Any two groups of a threshold of share holders must have a share holder in common, whose slashing protection
+	// refuses to sign conflicting messages.
+	if shares < 2 || t <= shares/2 || t > shares {
+		return fmt.Errorf("threshold must be a majority of the %d share holders, got %d", shares, t)
+	}
+	listenAddresses := make([]string, shares)
+	for i, address := range addresses {
+		u, err := url.ParseRequestURI(address)
+		if err != nil || u.Scheme == "" || u.Port() == "" {
+			return fmt.Errorf("share holder url %s must be in the format http(s)://host:port", address)
+		}
+		listenAddresses[i] = net.JoinHostPort("0.0.0.0", u.Port())
+	}
+	password, err := file.ReadFileAsBytes(cliCtx.String(SplitKeystorePasswordFileFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not read keystore password file")
+	}
+	sharePassword := password
+	if cliCtx.IsSet(SharePasswordFileFlag.Name) {
+		sharePassword, err = file.ReadFileAsBytes(cliCtx.String(SharePasswordFileFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not read share password file")
+		}
+	}
+	secretKeys, err := readSecretKeys(cliCtx.String(SplitKeystoresFlag.Name), strings.TrimSpace(string(password)))
+	if err != nil {
+		return err
+	}
+
+	secret := make([]byte, threshold.TransportSecretLength)
+	if _, err := rand.NewGenerator().Read(secret); err != nil {
+		return err
+	}
+	configs := make([]*threshold.ConfigFile, shares)
+	for i := range configs {
+		peers := make(map[uint64]string, shares-1)
+		for j, address := range addresses {
+			if j != i {
+				peers[uint64(j+1)] = strings.TrimSuffix(address, "/")
+			}
+		}
+		configs[i] = &threshold.ConfigFile{
+			ShareID:         uint64(i + 1),
+			Threshold:       t,
+			ListenAddress:   listenAddresses[i],
+			Peers:           peers,
+			TransportSecret: hexutil.Encode(secret),
+			Validators:      make([]*threshold.ValidatorConfig, 0, len(secretKeys)),
+		}
+	}
+
+	outputDir := cliCtx.String(SplitOutputDirFlag.Name)
+	for _, sk := range secretKeys {
+		pubKey := sk.PublicKey().Marshal()
+		shareKeys, err := bls.SplitSecretKey(sk, t, shares)
+		if err != nil {
+			return errors.Wrapf(err, "could not split key %#x", pubKey)
+		}
+		sharePubKeys := make([]string, shares)
+		for i, shareKey := range shareKeys {
+			sharePubKeys[i] = hexutil.Encode(shareKey.PublicKey().Marshal())
+		}
+		for i, shareKey := range shareKeys {
+			name := fmt.Sprintf("keystore-%x-share-%d.json", pubKey[:8], i+1)
+			if err := writeShareKeystore(shareDir(outputDir, i+1), name, shareKey, strings.TrimSpace(string(sharePassword))); err != nil {
+				return err
+			}
+			configs[i].Validators = append(configs[i].Validators, &threshold.ValidatorConfig{
+				PublicKey:       hexutil.Encode(pubKey),
+				SharePublicKeys: sharePubKeys,
+				Keystore:        name,
+			})
+		}
+	}
+	for i, cfg := range configs {
+		b, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := file.MkdirAll(shareDir(outputDir, i+1)); err != nil {
+			return errors.Wrap(err, "could not create share directory")
+		}
+		if err := file.WriteFile(filepath.Join(shareDir(outputDir, i+1), thresholdConfigFileName), b); err != nil {
+			return errors.Wrap(err, "could not write threshold configuration")
+		}
+	}
+	log.WithFields(log.Fields{
+		"keys":      len(secretKeys),
+		"shares":    shares,
+		"threshold": t,
+		"outputDir": outputDir,
+	}).Info("Split validator keys, copy every share directory to its share holder and delete the original keystores")
+	return nil
+}
+
+func shareDir(outputDir string, shareID int) string {
+	return filepath.Join(outputDir, fmt.Sprintf("share-%d", shareID))
+}
+
+// keystorePaths returns the path of a keystore, or the paths of the keystores of a directory.
+func keystorePaths(path string) ([]string, error) {
+	isDir, err := file.HasDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine if path is a directory")
+	}
+	if !isDir {
+		return []string{path}, nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read keystores directory")
+	}
+	var paths []string
+	for _, entry := range entries {
+		// Deposit data files sit next to the keystores generated by the deposit CLI.
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), "keystore") && strings.HasSuffix(entry.Name(), ".json") {
+			paths = append(paths, filepath.Join(path, entry.Name()))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no keystore found in %s", path)
+	}
+	return paths, nil
+}
+
+// readSecretKeys decrypts a keystore, or the keystores of a directory.
+func readSecretKeys(path, password string) ([]bls.SecretKey, error) {
+	paths, err := keystorePaths(path)
+	if err != nil {
+		return nil, err
+	}
+	secretKeys := make([]bls.SecretKey, len(paths))
+	for i, p := range paths {
+		b, err := file.ReadFileAsBytes(p)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read keystore")
+		}
+		keystore := &keymanager.Keystore{}
+		if err := json.Unmarshal(b, keystore); err != nil {
+			return nil, errors.Wrapf(err, "could not decode keystore %s", p)
+		}
+		secretKey, err := keystorev4.New().Decrypt(keystore.Crypto, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt keystore %s", p)
+		}
+		secretKeys[i], err = bls.SecretKeyFromBytes(secretKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid secret key in keystore %s", p)
+		}
+	}
+	return secretKeys, nil
+}
+
+func writeShareKeystore(dir, name string, sk bls.SecretKey, password string) error {
+	encryptor := keystorev4.New()
+	cryptoFields, err := encryptor.Encrypt(sk.Marshal(), password)
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt share")
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(&keymanager.Keystore{
+		Crypto:      cryptoFields,
+		ID:          id.String(),
+		Pubkey:      fmt.Sprintf("%x", sk.PublicKey().Marshal()),
+		Version:     encryptor.Version(),
+		Description: encryptor.Name(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := file.MkdirAll(dir); err != nil {
+		return errors.Wrap(err, "could not create share directory")
+	}
+	if err := file.WriteFile(filepath.Join(dir, name), b); err != nil {
+		return errors.Wrap(err, "could not write share keystore")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
+	"github.com/urfave/cli/v2"
+)
+
+func splitKeystoreContext(t *testing.T, keystores, passwordFile, outputDir string, threshold uint64, urls ...string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(SplitKeystoresFlag.Name, keystores, "")
+	set.String(SplitKeystorePasswordFileFlag.Name, passwordFile, "")
+	set.Uint64(SplitThresholdFlag.Name, threshold, "")
+	set.String(SplitOutputDirFlag.Name, outputDir, "")
+	require.NoError(t, ShareHolderURLsFlag.Apply(set))
+	for _, u := range urls {
+		require.NoError(t, set.Set(ShareHolderURLsFlag.Name, u))
+	}
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestSplitKeystores(t *testing.T) {
+	dir := t.TempDir()
+	keysDir := filepath.Join(dir, "keys")
+	require.NoError(t, os.MkdirAll(keysDir, 0700))
+	passwordFile := filepath.Join(dir, "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	require.NoError(t, writeShareKeystore(keysDir, "keystore-0.json", sk, "password"))
+	require.NoError(t, os.WriteFile(filepath.Join(keysDir, "deposit_data.json"), []byte("[]"), 0600))
+	outputDir := filepath.Join(dir, "shares")
+	urls := []string{"http://node-1:7600", "http://node-2:7600", "http://node-3:7601"}
+
+	err = splitKeystores(splitKeystoreContext(t, keysDir, passwordFile, outputDir, 1, urls...))
+	require.ErrorContains(t, "threshold must be a majority of the 3 share holders", err)
+	err = splitKeystores(splitKeystoreContext(t, keysDir, passwordFile, outputDir, 2, "node-1:7600", "node-2:7600"))
+	require.ErrorContains(t, "must be in the format http(s)://host:port", err)
+
+	require.NoError(t, splitKeystores(splitKeystoreContext(t, keysDir, passwordFile, outputDir, 2, urls...)))
+	msg := []byte("hello")
+	partials := make([]bls.Signature, 0, len(urls))
+	ids := make([]uint64, 0, len(urls))
+	for i := range urls {
+		cfg, err := threshold.LoadConfig(filepath.Join(shareDir(outputDir, i+1), thresholdConfigFileName), "password")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), cfg.ShareID)
+		assert.Equal(t, uint64(2), cfg.Threshold)
+		assert.Equal(t, 2, len(cfg.Peers))
+		require.Equal(t, 1, len(cfg.Shares))
+		assert.DeepEqual(t, sk.PublicKey().Marshal(), cfg.Shares[0].PublicKey[:])
+		assert.Equal(t, true, cfg.Shares[0].SharePublicKeys[i].Equals(cfg.Shares[0].SecretKey.PublicKey()))
+		partials = append(partials, cfg.Shares[0].SecretKey.Sign(msg))
+		ids = append(ids, cfg.ShareID)
+	}
+	cfg, err := threshold.LoadConfig(filepath.Join(outputDir, "share-3", thresholdConfigFileName), "password")
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:7601", cfg.ListenAddress)
+	assert.Equal(t, "http://node-1:7600", cfg.Peers[1])
+
+	sig, err := bls.RecoverSignature(partials[1:], ids[1:])
+	require.NoError(t, err)
+	assert.DeepEqual(t, sk.Sign(msg).Marshal(), sig.Marshal())
+
+	_, err = threshold.LoadConfig(filepath.Join(outputDir, "share-1", thresholdConfigFileName), "wrong")
+	require.ErrorContains(t, "wrong password for keystore", err)
+}
+
@@ -166,6 +166,7 @@ var Commands = &cli.Command{
 				flags.ExitAllFlag,
 				flags.ForceExitFlag,
 				flags.VoluntaryExitJSONOutputPathFlag,
+				flags.SigningAuditLogFlag,
 				features.Mainnet,
 				features.SepoliaTestnet,
 				features.HoleskyTestnet,
@@ -12,6 +12,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
@@ -85,6 +86,18 @@ func Exit(c *cli.Context, r io.Reader) error {
 		accounts.WithGRPCHeaders(grpcHeaders),
 		accounts.WithExitJSONOutputPath(c.String(flags.VoluntaryExitJSONOutputPathFlag.Name)),
 	}
+	if c.IsSet(flags.SigningAuditLogFlag.Name) {
+		signingAuditLog, err := audit.Open(c.String(flags.SigningAuditLogFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not open signing audit log")
+		}
+		defer func() {
+			if err := signingAuditLog.Close(); err != nil {
+				log.WithError(err).Error("Could not close signing audit log")
+			}
+		}()
+		opts = append(opts, accounts.WithSigningAuditLog(signingAuditLog))
+	}
 	// Get full set of public keys from the keymanager.
 	validatingPublicKeys, err := km.FetchValidatingPublicKeys(c.Context)
 	if err != nil {
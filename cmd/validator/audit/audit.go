@@ -0,0 +1,35 @@
+package auditcmd
+
+import (
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/urfave/cli/v2"
+)
+
+// Commands for inspecting the signing audit log of the validator client.
+var Commands = &cli.Command{
+	Name:     "audit",
+	Category: "audit",
+	Usage:    "Defines commands for inspecting the signing audit log of the validator client.",
+	Subcommands: []*cli.Command{
+		{
+			Name: "verify",
+			Usage: "Verifies the hash chain of a signing audit log, and prints its records as JSON lines, optionally " +
+				"only the records of some public keys or of an epoch.",
+			Flags: cmd.WrapFlags([]cli.Flag{
+				flags.SigningAuditLogFlag,
+				flags.SigningAuditPublicKeysFlag,
+				flags.SigningAuditEpochFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := verifySigningAuditLog(cliCtx); err != nil {
+					log.WithError(err).Fatal("Could not verify signing audit log")
+				}
+				return nil
+			},
+		},
+	},
+}
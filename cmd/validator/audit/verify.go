@@ -0,0 +1,69 @@
+package auditcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
+	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// verifySigningAuditLog verifies the hash chain of the signing audit log, and writes the records matching the
+// public key and epoch filters to the output of the application.
+func verifySigningAuditLog(cliCtx *cli.Context) error {
+	path := cliCtx.String(flags.SigningAuditLogFlag.Name)
+	if path == "" {
+		return fmt.Errorf("--%s not specified", flags.SigningAuditLogFlag.Name)
+	}
+	filter := &audit.Filter{}
+	if keys := cliCtx.String(flags.SigningAuditPublicKeysFlag.Name); keys != "" {
+		for _, key := range strings.Split(keys, ",") {
+			pubKey, err := helpers.PubKeyFromHex(strings.TrimSpace(key))
+			if err != nil {
+				return errors.Wrapf(err, "invalid public key %s", key)
+			}
+			filter.PubKeys = append(filter.PubKeys, fmt.Sprintf("%#x", pubKey))
+		}
+	}
+	if cliCtx.IsSet(flags.SigningAuditEpochFlag.Name) {
+		epoch := primitives.Epoch(cliCtx.Uint64(flags.SigningAuditEpochFlag.Name))
+		filter.Epoch = &epoch
+	}
+
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return errors.Wrap(err, "could not open signing audit log")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close signing audit log")
+		}
+	}()
+	records, err := audit.Verify(f, filter)
+	if err != nil {
+		return errors.Wrapf(err, "signing audit log %s is corrupted", path)
+	}
+
+	outcomes := make(map[audit.Outcome]int)
+	enc := json.NewEncoder(cliCtx.App.Writer)
+	for _, rec := range records {
+		outcomes[rec.Outcome]++
+		if err := enc.Encode(rec); err != nil {
+			return errors.Wrap(err, "could not write signing audit record")
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"records": len(records),
+		"signed":  outcomes[audit.Signed],
+		"failed":  outcomes[audit.Failed],
+		"refused": outcomes[audit.Refused],
+	}).Info("Verified the hash chain of the signing audit log")
+	return nil
+}
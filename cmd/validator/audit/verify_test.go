@@ -0,0 +1,92 @@
+package auditcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
+	"github.com/urfave/cli/v2"
+)
+
+func TestVerifySigningAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signing.log")
+	l, err := audit.Open(path)
+	require.NoError(t, err)
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}}
+	for _, pubKey := range pubKeys {
+		for epoch := 1; epoch <= 2; epoch++ {
+			require.NoError(t, l.Append(audit.RecordFromRequest(&validatorpb.SignRequest{
+				PublicKey: pubKey[:],
+				Object: &validatorpb.SignRequest_AttestationData{AttestationData: &ethpb.AttestationData{
+					Source: &ethpb.Checkpoint{Epoch: 0},
+					Target: &ethpb.Checkpoint{Epoch: 2},
+				}},
+				SigningSlot: 32 * 2,
+			}, audit.Signed, nil)))
+			require.NoError(t, l.Append(audit.RecordFromRequest(&validatorpb.SignRequest{
+				PublicKey:   pubKey[:],
+				Object:      &validatorpb.SignRequest_Epoch{Epoch: 3},
+				SigningSlot: 32 * 3,
+			}, audit.Signed, nil)))
+		}
+	}
+	require.NoError(t, l.Close())
+
+	verify := func(pubKeys string, epoch string) ([]*audit.Record, error) {
+		out := &bytes.Buffer{}
+		app := cli.App{Writer: out}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.SigningAuditLogFlag.Name, path, "")
+		set.String(flags.SigningAuditPublicKeysFlag.Name, pubKeys, "")
+		set.Uint64(flags.SigningAuditEpochFlag.Name, 0, "")
+		if epoch != "" {
+			require.NoError(t, set.Set(flags.SigningAuditEpochFlag.Name, epoch))
+		}
+		if err := verifySigningAuditLog(cli.NewContext(&app, set, nil)); err != nil {
+			return nil, err
+		}
+		var records []*audit.Record
+		dec := json.NewDecoder(out)
+		for dec.More() {
+			rec := &audit.Record{}
+			require.NoError(t, dec.Decode(rec))
+			records = append(records, rec)
+		}
+		return records, nil
+	}
+
+	records, err := verify("", "")
+	require.NoError(t, err)
+	assert.Equal(t, 8, len(records))
+	records, err = verify(fmt.Sprintf("%#x", pubKeys[1]), "")
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(records))
+	records, err = verify(fmt.Sprintf("%#x,%#x", pubKeys[0], pubKeys[1]), "3")
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(records))
+	records, err = verify(fmt.Sprintf("%#x", pubKeys[0]), "2")
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, "AttestationData", records[0].Object)
+	_, err = verify("0x1234", "")
+	assert.ErrorContains(t, "invalid public key", err)
+
+	content, err := os.ReadFile(path) // #nosec G304
+	require.NoError(t, err)
+	lines := strings.Split(string(content), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(append(lines[:3], lines[4:]...), "\n")), 0600))
+	_, err = verify("", "")
+	assert.ErrorContains(t, "is corrupted", err)
+}
@@ -83,8 +83,9 @@ var (
 	}
 	// GraffitiFlag defines the graffiti value included in proposed blocks
 	GraffitiFlag = &cli.StringFlag{
-		Name:  "graffiti",
-		Usage: "String to include in proposed blocks.",
+		Name: "graffiti",
+		Usage: "String to include in proposed blocks. Supports templates evaluated at proposal time, " +
+			"e.g. {{.ELClient}}{{.CLClient}} {{.ValidatorIndex}} {{.Epoch}} {{.Pubkey | short}}.",
 	}
 	// GRPCRetriesFlag defines the number of times to retry a failed gRPC request.
 	GRPCRetriesFlag = &cli.UintFlag{
@@ -255,6 +256,11 @@ var (
 		Name:  "slashing-protection-json-file",
 		Usage: "Path to an EIP-3076 compliant JSON file containing a user's slashing protection history.",
 	}
+	// SlashingProtectionJSONFilesFlag is used to enter the file paths of the slashing protection JSONs to merge.
+	SlashingProtectionJSONFilesFlag = &cli.StringSliceFlag{
+		Name:  "slashing-protection-json-files",
+		Usage: "Paths to EIP-3076 compliant JSON files containing slashing protection histories to merge into one.",
+	}
 	// KeysDirFlag defines the path for a directory where keystores to be imported at stored.
 	KeysDirFlag = &cli.StringFlag{
 		Name:  "keys-dir",
@@ -309,6 +315,44 @@ var (
 		Aliases: []string{"remote-signer-keys-file"},
 	}
 
+	// PKCS11ModuleFlag defines the PKCS#11 module used to sign with keys stored on a token, such as an HSM.
+	PKCS11ModuleFlag = &cli.StringFlag{
+		Name:  "pkcs11-module",
+		Usage: "Path to the PKCS#11 library of a token, such as an HSM, which holds the validator keys and signs with them. Replaces the wallet.",
+	}
+	// PKCS11TokenLabelFlag defines the label of the token holding the validator keys.
+	PKCS11TokenLabelFlag = &cli.StringFlag{
+		Name:  "pkcs11-token-label",
+		Usage: "Label of the PKCS#11 token which holds the validator keys.",
+	}
+	// PKCS11PinFileFlag defines a file containing the user PIN of the token.
+	PKCS11PinFileFlag = &cli.StringFlag{
+		Name:  "pkcs11-pin-file",
+		Usage: "Path to a file containing the user PIN of the PKCS#11 token.",
+	}
+	// PKCS11KeyTypeFlag defines the vendor defined key type of BLS12-381 keys on the token.
+	PKCS11KeyTypeFlag = &cli.Uint64Flag{
+		Name:  "pkcs11-key-type",
+		Usage: "Vendor defined CKA_KEY_TYPE of the BLS12-381 keys of the PKCS#11 token, as given by the token vendor.",
+	}
+	// PKCS11MechanismFlag defines the vendor defined mechanism which signs with BLS12-381 keys on the token.
+	PKCS11MechanismFlag = &cli.Uint64Flag{
+		Name:  "pkcs11-mechanism",
+		Usage: "Vendor defined mechanism which signs with the BLS12-381 keys of the PKCS#11 token, as given by the token vendor.",
+	}
+
+	// ThresholdConfigFlag defines the configuration of the key shares of a distributed validator.
+	ThresholdConfigFlag = &cli.StringFlag{
+		Name: "threshold-config",
+		Usage: "Path to the threshold configuration written by `prysmctl validator split-keystore`, to sign with a share " +
+			"of split validator keys and the partial signatures of the other share holders. Replaces the wallet.",
+	}
+	// ThresholdPasswordFileFlag defines a file containing the password of the key share keystores.
+	ThresholdPasswordFileFlag = &cli.StringFlag{
+		Name:  "threshold-password-file",
+		Usage: "Path to a file containing the password of the key share keystores of the threshold configuration.",
+	}
+
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
 		Name:  "keymanager-kind",
@@ -389,6 +433,45 @@ var (
 		Usage: "To enable the use of prysm validator client in Distributed Validator Cluster",
 		Value: false,
 	}
+	// MultiBeaconNodeFlag enables the use of every configured beacon node at once.
+	MultiBeaconNodeFlag = &cli.BoolFlag{
+		Name: "multi-beacon-node",
+		Usage: "Keeps connections open to every beacon node given as a comma separated list to --beacon-rpc-provider, " +
+			"or to --beacon-rest-api-provider with the REST API. With gRPC, --beacon-rest-api-provider must list the REST " +
+			"API of each beacon node in the same order. Requests go to the healthiest node, attestation data " +
+			"must be agreed on by a quorum of nodes and signed messages are broadcast to every node.",
+	}
+	// BeaconNodeQuorumFlag sets the number of beacon nodes which must agree on the attestation data in multi beacon node mode.
+	BeaconNodeQuorumFlag = &cli.IntFlag{
+		Name:        "beacon-node-quorum",
+		Usage:       "Number of beacon nodes which must agree on the head and target of attestation data when --multi-beacon-node is set.",
+		DefaultText: "majority of beacon nodes",
+	}
+	// SlashingProtectionDBURLFlag defines the URL of a SQL slashing protection database shared by several validator clients.
+	SlashingProtectionDBURLFlag = &cli.StringFlag{
+		Name: "slashing-protection-db-url",
+		Usage: "URL of a SQL slashing protection database which can be shared by several validator clients, such as " +
+			"sqlite:///mnt/shared/slashing-protection.sqlite. Every slashing protection check and save is atomic across " +
+			"the validator clients using the database. Replaces the database of the data directory.",
+	}
+	// SigningAuditLogFlag defines the path of the hash chained log of every signing request of the validator client.
+	SigningAuditLogFlag = &cli.StringFlag{
+		Name: "signing-audit-log",
+		Usage: "Path of a tamper-evident log of every signing request of the validator client, including the requests " +
+			"refused by slashing protection. A signature is not used if it cannot be recorded in the log.",
+	}
+	// SigningAuditPublicKeysFlag defines a comma-separated list of hex string public keys whose signing audit
+	// records are printed.
+	SigningAuditPublicKeysFlag = &cli.StringFlag{
+		Name:  "audit-public-keys",
+		Usage: "Comma separated list of public key hex strings of the validators whose signing audit records are printed.",
+		Value: "",
+	}
+	// SigningAuditEpochFlag defines the epoch whose signing audit records are printed.
+	SigningAuditEpochFlag = &cli.Uint64Flag{
+		Name:  "audit-epoch",
+		Usage: "Epoch of the signing audit records to print, which is the target epoch of attestations.",
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	accountcommands "github.com/prysmaticlabs/prysm/v5/cmd/validator/accounts"
+	auditcommands "github.com/prysmaticlabs/prysm/v5/cmd/validator/audit"
 	dbcommands "github.com/prysmaticlabs/prysm/v5/cmd/validator/db"
 	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
 	slashingprotectioncommands "github.com/prysmaticlabs/prysm/v5/cmd/validator/slashing-protection"
@@ -74,11 +75,24 @@ var appFlags = []cli.Flag{
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
 	flags.EnableDistributed,
+	flags.MultiBeaconNodeFlag,
+	flags.BeaconNodeQuorumFlag,
+	flags.SlashingProtectionDBURLFlag,
+	flags.SigningAuditLogFlag,
 	flags.AuthTokenPathFlag,
 	// Consensys' Web3Signer flags
 	flags.Web3SignerURLFlag,
 	flags.Web3SignerPublicValidatorKeysFlag,
 	flags.Web3SignerKeyFileFlag,
+	// PKCS#11 token flags
+	flags.PKCS11ModuleFlag,
+	flags.PKCS11TokenLabelFlag,
+	flags.PKCS11PinFileFlag,
+	flags.PKCS11KeyTypeFlag,
+	flags.PKCS11MechanismFlag,
+	// Threshold signing flags
+	flags.ThresholdConfigFlag,
+	flags.ThresholdPasswordFileFlag,
 	flags.SuggestedFeeRecipientFlag,
 	flags.ProposerSettingsURLFlag,
 	flags.ProposerSettingsFlag,
@@ -137,6 +151,7 @@ func main() {
 			walletcommands.Commands,
 			accountcommands.Commands,
 			slashingprotectioncommands.Commands,
+			auditcommands.Commands,
 			dbcommands.Commands,
 			web.Commands,
 		},
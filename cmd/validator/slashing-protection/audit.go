@@ -0,0 +1,44 @@
+package historycmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Audits the history of the validator database for records which are slashable with respect to each other,
+// and for gaps in the attestation history of the validators.
+func auditSlashingProtectionDB(cliCtx *cli.Context) error {
+	validatorDB, err := openValidatorDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeValidatorDB(validatorDB)
+	return auditSlashingProtectionDBFrom(cliCtx, validatorDB)
+}
+
+// auditSlashingProtectionDBFrom audits the history of an open validator database.
+func auditSlashingProtectionDBFrom(cliCtx *cli.Context, validatorDB iface.ValidatorDB) error {
+	local, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	if err != nil {
+		return errors.Wrap(err, "could not export slashing protection history")
+	}
+	issues, err := slashingprotection.AuditStandardProtectionJSON(local)
+	if err != nil {
+		return errors.Wrap(err, "could not audit slashing protection history")
+	}
+	slashable := logIssues(issues)
+	log.WithFields(logrus.Fields{
+		"validators": len(local.Data),
+		"slashable":  slashable,
+		"gaps":       len(issues) - slashable,
+	}).Info("Audited the validator database")
+	if slashable > 0 {
+		return fmt.Errorf("the validator database holds %d slashable records", slashable)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package historycmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/sqldb"
+	"github.com/urfave/cli/v2"
+)
+
+// openValidatorDB opens the existing validator database given by the user: the shared SQL database if its URL
+// is set, otherwise the minimal or complete database found in the data directory. The caller closes it.
+func openValidatorDB(cliCtx *cli.Context) (iface.ValidatorDB, error) {
+	// Use the shared SQL database if requested.
+	if cliCtx.IsSet(flags.SlashingProtectionDBURLFlag.Name) {
+		dbURL := cliCtx.String(flags.SlashingProtectionDBURLFlag.Name)
+		validatorDB, err := sqldb.NewStore(cliCtx.Context, dbURL, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not access validator database %s", dbURL)
+		}
+		return validatorDB, nil
+	}
+
+	// Check if a minimal database is requested
+	isDatabaseMinimal := cliCtx.Bool(features.EnableMinimalSlashingProtection.Name)
+
+	// Read the data directory from the CLI context.
+	var err error
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	if !cliCtx.IsSet(cmd.DataDirFlag.Name) {
+		dataDir, err = userprompt.InputDirectory(cliCtx, userprompt.DataDirDirPromptText, cmd.DataDirFlag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read directory value from input")
+		}
+	}
+
+	// Ensure that the database is found under the specified dir or its subdirectories
+	var found bool
+	if isDatabaseMinimal {
+		found, _, err = file.RecursiveDirFind(filesystem.DatabaseDirName, dataDir)
+	} else {
+		found, _, err = file.RecursiveFileFind(kv.ProtectionDbFileName, dataDir)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding validator database at path %s", dataDir)
+	}
+
+	if !found {
+		databaseFileDir := kv.ProtectionDbFileName
+		if isDatabaseMinimal {
+			databaseFileDir = filesystem.DatabaseDirName
+		}
+		return nil, fmt.Errorf("%s (validator database) was not found at path %s", databaseFileDir, dataDir)
+	}
+
+	// Open the validator database.
+	var validatorDB iface.ValidatorDB
+	if isDatabaseMinimal {
+		validatorDB, err = filesystem.NewStore(dataDir, nil)
+	} else {
+		validatorDB, err = kv.NewKVStore(cliCtx.Context, dataDir, nil)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not access validator database at path %s", dataDir)
+	}
+	return validatorDB, nil
+}
+
+// closeValidatorDB closes a database opened with openValidatorDB, logging any error.
+func closeValidatorDB(validatorDB iface.ValidatorDB) {
+	if err := validatorDB.Close(); err != nil {
+		log.WithError(err).Errorf("Could not close validator DB")
+	}
+}
@@ -0,0 +1,63 @@
+package historycmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Compares an EIP-3076 slashing protection file to the history of the validator database, before importing it.
+//
+// Steps:
+// 1. Open the validator database and export its history.
+// 2. Read the JSON file from user input.
+// 3. Report the records of the file which are slashable with respect to the database, and the records of the
+// file an import would add to the database.
+func diffSlashingProtectionJSON(cliCtx *cli.Context) error {
+	validatorDB, err := openValidatorDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeValidatorDB(validatorDB)
+	return diffSlashingProtectionJSONWith(cliCtx, validatorDB)
+}
+
+// diffSlashingProtectionJSONWith compares the slashing protection JSON file given by the user to the history of
+// an open validator database.
+func diffSlashingProtectionJSONWith(cliCtx *cli.Context, validatorDB iface.ValidatorDB) error {
+	protectionFilePath, err := userprompt.InputDirectory(cliCtx, userprompt.SlashingProtectionJSONPromptText, flags.SlashingProtectionJSONFileFlag)
+	if err != nil {
+		return errors.Wrap(err, "could not get slashing protection json file")
+	}
+	if protectionFilePath == "" {
+		return fmt.Errorf("no slashing protection file specified, you can specify it with the %s flag", flags.SlashingProtectionJSONFileFlag.Name)
+	}
+	protectionFile, err := readProtectionJSON(protectionFilePath)
+	if err != nil {
+		return err
+	}
+	local, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	if err != nil {
+		return errors.Wrap(err, "could not export slashing protection history")
+	}
+
+	issues, err := slashingprotection.DiffStandardProtectionJSON(local, protectionFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not compare %s to the validator database", protectionFilePath)
+	}
+	slashable := logIssues(issues)
+	log.WithFields(logrus.Fields{
+		"conflicts": slashable,
+		"new":       len(issues) - slashable,
+	}).Infof("Compared %s to the validator database", protectionFilePath)
+	if slashable > 0 {
+		return fmt.Errorf("%d records of %s are slashable with respect to the validator database", slashable, protectionFilePath)
+	}
+	return nil
+}
@@ -2,18 +2,13 @@ package historycmd
 
 import (
 	"encoding/json"
-	"fmt"
 	"path/filepath"
 
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
-	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/userprompt"
-	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
-	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
 	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
 	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
 	"github.com/urfave/cli/v2"
@@ -34,66 +29,25 @@ const (
 // the validator's db into an EIP standard slashing protection format
 // 4. Format and save the JSON file to a user's specified output directory.
 func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
-	var (
-		validatorDB iface.ValidatorDB
-		found       bool
-		err         error
-	)
-
 	log.Info(
 		"This command exports your validator's attestation and proposal history into " +
 			"a file that can then be imported into any other Prysm setup across computers",
 	)
 
-	// Check if a minimal database is requested
-	isDatabaseMinimal := cliCtx.Bool(features.EnableMinimalSlashingProtection.Name)
-
-	// Read the data directory from the CLI context.
-	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
-	if !cliCtx.IsSet(cmd.DataDirFlag.Name) {
-		dataDir, err = userprompt.InputDirectory(cliCtx, userprompt.DataDirDirPromptText, cmd.DataDirFlag)
-		if err != nil {
-			return errors.Wrapf(err, "could not read directory value from input")
-		}
-	}
-
-	// Ensure that the database is found under the specified dir or its subdirectories
-	if isDatabaseMinimal {
-		found, _, err = file.RecursiveDirFind(filesystem.DatabaseDirName, dataDir)
-	} else {
-		found, _, err = file.RecursiveFileFind(kv.ProtectionDbFileName, dataDir)
-	}
-
+	validatorDB, err := openValidatorDB(cliCtx)
 	if err != nil {
-		return errors.Wrapf(err, "error finding validator database at path %s", dataDir)
-	}
-
-	if !found {
-		databaseFileDir := kv.ProtectionDbFileName
-		if isDatabaseMinimal {
-			databaseFileDir = filesystem.DatabaseDirName
-		}
-		return fmt.Errorf("%s (validator database) was not found at path %s, so nothing to export", databaseFileDir, dataDir)
-	}
-
-	// Open the validator database.
-	if isDatabaseMinimal {
-		validatorDB, err = filesystem.NewStore(dataDir, nil)
-	} else {
-		validatorDB, err = kv.NewKVStore(cliCtx.Context, dataDir, nil)
-	}
-
-	if err != nil {
-		return errors.Wrapf(err, "could not access validator database at path %s", dataDir)
+		return err
 	}
 
 	// Close the database when we're done.
-	defer func() {
-		if err := validatorDB.Close(); err != nil {
-			log.WithError(err).Errorf("Could not close validator DB")
-		}
-	}()
+	defer closeValidatorDB(validatorDB)
+
+	return exportSlashingProtectionJSONFrom(cliCtx, validatorDB)
+}
 
+// exportSlashingProtectionJSONFrom exports the slashing protection history of an open validator database
+// to the output directory given by the user.
+func exportSlashingProtectionJSONFrom(cliCtx *cli.Context, validatorDB iface.ValidatorDB) error {
 	// Export the slashing protection history from the validator's database.
 	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
 	if err != nil {
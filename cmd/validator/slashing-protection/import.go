@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/sqldb"
 	"github.com/urfave/cli/v2"
 )
 
@@ -32,6 +33,21 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 		err   error
 	)
 
+	// Use the shared SQL database if requested.
+	if cliCtx.IsSet(flags.SlashingProtectionDBURLFlag.Name) {
+		dbURL := cliCtx.String(flags.SlashingProtectionDBURLFlag.Name)
+		valDB, err = sqldb.NewStore(cliCtx.Context, dbURL, nil)
+		if err != nil {
+			return errors.Wrapf(err, "could not access validator database %s", dbURL)
+		}
+		defer func() {
+			if err := valDB.Close(); err != nil {
+				log.WithError(err).Errorf("Could not close validator DB")
+			}
+		}()
+		return importSlashingProtectionJSONInto(cliCtx, valDB, dbURL)
+	}
+
 	// Check if a minimal database is requested
 	isDatabaseMimimal := cliCtx.Bool(features.EnableMinimalSlashingProtection.Name)
 
@@ -80,6 +96,12 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 		}
 	}()
 
+	return importSlashingProtectionJSONInto(cliCtx, valDB, dataDir)
+}
+
+// importSlashingProtectionJSONInto imports the slashing protection JSON file given by the user into an open
+// validator database, located at location.
+func importSlashingProtectionJSONInto(cliCtx *cli.Context, valDB iface.ValidatorDB, location string) error {
 	// Get the path to the slashing protection JSON file from the CLI context.
 	protectionFilePath, err := userprompt.InputDirectory(cliCtx, userprompt.SlashingProtectionJSONPromptText, flags.SlashingProtectionJSONFileFlag)
 	if err != nil {
@@ -107,7 +129,7 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 		return errors.Wrapf(err, "could not import slashing protection JSON file %s", protectionFilePath)
 	}
 
-	log.Infof("Slashing protection JSON successfully imported into %s", dataDir)
+	log.Infof("Slashing protection JSON successfully imported into %s", location)
 
 	return nil
 }
@@ -0,0 +1,91 @@
+package historycmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Merges several EIP-3076 slashing protection files, for instance exported from the machines a set of keys was
+// moved between, into a single file to import into the validator database of the machine which will run them.
+//
+// Steps:
+// 1. Read the JSON files given by the user.
+// 2. Merge them conservatively, keeping every record of every file.
+// 3. Audit the merged history and report the slashable records, which an import would refuse.
+// 4. Save the merged JSON file to a user's specified output directory.
+func mergeSlashingProtectionJSON(cliCtx *cli.Context) error {
+	paths := cliCtx.StringSlice(flags.SlashingProtectionJSONFilesFlag.Name)
+	if len(paths) < 2 {
+		return fmt.Errorf("at least two files to merge must be given with the %s flag", flags.SlashingProtectionJSONFilesFlag.Name)
+	}
+	files := make([]*format.EIPSlashingProtectionFormat, len(paths))
+	for i, path := range paths {
+		f, err := readProtectionJSON(path)
+		if err != nil {
+			return err
+		}
+		files[i] = f
+	}
+
+	merged, err := slashingprotection.MergeStandardProtectionJSON(files...)
+	if err != nil {
+		return errors.Wrap(err, "could not merge slashing protection files")
+	}
+	log.WithField("files", len(paths)).Infof("Merged the slashing protection history of %d validators", len(merged.Data))
+
+	issues, err := slashingprotection.AuditStandardProtectionJSON(merged)
+	if err != nil {
+		return errors.Wrap(err, "could not audit merged slashing protection history")
+	}
+	if slashable := logIssues(issues); slashable > 0 {
+		log.Warnf(
+			"The merged files hold %d slashable records. Importing the merged file will disable signing "+
+				"for the validators with slashable records",
+			slashable,
+		)
+	}
+
+	if err := writeToOutput(cliCtx, merged); err != nil {
+		return errors.Wrap(err, "could not write merged slashing protection history to output file")
+	}
+	return nil
+}
+
+// readProtectionJSON reads an EIP-3076 slashing protection file.
+func readProtectionJSON(path string) (*format.EIPSlashingProtectionFormat, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read slashing protection file %s", path)
+	}
+	f := &format.EIPSlashingProtectionFormat{}
+	if err := json.Unmarshal(enc, f); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal slashing protection file %s", path)
+	}
+	return f, nil
+}
+
+// logIssues logs the issues found in slashing protection histories and returns the number of slashable ones.
+func logIssues(issues []*slashingprotection.Issue) int {
+	slashable := 0
+	for _, issue := range issues {
+		entry := log.WithFields(logrus.Fields{
+			"pubkey": fmt.Sprintf("%#x", issue.PubKey),
+			"kind":   issue.Kind,
+		})
+		if issue.Slashable() {
+			slashable++
+			entry.Error(issue.Message)
+			continue
+		}
+		entry.Info(issue.Message)
+	}
+	return slashable
+}
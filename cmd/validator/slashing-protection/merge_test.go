@@ -0,0 +1,92 @@
+package historycmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	"github.com/prysmaticlabs/prysm/v5/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	dbTest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+	mocks "github.com/prysmaticlabs/prysm/v5/validator/testing"
+	"github.com/urfave/cli/v2"
+)
+
+func writeProtectionJSON(t *testing.T, path string, f *format.EIPSlashingProtectionFormat) {
+	encoded, err := json.Marshal(f)
+	require.NoError(t, err)
+	require.NoError(t, file.WriteFile(path, encoded))
+}
+
+func TestMergeDiffAuditSlashingProtectionCli(t *testing.T) {
+	dir := t.TempDir()
+	outputPath := filepath.Join(dir, "merged")
+	pubKeys, err := mocks.CreateRandomPubKeys(4)
+	require.NoError(t, err)
+	attestingHistory, proposalHistory := mocks.MockAttestingAndProposalHistories(pubKeys)
+	mockJSON, err := mocks.MockSlashingProtectionJSON(pubKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+
+	// The keys were run on two machines, each exporting the history of half of them.
+	paths := make([]string, 2)
+	for i := range paths {
+		half := *mockJSON
+		half.Data = mockJSON.Data[i*2 : i*2+2]
+		paths[i] = filepath.Join(dir, fmt.Sprintf("machine-%d.json", i))
+		writeProtectionJSON(t, paths[i], &half)
+	}
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(flags.SlashingProtectionExportDirFlag.Name, outputPath, "")
+	require.NoError(t, set.Set(flags.SlashingProtectionExportDirFlag.Name, outputPath))
+	require.NoError(t, flags.SlashingProtectionJSONFilesFlag.Apply(set))
+	require.NoError(t, set.Set(flags.SlashingProtectionJSONFilesFlag.Name, paths[0]))
+	cliCtx := cli.NewContext(&app, set, nil)
+	require.ErrorContains(t, "at least two files to merge", mergeSlashingProtectionJSON(cliCtx))
+	require.NoError(t, set.Set(flags.SlashingProtectionJSONFilesFlag.Name, paths[1]))
+	require.NoError(t, mergeSlashingProtectionJSON(cliCtx))
+
+	mergedPath := filepath.Join(outputPath, jsonExportFileName)
+	merged, err := readProtectionJSON(mergedPath)
+	require.NoError(t, err)
+	require.Equal(t, len(pubKeys), len(merged.Data))
+
+	// The merged file is imported into the database of the machine which will run all the keys.
+	validatorDB := dbTest.SetupDB(t, pubKeys, false)
+	dbPath := validatorDB.DatabasePath()
+	require.NoError(t, validatorDB.Close())
+	cliCtx = setupCliCtx(t, dbPath, mergedPath, outputPath)
+	require.NoError(t, importSlashingProtectionJSON(cliCtx))
+	require.NoError(t, diffSlashingProtectionJSON(cliCtx))
+	require.NoError(t, auditSlashingProtectionDB(cliCtx))
+
+	// A file with a block signed at the same slot as a block of the database is a double proposal.
+	conflicting := *mockJSON
+	conflicting.Data = []*format.ProtectionData{{
+		Pubkey: mockJSON.Data[0].Pubkey,
+		SignedBlocks: []*format.SignedBlock{{
+			Slot:        mockJSON.Data[0].SignedBlocks[0].Slot,
+			SigningRoot: fmt.Sprintf("%#x", [32]byte{'c'}),
+		}},
+	}}
+	conflictingPath := filepath.Join(dir, "conflicting.json")
+	writeProtectionJSON(t, conflictingPath, &conflicting)
+	cliCtx = setupCliCtx(t, dbPath, conflictingPath, outputPath)
+	err = diffSlashingProtectionJSON(cliCtx)
+	require.ErrorContains(t, "1 records of", err)
+	assert.ErrorContains(t, "are slashable with respect to the validator database", err)
+
+	set = flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, filepath.Join(dir, "empty"), "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, filepath.Join(dir, "empty")))
+	require.NoError(t, file.MkdirAll(filepath.Join(dir, "empty")))
+	err = auditSlashingProtectionDB(cli.NewContext(&app, set, nil))
+	require.ErrorContains(t, "was not found at path", err)
+}
@@ -25,6 +25,7 @@ var Commands = &cli.Command{
 				features.SepoliaTestnet,
 				features.HoleskyTestnet,
 				features.EnableMinimalSlashingProtection,
+				flags.SlashingProtectionDBURLFlag,
 				cmd.AcceptTosFlag,
 			}),
 			Before: func(cliCtx *cli.Context) error {
@@ -53,6 +54,7 @@ var Commands = &cli.Command{
 				features.SepoliaTestnet,
 				features.HoleskyTestnet,
 				features.EnableMinimalSlashingProtection,
+				flags.SlashingProtectionDBURLFlag,
 				cmd.AcceptTosFlag,
 			}),
 			Before: func(cliCtx *cli.Context) error {
@@ -72,5 +74,89 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "merge",
+			Description: `merges several EIP-3076 compliant JSON files into one, keeping every record of every file, to be imported with the import command`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				flags.SlashingProtectionJSONFilesFlag,
+				flags.SlashingProtectionExportDirFlag,
+				features.Mainnet,
+				features.SepoliaTestnet,
+				features.HoleskyTestnet,
+				cmd.AcceptTosFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				if err := cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags); err != nil {
+					return err
+				}
+				return tos.VerifyTosAcceptedOrPrompt(cliCtx)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := features.ConfigureValidator(cliCtx); err != nil {
+					return err
+				}
+				if err := mergeSlashingProtectionJSON(cliCtx); err != nil {
+					logrus.Fatalf("Could not merge slashing protection files: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "diff",
+			Description: `compares a selected EIP-3076 compliant JSON file to the validator database, reporting conflicting and new records`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.SlashingProtectionJSONFileFlag,
+				features.Mainnet,
+				features.SepoliaTestnet,
+				features.HoleskyTestnet,
+				features.EnableMinimalSlashingProtection,
+				flags.SlashingProtectionDBURLFlag,
+				cmd.AcceptTosFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				if err := cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags); err != nil {
+					return err
+				}
+				return tos.VerifyTosAcceptedOrPrompt(cliCtx)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := features.ConfigureValidator(cliCtx); err != nil {
+					return err
+				}
+				if err := diffSlashingProtectionJSON(cliCtx); err != nil {
+					logrus.Fatalf("Could not compare slashing protection file: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "audit",
+			Description: `scans the validator database for slashable records and gaps in the attestation history`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				features.Mainnet,
+				features.SepoliaTestnet,
+				features.HoleskyTestnet,
+				features.EnableMinimalSlashingProtection,
+				flags.SlashingProtectionDBURLFlag,
+				cmd.AcceptTosFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				if err := cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags); err != nil {
+					return err
+				}
+				return tos.VerifyTosAcceptedOrPrompt(cliCtx)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := features.ConfigureValidator(cliCtx); err != nil {
+					return err
+				}
+				if err := auditSlashingProtectionDB(cliCtx); err != nil {
+					logrus.Fatalf("Could not audit slashing protection history: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
@@ -106,6 +106,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HTTPServerCorsDomain,
 			flags.GRPCHeadersFlag,
 			flags.BeaconRESTApiProviderFlag,
+			flags.MultiBeaconNodeFlag,
+			flags.BeaconNodeQuorumFlag,
 		},
 	},
 	{
@@ -129,6 +131,23 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Web3SignerKeyFileFlag,
 		},
 	},
+	{
+		Name: "pkcs11",
+		Flags: []cli.Flag{
+			flags.PKCS11ModuleFlag,
+			flags.PKCS11TokenLabelFlag,
+			flags.PKCS11PinFileFlag,
+			flags.PKCS11KeyTypeFlag,
+			flags.PKCS11MechanismFlag,
+		},
+	},
+	{
+		Name: "threshold",
+		Flags: []cli.Flag{
+			flags.ThresholdConfigFlag,
+			flags.ThresholdPasswordFileFlag,
+		},
+	},
 	{
 		Name: "slasher",
 		Flags: []cli.Flag{
@@ -144,6 +163,8 @@ var appHelpFlagGroups = []flagGroup{
 			flags.DisableAccountMetricsFlag,
 			flags.EnableDistributed,
 			flags.AuthTokenPathFlag,
+			flags.SlashingProtectionDBURLFlag,
+			flags.SigningAuditLogFlag,
 		},
 	},
 	{
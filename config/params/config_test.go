@@ -105,3 +105,9 @@ func TestConfigGenesisValidatorRoot(t *testing.T) {
 		t.Fatal("mainnet params genesis validator root does not match the mainnet genesis state value")
 	}
 }
+
+func TestConfigGenesisTime(t *testing.T) {
+	g, err := genesis.State(params.MainnetName)
+	require.NoError(t, err)
+	require.Equal(t, g.GenesisTime(), params.BeaconConfig().GenesisTime)
+}
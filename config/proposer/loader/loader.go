@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/validator"
 	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -168,6 +169,9 @@ func (psl *settingsLoader) Load(cliCtx *cli.Context) (*proposer.Settings, error)
 			if settingFromFile == nil {
 				return nil, errors.Errorf("proposer settings is empty after unmarshalling from file specified by %s flag", flags.ProposerSettingsFlag.Name)
 			}
+			if err := validateGraffiti(settingFromFile); err != nil {
+				return nil, err
+			}
 			loadConfig = psl.processProposerSettings(settingFromFile, loadConfig)
 			log.WithField(flags.ProposerSettingsFlag.Name, cliCtx.String(flags.ProposerSettingsFlag.Name)).Info("Proposer settings loaded from file")
 		case urlFlag:
@@ -178,6 +182,9 @@ func (psl *settingsLoader) Load(cliCtx *cli.Context) (*proposer.Settings, error)
 			if settingFromURL == nil {
 				return nil, errors.New("proposer settings is empty after unmarshalling from url")
 			}
+			if err := validateGraffiti(settingFromURL); err != nil {
+				return nil, err
+			}
 			loadConfig = psl.processProposerSettings(settingFromURL, loadConfig)
 			log.WithField(flags.ProposerSettingsURLFlag.Name, cliCtx.String(flags.ProposerSettingsURLFlag.Name)).Infof("Proposer settings loaded from URL")
 		case onlyDB:
@@ -215,6 +222,25 @@ func (psl *settingsLoader) Load(cliCtx *cli.Context) (*proposer.Settings, error)
 	return ps, nil
 }
 
+// validateGraffiti checks that the graffiti templates of the loaded settings can be rendered, so that a broken
+// template is reported at startup rather than at proposal time.
+func validateGraffiti(settings *validatorpb.ProposerSettingsPayload) error {
+	if settings.DefaultConfig != nil && settings.DefaultConfig.Graffiti != nil {
+		if err := graffiti.ValidateTemplate(*settings.DefaultConfig.Graffiti); err != nil {
+			return errors.Wrap(err, "invalid default graffiti")
+		}
+	}
+	for key, option := range settings.ProposerConfig {
+		if option == nil || option.Graffiti == nil {
+			continue
+		}
+		if err := graffiti.ValidateTemplate(*option.Graffiti); err != nil {
+			return errors.Wrapf(err, "invalid graffiti for %s", key)
+		}
+	}
+	return nil
+}
+
 func (psl *settingsLoader) processProposerSettings(loadedSettings, dbSettings *validatorpb.ProposerSettingsPayload) *validatorpb.ProposerSettingsPayload {
 	if loadedSettings == nil && dbSettings == nil {
 		return nil
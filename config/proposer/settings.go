@@ -78,10 +78,18 @@ func verifyOption(key string, option *validatorpb.ProposerOptionPayload) error {
 
 // BuilderConfig is the struct representation of the JSON config file set in the validator through the CLI.
 // GasLimit is a number set to help the network decide on the maximum gas in each block.
+// The remaining fields are a circuit breaker which the beacon node applies to the builder bids of each proposal:
+// bids below MinBidGwei, or exceeding the local payload value by more than MaxBidDifferenceGwei, are discarded,
+// only bids from the AllowedRelays are accepted, and the local payload is used if no bid arrived within TimeoutMs.
+// Zero values and an empty allow-list leave the beacon node defaults in place. Relays does not restrict the bids.
 type BuilderConfig struct {
-	Enabled  bool             `json:"enabled" yaml:"enabled"`
-	GasLimit validator.Uint64 `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
-	Relays   []string         `json:"relays,omitempty" yaml:"relays,omitempty"`
+	Enabled              bool             `json:"enabled" yaml:"enabled"`
+	GasLimit             validator.Uint64 `json:"gas_limit,omitempty" yaml:"gas_limit,omitempty"`
+	Relays               []string         `json:"relays,omitempty" yaml:"relays,omitempty"`
+	MinBidGwei           validator.Uint64 `json:"min_bid_gwei,omitempty" yaml:"min_bid_gwei,omitempty"`
+	MaxBidDifferenceGwei validator.Uint64 `json:"max_bid_difference_gwei,omitempty" yaml:"max_bid_difference_gwei,omitempty"`
+	TimeoutMs            validator.Uint64 `json:"timeout_ms,omitempty" yaml:"timeout_ms,omitempty"`
+	AllowedRelays        []string         `json:"allowed_relays,omitempty" yaml:"allowed_relays,omitempty"`
 }
 
 // BuilderConfigFromConsensus converts protobuf to a builder config used in in-memory storage
@@ -90,14 +98,22 @@ func BuilderConfigFromConsensus(from *validatorpb.BuilderConfig) *BuilderConfig
 		return nil
 	}
 	c := &BuilderConfig{
-		Enabled:  from.Enabled,
-		GasLimit: from.GasLimit,
+		Enabled:              from.Enabled,
+		GasLimit:             from.GasLimit,
+		MinBidGwei:           from.MinBidGwei,
+		MaxBidDifferenceGwei: from.MaxBidDifferenceGwei,
+		TimeoutMs:            from.TimeoutMs,
 	}
 	if from.Relays != nil {
 		relays := make([]string, len(from.Relays))
 		copy(relays, from.Relays)
 		c.Relays = relays
 	}
+	if from.AllowedRelays != nil {
+		allowed := make([]string, len(from.AllowedRelays))
+		copy(allowed, from.AllowedRelays)
+		c.AllowedRelays = allowed
+	}
 	return c
 }
 
@@ -224,12 +240,20 @@ func (bc *BuilderConfig) Clone() *BuilderConfig {
 	c := &BuilderConfig{}
 	c.Enabled = bc.Enabled
 	c.GasLimit = bc.GasLimit
+	c.MinBidGwei = bc.MinBidGwei
+	c.MaxBidDifferenceGwei = bc.MaxBidDifferenceGwei
+	c.TimeoutMs = bc.TimeoutMs
 	var relays []string
 	if bc.Relays != nil {
 		relays = make([]string, len(bc.Relays))
 		copy(relays, bc.Relays)
 		c.Relays = relays
 	}
+	if bc.AllowedRelays != nil {
+		allowed := make([]string, len(bc.AllowedRelays))
+		copy(allowed, bc.AllowedRelays)
+		c.AllowedRelays = allowed
+	}
 	return c
 }
 
@@ -255,5 +279,13 @@ func (bc *BuilderConfig) ToConsensus() *validatorpb.BuilderConfig {
 		c.Relays = relays
 	}
 	c.GasLimit = bc.GasLimit
+	c.MinBidGwei = bc.MinBidGwei
+	c.MaxBidDifferenceGwei = bc.MaxBidDifferenceGwei
+	c.TimeoutMs = bc.TimeoutMs
+	if bc.AllowedRelays != nil {
+		allowed := make([]string, len(bc.AllowedRelays))
+		copy(allowed, bc.AllowedRelays)
+		c.AllowedRelays = allowed
+	}
 	return c
 }
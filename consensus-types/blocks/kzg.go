@@ -23,6 +23,7 @@ var (
 	errInvalidIndex          = errors.New("index out of bounds")
 	errInvalidBodyRoot       = errors.New("invalid Beacon Block Body root")
 	errInvalidInclusionProof = errors.New("invalid KZG commitment inclusion proof")
+	errInvalidProofLength    = errors.New("invalid KZG commitments inclusion proof length")
 )
 
 // VerifyKZGInclusionProof verifies the Merkle proof in a Blob sidecar against
@@ -80,6 +81,60 @@ func MerkleProofKZGCommitment(body interfaces.ReadOnlyBeaconBlockBody, index int
 	return proof, nil
 }
 
+// VerifyKZGCommitmentsInclusionProof verifies the Merkle proof of the KZG commitments list
+// in a data column sidecar against the beacon block body root.
+func VerifyKZGCommitmentsInclusionProof(dc RODataColumn) error {
+	if dc.SignedBlockHeader == nil || dc.SignedBlockHeader.Header == nil {
+		return errNilBlockHeader
+	}
+	root := dc.SignedBlockHeader.Header.BodyRoot
+	if len(root) != field_params.RootLength {
+		return errInvalidBodyRoot
+	}
+	if len(dc.KzgCommitmentsInclusionProof) != field_params.KzgCommitmentsInclusionProofDepth {
+		return errInvalidProofLength
+	}
+	leaf, err := kzgCommitmentsRoot(dc.KzgCommitments)
+	if err != nil {
+		return err
+	}
+	if !trie.VerifyMerkleProof(root, leaf[:], kzgPosition, dc.KzgCommitmentsInclusionProof) {
+		return errInvalidInclusionProof
+	}
+	return nil
+}
+
+// MerkleProofKZGCommitments constructs a Merkle proof of inclusion of the KZG
+// commitments list into the Beacon Block with the given `body`.
+func MerkleProofKZGCommitments(body interfaces.ReadOnlyBeaconBlockBody) ([][]byte, error) {
+	if body.Version() < version.Deneb {
+		return nil, errUnsupportedBeaconBlockBody
+	}
+	membersRoots, err := topLevelRoots(body)
+	if err != nil {
+		return nil, err
+	}
+	sparse, err := trie.GenerateTrieFromItems(membersRoots, logBodyLength)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := sparse.MerkleProof(kzgPosition)
+	if err != nil {
+		return nil, err
+	}
+	// sparse.MerkleProof always includes the length of the slice, which is not part of the body tree.
+	return proof[:len(proof)-1], nil
+}
+
+// kzgCommitmentsRoot computes the hash tree root of a KZG commitments list.
+func kzgCommitmentsRoot(commitments [][]byte) ([32]byte, error) {
+	sparse, err := trie.GenerateTrieFromItems(leavesFromCommitments(commitments), field_params.LogMaxBlobCommitments)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sparse.HashTreeRoot()
+}
+
 // leavesFromCommitments hashes each commitment to construct a slice of roots
 func leavesFromCommitments(commitments [][]byte) [][]byte {
 	leaves := make([][]byte, len(commitments))
@@ -96,11 +96,13 @@ func (s ROBlockSlice) Len() int {
 	return len(s)
 }
 
-// BlockWithROBlobs is a wrapper that collects the block and blob values together.
+// BlockWithROBlobs is a wrapper that collects the block and blob values together, or the block and its data
+// columns past the PeerDAS fork.
 // This is helpful because these values are collated from separate RPC requests.
 type BlockWithROBlobs struct {
-	Block ROBlock
-	Blobs []ROBlob
+	Block   ROBlock
+	Blobs   []ROBlob
+	Columns []RODataColumn
 }
 
 // BlockWithROBlobsSlice gives convenient access to getting a slice of just the ROBlocks,
@@ -0,0 +1,82 @@
+package blocks
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestRODataColumnNilChecks(t *testing.T) {
+	cases := []struct {
+		name   string
+		dcfunc func() *ethpb.DataColumnSidecar
+		err    error
+	}{
+		{
+			name:   "nil data column",
+			dcfunc: func() *ethpb.DataColumnSidecar { return nil },
+			err:    errNilDataColumn,
+		},
+		{
+			name:   "nil signed block header",
+			dcfunc: func() *ethpb.DataColumnSidecar { return &ethpb.DataColumnSidecar{} },
+			err:    errNilBlockHeader,
+		},
+		{
+			name: "nil signature",
+			dcfunc: func() *ethpb.DataColumnSidecar {
+				return &ethpb.DataColumnSidecar{
+					SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+						Header: &ethpb.BeaconBlockHeader{
+							ParentRoot: make([]byte, fieldparams.RootLength),
+							StateRoot:  make([]byte, fieldparams.RootLength),
+							BodyRoot:   make([]byte, fieldparams.RootLength),
+						},
+					},
+				}
+			},
+			err: errMissingBlockSignature,
+		},
+		{
+			name: "valid",
+			dcfunc: func() *ethpb.DataColumnSidecar {
+				return &ethpb.DataColumnSidecar{
+					SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+						Header: &ethpb.BeaconBlockHeader{
+							Slot:       10,
+							ParentRoot: make([]byte, fieldparams.RootLength),
+							StateRoot:  make([]byte, fieldparams.RootLength),
+							BodyRoot:   make([]byte, fieldparams.RootLength),
+						},
+						Signature: make([]byte, fieldparams.BLSSignatureLength),
+					},
+				}
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dc, err := NewRODataColumn(c.dcfunc())
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+			} else {
+				require.NoError(t, err)
+				hr, err := dc.SignedBlockHeader.Header.HashTreeRoot()
+				require.NoError(t, err)
+				require.Equal(t, hr, dc.BlockRoot())
+				require.Equal(t, dc.SignedBlockHeader.Header.Slot, dc.Slot())
+			}
+			root := bytesutil.ToBytes32([]byte("root"))
+			dc, err = NewRODataColumnWithRoot(c.dcfunc(), root)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, root, dc.BlockRoot())
+			}
+		})
+	}
+}
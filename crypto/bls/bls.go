@@ -80,3 +80,14 @@ func NewAggregateSignature() common.Signature {
 func RandKey() (common.SecretKey, error) {
 	return blst.RandKey()
 }
+
+// SplitSecretKey splits a secret key into shares, any threshold of which can sign for it.
+func SplitSecretKey(sk SecretKey, threshold, shares uint64) ([]SecretKey, error) {
+	return blst.SplitSecretKey(sk, threshold, shares)
+}
+
+// RecoverSignature combines partial signatures of shares, identified by their ids, into the signature of
+// the split secret key.
+func RecoverSignature(partials []Signature, ids []uint64) (Signature, error) {
+	return blst.RecoverSignature(partials, ids)
+}
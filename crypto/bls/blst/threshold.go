@@ -0,0 +1,113 @@
+//go:build ((linux && amd64) || (linux && arm64) || (darwin && amd64) || (darwin && arm64) || (windows && amd64)) && !blst_disabled
+
+package blst
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/crypto/rand"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// curveOrder is the order r of the BLS12-381 subgroups, secret keys are scalars modulo r.
+var curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// SplitSecretKey splits a secret key into shares with Shamir's secret sharing, so that any threshold of
+// the shares can produce a signature of the key with RecoverSignature, while fewer reveal nothing about it.
+// The share at index i is the evaluation of a random polynomial of degree threshold-1 at id i+1.
+func SplitSecretKey(sk common.SecretKey, threshold, shares uint64) ([]common.SecretKey, error) {
+	if sk == nil {
+		return nil, errors.New("nil secret key")
+	}
+	if threshold == 0 || threshold > shares {
+		return nil, fmt.Errorf("threshold must be between 1 and the number of shares %d, got %d", shares, threshold)
+	}
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = new(big.Int).SetBytes(sk.Marshal())
+	// Coefficients are reduced from twice as many random bits as the order, which makes their bias negligible.
+	var entropy [2 * scalarBytes]byte
+	for i := uint64(1); i < threshold; i++ {
+		if _, err := rand.NewGenerator().Read(entropy[:]); err != nil {
+			return nil, err
+		}
+		coefficients[i] = new(big.Int).Mod(new(big.Int).SetBytes(entropy[:]), curveOrder)
+	}
+
+	keys := make([]common.SecretKey, shares)
+	for i := range keys {
+		id := big.NewInt(int64(i + 1))
+		// Horner's method, from the coefficient of the highest degree.
+		value := new(big.Int)
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			value.Mul(value, id)
+			value.Add(value, coefficients[j])
+			value.Mod(value, curveOrder)
+		}
+		key, err := SecretKeyFromBytes(value.FillBytes(make([]byte, scalarBytes)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create share %d", i+1)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// RecoverSignature combines the signatures of the same message by distinct shares, identified by the ids
+// SplitSecretKey assigned to them, into the signature of the split secret key. It needs at least as many
+// partial signatures as the threshold of the split, which it cannot check: the caller must verify the result.
+func RecoverSignature(partials []common.Signature, ids []uint64) (common.Signature, error) {
+	if len(partials) == 0 {
+		return nil, errors.New("no partial signatures provided")
+	}
+	if len(partials) != len(ids) {
+		return nil, fmt.Errorf("got %d partial signatures for %d share ids", len(partials), len(ids))
+	}
+	xs := make([]*big.Int, len(ids))
+	seen := make(map[uint64]bool, len(ids))
+	for i, id := range ids {
+		if id == 0 {
+			return nil, errors.New("share id 0 is the secret key itself")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate share id %d", id)
+		}
+		seen[id] = true
+		xs[i] = new(big.Int).SetUint64(id)
+	}
+
+	result := new(blst.P2)
+	for i, partial := range partials {
+		sig, ok := partial.(*Signature)
+		if !ok || sig == nil {
+			return nil, errors.New("partial signature is not a blst signature")
+		}
+		var scalar blst.Scalar
+		if scalar.Deserialize(lagrangeCoefficient(xs, i).FillBytes(make([]byte, scalarBytes))) == nil {
+			return nil, fmt.Errorf("could not create lagrange coefficient of share %d", ids[i])
+		}
+		point := new(blst.P2)
+		point.FromAffine(sig.s)
+		result.AddAssign(point.Mult(&scalar))
+	}
+	return &Signature{s: result.ToAffine()}, nil
+}
+
+// lagrangeCoefficient returns the Lagrange basis polynomial of xs[i] evaluated at 0, modulo the curve order:
+// the product of x_j / (x_j - x_i) for every j other than i.
+func lagrangeCoefficient(xs []*big.Int, i int) *big.Int {
+	numerator, denominator := big.NewInt(1), big.NewInt(1)
+	for j, x := range xs {
+		if j == i {
+			continue
+		}
+		numerator.Mul(numerator, x)
+		numerator.Mod(numerator, curveOrder)
+		diff := new(big.Int).Sub(x, xs[i])
+		denominator.Mul(denominator, diff.Mod(diff, curveOrder))
+		denominator.Mod(denominator, curveOrder)
+	}
+	return numerator.Mul(numerator, denominator.ModInverse(denominator, curveOrder)).Mod(numerator, curveOrder)
+}
@@ -0,0 +1,64 @@
+//go:build ((linux && amd64) || (linux && arm64) || (darwin && amd64) || (darwin && arm64) || (windows && amd64)) && !blst_disabled
+
+package blst_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/blst"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestSplitSecretKey_RecoverSignature(t *testing.T) {
+	sk, err := blst.RandKey()
+	require.NoError(t, err)
+	msg := []byte("hello")
+	shares, err := blst.SplitSecretKey(sk, 3, 5)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(shares))
+
+	subsets := [][]uint64{{1, 2, 3}, {5, 3, 1}, {2, 4, 5}, {1, 2, 3, 4, 5}}
+	for _, ids := range subsets {
+		partials := make([]common.Signature, len(ids))
+		for i, id := range ids {
+			partials[i] = shares[id-1].Sign(msg)
+		}
+		sig, err := blst.RecoverSignature(partials, ids)
+		require.NoError(t, err)
+		assert.DeepEqual(t, sk.Sign(msg).Marshal(), sig.Marshal(), "ids %v", ids)
+	}
+
+	// Fewer partial signatures than the threshold do not recover the signature.
+	sig, err := blst.RecoverSignature([]common.Signature{shares[0].Sign(msg), shares[1].Sign(msg)}, []uint64{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, false, sig.Verify(sk.PublicKey(), msg))
+}
+
+func TestSplitSecretKey_Errors(t *testing.T) {
+	sk, err := blst.RandKey()
+	require.NoError(t, err)
+	_, err = blst.SplitSecretKey(sk, 0, 3)
+	require.ErrorContains(t, "threshold must be between 1", err)
+	_, err = blst.SplitSecretKey(sk, 4, 3)
+	require.ErrorContains(t, "threshold must be between 1", err)
+
+	shares, err := blst.SplitSecretKey(sk, 1, 2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, sk.Marshal(), shares[0].Marshal(), "shares of a threshold of 1 are the key")
+}
+
+func TestRecoverSignature_Errors(t *testing.T) {
+	sk, err := blst.RandKey()
+	require.NoError(t, err)
+	sig := sk.Sign([]byte("hello"))
+	_, err = blst.RecoverSignature(nil, nil)
+	require.ErrorContains(t, "no partial signatures", err)
+	_, err = blst.RecoverSignature([]common.Signature{sig}, []uint64{1, 2})
+	require.ErrorContains(t, "got 1 partial signatures for 2 share ids", err)
+	_, err = blst.RecoverSignature([]common.Signature{sig, sig}, []uint64{1, 1})
+	require.ErrorContains(t, "duplicate share id 1", err)
+	_, err = blst.RecoverSignature([]common.Signature{sig}, []uint64{0})
+	require.ErrorContains(t, "share id 0", err)
+}
@@ -29,6 +29,18 @@ func TestConvertToInterfacePubkey(t *testing.T) {
 	assert.DeepEqual(t, origRawKey, rawKey)
 }
 
+func TestConvertFromInterfacePubKey(t *testing.T) {
+	privKey, err := gcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	altPubkey, err := ConvertToInterfacePubkey(&privKey.PublicKey)
+	require.NoError(t, err)
+
+	pubkey, err := ConvertFromInterfacePubKey(altPubkey)
+	require.NoError(t, err)
+	assert.DeepEqual(t, gcrypto.FromECDSAPub(&privKey.PublicKey), gcrypto.FromECDSAPub(pubkey))
+}
+
 func TestConvertToInterfacePrivkey_HandlesShorterKeys(t *testing.T) {
 	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
 	assert.NoError(t, err)
@@ -0,0 +1,115 @@
+package multiproof_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/multiproof"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestGeneralizedIndex(t *testing.T) {
+	st := &ethpb.BeaconStateAltair{}
+	cases := []struct {
+		path   string
+		gindex uint64
+	}{
+		{path: "finalized_checkpoint.root", gindex: 105},
+		{path: "$.finalized_checkpoint.root", gindex: 105},
+		{path: "current_sync_committee", gindex: 54},
+		{path: "slot", gindex: 34},
+		// validators is field 11 of 24, a list of up to 2^40 validators.
+		{path: "validators.__len__", gindex: 43*2 + 1},
+		{path: "validators[0]", gindex: 43 * 2 << 40},
+		{path: "validators[3].effective_balance", gindex: ((43*2<<40)+3)*8 + 2},
+		// balances are packed four to a chunk.
+		{path: "balances[5]", gindex: (44 * 2 << 38) + 1},
+		{path: "block_roots.7", gindex: (37 << 13) + 7},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			gindex, err := multiproof.GeneralizedIndex(st, c.path)
+			require.NoError(t, err)
+			require.Equal(t, c.gindex, gindex)
+		})
+	}
+
+	for _, path := range []string{"foo", "slot.bar", "block_roots.8192", "block_roots.__len__"} {
+		_, err := multiproof.GeneralizedIndex(st, path)
+		require.ErrorIs(t, err, multiproof.ErrInvalidPath)
+	}
+}
+
+func TestFromValue_MatchesHashTreeRoot(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 16)
+	pb, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateAltair)
+	require.Equal(t, true, ok)
+	expected, err := pb.HashTreeRoot()
+	require.NoError(t, err)
+	n, err := multiproof.FromValue(pb)
+	require.NoError(t, err)
+	root, err := n.Root()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	att := &ethpb.PendingAttestation{
+		AggregationBits: bitfield.NewBitlist(9),
+		Data:            util.HydrateAttestationData(&ethpb.AttestationData{Slot: 3}),
+		InclusionDelay:  1,
+	}
+	att.AggregationBits.SetBitAt(4, true)
+	expected, err = att.HashTreeRoot()
+	require.NoError(t, err)
+	n, err = multiproof.FromValue(att)
+	require.NoError(t, err)
+	root, err = n.Root()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
+
+func TestProve(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 16)
+	pb, ok := st.ToProtoUnsafe().(*ethpb.BeaconStateAltair)
+	require.Equal(t, true, ok)
+	pb.Validators[3].EffectiveBalance = 123
+	root, err := pb.HashTreeRoot()
+	require.NoError(t, err)
+	n, err := multiproof.FromValue(pb)
+	require.NoError(t, err)
+
+	var indices []uint64
+	for _, path := range []string{"validators[3].effective_balance", "validators[4]", "validators.__len__", "slot"} {
+		gindex, err := multiproof.GeneralizedIndex(pb, path)
+		require.NoError(t, err)
+		indices = append(indices, gindex)
+	}
+	p, err := multiproof.Prove(n, indices)
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), uint64(p.Leaves[0][0]))
+	valRoot, err := pb.Validators[4].HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, valRoot, p.Leaves[1])
+	require.Equal(t, uint64(16), uint64(p.Leaves[2][0]))
+	require.Equal(t, len(multiproof.HelperIndices(indices)), len(p.Proof))
+	valid, err := p.Verify(root)
+	require.NoError(t, err)
+	require.Equal(t, true, valid)
+
+	p.Leaves[0][0]++
+	valid, err = p.Verify(root)
+	require.NoError(t, err)
+	require.Equal(t, false, valid)
+
+	p.Proof = p.Proof[1:]
+	_, err = p.Verify(root)
+	require.ErrorContains(t, "proof nodes", err)
+}
+
+func TestHelperIndices(t *testing.T) {
+	// Proving 8 and 9 only requires their parent's sibling and grandparent's sibling.
+	require.DeepEqual(t, []uint64{5, 3}, multiproof.HelperIndices([]uint64{8, 9}))
+	require.DeepEqual(t, []uint64{9, 5, 3}, multiproof.HelperIndices([]uint64{8}))
+	require.DeepEqual(t, []uint64{}, multiproof.HelperIndices([]uint64{1}))
+}
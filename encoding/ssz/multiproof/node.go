@@ -0,0 +1,172 @@
+package multiproof
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash/htr"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz"
+)
+
+// ErrNoChildren is returned when descending below a node whose subtree is not known.
+var ErrNoChildren = errors.New("node has no children")
+
+// Node is a node of an SSZ Merkle tree. Nodes are expanded lazily, so that a proof only requires
+// hashing the subtrees along the proven paths which are not already cached.
+type Node interface {
+	Root() ([32]byte, error)
+	// Child returns the left child of the node, or the right child if right is true.
+	Child(right bool) (Node, error)
+}
+
+// Leaf returns a node with the given root and no known children.
+func Leaf(root [32]byte) Node {
+	return leafNode(root)
+}
+
+type leafNode [32]byte
+
+func (n leafNode) Root() ([32]byte, error) {
+	return n, nil
+}
+
+func (leafNode) Child(bool) (Node, error) {
+	return nil, ErrNoChildren
+}
+
+// zeroNode is the root of a subtree of the given depth containing only zero chunks.
+type zeroNode int
+
+func (n zeroNode) Root() ([32]byte, error) {
+	return trie.ZeroHashes[n], nil
+}
+
+func (n zeroNode) Child(bool) (Node, error) {
+	if n == 0 {
+		return nil, ErrNoChildren
+	}
+	return n - 1, nil
+}
+
+type mixInNode struct {
+	tree   Node
+	length uint64
+}
+
+// MixIn returns the node of a list whose elements are merkleized in tree, mixing in the list length.
+func MixIn(tree Node, length uint64) Node {
+	return &mixInNode{tree: tree, length: length}
+}
+
+func (n *mixInNode) Root() ([32]byte, error) {
+	r, err := n.tree.Root()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	l := lengthChunk(n.length)
+	return hash.Hash(append(r[:], l[:]...)), nil
+}
+
+func (n *mixInNode) Child(right bool) (Node, error) {
+	if right {
+		return Leaf(lengthChunk(n.length)), nil
+	}
+	return n.tree, nil
+}
+
+func lengthChunk(length uint64) [32]byte {
+	var chunk [32]byte
+	binary.LittleEndian.PutUint64(chunk[:8], length)
+	return chunk
+}
+
+// ExpandFunc returns the subtree of the leaf at the given index of a Merkle tree.
+type ExpandFunc func(index uint64) (Node, error)
+
+// layerFunc returns the node at the given index of a layer of a Merkle tree, where layer 0 holds the leaves.
+// The boolean is false if the node is not stored, in which case it is the root of a zero subtree.
+type layerFunc func(layer int, index uint64) ([32]byte, bool)
+
+type layersNode struct {
+	get    layerFunc
+	layer  int
+	index  uint64
+	expand ExpandFunc
+}
+
+func (n *layersNode) Root() ([32]byte, error) {
+	if r, ok := n.get(n.layer, n.index); ok {
+		return r, nil
+	}
+	return trie.ZeroHashes[n.layer], nil
+}
+
+func (n *layersNode) Child(right bool) (Node, error) {
+	if n.layer == 0 {
+		if n.expand == nil {
+			return nil, ErrNoChildren
+		}
+		leaf, err := n.expand(n.index)
+		if err != nil {
+			return nil, err
+		}
+		return leaf.Child(right)
+	}
+	index := n.index * 2
+	if right {
+		index++
+	}
+	if _, ok := n.get(n.layer-1, index); !ok {
+		return zeroNode(n.layer - 1), nil
+	}
+	return &layersNode{get: n.get, layer: n.layer - 1, index: index, expand: n.expand}, nil
+}
+
+// FromLayers returns the root node of a Merkle tree stored as layers of node pointers, from the leaves up to the root,
+// such as the layers of a field trie. The expand function, if not nil, is used to descend below the leaves.
+func FromLayers(layers [][]*[32]byte, expand ExpandFunc) Node {
+	get := func(layer int, index uint64) ([32]byte, bool) {
+		if index >= uint64(len(layers[layer])) || layers[layer][index] == nil {
+			return [32]byte{}, false
+		}
+		return *layers[layer][index], true
+	}
+	return &layersNode{get: get, layer: len(layers) - 1, expand: expand}
+}
+
+// FromByteLayers returns the root node of a Merkle tree stored as layers of byte slices, from the leaves up to the root.
+func FromByteLayers(layers [][][]byte, expand ExpandFunc) Node {
+	get := func(layer int, index uint64) ([32]byte, bool) {
+		if index >= uint64(len(layers[layer])) || len(layers[layer][index]) != 32 {
+			return [32]byte{}, false
+		}
+		return [32]byte(layers[layer][index]), true
+	}
+	return &layersNode{get: get, layer: len(layers) - 1, expand: expand}
+}
+
+// FromLeaves merkleizes the leaves into a tree which can hold up to limit leaves.
+func FromLeaves(leaves [][32]byte, limit uint64, expand ExpandFunc) (Node, error) {
+	if uint64(len(leaves)) > limit {
+		return nil, errors.Errorf("%d leaves exceed the limit of %d", len(leaves), limit)
+	}
+	depth := int(ssz.Depth(limit))
+	layers := make([][][32]byte, depth+1)
+	layers[0] = leaves
+	for i := 0; i < depth && len(layers[i]) > 0; i++ {
+		layer := layers[i]
+		if len(layer)%2 == 1 {
+			layer = append(layer[:len(layer):len(layer)], trie.ZeroHashes[i])
+		}
+		layers[i+1] = htr.VectorizedSha256(layer)
+	}
+	get := func(layer int, index uint64) ([32]byte, bool) {
+		if index >= uint64(len(layers[layer])) {
+			return [32]byte{}, false
+		}
+		return layers[layer][index], true
+	}
+	return &layersNode{get: get, layer: depth, expand: expand}, nil
+}
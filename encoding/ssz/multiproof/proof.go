@@ -0,0 +1,180 @@
+package multiproof
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+)
+
+// Multiproof proves the values of several nodes of a Merkle tree against its root, sharing the branch nodes
+// between them as described in the consensus specs' ssz/merkle-proofs.md.
+type Multiproof struct {
+	// Indices are the generalized indices of the proven nodes.
+	Indices []uint64
+	// Leaves are the values of the proven nodes.
+	Leaves [][32]byte
+	// Proof holds the values of the helper nodes, ordered as returned by HelperIndices.
+	Proof [][32]byte
+}
+
+// HelperIndices returns the generalized indices of the nodes required to prove the nodes at the given indices,
+// in decreasing order.
+//
+// Spec pseudocode definition:
+//
+//	def get_helper_indices(indices: Sequence[GeneralizedIndex]) -> Sequence[GeneralizedIndex]:
+//	    all_helper_indices: Set[GeneralizedIndex] = set()
+//	    all_path_indices: Set[GeneralizedIndex] = set()
+//	    for index in indices:
+//	        all_helper_indices = all_helper_indices.union(set(get_branch_indices(index)))
+//	        all_path_indices = all_path_indices.union(set(get_path_indices(index)))
+//
+//	    return sorted(all_helper_indices.difference(all_path_indices), reverse=True)
+func HelperIndices(indices []uint64) []uint64 {
+	helpers := make(map[uint64]bool)
+	paths := make(map[uint64]bool)
+	for _, index := range indices {
+		for i := index; i > 1; i /= 2 {
+			helpers[i^1] = true
+			paths[i] = true
+		}
+	}
+	out := make([]uint64, 0, len(helpers))
+	for i := range helpers {
+		if !paths[i] {
+			out = append(out, i)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i] > out[j]
+	})
+	return out
+}
+
+// Prove builds a multiproof of the nodes at the given generalized indices of the tree.
+func Prove(root Node, indices []uint64) (*Multiproof, error) {
+	nodes := map[uint64]Node{1: root}
+	var nodeAt func(gindex uint64) (Node, error)
+	nodeAt = func(gindex uint64) (Node, error) {
+		if n, ok := nodes[gindex]; ok {
+			return n, nil
+		}
+		parent, err := nodeAt(gindex / 2)
+		if err != nil {
+			return nil, err
+		}
+		n, err := parent.Child(gindex%2 == 1)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get node at generalized index %d", gindex)
+		}
+		nodes[gindex] = n
+		return n, nil
+	}
+	rootAt := func(gindex uint64) ([32]byte, error) {
+		n, err := nodeAt(gindex)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return n.Root()
+	}
+
+	p := &Multiproof{
+		Indices: indices,
+		Leaves:  make([][32]byte, len(indices)),
+	}
+	for i, index := range indices {
+		if index == 0 {
+			return nil, errors.New("generalized index 0 is invalid")
+		}
+		r, err := rootAt(index)
+		if err != nil {
+			return nil, err
+		}
+		p.Leaves[i] = r
+	}
+	helpers := HelperIndices(indices)
+	p.Proof = make([][32]byte, len(helpers))
+	for i, index := range helpers {
+		r, err := rootAt(index)
+		if err != nil {
+			return nil, err
+		}
+		p.Proof[i] = r
+	}
+	return p, nil
+}
+
+// Root computes the root of the tree from the proven leaves and the proof.
+//
+// Spec pseudocode definition:
+//
+//	def calculate_multi_merkle_root(leaves: Sequence[Bytes32],
+//	                                proof: Sequence[Bytes32],
+//	                                indices: Sequence[GeneralizedIndex]) -> Root:
+//	    assert len(leaves) == len(indices)
+//	    helper_indices = get_helper_indices(indices)
+//	    assert len(proof) == len(helper_indices)
+//	    objects = {
+//	        **{index: node for index, node in zip(indices, leaves)},
+//	        **{index: node for index, node in zip(helper_indices, proof)}
+//	    }
+//	    keys = sorted(objects.keys(), reverse=True)
+//	    pos = 0
+//	    while pos < len(keys):
+//	        k = keys[pos]
+//	        if k in objects and k ^ 1 in objects and k // 2 not in objects:
+//	            objects[GeneralizedIndex(k // 2)] = hash(
+//	                objects[GeneralizedIndex((k | 1) ^ 1)] +
+//	                objects[GeneralizedIndex(k | 1)]
+//	            )
+//	            keys.append(GeneralizedIndex(k // 2))
+//	        pos += 1
+//	    return objects[GeneralizedIndex(1)]
+func (p *Multiproof) Root() ([32]byte, error) {
+	if len(p.Leaves) != len(p.Indices) {
+		return [32]byte{}, errors.Errorf("got %d leaves for %d indices", len(p.Leaves), len(p.Indices))
+	}
+	helpers := HelperIndices(p.Indices)
+	if len(p.Proof) != len(helpers) {
+		return [32]byte{}, errors.Errorf("got %d proof nodes, expected %d", len(p.Proof), len(helpers))
+	}
+	objects := make(map[uint64][32]byte, len(p.Indices)+len(helpers))
+	for i, index := range p.Indices {
+		objects[index] = p.Leaves[i]
+	}
+	for i, index := range helpers {
+		objects[index] = p.Proof[i]
+	}
+	keys := make([]uint64, 0, len(objects))
+	for k := range objects {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] > keys[j]
+	})
+	for pos := 0; pos < len(keys); pos++ {
+		k := keys[pos]
+		_, hasSibling := objects[k^1]
+		_, hasParent := objects[k/2]
+		if k > 1 && hasSibling && !hasParent {
+			left, right := objects[(k|1)^1], objects[k|1]
+			objects[k/2] = hash.Hash(append(left[:], right[:]...))
+			keys = append(keys, k/2)
+		}
+	}
+	root, ok := objects[1]
+	if !ok {
+		return [32]byte{}, errors.New("proof does not reach the root")
+	}
+	return root, nil
+}
+
+// Verify checks the multiproof against the root of the tree.
+func (p *Multiproof) Verify(root [32]byte) (bool, error) {
+	r, err := p.Root()
+	if err != nil {
+		return false, err
+	}
+	return r == root, nil
+}
@@ -0,0 +1,414 @@
+package multiproof
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+var (
+	// ErrInvalidPath is returned when a path does not resolve to a node of the SSZ type.
+	ErrInvalidPath     = errors.New("invalid path")
+	errUnsupportedType = errors.New("unsupported ssz type")
+)
+
+// LengthSegment is the path segment referring to the length mixed into the root of a list.
+const LengthSegment = "__len__"
+
+type kind int
+
+const (
+	basicKind kind = iota
+	containerKind
+	vectorKind
+	listKind
+	bitlistKind
+)
+
+// sszType describes the SSZ type of a Go value, derived from its ssz-size and ssz-max struct tags.
+type sszType struct {
+	kind kind
+	// size in bytes of a basic type.
+	size int
+	// length of a vector or limit of a list or bitlist.
+	length uint64
+	elem   *sszType
+	fields []*sszField
+}
+
+type sszField struct {
+	name  string
+	index int
+	typ   *sszType
+}
+
+var containerTypes sync.Map
+
+func typeOf(t reflect.Type, sizes, maxes []string) (*sszType, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeOf(t.Elem(), sizes, maxes)
+	case reflect.Bool, reflect.Uint8:
+		return &sszType{kind: basicKind, size: 1}, nil
+	case reflect.Uint16:
+		return &sszType{kind: basicKind, size: 2}, nil
+	case reflect.Uint32:
+		return &sszType{kind: basicKind, size: 4}, nil
+	case reflect.Uint64:
+		return &sszType{kind: basicKind, size: 8}, nil
+	case reflect.Struct:
+		return containerType(t)
+	case reflect.Slice, reflect.Array:
+		if t == reflect.TypeOf(bitfield.Bitlist{}) {
+			if len(maxes) == 0 {
+				return nil, errors.Wrap(errUnsupportedType, "bitlist without a maximum length")
+			}
+			limit, err := strconv.ParseUint(maxes[0], 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(errUnsupportedType, "invalid bitlist limit %s", maxes[0])
+			}
+			return &sszType{kind: bitlistKind, length: limit}, nil
+		}
+		size := ""
+		if len(sizes) > 0 {
+			size, sizes = sizes[0], sizes[1:]
+		}
+		if size == "" && t.Kind() == reflect.Array {
+			size = strconv.Itoa(t.Len())
+		}
+		typ := &sszType{kind: vectorKind}
+		if size == "" || size == "?" {
+			if len(maxes) == 0 {
+				return nil, errors.Wrapf(errUnsupportedType, "list of %s without a maximum length", t.Elem())
+			}
+			typ.kind = listKind
+			size, maxes = maxes[0], maxes[1:]
+		}
+		length, err := strconv.ParseUint(size, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(errUnsupportedType, "invalid length %s", size)
+		}
+		typ.length = length
+		typ.elem, err = typeOf(t.Elem(), sizes, maxes)
+		if err != nil {
+			return nil, err
+		}
+		return typ, nil
+	default:
+		return nil, errors.Wrapf(errUnsupportedType, "%s", t)
+	}
+}
+
+// containerType describes a protobuf generated struct, whose fields are in SSZ order.
+func containerType(t reflect.Type) (*sszType, error) {
+	if typ, ok := containerTypes.Load(t); ok {
+		return typ.(*sszType), nil
+	}
+	typ := &sszType{kind: containerKind}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := protobufName(f.Tag.Get("protobuf"))
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		ft, err := typeOf(f.Type, splitTag(f.Tag.Get("ssz-size")), splitTag(f.Tag.Get("ssz-max")))
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s of %s", name, t)
+		}
+		typ.fields = append(typ.fields, &sszField{name: name, index: i, typ: ft})
+	}
+	if len(typ.fields) == 0 {
+		return nil, errors.Wrapf(errUnsupportedType, "%s has no ssz fields", t)
+	}
+	containerTypes.Store(t, typ)
+	return typ, nil
+}
+
+func protobufName(tag string) string {
+	for _, part := range strings.Split(tag, ",") {
+		if name, ok := strings.CutPrefix(part, "name="); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func splitTag(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// chunkCount is the number of leaves of the Merkle tree of the type, before mixing in a list length.
+func (t *sszType) chunkCount() uint64 {
+	switch t.kind {
+	case containerKind:
+		return uint64(len(t.fields))
+	case vectorKind, listKind:
+		if t.elem.kind == basicKind {
+			return (t.length*uint64(t.elem.size) + 31) / 32
+		}
+		return t.length
+	case bitlistKind:
+		return (t.length + 255) / 256
+	default:
+		return 1
+	}
+}
+
+func (t *sszType) field(name string) (*sszField, int, bool) {
+	for i, f := range t.fields {
+		if f.name == name {
+			return f, i, true
+		}
+	}
+	return nil, 0, false
+}
+
+// GeneralizedIndex returns the generalized index of the node at the path within the Merkle tree of the value,
+// which must be a protobuf generated SSZ container. The path is made of field names, as used in JSON, and
+// list or vector indices, e.g. "validators[3].effective_balance" or "historical_summaries.2". The LengthSegment
+// selects the length of a list. Elements of basic type are packed, so their generalized index is that of the
+// chunk containing them.
+func GeneralizedIndex(v interface{}, path string) (uint64, error) {
+	typ, err := typeOf(reflect.TypeOf(v), nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	gindex := uint64(1)
+	for _, segment := range splitPath(path) {
+		var pos, width uint64
+		var next *sszType
+		switch typ.kind {
+		case containerKind:
+			f, i, ok := typ.field(segment)
+			if !ok {
+				return 0, errors.Wrapf(ErrInvalidPath, "no field %s", segment)
+			}
+			pos, width, next = uint64(i), nextPowerOfTwo(typ.chunkCount()), f.typ
+		case vectorKind, listKind, bitlistKind:
+			if segment == LengthSegment {
+				if typ.kind == vectorKind {
+					return 0, errors.Wrap(ErrInvalidPath, "vectors have no length")
+				}
+				pos, width, next = 1, 2, &sszType{kind: basicKind, size: 8}
+				break
+			}
+			i, err := strconv.ParseUint(segment, 10, 64)
+			if err != nil || i >= typ.length {
+				return 0, errors.Wrapf(ErrInvalidPath, "index %s out of range", segment)
+			}
+			switch {
+			case typ.kind == bitlistKind:
+				pos, next = i/256, &sszType{kind: basicKind, size: 1}
+			case typ.elem.kind == basicKind:
+				pos, next = i*uint64(typ.elem.size)/32, typ.elem
+			default:
+				pos, next = i, typ.elem
+			}
+			width = nextPowerOfTwo(typ.chunkCount())
+			if typ.kind != vectorKind {
+				// Descend into the left subtree, the right one holding the length.
+				width *= 2
+			}
+		default:
+			return 0, errors.Wrapf(ErrInvalidPath, "cannot select %s in a basic value", segment)
+		}
+		hi, lo := bits.Mul64(gindex, width)
+		if hi != 0 || lo > math.MaxUint64-pos {
+			return 0, errors.Wrap(ErrInvalidPath, "generalized index overflows")
+		}
+		gindex, typ = lo+pos, next
+	}
+	return gindex, nil
+}
+
+func splitPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	path = strings.ReplaceAll(path, "]", "")
+	path = strings.ReplaceAll(path, "[", ".")
+	var segments []string
+	for _, s := range strings.Split(path, ".") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+func nextPowerOfTwo(v uint64) uint64 {
+	if v <= 1 {
+		return 1
+	}
+	return 1 << bits.Len64(v-1)
+}
+
+// FromValue returns the root node of the Merkle tree of a protobuf generated SSZ container.
+func FromValue(v interface{}) (Node, error) {
+	val := reflect.ValueOf(v)
+	typ, err := typeOf(val.Type(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return nodeOf(val, typ)
+}
+
+// FieldFromValue returns the node of the field at the given position of a protobuf generated SSZ container.
+func FieldFromValue(v interface{}, position int) (Node, error) {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	typ, err := typeOf(val.Type(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 || position >= len(typ.fields) {
+		return nil, errors.Wrapf(ErrNoChildren, "field position %d out of range", position)
+	}
+	f := typ.fields[position]
+	return nodeOf(val.Field(f.index), f.typ)
+}
+
+// ElementFromValue returns the node of an element of the list or vector field at the given position
+// of a protobuf generated SSZ container.
+func ElementFromValue(v interface{}, position int, index uint64) (Node, error) {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	typ, err := typeOf(val.Type(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 || position >= len(typ.fields) {
+		return nil, errors.Wrapf(ErrNoChildren, "field position %d out of range", position)
+	}
+	f := typ.fields[position]
+	if f.typ.kind != vectorKind && f.typ.kind != listKind {
+		return nil, errors.Errorf("field %s is not a list or vector", f.name)
+	}
+	fv := val.Field(f.index)
+	if index >= uint64(fv.Len()) {
+		return nil, errors.Wrapf(ErrNoChildren, "element %d of %s out of range", index, f.name)
+	}
+	return nodeOf(fv.Index(int(index)), f.typ.elem)
+}
+
+type hashRooter interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+func nodeOf(v reflect.Value, typ *sszType) (Node, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.New(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	switch typ.kind {
+	case basicKind:
+		var chunk [32]byte
+		putBasic(chunk[:], v, typ.size)
+		return Leaf(chunk), nil
+	case containerKind:
+		nodes := make([]Node, len(typ.fields))
+		roots := make([][32]byte, len(typ.fields))
+		for i, f := range typ.fields {
+			n, err := nodeOf(v.Field(f.index), f.typ)
+			if err != nil {
+				return nil, err
+			}
+			if roots[i], err = n.Root(); err != nil {
+				return nil, err
+			}
+			nodes[i] = n
+		}
+		return FromLeaves(roots, uint64(len(roots)), func(i uint64) (Node, error) {
+			return nodes[i], nil
+		})
+	case bitlistKind:
+		bl := bitfield.Bitlist(v.Bytes())
+		tree, err := FromLeaves(pack(bl.Bytes()), typ.chunkCount(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return MixIn(tree, bl.Len()), nil
+	}
+	var tree Node
+	var err error
+	if typ.elem.kind == basicKind {
+		tree, err = FromLeaves(pack(serializeBasic(v, typ.elem.size)), typ.chunkCount(), nil)
+	} else {
+		tree, err = compositeTree(v, typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if typ.kind == listKind {
+		return MixIn(tree, uint64(v.Len())), nil
+	}
+	return tree, nil
+}
+
+func compositeTree(v reflect.Value, typ *sszType) (Node, error) {
+	roots := make([][32]byte, v.Len())
+	for i := range roots {
+		ev := v.Index(i)
+		if hr, ok := ev.Interface().(hashRooter); ok && !(ev.Kind() == reflect.Ptr && ev.IsNil()) {
+			r, err := hr.HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			roots[i] = r
+			continue
+		}
+		n, err := nodeOf(ev, typ.elem)
+		if err != nil {
+			return nil, err
+		}
+		if roots[i], err = n.Root(); err != nil {
+			return nil, err
+		}
+	}
+	return FromLeaves(roots, typ.chunkCount(), func(i uint64) (Node, error) {
+		if i >= uint64(v.Len()) {
+			return nil, errors.Wrapf(ErrNoChildren, "element %d out of range", i)
+		}
+		return nodeOf(v.Index(int(i)), typ.elem)
+	})
+}
+
+func putBasic(dst []byte, v reflect.Value, size int) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			dst[0] = 1
+		}
+	default:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], v.Uint())
+		copy(dst[:size], buf[:size])
+	}
+}
+
+func serializeBasic(v reflect.Value, size int) []byte {
+	if size == 1 && v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return v.Bytes()
+	}
+	out := make([]byte, v.Len()*size)
+	for i := 0; i < v.Len(); i++ {
+		putBasic(out[i*size:(i+1)*size], v.Index(i), size)
+	}
+	return out
+}
+
+func pack(b []byte) [][32]byte {
+	chunks := make([][32]byte, (len(b)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], b[i*32:])
+	}
+	return chunks
+}
@@ -68,6 +68,15 @@ func (b PayloadIDBytes) MarshalJSON() ([]byte, error) {
 	return json.Marshal(hexutil.Bytes(b[:]))
 }
 
+// ClientVersionV1 identifies a client implementation in the engine_getClientVersionV1 endpoint
+// via JSON-RPC. Code is the two letter client code, and Commit the first four bytes of the git commit.
+type ClientVersionV1 struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
 // ExecutionBlock is the response kind received by the eth_getBlockByHash and
 // eth_getBlockByNumber endpoints via JSON-RPC.
 type ExecutionBlock struct {
@@ -30,3 +30,16 @@ func BlockFieldsFromBlob(blob blocks.ROBlob) logrus.Fields {
 		"parentRoot":    fmt.Sprintf("%#x", blob.ParentRoot()),
 	}
 }
+
+// DataColumnFields extracts a standard set of fields from a DataColumnSidecar into a logrus.Fields struct
+// which can be passed to log.WithFields.
+func DataColumnFields(column blocks.RODataColumn) logrus.Fields {
+	return logrus.Fields{
+		"slot":           column.Slot(),
+		"proposerIndex":  column.ProposerIndex(),
+		"blockRoot":      fmt.Sprintf("%#x", column.BlockRoot()),
+		"parentRoot":     fmt.Sprintf("%#x", column.ParentRoot()),
+		"kzgCommitments": len(column.KzgCommitments),
+		"index":          column.Index,
+	}
+}
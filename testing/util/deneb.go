@@ -32,6 +32,7 @@ type denebBlockGenerator struct {
 	proposer primitives.ValidatorIndex
 	valRoot  []byte
 	payload  *enginev1.ExecutionPayloadDeneb
+	commits  [][]byte
 }
 
 func WithProposerSigning(idx primitives.ValidatorIndex, sk bls.SecretKey, valRoot []byte) DenebBlockGeneratorOption {
@@ -109,8 +110,12 @@ func GenerateTestDenebBlockWithSidecar(t *testing.T, parent [32]byte, slot primi
 	commitments := make([][48]byte, g.nblobs)
 	block.Block.Body.BlobKzgCommitments = make([][]byte, g.nblobs)
 	for i := range commitments {
-		binary.LittleEndian.PutUint16(commitments[i][0:16], uint16(i))
-		binary.LittleEndian.PutUint16(commitments[i][16:32], uint16(g.slot))
+		if g.commits != nil {
+			copy(commitments[i][:], g.commits[i])
+		} else {
+			binary.LittleEndian.PutUint16(commitments[i][0:16], uint16(i))
+			binary.LittleEndian.PutUint16(commitments[i][16:32], uint16(g.slot))
+		}
 		block.Block.Body.BlobKzgCommitments[i] = commitments[i][:]
 	}
 
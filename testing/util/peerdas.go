@@ -0,0 +1,52 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// GenerateTestDenebBlockWithColumns generates a deneb block with nblobs valid blobs and KZG commitments,
+// along with the data column sidecars built from them.
+func GenerateTestDenebBlockWithColumns(t *testing.T, parent [32]byte, slot primitives.Slot, nblobs int, opts ...DenebBlockGeneratorOption) (blocks.ROBlock, []blocks.RODataColumn) {
+	require.NoError(t, kzg.Start())
+	blobs := make([][]byte, nblobs)
+	commitments := make([][]byte, nblobs)
+	for i := range blobs {
+		blobs[i] = validTestBlob(slot, i)
+		c, err := kzg.BlobToKZGCommitment(blobs[i])
+		require.NoError(t, err)
+		commitments[i] = c
+	}
+	opts = append(opts, func(g *denebBlockGenerator) {
+		g.commits = commitments
+	})
+	blk, _ := GenerateTestDenebBlockWithSidecar(t, parent, slot, nblobs, opts...)
+	sidecars, err := peerdas.DataColumnSidecars(blk, blobs)
+	require.NoError(t, err)
+	columns := make([]blocks.RODataColumn, len(sidecars))
+	for i := range sidecars {
+		columns[i], err = blocks.NewRODataColumnWithRoot(sidecars[i], blk.Root())
+		require.NoError(t, err)
+	}
+	return blk, columns
+}
+
+// validTestBlob returns a deterministic blob whose field elements are all canonical.
+func validTestBlob(slot primitives.Slot, index int) []byte {
+	blob := make([]byte, fieldparams.BlobLength)
+	seed := hash.Hash(append(bytesutil.Bytes8(uint64(slot)), bytesutil.Bytes8(uint64(index))...))
+	for i := 0; i < len(blob); i += 32 {
+		seed = hash.Hash(seed[:])
+		// Leaving the most significant byte empty keeps the element below the BLS modulus.
+		copy(blob[i+1:i+32], seed[:31])
+	}
+	return blob
+}
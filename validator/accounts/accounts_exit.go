@@ -16,6 +16,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	beacon_api "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
@@ -31,6 +32,8 @@ type PerformExitCfg struct {
 	RawPubKeys       [][]byte
 	FormattedPubKeys []string
 	OutputDirectory  string
+	// SigningAuditLog records the signatures of the exits, they are not recorded when it is nil.
+	SigningAuditLog *audit.Log
 }
 
 // Exit performs a voluntary exit on one or more accounts.
@@ -66,6 +69,7 @@ func (acm *CLIManager) Exit(ctx context.Context) error {
 		acm.rawPubKeys,
 		acm.formattedPubKeys,
 		acm.exitJSONOutputPath,
+		acm.signingAuditLog,
 	}
 	rawExitedKeys, trimmedExitedKeys, err := PerformVoluntaryExit(ctx, cfg)
 	if err != nil {
@@ -81,6 +85,7 @@ func PerformVoluntaryExit(
 	ctx context.Context, cfg PerformExitCfg,
 ) (rawExitedKeys [][]byte, formattedExitedKeys []string, err error) {
 	var rawNotExitedKeys [][]byte
+	sign := cfg.SigningAuditLog.Wrap(cfg.Keymanager.Sign)
 	genesisResponse, err := cfg.NodeClient.Genesis(ctx, &emptypb.Empty{})
 	if err != nil {
 		log.WithError(err).Errorf("voluntary exit failed: %v", err)
@@ -93,7 +98,7 @@ func PerformVoluntaryExit(
 			log.WithError(err).Errorf("voluntary exit failed: %v", err)
 		}
 		if len(cfg.OutputDirectory) > 0 {
-			sve, err := client.CreateSignedVoluntaryExit(ctx, cfg.ValidatorClient, cfg.Keymanager.Sign, key, epoch)
+			sve, err := client.CreateSignedVoluntaryExit(ctx, cfg.ValidatorClient, sign, key, epoch)
 			if err != nil {
 				rawNotExitedKeys = append(rawNotExitedKeys, key)
 				msg := err.Error()
@@ -106,7 +111,7 @@ func PerformVoluntaryExit(
 			} else if err := writeSignedVoluntaryExitJSON(sve, cfg.OutputDirectory); err != nil {
 				log.WithError(err).Error("failed to write voluntary exit")
 			}
-		} else if err := client.ProposeExit(ctx, cfg.ValidatorClient, cfg.Keymanager.Sign, key, epoch); err != nil {
+		} else if err := client.ProposeExit(ctx, cfg.ValidatorClient, sign, key, epoch); err != nil {
 			rawNotExitedKeys = append(rawNotExitedKeys, key)
 
 			msg := err.Error()
@@ -6,6 +6,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"google.golang.org/grpc"
 )
@@ -205,6 +206,14 @@ func WithExitJSONOutputPath(outputPath string) Option {
 	}
 }
 
+// WithSigningAuditLog provides the log recording the signatures of the voluntary exits.
+func WithSigningAuditLog(l *audit.Log) Option {
+	return func(acc *CLIManager) error {
+		acc.signingAuditLog = l
+		return nil
+	}
+}
+
 // WithWalletDir specifies the password for backups.
 func WithWalletDir(walletDir string) Option {
 	return func(acc *CLIManager) error {
@@ -4,13 +4,17 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/pkcs11"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 )
 
 // InitKeymanagerConfig defines configuration options for initializing a keymanager.
 type InitKeymanagerConfig struct {
 	ListenForChanges bool
 	Web3SignerConfig *remoteweb3signer.SetupConfig
+	PKCS11Config     *pkcs11.SetupConfig
+	ThresholdConfig  *threshold.SetupConfig
 }
 
 // Wallet defines a struct which has capabilities and knowledge of how
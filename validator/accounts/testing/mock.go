@@ -246,6 +246,11 @@ func (m *Validator) DeleteGraffiti(_ context.Context, _ [fieldparams.BLSPubkeyLe
 	return nil
 }
 
+// DutySchedule for mocking
+func (*Validator) DutySchedule(_ context.Context) ([]*iface2.Duty, error) {
+	return nil, nil
+}
+
 func (*Validator) StartEventStream(_ context.Context, _ []string, _ chan<- *event.Event) {
 	panic("implement me")
 }
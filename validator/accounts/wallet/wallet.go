@@ -18,7 +18,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/pkcs11"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -263,6 +265,22 @@ func NewWalletForWeb3Signer(cliCtx *cli.Context) *Wallet {
 	}
 }
 
+// NewWalletForPKCS11 returns a new wallet for a PKCS#11 token which is temporary and not stored locally.
+func NewWalletForPKCS11(cliCtx *cli.Context) *Wallet {
+	return &Wallet{
+		walletDir:      cliCtx.String(flags.WalletDirFlag.Name),
+		keymanagerKind: keymanager.PKCS11,
+	}
+}
+
+// NewWalletForThreshold returns a new wallet for key shares which is temporary and not stored locally.
+func NewWalletForThreshold(cliCtx *cli.Context) *Wallet {
+	return &Wallet{
+		walletDir:      cliCtx.String(flags.WalletDirFlag.Name),
+		keymanagerKind: keymanager.Threshold,
+	}
+}
+
 // OpenWallet instantiates a wallet from a specified path. It checks the
 // type of keymanager associated with the wallet by reading files in the wallet
 // path, if applicable. If a wallet does not exist, returns an appropriate error.
@@ -363,6 +381,22 @@ func (w *Wallet) InitializeKeymanager(ctx context.Context, cfg iface.InitKeymana
 		if err != nil {
 			return nil, errors.Wrap(err, "could not initialize web3signer keymanager")
 		}
+	case keymanager.PKCS11:
+		if cfg.PKCS11Config == nil {
+			return nil, errors.New("pkcs11 config is nil")
+		}
+		km, err = pkcs11.NewKeymanager(ctx, cfg.PKCS11Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not initialize pkcs11 keymanager")
+		}
+	case keymanager.Threshold:
+		if cfg.ThresholdConfig == nil {
+			return nil, errors.New("threshold config is nil")
+		}
+		km, err = threshold.NewKeymanager(ctx, cfg.ThresholdConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not initialize threshold keymanager")
+		}
 	default:
 		return nil, fmt.Errorf("keymanager kind not supported: %s", w.keymanagerKind)
 	}
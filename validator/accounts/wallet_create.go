@@ -55,6 +55,10 @@ func (acm *CLIManager) WalletCreate(ctx context.Context) (*wallet.Wallet, error)
 		)
 	case keymanager.Web3Signer:
 		return nil, errors.New("web3signer keymanager does not require persistent wallets.")
+	case keymanager.PKCS11:
+		return nil, errors.New("pkcs11 keymanager does not require persistent wallets.")
+	case keymanager.Threshold:
+		return nil, errors.New("threshold keymanager does not require persistent wallets.")
 	default:
 		return nil, errors.Wrapf(err, errKeymanagerNotSupported, w.KeymanagerKind())
 	}
@@ -0,0 +1,326 @@
+// Package audit implements a tamper-evident log of the signing requests of the validator client. Every record
+// holds the hash of the record before it, so that removing, reordering or editing a record breaks the chain.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// Outcome of a signing request.
+type Outcome string
+
+const (
+	// Signed is the outcome of a request signed by the keymanager.
+	Signed Outcome = "signed"
+	// Failed is the outcome of a request the keymanager could not sign.
+	Failed Outcome = "failed"
+	// Refused is the outcome of a signed request whose signature was discarded by slashing protection.
+	Refused Outcome = "refused"
+)
+
+// maxRecordSize is the maximum size in bytes of a record of the log.
+const maxRecordSize = 1 << 20
+
+// genesisHash is the previous hash of the first record of a log.
+var genesisHash = hex.EncodeToString(make([]byte, sha256.Size))
+
+// Record of a signing request, written as a line of JSON.
+type Record struct {
+	Index       uint64            `json:"index"`
+	Time        time.Time         `json:"time"`
+	PubKey      string            `json:"pubkey"`
+	SigningRoot string            `json:"signing_root"`
+	Domain      string            `json:"domain"`
+	Object      string            `json:"object"`
+	Slot        *primitives.Slot  `json:"slot,omitempty"`
+	Epoch       *primitives.Epoch `json:"epoch,omitempty"`
+	SourceEpoch *primitives.Epoch `json:"source_epoch,omitempty"`
+	Outcome     Outcome           `json:"outcome"`
+	Error       string            `json:"error,omitempty"`
+	PrevHash    string            `json:"prev_hash"`
+	Hash        string            `json:"hash"`
+}
+
+// RecordFromRequest returns the record of a signing request with the given outcome. The slot is the signing slot of
+// the request, and the epoch the epoch the signed object is for: the target epoch of attestations, the epoch of randao
+// reveals and exits, and the epoch of the signing slot otherwise.
+func RecordFromRequest(req *validatorpb.SignRequest, outcome Outcome, err error) *Record {
+	rec := &Record{
+		PubKey:      fmt.Sprintf("%#x", req.PublicKey),
+		SigningRoot: fmt.Sprintf("%#x", req.SigningRoot),
+		Domain:      fmt.Sprintf("%#x", req.SignatureDomain),
+		Object:      objectType(req),
+		Outcome:     outcome,
+	}
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	slot := req.SigningSlot
+	epoch := slots.ToEpoch(slot)
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_Registration:
+		// Registrations are not bound to a slot.
+		return rec
+	case *validatorpb.SignRequest_AttestationData:
+		if o.AttestationData != nil && o.AttestationData.Source != nil && o.AttestationData.Target != nil {
+			source := o.AttestationData.Source.Epoch
+			rec.SourceEpoch = &source
+			epoch = o.AttestationData.Target.Epoch
+		}
+	case *validatorpb.SignRequest_Epoch:
+		epoch = o.Epoch
+	case *validatorpb.SignRequest_Exit:
+		if o.Exit != nil {
+			epoch = o.Exit.Epoch
+		}
+	}
+	rec.Slot = &slot
+	rec.Epoch = &epoch
+	return rec
+}
+
+// objectType returns the name of the type of the object of a signing request, such as BlockDeneb or
+// AttestationData.
+func objectType(req *validatorpb.SignRequest) string {
+	if req.Object == nil {
+		return "Unknown"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", req.Object), "*validatorpb.SignRequest_")
+}
+
+// computeHash returns the hash of a record, which is the SHA-256 hash of its JSON encoding without the hash.
+func computeHash(rec *Record) (string, error) {
+	cpy := *rec
+	cpy.Hash = ""
+	enc, err := json.Marshal(&cpy)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(enc)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// Log is an append only, hash chained log of signing requests. It is safe for concurrent use.
+type Log struct {
+	path     string
+	f        *os.File
+	next     uint64
+	lastHash string
+	lock     sync.Mutex
+}
+
+// Open opens the log at the given path, creating it if needed. The chain of an existing log is verified, and
+// records are appended after its last record.
+func Open(path string) (*Log, error) {
+	if err := file.MkdirAll(filepath.Dir(path)); err != nil {
+		return nil, errors.Wrap(err, "could not create signing audit log directory")
+	}
+	l := &Log{path: path, lastHash: genesisHash}
+	if exists, err := file.Exists(path, file.Regular); err != nil {
+		return nil, err
+	} else if exists {
+		existing, err := os.Open(path) // #nosec G304
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open signing audit log")
+		}
+		count, lastHash, err := verify(existing, nil)
+		if closeErr := existing.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "signing audit log %s is corrupted", path)
+		}
+		l.next, l.lastHash = count, lastHash
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open signing audit log")
+	}
+	l.f = f
+	return l, nil
+}
+
+// Path of the log.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append sets the index, time and hashes of a record, and appends it to the log. The record is synced to disk before
+// Append returns.
+func (l *Log) Append(rec *Record) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.f == nil {
+		return errors.New("signing audit log is closed")
+	}
+	rec.Index = l.next
+	rec.Time = time.Now().UTC()
+	rec.PrevHash = l.lastHash
+	hash, err := computeHash(rec)
+	if err != nil {
+		return errors.Wrap(err, "could not hash signing audit record")
+	}
+	rec.Hash = hash
+	enc, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "could not encode signing audit record")
+	}
+	if _, err := l.f.Write(append(enc, '\n')); err != nil {
+		return errors.Wrap(err, "could not write signing audit record")
+	}
+	if err := l.f.Sync(); err != nil {
+		return errors.Wrap(err, "could not sync signing audit log")
+	}
+	l.next++
+	l.lastHash = hash
+	return nil
+}
+
+// SignFunc signs a request, as the Sign method of a keymanager.
+type SignFunc = func(context.Context, *validatorpb.SignRequest) (bls.Signature, error)
+
+// Wrap returns a signing function which records every request signed with the given function in the log. A
+// signature is not returned when its record cannot be written. Wrapping with a nil log returns the function as is.
+func (l *Log) Wrap(sign SignFunc) SignFunc {
+	if l == nil {
+		return sign
+	}
+	return func(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+		sig, err := sign(ctx, req)
+		outcome := Signed
+		if err != nil {
+			outcome = Failed
+		}
+		if auditErr := l.Append(RecordFromRequest(req, outcome, err)); auditErr != nil {
+			if err != nil {
+				log.WithError(auditErr).Error("Could not record failed signing request in signing audit log")
+				return nil, err
+			}
+			return nil, errors.Wrap(auditErr, "could not record signature in signing audit log")
+		}
+		return sig, err
+	}
+}
+
+// Refused records a request whose signature was discarded by slashing protection. It is a no-op on a nil log.
+func (l *Log) Refused(req *validatorpb.SignRequest, reason error) {
+	if l == nil {
+		return
+	}
+	if err := l.Append(RecordFromRequest(req, Refused, reason)); err != nil {
+		log.WithError(err).Error("Could not record refused signature in signing audit log")
+	}
+}
+
+// Close the log.
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// Filter selects records of a log. Empty fields match every record.
+type Filter struct {
+	PubKeys []string
+	Epoch   *primitives.Epoch
+}
+
+func (f *Filter) matches(rec *Record) bool {
+	if f == nil {
+		return true
+	}
+	if f.Epoch != nil && (rec.Epoch == nil || *rec.Epoch != *f.Epoch) {
+		return false
+	}
+	if len(f.PubKeys) == 0 {
+		return true
+	}
+	for _, pubKey := range f.PubKeys {
+		if strings.EqualFold(pubKey, rec.PubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify checks the hash chain of a log, and returns the records matching the filter. The error names the first
+// record which breaks the chain.
+func Verify(r io.Reader, filter *Filter) ([]*Record, error) {
+	var records []*Record
+	_, _, err := verify(r, func(rec *Record) {
+		if filter.matches(rec) {
+			records = append(records, rec)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// verify checks the hash chain of a log, calling fn for each record. It returns the number of records and the hash of
+// the last record.
+func verify(r io.Reader, fn func(*Record)) (uint64, string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxRecordSize)
+	var count uint64
+	prevHash := genesisHash
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		rec := &Record{}
+		if err := json.Unmarshal(line, rec); err != nil {
+			return 0, "", errors.Wrapf(err, "could not decode record %d", count)
+		}
+		if rec.Index != count {
+			return 0, "", fmt.Errorf("record %d has index %d", count, rec.Index)
+		}
+		if rec.PrevHash != prevHash {
+			return 0, "", fmt.Errorf("record %d does not follow the previous record", count)
+		}
+		hash, err := computeHash(rec)
+		if err != nil {
+			return 0, "", errors.Wrapf(err, "could not hash record %d", count)
+		}
+		if rec.Hash != hash {
+			return 0, "", fmt.Errorf("record %d has hash %s, but its content hashes to %s", count, rec.Hash, hash)
+		}
+		if fn != nil {
+			fn(rec)
+		}
+		prevHash = hash
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, "", errors.Wrapf(err, "could not read record %d", count)
+	}
+	return count, prevHash, nil
+}
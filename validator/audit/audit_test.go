@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func attestationRequest(pubKey byte, source, target primitives.Epoch) *validatorpb.SignRequest {
+	return &validatorpb.SignRequest{
+		PublicKey:       []byte{pubKey},
+		SigningRoot:     []byte{2},
+		SignatureDomain: []byte{3},
+		Object: &validatorpb.SignRequest_AttestationData{AttestationData: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: source},
+			Target: &ethpb.Checkpoint{Epoch: target},
+		}},
+		SigningSlot: primitives.Slot(target) * 32,
+	}
+}
+
+func TestRecordFromRequest(t *testing.T) {
+	rec := RecordFromRequest(attestationRequest(1, 4, 5), Signed, nil)
+	assert.Equal(t, "0x01", rec.PubKey)
+	assert.Equal(t, "0x02", rec.SigningRoot)
+	assert.Equal(t, "0x03", rec.Domain)
+	assert.Equal(t, "AttestationData", rec.Object)
+	assert.Equal(t, primitives.Slot(160), *rec.Slot)
+	assert.Equal(t, primitives.Epoch(5), *rec.Epoch)
+	assert.Equal(t, primitives.Epoch(4), *rec.SourceEpoch)
+	assert.Equal(t, "", rec.Error)
+
+	rec = RecordFromRequest(&validatorpb.SignRequest{
+		Object:      &validatorpb.SignRequest_Epoch{Epoch: 7},
+		SigningSlot: 230,
+	}, Failed, errors.New("remote signer is down"))
+	assert.Equal(t, "Epoch", rec.Object)
+	assert.Equal(t, primitives.Epoch(7), *rec.Epoch)
+	assert.Equal(t, "remote signer is down", rec.Error)
+
+	rec = RecordFromRequest(&validatorpb.SignRequest{
+		Object: &validatorpb.SignRequest_Registration{Registration: &ethpb.ValidatorRegistrationV1{}},
+	}, Signed, nil)
+	assert.Equal(t, "Registration", rec.Object)
+	assert.Equal(t, true, rec.Slot == nil)
+	assert.Equal(t, true, rec.Epoch == nil)
+}
+
+func TestLog_AppendAndVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "signing.log")
+	l, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(RecordFromRequest(attestationRequest(1, 0, 1), Signed, nil)))
+	require.NoError(t, l.Append(RecordFromRequest(attestationRequest(2, 0, 1), Signed, nil)))
+	require.NoError(t, l.Close())
+	require.ErrorContains(t, "closed", l.Append(RecordFromRequest(attestationRequest(1, 1, 2), Signed, nil)))
+
+	// Reopening the log continues the chain.
+	l, err = Open(path)
+	require.NoError(t, err)
+	require.NoError(t, l.Append(RecordFromRequest(attestationRequest(1, 1, 2), Refused, errors.New("slashable"))))
+	require.NoError(t, l.Close())
+
+	content, err := os.ReadFile(path) // #nosec G304
+	require.NoError(t, err)
+	records, err := Verify(bytes.NewReader(content), nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	for i, rec := range records {
+		assert.Equal(t, uint64(i), rec.Index)
+	}
+	assert.Equal(t, genesisHash, records[0].PrevHash)
+	assert.Equal(t, records[1].Hash, records[2].PrevHash)
+	assert.Equal(t, Refused, records[2].Outcome)
+
+	epoch := primitives.Epoch(1)
+	records, err = Verify(bytes.NewReader(content), &Filter{Epoch: &epoch})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	records, err = Verify(bytes.NewReader(content), &Filter{PubKeys: []string{"0x01"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(records))
+	records, err = Verify(bytes.NewReader(content), &Filter{PubKeys: []string{"0x01"}, Epoch: &epoch})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(records))
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+
+	// Editing a record breaks its hash.
+	tampered := strings.Replace(string(content), `"outcome":"refused"`, `"outcome":"signed"`, 1)
+	_, err = Verify(strings.NewReader(tampered), nil)
+	assert.ErrorContains(t, "record 2 has hash", err)
+
+	// Removing a record breaks the chain.
+	_, err = Verify(strings.NewReader(lines[0]+"\n"+lines[2]+"\n"), nil)
+	assert.ErrorContains(t, "record 1 has index 2", err)
+
+	// A corrupted log cannot be opened for appending.
+	require.NoError(t, os.WriteFile(path, []byte(tampered), 0600))
+	_, err = Open(path)
+	assert.ErrorContains(t, "is corrupted", err)
+}
+
+func TestLog_Wrap(t *testing.T) {
+	var nilLog *Log
+	signed := func(context.Context, *validatorpb.SignRequest) (bls.Signature, error) {
+		return bls.NewAggregateSignature(), nil
+	}
+	failing := func(context.Context, *validatorpb.SignRequest) (bls.Signature, error) {
+		return nil, errors.New("keymanager is locked")
+	}
+	_, err := nilLog.Wrap(signed)(context.Background(), attestationRequest(1, 0, 1))
+	require.NoError(t, err)
+	nilLog.Refused(attestationRequest(1, 0, 1), errors.New("slashable"))
+
+	path := filepath.Join(t.TempDir(), "signing.log")
+	l, err := Open(path)
+	require.NoError(t, err)
+	sig, err := l.Wrap(signed)(context.Background(), attestationRequest(1, 0, 1))
+	require.NoError(t, err)
+	assert.NotNil(t, sig)
+	_, err = l.Wrap(failing)(context.Background(), attestationRequest(1, 1, 2))
+	require.ErrorContains(t, "keymanager is locked", err)
+	l.Refused(attestationRequest(1, 1, 2), errors.New("slashable"))
+	require.NoError(t, l.Close())
+
+	// A signature is not returned when it cannot be recorded.
+	_, err = l.Wrap(signed)(context.Background(), attestationRequest(1, 2, 3))
+	require.ErrorContains(t, "could not record signature", err)
+
+	f, err := os.Open(path) // #nosec G304
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	records, err := Verify(f, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	assert.Equal(t, Signed, records[0].Outcome)
+	assert.Equal(t, Failed, records[1].Outcome)
+	assert.Equal(t, "keymanager is locked", records[1].Error)
+	assert.Equal(t, Refused, records[2].Outcome)
+}
@@ -185,7 +185,7 @@ func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fiel
 	if err != nil {
 		return nil, err
 	}
-	sig, err = v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -262,7 +262,7 @@ func (v *validator) aggregateAndProofSig(ctx context.Context, pubKey [fieldparam
 		signRequest.Object = &validatorpb.SignRequest_AggregateAttestationAndProof{AggregateAttestationAndProof: aggregate}
 	}
 
-	sig, err := v.km.Sign(ctx, signRequest)
+	sig, err := v.sign(ctx, signRequest)
 	if err != nil {
 		return nil, err
 	}
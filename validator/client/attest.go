@@ -112,7 +112,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 		}
 	}
 
-	_, signingRoot, err := v.domainAndSigningRoot(ctx, indexedAtt.GetData())
+	domain, signingRoot, err := v.domainAndSigningRoot(ctx, indexedAtt.GetData())
 	if err != nil {
 		log.WithError(err).Error("Could not get domain and signing root from attestation")
 		if v.emitAccountMetrics {
@@ -148,6 +148,13 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 			log.WithFields(
 				attestationLogFields(pubKey, indexedAtt),
 			).Debug("Attempted slashable attestation details")
+			v.signingAuditLog.Refused(&validatorpb.SignRequest{
+				PublicKey:       pubKey[:],
+				SigningRoot:     signingRoot[:],
+				SignatureDomain: domain.SignatureDomain,
+				Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+				SigningSlot:     slot,
+			}, err)
 			tracing.AnnotateError(span, err)
 			return
 		}
@@ -239,7 +246,7 @@ func (v *validator) signAtt(ctx context.Context, pubKey [fieldparams.BLSPubkeyLe
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
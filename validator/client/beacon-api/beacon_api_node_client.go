@@ -2,13 +2,17 @@ package beacon_api
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -23,6 +27,10 @@ type beaconApiNodeClient struct {
 	jsonRestHandler JsonRestHandler
 	genesisProvider GenesisProvider
 	healthTracker   *beacon.NodeHealthTracker
+	versionLock     sync.Mutex
+	version         *ethpb.Version
+	versionHost     string
+	versionExpiry   time.Time
 }
 
 func (c *beaconApiNodeClient) SyncStatus(ctx context.Context, _ *empty.Empty) (*ethpb.SyncStatus, error) {
@@ -79,7 +87,30 @@ func (c *beaconApiNodeClient) Genesis(ctx context.Context, _ *empty.Empty) (*eth
 	}, nil
 }
 
+// Version returns the version of the beacon node and, in the metadata, the versions of the beacon node and execution
+// clients. The versions only change when the clients are restarted, so the result is cached for an epoch per beacon
+// node host.
 func (c *beaconApiNodeClient) Version(ctx context.Context, _ *empty.Empty) (*ethpb.Version, error) {
+	c.versionLock.Lock()
+	defer c.versionLock.Unlock()
+
+	host := c.jsonRestHandler.Host()
+	if c.version != nil && c.versionHost == host && time.Now().Before(c.versionExpiry) {
+		return c.version, nil
+	}
+	version, err := c.fetchVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.version, c.versionHost, c.versionExpiry = version, host, time.Now().Add(epochDuration())
+	return version, nil
+}
+
+func epochDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)) * time.Second
+}
+
+func (c *beaconApiNodeClient) fetchVersion(ctx context.Context) (*ethpb.Version, error) {
 	var versionResponse structs.GetVersionResponse
 	if err := c.jsonRestHandler.Get(ctx, "/eth/v1/node/version", &versionResponse); err != nil {
 		return nil, err
@@ -89,8 +120,22 @@ func (c *beaconApiNodeClient) Version(ctx context.Context, _ *empty.Empty) (*eth
 		return nil, errors.New("empty version response")
 	}
 
+	// The v2 endpoint identifying the execution client is optional, older beacon nodes do not serve it.
+	var metadata string
+	var versionV2Response structs.GetVersionV2Response
+	if err := c.jsonRestHandler.Get(ctx, "/eth/v2/node/version", &versionV2Response); err != nil {
+		log.WithError(err).Debug("Could not get client versions")
+	} else if versionV2Response.Data != nil {
+		encoded, err := json.Marshal(versionV2Response.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode client versions")
+		}
+		metadata = string(encoded)
+	}
+
 	return &ethpb.Version{
-		Version: versionResponse.Data.Version,
+		Version:  versionResponse.Data.Version,
+		Metadata: metadata,
 	}, nil
 }
 
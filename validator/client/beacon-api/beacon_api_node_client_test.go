@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api/mock"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -227,13 +228,16 @@ func TestGetSyncStatus(t *testing.T) {
 
 func TestGetVersion(t *testing.T) {
 	const versionEndpoint = "/eth/v1/node/version"
+	const versionV2Endpoint = "/eth/v2/node/version"
 
 	testCases := []struct {
-		name                 string
-		restEndpointResponse structs.GetVersionResponse
-		restEndpointError    error
-		expectedResponse     *ethpb.Version
-		expectedError        string
+		name                   string
+		restEndpointResponse   structs.GetVersionResponse
+		restEndpointError      error
+		restV2EndpointResponse structs.GetVersionV2Response
+		restV2EndpointError    error
+		expectedResponse       *ethpb.Version
+		expectedError          string
 	}{
 		{
 			name:              "fails to query REST endpoint",
@@ -252,10 +256,29 @@ func TestGetVersion(t *testing.T) {
 					Version: "prysm/local",
 				},
 			},
+			restV2EndpointError: errors.New("404 NotFound"),
 			expectedResponse: &ethpb.Version{
 				Version: "prysm/local",
 			},
 		},
+		{
+			name: "returns client versions in metadata",
+			restEndpointResponse: structs.GetVersionResponse{
+				Data: &structs.Version{
+					Version: "prysm/local",
+				},
+			},
+			restV2EndpointResponse: structs.GetVersionV2Response{
+				Data: &structs.VersionV2{
+					BeaconNode:      &structs.ClientVersionV1{Code: "PM", Name: "Prysm", Version: "v5.1.0", Commit: "0x1a2b3c4d"},
+					ExecutionClient: &structs.ClientVersionV1{Code: "GE", Name: "Geth", Version: "1.14.0", Commit: "0xfa4ff922"},
+				},
+			},
+			expectedResponse: &ethpb.Version{
+				Version:  "prysm/local",
+				Metadata: `{"beacon_node":{"code":"PM","name":"Prysm","version":"v5.1.0","commit":"0x1a2b3c4d"},"execution_client":{"code":"GE","name":"Geth","version":"1.14.0","commit":"0xfa4ff922"}}`,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -266,6 +289,7 @@ func TestGetVersion(t *testing.T) {
 
 			var versionResponse structs.GetVersionResponse
 			jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 			jsonRestHandler.EXPECT().Get(
 				gomock.Any(),
 				versionEndpoint,
@@ -276,6 +300,19 @@ func TestGetVersion(t *testing.T) {
 				2,
 				testCase.restEndpointResponse,
 			)
+			if testCase.expectedResponse != nil {
+				var versionV2Response structs.GetVersionV2Response
+				jsonRestHandler.EXPECT().Get(
+					gomock.Any(),
+					versionV2Endpoint,
+					&versionV2Response,
+				).Return(
+					testCase.restV2EndpointError,
+				).SetArg(
+					2,
+					testCase.restV2EndpointResponse,
+				)
+			}
 
 			nodeClient := &beaconApiNodeClient{jsonRestHandler: jsonRestHandler}
 			version, err := nodeClient.Version(ctx, &emptypb.Empty{})
@@ -288,3 +325,32 @@ func TestGetVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersion_Cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	host := "http://localhost:3500"
+	jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().Host().DoAndReturn(func() string { return host }).AnyTimes()
+	jsonRestHandler.EXPECT().Get(gomock.Any(), "/eth/v1/node/version", gomock.Any()).Return(nil).SetArg(
+		2,
+		structs.GetVersionResponse{Data: &structs.Version{Version: "prysm/local"}},
+	).Times(2)
+	jsonRestHandler.EXPECT().Get(gomock.Any(), "/eth/v2/node/version", gomock.Any()).Return(errors.New("404 NotFound")).Times(2)
+
+	nodeClient := &beaconApiNodeClient{jsonRestHandler: jsonRestHandler}
+	want := &ethpb.Version{Version: "prysm/local"}
+	for i := 0; i < 2; i++ {
+		version, err := nodeClient.Version(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, version)
+	}
+
+	// The versions are queried again from another beacon node.
+	host = "http://localhost:3501"
+	version, err := nodeClient.Version(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, version)
+}
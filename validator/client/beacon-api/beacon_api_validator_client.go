@@ -92,7 +92,7 @@ func (c *beaconApiValidatorClient) BeaconBlock(ctx context.Context, in *ethpb.Bl
 	defer span.End()
 
 	return wrapInMetrics[*ethpb.GenericBeaconBlock]("BeaconBlock", func() (*ethpb.GenericBeaconBlock, error) {
-		return c.beaconBlock(ctx, in.Slot, in.RandaoReveal, in.Graffiti)
+		return c.beaconBlock(ctx, in)
 	})
 }
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	neturl "net/url"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
@@ -22,11 +23,24 @@ type abstractProduceBlockResponseJson struct {
 	Data    json.RawMessage `json:"data"`
 }
 
-func (c *beaconApiValidatorClient) beaconBlock(ctx context.Context, slot primitives.Slot, randaoReveal, graffiti []byte) (*ethpb.GenericBeaconBlock, error) {
+func (c *beaconApiValidatorClient) beaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	slot := in.Slot
 	queryParams := neturl.Values{}
-	queryParams.Add("randao_reveal", hexutil.Encode(randaoReveal))
-	if len(graffiti) > 0 {
-		queryParams.Add("graffiti", hexutil.Encode(graffiti))
+	queryParams.Add("randao_reveal", hexutil.Encode(in.RandaoReveal))
+	if len(in.Graffiti) > 0 {
+		queryParams.Add("graffiti", hexutil.Encode(in.Graffiti))
+	}
+	if in.BuilderMinBidGwei > 0 {
+		queryParams.Add("builder_min_bid_gwei", strconv.FormatUint(uint64(in.BuilderMinBidGwei), 10))
+	}
+	if in.BuilderMaxBidDifferenceGwei > 0 {
+		queryParams.Add("builder_max_bid_difference_gwei", strconv.FormatUint(uint64(in.BuilderMaxBidDifferenceGwei), 10))
+	}
+	for _, r := range in.BuilderRelays {
+		queryParams.Add("builder_relays", r)
+	}
+	if in.BuilderTimeoutMs > 0 {
+		queryParams.Add("builder_timeout_ms", strconv.FormatUint(in.BuilderTimeoutMs, 10))
 	}
 
 	var ver string
@@ -52,6 +52,7 @@ func TestValidatorStatus_Nominal(t *testing.T) {
 	).Times(1)
 
 	jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 	validatorClient := beaconApiValidatorClient{
 		stateValidatorsProvider: stateValidatorsProvider,
 		prysmChainClient: prysmChainClient{
@@ -167,6 +168,7 @@ func TestMultipleValidatorStatus_Nominal(t *testing.T) {
 	).Times(1)
 
 	jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 
 	// Expect node version endpoint call.
 	var nodeVersionResponse structs.GetVersionResponse
@@ -328,6 +330,7 @@ func TestGetValidatorsStatusResponse_Nominal_SomeActiveValidators(t *testing.T)
 	).Times(1)
 
 	jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 
 	// Expect node version endpoint call.
 	var nodeVersionResponse structs.GetVersionResponse
@@ -342,6 +345,15 @@ func TestGetValidatorsStatusResponse_Nominal_SomeActiveValidators(t *testing.T)
 		structs.GetVersionResponse{Data: &structs.Version{Version: "prysm/v0.0.1"}},
 	).Times(1)
 
+	var nodeVersionV2Response structs.GetVersionV2Response
+	jsonRestHandler.EXPECT().Get(
+		gomock.Any(),
+		"/eth/v2/node/version",
+		&nodeVersionV2Response,
+	).Return(
+		nil,
+	).Times(1)
+
 	var validatorCountResponse structs.GetValidatorCountResponse
 	jsonRestHandler.EXPECT().Get(
 		gomock.Any(),
@@ -476,6 +488,7 @@ func TestGetValidatorsStatusResponse_Nominal_NoActiveValidators(t *testing.T) {
 	).Times(1)
 
 	jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 
 	// Expect node version endpoint call.
 	var nodeVersionResponse structs.GetVersionResponse
@@ -715,6 +728,7 @@ func TestValidatorStatusResponse_InvalidData(t *testing.T) {
 				).Times(1)
 
 				jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+				jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 
 				// Expect node version endpoint call.
 				var nodeVersionResponse structs.GetVersionResponse
@@ -114,6 +114,7 @@ func TestGetValidatorCount(t *testing.T) {
 
 			ctx := context.Background()
 			jsonRestHandler := mock.NewMockJsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().Host().Return("http://localhost:3500").AnyTimes()
 
 			// Expect node version endpoint call.
 			var nodeVersionResponse structs.GetVersionResponse
@@ -127,6 +128,16 @@ func TestGetValidatorCount(t *testing.T) {
 				2,
 				test.versionResponse,
 			)
+			if test.versionEndpointError == nil {
+				var nodeVersionV2Response structs.GetVersionV2Response
+				jsonRestHandler.EXPECT().Get(
+					gomock.Any(),
+					"/eth/v2/node/version",
+					&nodeVersionV2Response,
+				).Return(
+					nil,
+				)
+			}
 
 			var validatorCountResponse structs.GetValidatorCountResponse
 			jsonRestHandler.EXPECT().Get(
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+// DutySchedule returns the upcoming duties of the validators of the client for the current and next epoch, from
+// the duties last fetched from the beacon node and sorted by slot. Duties of slots which already started are left
+// out, but for the sync committee duty of the current epoch. Attestation aggregation is taken from the selection
+// proofs computed when the duties were fetched, so the schedule never signs. Sync committee aggregation is decided
+// slot by slot with a request to the beacon node, and is not part of the schedule.
+func (v *validator) DutySchedule(ctx context.Context) ([]*iface.Duty, error) {
+	ctx, span := trace.StartSpan(ctx, "validator.DutySchedule")
+	defer span.End()
+
+	v.dutiesLock.RLock()
+	defer v.dutiesLock.RUnlock()
+	v.aggregatorsLock.RLock()
+	defer v.aggregatorsLock.RUnlock()
+
+	if v.duties == nil {
+		return nil, errors.New("duties are not known yet")
+	}
+
+	currentSlot := slots.CurrentSlot(v.genesisTime)
+	currentEpoch := slots.ToEpoch(currentSlot)
+	schedule := make([]*iface.Duty, 0)
+	for i, duties := range [][]*ethpb.DutiesResponse_Duty{v.duties.CurrentEpochDuties, v.duties.NextEpochDuties} {
+		epoch := currentEpoch + primitives.Epoch(i)
+		for _, duty := range duties {
+			if duty == nil || (duty.Status != ethpb.ValidatorStatus_ACTIVE && duty.Status != ethpb.ValidatorStatus_EXITING) {
+				continue
+			}
+			pubKey := bytesutil.ToBytes48(duty.PublicKey)
+			newDuty := func(role iface.ValidatorRole, start, end primitives.Slot) *iface.Duty {
+				return &iface.Duty{
+					PubKey:         pubKey,
+					ValidatorIndex: duty.ValidatorIndex,
+					Role:           role,
+					Epoch:          epoch,
+					StartSlot:      start,
+					EndSlot:        end,
+					Start:          slots.StartTime(v.genesisTime, start),
+					End:            slots.StartTime(v.genesisTime, end+1),
+				}
+			}
+
+			for _, proposerSlot := range duty.ProposerSlots {
+				if proposerSlot != 0 && proposerSlot >= currentSlot && slots.ToEpoch(proposerSlot) == epoch {
+					schedule = append(schedule, newDuty(iface.RoleProposer, proposerSlot, proposerSlot))
+				}
+			}
+
+			if duty.AttesterSlot >= currentSlot && slots.ToEpoch(duty.AttesterSlot) == epoch {
+				attester := newDuty(iface.RoleAttester, duty.AttesterSlot, duty.AttesterSlot)
+				attester.CommitteeIndex = duty.CommitteeIndex
+				schedule = append(schedule, attester)
+
+				if v.aggregators[attSelectionKey{slot: duty.AttesterSlot, index: duty.ValidatorIndex}] {
+					aggregatorDuty := newDuty(iface.RoleAggregator, duty.AttesterSlot, duty.AttesterSlot)
+					aggregatorDuty.CommitteeIndex = duty.CommitteeIndex
+					schedule = append(schedule, aggregatorDuty)
+				}
+			}
+
+			if duty.IsSyncCommittee {
+				start := slots.UnsafeEpochStart(epoch)
+				schedule = append(schedule, newDuty(iface.RoleSyncCommittee, start, start+params.BeaconConfig().SlotsPerEpoch-1))
+			}
+		}
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool {
+		a, b := schedule[i], schedule[j]
+		if a.StartSlot != b.StartSlot {
+			return a.StartSlot < b.StartSlot
+		}
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return bytes.Compare(a.PubKey[:], b.PubKey[:]) < 0
+	})
+	return schedule, nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+func TestDutySchedule(t *testing.T) {
+	v, _, validatorKey, finish := setup(t, false)
+	defer finish()
+	pubKey := validatorKey.PublicKey().Marshal()
+
+	_, err := v.DutySchedule(context.Background())
+	require.ErrorContains(t, "duties are not known yet", err)
+
+	// The chain is at slot 2.
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	v.genesisTime = uint64(time.Now().Unix()) - 2*secondsPerSlot
+	v.duties = &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:    1,
+				ProposerSlots:   []primitives.Slot{3},
+				PublicKey:       pubKey,
+				ValidatorIndex:  1,
+				Status:          ethpb.ValidatorStatus_ACTIVE,
+				IsSyncCommittee: true,
+			},
+			{
+				AttesterSlot: 4,
+				PublicKey:    bytesutil.PadTo([]byte{2}, 48),
+				Status:       ethpb.ValidatorStatus_PENDING,
+			},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				CommitteeIndex: 2,
+				AttesterSlot:   40,
+				PublicKey:      pubKey,
+				ValidatorIndex: 1,
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+		},
+	}
+	// Aggregators are found when the duties are fetched, the schedule does not sign.
+	v.aggregators = map[attSelectionKey]bool{{slot: 40, index: 1}: true}
+
+	schedule, err := v.DutySchedule(context.Background())
+	require.NoError(t, err)
+	roles := make([]iface.ValidatorRole, len(schedule))
+	for i, duty := range schedule {
+		roles[i] = duty.Role
+		assert.Equal(t, bytesutil.ToBytes48(pubKey), duty.PubKey)
+		assert.Equal(t, primitives.ValidatorIndex(1), duty.ValidatorIndex)
+	}
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleSyncCommittee, iface.RoleProposer, iface.RoleAttester, iface.RoleAggregator}, roles)
+
+	syncCommittee := schedule[0]
+	assert.Equal(t, primitives.Slot(0), syncCommittee.StartSlot)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch-1, syncCommittee.EndSlot)
+	assert.Equal(t, time.Duration(params.BeaconConfig().SlotsPerEpoch)*time.Duration(secondsPerSlot)*time.Second, syncCommittee.End.Sub(syncCommittee.Start))
+	assert.Equal(t, primitives.Slot(3), schedule[1].StartSlot)
+	assert.Equal(t, primitives.Epoch(1), schedule[2].Epoch)
+	assert.Equal(t, primitives.Slot(40), schedule[2].StartSlot)
+	assert.Equal(t, primitives.CommitteeIndex(2), schedule[3].CommitteeIndex)
+}
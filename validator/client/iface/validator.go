@@ -33,6 +33,41 @@ const (
 	RoleSyncCommitteeAggregator
 )
 
+// String returns the name of the role.
+func (r ValidatorRole) String() string {
+	switch r {
+	case RoleAttester:
+		return "attester"
+	case RoleProposer:
+		return "proposer"
+	case RoleAggregator:
+		return "aggregator"
+	case RoleSyncCommittee:
+		return "sync_committee"
+	case RoleSyncCommitteeAggregator:
+		return "sync_committee_aggregator"
+	default:
+		return "unknown"
+	}
+}
+
+// Duty is an upcoming duty of a validator of the client.
+type Duty struct {
+	PubKey         [fieldparams.BLSPubkeyLength]byte
+	ValidatorIndex primitives.ValidatorIndex
+	Role           ValidatorRole
+	Epoch          primitives.Epoch
+	// StartSlot and EndSlot are the first and last slots of the duty. They are equal, but for sync committee
+	// duties which last for the whole epoch.
+	StartSlot primitives.Slot
+	EndSlot   primitives.Slot
+	// CommitteeIndex is the committee of attester and aggregator duties.
+	CommitteeIndex primitives.CommitteeIndex
+	// Start and End are the times the first slot of the duty starts and its last slot ends.
+	Start time.Time
+	End   time.Time
+}
+
 // Validator interface defines the primary methods of a validator client.
 type Validator interface {
 	Done()
@@ -67,6 +102,7 @@ type Validator interface {
 	Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error)
 	SetGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error
 	DeleteGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error
+	DutySchedule(ctx context.Context) ([]*Duty, error)
 	HealthTracker() *beacon.NodeHealthTracker
 	Host() string
 	ChangeHost()
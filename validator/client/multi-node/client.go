@@ -0,0 +1,499 @@
+package multi_node
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/sirupsen/logrus"
+)
+
+// maxSyncDistance is the number of slots a node may lag behind the best head of all nodes and still be healthy.
+const maxSyncDistance = primitives.Slot(2)
+
+var _ iface.ValidatorClient = (*Client)(nil)
+
+// nodeTimeout is the time each node is given to answer a request sent to every node, so that a node which hangs
+// does not hold back the others. Attestation data must be agreed on within the first third of the slot.
+func nodeTimeout() time.Duration {
+	return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 3
+}
+
+// Client is a validator client which keeps clients open to several beacon nodes at once. Requests are sent to
+// the best node, ranked by health, sync distance and latency, and fall back to the next ones on failure.
+// Attestation data is requested from every node and only returned when a quorum of nodes agrees on its head
+// and target. Signed messages are broadcast to every node at the same time.
+type Client struct {
+	nodes   []*Node
+	quorum  int
+	timeout time.Duration
+	// pending tracks the broadcasts which are still running after broadcast returned.
+	pending sync.WaitGroup
+
+	lock      sync.RWMutex
+	ranked    []*Node
+	preferred string
+	eventNode *Node
+}
+
+// NewClient creates a client for the given beacon nodes. A quorum of 0 requires a majority of the nodes to agree
+// on the attestation data.
+func NewClient(nodes []*Node, quorum int) (*Client, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("no beacon nodes provided")
+	}
+	if quorum == 0 {
+		quorum = len(nodes)/2 + 1
+	}
+	if quorum < 0 || quorum > len(nodes) {
+		return nil, fmt.Errorf("quorum %d must be between 1 and the number of beacon nodes %d", quorum, len(nodes))
+	}
+	ranked := make([]*Node, len(nodes))
+	copy(ranked, nodes)
+	return &Client{
+		nodes:     nodes,
+		quorum:    quorum,
+		timeout:   nodeTimeout(),
+		ranked:    ranked,
+		preferred: nodes[0].Host,
+	}, nil
+}
+
+// Run checks the health of the beacon nodes every interval until the context is canceled.
+func (c *Client) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		c.CheckHealth(checkCtx)
+		cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth checks the health of every beacon node concurrently and ranks them.
+func (c *Client) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range c.nodes {
+		wg.Add(1)
+		go func(n *Node) {
+			defer wg.Done()
+			n.checkHealth(ctx)
+		}(n)
+	}
+	wg.Wait()
+	c.rank()
+}
+
+// rank orders the nodes with healthy nodes first, then by sync distance, preference and latency.
+func (c *Client) rank() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	healths := make(map[*Node]*nodeHealth, len(c.nodes))
+	best := primitives.Slot(0)
+	for _, n := range c.nodes {
+		h := n.health()
+		healths[n] = h
+		if h.checked && h.err == nil && h.headSlot > best {
+			best = h.headSlot
+		}
+	}
+	for _, n := range c.nodes {
+		h := healths[n]
+		if best > h.headSlot {
+			h.syncDistance = best - h.headSlot
+		}
+		healthy := 0.0
+		if h.healthy(maxSyncDistance) {
+			healthy = 1
+		}
+		beaconNodeHealthyGauge.WithLabelValues(n.Host).Set(healthy)
+		beaconNodeSyncDistanceGauge.WithLabelValues(n.Host).Set(float64(h.syncDistance))
+	}
+
+	ranked := make([]*Node, len(c.nodes))
+	copy(ranked, c.nodes)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		hi, hj := healths[ranked[i]], healths[ranked[j]]
+		if hi.healthy(maxSyncDistance) != hj.healthy(maxSyncDistance) {
+			return hi.healthy(maxSyncDistance)
+		}
+		if hi.syncDistance != hj.syncDistance {
+			return hi.syncDistance < hj.syncDistance
+		}
+		if pi, pj := ranked[i].Host == c.preferred, ranked[j].Host == c.preferred; pi != pj {
+			return pi
+		}
+		return hi.latency < hj.latency
+	})
+	if ranked[0] != c.ranked[0] {
+		h := healths[ranked[0]]
+		log.WithFields(logrus.Fields{
+			"host":         ranked[0].Host,
+			"healthy":      h.healthy(maxSyncDistance),
+			"syncDistance": h.syncDistance,
+			"latency":      h.latency,
+		}).Info("Switched best beacon node")
+	}
+	c.ranked = ranked
+}
+
+func (c *Client) rankedNodes() []*Node {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.ranked
+}
+
+// first sends a request to the nodes in rank order and returns the first successful response.
+func first[T any](ctx context.Context, c *Client, call func(iface.ValidatorClient) (T, error)) (T, error) {
+	var zero T
+	var err error
+	for _, n := range c.rankedNodes() {
+		var resp T
+		resp, err = call(n.Validator)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return zero, err
+		}
+		log.WithError(err).WithField("host", n.Host).Debug("Request to beacon node failed, trying the next one")
+	}
+	return zero, errors.Wrapf(err, "request failed on all %d beacon nodes", len(c.nodes))
+}
+
+// broadcast sends a request to every node concurrently and returns the response of the first node which
+// succeeded, without waiting for the others. Each node is given the node timeout to answer, also after
+// broadcast returned, so that slower nodes still receive the message. It only fails when every node failed.
+func broadcast[T any](ctx context.Context, c *Client, call func(context.Context, iface.ValidatorClient) (T, error)) (T, error) {
+	var zero T
+	ranked := c.rankedNodes()
+	type result struct {
+		resp T
+		err  error
+	}
+	results := make(chan result, len(ranked))
+	// The requests outlive the call when a node succeeds early, so they are only bounded by the node timeout.
+	nodesCtx := context.WithoutCancel(ctx)
+	for _, n := range ranked {
+		c.pending.Add(1)
+		go func(n *Node) {
+			defer c.pending.Done()
+			nodeCtx, cancel := context.WithTimeout(nodesCtx, c.timeout)
+			defer cancel()
+			resp, err := call(nodeCtx, n.Validator)
+			if err != nil {
+				beaconNodeBroadcastFailuresCount.WithLabelValues(n.Host).Inc()
+				log.WithError(err).WithField("host", n.Host).Debug("Could not broadcast to beacon node")
+			}
+			results <- result{resp: resp, err: err}
+		}(n)
+	}
+
+	var err error
+	for range ranked {
+		select {
+		case r := <-results:
+			if r.err == nil {
+				return r.resp, nil
+			}
+			err = r.err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+	return zero, errors.Wrapf(err, "could not broadcast to any of the %d beacon nodes", len(ranked))
+}
+
+type attestationDataKey struct {
+	beaconBlockRoot [32]byte
+	targetEpoch     primitives.Epoch
+	targetRoot      [32]byte
+}
+
+// AttestationData requests attestation data from every node and returns as soon as a quorum of nodes agree on
+// its head and target, with the data of the best ranked node of the group which answered. The requests to the
+// other nodes are then canceled. It fails once the quorum can no longer be reached, so that the validator does
+// not sign a vote only some of the nodes would make. Each node is given the node timeout to answer.
+func (c *Client) AttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	ranked := c.rankedNodes()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		node int
+		data *ethpb.AttestationData
+		err  error
+	}
+	results := make(chan result, len(ranked))
+	for i, n := range ranked {
+		go func(i int, n *Node) {
+			nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			data, err := n.Validator.AttestationData(nodeCtx, in)
+			results <- result{node: i, data: data, err: err}
+		}(i, n)
+	}
+
+	groups := make(map[attestationDataKey][]int)
+	resps := make([]*ethpb.AttestationData, len(ranked))
+	largest := 0
+	var err error
+	for received := 1; received <= len(ranked); received++ {
+		var r result
+		select {
+		case r = <-results:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if r.err != nil {
+			err = r.err
+			log.WithError(r.err).WithField("host", ranked[r.node].Host).Debug("Could not get attestation data from beacon node")
+		} else if r.data != nil && r.data.Target != nil {
+			key := attestationDataKey{
+				beaconBlockRoot: bytesutil.ToBytes32(r.data.BeaconBlockRoot),
+				targetEpoch:     r.data.Target.Epoch,
+				targetRoot:      bytesutil.ToBytes32(r.data.Target.Root),
+			}
+			resps[r.node] = r.data
+			groups[key] = append(groups[key], r.node)
+			largest = max(largest, len(groups[key]))
+			if len(groups[key]) >= c.quorum {
+				return resps[slices.Min(groups[key])], nil
+			}
+		}
+		// Stop waiting once the nodes which did not answer yet can no longer make a group reach the quorum.
+		if largest+len(ranked)-received < c.quorum {
+			break
+		}
+	}
+	if largest == 0 {
+		return nil, errors.Wrapf(err, "could not get attestation data from any of the %d beacon nodes", len(ranked))
+	}
+	attestationDataQuorumFailuresCount.Inc()
+	return nil, fmt.Errorf(
+		"only %d of %d beacon nodes agree on the head and target of slot %d, %d are required",
+		largest, len(ranked), in.Slot, c.quorum,
+	)
+}
+
+func (c *Client) Duties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.DutiesResponse, error) {
+		return v.Duties(ctx, in)
+	})
+}
+
+func (c *Client) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.DomainResponse, error) {
+		return v.DomainData(ctx, in)
+	})
+}
+
+func (c *Client) WaitForChainStart(ctx context.Context, in *empty.Empty) (*ethpb.ChainStartResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.ChainStartResponse, error) {
+		return v.WaitForChainStart(ctx, in)
+	})
+}
+
+func (c *Client) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.ValidatorIndexResponse, error) {
+		return v.ValidatorIndex(ctx, in)
+	})
+}
+
+func (c *Client) ValidatorStatus(ctx context.Context, in *ethpb.ValidatorStatusRequest) (*ethpb.ValidatorStatusResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.ValidatorStatusResponse, error) {
+		return v.ValidatorStatus(ctx, in)
+	})
+}
+
+func (c *Client) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.MultipleValidatorStatusResponse, error) {
+		return v.MultipleValidatorStatus(ctx, in)
+	})
+}
+
+func (c *Client) BeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.GenericBeaconBlock, error) {
+		return v.BeaconBlock(ctx, in)
+	})
+}
+
+func (c *Client) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*ethpb.ProposeResponse, error) {
+		return v.ProposeBeaconBlock(ctx, in)
+	})
+}
+
+func (c *Client) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*empty.Empty, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*empty.Empty, error) {
+		return v.PrepareBeaconProposer(ctx, in)
+	})
+}
+
+func (c *Client) FeeRecipientByPubKey(ctx context.Context, in *ethpb.FeeRecipientByPubKeyRequest) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.FeeRecipientByPubKeyResponse, error) {
+		return v.FeeRecipientByPubKey(ctx, in)
+	})
+}
+
+func (c *Client) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return v.ProposeAttestation(ctx, in)
+	})
+}
+
+func (c *Client) ProposeAttestationElectra(ctx context.Context, in *ethpb.AttestationElectra) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return v.ProposeAttestationElectra(ctx, in)
+	})
+}
+
+func (c *Client) SubmitAggregateSelectionProof(
+	ctx context.Context,
+	in *ethpb.AggregateSelectionRequest,
+	index primitives.ValidatorIndex,
+	committeeLength uint64,
+) (*ethpb.AggregateSelectionResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.AggregateSelectionResponse, error) {
+		return v.SubmitAggregateSelectionProof(ctx, in, index, committeeLength)
+	})
+}
+
+func (c *Client) SubmitAggregateSelectionProofElectra(
+	ctx context.Context,
+	in *ethpb.AggregateSelectionRequest,
+	index primitives.ValidatorIndex,
+	committeeLength uint64,
+) (*ethpb.AggregateSelectionElectraResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.AggregateSelectionElectraResponse, error) {
+		return v.SubmitAggregateSelectionProofElectra(ctx, in, index, committeeLength)
+	})
+}
+
+func (c *Client) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return v.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+}
+
+func (c *Client) SubmitSignedAggregateSelectionProofElectra(ctx context.Context, in *ethpb.SignedAggregateSubmitElectraRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return v.SubmitSignedAggregateSelectionProofElectra(ctx, in)
+	})
+}
+
+func (c *Client) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*ethpb.ProposeExitResponse, error) {
+		return v.ProposeExit(ctx, in)
+	})
+}
+
+func (c *Client) SubscribeCommitteeSubnets(
+	ctx context.Context,
+	in *ethpb.CommitteeSubnetsSubscribeRequest,
+	duties []*ethpb.DutiesResponse_Duty,
+) (*empty.Empty, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*empty.Empty, error) {
+		return v.SubscribeCommitteeSubnets(ctx, in, duties)
+	})
+}
+
+func (c *Client) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.DoppelGangerResponse, error) {
+		return v.CheckDoppelGanger(ctx, in)
+	})
+}
+
+func (c *Client) SyncMessageBlockRoot(ctx context.Context, in *empty.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.SyncMessageBlockRootResponse, error) {
+		return v.SyncMessageBlockRoot(ctx, in)
+	})
+}
+
+func (c *Client) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*empty.Empty, error) {
+		return v.SubmitSyncMessage(ctx, in)
+	})
+}
+
+func (c *Client) SyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+		return v.SyncSubcommitteeIndex(ctx, in)
+	})
+}
+
+func (c *Client) SyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) (*ethpb.SyncCommitteeContribution, error) {
+		return v.SyncCommitteeContribution(ctx, in)
+	})
+}
+
+func (c *Client) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*empty.Empty, error) {
+		return v.SubmitSignedContributionAndProof(ctx, in)
+	})
+}
+
+func (c *Client) SubmitValidatorRegistrations(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+	return broadcast(ctx, c, func(ctx context.Context, v iface.ValidatorClient) (*empty.Empty, error) {
+		return v.SubmitValidatorRegistrations(ctx, in)
+	})
+}
+
+func (c *Client) AggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) ([]iface.BeaconCommitteeSelection, error) {
+		return v.AggregatedSelections(ctx, selections)
+	})
+}
+
+func (c *Client) AggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return first(ctx, c, func(v iface.ValidatorClient) ([]iface.SyncCommitteeSelection, error) {
+		return v.AggregatedSyncSelections(ctx, selections)
+	})
+}
+
+// StartEventStream starts the event stream of the best node. The stream stays on that node until it is
+// started again.
+func (c *Client) StartEventStream(ctx context.Context, topics []string, eventsChannel chan<- *event.Event) {
+	n := c.rankedNodes()[0]
+	c.lock.Lock()
+	c.eventNode = n
+	c.lock.Unlock()
+	n.Validator.StartEventStream(ctx, topics, eventsChannel)
+}
+
+func (c *Client) EventStreamIsRunning() bool {
+	c.lock.RLock()
+	n := c.eventNode
+	c.lock.RUnlock()
+	return n != nil && n.Validator.EventStreamIsRunning()
+}
+
+// Host returns the host of the best node.
+func (c *Client) Host() string {
+	return c.rankedNodes()[0].Host
+}
+
+// SetHost makes the node with the given host preferred over other nodes with the same health and sync distance.
+func (c *Client) SetHost(host string) {
+	c.lock.Lock()
+	c.preferred = host
+	c.lock.Unlock()
+	c.rank()
+}
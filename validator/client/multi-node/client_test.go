@@ -0,0 +1,222 @@
+package multi_node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"go.uber.org/mock/gomock"
+)
+
+type testNode struct {
+	*Node
+	validator *validatormock.MockValidatorClient
+	chain     *validatormock.MockChainClient
+	node      *validatormock.MockNodeClient
+}
+
+func newTestNodes(ctrl *gomock.Controller, hosts ...string) ([]*testNode, []*Node) {
+	var testNodes []*testNode
+	var nodes []*Node
+	for _, host := range hosts {
+		tn := &testNode{
+			validator: validatormock.NewMockValidatorClient(ctrl),
+			chain:     validatormock.NewMockChainClient(ctrl),
+			node:      validatormock.NewMockNodeClient(ctrl),
+		}
+		tn.Node = &Node{Host: host, Validator: tn.validator, Chain: tn.chain, NodeClient: tn.node}
+		testNodes = append(testNodes, tn)
+		nodes = append(nodes, tn.Node)
+	}
+	return testNodes, nodes
+}
+
+func (tn *testNode) expectHealth(syncing bool, headSlot primitives.Slot, err error) {
+	tn.node.EXPECT().SyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: syncing}, err)
+	if err == nil {
+		tn.chain.EXPECT().ChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: headSlot}, nil)
+	}
+}
+
+func attestationData(head, target byte) *ethpb.AttestationData {
+	return &ethpb.AttestationData{
+		BeaconBlockRoot: bytesutil.PadTo([]byte{head}, 32),
+		Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte{target}, 32)},
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	_, nodes := newTestNodes(ctrl, "a", "b", "c")
+	c, err := NewClient(nodes, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.quorum)
+	assert.Equal(t, "a", c.Host())
+
+	_, err = NewClient(nodes, 4)
+	require.ErrorContains(t, "quorum 4 must be between 1", err)
+	_, err = NewClient(nil, 0)
+	require.ErrorContains(t, "no beacon nodes provided", err)
+}
+
+func TestClient_CheckHealth(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	testNodes, nodes := newTestNodes(ctrl, "down", "syncing", "lagging", "slow", "fast")
+	c, err := NewClient(nodes, 1)
+	require.NoError(t, err)
+
+	testNodes[0].expectHealth(false, 0, errors.New("connection refused"))
+	testNodes[1].expectHealth(true, 100, nil)
+	testNodes[2].expectHealth(false, 90, nil)
+	testNodes[3].expectHealth(false, 100, nil)
+	testNodes[4].expectHealth(false, 100, nil)
+	c.CheckHealth(ctx)
+	// Latency cannot be controlled, so only compare the nodes which are ranked by health and sync distance.
+	ranked := c.rankedNodes()
+	require.Equal(t, 5, len(ranked))
+	assert.Equal(t, "syncing", ranked[2].Host)
+	assert.Equal(t, "lagging", ranked[3].Host)
+	assert.Equal(t, "down", ranked[4].Host)
+
+	// The preferred node goes first among equally healthy nodes.
+	c.SetHost("fast")
+	assert.Equal(t, "fast", c.Host())
+	c.SetHost("slow")
+	assert.Equal(t, "slow", c.Host())
+}
+
+func TestClient_AttestationData(t *testing.T) {
+	ctx := context.Background()
+	req := &ethpb.AttestationDataRequest{Slot: 33}
+
+	t.Run("quorum reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		testNodes, nodes := newTestNodes(ctrl, "a", "b", "c")
+		c, err := NewClient(nodes, 2)
+		require.NoError(t, err)
+		testNodes[0].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(1, 1), nil)
+		testNodes[1].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(2, 1), nil)
+		testNodes[2].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(2, 1), nil)
+		data, err := c.AttestationData(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, attestationData(2, 1), data)
+	})
+	t.Run("quorum not reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		testNodes, nodes := newTestNodes(ctrl, "a", "b", "c")
+		c, err := NewClient(nodes, 2)
+		require.NoError(t, err)
+		testNodes[0].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(1, 1), nil)
+		testNodes[1].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(2, 1), nil)
+		testNodes[2].validator.EXPECT().AttestationData(gomock.Any(), req).Return(nil, errors.New("timeout"))
+		_, err = c.AttestationData(ctx, req)
+		require.ErrorContains(t, "only 1 of 3 beacon nodes agree", err)
+	})
+	t.Run("hung node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		testNodes, nodes := newTestNodes(ctrl, "a", "b", "c")
+		c, err := NewClient(nodes, 2)
+		require.NoError(t, err)
+		canceled := make(chan struct{})
+		testNodes[0].validator.EXPECT().AttestationData(gomock.Any(), req).DoAndReturn(
+			func(ctx context.Context, _ *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+				<-ctx.Done()
+				close(canceled)
+				return nil, ctx.Err()
+			})
+		testNodes[1].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(2, 1), nil)
+		testNodes[2].validator.EXPECT().AttestationData(gomock.Any(), req).Return(attestationData(2, 1), nil)
+		// The quorum is reached without the hung node, whose request is then canceled.
+		data, err := c.AttestationData(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, attestationData(2, 1), data)
+		<-canceled
+	})
+	t.Run("all nodes fail", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		testNodes, nodes := newTestNodes(ctrl, "a")
+		c, err := NewClient(nodes, 1)
+		require.NoError(t, err)
+		testNodes[0].validator.EXPECT().AttestationData(gomock.Any(), req).Return(nil, errors.New("timeout"))
+		_, err = c.AttestationData(ctx, req)
+		require.ErrorContains(t, "timeout", err)
+	})
+}
+
+func TestClient_First(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	testNodes, nodes := newTestNodes(ctrl, "a", "b")
+	c, err := NewClient(nodes, 1)
+	require.NoError(t, err)
+	req := &ethpb.DutiesRequest{Epoch: 1}
+	resp := &ethpb.DutiesResponse{}
+
+	testNodes[0].validator.EXPECT().Duties(gomock.Any(), req).Return(nil, errors.New("unavailable"))
+	testNodes[1].validator.EXPECT().Duties(gomock.Any(), req).Return(resp, nil)
+	got, err := c.Duties(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, resp, got)
+
+	testNodes[0].validator.EXPECT().Duties(gomock.Any(), req).Return(nil, errors.New("unavailable"))
+	testNodes[1].validator.EXPECT().Duties(gomock.Any(), req).Return(nil, errors.New("unavailable"))
+	_, err = c.Duties(ctx, req)
+	require.ErrorContains(t, "request failed on all 2 beacon nodes", err)
+}
+
+func TestClient_Broadcast(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	testNodes, nodes := newTestNodes(ctrl, "a", "b", "c")
+	c, err := NewClient(nodes, 1)
+	require.NoError(t, err)
+	att := &ethpb.Attestation{Data: attestationData(1, 1)}
+	resp := &ethpb.AttestResponse{AttestationDataRoot: make([]byte, 32)}
+
+	// Every node receives the attestation, and a single success is enough.
+	testNodes[0].validator.EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("unavailable"))
+	testNodes[1].validator.EXPECT().ProposeAttestation(gomock.Any(), att).Return(resp, nil)
+	testNodes[2].validator.EXPECT().ProposeAttestation(gomock.Any(), att).Return(resp, nil)
+	got, err := c.ProposeAttestation(ctx, att)
+	require.NoError(t, err)
+	assert.Equal(t, resp, got)
+	c.pending.Wait()
+
+	for _, tn := range testNodes {
+		tn.validator.EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("unavailable"))
+	}
+	_, err = c.ProposeAttestation(ctx, att)
+	require.ErrorContains(t, "could not broadcast to any of the 3 beacon nodes", err)
+}
+
+func TestClient_BroadcastHungNode(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	testNodes, nodes := newTestNodes(ctrl, "a", "b")
+	c, err := NewClient(nodes, 1)
+	require.NoError(t, err)
+	c.timeout = 50 * time.Millisecond
+	att := &ethpb.Attestation{Data: attestationData(1, 1)}
+	resp := &ethpb.AttestResponse{AttestationDataRoot: make([]byte, 32)}
+
+	testNodes[0].validator.EXPECT().ProposeAttestation(gomock.Any(), att).DoAndReturn(
+		func(ctx context.Context, _ *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	testNodes[1].validator.EXPECT().ProposeAttestation(gomock.Any(), att).Return(resp, nil)
+	// The first success is returned without waiting for the hung node, which times out on its own.
+	got, err := c.ProposeAttestation(ctx, att)
+	require.NoError(t, err)
+	assert.Equal(t, resp, got)
+	c.pending.Wait()
+}
@@ -0,0 +1,5 @@
+package multi_node
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "multi-node")
@@ -0,0 +1,36 @@
+package multi_node
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	beaconNodeHealthyGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "validator_beacon_node_healthy",
+			Help: "1 if the beacon node answered its last health check, is synced and close to the best head, 0 otherwise.",
+		},
+		[]string{"host"},
+	)
+	beaconNodeSyncDistanceGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "validator_beacon_node_sync_distance",
+			Help: "The number of slots between the head of the beacon node and the best head of all beacon nodes.",
+		},
+		[]string{"host"},
+	)
+	beaconNodeBroadcastFailuresCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "validator_beacon_node_broadcast_failures_total",
+			Help: "The number of signed messages which could not be broadcast to the beacon node.",
+		},
+		[]string{"host"},
+	)
+	attestationDataQuorumFailuresCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "validator_attestation_data_quorum_failures_total",
+			Help: "The number of times the beacon nodes did not reach a quorum on the attestation data.",
+		},
+	)
+)
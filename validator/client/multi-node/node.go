@@ -0,0 +1,80 @@
+package multi_node
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+// Node holds the clients of one beacon node along with its last known health.
+type Node struct {
+	Host       string
+	Validator  iface.ValidatorClient
+	Chain      iface.ChainClient
+	NodeClient iface.NodeClient
+
+	lock     sync.RWMutex
+	checked  bool
+	err      error
+	syncing  bool
+	headSlot primitives.Slot
+	latency  time.Duration
+}
+
+// nodeHealth is a snapshot of the health of a node, taken when ranking nodes.
+type nodeHealth struct {
+	checked      bool
+	err          error
+	syncing      bool
+	headSlot     primitives.Slot
+	syncDistance primitives.Slot
+	latency      time.Duration
+}
+
+// healthy returns whether the node answered its last health check, is not syncing and has a head close
+// enough to the best head of all nodes.
+func (h *nodeHealth) healthy(maxSyncDistance primitives.Slot) bool {
+	return h.checked && h.err == nil && !h.syncing && h.syncDistance <= maxSyncDistance
+}
+
+// checkHealth asks the node for its sync status and head, recording how long it took to answer.
+func (n *Node) checkHealth(ctx context.Context) {
+	start := time.Now()
+	var headSlot primitives.Slot
+	status, err := n.NodeClient.SyncStatus(ctx, &empty.Empty{})
+	if err != nil {
+		err = errors.Wrap(err, "could not get sync status")
+	} else {
+		head, headErr := n.Chain.ChainHead(ctx, &empty.Empty{})
+		if headErr != nil {
+			err = errors.Wrap(headErr, "could not get chain head")
+		} else {
+			headSlot = head.HeadSlot
+		}
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.checked = true
+	n.err = err
+	n.syncing = status != nil && status.Syncing
+	n.headSlot = headSlot
+	n.latency = time.Since(start)
+}
+
+func (n *Node) health() *nodeHealth {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return &nodeHealth{
+		checked:  n.checked,
+		err:      n.err,
+		syncing:  n.syncing,
+		headSlot: n.headSlot,
+		latency:  n.latency,
+	}
+}
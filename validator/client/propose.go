@@ -2,13 +2,16 @@ package client
 
 // Validator client proposer functions.
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/async"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
@@ -27,8 +30,10 @@ import (
 	prysmTime "github.com/prysmaticlabs/prysm/v5/time"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 const (
@@ -77,13 +82,16 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		// to produce the block.
 		log.WithError(err).Warn("Could not get graffiti")
 	}
+	g = v.renderGraffiti(ctx, g, slot, pubKey)
 
 	// Request block from beacon node
-	b, err := v.validatorClient.BeaconBlock(ctx, &ethpb.BlockRequest{
+	req := &ethpb.BlockRequest{
 		Slot:         slot,
 		RandaoReveal: randaoReveal,
 		Graffiti:     g,
-	})
+	}
+	v.setBuilderCircuitBreaker(req, pubKey)
+	b, err := v.validatorClient.BeaconBlock(ctx, req)
 	if err != nil {
 		log.WithField("slot", slot).WithError(err).Error("Failed to request block from beacon node")
 		if v.emitAccountMetrics {
@@ -121,6 +129,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot primitives.Slot, pubK
 		log.WithFields(
 			blockLogFields(pubKey, wb, nil),
 		).WithError(err).Error("Failed block slashing protection check")
+		v.recordRefusedBlock(ctx, pubKey, epoch, slot, wb, signingRoot, err)
 		if v.emitAccountMetrics {
 			ValidatorProposeFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -350,7 +359,7 @@ func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BL
 	if err != nil {
 		return nil, err
 	}
-	randaoReveal, err = v.km.Sign(ctx, &validatorpb.SignRequest{
+	randaoReveal, err = v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -385,7 +394,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [fieldparams.BLSPubkey
 	if err != nil {
 		return nil, [32]byte{}, err
 	}
-	sig, err := v.km.Sign(ctx, &validatorpb.SignRequest{
+	sig, err := v.sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     blockRoot[:],
 		SignatureDomain: domain.SignatureDomain,
@@ -398,6 +407,35 @@ func (v *validator) signBlock(ctx context.Context, pubKey [fieldparams.BLSPubkey
 	return sig.Marshal(), blockRoot, nil
 }
 
+// recordRefusedBlock records a block whose signature was discarded by slashing protection in the signing audit log.
+func (v *validator) recordRefusedBlock(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	epoch primitives.Epoch,
+	slot primitives.Slot,
+	b interfaces.ReadOnlyBeaconBlock,
+	signingRoot [32]byte,
+	reason error,
+) {
+	if v.signingAuditLog == nil {
+		return
+	}
+	req := &validatorpb.SignRequest{
+		PublicKey:   pubKey[:],
+		SigningRoot: signingRoot[:],
+		SigningSlot: slot,
+	}
+	// The domain and object were already computed to sign the block, so errors are not expected here. The refusal
+	// is recorded without them otherwise.
+	if domain, err := v.domainData(ctx, epoch, params.BeaconConfig().DomainBeaconProposer[:]); err == nil && domain != nil {
+		req.SignatureDomain = domain.SignatureDomain
+	}
+	if sro, err := b.AsSignRequestObject(); err == nil {
+		req.Object = sro
+	}
+	v.signingAuditLog.Refused(req, reason)
+}
+
 // Sign voluntary exit with proposer domain and private key.
 func signVoluntaryExit(
 	ctx context.Context,
@@ -441,6 +479,32 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
+// setBuilderCircuitBreaker sets the builder bid limits of the proposer settings for the validator public key
+// on the block request, so that the beacon node discards the builder bids which violate them. Settings for the
+// specific key take priority over the default settings.
+func (v *validator) setBuilderCircuitBreaker(req *ethpb.BlockRequest, pubKey [fieldparams.BLSPubkeyLength]byte) {
+	if v.proposerSettings == nil {
+		return
+	}
+	var config *proposer.BuilderConfig
+	if v.proposerSettings.DefaultConfig != nil {
+		config = v.proposerSettings.DefaultConfig.BuilderConfig
+	}
+	if v.proposerSettings.ProposeConfig != nil {
+		option, ok := v.proposerSettings.ProposeConfig[pubKey]
+		if ok && option != nil && option.BuilderConfig != nil {
+			config = option.BuilderConfig
+		}
+	}
+	if config == nil || !config.Enabled {
+		return
+	}
+	req.BuilderMinBidGwei = primitives.Gwei(config.MinBidGwei)
+	req.BuilderMaxBidDifferenceGwei = primitives.Gwei(config.MaxBidDifferenceGwei)
+	req.BuilderRelays = config.AllowedRelays
+	req.BuilderTimeoutMs = uint64(config.TimeoutMs)
+}
+
 // Graffiti gets the graffiti from cli or file for the validator public key.
 func (v *validator) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "validator.Graffiti")
@@ -508,6 +572,67 @@ func (v *validator) Graffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyL
 	return []byte{}, nil
 }
 
+// renderGraffiti expands graffiti templates with the proposal's runtime data. Graffiti which is not a
+// template is returned as is. A template which fails to render results in empty graffiti, so the raw
+// template does not end up on chain.
+func (v *validator) renderGraffiti(ctx context.Context, g []byte, slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) []byte {
+	raw := string(bytes.TrimRight(g, "\x00"))
+	if !graffiti.IsTemplate(raw) {
+		return g
+	}
+	rendered, err := graffiti.RenderTemplate(raw, v.graffitiTemplateData(ctx, slot, pubKey))
+	if err != nil {
+		log.WithError(err).Warn("Could not render graffiti template")
+		return []byte{}
+	}
+	return bytesutil.PadTo([]byte(rendered), 32)
+}
+
+// graffitiTemplateData collects the data available to graffiti templates. Data which cannot be
+// retrieved is left empty rather than failing the proposal.
+func (v *validator) graffitiTemplateData(ctx context.Context, slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) *graffiti.TemplateData {
+	data := &graffiti.TemplateData{
+		Epoch:  slots.ToEpoch(slot),
+		Slot:   slot,
+		Pubkey: hexutil.Encode(pubKey[:]),
+	}
+
+	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
+	if err != nil {
+		log.WithError(err).Debug("Could not get validator index for graffiti template")
+	} else {
+		data.ValidatorIndex = idx.Index
+	}
+
+	if v.nodeClient == nil {
+		return data
+	}
+	nodeVersion, err := v.nodeClient.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).Debug("Could not get client versions for graffiti template")
+		return data
+	}
+	if nodeVersion.Metadata == "" {
+		return data
+	}
+	versions := &structs.VersionV2{}
+	if err := json.Unmarshal([]byte(nodeVersion.Metadata), versions); err != nil {
+		log.WithError(err).Debug("Could not decode client versions for graffiti template")
+		return data
+	}
+	if versions.BeaconNode != nil {
+		data.CLClient = versions.BeaconNode.Code
+		data.CLVersion = versions.BeaconNode.Version
+		data.CLCommit = versions.BeaconNode.Commit
+	}
+	if versions.ExecutionClient != nil {
+		data.ELClient = versions.ExecutionClient.Code
+		data.ELVersion = versions.ExecutionClient.Version
+		data.ELCommit = versions.ExecutionClient.Commit
+	}
+	return data
+}
+
 func (v *validator) SetGraffiti(ctx context.Context, pubkey [fieldparams.BLSPubkeyLength]byte, graffiti []byte) error {
 	ctx, span := trace.StartSpan(ctx, "validator.SetGraffiti")
 	defer span.End()
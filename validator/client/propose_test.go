@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	testing2 "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -392,6 +395,55 @@ func TestProposeBlock_BlocksDoubleProposal(t *testing.T) {
 	}
 }
 
+func TestProposeBlock_RecordsSigningAudit(t *testing.T) {
+	validator, m, validatorKey, finish := setup(t, false)
+	defer finish()
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	auditPath := filepath.Join(t.TempDir(), "signing.log")
+	auditLog, err := audit.Open(auditPath)
+	require.NoError(t, err)
+	validator.signingAuditLog = auditLog
+
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(5).Add(2)
+	block0, block1 := util.NewBeaconBlock(), util.NewBeaconBlock()
+	block0.Block.Slot, block1.Block.Slot = slot, slot
+	block1.Block.Body.Graffiti = bytesutil.PadTo([]byte("someothergraffiti"), 32)
+	m.validatorClient.EXPECT().DomainData(gomock.Any(), gomock.Any()).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil).AnyTimes()
+	for _, b := range []*ethpb.SignedBeaconBlock{block0, block1} {
+		m.validatorClient.EXPECT().BeaconBlock(gomock.Any(), gomock.AssignableToTypeOf(&ethpb.BlockRequest{})).
+			Return(&ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: b.Block}}, nil)
+	}
+	m.validatorClient.EXPECT().ProposeBeaconBlock(gomock.Any(), gomock.AssignableToTypeOf(&ethpb.GenericSignedBeaconBlock{})).
+		Return(&ethpb.ProposeResponse{BlockRoot: make([]byte, 32)}, nil)
+
+	// The second block is a double proposal, which is refused by slashing protection.
+	validator.ProposeBlock(context.Background(), slot, pubKey)
+	validator.ProposeBlock(context.Background(), slot, pubKey)
+	require.NoError(t, auditLog.Close())
+
+	f, err := os.Open(auditPath) // #nosec G304
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	records, err := audit.Verify(f, nil)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(records))
+	for i, object := range []string{"Epoch", "Block", "Epoch", "Block", "Block"} {
+		assert.Equal(t, object, records[i].Object)
+		assert.Equal(t, fmt.Sprintf("%#x", pubKey), records[i].PubKey)
+		assert.Equal(t, slot, *records[i].Slot)
+	}
+	assert.Equal(t, audit.Signed, records[3].Outcome)
+	assert.Equal(t, audit.Refused, records[4].Outcome)
+	assert.NotEqual(t, records[1].SigningRoot, records[3].SigningRoot)
+	assert.Equal(t, records[3].SigningRoot, records[4].SigningRoot)
+	assert.Equal(t, records[3].Domain, records[4].Domain)
+	assert.StringContains(t, "could not sign block", records[4].Error)
+}
+
 func TestProposeBlock_BlocksDoubleProposal_After54KEpochs(t *testing.T) {
 	for _, isSlashingProtectionMinimal := range [...]bool{false, true} {
 		t.Run(fmt.Sprintf("SlashingProtectionMinimal:%v", isSlashingProtectionMinimal), func(t *testing.T) {
@@ -964,6 +1016,51 @@ func TestSignBellatrixBlock(t *testing.T) {
 	}
 }
 
+func TestSetBuilderCircuitBreaker(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
+	otherKey := [fieldparams.BLSPubkeyLength]byte{'b'}
+	disabledKey := [fieldparams.BLSPubkeyLength]byte{'c'}
+	v := &validator{
+		proposerSettings: &proposer.Settings{
+			ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*proposer.Option{
+				pubKey: {
+					BuilderConfig: &proposer.BuilderConfig{
+						Enabled:              true,
+						Relays:               []string{"https://other.example.com"},
+						AllowedRelays:        []string{"https://relay.example.com"},
+						MinBidGwei:           100,
+						MaxBidDifferenceGwei: 2000,
+						TimeoutMs:            500,
+					},
+				},
+				disabledKey: {
+					BuilderConfig: &proposer.BuilderConfig{Enabled: false, MinBidGwei: 100},
+				},
+			},
+			DefaultConfig: &proposer.Option{
+				BuilderConfig: &proposer.BuilderConfig{Enabled: true, MinBidGwei: 10},
+			},
+		},
+	}
+
+	req := &ethpb.BlockRequest{}
+	v.setBuilderCircuitBreaker(req, pubKey)
+	assert.DeepEqual(t, &ethpb.BlockRequest{
+		BuilderMinBidGwei:           100,
+		BuilderMaxBidDifferenceGwei: 2000,
+		BuilderRelays:               []string{"https://relay.example.com"},
+		BuilderTimeoutMs:            500,
+	}, req)
+
+	req = &ethpb.BlockRequest{}
+	v.setBuilderCircuitBreaker(req, otherKey)
+	assert.DeepEqual(t, &ethpb.BlockRequest{BuilderMinBidGwei: 10}, req)
+
+	req = &ethpb.BlockRequest{}
+	v.setBuilderCircuitBreaker(req, disabledKey)
+	assert.DeepEqual(t, &ethpb.BlockRequest{}, req)
+}
+
 func TestGetGraffiti_Ok(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := &mocks{
@@ -1273,3 +1370,46 @@ func Test_validator_SetGraffiti(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderGraffiti(t *testing.T) {
+	v, m, validatorKey, finish := setup(t, false)
+	defer finish()
+	v.nodeClient = m.nodeClient
+	pubKey := [fieldparams.BLSPubkeyLength]byte{}
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	slot := params.BeaconConfig().SlotsPerEpoch*3 + 1
+
+	t.Run("plain graffiti", func(t *testing.T) {
+		g := bytesutil.PadTo([]byte("Mr T was here"), 32)
+		assert.DeepEqual(t, g, v.renderGraffiti(context.Background(), g, slot, pubKey))
+	})
+	t.Run("template", func(t *testing.T) {
+		m.validatorClient.EXPECT().ValidatorIndex(
+			gomock.Any(),
+			&ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]},
+		).Return(&ethpb.ValidatorIndexResponse{Index: 42}, nil)
+		m.nodeClient.EXPECT().Version(gomock.Any(), gomock.Any()).Return(&ethpb.Version{
+			Version:  "Prysm/v5.1.0",
+			Metadata: `{"beacon_node":{"code":"PM","name":"Prysm","version":"v5.1.0","commit":"0x1a2b3c4d"},"execution_client":{"code":"GE","name":"Geth","version":"1.14.0","commit":"0xfa4ff922"}}`,
+		}, nil)
+
+		g := []byte("{{.ELClient}}{{.CLClient}} {{.ValidatorIndex}} {{.Epoch}} {{.Pubkey | short}}")
+		want := fmt.Sprintf("GEPM 42 3 %s", hexutil.Encode(pubKey[:4]))
+		assert.DeepEqual(t, bytesutil.PadTo([]byte(want), 32), v.renderGraffiti(context.Background(), g, slot, pubKey))
+	})
+	t.Run("template without client versions", func(t *testing.T) {
+		m.validatorClient.EXPECT().ValidatorIndex(gomock.Any(), gomock.Any()).Return(nil, errors.New("unknown index"))
+		m.nodeClient.EXPECT().Version(gomock.Any(), gomock.Any()).Return(&ethpb.Version{Version: "Prysm/v5.1.0"}, nil)
+
+		g := bytesutil.PadTo([]byte("{{.ELClient}}{{.CLClient}}{{.Slot}}"), 32)
+		assert.DeepEqual(t, bytesutil.PadTo([]byte("97"), 32), v.renderGraffiti(context.Background(), g, slot, pubKey))
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		m.validatorClient.EXPECT().ValidatorIndex(gomock.Any(), gomock.Any()).Return(&ethpb.ValidatorIndexResponse{Index: 42}, nil)
+		m.nodeClient.EXPECT().Version(gomock.Any(), gomock.Any()).Return(&ethpb.Version{Version: "Prysm/v5.1.0"}, nil)
+
+		assert.DeepEqual(t, []byte{}, v.renderGraffiti(context.Background(), []byte("{{.Unknown}}"), slot, pubKey))
+		require.LogsContain(t, hook, "Could not render graffiti template")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	prysmTrace "github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -104,6 +105,10 @@ func run(ctx context.Context, v iface.Validator) {
 			// Start fetching domain data for the next epoch.
 			if slots.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
+				// Keys changed outside of the validator client reach the accounts changed channel once discovered.
+				if refresher, ok := km.(keymanager.Refresher); ok {
+					go refreshKeys(ctx, refresher)
+				}
 			}
 
 			var wg sync.WaitGroup
@@ -136,6 +141,12 @@ func run(ctx context.Context, v iface.Validator) {
 	}
 }
 
+func refreshKeys(ctx context.Context, refresher keymanager.Refresher) {
+	if err := refresher.Refresh(ctx); err != nil {
+		log.WithError(err).Error("Could not refresh validator keys")
+	}
+}
+
 func onAccountsChanged(ctx context.Context, v iface.Validator, current [][48]byte, ac chan [][fieldparams.BLSPubkeyLength]byte) {
 	anyActive, err := v.HandleKeyReload(ctx, current)
 	if err != nil {
@@ -370,3 +370,37 @@ func TestUpdateProposerSettingsAt_EpochEndOk(t *testing.T) {
 	// can't test "Failed to update proposer settings" because of log.fatal
 	assert.LogsContain(t, hook, "Mock updated proposer settings")
 }
+
+type refreshingKeymanager struct {
+	*mockKeymanager
+	refreshed chan struct{}
+}
+
+func (km *refreshingKeymanager) Refresh(context.Context) error {
+	km.refreshed <- struct{}{}
+	return nil
+}
+
+func TestRefreshesKeysAtEpochEnd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	node := healthTesting.NewMockHealthClient(ctrl)
+	tracker := beacon.NewNodeHealthTracker(node)
+	node.EXPECT().IsHealthy(gomock.Any()).Return(true).AnyTimes()
+	_ = tracker.CheckHealth(context.Background())
+	km := &refreshingKeymanager{mockKeymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}, refreshed: make(chan struct{}, 2)}
+	v := &testutil.FakeValidator{Km: km, Tracker: tracker}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ticker := make(chan primitives.Slot)
+	v.NextSlotRet = ticker
+	go func() {
+		ticker <- params.BeaconConfig().SlotsPerEpoch + 1
+		ticker <- params.BeaconConfig().SlotsPerEpoch*2 - 1
+		<-km.refreshed
+		cancel()
+	}()
+
+	run(ctx, v)
+	require.Equal(t, 0, len(km.refreshed), "Expected keys to be refreshed only at the epoch end")
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
@@ -15,15 +16,18 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v5/api/grpc"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-chain-client-factory"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	multiNode "github.com/prysmaticlabs/prysm/v5/validator/client/multi-node"
 	nodeclientfactory "github.com/prysmaticlabs/prysm/v5/validator/client/node-client-factory"
 	validatorclientfactory "github.com/prysmaticlabs/prysm/v5/validator/client/validator-client-factory"
 	"github.com/prysmaticlabs/prysm/v5/validator/db"
@@ -31,7 +35,9 @@ import (
 	validatorHelpers "github.com/prysmaticlabs/prysm/v5/validator/helpers"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/pkcs11"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -51,12 +57,24 @@ type ValidatorService struct {
 	graffitiStruct          *graffiti.Graffiti
 	interopKeysConfig       *local.InteropKeymanagerConfig
 	web3SignerConfig        *remoteweb3signer.SetupConfig
+	pkcs11Config            *pkcs11.SetupConfig
+	thresholdConfig         *threshold.SetupConfig
+	signingAuditLog         *audit.Log
 	proposerSettings        *proposer.Settings
 	validatorsRegBatchSize  int
 	useWeb                  bool
 	emitAccountMetrics      bool
 	logValidatorPerformance bool
 	distributed             bool
+	multiBeaconNode         bool
+	beaconNodeQuorum        int
+	beaconNodeConns         []*beaconNodeConn
+}
+
+// beaconNodeConn is the gRPC connection to one of the beacon nodes used in multi beacon node mode.
+type beaconNodeConn struct {
+	host string
+	conn validatorHelpers.NodeConnection
 }
 
 // Config for the validator service.
@@ -77,12 +95,17 @@ type Config struct {
 	GraffitiStruct          *graffiti.Graffiti
 	InteropKmConfig         *local.InteropKeymanagerConfig
 	Web3SignerConfig        *remoteweb3signer.SetupConfig
+	PKCS11Config            *pkcs11.SetupConfig
+	ThresholdConfig         *threshold.SetupConfig
+	SigningAuditLog         *audit.Log
 	ProposerSettings        *proposer.Settings
 	ValidatorsRegBatchSize  int
 	UseWeb                  bool
 	LogValidatorPerformance bool
 	EmitAccountMetrics      bool
 	Distributed             bool
+	MultiBeaconNode         bool
+	BeaconNodeQuorum        int
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -100,12 +123,17 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		graffitiStruct:          cfg.GraffitiStruct,
 		interopKeysConfig:       cfg.InteropKmConfig,
 		web3SignerConfig:        cfg.Web3SignerConfig,
+		pkcs11Config:            cfg.PKCS11Config,
+		thresholdConfig:         cfg.ThresholdConfig,
+		signingAuditLog:         cfg.SigningAuditLog,
 		proposerSettings:        cfg.ProposerSettings,
 		validatorsRegBatchSize:  cfg.ValidatorsRegBatchSize,
 		useWeb:                  cfg.UseWeb,
 		emitAccountMetrics:      cfg.EmitAccountMetrics,
 		logValidatorPerformance: cfg.LogValidatorPerformance,
 		distributed:             cfg.Distributed,
+		multiBeaconNode:         cfg.MultiBeaconNode,
+		beaconNodeQuorum:        cfg.BeaconNodeQuorum,
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -133,6 +161,29 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		cfg.BeaconApiTimeout,
 	)
 
+	// Each beacon node gets its own connection, instead of sharing one which only uses the first reachable node.
+	// The REST API of a node, used for its event stream, is given at the same position as its gRPC endpoint.
+	if cfg.MultiBeaconNode && !features.Get().EnableBeaconRESTApi {
+		hosts := strings.Split(strings.ReplaceAll(cfg.BeaconNodeGRPCEndpoint, " ", ""), ",")
+		restHosts := strings.Split(strings.ReplaceAll(cfg.BeaconApiEndpoint, " ", ""), ",")
+		if len(restHosts) != len(hosts) {
+			return s, fmt.Errorf(
+				"%d beacon node REST API endpoints are given for %d beacon node gRPC endpoints, each beacon node needs its own",
+				len(restHosts), len(hosts),
+			)
+		}
+		for i, host := range hosts {
+			conn, err := grpc.DialContext(ctx, host, dialOpts...)
+			if err != nil {
+				return s, errors.Wrapf(err, "could not dial beacon node %s", host)
+			}
+			s.beaconNodeConns = append(s.beaconNodeConns, &beaconNodeConn{
+				host: host,
+				conn: validatorHelpers.NewNodeConnection(conn, restHosts[i], cfg.BeaconApiTimeout),
+			})
+		}
+	}
+
 	return s, nil
 }
 
@@ -178,6 +229,16 @@ func (v *ValidatorService) Start() {
 	)
 
 	validatorClient := validatorclientfactory.NewValidatorClient(v.conn, restHandler)
+	if v.multiBeaconNode {
+		multiNodeClient, nodeHosts, err := v.multiNodeClient(hosts)
+		if err != nil {
+			log.WithError(err).Error("Could not create multi beacon node client")
+			return
+		}
+		go multiNodeClient.Run(v.ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second)
+		validatorClient = multiNodeClient
+		hosts = nodeHosts
+	}
 
 	valStruct := &validator{
 		slotFeed:                       new(event.Feed),
@@ -200,6 +261,9 @@ func (v *ValidatorService) Start() {
 		db:                             v.db,
 		km:                             nil,
 		web3SignerConfig:               v.web3SignerConfig,
+		pkcs11Config:                   v.pkcs11Config,
+		thresholdConfig:                v.thresholdConfig,
+		signingAuditLog:                v.signingAuditLog,
 		proposerSettings:               v.proposerSettings,
 		signedValidatorRegistrations:   make(map[[fieldparams.BLSPubkeyLength]byte]*ethpb.SignedValidatorRegistrationV1),
 		validatorsRegBatchSize:         v.validatorsRegBatchSize,
@@ -221,10 +285,61 @@ func (v *ValidatorService) Start() {
 	go run(v.ctx, v.validator)
 }
 
+// multiNodeClient creates a validator client using every configured beacon node at once, along with the hosts
+// of the nodes. The REST API hosts are used with the REST API, and the gRPC endpoints otherwise.
+func (v *ValidatorService) multiNodeClient(restHosts []string) (*multiNode.Client, []string, error) {
+	var nodes []*multiNode.Node
+	if features.Get().EnableBeaconRESTApi {
+		for _, host := range restHosts {
+			restHandler := beaconApi.NewBeaconApiJsonRestHandler(
+				http.Client{Timeout: v.conn.GetBeaconApiTimeout()},
+				host,
+			)
+			nodes = append(nodes, newMultiNodeNode(host, v.conn, restHandler))
+		}
+	} else {
+		for _, c := range v.beaconNodeConns {
+			restHandler := beaconApi.NewBeaconApiJsonRestHandler(
+				http.Client{Timeout: c.conn.GetBeaconApiTimeout()},
+				c.conn.GetBeaconApiUrl(),
+			)
+			nodes = append(nodes, newMultiNodeNode(c.host, c.conn, restHandler))
+		}
+	}
+	client, err := multiNode.NewClient(nodes, v.beaconNodeQuorum)
+	if err != nil {
+		return nil, nil, err
+	}
+	hosts := make([]string, len(nodes))
+	for i, n := range nodes {
+		hosts[i] = n.Host
+	}
+	return client, hosts, nil
+}
+
+func newMultiNodeNode(host string, conn validatorHelpers.NodeConnection, restHandler beaconApi.JsonRestHandler) *multiNode.Node {
+	return &multiNode.Node{
+		Host:       host,
+		Validator:  validatorclientfactory.NewValidatorClient(conn, restHandler),
+		Chain:      beaconChainClientFactory.NewChainClient(conn, restHandler),
+		NodeClient: nodeclientfactory.NewNodeClient(conn, restHandler),
+	}
+}
+
 // Stop the validator service.
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	for _, c := range v.beaconNodeConns {
+		if err := c.conn.GetGrpcClientConn().Close(); err != nil {
+			log.WithError(err).WithField("host", c.host).Error("Could not close beacon node connection")
+		}
+	}
+	if v.signingAuditLog != nil {
+		if err := v.signingAuditLog.Close(); err != nil {
+			log.WithError(err).Error("Could not close signing audit log")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.GetGrpcClientConn().Close()
 	}
@@ -239,6 +354,11 @@ func (v *ValidatorService) Status() error {
 	return nil
 }
 
+// SigningAuditLog returns the signing audit log of the service, which is nil when it is not enabled.
+func (v *ValidatorService) SigningAuditLog() *audit.Log {
+	return v.signingAuditLog
+}
+
 // InteropKeysConfig returns the useInteropKeys flag.
 func (v *ValidatorService) InteropKeysConfig() *local.InteropKeymanagerConfig {
 	return v.interopKeysConfig
@@ -348,3 +468,11 @@ func (v *ValidatorService) DeleteGraffiti(ctx context.Context, pubKey [fieldpara
 	}
 	return v.validator.DeleteGraffiti(ctx, pubKey)
 }
+
+// DutySchedule returns the upcoming duties of the validators of the client.
+func (v *ValidatorService) DutySchedule(ctx context.Context) ([]*iface.Duty, error) {
+	if v.validator == nil {
+		return nil, errors.New("validator is unavailable")
+	}
+	return v.validator.DutySchedule(ctx)
+}
@@ -38,6 +38,23 @@ func TestNew_Insecure(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC connection")
 }
 
+func TestNew_MultiBeaconNodeRESTEndpoints(t *testing.T) {
+	cfg := &Config{
+		MultiBeaconNode:        true,
+		BeaconNodeGRPCEndpoint: "localhost:4000,localhost:4001",
+		BeaconApiEndpoint:      "http://localhost:3500",
+	}
+	_, err := NewValidatorService(context.Background(), cfg)
+	require.ErrorContains(t, "1 beacon node REST API endpoints are given for 2 beacon node gRPC endpoints", err)
+
+	cfg.BeaconApiEndpoint = "http://localhost:3500,http://localhost:3501"
+	s, err := NewValidatorService(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(s.beaconNodeConns))
+	assert.Equal(t, "http://localhost:3501", s.beaconNodeConns[1].conn.GetBeaconApiUrl())
+	require.NoError(t, s.Stop())
+}
+
 func TestStatus_NoConnectionError(t *testing.T) {
 	validatorService := &ValidatorService{}
 	assert.ErrorContains(t, "no connection", validatorService.Status())
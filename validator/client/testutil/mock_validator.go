@@ -52,6 +52,7 @@ type FakeValidator struct {
 	UpdateDutiesRet                   error
 	ProposerSettingsErr               error
 	RolesAtRet                        []iface.ValidatorRole
+	DutyScheduleRet                   []*iface.Duty
 	Balances                          map[[fieldparams.BLSPubkeyLength]byte]uint64
 	IndexToPubkeyMap                  map[uint64][fieldparams.BLSPubkeyLength]byte
 	PubkeyToIndexMap                  map[[fieldparams.BLSPubkeyLength]byte]uint64
@@ -308,6 +309,11 @@ func (fv *FakeValidator) DeleteGraffiti(_ context.Context, _ [fieldparams.BLSPub
 	return nil
 }
 
+// DutySchedule for mocking
+func (fv *FakeValidator) DutySchedule(_ context.Context) ([]*iface.Duty, error) {
+	return fv.DutyScheduleRet, nil
+}
+
 func (*FakeValidator) StartEventStream(_ context.Context, _ []string, _ chan<- *event.Event) {
 
 }
@@ -32,20 +32,25 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	accountsiface "github.com/prysmaticlabs/prysm/v5/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/db"
 	dbCommon "github.com/prysmaticlabs/prysm/v5/validator/db/common"
 	"github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/pkcs11"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
@@ -88,12 +93,17 @@ type validator struct {
 	prysmChainClient                   iface.PrysmChainClient
 	db                                 db.Database
 	km                                 keymanager.IKeymanager
+	signingAuditLog                    *audit.Log
 	web3SignerConfig                   *remoteweb3signer.SetupConfig
+	pkcs11Config                       *pkcs11.SetupConfig
+	thresholdConfig                    *threshold.SetupConfig
 	proposerSettings                   *proposer.Settings
 	signedValidatorRegistrations       map[[fieldparams.BLSPubkeyLength]byte]*ethpb.SignedValidatorRegistrationV1
 	validatorsRegBatchSize             int
 	interopKeysConfig                  *local.InteropKeymanagerConfig
 	attSelections                      map[attSelectionKey]iface.BeaconCommitteeSelection
+	aggregators                        map[attSelectionKey]bool
+	aggregatorSelections               map[aggregatorSelectionKey]bool
 	aggregatedSlotCommitteeIDCache     *lru.Cache
 	domainDataCache                    *ristretto.Cache
 	voteStats                          voteStats
@@ -111,6 +121,7 @@ type validator struct {
 	prevEpochBalancesLock              sync.RWMutex
 	blacklistedPubkeysLock             sync.RWMutex
 	attSelectionLock                   sync.Mutex
+	aggregatorsLock                    sync.RWMutex
 	dutiesLock                         sync.RWMutex
 }
 
@@ -125,9 +136,23 @@ type attSelectionKey struct {
 	index primitives.ValidatorIndex
 }
 
+// aggregatorSelectionKey identifies the aggregator selection of a validator for an attester duty. The committee
+// size is part of the key as it determines the selection modulo.
+type aggregatorSelectionKey struct {
+	slot           primitives.Slot
+	committeeIndex primitives.CommitteeIndex
+	committeeSize  int
+	pubKey         [fieldparams.BLSPubkeyLength]byte
+}
+
 // Done cleans up the validator.
 func (v *validator) Done() {
 	v.ticker.Done()
+	if closer, ok := v.km.(keymanager.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.WithError(err).Error("Could not close keymanager")
+		}
+	}
 }
 
 // WaitForKeymanagerInitialization checks if the validator needs to wait for keymanager initialization.
@@ -161,7 +186,12 @@ func (v *validator) WaitForKeymanagerInitialization(ctx context.Context) error {
 			if v.web3SignerConfig != nil {
 				v.web3SignerConfig.GenesisValidatorsRoot = genesisRoot
 			}
-			keyManager, err := v.wallet.InitializeKeymanager(ctx, accountsiface.InitKeymanagerConfig{ListenForChanges: true, Web3SignerConfig: v.web3SignerConfig})
+			keyManager, err := v.wallet.InitializeKeymanager(ctx, accountsiface.InitKeymanagerConfig{
+				ListenForChanges: true,
+				Web3SignerConfig: v.web3SignerConfig,
+				PKCS11Config:     v.pkcs11Config,
+				ThresholdConfig:  v.thresholdConfig,
+			})
 			if err != nil {
 				return errors.Wrap(err, "could not initialize key manager")
 			}
@@ -604,6 +634,13 @@ func (v *validator) subscribeToSubnets(ctx context.Context, duties *ethpb.Duties
 	subscribeIsAggregator := make([]bool, 0, len(duties.CurrentEpochDuties)+len(duties.NextEpochDuties))
 	activeDuties := make([]*ethpb.DutiesResponse_Duty, 0, len(duties.CurrentEpochDuties)+len(duties.NextEpochDuties))
 	alreadySubscribed := make(map[[64]byte]bool)
+	// The aggregators of the duties are kept for the duty schedule, which must not sign to find them.
+	aggregators := make(map[attSelectionKey]bool)
+	// Duties which were already checked are not signed again, only the selections of the current duties are kept.
+	v.aggregatorsLock.RLock()
+	previousSelections := v.aggregatorSelections
+	v.aggregatorsLock.RUnlock()
+	selections := make(map[aggregatorSelectionKey]bool)
 
 	if v.distributed {
 		// Get aggregated selection proofs to calculate isAggregator.
@@ -613,21 +650,21 @@ func (v *validator) subscribeToSubnets(ctx context.Context, duties *ethpb.Duties
 	}
 
 	for _, duty := range duties.CurrentEpochDuties {
-		pk := bytesutil.ToBytes48(duty.PublicKey)
 		if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
 			attesterSlot := duty.AttesterSlot
 			committeeIndex := duty.CommitteeIndex
 			validatorIndex := duty.ValidatorIndex
 
+			aggregator, err := v.dutyIsAggregator(ctx, duty, previousSelections, selections)
+			if err != nil {
+				return errors.Wrap(err, "could not check if a validator is an aggregator")
+			}
+			aggregators[attSelectionKey{slot: attesterSlot, index: validatorIndex}] = aggregator
+
 			alreadySubscribedKey := validatorSubnetSubscriptionKey(attesterSlot, committeeIndex)
 			if _, ok := alreadySubscribed[alreadySubscribedKey]; ok {
 				continue
 			}
-
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk, validatorIndex)
-			if err != nil {
-				return errors.Wrap(err, "could not check if a validator is an aggregator")
-			}
 			if aggregator {
 				alreadySubscribed[alreadySubscribedKey] = true
 			}
@@ -645,15 +682,16 @@ func (v *validator) subscribeToSubnets(ctx context.Context, duties *ethpb.Duties
 			committeeIndex := duty.CommitteeIndex
 			validatorIndex := duty.ValidatorIndex
 
+			aggregator, err := v.dutyIsAggregator(ctx, duty, previousSelections, selections)
+			if err != nil {
+				return errors.Wrap(err, "could not check if a validator is an aggregator")
+			}
+			aggregators[attSelectionKey{slot: attesterSlot, index: validatorIndex}] = aggregator
+
 			alreadySubscribedKey := validatorSubnetSubscriptionKey(attesterSlot, committeeIndex)
 			if _, ok := alreadySubscribed[alreadySubscribedKey]; ok {
 				continue
 			}
-
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey), validatorIndex)
-			if err != nil {
-				return errors.Wrap(err, "could not check if a validator is an aggregator")
-			}
 			if aggregator {
 				alreadySubscribed[alreadySubscribedKey] = true
 			}
@@ -665,6 +703,11 @@ func (v *validator) subscribeToSubnets(ctx context.Context, duties *ethpb.Duties
 		}
 	}
 
+	v.aggregatorsLock.Lock()
+	v.aggregators = aggregators
+	v.aggregatorSelections = selections
+	v.aggregatorsLock.Unlock()
+
 	_, err := v.validatorClient.SubscribeCommitteeSubnets(ctx,
 		&ethpb.CommitteeSubnetsSubscribeRequest{
 			Slots:        subscribeSlots,
@@ -788,8 +831,38 @@ func (v *validator) Keymanager() (keymanager.IKeymanager, error) {
 	return v.km, nil
 }
 
+// sign signs a request with the keymanager, recording it in the signing audit log if one is configured.
+func (v *validator) sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	return v.signingAuditLog.Wrap(v.km.Sign)(ctx, req)
+}
+
 // isAggregator checks if a validator is an aggregator of a given slot and committee,
 // it uses a modulo calculated by validator count in committee and samples randomness around it.
+// dutyIsAggregator returns whether the validator of an attester duty is an aggregator, reusing the selection
+// found for the same duty by a previous subscription. The selection is recorded in selections.
+func (v *validator) dutyIsAggregator(
+	ctx context.Context,
+	duty *ethpb.DutiesResponse_Duty,
+	previous, selections map[aggregatorSelectionKey]bool,
+) (bool, error) {
+	key := aggregatorSelectionKey{
+		slot:           duty.AttesterSlot,
+		committeeIndex: duty.CommitteeIndex,
+		committeeSize:  len(duty.Committee),
+		pubKey:         bytesutil.ToBytes48(duty.PublicKey),
+	}
+	aggregator, ok := previous[key]
+	if !ok {
+		var err error
+		aggregator, err = v.isAggregator(ctx, duty.Committee, duty.AttesterSlot, key.pubKey, duty.ValidatorIndex)
+		if err != nil {
+			return false, err
+		}
+	}
+	selections[key] = aggregator
+	return aggregator, nil
+}
+
 func (v *validator) isAggregator(
 	ctx context.Context,
 	committeeIndex []primitives.ValidatorIndex,
@@ -1121,7 +1194,7 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 	}); err != nil {
 		return err
 	}
-	signedRegReqs := v.buildSignedRegReqs(ctx, filteredKeys, km.Sign, slot, forceFullPush)
+	signedRegReqs := v.buildSignedRegReqs(ctx, filteredKeys, v.signingAuditLog.Wrap(km.Sign), slot, forceFullPush)
 	if len(signedRegReqs) > 0 {
 		go func() {
 			if err := SubmitValidatorRegistrations(ctx, v.validatorClient, signedRegReqs, v.validatorsRegBatchSize); err != nil {
@@ -1147,6 +1220,8 @@ func (v *validator) ProcessEvent(event *eventClient.Event) {
 		log.Error(string(event.Data))
 	case eventClient.EventConnectionError:
 		log.WithError(errors.New(string(event.Data))).Error("Event stream interrupted")
+	case eventClient.EventGap:
+		log.WithField("data", string(event.Data)).Warn("Events missed while the event stream was interrupted could not be replayed")
 	case eventClient.EventHead:
 		log.Debug("Received head event")
 		head := &structs.HeadEvent{}
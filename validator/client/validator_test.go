@@ -36,6 +36,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/testing/util"
 	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	dbTest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
@@ -662,6 +663,67 @@ func TestUpdateDuties_Distributed(t *testing.T) {
 	require.NoError(t, v.UpdateDuties(context.Background(), slot), "Could not update assignments")
 	util.WaitTimeout(&wg, 2*time.Second)
 	require.Equal(t, 2, len(v.attSelections))
+	// The aggregators of both epochs are kept for the duty schedule.
+	v.aggregatorsLock.RLock()
+	defer v.aggregatorsLock.RUnlock()
+	assert.DeepEqual(t, map[attSelectionKey]bool{
+		{slot: slot, index: 200}: true,
+		{slot: slot + params.BeaconConfig().SlotsPerEpoch, index: 200}: true,
+	}, v.aggregators)
+}
+
+func TestSubscribeToSubnets_ReusesAggregatorSelections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := validatormock.NewMockValidatorClient(ctrl)
+
+	slot := 2 * params.BeaconConfig().SlotsPerEpoch
+	keys := randKeypair(t)
+	duties := &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:   slot,
+				ValidatorIndex: 200,
+				CommitteeIndex: 100,
+				PublicKey:      keys.pub[:],
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:   slot + params.BeaconConfig().SlotsPerEpoch,
+				ValidatorIndex: 200,
+				CommitteeIndex: 100,
+				PublicKey:      keys.pub[:],
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+		},
+	}
+	v := validator{
+		km:              newMockKeymanager(t, keys),
+		validatorClient: client,
+	}
+
+	// The slots of the duties are only signed by the first subscription.
+	client.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(
+		&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)},
+		nil, /*err*/
+	).Times(2)
+	client.EXPECT().SubscribeCommitteeSubnets(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(nil, nil).Times(2)
+
+	require.NoError(t, v.subscribeToSubnets(context.Background(), duties))
+	require.NoError(t, v.subscribeToSubnets(context.Background(), duties))
+	v.aggregatorsLock.RLock()
+	defer v.aggregatorsLock.RUnlock()
+	require.Equal(t, 2, len(v.aggregatorSelections))
+	require.Equal(t, 2, len(v.aggregators))
 }
 
 func TestRolesAt_OK(t *testing.T) {
@@ -2900,3 +2962,20 @@ func TestUpdateValidatorStatusCache(t *testing.T) {
 		require.Equal(t, mockResponse.Indices[i], status.index)
 	}
 }
+
+type closingKeymanager struct {
+	*mockKeymanager
+	closed bool
+}
+
+func (km *closingKeymanager) Close() error {
+	km.closed = true
+	return nil
+}
+
+func TestValidator_DoneClosesKeymanager(t *testing.T) {
+	km := &closingKeymanager{mockKeymanager: &mockKeymanager{}}
+	v := &validator{ticker: slots.NewSlotTicker(time.Now(), 1), km: km}
+	v.Done()
+	require.Equal(t, true, km.closed)
+}
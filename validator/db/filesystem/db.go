@@ -246,9 +246,13 @@ func (s *Store) configuration() (*Configuration, error) {
 	// To get the same behavior as the BoltDB implementation, we need to convert empty array to nil.
 	if config.ProposerSettings != nil &&
 		config.ProposerSettings.DefaultConfig != nil &&
-		config.ProposerSettings.DefaultConfig.Builder != nil &&
-		len(config.ProposerSettings.DefaultConfig.Builder.Relays) == 0 {
-		config.ProposerSettings.DefaultConfig.Builder.Relays = nil
+		config.ProposerSettings.DefaultConfig.Builder != nil {
+		if len(config.ProposerSettings.DefaultConfig.Builder.Relays) == 0 {
+			config.ProposerSettings.DefaultConfig.Builder.Relays = nil
+		}
+		if len(config.ProposerSettings.DefaultConfig.Builder.AllowedRelays) == 0 {
+			config.ProposerSettings.DefaultConfig.Builder.AllowedRelays = nil
+		}
 	}
 
 	if config.ProposerSettings != nil && config.ProposerSettings.ProposerConfig != nil {
@@ -256,6 +260,9 @@ func (s *Store) configuration() (*Configuration, error) {
 			if option.Builder != nil && len(option.Builder.Relays) == 0 {
 				option.Builder.Relays = nil
 			}
+			if option.Builder != nil && len(option.Builder.AllowedRelays) == 0 {
+				option.Builder.AllowedRelays = nil
+			}
 		}
 	}
 
@@ -0,0 +1,240 @@
+// Package sqldb implements the validator client database over SQL, so that several validator clients can share
+// one slashing protection database. Every slashing protection check and the save which follows it run in a
+// single transaction, which makes them atomic across every validator client using the database.
+//
+// The database follows the EIP-3076 minimal slashing protection model: only the latest signed block slot and
+// the latest signed attestation source and target epochs are kept for each public key.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	"github.com/sirupsen/logrus"
+
+	// Registers the sqlite3 driver.
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const backupsDirectoryName = "backups"
+
+// Ensure the SQL store implements the interface.
+var _ = iface.ValidatorDB(&Store{})
+
+var log = logrus.WithField("prefix", "db")
+
+// schema is the SQL schema of the database. Public keys, roots and settings are stored as blobs and epochs
+// and slots as integers.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS metadata (
+		name TEXT PRIMARY KEY,
+		value BLOB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS slashing_protection (
+		pubkey BLOB PRIMARY KEY,
+		block_slot INTEGER,
+		block_signing_root BLOB,
+		source_epoch INTEGER,
+		target_epoch INTEGER,
+		attestation_signing_root BLOB
+	)`,
+	`CREATE TABLE IF NOT EXISTS blacklisted_pubkeys (
+		pubkey BLOB PRIMARY KEY
+	)`,
+}
+
+type (
+	// Store is a SQL implementation of the validator client database.
+	Store struct {
+		db           *sql.DB
+		dialect      *dialect
+		databasePath string
+	}
+
+	// Config represents store's config object.
+	Config struct {
+		PubKeys [][fieldparams.BLSPubkeyLength]byte
+	}
+
+	// dialect holds what differs between SQL engines. Queries are written with ? placeholders and standard
+	// upserts, so that adding an engine only requires a new dialect.
+	dialect struct {
+		driverName string
+		// dataSourceName returns the driver data source of the database at a location.
+		dataSourceName func(location string) string
+		// lockRowSuffix is appended to the select of the slashing protection of a public key in a transaction,
+		// for engines which do not lock the whole database when a write transaction starts.
+		lockRowSuffix string
+		// backupQuery writes a copy of the database to the path given as its only argument.
+		backupQuery string
+		// isFile is true when the location of the database is a file on the local filesystem.
+		isFile bool
+	}
+)
+
+// dialects maps the scheme of a database URL to its dialect.
+var dialects = map[string]*dialect{
+	// SQLite serializes writers with a lock on the database file. Transactions take that lock when they begin,
+	// so that two validator clients cannot read the same slashing protection before one of them saves.
+	// The busy timeout makes a client wait for the lock instead of failing when another one holds it.
+	"sqlite": {
+		driverName: "sqlite3",
+		dataSourceName: func(location string) string {
+			return fmt.Sprintf("file:%s?_busy_timeout=10000&_txlock=immediate", location)
+		},
+		backupQuery: "VACUUM INTO ?",
+		isFile:      true,
+	},
+}
+
+// NewStore opens the SQL database at a URL of the form <engine>://<location>, such as
+// sqlite:///mnt/shared/slashing-protection.sqlite, and creates its tables if needed.
+func NewStore(ctx context.Context, url string, config *Config) (*Store, error) {
+	scheme, location, ok := strings.Cut(url, "://")
+	if !ok || location == "" {
+		return nil, fmt.Errorf("database URL %s is not of the form <engine>://<location>", url)
+	}
+	d, ok := dialects[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database engine %s", scheme)
+	}
+
+	databasePath := location
+	if d.isFile {
+		expanded, err := file.ExpandPath(location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not expand path %s", location)
+		}
+		location = expanded
+		databasePath = filepath.Dir(expanded)
+		if err := file.MkdirAll(databasePath); err != nil {
+			return nil, errors.Wrapf(err, "could not create directory %s", databasePath)
+		}
+	}
+
+	db, err := sql.Open(d.driverName, d.dataSourceName(location))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open database")
+	}
+	s := &Store{db: db, dialect: d, databasePath: databasePath}
+	if err := s.createSchema(ctx); err != nil {
+		return nil, errors.Wrap(s.closeOnError(err), "could not create database schema")
+	}
+	if config != nil {
+		if err := s.UpdatePublicKeysBuckets(config.PubKeys); err != nil {
+			return nil, s.closeOnError(err)
+		}
+	}
+	return s, nil
+}
+
+func (s *Store) createSchema(ctx context.Context) error {
+	return s.update(ctx, func(tx *sql.Tx) error {
+		for _, statement := range schema {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store) closeOnError(err error) error {
+	if closeErr := s.db.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Could not close database")
+	}
+	return err
+}
+
+// update runs f in a transaction, which is committed if f succeeds and rolled back otherwise.
+func (s *Store) update(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	if err := f(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.WithError(rollbackErr).Error("Could not roll back transaction")
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// DatabasePath returns the directory of the database file, or the location of the database for engines
+// which are not file based.
+func (s *Store) DatabasePath() string {
+	return s.databasePath
+}
+
+// ClearDB removes every record of the database. The database itself is kept, as other validator clients
+// may be using it.
+func (s *Store) ClearDB() error {
+	return s.update(context.Background(), func(tx *sql.Tx) error {
+		for _, table := range []string{"metadata", "slashing_protection", "blacklisted_pubkeys"} {
+			if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+				return errors.Wrapf(err, "could not clear table %s", table)
+			}
+		}
+		return nil
+	})
+}
+
+// Backup writes a copy of the database to the backups directory of outputDir.
+func (s *Store) Backup(ctx context.Context, outputDir string, permissionOverride bool) error {
+	if s.dialect.backupQuery == "" {
+		return errors.New("backups are not supported by this database engine")
+	}
+	backupsDir, err := file.ExpandPath(filepath.Join(outputDir, backupsDirectoryName))
+	if err != nil {
+		return errors.Wrapf(err, "could not expand path %s", outputDir)
+	}
+	if err := file.HandleBackupDir(backupsDir, permissionOverride); err != nil {
+		return err
+	}
+	backupPath := filepath.Join(backupsDir, fmt.Sprintf("prysm_validatordb_%d.backup", time.Now().Unix()))
+	log.WithField("backup", backupPath).Info("Writing backup database")
+	if _, err := s.db.ExecContext(ctx, s.dialect.backupQuery, backupPath); err != nil {
+		return errors.Wrap(err, "could not write backup")
+	}
+	return os.Chmod(backupPath, 0600)
+}
+
+// UpdatePublicKeysBuckets creates an empty slashing protection record for each public key if needed.
+func (s *Store) UpdatePublicKeysBuckets(pubKeys [][fieldparams.BLSPubkeyLength]byte) error {
+	return s.update(context.Background(), func(tx *sql.Tx) error {
+		for _, pubKey := range pubKeys {
+			if _, err := tx.Exec(
+				"INSERT INTO slashing_protection (pubkey) VALUES (?) ON CONFLICT (pubkey) DO NOTHING",
+				pubKey[:],
+			); err != nil {
+				return errors.Wrapf(err, "could not insert public key %#x", pubKey)
+			}
+		}
+		return nil
+	})
+}
+
+// RunUpMigrations only exists to satisfy the interface, the schema is created when the database is opened.
+func (*Store) RunUpMigrations(_ context.Context) error {
+	return nil
+}
+
+// RunDownMigrations only exists to satisfy the interface.
+func (*Store) RunDownMigrations(_ context.Context) error {
+	return nil
+}
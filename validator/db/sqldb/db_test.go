@@ -0,0 +1,124 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func databaseURL(t *testing.T) string {
+	return "sqlite://" + filepath.Join(t.TempDir(), "shared", "slashing-protection.sqlite")
+}
+
+func setupDB(t *testing.T, url string, pubKeys [][fieldparams.BLSPubkeyLength]byte) *Store {
+	s, err := NewStore(context.Background(), url, &Config{PubKeys: pubKeys})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	return s
+}
+
+func pubKey(b byte) [fieldparams.BLSPubkeyLength]byte {
+	var k [fieldparams.BLSPubkeyLength]byte
+	k[0] = b
+	return k
+}
+
+func TestNewStore(t *testing.T) {
+	ctx := context.Background()
+	for _, url := range []string{"protection.sqlite", "sqlite://", "postgres://localhost/protection"} {
+		_, err := NewStore(ctx, url, nil)
+		assert.NotNil(t, err, url)
+	}
+
+	url := databaseURL(t)
+	s := setupDB(t, url, [][fieldparams.BLSPubkeyLength]byte{pubKey(1), pubKey(2)})
+	assert.Equal(t, filepath.Dir(url[len("sqlite://"):]), s.DatabasePath())
+	keys, err := s.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{pubKey(1), pubKey(2)}, keys)
+
+	// Opening the database again keeps its records.
+	other := setupDB(t, url, nil)
+	keys, err = other.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(keys))
+}
+
+func TestStore_ClearDB(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), [][fieldparams.BLSPubkeyLength]byte{pubKey(1)})
+	require.NoError(t, s.SaveGenesisValidatorsRoot(ctx, make([]byte, 32)))
+	require.NoError(t, s.SaveProposalHistoryForSlot(ctx, pubKey(1), 10, []byte{1}))
+
+	require.NoError(t, s.ClearDB())
+	keys, err := s.ProposedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(keys))
+	root, err := s.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(root))
+}
+
+func TestStore_Backup(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+	require.NoError(t, s.SaveProposalHistoryForSlot(ctx, pubKey(1), 10, []byte{1}))
+
+	outputDir := t.TempDir()
+	require.NoError(t, s.Backup(ctx, outputDir, true))
+	entries, err := os.ReadDir(filepath.Join(outputDir, backupsDirectoryName))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+
+	backup := setupDB(t, "sqlite://"+filepath.Join(outputDir, backupsDirectoryName, entries[0].Name()), nil)
+	slot, exists, err := backup.HighestSignedProposal(ctx, pubKey(1))
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(10), slot)
+}
+
+// Validator clients sharing a database must never both sign conflicting messages.
+func TestStore_ConcurrentClients(t *testing.T) {
+	ctx := context.Background()
+	url := databaseURL(t)
+	const clients = 4
+	stores := make([]*Store, clients)
+	for i := range stores {
+		stores[i] = setupDB(t, url, nil)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		signed  int
+		errored int
+	)
+	for i, s := range stores {
+		wg.Add(1)
+		go func(i int, s *Store) {
+			defer wg.Done()
+			err := s.SaveProposalHistoryForSlot(ctx, pubKey(1), 10, []byte(fmt.Sprintf("root %d", i)))
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				require.ErrorContains(t, "could not sign proposal", err)
+				errored++
+				return
+			}
+			signed++
+		}(i, s)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, signed)
+	assert.Equal(t, clients-1, errored)
+}
@@ -0,0 +1,113 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/common"
+	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+// ImportStandardProtectionJSON takes in EIP-3076 compliant JSON file used for slashing protection
+// by Ethereum validators and imports the latest signed proposal and attestation of each public key.
+func (s *Store) ImportStandardProtectionJSON(ctx context.Context, r io.Reader) error {
+	encodedJSON, err := io.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "could not read slashing protection JSON file")
+	}
+	interchangeJSON := &format.EIPSlashingProtectionFormat{}
+	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
+		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+	}
+	if interchangeJSON.Data == nil {
+		return nil
+	}
+	if err := helpers.ValidateMetadata(ctx, s, interchangeJSON); err != nil {
+		return errors.Wrap(err, "slashing protection JSON metadata was incorrect")
+	}
+
+	bar := common.InitializeProgressBar(len(interchangeJSON.Data), "Save blocks proposals and attestations:")
+	for _, item := range interchangeJSON.Data {
+		if err := bar.Add(1); err != nil {
+			return errors.Wrap(err, "could not update progress bar")
+		}
+		if item == nil {
+			continue
+		}
+		pubKeyBytes, err := hexutil.Decode(item.Pubkey)
+		if err != nil {
+			return errors.Wrap(err, "could not decode public key from hex")
+		}
+		if len(pubKeyBytes) != fieldparams.BLSPubkeyLength {
+			return errors.Errorf("public key %s has an invalid length", item.Pubkey)
+		}
+		pubKey := [fieldparams.BLSPubkeyLength]byte(pubKeyBytes)
+		if err := s.importProposals(ctx, pubKey, item); err != nil {
+			return errors.Wrap(err, "could not import block proposals")
+		}
+		if err := s.importAttestations(ctx, pubKey, item); err != nil {
+			return errors.Wrap(err, "could not import attestations")
+		}
+	}
+	return nil
+}
+
+// importProposals saves the highest slot of the signed blocks of an item, if it is above the saved one.
+func (s *Store) importProposals(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, item *format.ProtectionData) error {
+	var highest *primitives.Slot
+	for _, sb := range item.SignedBlocks {
+		if sb == nil {
+			continue
+		}
+		slot, err := helpers.SlotFromString(sb.Slot)
+		if err != nil {
+			return errors.Wrap(err, "could not convert slot to primitives.Slot")
+		}
+		if highest == nil || slot > *highest {
+			highest = &slot
+		}
+	}
+	if highest == nil {
+		return nil
+	}
+	err := s.SaveProposalHistoryForSlot(ctx, pubKey, *highest, []byte{})
+	if err != nil && !strings.Contains(err.Error(), "could not sign proposal") {
+		return errors.Wrap(err, "could not save proposal history from imported JSON to database")
+	}
+	return nil
+}
+
+func (s *Store) importAttestations(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, item *format.ProtectionData) error {
+	atts := make([]*ethpb.IndexedAttestation, 0, len(item.SignedAttestations))
+	for _, sa := range item.SignedAttestations {
+		if sa == nil {
+			continue
+		}
+		source, err := helpers.EpochFromString(sa.SourceEpoch)
+		if err != nil {
+			return errors.Wrap(err, "could not convert source epoch to primitives.Epoch")
+		}
+		target, err := helpers.EpochFromString(sa.TargetEpoch)
+		if err != nil {
+			return errors.Wrap(err, "could not convert target epoch to primitives.Epoch")
+		}
+		atts = append(atts, &ethpb.IndexedAttestation{
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: source},
+				Target: &ethpb.Checkpoint{Epoch: target},
+			},
+		})
+	}
+	if err := s.SaveAttestationsForPubKey(ctx, pubKey, nil, atts); err != nil {
+		return errors.Wrap(err, "could not save attestation record from imported JSON to database")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package sqldb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ImportStandardProtectionJSON(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+	key := pubKey(1)
+	interchange := fmt.Sprintf(`{
+		"metadata": {
+			"interchange_format_version": "5",
+			"genesis_validators_root": "0x0400000000000000000000000000000000000000000000000000000000000000"
+		},
+		"data": [{
+			"pubkey": "%#x",
+			"signed_blocks": [{"slot": "12"}, {"slot": "15"}],
+			"signed_attestations": [{"source_epoch": "2", "target_epoch": "3"}, {"source_epoch": "1", "target_epoch": "5"}]
+		}]
+	}`, key)
+	require.NoError(t, s.ImportStandardProtectionJSON(ctx, bytes.NewBufferString(interchange)))
+
+	slot, exists, err := s.HighestSignedProposal(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(15), slot)
+	history, err := s.AttestationHistoryForPubKey(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	assert.Equal(t, primitives.Epoch(2), history[0].Source)
+	assert.Equal(t, primitives.Epoch(5), history[0].Target)
+
+	// Importing a file of another network fails.
+	require.ErrorContains(t, "metadata was incorrect", s.ImportStandardProtectionJSON(
+		ctx,
+		bytes.NewBufferString(`{"metadata": {"interchange_format_version": "5", "genesis_validators_root": "0x05"}, "data": []}`),
+	))
+}
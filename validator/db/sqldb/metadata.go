@@ -0,0 +1,150 @@
+package sqldb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/proposer"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"google.golang.org/protobuf/proto"
+)
+
+// Names of the values of the metadata table.
+const (
+	genesisValidatorsRootName = "genesis_validators_root"
+	graffitiFileHashName      = "graffiti_file_hash"
+	graffitiOrderedIndexName  = "graffiti_ordered_index"
+	proposerSettingsName      = "proposer_settings"
+)
+
+// ErrNoProposerSettingsFound is an error thrown when no settings are found in the database.
+var ErrNoProposerSettingsFound = errors.New("no proposer settings found in bucket")
+
+func (s *Store) metadata(ctx context.Context, q queryer, name string) ([]byte, error) {
+	var value []byte
+	err := q.QueryRowContext(ctx, "SELECT value FROM metadata WHERE name = ?", name).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get %s", name)
+	}
+	return value, nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func saveMetadata(ctx context.Context, e execer, name string, value []byte) error {
+	_, err := e.ExecContext(
+		ctx,
+		"INSERT INTO metadata (name, value) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET value = excluded.value",
+		name, value,
+	)
+	return errors.Wrapf(err, "could not save %s", name)
+}
+
+// GenesisValidatorsRoot returns the genesis validators root of the network of the database.
+func (s *Store) GenesisValidatorsRoot(ctx context.Context) ([]byte, error) {
+	return s.metadata(ctx, s.db, genesisValidatorsRootName)
+}
+
+// SaveGenesisValidatorsRoot saves the genesis validators root of the network of the database.
+func (s *Store) SaveGenesisValidatorsRoot(ctx context.Context, genValRoot []byte) error {
+	if genValRoot == nil {
+		return nil
+	}
+	return s.update(ctx, func(tx *sql.Tx) error {
+		existing, err := s.metadata(ctx, tx, genesisValidatorsRootName)
+		if err != nil {
+			return err
+		}
+		if len(existing) != 0 && !bytes.Equal(existing, genValRoot) {
+			return errors.Errorf("cannot overwrite existing genesis validators root: %#x", existing)
+		}
+		return saveMetadata(ctx, tx, genesisValidatorsRootName, genValRoot)
+	})
+}
+
+// SaveGraffitiOrderedIndex saves the index of the next graffiti of the graffiti file.
+func (s *Store) SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error {
+	return saveMetadata(ctx, s.db, graffitiOrderedIndexName, binary.LittleEndian.AppendUint64(nil, index))
+}
+
+// GraffitiOrderedIndex returns the index of the next graffiti of the graffiti file. The index is reset when
+// the hash of the graffiti file changes.
+func (s *Store) GraffitiOrderedIndex(ctx context.Context, fileHash [32]byte) (uint64, error) {
+	var index uint64
+	err := s.update(ctx, func(tx *sql.Tx) error {
+		savedHash, err := s.metadata(ctx, tx, graffitiFileHashName)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(savedHash, fileHash[:]) {
+			if err := saveMetadata(ctx, tx, graffitiFileHashName, fileHash[:]); err != nil {
+				return err
+			}
+			return saveMetadata(ctx, tx, graffitiOrderedIndexName, binary.LittleEndian.AppendUint64(nil, 0))
+		}
+		b, err := s.metadata(ctx, tx, graffitiOrderedIndexName)
+		if err != nil {
+			return err
+		}
+		if len(b) == 8 {
+			index = binary.LittleEndian.Uint64(b)
+		}
+		return nil
+	})
+	return index, err
+}
+
+// GraffitiFileHash returns the hash of the graffiti file the ordered index refers to.
+func (s *Store) GraffitiFileHash() ([32]byte, bool, error) {
+	b, err := s.metadata(context.Background(), s.db, graffitiFileHashName)
+	if err != nil || b == nil {
+		return [32]byte{}, false, err
+	}
+	if len(b) != 32 {
+		return [32]byte{}, false, errors.New("invalid graffiti file hash length")
+	}
+	return [32]byte(b), true, nil
+}
+
+// ProposerSettings returns the proposer settings saved in the database.
+func (s *Store) ProposerSettings(ctx context.Context) (*proposer.Settings, error) {
+	b, err := s.metadata(ctx, s.db, proposerSettingsName)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, ErrNoProposerSettingsFound
+	}
+	payload := &validatorpb.ProposerSettingsPayload{}
+	if err := proto.Unmarshal(b, payload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposer settings")
+	}
+	return proposer.SettingFromConsensus(payload)
+}
+
+// ProposerSettingsExists returns whether proposer settings are saved in the database.
+func (s *Store) ProposerSettingsExists(ctx context.Context) (bool, error) {
+	b, err := s.metadata(ctx, s.db, proposerSettingsName)
+	return len(b) > 0, err
+}
+
+// SaveProposerSettings saves the proposer settings in the database.
+func (s *Store) SaveProposerSettings(ctx context.Context, settings *proposer.Settings) error {
+	if !settings.ShouldBeSaved() {
+		log.Warn("proposer settings are empty, nothing has been saved")
+		return nil
+	}
+	b, err := proto.Marshal(settings.ToConsensus())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal proposer settings")
+	}
+	return saveMetadata(ctx, s.db, proposerSettingsName, b)
+}
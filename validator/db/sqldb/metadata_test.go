@@ -0,0 +1,33 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_GenesisValidatorsRoot(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+	root, err := s.GenesisValidatorsRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(root))
+
+	require.NoError(t, s.SaveGenesisValidatorsRoot(ctx, []byte{1}))
+	require.NoError(t, s.SaveGenesisValidatorsRoot(ctx, []byte{1}))
+	require.ErrorContains(t, "cannot overwrite existing genesis validators root", s.SaveGenesisValidatorsRoot(ctx, []byte{2}))
+
+	index, err := s.GraffitiOrderedIndex(ctx, [32]byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), index)
+	require.NoError(t, s.SaveGraffitiOrderedIndex(ctx, 3))
+	index, err = s.GraffitiOrderedIndex(ctx, [32]byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), index)
+	// A new graffiti file resets the index.
+	index, err = s.GraffitiOrderedIndex(ctx, [32]byte{2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), index)
+}
@@ -0,0 +1,378 @@
+package sqldb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/common"
+)
+
+const failedAttLocalProtectionErr = "attempted to make slashable attestation, rejected by local slashing protection"
+
+// protection is the slashing protection record of a public key. Fields are nil when nothing was signed.
+type protection struct {
+	blockSlot              sql.NullInt64
+	blockSigningRoot       []byte
+	sourceEpoch            sql.NullInt64
+	targetEpoch            sql.NullInt64
+	attestationSigningRoot []byte
+}
+
+// queryer is implemented by both the database and its transactions.
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// protection returns the slashing protection record of a public key, or nil if there is none. Within a
+// transaction, the record stays locked until the transaction ends.
+func (s *Store) protection(ctx context.Context, q queryer, pubKey [fieldparams.BLSPubkeyLength]byte) (*protection, error) {
+	p := &protection{}
+	err := q.QueryRowContext(
+		ctx,
+		`SELECT block_slot, block_signing_root, source_epoch, target_epoch, attestation_signing_root
+		FROM slashing_protection WHERE pubkey = ?`+s.dialect.lockRowSuffix,
+		pubKey[:],
+	).Scan(&p.blockSlot, &p.blockSigningRoot, &p.sourceEpoch, &p.targetEpoch, &p.attestationSigningRoot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get slashing protection of %#x", pubKey)
+	}
+	return p, nil
+}
+
+func saveProposal(ctx context.Context, tx *sql.Tx, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot []byte) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO slashing_protection (pubkey, block_slot, block_signing_root) VALUES (?, ?, ?)
+		ON CONFLICT (pubkey) DO UPDATE SET block_slot = excluded.block_slot, block_signing_root = excluded.block_signing_root`,
+		pubKey[:], int64(slot), signingRoot,
+	)
+	return errors.Wrap(err, "could not save proposal")
+}
+
+func saveAttestation(
+	ctx context.Context,
+	tx *sql.Tx,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	source, target primitives.Epoch,
+	signingRoot []byte,
+) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO slashing_protection (pubkey, source_epoch, target_epoch, attestation_signing_root) VALUES (?, ?, ?, ?)
+		ON CONFLICT (pubkey) DO UPDATE SET source_epoch = excluded.source_epoch, target_epoch = excluded.target_epoch,
+		attestation_signing_root = excluded.attestation_signing_root`,
+		pubKey[:], int64(source), int64(target), signingRoot,
+	)
+	return errors.Wrap(err, "could not save attestation")
+}
+
+// HighestSignedProposal returns the slot of the latest signed proposal of a public key.
+func (s *Store) HighestSignedProposal(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil || p == nil || !p.blockSlot.Valid {
+		return 0, false, err
+	}
+	return primitives.Slot(p.blockSlot.Int64), true, nil
+}
+
+// LowestSignedProposal returns the slot of the latest signed proposal of a public key, which is the only one
+// kept by the minimal slashing protection model.
+func (s *Store) LowestSignedProposal(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) (primitives.Slot, bool, error) {
+	return s.HighestSignedProposal(ctx, pubKey)
+}
+
+// ProposalHistoryForPubKey returns the latest signed proposal of a public key.
+func (s *Store) ProposalHistoryForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*common.Proposal, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || !p.blockSlot.Valid {
+		return []*common.Proposal{}, nil
+	}
+	return []*common.Proposal{{Slot: primitives.Slot(p.blockSlot.Int64), SigningRoot: p.blockSigningRoot}}, nil
+}
+
+// ProposalHistoryForSlot returns the signing root of the proposal of a public key at a slot, whether a proposal
+// was signed at that slot and whether its signing root is known. Only the latest signed proposal is known.
+func (s *Store) ProposalHistoryForSlot(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	slot primitives.Slot,
+) ([fieldparams.RootLength]byte, bool, bool, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil || p == nil || !p.blockSlot.Valid || primitives.Slot(p.blockSlot.Int64) != slot {
+		return [fieldparams.RootLength]byte{}, false, false, err
+	}
+	var root [fieldparams.RootLength]byte
+	copy(root[:], p.blockSigningRoot)
+	return root, true, len(p.blockSigningRoot) > 0, nil
+}
+
+// SaveProposalHistoryForSlot checks if the incoming proposal is valid regarding EIP-3076 minimal slashing protection,
+// and saves it if so. A proposal at the latest signed slot is only valid with the same signing root.
+// The check and the save are atomic across every client of the database.
+func (s *Store) SaveProposalHistoryForSlot(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	slot primitives.Slot,
+	signingRoot []byte,
+) error {
+	return s.update(ctx, func(tx *sql.Tx) error {
+		p, err := s.protection(ctx, tx, pubKey)
+		if err != nil {
+			return err
+		}
+		if p != nil && p.blockSlot.Valid {
+			latest := primitives.Slot(p.blockSlot.Int64)
+			if slot == latest && len(signingRoot) > 0 && bytes.Equal(signingRoot, p.blockSigningRoot) {
+				return nil
+			}
+			if slot <= latest {
+				return errors.Errorf("could not sign proposal with slot lower than or equal to recorded slot, %d <= %d", slot, latest)
+			}
+		}
+		return saveProposal(ctx, tx, pubKey, slot, signingRoot)
+	})
+}
+
+// ProposedPublicKeys returns every public key of the database, as the exports expect the public keys which
+// attested to be a subset of them.
+func (s *Store) ProposedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return s.publicKeys(ctx, "SELECT pubkey FROM slashing_protection ORDER BY pubkey")
+}
+
+// SlashableProposalCheck checks if a block proposal is slashable by comparing it with the latest signed proposal
+// of the public key, and saves it if it is not.
+func (s *Store) SlashableProposalCheck(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signedBlock interfaces.ReadOnlySignedBeaconBlock,
+	signingRoot [fieldparams.RootLength]byte,
+	emitAccountMetrics bool,
+	validatorProposeFailVec *prometheus.CounterVec,
+) error {
+	if err := s.SaveProposalHistoryForSlot(ctx, pubKey, signedBlock.Block().Slot(), signingRoot[:]); err != nil {
+		if emitAccountMetrics {
+			validatorProposeFailVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Inc()
+		}
+		if strings.Contains(err.Error(), "could not sign proposal") {
+			return errors.Wrap(err, common.FailedBlockSignLocalErr)
+		}
+		return errors.Wrap(err, "failed to save updated proposal history")
+	}
+	return nil
+}
+
+// EIPImportBlacklistedPublicKeys returns the public keys which were found slashable when importing an
+// EIP-3076 slashing protection file.
+func (s *Store) EIPImportBlacklistedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return s.publicKeys(ctx, "SELECT pubkey FROM blacklisted_pubkeys ORDER BY pubkey")
+}
+
+// SaveEIPImportBlacklistedPublicKeys saves public keys which were found slashable when importing an
+// EIP-3076 slashing protection file.
+func (s *Store) SaveEIPImportBlacklistedPublicKeys(ctx context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte) error {
+	return s.update(ctx, func(tx *sql.Tx) error {
+		for _, pubKey := range pubKeys {
+			if _, err := tx.ExecContext(
+				ctx,
+				"INSERT INTO blacklisted_pubkeys (pubkey) VALUES (?) ON CONFLICT (pubkey) DO NOTHING",
+				pubKey[:],
+			); err != nil {
+				return errors.Wrapf(err, "could not blacklist public key %#x", pubKey)
+			}
+		}
+		return nil
+	})
+}
+
+// SigningRootAtTargetEpoch returns the signing root of the attestation of a public key at a target epoch, if it
+// is the latest signed attestation.
+func (s *Store) SigningRootAtTargetEpoch(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, target primitives.Epoch) ([]byte, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil || p == nil || !p.targetEpoch.Valid || primitives.Epoch(p.targetEpoch.Int64) != target {
+		return nil, err
+	}
+	return p.attestationSigningRoot, nil
+}
+
+// LowestSignedTargetEpoch returns the target epoch of the latest signed attestation of a public key.
+func (s *Store) LowestSignedTargetEpoch(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil || p == nil || !p.targetEpoch.Valid {
+		return 0, false, err
+	}
+	return primitives.Epoch(p.targetEpoch.Int64), true, nil
+}
+
+// LowestSignedSourceEpoch returns the source epoch of the latest signed attestation of a public key.
+func (s *Store) LowestSignedSourceEpoch(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) (primitives.Epoch, bool, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil || p == nil || !p.sourceEpoch.Valid {
+		return 0, false, err
+	}
+	return primitives.Epoch(p.sourceEpoch.Int64), true, nil
+}
+
+// AttestedPublicKeys returns the public keys which signed an attestation.
+func (s *Store) AttestedPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return s.publicKeys(ctx, "SELECT pubkey FROM slashing_protection WHERE target_epoch IS NOT NULL ORDER BY pubkey")
+}
+
+// SlashableAttestationCheck checks if an attestation is slashable by comparing it with the latest signed
+// attestation of the public key, and saves it if it is not.
+func (s *Store) SlashableAttestationCheck(
+	ctx context.Context,
+	indexedAtt ethpb.IndexedAtt,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signingRoot32 [32]byte,
+	emitAccountMetrics bool,
+	validatorAttestFailVec *prometheus.CounterVec,
+) error {
+	ctx, span := trace.StartSpan(ctx, "validator.postAttSignUpdate")
+	defer span.End()
+
+	if err := s.SaveAttestationForPubKey(ctx, pubKey, signingRoot32, indexedAtt); err != nil {
+		if emitAccountMetrics {
+			validatorAttestFailVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Inc()
+		}
+		if strings.Contains(err.Error(), "could not sign attestation") {
+			return errors.Wrap(err, failedAttLocalProtectionErr)
+		}
+		return errors.Wrap(err, "could not save attestation history for validator public key")
+	}
+	return nil
+}
+
+// SaveAttestationForPubKey checks if the incoming attestation is valid regarding EIP-3076 minimal slashing
+// protection, and saves it if so. An attestation with the latest signed target is only valid with the same
+// signing root. The check and the save are atomic across every client of the database.
+func (s *Store) SaveAttestationForPubKey(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signingRoot [fieldparams.RootLength]byte,
+	att ethpb.IndexedAtt,
+) error {
+	if att == nil || att.GetData() == nil || att.GetData().Source == nil || att.GetData().Target == nil {
+		return errors.New("incoming attestation does not contain source and/or target epoch")
+	}
+	source, target := att.GetData().Source.Epoch, att.GetData().Target.Epoch
+
+	return s.update(ctx, func(tx *sql.Tx) error {
+		p, err := s.protection(ctx, tx, pubKey)
+		if err != nil {
+			return err
+		}
+		if p != nil && p.sourceEpoch.Valid && source < primitives.Epoch(p.sourceEpoch.Int64) {
+			return errors.Errorf(
+				"could not sign attestation with source lower than recorded source epoch, %d < %d",
+				source, p.sourceEpoch.Int64,
+			)
+		}
+		if p != nil && p.targetEpoch.Valid {
+			latest := primitives.Epoch(p.targetEpoch.Int64)
+			if target == latest && bytes.Equal(signingRoot[:], p.attestationSigningRoot) {
+				return nil
+			}
+			if target <= latest {
+				return errors.Errorf(
+					"could not sign attestation with target lower than or equal to recorded target epoch, %d <= %d",
+					target, latest,
+				)
+			}
+		}
+		return saveAttestation(ctx, tx, pubKey, source, target, signingRoot[:])
+	})
+}
+
+// SaveAttestationsForPubKey saves the highest source and target epochs of attestations for a public key
+// WITHOUT checking if they are slashable. It is used to import slashing protection history.
+func (s *Store) SaveAttestationsForPubKey(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	_ [][]byte,
+	atts []*ethpb.IndexedAttestation,
+) error {
+	if len(atts) == 0 {
+		return nil
+	}
+	var source, target primitives.Epoch
+	for _, att := range atts {
+		if att == nil || att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+			return errors.New("incoming attestation does not contain source and/or target epoch")
+		}
+		source = max(source, att.Data.Source.Epoch)
+		target = max(target, att.Data.Target.Epoch)
+	}
+
+	return s.update(ctx, func(tx *sql.Tx) error {
+		p, err := s.protection(ctx, tx, pubKey)
+		if err != nil {
+			return err
+		}
+		if p != nil && p.sourceEpoch.Valid {
+			source = max(source, primitives.Epoch(p.sourceEpoch.Int64))
+		}
+		if p != nil && p.targetEpoch.Valid {
+			target = max(target, primitives.Epoch(p.targetEpoch.Int64))
+		}
+		return saveAttestation(ctx, tx, pubKey, source, target, nil)
+	})
+}
+
+// AttestationHistoryForPubKey returns the latest signed attestation of a public key.
+func (s *Store) AttestationHistoryForPubKey(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]*common.AttestationRecord, error) {
+	p, err := s.protection(ctx, s.db, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || !p.targetEpoch.Valid {
+		return []*common.AttestationRecord{}, nil
+	}
+	return []*common.AttestationRecord{
+		{
+			PubKey:      pubKey,
+			Source:      primitives.Epoch(p.sourceEpoch.Int64),
+			Target:      primitives.Epoch(p.targetEpoch.Int64),
+			SigningRoot: p.attestationSigningRoot,
+		},
+	}, nil
+}
+
+func (s *Store) publicKeys(ctx context.Context, query string) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get public keys")
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.WithError(err).Error("Could not close rows")
+		}
+	}()
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	for rows.Next() {
+		var b []byte
+		if err := rows.Scan(&b); err != nil {
+			return nil, errors.Wrap(err, "could not read public key")
+		}
+		if len(b) != fieldparams.BLSPubkeyLength {
+			return nil, fmt.Errorf("public key %#x has an invalid length", b)
+		}
+		pubKeys = append(pubKeys, [fieldparams.BLSPubkeyLength]byte(b))
+	}
+	return pubKeys, rows.Err()
+}
@@ -0,0 +1,120 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func indexedAttestation(source, target primitives.Epoch) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: source},
+			Target: &ethpb.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func TestStore_SaveProposalHistoryForSlot(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+
+	_, exists, err := s.HighestSignedProposal(ctx, pubKey(1))
+	require.NoError(t, err)
+	assert.Equal(t, false, exists)
+
+	require.NoError(t, s.SaveProposalHistoryForSlot(ctx, pubKey(1), 10, []byte{1}))
+	// The same proposal can be signed again.
+	require.NoError(t, s.SaveProposalHistoryForSlot(ctx, pubKey(1), 10, []byte{1}))
+	err = s.SaveProposalHistoryForSlot(ctx, pubKey(1), 10, []byte{2})
+	require.ErrorContains(t, "could not sign proposal", err)
+	err = s.SaveProposalHistoryForSlot(ctx, pubKey(1), 9, []byte{1})
+	require.ErrorContains(t, "could not sign proposal", err)
+	require.NoError(t, s.SaveProposalHistoryForSlot(ctx, pubKey(1), 11, []byte{3}))
+
+	slot, exists, err := s.HighestSignedProposal(ctx, pubKey(1))
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Slot(11), slot)
+	root, exists, rootExists, err := s.ProposalHistoryForSlot(ctx, pubKey(1), 11)
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, true, rootExists)
+	assert.DeepEqual(t, [32]byte{3}, root)
+	history, err := s.ProposalHistoryForPubKey(ctx, pubKey(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	assert.Equal(t, primitives.Slot(11), history[0].Slot)
+}
+
+func TestStore_SaveAttestationForPubKey(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+
+	require.NoError(t, s.SaveAttestationForPubKey(ctx, pubKey(1), [32]byte{1}, indexedAttestation(2, 3)))
+	// The same attestation can be signed again.
+	require.NoError(t, s.SaveAttestationForPubKey(ctx, pubKey(1), [32]byte{1}, indexedAttestation(2, 3)))
+
+	tests := []struct {
+		name           string
+		source, target primitives.Epoch
+		wantErr        string
+	}{
+		{name: "double vote", source: 2, target: 3, wantErr: "target lower than or equal"},
+		{name: "lower target", source: 2, target: 2, wantErr: "target lower than or equal"},
+		{name: "lower source", source: 1, target: 4, wantErr: "source lower than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.SaveAttestationForPubKey(ctx, pubKey(1), [32]byte{2}, indexedAttestation(tt.source, tt.target))
+			require.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+
+	require.NoError(t, s.SaveAttestationForPubKey(ctx, pubKey(1), [32]byte{2}, indexedAttestation(3, 4)))
+	source, exists, err := s.LowestSignedSourceEpoch(ctx, pubKey(1))
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(3), source)
+	target, exists, err := s.LowestSignedTargetEpoch(ctx, pubKey(1))
+	require.NoError(t, err)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, primitives.Epoch(4), target)
+	root, err := s.SigningRootAtTargetEpoch(ctx, pubKey(1), 4)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytesutil.PadTo([]byte{2}, 32), root)
+
+	keys, err := s.AttestedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][48]byte{pubKey(1)}, keys)
+}
+
+func TestStore_SaveAttestationsForPubKey(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+	require.NoError(t, s.SaveAttestationForPubKey(ctx, pubKey(1), [32]byte{1}, indexedAttestation(5, 6)))
+
+	// Imported attestations never lower the saved epochs.
+	atts := []*ethpb.IndexedAttestation{indexedAttestation(1, 2), indexedAttestation(7, 4)}
+	require.NoError(t, s.SaveAttestationsForPubKey(ctx, pubKey(1), nil, atts))
+	history, err := s.AttestationHistoryForPubKey(ctx, pubKey(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history))
+	assert.Equal(t, primitives.Epoch(7), history[0].Source)
+	assert.Equal(t, primitives.Epoch(6), history[0].Target)
+}
+
+func TestStore_EIPImportBlacklistedPublicKeys(t *testing.T) {
+	ctx := context.Background()
+	s := setupDB(t, databaseURL(t), nil)
+	require.NoError(t, s.SaveEIPImportBlacklistedPublicKeys(ctx, [][48]byte{pubKey(2), pubKey(1)}))
+	require.NoError(t, s.SaveEIPImportBlacklistedPublicKeys(ctx, [][48]byte{pubKey(1)}))
+	keys, err := s.EIPImportBlacklistedPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][48]byte{pubKey(1), pubKey(2)}, keys)
+}
@@ -56,9 +56,36 @@ func ParseGraffitiFile(f string) (*Graffiti, error) {
 	g.Default = ParseHexGraffiti(g.Default)
 	g.Hash = hash.Hash(yamlFile)
 
+	if err := g.validateTemplates(); err != nil {
+		return nil, err
+	}
+
 	return g, nil
 }
 
+// validateTemplates checks that all graffiti templates in the file can be rendered.
+func (g *Graffiti) validateTemplates() error {
+	for i, s := range g.Specific {
+		if err := ValidateTemplate(s); err != nil {
+			return errors.Wrapf(err, "invalid graffiti for validator index %d", i)
+		}
+	}
+	for _, s := range g.Ordered {
+		if err := ValidateTemplate(s); err != nil {
+			return errors.Wrap(err, "invalid ordered graffiti")
+		}
+	}
+	for _, s := range g.Random {
+		if err := ValidateTemplate(s); err != nil {
+			return errors.Wrap(err, "invalid random graffiti")
+		}
+	}
+	if err := ValidateTemplate(g.Default); err != nil {
+		return errors.Wrap(err, "invalid default graffiti")
+	}
+	return nil
+}
+
 // ParseHexGraffiti checks if a graffiti input is being represented in hex and converts it to ASCII if so
 func ParseHexGraffiti(rawGraffiti string) string {
 	splitGraffiti := strings.SplitN(rawGraffiti, ":", 2)
@@ -0,0 +1,91 @@
+package graffiti
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+const (
+	templateDelimiter = "{{"
+	graffitiLength    = 32
+	shortHexLength    = 10 // 0x prefix followed by 4 bytes.
+)
+
+// TemplateData is the data available to graffiti templates when they are rendered at proposal time.
+// Client codes follow the two letter codes of engine_getClientVersionV1, e.g. GE for Geth and PM for Prysm.
+// Empty values mean the data could not be retrieved.
+type TemplateData struct {
+	ELClient       string
+	ELVersion      string
+	ELCommit       string
+	CLClient       string
+	CLVersion      string
+	CLCommit       string
+	ValidatorIndex primitives.ValidatorIndex
+	Epoch          primitives.Epoch
+	Slot           primitives.Slot
+	Pubkey         string
+}
+
+var templateFuncs = template.FuncMap{
+	"short": short,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trunc": trunc,
+}
+
+// IsTemplate returns true if the graffiti contains template actions which must be rendered before proposing.
+func IsTemplate(graffiti string) bool {
+	return strings.Contains(graffiti, templateDelimiter)
+}
+
+// ValidateTemplate checks that the graffiti template can be parsed and rendered.
+// Graffiti without template actions is always valid.
+func ValidateTemplate(graffiti string) error {
+	if !IsTemplate(graffiti) {
+		return nil
+	}
+	_, err := RenderTemplate(graffiti, &TemplateData{})
+	return err
+}
+
+// RenderTemplate expands the graffiti template with the given data.
+// The result is truncated to the maximum graffiti length.
+func RenderTemplate(graffiti string, data *TemplateData) (string, error) {
+	tmpl, err := template.New("graffiti").Funcs(templateFuncs).Option("missingkey=error").Parse(graffiti)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse graffiti template")
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "could not render graffiti template")
+	}
+	return trunc(graffitiLength, buf.String()), nil
+}
+
+// short shortens a hex string to its first 4 bytes.
+func short(s string) string {
+	if !strings.HasPrefix(s, "0x") {
+		s = "0x" + s
+	}
+	return trunc(shortHexLength, s)
+}
+
+// trunc truncates the string to at most n bytes, without splitting a multi-byte character.
+func trunc(n int, s string) string {
+	if n < 0 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
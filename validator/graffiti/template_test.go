@@ -0,0 +1,108 @@
+package graffiti
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestIsTemplate(t *testing.T) {
+	assert.Equal(t, false, IsTemplate("Mr T was here"))
+	assert.Equal(t, true, IsTemplate("{{.CLClient}}"))
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := &TemplateData{
+		ELClient:       "GE",
+		ELVersion:      "1.14.0",
+		ELCommit:       "0xfa4ff922",
+		CLClient:       "PM",
+		CLVersion:      "v5.1.0",
+		CLCommit:       "0x1a2b3c4d",
+		ValidatorIndex: 1234,
+		Epoch:          56,
+		Slot:           1799,
+		Pubkey:         "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+	}
+	tests := []struct {
+		name     string
+		graffiti string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "plain graffiti",
+			graffiti: "Mr T was here",
+			want:     "Mr T was here",
+		},
+		{
+			name:     "client versions",
+			graffiti: "{{.ELClient}}{{.ELVersion}}{{.CLClient}}{{.CLVersion}}",
+			want:     "GE1.14.0PMv5.1.0",
+		},
+		{
+			name:     "runtime data",
+			graffiti: "{{.ValidatorIndex}}/{{.Epoch}}/{{.Slot}} {{.Pubkey | short}}",
+			want:     "1234/56/1799 0xa99a76ed",
+		},
+		{
+			name:     "functions",
+			graffiti: "{{.ELClient | lower}}{{.CLClient | lower | upper}}{{trunc 3 .CLVersion}}",
+			want:     "gePMv5.",
+		},
+		{
+			name:     "truncated to graffiti length",
+			graffiti: "{{.Pubkey}}",
+			want:     "0xa99a76ed7796f7be22d5b7e85deeb7",
+		},
+		{
+			name:     "truncated on a character boundary",
+			graffiti: "{{.CLVersion}} 🦀🦀🦀🦀🦀🦀🦀🦀",
+			want:     "v5.1.0 🦀🦀🦀🦀🦀🦀",
+		},
+		{
+			name:     "unknown field",
+			graffiti: "{{.Unknown}}",
+			wantErr:  "could not render graffiti template",
+		},
+		{
+			name:     "malformed",
+			graffiti: "{{.CLClient",
+			wantErr:  "could not parse graffiti template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(tt.graffiti, data)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	require.NoError(t, ValidateTemplate("Mr T was here"))
+	require.NoError(t, ValidateTemplate("{{.ELClient}}{{.CLClient}} {{.Pubkey | short}}"))
+	require.ErrorContains(t, "could not parse graffiti template", ValidateTemplate("{{.ELClient"))
+	require.ErrorContains(t, "could not render graffiti template", ValidateTemplate("{{.Unknown}}"))
+}
+
+func TestParseGraffitiFile_InvalidTemplate(t *testing.T) {
+	input := []byte(`default: "{{.CLClient"`)
+
+	dirName := t.TempDir() + "somedir"
+	err := os.MkdirAll(dirName, os.ModePerm)
+	require.NoError(t, err)
+	someFileName := filepath.Join(dirName, "somefile.txt")
+	require.NoError(t, os.WriteFile(someFileName, input, os.ModePerm))
+
+	_, err = ParseGraffitiFile(someFileName)
+	require.ErrorContains(t, "invalid default graffiti", err)
+}
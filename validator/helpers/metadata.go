@@ -11,22 +11,13 @@ import (
 )
 
 func ValidateMetadata(ctx context.Context, validatorDB iface.ValidatorDB, interchangeJSON *format.EIPSlashingProtectionFormat) error {
-	// We need to ensure the version in the metadata field matches the one we support.
-	version := interchangeJSON.Metadata.InterchangeFormatVersion
-	if version != format.InterchangeFormatVersion {
-		return fmt.Errorf(
-			"slashing protection JSON version '%s' is not supported, wanted '%s'",
-			version,
-			format.InterchangeFormatVersion,
-		)
+	gvr, err := ValidateFormat(interchangeJSON)
+	if err != nil {
+		return err
 	}
 
 	// We need to verify the genesis validators root matches that of our chain data, otherwise
 	// the imported slashing protection JSON was created on a different chain.
-	gvr, err := RootFromHex(interchangeJSON.Metadata.GenesisValidatorsRoot)
-	if err != nil {
-		return fmt.Errorf("%#x is not a valid root: %w", interchangeJSON.Metadata.GenesisValidatorsRoot, err)
-	}
 	dbGvr, err := validatorDB.GenesisValidatorsRoot(ctx)
 	if err != nil {
 		return errors.Wrap(err, "could not retrieve genesis validators root to db")
@@ -43,3 +34,22 @@ func ValidateMetadata(ctx context.Context, validatorDB iface.ValidatorDB, interc
 	}
 	return nil
 }
+
+// ValidateFormat checks the metadata of a slashing protection JSON file the way an import does, without comparing
+// it to a validator database, and returns its genesis validators root.
+func ValidateFormat(interchangeJSON *format.EIPSlashingProtectionFormat) ([32]byte, error) {
+	// We need to ensure the version in the metadata field matches the one we support.
+	version := interchangeJSON.Metadata.InterchangeFormatVersion
+	if version != format.InterchangeFormatVersion {
+		return [32]byte{}, fmt.Errorf(
+			"slashing protection JSON version '%s' is not supported, wanted '%s'",
+			version,
+			format.InterchangeFormatVersion,
+		)
+	}
+	gvr, err := RootFromHex(interchangeJSON.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("%#x is not a valid root: %w", interchangeJSON.Metadata.GenesisValidatorsRoot, err)
+	}
+	return gvr, nil
+}
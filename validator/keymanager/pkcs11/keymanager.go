@@ -0,0 +1,210 @@
+// Package pkcs11 defines a keymanager whose validator keys never leave a hardware security module or any
+// other token reachable through PKCS#11. The keymanager discovers the BLS keys of the token when it starts and
+// again every epoch, and signs the signing root of every request on the token.
+package pkcs11
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/petnames"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+)
+
+// SetupConfig includes the configuration values needed to initialize a PKCS#11 keymanager. Module is used to
+// open the token when Token is nil.
+type SetupConfig struct {
+	Module *ModuleConfig
+	Token  Token
+	PIN    string
+}
+
+// Keymanager signs with the keys of a PKCS#11 token.
+type Keymanager struct {
+	token               Token
+	keys                map[[fieldparams.BLSPubkeyLength]byte]*Key
+	publicKeys          [][fieldparams.BLSPubkeyLength]byte
+	accountsChangedFeed *event.Feed
+	lock                sync.RWMutex
+}
+
+// NewKeymanager logs in to the token and discovers its keys.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	_, span := trace.StartSpan(ctx, "pkcs11-keymanager.NewKeymanager")
+	defer span.End()
+	if cfg == nil {
+		return nil, errors.New("PKCS#11 keymanager config is nil")
+	}
+	token := cfg.Token
+	if token == nil {
+		var err error
+		token, err = OpenModule(cfg.Module)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := token.Login(cfg.PIN); err != nil {
+		return nil, closeOnError(token, err)
+	}
+	km := &Keymanager{
+		token:               token,
+		accountsChangedFeed: new(event.Feed),
+	}
+	if err := km.reloadKeys(); err != nil {
+		return nil, closeOnError(token, err)
+	}
+	log.WithField("keys", len(km.publicKeys)).Info("Discovered validator keys on PKCS#11 token")
+	return km, nil
+}
+
+func closeOnError(token Token, err error) error {
+	if closeErr := token.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Could not close PKCS#11 token")
+	}
+	return err
+}
+
+// reloadKeys discovers the keys of the token again and notifies subscribers if they changed.
+func (km *Keymanager) reloadKeys() error {
+	found, err := km.token.FindKeys()
+	if err != nil {
+		return errors.Wrap(err, "could not find keys on token")
+	}
+	keys := make(map[[fieldparams.BLSPubkeyLength]byte]*Key, len(found))
+	publicKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(found))
+	for _, key := range found {
+		if _, ok := keys[key.PublicKey]; ok {
+			log.WithField("publicKey", fmt.Sprintf("%#x", key.PublicKey)).Warn("Ignoring duplicate key on token")
+			continue
+		}
+		if _, err := bls.PublicKeyFromBytes(key.PublicKey[:]); err != nil {
+			return errors.Wrapf(err, "token key %s has an invalid public key", key.Label)
+		}
+		keys[key.PublicKey] = key
+		publicKeys = append(publicKeys, key.PublicKey)
+	}
+
+	km.lock.Lock()
+	changed := len(km.publicKeys) != len(publicKeys)
+	for _, pubKey := range km.publicKeys {
+		if _, ok := keys[pubKey]; !ok {
+			changed = true
+		}
+	}
+	km.keys = keys
+	km.publicKeys = publicKeys
+	km.lock.Unlock()
+	if changed {
+		km.accountsChangedFeed.Send(publicKeys)
+	}
+	return nil
+}
+
+// Refresh discovers the keys of the token again, to pick up keys added or removed by its administrators.
+func (km *Keymanager) Refresh(ctx context.Context) error {
+	_, span := trace.StartSpan(ctx, "pkcs11-keymanager.Refresh")
+	defer span.End()
+	return km.reloadKeys()
+}
+
+// FetchValidatingPublicKeys returns the public keys of the token.
+func (km *Keymanager) FetchValidatingPublicKeys(_ context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	return append(make([][fieldparams.BLSPubkeyLength]byte, 0, len(km.publicKeys)), km.publicKeys...), nil
+}
+
+// Sign signs the signing root of a request on the token. The signature is verified before being returned,
+// so that a misconfigured mechanism cannot produce invalid messages.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	_, span := trace.StartSpan(ctx, "pkcs11-keymanager.Sign")
+	defer span.End()
+	if req == nil {
+		return nil, errors.New("nil sign request provided")
+	}
+	if len(req.PublicKey) != fieldparams.BLSPubkeyLength {
+		return nil, fmt.Errorf("public key has an invalid length %d", len(req.PublicKey))
+	}
+	if len(req.SigningRoot) != fieldparams.RootLength {
+		return nil, fmt.Errorf("signing root has an invalid length %d", len(req.SigningRoot))
+	}
+	km.lock.RLock()
+	key, ok := km.keys[[fieldparams.BLSPubkeyLength]byte(req.PublicKey)]
+	km.lock.RUnlock()
+	if !ok {
+		return nil, errors.New("no signing key found in keys cache")
+	}
+
+	signRequestsTotal.Inc()
+	b, err := km.token.Sign(key.Handle, req.SigningRoot)
+	if err != nil {
+		failedSignRequestsTotal.Inc()
+		return nil, errors.Wrapf(err, "could not sign with key %s", key.Label)
+	}
+	sig, err := bls.SignatureFromBytes(b)
+	if err != nil {
+		failedSignRequestsTotal.Inc()
+		return nil, errors.Wrap(err, "token returned an invalid signature")
+	}
+	pubKey, err := bls.PublicKeyFromBytes(req.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(pubKey, req.SigningRoot) {
+		failedSignRequestsTotal.Inc()
+		return nil, fmt.Errorf("token returned a signature which does not verify with key %s", key.Label)
+	}
+	return sig, nil
+}
+
+// SubscribeAccountChanges returns the event subscription for changes to public keys.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][fieldparams.BLSPubkeyLength]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+// ExtractKeystores is not supported, keys never leave the token.
+func (*Keymanager) ExtractKeystores(_ context.Context, _ []bls.PublicKey, _ string) ([]*keymanager.Keystore, error) {
+	return nil, errors.New("extracting keys is not supported for a PKCS#11 keymanager")
+}
+
+// DeleteKeystores is not supported, keys are managed by the administrators of the token.
+func (*Keymanager) DeleteKeystores(context.Context, [][]byte) ([]*keymanager.KeyStatus, error) {
+	return nil, errors.New("wrong wallet type: pkcs11. Only Imported or Derived wallets can delete accounts")
+}
+
+// ListKeymanagerAccounts prints the public keys of the token.
+func (km *Keymanager) ListKeymanagerAccounts(ctx context.Context, _ keymanager.ListKeymanagerAccountConfig) error {
+	au := aurora.NewAurora(true)
+	fmt.Printf("(keymanager kind) %s\n", au.BrightGreen("pkcs11").Bold())
+	validatingPubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating public keys")
+	}
+	if len(validatingPubKeys) == 0 {
+		fmt.Print("No accounts found\n")
+		return nil
+	}
+	fmt.Printf("Showing %d validator accounts\n", len(validatingPubKeys))
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	for _, pubKey := range validatingPubKeys {
+		fmt.Println("")
+		fmt.Printf("%s\n", au.BrightGreen(petnames.DeterministicName(pubKey[:], "-")).Bold())
+		fmt.Printf("%s %s\n", au.BrightCyan("[token label]").Bold(), km.keys[pubKey].Label)
+		fmt.Printf("%s %#x\n", au.BrightCyan("[validating public key]").Bold(), pubKey)
+	}
+	return nil
+}
+
+// Close closes the token.
+func (km *Keymanager) Close() error {
+	return km.token.Close()
+}
@@ -0,0 +1,115 @@
+package pkcs11
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func secretKeys(t *testing.T, count int) []bls.SecretKey {
+	keys := make([]bls.SecretKey, count)
+	for i := range keys {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = sk
+	}
+	return keys
+}
+
+// swappingToken returns the signature of its other key, like a token configured with the wrong mechanism
+// or key would.
+type swappingToken struct {
+	*SoftToken
+}
+
+func (t *swappingToken) Sign(handle ObjectHandle, message []byte) ([]byte, error) {
+	return t.SoftToken.Sign(1-handle, message)
+}
+
+func TestNewKeymanager(t *testing.T) {
+	ctx := context.Background()
+	keys := secretKeys(t, 2)
+
+	_, err := NewKeymanager(ctx, &SetupConfig{Token: NewSoftToken("1234", keys...), PIN: "0000"})
+	require.ErrorContains(t, "incorrect PIN", err)
+	_, err = NewKeymanager(ctx, &SetupConfig{Module: &ModuleConfig{Path: "/nonexistent/libpkcs11.so"}})
+	require.ErrorContains(t, "token label are required", err)
+
+	km, err := NewKeymanager(ctx, &SetupConfig{Token: NewSoftToken("1234", keys...), PIN: "1234"})
+	require.NoError(t, err)
+	pubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(pubKeys))
+	for i, sk := range keys {
+		assert.DeepEqual(t, sk.PublicKey().Marshal(), pubKeys[i][:])
+	}
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	ctx := context.Background()
+	keys := secretKeys(t, 2)
+	km, err := NewKeymanager(ctx, &SetupConfig{Token: NewSoftToken("1234", keys...), PIN: "1234"})
+	require.NoError(t, err)
+	root := make([]byte, fieldparams.RootLength)
+	root[0] = 1
+
+	sig, err := km.Sign(ctx, &validatorpb.SignRequest{PublicKey: keys[1].PublicKey().Marshal(), SigningRoot: root})
+	require.NoError(t, err)
+	assert.DeepEqual(t, keys[1].Sign(root).Marshal(), sig.Marshal())
+
+	other := secretKeys(t, 1)[0]
+	_, err = km.Sign(ctx, &validatorpb.SignRequest{PublicKey: other.PublicKey().Marshal(), SigningRoot: root})
+	require.ErrorContains(t, "no signing key found", err)
+	_, err = km.Sign(ctx, &validatorpb.SignRequest{PublicKey: keys[0].PublicKey().Marshal(), SigningRoot: root[:4]})
+	require.ErrorContains(t, "signing root has an invalid length", err)
+
+	swapping, err := NewKeymanager(ctx, &SetupConfig{Token: &swappingToken{NewSoftToken("1234", keys...)}, PIN: "1234"})
+	require.NoError(t, err)
+	_, err = swapping.Sign(ctx, &validatorpb.SignRequest{PublicKey: keys[0].PublicKey().Marshal(), SigningRoot: root})
+	require.ErrorContains(t, "does not verify", err)
+}
+
+func TestKeymanager_Refresh(t *testing.T) {
+	ctx := context.Background()
+	keys := secretKeys(t, 2)
+	token := NewSoftToken("1234", keys[0])
+	km, err := NewKeymanager(ctx, &SetupConfig{Token: token, PIN: "1234"})
+	require.NoError(t, err)
+
+	pubKeysChan := make(chan [][fieldparams.BLSPubkeyLength]byte, 1)
+	sub := km.SubscribeAccountChanges(pubKeysChan)
+	defer sub.Unsubscribe()
+
+	// Refreshing without changes on the token does not notify subscribers.
+	require.NoError(t, km.Refresh(ctx))
+	token.lock.Lock()
+	token.keys = keys
+	token.lock.Unlock()
+	require.NoError(t, km.Refresh(ctx))
+	select {
+	case pubKeys := <-pubKeysChan:
+		assert.Equal(t, 2, len(pubKeys))
+	case <-time.After(time.Second):
+		t.Fatal("subscribers were not notified of the new key")
+	}
+}
+
+func TestSoftToken(t *testing.T) {
+	token := NewSoftToken("1234", secretKeys(t, 1)...)
+	_, err := token.FindKeys()
+	require.ErrorIs(t, err, errNotLoggedIn)
+	_, err = token.Sign(0, []byte("message"))
+	require.ErrorIs(t, err, errNotLoggedIn)
+
+	require.NoError(t, token.Login("1234"))
+	_, err = token.Sign(1, []byte("message"))
+	require.ErrorContains(t, "no key with handle 1", err)
+	require.NoError(t, token.Close())
+	require.ErrorContains(t, "token is closed", token.Login("1234"))
+}
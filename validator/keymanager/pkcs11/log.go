@@ -0,0 +1,5 @@
+package pkcs11
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "pkcs11-keymanager")
@@ -0,0 +1,17 @@
+package pkcs11
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	signRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pkcs11_sign_requests_total",
+		Help: "Total number of sign requests sent to the PKCS#11 token",
+	})
+	failedSignRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "pkcs11_failed_sign_requests_total",
+		Help: "Total number of sign requests which failed or returned an invalid signature",
+	})
+)
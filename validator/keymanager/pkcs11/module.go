@@ -0,0 +1,205 @@
+package pkcs11
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+)
+
+const findObjectsBatchSize = 64
+
+// ModuleConfig defines how to reach a token through the PKCS#11 module of its vendor. BLS12-381 is not part
+// of the PKCS#11 standard, so tokens which support it expose vendor defined key types and mechanisms.
+type ModuleConfig struct {
+	// Path of the PKCS#11 shared library of the vendor.
+	Path string
+	// TokenLabel selects the token among the slots of the module.
+	TokenLabel string
+	// KeyType is the vendor defined CKA_KEY_TYPE of BLS12-381 keys.
+	KeyType uint
+	// Mechanism is the vendor defined mechanism which signs a message with a BLS12-381 private key.
+	Mechanism uint
+}
+
+// moduleToken is a Token reached through a PKCS#11 module. The public key of a BLS key pair is read from the
+// CKA_VALUE attribute of its public key object, and its private key object is found by their common CKA_ID.
+type moduleToken struct {
+	config  *ModuleConfig
+	ctx     *p11.Ctx
+	session p11.SessionHandle
+	// lock serializes the operations of the session, which PKCS#11 does not allow to run concurrently.
+	lock sync.Mutex
+}
+
+// OpenModule loads a PKCS#11 module and opens a session on the token labeled cfg.TokenLabel.
+func OpenModule(cfg *ModuleConfig) (Token, error) {
+	if cfg == nil || cfg.Path == "" || cfg.TokenLabel == "" {
+		return nil, errors.New("PKCS#11 module path and token label are required")
+	}
+	ctx := p11.New(cfg.Path)
+	if ctx == nil {
+		return nil, fmt.Errorf("could not load PKCS#11 module %s", cfg.Path)
+	}
+	if err := ctx.Initialize(); err != nil && !isError(err, p11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, errors.Wrapf(err, "could not initialize PKCS#11 module %s", cfg.Path)
+	}
+	t := &moduleToken{config: cfg, ctx: ctx}
+	slot, err := t.findSlot()
+	if err != nil {
+		return nil, t.finalizeOnError(err)
+	}
+	t.session, err = ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, t.finalizeOnError(errors.Wrapf(err, "could not open session on token %s", cfg.TokenLabel))
+	}
+	return t, nil
+}
+
+func (t *moduleToken) findSlot() (uint, error) {
+	slots, err := t.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list slots")
+	}
+	for _, slot := range slots {
+		info, err := t.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not get token info of slot %d", slot)
+		}
+		// Token labels are padded with spaces to 32 bytes.
+		if strings.TrimRight(info.Label, " \x00") == t.config.TokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("no token labeled %s found in %d slots", t.config.TokenLabel, len(slots))
+}
+
+func (t *moduleToken) finalizeOnError(err error) error {
+	if finalizeErr := t.ctx.Finalize(); finalizeErr != nil {
+		log.WithError(finalizeErr).Error("Could not finalize PKCS#11 module")
+	}
+	t.ctx.Destroy()
+	return err
+}
+
+// Login logs the user in to the session.
+func (t *moduleToken) Login(pin string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if err := t.ctx.Login(t.session, p11.CKU_USER, pin); err != nil && !isError(err, p11.CKR_USER_ALREADY_LOGGED_IN) {
+		return errors.Wrapf(err, "could not log in to token %s", t.config.TokenLabel)
+	}
+	return nil
+}
+
+// FindKeys returns the BLS key pairs of the token.
+func (t *moduleToken) FindKeys() ([]*Key, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	publicKeys, err := t.findObjects(
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+		p11.NewAttribute(p11.CKA_KEY_TYPE, t.config.KeyType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*Key, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		attributes, err := t.ctx.GetAttributeValue(t.session, publicKey, []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_ID, nil),
+			p11.NewAttribute(p11.CKA_LABEL, nil),
+			p11.NewAttribute(p11.CKA_VALUE, nil),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read public key attributes")
+		}
+		id, label, value := attributes[0].Value, string(attributes[1].Value), attributes[2].Value
+		if len(value) != fieldparams.BLSPubkeyLength {
+			log.WithField("label", label).Warnf("Skipping public key of %d bytes", len(value))
+			continue
+		}
+		privateKeys, err := t.findObjects(
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY),
+			p11.NewAttribute(p11.CKA_KEY_TYPE, t.config.KeyType),
+			p11.NewAttribute(p11.CKA_ID, id),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(privateKeys) != 1 {
+			log.WithField("label", label).Warnf("Skipping public key with %d matching private keys", len(privateKeys))
+			continue
+		}
+		keys = append(keys, &Key{
+			Handle:    ObjectHandle(privateKeys[0]),
+			Label:     label,
+			PublicKey: [fieldparams.BLSPubkeyLength]byte(value),
+		})
+	}
+	return keys, nil
+}
+
+func (t *moduleToken) findObjects(template ...*p11.Attribute) ([]p11.ObjectHandle, error) {
+	if err := t.ctx.FindObjectsInit(t.session, template); err != nil {
+		return nil, errors.Wrap(err, "could not initialize object search")
+	}
+	var objects []p11.ObjectHandle
+	for {
+		batch, _, err := t.ctx.FindObjects(t.session, findObjectsBatchSize)
+		if err != nil {
+			if finalErr := t.ctx.FindObjectsFinal(t.session); finalErr != nil {
+				log.WithError(finalErr).Error("Could not finalize object search")
+			}
+			return nil, errors.Wrap(err, "could not search objects")
+		}
+		if len(batch) == 0 {
+			break
+		}
+		objects = append(objects, batch...)
+	}
+	if err := t.ctx.FindObjectsFinal(t.session); err != nil {
+		return nil, errors.Wrap(err, "could not finalize object search")
+	}
+	return objects, nil
+}
+
+// Sign signs a message with the vendor defined BLS mechanism.
+func (t *moduleToken) Sign(handle ObjectHandle, message []byte) ([]byte, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	mechanism := []*p11.Mechanism{p11.NewMechanism(t.config.Mechanism, nil)}
+	if err := t.ctx.SignInit(t.session, mechanism, p11.ObjectHandle(handle)); err != nil {
+		return nil, errors.Wrap(err, "could not initialize signature")
+	}
+	signature, err := t.ctx.Sign(t.session, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign")
+	}
+	return signature, nil
+}
+
+// Close logs out, closes the session and unloads the module.
+func (t *moduleToken) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if err := t.ctx.Logout(t.session); err != nil && !isError(err, p11.CKR_USER_NOT_LOGGED_IN) {
+		log.WithError(err).Error("Could not log out of token")
+	}
+	if err := t.ctx.CloseSession(t.session); err != nil {
+		log.WithError(err).Error("Could not close session")
+	}
+	if err := t.ctx.Finalize(); err != nil {
+		return errors.Wrap(err, "could not finalize PKCS#11 module")
+	}
+	t.ctx.Destroy()
+	return nil
+}
+
+func isError(err error, code uint) bool {
+	var p11Err p11.Error
+	return errors.As(err, &p11Err) && uint(p11Err) == code
+}
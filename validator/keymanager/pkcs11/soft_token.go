@@ -0,0 +1,80 @@
+package pkcs11
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+)
+
+var errNotLoggedIn = errors.New("user is not logged in to the token")
+
+// SoftToken is a Token which keeps its keys in memory. It behaves like a hardware token, keys are only usable
+// after a login with the right PIN, and is meant for tests and development.
+type SoftToken struct {
+	pin      string
+	keys     []bls.SecretKey
+	lock     sync.Mutex
+	loggedIn bool
+	closed   bool
+}
+
+// NewSoftToken returns a software token protected by a PIN which holds secret keys.
+func NewSoftToken(pin string, keys ...bls.SecretKey) *SoftToken {
+	return &SoftToken{pin: pin, keys: keys}
+}
+
+// Login opens a user session on the token.
+func (t *SoftToken) Login(pin string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return errors.New("token is closed")
+	}
+	if pin != t.pin {
+		return errors.New("incorrect PIN")
+	}
+	t.loggedIn = true
+	return nil
+}
+
+// FindKeys returns the keys of the token, their handles are their positions.
+func (t *SoftToken) FindKeys() ([]*Key, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.loggedIn {
+		return nil, errNotLoggedIn
+	}
+	keys := make([]*Key, len(t.keys))
+	for i, sk := range t.keys {
+		keys[i] = &Key{
+			Handle:    ObjectHandle(i),
+			Label:     fmt.Sprintf("soft-key-%d", i),
+			PublicKey: [48]byte(sk.PublicKey().Marshal()),
+		}
+	}
+	return keys, nil
+}
+
+// Sign signs a message with the key of a handle.
+func (t *SoftToken) Sign(handle ObjectHandle, message []byte) ([]byte, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.loggedIn {
+		return nil, errNotLoggedIn
+	}
+	if int(handle) >= len(t.keys) {
+		return nil, fmt.Errorf("no key with handle %d", handle)
+	}
+	return t.keys[handle].Sign(message).Marshal(), nil
+}
+
+// Close ends the session.
+func (t *SoftToken) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.loggedIn = false
+	t.closed = true
+	return nil
+}
@@ -0,0 +1,30 @@
+package pkcs11
+
+import (
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+)
+
+// ObjectHandle identifies a private key object in a session of a token.
+type ObjectHandle uint
+
+// Key is a BLS key stored on a token. Only its public part can be read, the private key stays on the token
+// and is referenced by its handle.
+type Key struct {
+	Handle    ObjectHandle
+	Label     string
+	PublicKey [fieldparams.BLSPubkeyLength]byte
+}
+
+// Token is the subset of the PKCS#11 interface the keymanager relies on: a user session on a token, the
+// discovery of the BLS keys it holds and signing with them. Implementations must be safe for concurrent use.
+type Token interface {
+	// Login opens a user session on the token. Every other method requires it.
+	Login(pin string) error
+	// FindKeys returns the BLS keys stored on the token.
+	FindKeys() ([]*Key, error)
+	// Sign signs a message with the private key of a handle returned by FindKeys, and returns the
+	// compressed BLS signature.
+	Sign(handle ObjectHandle, message []byte) ([]byte, error)
+	// Close ends the session and releases the token.
+	Close() error
+}
@@ -0,0 +1,149 @@
+package threshold
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// DefaultTimeout is how long a signature waits for the partial signatures of peers when the configuration
+// does not set a timeout.
+const DefaultTimeout = 2 * time.Second
+
+// ConfigFile is the JSON configuration of a share holder, as written by `prysmctl validator split-keystore`.
+type ConfigFile struct {
+	// ShareID is the id of the shares held by this node, the point at which the polynomial of the split was
+	// evaluated.
+	ShareID uint64 `json:"share_id"`
+	// Threshold is the number of partial signatures needed to produce a signature.
+	Threshold uint64 `json:"threshold"`
+	// ListenAddress is the address on which the node serves partial signatures to its peers.
+	ListenAddress string `json:"listen_address"`
+	// Peers maps the share ids of the other share holders to the URLs of their listen addresses.
+	Peers map[uint64]string `json:"peers"`
+	// TransportSecret is the hex encoded key authenticating the requests between share holders.
+	TransportSecret string `json:"transport_secret"`
+	// Timeout is how long to wait for partial signatures, such as "2s".
+	Timeout string `json:"timeout,omitempty"`
+	// Validators are the validators this node holds a share of.
+	Validators []*ValidatorConfig `json:"validators"`
+}
+
+// ValidatorConfig describes the shares of a validator key.
+type ValidatorConfig struct {
+	// PublicKey is the hex encoded public key of the validator.
+	PublicKey string `json:"public_key"`
+	// SharePublicKeys are the hex encoded public keys of all the shares, ordered by share id.
+	SharePublicKeys []string `json:"share_public_keys"`
+	// Keystore is the path of the EIP-2335 keystore of the share of this node, relative to the configuration
+	// file unless absolute.
+	Keystore string `json:"keystore"`
+}
+
+// Share is the share of a validator key held by this node.
+type Share struct {
+	// PublicKey is the public key of the validator.
+	PublicKey [fieldparams.BLSPubkeyLength]byte
+	// SharePublicKeys are the public keys of all the shares, the key of share id i at index i-1.
+	SharePublicKeys []bls.PublicKey
+	// SecretKey is the share of this node.
+	SecretKey bls.SecretKey
+}
+
+// LoadConfig reads the configuration file of a share holder and decrypts the keystores of its shares with
+// a password.
+func LoadConfig(path, password string) (*SetupConfig, error) {
+	b, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read threshold configuration")
+	}
+	cfgFile := &ConfigFile{}
+	if err := json.Unmarshal(b, cfgFile); err != nil {
+		return nil, errors.Wrap(err, "could not decode threshold configuration")
+	}
+	// The secret is not wrapped in the error, which would log it.
+	secret, err := hexutil.Decode(cfgFile.TransportSecret)
+	if err != nil || len(secret) != TransportSecretLength {
+		return nil, fmt.Errorf("transport secret must be %d hex encoded bytes", TransportSecretLength)
+	}
+	timeout := DefaultTimeout
+	if cfgFile.Timeout != "" {
+		timeout, err = time.ParseDuration(cfgFile.Timeout)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse timeout")
+		}
+	}
+	cfg := &SetupConfig{
+		ShareID:         cfgFile.ShareID,
+		Threshold:       cfgFile.Threshold,
+		ListenAddress:   cfgFile.ListenAddress,
+		Peers:           cfgFile.Peers,
+		TransportSecret: secret,
+		Timeout:         timeout,
+		Shares:          make([]*Share, 0, len(cfgFile.Validators)),
+	}
+	for _, v := range cfgFile.Validators {
+		keystorePath := v.Keystore
+		if !filepath.IsAbs(keystorePath) {
+			keystorePath = filepath.Join(filepath.Dir(path), keystorePath)
+		}
+		share, err := loadShare(v, keystorePath, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not load share of validator %s", v.PublicKey)
+		}
+		cfg.Shares = append(cfg.Shares, share)
+	}
+	return cfg, nil
+}
+
+func loadShare(v *ValidatorConfig, keystorePath, password string) (*Share, error) {
+	pubKey, err := bytesutil.DecodeHexWithLength(v.PublicKey, fieldparams.BLSPubkeyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	share := &Share{
+		PublicKey:       [fieldparams.BLSPubkeyLength]byte(pubKey),
+		SharePublicKeys: make([]bls.PublicKey, len(v.SharePublicKeys)),
+	}
+	for i, s := range v.SharePublicKeys {
+		b, err := bytesutil.DecodeHexWithLength(s, fieldparams.BLSPubkeyLength)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of share %d", i+1)
+		}
+		share.SharePublicKeys[i], err = bls.PublicKeyFromBytes(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of share %d", i+1)
+		}
+	}
+
+	b, err := file.ReadFileAsBytes(keystorePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read keystore")
+	}
+	keystore := &keymanager.Keystore{}
+	if err := json.Unmarshal(b, keystore); err != nil {
+		return nil, errors.Wrap(err, "could not decode keystore")
+	}
+	secretKey, err := keystorev4.New().Decrypt(keystore.Crypto, password)
+	if err != nil && strings.Contains(err.Error(), keymanager.IncorrectPasswordErrMsg) {
+		return nil, fmt.Errorf("wrong password for keystore %s", keystorePath)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not decrypt keystore")
+	}
+	share.SecretKey, err = bls.SecretKeyFromBytes(secretKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid secret key in keystore")
+	}
+	return share, nil
+}
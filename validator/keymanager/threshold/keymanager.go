@@ -0,0 +1,308 @@
+// Package threshold defines a keymanager for distributed validators, whose keys are split with Shamir's secret
+// sharing among several share holders so that no machine ever holds a full validator key. Each share holder
+// runs a validator client with this keymanager: it signs with its own share, collects the partial signatures of
+// its peers over an authenticated HTTP transport, and recovers the signature of the validator once it has a
+// threshold of them.
+package threshold
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/async/event"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/accounts/petnames"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// SetupConfig includes the configuration values needed to initialize a threshold keymanager.
+type SetupConfig struct {
+	ShareID   uint64
+	Threshold uint64
+	// ListenAddress is the address on which partial signatures are served to peers, they are not served
+	// when it is empty.
+	ListenAddress   string
+	Peers           map[uint64]string
+	TransportSecret []byte
+	Timeout         time.Duration
+	Shares          []*Share
+	// SigningAuditLog records the partial signatures served to peers, they are not recorded when it is nil.
+	SigningAuditLog *audit.Log
+	// SlashingProtectionDB is the slashing protection database of the validator client, which the requests
+	// signed by the share are checked against and recorded in.
+	SlashingProtectionDB iface.ValidatorDB
+}
+
+// Keymanager signs with a threshold of the shares of its validator keys, one of which it holds.
+type Keymanager struct {
+	shareID             uint64
+	threshold           uint64
+	peers               map[uint64]string
+	transportSecret     []byte
+	timeout             time.Duration
+	shares              map[[fieldparams.BLSPubkeyLength]byte]*Share
+	publicKeys          [][fieldparams.BLSPubkeyLength]byte
+	protector           *protector
+	client              *http.Client
+	server              *http.Server
+	auditLog            *audit.Log
+	accountsChangedFeed *event.Feed
+}
+
+// NewKeymanager checks the shares of the configuration and starts serving partial signatures to peers.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	_, span := trace.StartSpan(ctx, "threshold-keymanager.NewKeymanager")
+	defer span.End()
+	if cfg == nil {
+		return nil, errors.New("threshold keymanager config is nil")
+	}
+	if cfg.ShareID == 0 {
+		return nil, errors.New("share ids start at 1")
+	}
+	if cfg.Threshold == 0 || cfg.Threshold > uint64(len(cfg.Peers))+1 {
+		return nil, fmt.Errorf("threshold %d cannot be reached with %d peers", cfg.Threshold, len(cfg.Peers))
+	}
+	if len(cfg.TransportSecret) != TransportSecretLength {
+		return nil, fmt.Errorf("transport secret must be %d bytes", TransportSecretLength)
+	}
+	if _, ok := cfg.Peers[cfg.ShareID]; ok {
+		return nil, fmt.Errorf("share id %d of this node is also a peer", cfg.ShareID)
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	km := &Keymanager{
+		shareID:             cfg.ShareID,
+		threshold:           cfg.Threshold,
+		peers:               cfg.Peers,
+		transportSecret:     cfg.TransportSecret,
+		timeout:             timeout,
+		shares:              make(map[[fieldparams.BLSPubkeyLength]byte]*Share, len(cfg.Shares)),
+		publicKeys:          make([][fieldparams.BLSPubkeyLength]byte, 0, len(cfg.Shares)),
+		protector:           newProtector(cfg.SlashingProtectionDB),
+		client:              &http.Client{},
+		auditLog:            cfg.SigningAuditLog,
+		accountsChangedFeed: new(event.Feed),
+	}
+	for _, share := range cfg.Shares {
+		if err := km.checkShare(share); err != nil {
+			return nil, errors.Wrapf(err, "invalid share of validator %#x", share.PublicKey)
+		}
+		if _, ok := km.shares[share.PublicKey]; ok {
+			return nil, fmt.Errorf("duplicate share of validator %#x", share.PublicKey)
+		}
+		km.shares[share.PublicKey] = share
+		km.publicKeys = append(km.publicKeys, share.PublicKey)
+	}
+	if cfg.ListenAddress != "" {
+		listener, err := net.Listen("tcp", cfg.ListenAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not listen on %s", cfg.ListenAddress)
+		}
+		km.server = &http.Server{Handler: km, ReadHeaderTimeout: time.Second}
+		go func() {
+			if err := km.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.WithError(err).Error("Partial signature server stopped")
+			}
+		}()
+	}
+	log.WithFields(logrus.Fields{
+		"shareID":   km.shareID,
+		"threshold": km.threshold,
+		"peers":     len(km.peers),
+		"keys":      len(km.publicKeys),
+	}).Info("Loaded threshold validator key shares")
+	return km, nil
+}
+
+func (km *Keymanager) checkShare(share *Share) error {
+	if share == nil || share.SecretKey == nil {
+		return errors.New("share has no secret key")
+	}
+	shares := uint64(len(share.SharePublicKeys))
+	if km.shareID > shares {
+		return fmt.Errorf("share id %d is above the number of shares %d", km.shareID, shares)
+	}
+	for id := range km.peers {
+		if id == 0 || id > shares {
+			return fmt.Errorf("peer share id %d is not between 1 and the number of shares %d", id, shares)
+		}
+	}
+	if !share.SecretKey.PublicKey().Equals(share.SharePublicKeys[km.shareID-1]) {
+		return fmt.Errorf("secret key is not the share %d of the validator", km.shareID)
+	}
+	return nil
+}
+
+// FetchValidatingPublicKeys returns the public keys of the validators this node holds a share of.
+func (km *Keymanager) FetchValidatingPublicKeys(_ context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	return append(make([][fieldparams.BLSPubkeyLength]byte, 0, len(km.publicKeys)), km.publicKeys...), nil
+}
+
+// signShare signs a request with the share of this node, after checking it against the slashing protection.
+func (km *Keymanager) signShare(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	if len(req.PublicKey) != fieldparams.BLSPubkeyLength {
+		return nil, fmt.Errorf("public key has an invalid length %d", len(req.PublicKey))
+	}
+	share, ok := km.shares[[fieldparams.BLSPubkeyLength]byte(req.PublicKey)]
+	if !ok {
+		return nil, errors.New("no signing key found in keys cache")
+	}
+	if err := verifySigningRoot(req); err != nil {
+		return nil, err
+	}
+	if err := km.protector.checkDatabase(ctx, share.PublicKey, req); err != nil {
+		return nil, err
+	}
+	if err := km.protector.checkAndRecord(share.PublicKey, req); err != nil {
+		return nil, err
+	}
+	return share.SecretKey.Sign(req.SigningRoot), nil
+}
+
+type partialSignature struct {
+	shareID   uint64
+	signature bls.Signature
+	err       error
+}
+
+// Sign signs a request with the share of this node and with the shares of peers, until it has a threshold of
+// partial signatures from which it recovers the signature of the validator.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	ctx, span := trace.StartSpan(ctx, "threshold-keymanager.Sign")
+	defer span.End()
+	if req == nil {
+		return nil, errors.New("nil sign request provided")
+	}
+	signRequestsTotal.Inc()
+	partial, err := km.signShare(ctx, req)
+	if err != nil {
+		failedSignRequestsTotal.Inc()
+		return nil, err
+	}
+	share := km.shares[[fieldparams.BLSPubkeyLength]byte(req.PublicKey)]
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode sign request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, km.timeout)
+	defer cancel()
+	results := make(chan *partialSignature, len(km.peers))
+	for id, url := range km.peers {
+		go func(id uint64, url string) {
+			sig, err := km.peerPartialSignature(ctx, url, body, share.SharePublicKeys[id-1], req.SigningRoot)
+			results <- &partialSignature{shareID: id, signature: sig, err: err}
+		}(id, url)
+	}
+	partials := []bls.Signature{partial}
+	ids := []uint64{km.shareID}
+	for range km.peers {
+		if uint64(len(partials)) >= km.threshold {
+			break
+		}
+		result := <-results
+		if result.err != nil {
+			log.WithError(result.err).WithField("peer", result.shareID).Warn("Could not get partial signature")
+			continue
+		}
+		partials = append(partials, result.signature)
+		ids = append(ids, result.shareID)
+	}
+	if uint64(len(partials)) < km.threshold {
+		failedSignRequestsTotal.Inc()
+		return nil, fmt.Errorf("could only collect %d of %d partial signatures", len(partials), km.threshold)
+	}
+
+	sig, err := bls.RecoverSignature(partials, ids)
+	if err != nil {
+		failedSignRequestsTotal.Inc()
+		return nil, errors.Wrap(err, "could not recover signature")
+	}
+	pubKey, err := bls.PublicKeyFromBytes(req.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !sig.Verify(pubKey, req.SigningRoot) {
+		failedSignRequestsTotal.Inc()
+		return nil, errors.New("recovered signature does not verify with the validator public key")
+	}
+	return sig, nil
+}
+
+// peerPartialSignature requests the partial signature of a peer and verifies it with the public key of its share.
+func (km *Keymanager) peerPartialSignature(
+	ctx context.Context, url string, body []byte, sharePubKey bls.PublicKey, signingRoot []byte,
+) (bls.Signature, error) {
+	b, err := km.requestPartialSignature(ctx, url, body)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := bls.SignatureFromBytes(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "peer returned an invalid signature")
+	}
+	if !sig.Verify(sharePubKey, signingRoot) {
+		return nil, errors.New("peer returned a signature which does not verify with its share public key")
+	}
+	return sig, nil
+}
+
+// SubscribeAccountChanges returns the event subscription for changes to public keys.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][fieldparams.BLSPubkeyLength]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+// ExtractKeystores is not supported, no share holder has the full keys.
+func (*Keymanager) ExtractKeystores(_ context.Context, _ []bls.PublicKey, _ string) ([]*keymanager.Keystore, error) {
+	return nil, errors.New("extracting keys is not supported for a threshold keymanager")
+}
+
+// DeleteKeystores is not supported, shares are set in the threshold configuration.
+func (*Keymanager) DeleteKeystores(context.Context, [][]byte) ([]*keymanager.KeyStatus, error) {
+	return nil, errors.New("wrong wallet type: threshold. Only Imported or Derived wallets can delete accounts")
+}
+
+// ListKeymanagerAccounts prints the public keys of the validators this node holds a share of.
+func (km *Keymanager) ListKeymanagerAccounts(ctx context.Context, _ keymanager.ListKeymanagerAccountConfig) error {
+	au := aurora.NewAurora(true)
+	fmt.Printf("(keymanager kind) %s\n", au.BrightGreen("threshold").Bold())
+	fmt.Printf("Holding share %d, signing with %d of %d shares\n", km.shareID, km.threshold, len(km.peers)+1)
+	validatingPubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating public keys")
+	}
+	if len(validatingPubKeys) == 0 {
+		fmt.Print("No accounts found\n")
+		return nil
+	}
+	fmt.Printf("Showing %d validator accounts\n", len(validatingPubKeys))
+	for _, pubKey := range validatingPubKeys {
+		fmt.Println("")
+		fmt.Printf("%s\n", au.BrightGreen(petnames.DeterministicName(pubKey[:], "-")).Bold())
+		fmt.Printf("%s %#x\n", au.BrightCyan("[validating public key]").Bold(), pubKey)
+		fmt.Printf("%s %#x\n", au.BrightCyan("[share public key]").Bold(), km.shares[pubKey].SecretKey.PublicKey().Marshal())
+	}
+	return nil
+}
+
+// Close stops serving partial signatures.
+func (km *Keymanager) Close() error {
+	if km.server == nil {
+		return nil
+	}
+	return km.server.Close()
+}
@@ -0,0 +1,227 @@
+package threshold
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
+	dbtest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
+	"google.golang.org/protobuf/proto"
+)
+
+// cluster runs share holders of a split key, each serving partial signatures on a test server.
+type cluster struct {
+	secretKey bls.SecretKey
+	nodes     []*Keymanager
+	servers   []*httptest.Server
+}
+
+func newCluster(t *testing.T, threshold, shares uint64) *cluster {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	shareKeys, err := bls.SplitSecretKey(sk, threshold, shares)
+	require.NoError(t, err)
+	sharePubKeys := make([]bls.PublicKey, shares)
+	for i, key := range shareKeys {
+		sharePubKeys[i] = key.PublicKey()
+	}
+	secret := make([]byte, TransportSecretLength)
+	secret[0] = 1
+
+	c := &cluster{secretKey: sk}
+	for range shareKeys {
+		c.servers = append(c.servers, httptest.NewUnstartedServer(http.NotFoundHandler()))
+	}
+	for i, key := range shareKeys {
+		peers := make(map[uint64]string)
+		for j, server := range c.servers {
+			if j != i {
+				peers[uint64(j+1)] = "http://" + server.Listener.Addr().String()
+			}
+		}
+		km, err := NewKeymanager(context.Background(), &SetupConfig{
+			ShareID:         uint64(i + 1),
+			Threshold:       threshold,
+			Peers:           peers,
+			TransportSecret: secret,
+			Shares: []*Share{{
+				PublicKey:       [fieldparams.BLSPubkeyLength]byte(sk.PublicKey().Marshal()),
+				SharePublicKeys: sharePubKeys,
+				SecretKey:       key,
+			}},
+		})
+		require.NoError(t, err)
+		c.servers[i].Config.Handler = km
+		c.servers[i].Start()
+		t.Cleanup(c.servers[i].Close)
+		c.nodes = append(c.nodes, km)
+	}
+	return c
+}
+
+func attestationRequest(t *testing.T, sk bls.SecretKey, source, target primitives.Epoch, root byte) *validatorpb.SignRequest {
+	data := util.HydrateAttestationData(&ethpb.AttestationData{
+		Source: &ethpb.Checkpoint{Epoch: source},
+		Target: &ethpb.Checkpoint{Epoch: target},
+	})
+	data.BeaconBlockRoot[0] = root
+	domain := make([]byte, 32)
+	signingRoot, err := signing.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+	return &validatorpb.SignRequest{
+		PublicKey:       sk.PublicKey().Marshal(),
+		SigningRoot:     signingRoot[:],
+		SignatureDomain: domain,
+		Object:          &validatorpb.SignRequest_AttestationData{AttestationData: data},
+	}
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 2, 3)
+	req := attestationRequest(t, c.secretKey, 1, 2, 1)
+
+	for _, node := range c.nodes {
+		sig, err := node.Sign(ctx, req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, c.secretKey.Sign(req.SigningRoot).Marshal(), sig.Marshal())
+	}
+
+	// A threshold of share holders is enough.
+	c.servers[2].Close()
+	req = attestationRequest(t, c.secretKey, 2, 3, 1)
+	sig, err := c.nodes[0].Sign(ctx, req)
+	require.NoError(t, err)
+	assert.DeepEqual(t, c.secretKey.Sign(req.SigningRoot).Marshal(), sig.Marshal())
+	c.servers[1].Close()
+	_, err = c.nodes[0].Sign(ctx, attestationRequest(t, c.secretKey, 3, 4, 1))
+	require.ErrorContains(t, "could only collect 1 of 2 partial signatures", err)
+}
+
+func TestKeymanager_Sign_SlashingProtection(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 2, 3)
+	_, err := c.nodes[0].Sign(ctx, attestationRequest(t, c.secretKey, 1, 2, 1))
+	require.NoError(t, err)
+
+	// The same target with another block root is a double vote, refused by the share holder which signed the first
+	// attestation for a peer.
+	_, err = c.nodes[0].Sign(ctx, attestationRequest(t, c.secretKey, 1, 2, 2))
+	require.ErrorContains(t, "refusing to sign attestation with target 2", err)
+	signed := 0
+	for _, node := range c.nodes[1:] {
+		if _, err := node.Sign(ctx, attestationRequest(t, c.secretKey, 1, 2, 2)); err == nil {
+			signed++
+		}
+	}
+	assert.Equal(t, 0, signed, "a double vote was signed")
+
+	req := attestationRequest(t, c.secretKey, 2, 3, 1)
+	req.SigningRoot[0]++
+	_, err = c.nodes[0].Sign(ctx, req)
+	require.ErrorContains(t, "does not match the object of the request", err)
+}
+
+func TestKeymanager_ServeHTTP_SlashingProtectionDB(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 2, 2)
+	pubKey := [fieldparams.BLSPubkeyLength]byte(c.secretKey.PublicKey().Marshal())
+	db := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}, false)
+	c.nodes[1].protector = newProtector(db)
+	_, err := c.nodes[0].Sign(ctx, attestationRequest(t, c.secretKey, 1, 2, 1))
+	require.NoError(t, err)
+
+	// After a restart the watermarks are gone, the database still refuses the double vote to the peer.
+	c.nodes[0].protector = newProtector(nil)
+	c.nodes[1].protector = newProtector(db)
+	_, err = c.nodes[0].Sign(ctx, attestationRequest(t, c.secretKey, 1, 2, 2))
+	require.ErrorContains(t, "could only collect 1 of 2 partial signatures", err)
+	_, err = c.nodes[0].Sign(ctx, attestationRequest(t, c.secretKey, 2, 3, 1))
+	require.NoError(t, err)
+}
+
+func TestKeymanager_ServeHTTP_SigningAuditLog(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 2, 2)
+	auditLog, err := audit.Open(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(t, err)
+	c.nodes[1].auditLog = auditLog
+
+	// The partial signature served by the second share holder is recorded in its log.
+	req := attestationRequest(t, c.secretKey, 1, 2, 1)
+	_, err = c.nodes[0].Sign(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Close())
+	f, err := os.Open(auditLog.Path())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+	records, err := audit.Verify(f, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, audit.Signed, records[0].Outcome)
+	assert.Equal(t, fmt.Sprintf("%#x", req.SigningRoot), records[0].SigningRoot)
+}
+
+func TestKeymanager_ServeHTTP_Authentication(t *testing.T) {
+	c := newCluster(t, 2, 2)
+	req := attestationRequest(t, c.secretKey, 1, 2, 1)
+	impostor, err := NewKeymanager(context.Background(), &SetupConfig{
+		ShareID:         2,
+		Threshold:       1,
+		Peers:           map[uint64]string{1: c.servers[0].URL},
+		TransportSecret: make([]byte, TransportSecretLength),
+	})
+	require.NoError(t, err)
+	body, err := proto.Marshal(req)
+	require.NoError(t, err)
+	_, err = impostor.requestPartialSignature(context.Background(), c.servers[0].URL, body)
+	require.ErrorContains(t, fmt.Sprintf("status %d", http.StatusUnauthorized), err)
+
+	b, err := c.nodes[1].requestPartialSignature(context.Background(), c.servers[0].URL, body)
+	require.NoError(t, err)
+	sig, err := bls.SignatureFromBytes(b)
+	require.NoError(t, err)
+	assert.Equal(t, true, sig.Verify(c.nodes[0].shares[[fieldparams.BLSPubkeyLength]byte(req.PublicKey)].SecretKey.PublicKey(), req.SigningRoot))
+}
+
+func TestNewKeymanager(t *testing.T) {
+	ctx := context.Background()
+	secret := make([]byte, TransportSecretLength)
+	_, err := NewKeymanager(ctx, &SetupConfig{ShareID: 1, Threshold: 3, Peers: map[uint64]string{2: ""}, TransportSecret: secret})
+	require.ErrorContains(t, "threshold 3 cannot be reached with 1 peers", err)
+	_, err = NewKeymanager(ctx, &SetupConfig{ShareID: 1, Threshold: 1, TransportSecret: secret[:4]})
+	require.ErrorContains(t, "transport secret must be 32 bytes", err)
+	_, err = NewKeymanager(ctx, &SetupConfig{ShareID: 1, Threshold: 1, Peers: map[uint64]string{1: ""}, TransportSecret: secret})
+	require.ErrorContains(t, "share id 1 of this node is also a peer", err)
+
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	shares, err := bls.SplitSecretKey(sk, 2, 2)
+	require.NoError(t, err)
+	_, err = NewKeymanager(ctx, &SetupConfig{
+		ShareID:         1,
+		Threshold:       2,
+		Peers:           map[uint64]string{2: ""},
+		TransportSecret: secret,
+		Shares: []*Share{{
+			PublicKey:       [fieldparams.BLSPubkeyLength]byte(sk.PublicKey().Marshal()),
+			SharePublicKeys: []bls.PublicKey{shares[1].PublicKey(), shares[0].PublicKey()},
+			SecretKey:       shares[0],
+		}},
+	})
+	require.ErrorContains(t, "secret key is not the share 1", err)
+}
@@ -0,0 +1,25 @@
+package threshold
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	signRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "threshold_sign_requests_total",
+		Help: "Total number of sign requests of the local validator client",
+	})
+	failedSignRequestsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "threshold_failed_sign_requests_total",
+		Help: "Total number of sign requests which were refused or could not collect enough partial signatures",
+	})
+	servedPartialSignaturesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "threshold_served_partial_signatures_total",
+		Help: "Total number of authenticated partial signature requests of peers",
+	})
+	failedServedPartialSignaturesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "threshold_failed_served_partial_signatures_total",
+		Help: "Total number of partial signature requests of peers which were refused",
+	})
+)
@@ -0,0 +1,215 @@
+package threshold
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	fssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
+)
+
+// signedObject returns the object a sign request asks to sign, from which its signing root is computed.
+func signedObject(req *validatorpb.SignRequest) (fssz.HashRoot, error) {
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		return o.Block, nil
+	case *validatorpb.SignRequest_BlockAltair:
+		return o.BlockAltair, nil
+	case *validatorpb.SignRequest_BlockBellatrix:
+		return o.BlockBellatrix, nil
+	case *validatorpb.SignRequest_BlindedBlockBellatrix:
+		return o.BlindedBlockBellatrix, nil
+	case *validatorpb.SignRequest_BlockCapella:
+		return o.BlockCapella, nil
+	case *validatorpb.SignRequest_BlindedBlockCapella:
+		return o.BlindedBlockCapella, nil
+	case *validatorpb.SignRequest_BlockDeneb:
+		return o.BlockDeneb, nil
+	case *validatorpb.SignRequest_BlindedBlockDeneb:
+		return o.BlindedBlockDeneb, nil
+	case *validatorpb.SignRequest_BlockElectra:
+		return o.BlockElectra, nil
+	case *validatorpb.SignRequest_BlindedBlockElectra:
+		return o.BlindedBlockElectra, nil
+	case *validatorpb.SignRequest_AttestationData:
+		return o.AttestationData, nil
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		return o.AggregateAttestationAndProof, nil
+	case *validatorpb.SignRequest_AggregateAttestationAndProofElectra:
+		return o.AggregateAttestationAndProofElectra, nil
+	case *validatorpb.SignRequest_Exit:
+		return o.Exit, nil
+	case *validatorpb.SignRequest_Slot:
+		slot := primitives.SSZUint64(o.Slot)
+		return &slot, nil
+	case *validatorpb.SignRequest_Epoch:
+		epoch := primitives.SSZUint64(o.Epoch)
+		return &epoch, nil
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		return o.SyncAggregatorSelectionData, nil
+	case *validatorpb.SignRequest_ContributionAndProof:
+		return o.ContributionAndProof, nil
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		root := primitives.SSZBytes(o.SyncMessageBlockRoot)
+		return &root, nil
+	case *validatorpb.SignRequest_Registration:
+		return o.Registration, nil
+	default:
+		return nil, fmt.Errorf("unsupported sign request object %T", req.Object)
+	}
+}
+
+// verifySigningRoot checks that the signing root of a request is the one of its object in its domain, so
+// that a peer cannot have a share sign a root the slashing protection did not see.
+func verifySigningRoot(req *validatorpb.SignRequest) error {
+	if req.Object == nil {
+		return errors.New("sign request has no object")
+	}
+	object, err := signedObject(req)
+	if err != nil {
+		return err
+	}
+	if object == nil {
+		return errors.New("sign request object is nil")
+	}
+	root, err := signing.ComputeSigningRoot(object, req.SignatureDomain)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	if !bytes.Equal(root[:], req.SigningRoot) {
+		return fmt.Errorf("signing root %#x does not match the object of the request", req.SigningRoot)
+	}
+	return nil
+}
+
+// watermarks are the highest slot and attestation epochs a share signed for a validator, with the signing
+// roots signed at these heights.
+type watermarks struct {
+	blockSlot       primitives.Slot
+	blockRoot       []byte
+	sourceEpoch     primitives.Epoch
+	targetEpoch     primitives.Epoch
+	attestationRoot []byte
+}
+
+// protector applies the minimal slashing protection of EIP-3076 to the requests signed by the share, whether
+// they come from the local validator client or from a peer. A share never signs a block below its highest
+// block slot, an attestation with a source below its highest source epoch or a target at or below its highest
+// target epoch, unless it is the very message it signed last. Watermarks are kept in memory, so requests are
+// also checked against and recorded in the slashing protection database of the validator client, which
+// survives restarts.
+type protector struct {
+	watermarks map[[fieldparams.BLSPubkeyLength]byte]*watermarks
+	db         iface.ValidatorDB
+	lock       sync.Mutex
+}
+
+func newProtector(db iface.ValidatorDB) *protector {
+	return &protector{watermarks: make(map[[fieldparams.BLSPubkeyLength]byte]*watermarks), db: db}
+}
+
+// checkDatabase returns an error if the slashing protection database finds signing the request slashable,
+// and records it otherwise. The validator client records the same signing roots after signing, which the
+// database accepts as repeated signatures.
+func (p *protector) checkDatabase(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, req *validatorpb.SignRequest) error {
+	if p.db == nil {
+		return nil
+	}
+	signingRoot := bytesutil.ToBytes32(req.SigningRoot)
+	if data, ok := req.Object.(*validatorpb.SignRequest_AttestationData); ok {
+		if data.AttestationData == nil || data.AttestationData.Source == nil || data.AttestationData.Target == nil {
+			return errors.New("attestation data has no checkpoints")
+		}
+		att := &ethpb.IndexedAttestation{
+			Data:      data.AttestationData,
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		}
+		return p.db.SlashableAttestationCheck(ctx, att, pubKey, signingRoot, false, nil)
+	}
+	if _, ok := blockSlot(req); ok {
+		object, err := signedObject(req)
+		if err != nil {
+			return err
+		}
+		blk, err := blocks.NewBeaconBlock(object)
+		if err != nil {
+			return errors.Wrap(err, "could not read block")
+		}
+		signed, err := blocks.BuildSignedBeaconBlock(blk, make([]byte, fieldparams.BLSSignatureLength))
+		if err != nil {
+			return errors.Wrap(err, "could not read block")
+		}
+		return p.db.SlashableProposalCheck(ctx, pubKey, signed, signingRoot, false, nil)
+	}
+	return nil
+}
+
+// checkAndRecord returns an error if signing the request could be slashable, and records it otherwise.
+func (p *protector) checkAndRecord(pubKey [fieldparams.BLSPubkeyLength]byte, req *validatorpb.SignRequest) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	w, ok := p.watermarks[pubKey]
+	if !ok {
+		w = &watermarks{}
+	}
+	if slot, ok := blockSlot(req); ok {
+		if w.blockRoot != nil && (slot < w.blockSlot || (slot == w.blockSlot && !bytes.Equal(req.SigningRoot, w.blockRoot))) {
+			return fmt.Errorf("refusing to sign block at slot %d, a block was already signed at slot %d", slot, w.blockSlot)
+		}
+		w.blockSlot, w.blockRoot = slot, req.SigningRoot
+	}
+	if data, ok := req.Object.(*validatorpb.SignRequest_AttestationData); ok && data.AttestationData != nil {
+		source, target := data.AttestationData.Source, data.AttestationData.Target
+		if source == nil || target == nil {
+			return errors.New("attestation data has no checkpoints")
+		}
+		if w.attestationRoot != nil && !(target.Epoch == w.targetEpoch && bytes.Equal(req.SigningRoot, w.attestationRoot)) {
+			if source.Epoch < w.sourceEpoch {
+				return fmt.Errorf("refusing to sign attestation with source %d, below signed source %d", source.Epoch, w.sourceEpoch)
+			}
+			if target.Epoch <= w.targetEpoch {
+				return fmt.Errorf("refusing to sign attestation with target %d, an attestation was already signed with target %d", target.Epoch, w.targetEpoch)
+			}
+		}
+		w.sourceEpoch, w.targetEpoch, w.attestationRoot = source.Epoch, target.Epoch, req.SigningRoot
+	}
+	p.watermarks[pubKey] = w
+	return nil
+}
+
+func blockSlot(req *validatorpb.SignRequest) (primitives.Slot, bool) {
+	switch o := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		return o.Block.GetSlot(), true
+	case *validatorpb.SignRequest_BlockAltair:
+		return o.BlockAltair.GetSlot(), true
+	case *validatorpb.SignRequest_BlockBellatrix:
+		return o.BlockBellatrix.GetSlot(), true
+	case *validatorpb.SignRequest_BlindedBlockBellatrix:
+		return o.BlindedBlockBellatrix.GetSlot(), true
+	case *validatorpb.SignRequest_BlockCapella:
+		return o.BlockCapella.GetSlot(), true
+	case *validatorpb.SignRequest_BlindedBlockCapella:
+		return o.BlindedBlockCapella.GetSlot(), true
+	case *validatorpb.SignRequest_BlockDeneb:
+		return o.BlockDeneb.GetSlot(), true
+	case *validatorpb.SignRequest_BlindedBlockDeneb:
+		return o.BlindedBlockDeneb.GetSlot(), true
+	case *validatorpb.SignRequest_BlockElectra:
+		return o.BlockElectra.GetSlot(), true
+	case *validatorpb.SignRequest_BlindedBlockElectra:
+		return o.BlindedBlockElectra.GetSlot(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,139 @@
+package threshold
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// TransportSecretLength is the length of the key authenticating the requests between share holders.
+	TransportSecretLength = 32
+	// SignPath is the path on which share holders serve partial signatures.
+	SignPath = "/threshold/v1/sign"
+
+	shareIDHeader   = "Prysm-Threshold-Share-Id"
+	timestampHeader = "Prysm-Threshold-Timestamp"
+	macHeader       = "Prysm-Threshold-Mac"
+	// maxClockSkew bounds the age of the requests a share holder accepts, which limits their replay.
+	maxClockSkew   = 30 * time.Second
+	maxRequestSize = 1 << 22
+)
+
+// requestMAC authenticates a request of a share holder at a unix time in milliseconds.
+func requestMAC(secret []byte, shareID uint64, timestamp int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	var header [16]byte
+	binary.BigEndian.PutUint64(header[:8], shareID)
+	binary.BigEndian.PutUint64(header[8:], uint64(timestamp))
+	mac.Write(header[:])
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// requestPartialSignature asks a peer for the partial signature of its share.
+func (km *Keymanager) requestPartialSignature(ctx context.Context, url string, body []byte) ([]byte, error) {
+	timestamp := time.Now().UnixMilli()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url+SignPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/octet-stream")
+	httpReq.Header.Set(shareIDHeader, strconv.FormatUint(km.shareID, 10))
+	httpReq.Header.Set(timestampHeader, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(macHeader, hex.EncodeToString(requestMAC(km.transportSecret, km.shareID, timestamp, body)))
+	resp, err := km.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxRequestSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer responded with status %d: %s", resp.StatusCode, string(b))
+	}
+	if len(b) != fieldparams.BLSSignatureLength {
+		return nil, fmt.Errorf("peer responded with %d bytes instead of a signature", len(b))
+	}
+	return b, nil
+}
+
+// authenticate checks that a request comes from a peer holding the transport secret, and returns its share id.
+func (km *Keymanager) authenticate(r *http.Request, body []byte) (uint64, error) {
+	shareID, err := strconv.ParseUint(r.Header.Get(shareIDHeader), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid share id")
+	}
+	if _, ok := km.peers[shareID]; !ok {
+		return 0, fmt.Errorf("share id %d is not a peer", shareID)
+	}
+	timestamp, err := strconv.ParseInt(r.Header.Get(timestampHeader), 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid timestamp")
+	}
+	if age := time.Since(time.UnixMilli(timestamp)); age > maxClockSkew || age < -maxClockSkew {
+		return 0, fmt.Errorf("request timestamp is %s away from local time", age)
+	}
+	mac, err := hex.DecodeString(r.Header.Get(macHeader))
+	if err != nil || !hmac.Equal(mac, requestMAC(km.transportSecret, shareID, timestamp, body)) {
+		return 0, errors.New("invalid request authentication code")
+	}
+	return shareID, nil
+}
+
+// ServeHTTP serves the partial signatures of the shares of this node to authenticated peers. The signing root of
+// a request must match its object, which goes through the slashing protection of the share. Served partial
+// signatures are recorded in the signing audit log like the signatures of this node.
+func (km *Keymanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != SignPath {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, "could not read request", http.StatusBadRequest)
+		return
+	}
+	peerID, err := km.authenticate(r, body)
+	if err != nil {
+		log.WithError(err).Warn("Rejected unauthenticated partial signature request")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	req := &validatorpb.SignRequest{}
+	if err := proto.Unmarshal(body, req); err != nil {
+		http.Error(w, "could not decode sign request", http.StatusBadRequest)
+		return
+	}
+	servedPartialSignaturesTotal.Inc()
+	sig, err := km.auditLog.Wrap(km.signShare)(r.Context(), req)
+	if err != nil {
+		failedServedPartialSignaturesTotal.Inc()
+		log.WithError(err).WithField("peer", peerID).Warn("Refused partial signature request")
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err := w.Write(sig.Marshal()); err != nil {
+		log.WithError(err).Debug("Could not write partial signature")
+	}
+}
@@ -88,6 +88,17 @@ type PublicKeyDeleter interface {
 	DeletePublicKeys(publicKeys []string) ([]*KeyStatus, error)
 }
 
+// Refresher can discover keys added to or removed from the keymanager outside of the validator client,
+// notifying the subscribers of account changes if they changed.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// Closer releases the resources held by the keymanager when the validator client shuts down.
+type Closer interface {
+	Close() error
+}
+
 type ListKeymanagerAccountConfig struct {
 	ShowPrivateKeys          bool
 	WalletAccountsDir        string
@@ -120,6 +131,10 @@ const (
 	Derived
 	// Web3Signer keymanager capable of signing data using a remote signer called Web3Signer.
 	Web3Signer
+	// PKCS11 keymanager capable of signing data with keys which never leave a PKCS#11 token, such as an HSM.
+	PKCS11
+	// Threshold keymanager capable of signing data with a share of keys split among several share holders.
+	Threshold
 )
 
 // IncorrectPasswordErrMsg defines a common error string representing an EIP-2335
@@ -139,6 +154,10 @@ func (k Kind) String() string {
 		return "direct"
 	case Web3Signer:
 		return "web3signer"
+	case PKCS11:
+		return "pkcs11"
+	case Threshold:
+		return "threshold"
 	default:
 		return fmt.Sprintf("%d", int(k))
 	}
@@ -153,6 +172,10 @@ func ParseKind(k string) (Kind, error) {
 		return Local, nil
 	case "web3signer":
 		return Web3Signer, nil
+	case "pkcs11":
+		return PKCS11, nil
+	case "threshold":
+		return Threshold, nil
 	default:
 		return 0, fmt.Errorf("%s is not an allowed keymanager", k)
 	}
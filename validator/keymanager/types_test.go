@@ -10,12 +10,16 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/pkcs11"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 )
 
 var (
 	_ = keymanager.IKeymanager(&local.Keymanager{})
 	_ = keymanager.IKeymanager(&derived.Keymanager{})
+	_ = keymanager.IKeymanager(&pkcs11.Keymanager{})
+	_ = keymanager.IKeymanager(&threshold.Keymanager{})
 
 	// More granular assertions.
 	_ = keymanager.KeysFetcher(&local.Keymanager{})
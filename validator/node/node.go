@@ -36,14 +36,18 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/runtime/prereqs"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	"github.com/prysmaticlabs/prysm/v5/validator/db"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/iface"
 	"github.com/prysmaticlabs/prysm/v5/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/validator/db/sqldb"
 	g "github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/local"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/pkcs11"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/threshold"
 	"github.com/prysmaticlabs/prysm/v5/validator/rpc"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -237,6 +241,10 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context, router *http.Se
 		// Custom Check For Web3Signer
 		if isWeb3SignerURLFlagSet {
 			c.wallet = wallet.NewWalletForWeb3Signer(cliCtx)
+		} else if cliCtx.IsSet(flags.PKCS11ModuleFlag.Name) {
+			c.wallet = wallet.NewWalletForPKCS11(cliCtx)
+		} else if cliCtx.IsSet(flags.ThresholdConfigFlag.Name) {
+			c.wallet = wallet.NewWalletForThreshold(cliCtx)
 		} else {
 			w, err := wallet.OpenWalletOrElseCli(cliCtx, func(cliCtx *cli.Context) (*wallet.Wallet, error) {
 				return nil, wallet.ErrNoWalletFound
@@ -277,6 +285,10 @@ func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context, router *http.Ser
 	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
 		// Custom Check For Web3Signer
 		c.wallet = wallet.NewWalletForWeb3Signer(cliCtx)
+	} else if cliCtx.IsSet(flags.PKCS11ModuleFlag.Name) {
+		c.wallet = wallet.NewWalletForPKCS11(cliCtx)
+	} else if cliCtx.IsSet(flags.ThresholdConfigFlag.Name) {
+		c.wallet = wallet.NewWalletForThreshold(cliCtx)
 	} else {
 		// Read the wallet password file from the cli context.
 		if err := setWalletPasswordFilePath(cliCtx); err != nil {
@@ -325,10 +337,17 @@ func (c *ValidatorClient) initializeDB(cliCtx *cli.Context) error {
 	kvDataFile := filepath.Join(kvDataDir, kv.ProtectionDbFileName)
 	walletDir := cliCtx.String(flags.WalletDirFlag.Name)
 	isInteropNumValidatorsSet := cliCtx.IsSet(flags.InteropNumValidators.Name)
-	isWeb3SignerURLFlagSet := cliCtx.IsSet(flags.Web3SignerURLFlag.Name)
+	// Wallets of PKCS#11 tokens and of key shares have no accounts directory either, so they are handled like
+	// web3signer ones.
+	isWeb3SignerURLFlagSet := cliCtx.IsSet(flags.Web3SignerURLFlag.Name) || cliCtx.IsSet(flags.PKCS11ModuleFlag.Name) ||
+		cliCtx.IsSet(flags.ThresholdConfigFlag.Name)
 	clearFlag := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearFlag := cliCtx.Bool(cmd.ForceClearDB.Name)
 
+	if cliCtx.IsSet(flags.SlashingProtectionDBURLFlag.Name) {
+		return c.initializeSQLDB(cliCtx.Context, cliCtx.String(flags.SlashingProtectionDBURLFlag.Name), clearFlag, forceClearFlag)
+	}
+
 	// Workaround for https://github.com/prysmaticlabs/prysm/issues/13391
 	kvDataDir, _, err := c.getLegacyDatabaseLocation(
 		isInteropNumValidatorsSet,
@@ -432,6 +451,40 @@ func (c *ValidatorClient) initializeDB(cliCtx *cli.Context) error {
 	return nil
 }
 
+// initializeSQLDB opens the shared SQL slashing protection database, instead of the database of the data directory.
+func (c *ValidatorClient) initializeSQLDB(ctx context.Context, url string, clearFlag, forceClearFlag bool) error {
+	log.WithField("url", url).Info("Checking shared slashing protection DB")
+	valDB, err := sqldb.NewStore(ctx, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not create validator database")
+	}
+
+	if clearFlag || forceClearFlag {
+		clearDBConfirmed := forceClearFlag
+		if !forceClearFlag {
+			actionText := "This will delete the historical actions of every validator client using the shared database. " +
+				"This may lead to potential slashing - do you want to proceed? (Y/N)"
+			deniedText := "The historical actions database will not be deleted. No changes have been made."
+			clearDBConfirmed, err = cmd.ConfirmAction(actionText, deniedText)
+			if err != nil {
+				return errors.Wrapf(err, "could not clear DB %s", url)
+			}
+		}
+		if clearDBConfirmed {
+			log.Warning("Removing database records")
+			if err := valDB.ClearDB(); err != nil {
+				return errors.Wrapf(err, "could not clear DB %s", url)
+			}
+		}
+	}
+
+	c.db = valDB
+	if err := valDB.RunUpMigrations(ctx); err != nil {
+		return errors.Wrap(err, "could not run database migration")
+	}
+	return nil
+}
+
 func (c *ValidatorClient) registerPrometheusService(cliCtx *cli.Context) error {
 	var additionalHandlers []prometheus.Handler
 	if cliCtx.IsSet(cmd.EnableBackupWebhookFlag.Name) {
@@ -467,6 +520,10 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	}
 
 	// Configure graffiti.
+	graffiti := g.ParseHexGraffiti(c.cliCtx.String(flags.GraffitiFlag.Name))
+	if err := g.ValidateTemplate(graffiti); err != nil {
+		return errors.Wrapf(err, "invalid --%s", flags.GraffitiFlag.Name)
+	}
 	graffitiStruct := &g.Graffiti{}
 	if c.cliCtx.IsSet(flags.GraffitiFileFlag.Name) {
 		graffitiFilePath := c.cliCtx.String(flags.GraffitiFileFlag.Name)
@@ -482,11 +539,34 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	pkcs11Config, err := PKCS11Config(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
+	thresholdConfig, err := ThresholdConfig(c.cliCtx)
+	if err != nil {
+		return err
+	}
+
 	ps, err := proposerSettings(c.cliCtx, c.db)
 	if err != nil {
 		return err
 	}
 
+	var signingAuditLog *audit.Log
+	if c.cliCtx.IsSet(flags.SigningAuditLogFlag.Name) {
+		signingAuditLog, err = audit.Open(c.cliCtx.String(flags.SigningAuditLogFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not open signing audit log")
+		}
+		log.WithField("path", signingAuditLog.Path()).Info("Recording signing requests in signing audit log")
+	}
+	if thresholdConfig != nil {
+		thresholdConfig.SigningAuditLog = signingAuditLog
+		thresholdConfig.SlashingProtectionDB = c.db
+	}
+
 	validatorService, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		DB:                      c.db,
 		Wallet:                  c.wallet,
@@ -499,16 +579,21 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		BeaconNodeCert:          c.cliCtx.String(flags.CertFlag.Name),
 		BeaconApiEndpoint:       c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
 		BeaconApiTimeout:        time.Second * 30,
-		Graffiti:                g.ParseHexGraffiti(c.cliCtx.String(flags.GraffitiFlag.Name)),
+		Graffiti:                graffiti,
 		GraffitiStruct:          graffitiStruct,
 		InteropKmConfig:         interopKmConfig,
 		Web3SignerConfig:        web3signerConfig,
+		PKCS11Config:            pkcs11Config,
+		ThresholdConfig:         thresholdConfig,
+		SigningAuditLog:         signingAuditLog,
 		ProposerSettings:        ps,
 		ValidatorsRegBatchSize:  c.cliCtx.Int(flags.ValidatorsRegistrationBatchSizeFlag.Name),
 		UseWeb:                  c.cliCtx.Bool(flags.EnableWebFlag.Name),
 		LogValidatorPerformance: !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
 		EmitAccountMetrics:      !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
 		Distributed:             c.cliCtx.Bool(flags.EnableDistributed.Name),
+		MultiBeaconNode:         c.cliCtx.Bool(flags.MultiBeaconNodeFlag.Name),
+		BeaconNodeQuorum:        c.cliCtx.Int(flags.BeaconNodeQuorumFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -555,6 +640,60 @@ func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 	return web3signerConfig, nil
 }
 
+// PKCS11Config returns the configuration of the PKCS#11 keymanager, or nil if no PKCS#11 module is set.
+func PKCS11Config(cliCtx *cli.Context) (*pkcs11.SetupConfig, error) {
+	if !cliCtx.IsSet(flags.PKCS11ModuleFlag.Name) {
+		return nil, nil
+	}
+	if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
+		return nil, fmt.Errorf("%s cannot be used with %s", flags.PKCS11ModuleFlag.Name, flags.Web3SignerURLFlag.Name)
+	}
+	for _, f := range []cli.Flag{flags.PKCS11TokenLabelFlag, flags.PKCS11PinFileFlag, flags.PKCS11KeyTypeFlag, flags.PKCS11MechanismFlag} {
+		if !cliCtx.IsSet(f.Names()[0]) {
+			return nil, fmt.Errorf("%s is required with %s", f.Names()[0], flags.PKCS11ModuleFlag.Name)
+		}
+	}
+	pin, err := file.ReadFileAsBytes(cliCtx.String(flags.PKCS11PinFileFlag.Name))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read PKCS#11 PIN file")
+	}
+	if cliCtx.IsSet(flags.WalletPasswordFileFlag.Name) {
+		log.Warnf("%s was provided while using a PKCS#11 token and will be ignored", flags.WalletPasswordFileFlag.Name)
+	}
+	return &pkcs11.SetupConfig{
+		Module: &pkcs11.ModuleConfig{
+			Path:       cliCtx.String(flags.PKCS11ModuleFlag.Name),
+			TokenLabel: cliCtx.String(flags.PKCS11TokenLabelFlag.Name),
+			KeyType:    uint(cliCtx.Uint64(flags.PKCS11KeyTypeFlag.Name)),
+			Mechanism:  uint(cliCtx.Uint64(flags.PKCS11MechanismFlag.Name)),
+		},
+		PIN: strings.TrimSpace(string(pin)),
+	}, nil
+}
+
+// ThresholdConfig returns the configuration of the threshold keymanager, or nil if no threshold configuration is set.
+func ThresholdConfig(cliCtx *cli.Context) (*threshold.SetupConfig, error) {
+	if !cliCtx.IsSet(flags.ThresholdConfigFlag.Name) {
+		return nil, nil
+	}
+	for _, f := range []cli.Flag{flags.Web3SignerURLFlag, flags.PKCS11ModuleFlag} {
+		if cliCtx.IsSet(f.Names()[0]) {
+			return nil, fmt.Errorf("%s cannot be used with %s", flags.ThresholdConfigFlag.Name, f.Names()[0])
+		}
+	}
+	if !cliCtx.IsSet(flags.ThresholdPasswordFileFlag.Name) {
+		return nil, fmt.Errorf("%s is required with %s", flags.ThresholdPasswordFileFlag.Name, flags.ThresholdConfigFlag.Name)
+	}
+	password, err := file.ReadFileAsBytes(cliCtx.String(flags.ThresholdPasswordFileFlag.Name))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read threshold keystores password file")
+	}
+	if cliCtx.IsSet(flags.WalletPasswordFileFlag.Name) {
+		log.Warnf("%s was provided while using key shares and will be ignored", flags.WalletPasswordFileFlag.Name)
+	}
+	return threshold.LoadConfig(cliCtx.String(flags.ThresholdConfigFlag.Name), strings.TrimSpace(string(password)))
+}
+
 func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*proposer.Settings, error) {
 	l, err := loader.NewProposerSettingsLoader(
 		cliCtx,
@@ -262,6 +262,7 @@ func (s *Server) VoluntaryExit(w http.ResponseWriter, r *http.Request) {
 		Keymanager:       km,
 		RawPubKeys:       pubKeys,
 		FormattedPubKeys: req.PublicKeys,
+		SigningAuditLog:  s.validatorService.SigningAuditLog(),
 	}
 	rawExitedKeys, _, err := accounts.PerformVoluntaryExit(ctx, cfg)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts"
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/iface"
 	mock "github.com/prysmaticlabs/prysm/v5/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v5/validator/audit"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
@@ -282,11 +284,14 @@ func TestServer_VoluntaryExit(t *testing.T) {
 	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
 	require.NoError(t, err)
 	require.NoError(t, err)
+	signingAuditLog, err := audit.Open(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(t, err)
 	vs, err := client.NewValidatorService(ctx, &client.Config{
 		Wallet: w,
 		Validator: &mock.Validator{
 			Km: km,
 		},
+		SigningAuditLog: signingAuditLog,
 	})
 	require.NoError(t, err)
 	s := &Server{
@@ -325,4 +330,13 @@ func TestServer_VoluntaryExit(t *testing.T) {
 		require.Equal(t, rawPubKeys[i], hexutil.Encode(res.ExitedKeys[i]))
 	}
 
+	// Both exits are recorded in the signing audit log.
+	require.NoError(t, signingAuditLog.Close())
+	f, err := os.Open(signingAuditLog.Path())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, f.Close()) }()
+	records, err := audit.Verify(f, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, "Exit", records[0].Object)
 }
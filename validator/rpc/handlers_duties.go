@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+)
+
+const (
+	calendarMediaType = "text/calendar; charset=utf-8"
+	// calendarLineLength is the maximum length in octets of a line of an iCalendar file, see RFC 5545.
+	calendarLineLength = 75
+	calendarTimeFormat = "20060102T150405Z"
+)
+
+// GetDuties returns the upcoming attester, proposer, aggregator and sync committee duties of the validators of the
+// client for the current and next epoch. The optional role query parameter, which can be repeated or hold a comma
+// separated list, only returns duties of the given roles.
+func (s *Server) GetDuties(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.web.duties.GetDuties")
+	defer span.End()
+
+	duties, ok := s.dutySchedule(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	data := make([]*Duty, len(duties))
+	for i, duty := range duties {
+		data[i] = &Duty{
+			Pubkey:         fmt.Sprintf("%#x", duty.PubKey),
+			ValidatorIndex: strconv.FormatUint(uint64(duty.ValidatorIndex), 10),
+			Role:           duty.Role.String(),
+			Epoch:          strconv.FormatUint(uint64(duty.Epoch), 10),
+			StartSlot:      strconv.FormatUint(uint64(duty.StartSlot), 10),
+			EndSlot:        strconv.FormatUint(uint64(duty.EndSlot), 10),
+			StartTime:      duty.Start.UTC().Format(time.RFC3339),
+			EndTime:        duty.End.UTC().Format(time.RFC3339),
+		}
+		if duty.Role == iface.RoleAttester || duty.Role == iface.RoleAggregator {
+			data[i].CommitteeIndex = strconv.FormatUint(uint64(duty.CommitteeIndex), 10)
+		}
+	}
+	httputil.WriteJson(w, &GetDutiesResponse{Data: data})
+}
+
+// GetDutiesCalendar returns the duties of GetDuties as an iCalendar feed, with an event per duty, so that
+// maintenance windows can be planned around proposals and sync committee periods.
+func (s *Server) GetDutiesCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "validator.web.duties.GetDutiesCalendar")
+	defer span.End()
+
+	duties, ok := s.dutySchedule(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC().Format(calendarTimeFormat)
+	var b strings.Builder
+	writeCalendarLine(&b, "BEGIN:VCALENDAR")
+	writeCalendarLine(&b, "VERSION:2.0")
+	writeCalendarLine(&b, "PRODID:-//Prysmatic Labs//Prysm Validator Duties//EN")
+	writeCalendarLine(&b, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&b, "X-WR-CALNAME:Validator duties")
+	for _, duty := range duties {
+		role := strings.ReplaceAll(duty.Role.String(), "_", " ")
+		writeCalendarLine(&b, "BEGIN:VEVENT")
+		writeCalendarLine(&b, fmt.Sprintf("UID:%s-%d-%x@prysm", duty.Role, duty.StartSlot, duty.PubKey))
+		writeCalendarLine(&b, "DTSTAMP:"+now)
+		writeCalendarLine(&b, "DTSTART:"+duty.Start.UTC().Format(calendarTimeFormat))
+		writeCalendarLine(&b, "DTEND:"+duty.End.UTC().Format(calendarTimeFormat))
+		writeCalendarLine(&b, fmt.Sprintf("SUMMARY:Validator %d %s duty", duty.ValidatorIndex, role))
+		description := fmt.Sprintf("Validator %d (%#x) %s duty at slot %d of epoch %d", duty.ValidatorIndex, duty.PubKey, role, duty.StartSlot, duty.Epoch)
+		if duty.EndSlot != duty.StartSlot {
+			description = fmt.Sprintf("Validator %d (%#x) %s duty from slot %d to slot %d of epoch %d", duty.ValidatorIndex, duty.PubKey, role, duty.StartSlot, duty.EndSlot, duty.Epoch)
+		}
+		writeCalendarLine(&b, "DESCRIPTION:"+description)
+		writeCalendarLine(&b, "CATEGORIES:"+strings.ToUpper(duty.Role.String()))
+		writeCalendarLine(&b, "END:VEVENT")
+	}
+	writeCalendarLine(&b, "END:VCALENDAR")
+
+	w.Header().Set("Content-Type", calendarMediaType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(b.String())); err != nil {
+		log.WithError(err).Error("Could not write duties calendar")
+	}
+}
+
+// dutySchedule returns the duty schedule of the validator service, filtered by the roles of the request. It writes
+// an error response and returns false if the schedule cannot be read.
+func (s *Server) dutySchedule(ctx context.Context, w http.ResponseWriter, r *http.Request) ([]*iface.Duty, bool) {
+	if s.validatorService == nil {
+		httputil.HandleError(w, "Validator service not ready.", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	roles := make(map[string]bool)
+	for _, param := range r.URL.Query()["role"] {
+		for _, role := range strings.Split(param, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles[role] = true
+			}
+		}
+	}
+	for role := range roles {
+		switch role {
+		case iface.RoleAttester.String(), iface.RoleProposer.String(), iface.RoleAggregator.String(), iface.RoleSyncCommittee.String():
+		default:
+			httputil.HandleError(w, fmt.Sprintf("Invalid role %q", role), http.StatusBadRequest)
+			return nil, false
+		}
+	}
+
+	duties, err := s.validatorService.DutySchedule(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get duty schedule: "+err.Error(), http.StatusServiceUnavailable)
+		return nil, false
+	}
+	if len(roles) == 0 {
+		return duties, true
+	}
+	filtered := make([]*iface.Duty, 0, len(duties))
+	for _, duty := range duties {
+		if roles[duty.Role.String()] {
+			filtered = append(filtered, duty)
+		}
+	}
+	return filtered, true
+}
+
+// writeCalendarLine writes a content line of an iCalendar file, folded to the maximum line length.
+func writeCalendarLine(b *strings.Builder, line string) {
+	limit := calendarLineLength
+	for len(line) > limit {
+		b.WriteString(line[:limit])
+		b.WriteString("\r\n ")
+		line = line[limit:]
+		// Continuation lines start with a space, which counts towards their length.
+		limit = calendarLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/client"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/testutil"
+)
+
+func dutiesServer(t *testing.T) *Server {
+	start := time.Unix(1700000000, 0)
+	m := &testutil.FakeValidator{DutyScheduleRet: []*iface.Duty{
+		{
+			PubKey:         [fieldparams.BLSPubkeyLength]byte{1},
+			ValidatorIndex: 1,
+			Role:           iface.RoleSyncCommittee,
+			StartSlot:      0,
+			EndSlot:        31,
+			Start:          start,
+			End:            start.Add(384 * time.Second),
+		},
+		{
+			PubKey:         [fieldparams.BLSPubkeyLength]byte{1},
+			ValidatorIndex: 1,
+			Role:           iface.RoleAttester,
+			StartSlot:      3,
+			EndSlot:        3,
+			CommitteeIndex: 2,
+			Start:          start.Add(36 * time.Second),
+			End:            start.Add(48 * time.Second),
+		},
+	}}
+	vs, err := client.NewValidatorService(context.Background(), &client.Config{Validator: m})
+	require.NoError(t, err)
+	return &Server{validatorService: vs}
+}
+
+func TestServer_GetDuties(t *testing.T) {
+	s := dutiesServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/validator/duties", nil)
+	w := httptest.NewRecorder()
+	s.GetDuties(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp := &GetDutiesResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, "sync_committee", resp.Data[0].Role)
+	assert.Equal(t, "31", resp.Data[0].EndSlot)
+	assert.Equal(t, "", resp.Data[0].CommitteeIndex)
+	assert.Equal(t, "attester", resp.Data[1].Role)
+	assert.Equal(t, "2", resp.Data[1].CommitteeIndex)
+	assert.Equal(t, "2023-11-14T22:13:56Z", resp.Data[1].StartTime)
+
+	req = httptest.NewRequest(http.MethodGet, "/v2/validator/duties?role=proposer,attester", nil)
+	w = httptest.NewRecorder()
+	s.GetDuties(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = &GetDutiesResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, "attester", resp.Data[0].Role)
+
+	req = httptest.NewRequest(http.MethodGet, "/v2/validator/duties?role=builder", nil)
+	w = httptest.NewRecorder()
+	s.GetDuties(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.StringContains(t, "Invalid role", w.Body.String())
+
+	w = httptest.NewRecorder()
+	(&Server{}).GetDuties(w, httptest.NewRequest(http.MethodGet, "/v2/validator/duties", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestServer_GetDutiesCalendar(t *testing.T) {
+	s := dutiesServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/validator/duties/calendar?role=sync_committee", nil)
+	w := httptest.NewRecorder()
+	s.GetDutiesCalendar(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, calendarMediaType, w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Equal(t, true, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.Equal(t, true, strings.HasSuffix(body, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Equal(t, 1, strings.Count(body, "BEGIN:VEVENT"))
+	assert.StringContains(t, "DTSTART:20231114T221320Z\r\n", body)
+	assert.StringContains(t, "DTEND:20231114T221944Z\r\n", body)
+	assert.StringContains(t, "SUMMARY:Validator 1 sync committee duty\r\n", body)
+	for _, line := range strings.Split(body, "\r\n") {
+		assert.Equal(t, true, len(line) <= calendarLineLength, "line longer than %d octets: %s", calendarLineLength, line)
+	}
+	// Folded lines are unfolded by removing a line break followed by a space.
+	assert.StringContains(t, "from slot 0 to slot 31 of epoch 0", strings.ReplaceAll(body, "\r\n ", ""))
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	"github.com/prysmaticlabs/prysm/v5/validator/client"
+	"github.com/prysmaticlabs/prysm/v5/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/derived"
 	slashingprotection "github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history"
@@ -366,7 +367,7 @@ func (s *Server) SetVoluntaryExit(w http.ResponseWriter, r *http.Request) {
 	sve, err := client.CreateSignedVoluntaryExit(
 		ctx,
 		s.beaconNodeValidatorClient,
-		km.Sign,
+		s.validatorService.SigningAuditLog().Wrap(km.Sign),
 		pubkey,
 		epoch,
 	)
@@ -914,6 +915,10 @@ func (s *Server) SetGraffiti(w http.ResponseWriter, r *http.Request) {
 		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if err := graffiti.ValidateTemplate(req.Graffiti); err != nil {
+		httputil.HandleError(w, "Invalid graffiti: "+err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	if err := s.validatorService.SetGraffiti(ctx, bytesutil.ToBytes48(pubkey), []byte(req.Graffiti)); err != nil {
 		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
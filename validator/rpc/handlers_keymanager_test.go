@@ -1981,3 +1981,43 @@ func TestServer_Graffiti(t *testing.T) {
 	s.DeleteGraffiti(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestServer_SetGraffiti_Template(t *testing.T) {
+	m := &mock.Validator{}
+	vs, err := client.NewValidatorService(context.Background(), &client.Config{
+		Validator: m,
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+	}
+	pubkey := "0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"
+
+	t.Run("valid template", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), strings.NewReader(`{"graffiti":"{{.ELClient}}{{.CLClient}} {{.Pubkey | short}}"}`))
+		req.SetPathValue("pubkey", pubkey)
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.SetGraffiti(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), nil)
+		req.SetPathValue("pubkey", pubkey)
+		w = httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.GetGraffiti(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &GetGraffitiResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		assert.Equal(t, "{{.ELClient}}{{.CLClient}} {{.Pubkey | short}}", resp.Data.Graffiti)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/eth/v1/validator/{pubkey}/graffiti"), strings.NewReader(`{"graffiti":"{{.Unknown}}"}`))
+		req.SetPathValue("pubkey", pubkey)
+		w := httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		s.SetGraffiti(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.StringContains(t, "Invalid graffiti", w.Body.String())
+	})
+}
@@ -208,6 +208,9 @@ func (s *Server) InitializeRoutes() error {
 	s.router.HandleFunc("GET "+api.WebUrlPrefix+"beacon/validators", s.GetValidators)
 	s.router.HandleFunc("GET "+api.WebUrlPrefix+"beacon/balances", s.GetValidatorBalances)
 	s.router.HandleFunc("GET "+api.WebUrlPrefix+"beacon/peers", s.GetPeers)
+	// Duty schedule endpoints.
+	s.router.HandleFunc("GET "+api.WebUrlPrefix+"duties", s.GetDuties)
+	s.router.HandleFunc("GET "+api.WebUrlPrefix+"duties/calendar", s.GetDutiesCalendar)
 	// web wallet endpoints
 	s.router.HandleFunc("GET "+api.WebUrlPrefix+"wallet", s.WalletConfig)
 	s.router.HandleFunc("POST "+api.WebUrlPrefix+"wallet/create", s.CreateWallet)
@@ -109,6 +109,23 @@ type GraffitiData struct {
 	Graffiti string `json:"graffiti"`
 }
 
+// duty schedule api
+type GetDutiesResponse struct {
+	Data []*Duty `json:"data"`
+}
+
+type Duty struct {
+	Pubkey         string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Role           string `json:"role"`
+	Epoch          string `json:"epoch"`
+	StartSlot      string `json:"start_slot"`
+	EndSlot        string `json:"end_slot"`
+	CommitteeIndex string `json:"committee_index,omitempty"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
+}
+
 type BeaconStatusResponse struct {
 	BeaconNodeEndpoint     string     `json:"beacon_node_endpoint"`
 	Connected              bool       `json:"connected"`
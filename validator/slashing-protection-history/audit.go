@@ -0,0 +1,246 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/slashings"
+	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+// IssueKind is the kind of problem found in a slashing protection history.
+type IssueKind string
+
+const (
+	// DoubleProposal is a slot signed with different, or unknown, signing roots.
+	DoubleProposal IssueKind = "double proposal"
+	// DoubleVote is a target epoch signed with different, or unknown, signing roots.
+	DoubleVote IssueKind = "double vote"
+	// SurroundVote is an attestation surrounding another one.
+	SurroundVote IssueKind = "surround vote"
+	// InvalidAttestation is an attestation with a source epoch greater than its target epoch.
+	InvalidAttestation IssueKind = "invalid attestation"
+	// Gap is a range of target epochs without any signed attestation, between two signed ones.
+	Gap IssueKind = "gap"
+	// Missing is a record of a compared history which is not in the base history.
+	Missing IssueKind = "missing"
+)
+
+// Issue is a problem found in the slashing protection history of a validator.
+type Issue struct {
+	PubKey  [fieldparams.BLSPubkeyLength]byte
+	Kind    IssueKind
+	Message string
+}
+
+// Slashable is true if the issue is made of messages which could get the validator slashed. Importing a history
+// with such an issue makes Prysm refuse to sign anything with the key.
+func (i *Issue) Slashable() bool {
+	switch i.Kind {
+	case DoubleProposal, DoubleVote, SurroundVote, InvalidAttestation:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	baseOrigin = iota
+	otherOrigin
+)
+
+// AuditStandardProtectionJSON scans an EIP-3076 slashing protection history, for instance one exported from the
+// validator database, for records which are slashable with respect to each other, and for gaps between the
+// target epochs of the attestations of a validator.
+func AuditStandardProtectionJSON(data *format.EIPSlashingProtectionFormat) ([]*Issue, error) {
+	if data == nil {
+		return nil, errors.New("nil slashing protection history")
+	}
+	if _, err := helpers.ValidateFormat(data); err != nil {
+		return nil, errors.Wrap(err, "invalid slashing protection history metadata")
+	}
+	h := make(histories)
+	if err := h.add(data, baseOrigin); err != nil {
+		return nil, err
+	}
+	issues := make([]*Issue, 0)
+	for _, pubKey := range h.sortedPubKeys() {
+		history := h[pubKey]
+		history.sort()
+		issues = append(issues, history.conflicts(pubKey, false)...)
+		issues = append(issues, history.gaps(pubKey)...)
+	}
+	return issues, nil
+}
+
+// DiffStandardProtectionJSON compares an EIP-3076 slashing protection history to a base one, typically a file
+// about to be imported to the history exported from the validator database. It returns the records of both
+// histories which are slashable with respect to each other, and the records of the compared history missing
+// from the base one. Issues within a single history are left to AuditStandardProtectionJSON.
+func DiffStandardProtectionJSON(base, other *format.EIPSlashingProtectionFormat) ([]*Issue, error) {
+	if base == nil || other == nil {
+		return nil, errors.New("nil slashing protection history")
+	}
+	baseRoot, err := helpers.ValidateFormat(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base history metadata")
+	}
+	otherRoot, err := helpers.ValidateFormat(other)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid compared history metadata")
+	}
+	if baseRoot != otherRoot {
+		return nil, fmt.Errorf("compared history has genesis validators root %#x, base history has %#x", otherRoot, baseRoot)
+	}
+	h := make(histories)
+	if err := h.add(base, baseOrigin); err != nil {
+		return nil, errors.Wrap(err, "could not parse base history")
+	}
+	if err := h.add(other, otherOrigin); err != nil {
+		return nil, errors.Wrap(err, "could not parse compared history")
+	}
+	issues := make([]*Issue, 0)
+	for _, pubKey := range h.sortedPubKeys() {
+		history := h[pubKey]
+		history.sort()
+		issues = append(issues, history.conflicts(pubKey, true)...)
+		issues = append(issues, history.missing(pubKey)...)
+	}
+	return issues, nil
+}
+
+// conflicts returns the slashable pairs of records of a sorted history. When crossOrigin is set, only pairs of
+// records of different origins are considered.
+func (s *signingHistory) conflicts(pubKey [fieldparams.BLSPubkeyLength]byte, crossOrigin bool) []*Issue {
+	issues := make([]*Issue, 0)
+	paired := func(a, b int) bool {
+		return !crossOrigin || a != b
+	}
+
+	// Blocks are sorted by slot, so double proposals are within runs of the same slot.
+	for i := 0; i < len(s.blocks); i++ {
+		for j := i + 1; j < len(s.blocks) && s.blocks[j].slot == s.blocks[i].slot; j++ {
+			a, b := s.blocks[i], s.blocks[j]
+			if paired(a.origin, b.origin) && slashings.SigningRootsDiffer(a.signingRoot, b.signingRoot) {
+				issues = append(issues, &Issue{
+					PubKey: pubKey,
+					Kind:   DoubleProposal,
+					Message: fmt.Sprintf(
+						"slot %d signed with signing roots %s and %s", a.slot, describeRoot(a.signingRoot), describeRoot(b.signingRoot),
+					),
+				})
+			}
+		}
+	}
+
+	// Attestations are sorted by target epoch, so double votes are within runs of the same target.
+	for i := 0; i < len(s.attestations); i++ {
+		a := s.attestations[i]
+		if a.source > a.target && !crossOrigin {
+			issues = append(issues, &Issue{
+				PubKey:  pubKey,
+				Kind:    InvalidAttestation,
+				Message: fmt.Sprintf("source epoch %d is greater than target epoch %d", a.source, a.target),
+			})
+		}
+		for j := i + 1; j < len(s.attestations) && s.attestations[j].target == a.target; j++ {
+			b := s.attestations[j]
+			if paired(a.origin, b.origin) && slashings.SigningRootsDiffer(a.signingRoot, b.signingRoot) {
+				issues = append(issues, &Issue{
+					PubKey: pubKey,
+					Kind:   DoubleVote,
+					Message: fmt.Sprintf(
+						"target epoch %d signed with signing roots %s and %s", a.target, describeRoot(a.signingRoot), describeRoot(b.signingRoot),
+					),
+				})
+			}
+		}
+	}
+
+	// An attestation is surrounded if an attestation with a strictly lower source has a strictly higher target.
+	// Going through attestations by increasing source, we keep the attestation with the highest target among
+	// those with a lower source, for each origin.
+	bySource := make([]*signedAttestation, len(s.attestations))
+	copy(bySource, s.attestations)
+	sort.SliceStable(bySource, func(i, j int) bool {
+		return bySource[i].source < bySource[j].source
+	})
+	highest := make(map[int]*signedAttestation)
+	for i := 0; i < len(bySource); {
+		j := i
+		for ; j < len(bySource) && bySource[j].source == bySource[i].source; j++ {
+			inner := bySource[j]
+			for origin, outer := range highest {
+				if paired(origin, inner.origin) && outer.target > inner.target {
+					issues = append(issues, &Issue{
+						PubKey: pubKey,
+						Kind:   SurroundVote,
+						Message: fmt.Sprintf(
+							"attestation with source %d and target %d surrounds attestation with source %d and target %d",
+							outer.source, outer.target, inner.source, inner.target,
+						),
+					})
+					break
+				}
+			}
+		}
+		for ; i < j; i++ {
+			if h, ok := highest[bySource[i].origin]; !ok || bySource[i].target > h.target {
+				highest[bySource[i].origin] = bySource[i]
+			}
+		}
+	}
+	return issues
+}
+
+// gaps returns the ranges of target epochs without attestations between the attestations of a sorted history.
+func (s *signingHistory) gaps(pubKey [fieldparams.BLSPubkeyLength]byte) []*Issue {
+	issues := make([]*Issue, 0)
+	for i := 1; i < len(s.attestations); i++ {
+		prev, next := s.attestations[i-1].target, s.attestations[i].target
+		if next > prev+1 {
+			issues = append(issues, &Issue{
+				PubKey:  pubKey,
+				Kind:    Gap,
+				Message: fmt.Sprintf("no attestation signed for target epochs %d to %d", prev+1, next-1),
+			})
+		}
+	}
+	return issues
+}
+
+// missing returns the records of the compared history which are not in the base history.
+func (s *signingHistory) missing(pubKey [fieldparams.BLSPubkeyLength]byte) []*Issue {
+	issues := make([]*Issue, 0)
+	for _, blk := range s.blocks {
+		if blk.origin == otherOrigin && !s.seen[blockKey(baseOrigin, blk.slot, blk.signingRoot)] {
+			issues = append(issues, &Issue{
+				PubKey:  pubKey,
+				Kind:    Missing,
+				Message: fmt.Sprintf("block at slot %d with signing root %s", blk.slot, describeRoot(blk.signingRoot)),
+			})
+		}
+	}
+	for _, att := range s.attestations {
+		if att.origin == otherOrigin && !s.seen[attestationKey(baseOrigin, att.source, att.target, att.signingRoot)] {
+			issues = append(issues, &Issue{
+				PubKey: pubKey,
+				Kind:   Missing,
+				Message: fmt.Sprintf(
+					"attestation with source %d and target %d with signing root %s", att.source, att.target, describeRoot(att.signingRoot),
+				),
+			})
+		}
+	}
+	return issues
+}
+
+func describeRoot(root []byte) string {
+	if len(root) == 0 {
+		return "unknown"
+	}
+	return fmt.Sprintf("%#x", root)
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+func issueKinds(issues []*Issue) []IssueKind {
+	kinds := make([]IssueKind, len(issues))
+	for i, issue := range issues {
+		kinds[i] = issue.Kind
+	}
+	return kinds
+}
+
+func TestAuditStandardProtectionJSON(t *testing.T) {
+	tests := []struct {
+		name         string
+		blocks       []*format.SignedBlock
+		attestations []*format.SignedAttestation
+		want         []IssueKind
+	}{
+		{
+			name:   "consistent history",
+			blocks: []*format.SignedBlock{{Slot: "1", SigningRoot: rootHex(1)}, {Slot: "2", SigningRoot: rootHex(2)}},
+			attestations: []*format.SignedAttestation{
+				{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(1)},
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: rootHex(2)},
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: rootHex(2)},
+			},
+			want: []IssueKind{},
+		},
+		{
+			name:   "double proposal",
+			blocks: []*format.SignedBlock{{Slot: "1", SigningRoot: rootHex(1)}, {Slot: "1", SigningRoot: rootHex(2)}},
+			want:   []IssueKind{DoubleProposal},
+		},
+		{
+			name:   "double proposal with unknown signing root",
+			blocks: []*format.SignedBlock{{Slot: "1"}, {Slot: "1", SigningRoot: rootHex(2)}},
+			want:   []IssueKind{DoubleProposal},
+		},
+		{
+			name: "double vote",
+			attestations: []*format.SignedAttestation{
+				{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(1)},
+				{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(2)},
+			},
+			want: []IssueKind{DoubleVote},
+		},
+		{
+			name: "surround vote",
+			attestations: []*format.SignedAttestation{
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: rootHex(3)},
+				{SourceEpoch: "1", TargetEpoch: "4", SigningRoot: rootHex(4)},
+			},
+			want: []IssueKind{SurroundVote},
+		},
+		{
+			name: "invalid attestation and gap",
+			attestations: []*format.SignedAttestation{
+				{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(1)},
+				{SourceEpoch: "5", TargetEpoch: "4", SigningRoot: rootHex(4)},
+			},
+			want: []IssueKind{InvalidAttestation, Gap},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := AuditStandardProtectionJSON(protectionFile(1, &format.ProtectionData{
+				Pubkey:             pubKeyHex(1),
+				SignedBlocks:       tt.blocks,
+				SignedAttestations: tt.attestations,
+			}))
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.want, issueKinds(issues))
+		})
+	}
+}
+
+func TestAuditStandardProtectionJSON_Gap(t *testing.T) {
+	issues, err := AuditStandardProtectionJSON(protectionFile(1, &format.ProtectionData{
+		Pubkey: pubKeyHex(1),
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(1)},
+			{SourceEpoch: "1", TargetEpoch: "5", SigningRoot: rootHex(5)},
+		},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(issues))
+	assert.Equal(t, "no attestation signed for target epochs 2 to 4", issues[0].Message)
+	assert.Equal(t, false, issues[0].Slashable())
+}
+
+func TestDiffStandardProtectionJSON(t *testing.T) {
+	local := protectionFile(1, &format.ProtectionData{
+		Pubkey:       pubKeyHex(1),
+		SignedBlocks: []*format.SignedBlock{{Slot: "1", SigningRoot: rootHex(1)}},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(1)},
+			{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: rootHex(4)},
+		},
+	})
+	// Slashable records within the compared file are not reported, only those conflicting with the base history.
+	file := protectionFile(1, &format.ProtectionData{
+		Pubkey:       pubKeyHex(1),
+		SignedBlocks: []*format.SignedBlock{{Slot: "1", SigningRoot: rootHex(1)}, {Slot: "2", SigningRoot: rootHex(2)}},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "0", TargetEpoch: "1", SigningRoot: rootHex(2)},
+			{SourceEpoch: "2", TargetEpoch: "5", SigningRoot: rootHex(5)},
+		},
+	})
+
+	issues, err := DiffStandardProtectionJSON(local, file)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []IssueKind{DoubleVote, SurroundVote, Missing, Missing, Missing}, issueKinds(issues))
+	assert.Equal(t, "attestation with source 2 and target 5 surrounds attestation with source 3 and target 4", issues[1].Message)
+	assert.Equal(t, true, issues[1].Slashable())
+
+	issues, err = DiffStandardProtectionJSON(local, local)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(issues))
+
+	_, err = DiffStandardProtectionJSON(local, protectionFile(2))
+	require.ErrorContains(t, "compared history has genesis validators root", err)
+
+	// Files an import would reject are rejected.
+	unsupported := protectionFile(1)
+	unsupported.Metadata.InterchangeFormatVersion = "4"
+	_, err = DiffStandardProtectionJSON(local, unsupported)
+	require.ErrorContains(t, "slashing protection JSON version '4' is not supported", err)
+	_, err = AuditStandardProtectionJSON(unsupported)
+	require.ErrorContains(t, "slashing protection JSON version '4' is not supported", err)
+	invalidRoot := protectionFile(1)
+	invalidRoot.Metadata.GenesisValidatorsRoot = "0x1234"
+	_, err = DiffStandardProtectionJSON(local, invalidRoot)
+	require.ErrorContains(t, "invalid compared history metadata", err)
+}
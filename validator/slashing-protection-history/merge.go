@@ -0,0 +1,240 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/validator/helpers"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+// signedBlock is a parsed block of an EIP-3076 file, tagged with the origin of the history it was read from.
+type signedBlock struct {
+	slot        primitives.Slot
+	signingRoot []byte
+	origin      int
+}
+
+// signedAttestation is a parsed attestation of an EIP-3076 file, tagged with the origin of the history it was
+// read from.
+type signedAttestation struct {
+	source      primitives.Epoch
+	target      primitives.Epoch
+	signingRoot []byte
+	origin      int
+}
+
+// signingHistory is the deduplicated signing history of a validator.
+type signingHistory struct {
+	blocks       []*signedBlock
+	attestations []*signedAttestation
+	seen         map[string]bool
+}
+
+// histories are the signing histories of validators, by public key.
+type histories map[[fieldparams.BLSPubkeyLength]byte]*signingHistory
+
+// add parses the records of an EIP-3076 file into the histories. A record is added once, even if it is repeated
+// in the file or was already added from the same origin.
+func (h histories) add(data *format.EIPSlashingProtectionFormat, origin int) error {
+	for _, item := range data.Data {
+		if item == nil {
+			continue
+		}
+		pubKey, err := helpers.PubKeyFromHex(item.Pubkey)
+		if err != nil {
+			return errors.Wrapf(err, "%s is not a valid public key", item.Pubkey)
+		}
+		history, ok := h[pubKey]
+		if !ok {
+			history = &signingHistory{seen: make(map[string]bool)}
+			h[pubKey] = history
+		}
+		for _, blk := range item.SignedBlocks {
+			if blk == nil {
+				continue
+			}
+			slot, err := helpers.SlotFromString(blk.Slot)
+			if err != nil {
+				return errors.Wrapf(err, "%s is not a valid slot", blk.Slot)
+			}
+			root, err := optionalRoot(blk.SigningRoot)
+			if err != nil {
+				return err
+			}
+			key := blockKey(origin, slot, root)
+			if history.seen[key] {
+				continue
+			}
+			history.seen[key] = true
+			history.blocks = append(history.blocks, &signedBlock{slot: slot, signingRoot: root, origin: origin})
+		}
+		for _, att := range item.SignedAttestations {
+			if att == nil {
+				continue
+			}
+			source, err := helpers.EpochFromString(att.SourceEpoch)
+			if err != nil {
+				return errors.Wrapf(err, "%s is not a valid source epoch", att.SourceEpoch)
+			}
+			target, err := helpers.EpochFromString(att.TargetEpoch)
+			if err != nil {
+				return errors.Wrapf(err, "%s is not a valid target epoch", att.TargetEpoch)
+			}
+			root, err := optionalRoot(att.SigningRoot)
+			if err != nil {
+				return err
+			}
+			key := attestationKey(origin, source, target, root)
+			if history.seen[key] {
+				continue
+			}
+			history.seen[key] = true
+			history.attestations = append(history.attestations, &signedAttestation{
+				source:      source,
+				target:      target,
+				signingRoot: root,
+				origin:      origin,
+			})
+		}
+	}
+	return nil
+}
+
+func blockKey(origin int, slot primitives.Slot, root []byte) string {
+	return fmt.Sprintf("block/%d/%d/%x", origin, slot, root)
+}
+
+func attestationKey(origin int, source, target primitives.Epoch, root []byte) string {
+	return fmt.Sprintf("attestation/%d/%d/%d/%x", origin, source, target, root)
+}
+
+// optionalRoot parses a signing root, which EIP-3076 allows to omit.
+func optionalRoot(str string) ([]byte, error) {
+	if str == "" {
+		return nil, nil
+	}
+	root, err := helpers.RootFromHex(str)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s is not a valid signing root", str)
+	}
+	return root[:], nil
+}
+
+// MergeStandardProtectionJSON combines EIP-3076 slashing protection files of the same chain into one. The merge
+// is conservative: every record of every file is kept, so the merged file holds the highest slot, source and
+// target epochs of each validator along with every signing root it ever signed. Histories which are slashable
+// with respect to each other are kept as is, see AuditStandardProtectionJSON to find them before importing the
+// merged file.
+func MergeStandardProtectionJSON(files ...*format.EIPSlashingProtectionFormat) (*format.EIPSlashingProtectionFormat, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no slashing protection file to merge")
+	}
+	merged := &format.EIPSlashingProtectionFormat{}
+	merged.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	var genesisValidatorsRoot [32]byte
+	h := make(histories)
+	for i, f := range files {
+		if f == nil {
+			return nil, fmt.Errorf("slashing protection file %d is nil", i)
+		}
+		if f.Metadata.InterchangeFormatVersion != format.InterchangeFormatVersion {
+			return nil, fmt.Errorf(
+				"slashing protection file %d has version '%s', wanted '%s'",
+				i, f.Metadata.InterchangeFormatVersion, format.InterchangeFormatVersion,
+			)
+		}
+		gvr, err := helpers.RootFromHex(f.Metadata.GenesisValidatorsRoot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "slashing protection file %d has an invalid genesis validators root", i)
+		}
+		if i == 0 {
+			genesisValidatorsRoot = gvr
+			merged.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", gvr)
+		} else if gvr != genesisValidatorsRoot {
+			return nil, fmt.Errorf(
+				"slashing protection file %d has genesis validators root %#x, other files have %#x",
+				i, gvr, genesisValidatorsRoot,
+			)
+		}
+		// All files share the same origin, so that records repeated across files are merged.
+		if err := h.add(f, baseOrigin); err != nil {
+			return nil, errors.Wrapf(err, "could not parse slashing protection file %d", i)
+		}
+	}
+	merged.Data = h.toStandard()
+	return merged, nil
+}
+
+// toStandard formats the histories as EIP-3076 data, sorted by public key, slot and epochs.
+func (h histories) toStandard() []*format.ProtectionData {
+	pubKeys := h.sortedPubKeys()
+	data := make([]*format.ProtectionData, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		history := h[pubKey]
+		history.sort()
+		item := &format.ProtectionData{
+			Pubkey:             fmt.Sprintf("%#x", pubKey),
+			SignedBlocks:       make([]*format.SignedBlock, 0, len(history.blocks)),
+			SignedAttestations: make([]*format.SignedAttestation, 0, len(history.attestations)),
+		}
+		for _, blk := range history.blocks {
+			item.SignedBlocks = append(item.SignedBlocks, &format.SignedBlock{
+				Slot:        fmt.Sprintf("%d", blk.slot),
+				SigningRoot: rootToString(blk.signingRoot),
+			})
+		}
+		for _, att := range history.attestations {
+			item.SignedAttestations = append(item.SignedAttestations, &format.SignedAttestation{
+				SourceEpoch: fmt.Sprintf("%d", att.source),
+				TargetEpoch: fmt.Sprintf("%d", att.target),
+				SigningRoot: rootToString(att.signingRoot),
+			})
+		}
+		data = append(data, item)
+	}
+	return data
+}
+
+func (h histories) sortedPubKeys() [][fieldparams.BLSPubkeyLength]byte {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(h))
+	for pubKey := range h {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+	return pubKeys
+}
+
+// sort orders blocks by slot and attestations by target then source epoch.
+func (s *signingHistory) sort() {
+	sort.SliceStable(s.blocks, func(i, j int) bool {
+		a, b := s.blocks[i], s.blocks[j]
+		if a.slot != b.slot {
+			return a.slot < b.slot
+		}
+		return bytes.Compare(a.signingRoot, b.signingRoot) < 0
+	})
+	sort.SliceStable(s.attestations, func(i, j int) bool {
+		a, b := s.attestations[i], s.attestations[j]
+		if a.target != b.target {
+			return a.target < b.target
+		}
+		if a.source != b.source {
+			return a.source < b.source
+		}
+		return bytes.Compare(a.signingRoot, b.signingRoot) < 0
+	})
+}
+
+func rootToString(root []byte) string {
+	if len(root) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%#x", root)
+}
@@ -0,0 +1,127 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	dbtest "github.com/prysmaticlabs/prysm/v5/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/validator/slashing-protection-history/format"
+)
+
+func protectionFile(gvr byte, data ...*format.ProtectionData) *format.EIPSlashingProtectionFormat {
+	f := &format.EIPSlashingProtectionFormat{Data: data}
+	f.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	f.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [32]byte{gvr})
+	return f
+}
+
+func pubKeyHex(b byte) string {
+	return fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{b})
+}
+
+func rootHex(b byte) string {
+	return fmt.Sprintf("%#x", [32]byte{b})
+}
+
+func TestMergeStandardProtectionJSON(t *testing.T) {
+	first := protectionFile(1, &format.ProtectionData{
+		Pubkey:       pubKeyHex(1),
+		SignedBlocks: []*format.SignedBlock{{Slot: "10", SigningRoot: rootHex(10)}},
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: rootHex(2)},
+			{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: rootHex(3)},
+		},
+	})
+	second := protectionFile(1,
+		&format.ProtectionData{
+			Pubkey:       pubKeyHex(1),
+			SignedBlocks: []*format.SignedBlock{{Slot: "12", SigningRoot: rootHex(12)}, {Slot: "10", SigningRoot: rootHex(10)}},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "3", TargetEpoch: "5", SigningRoot: rootHex(5)},
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: rootHex(3)},
+			},
+		},
+		&format.ProtectionData{
+			Pubkey:             pubKeyHex(2),
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "0", TargetEpoch: "1"}},
+		},
+	)
+
+	merged, err := MergeStandardProtectionJSON(first, second)
+	require.NoError(t, err)
+	assert.Equal(t, format.InterchangeFormatVersion, merged.Metadata.InterchangeFormatVersion)
+	assert.Equal(t, rootHex(1), merged.Metadata.GenesisValidatorsRoot)
+	require.Equal(t, 2, len(merged.Data))
+	assert.Equal(t, pubKeyHex(1), merged.Data[0].Pubkey)
+	assert.DeepEqual(t, []*format.SignedBlock{
+		{Slot: "10", SigningRoot: rootHex(10)},
+		{Slot: "12", SigningRoot: rootHex(12)},
+	}, merged.Data[0].SignedBlocks)
+	assert.DeepEqual(t, []*format.SignedAttestation{
+		{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: rootHex(2)},
+		{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: rootHex(3)},
+		{SourceEpoch: "3", TargetEpoch: "5", SigningRoot: rootHex(5)},
+	}, merged.Data[0].SignedAttestations)
+	assert.Equal(t, pubKeyHex(2), merged.Data[1].Pubkey)
+	assert.Equal(t, 0, len(merged.Data[1].SignedBlocks))
+	assert.DeepEqual(t, []*format.SignedAttestation{{SourceEpoch: "0", TargetEpoch: "1"}}, merged.Data[1].SignedAttestations)
+
+	// Conflicting records are all kept.
+	conflicting := protectionFile(1, &format.ProtectionData{
+		Pubkey:       pubKeyHex(1),
+		SignedBlocks: []*format.SignedBlock{{Slot: "12", SigningRoot: rootHex(13)}},
+	})
+	merged, err = MergeStandardProtectionJSON(first, second, conflicting)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(merged.Data[0].SignedBlocks))
+
+	_, err = MergeStandardProtectionJSON()
+	require.ErrorContains(t, "no slashing protection file to merge", err)
+	_, err = MergeStandardProtectionJSON(first, protectionFile(2))
+	require.ErrorContains(t, "slashing protection file 1 has genesis validators root", err)
+	old := protectionFile(1)
+	old.Metadata.InterchangeFormatVersion = "4"
+	_, err = MergeStandardProtectionJSON(first, old)
+	require.ErrorContains(t, "has version '4'", err)
+	invalid := protectionFile(1, &format.ProtectionData{
+		Pubkey:       pubKeyHex(1),
+		SignedBlocks: []*format.SignedBlock{{Slot: "ten"}},
+	})
+	_, err = MergeStandardProtectionJSON(first, invalid)
+	require.ErrorContains(t, "ten is not a valid slot", err)
+}
+
+func TestMergeStandardProtectionJSON_Import(t *testing.T) {
+	ctx := context.Background()
+	first := protectionFile(1, &format.ProtectionData{
+		Pubkey:             pubKeyHex(1),
+		SignedBlocks:       []*format.SignedBlock{{Slot: "10", SigningRoot: rootHex(10)}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: rootHex(2)}},
+	})
+	second := protectionFile(1, &format.ProtectionData{
+		Pubkey:             pubKeyHex(1),
+		SignedBlocks:       []*format.SignedBlock{{Slot: "12", SigningRoot: rootHex(12)}},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "2", TargetEpoch: "4", SigningRoot: rootHex(4)}},
+	})
+	merged, err := MergeStandardProtectionJSON(first, second)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(merged)
+	require.NoError(t, err)
+
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{{1}}, false)
+	require.NoError(t, validatorDB.ImportStandardProtectionJSON(ctx, bytes.NewReader(encoded)))
+	exported, err := ExportStandardProtectionJSON(ctx, validatorDB)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(exported.Data))
+	assert.Equal(t, 2, len(exported.Data[0].SignedBlocks))
+	assert.Equal(t, 2, len(exported.Data[0].SignedAttestations))
+	issues, err := DiffStandardProtectionJSON(exported, merged)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(issues))
+}